@@ -1023,6 +1023,7 @@ func (c *Converter) packageFiles(v vocabulary, m *gen.ManagerGenerator) (f []*Fi
 			pub := v.typeArray()[0].PublicPackage()
 			file := jen.NewFilePath(pub.Path())
 			file.Add(pubI.Definition())
+			file.Add(gen.PropertyInterface(pub).Definition())
 			f = append(f, &File{
 				F:         file,
 				FileName:  "gen_pkg.go",
@@ -1111,6 +1112,7 @@ func (c *Converter) typePackageFiles(tg *gen.TypeGenerator, vocabName string, m
 	pub := tg.PublicPackage()
 	file := jen.NewFilePath(pub.Path())
 	file.Add(pubI.Definition())
+	file.Add(gen.PropertyInterface(pub).Definition())
 	f = append(f, &File{
 		F:         file,
 		FileName:  "gen_pkg.go",
@@ -1177,9 +1179,10 @@ func (c *Converter) propertyPackageFiles(pg *gen.PropertyGenerator, vocabName st
 		s,
 	).Line().Add(
 		i.Definition(),
-	).Line().Add(
-		fn.Definition(),
 	).Line()
+	for _, elem := range fn {
+		file.Add(elem.Definition()).Line()
+	}
 	f = append(f, &File{
 		F:         file,
 		FileName:  "gen_pkg.go",
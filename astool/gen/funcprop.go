@@ -544,6 +544,7 @@ func (p *FunctionalPropertyGenerator) singleTypeDef() *codegen.Struct {
 	methods = append(methods, p.funcs()...)
 	methods = append(methods, p.commonMethods()...)
 	methods = append(methods, p.nameMethod())
+	methods = append(methods, p.cloneMethod())
 	return codegen.NewStruct(comment,
 		p.StructName(),
 		methods,
@@ -802,6 +803,7 @@ func (p *FunctionalPropertyGenerator) multiTypeDef() *codegen.Struct {
 	methods = append(methods, p.funcs()...)
 	methods = append(methods, p.commonMethods()...)
 	methods = append(methods, p.nameMethod())
+	methods = append(methods, p.cloneMethod())
 	return codegen.NewStruct(comment,
 		p.StructName(),
 		methods,
@@ -1033,6 +1035,47 @@ func (p *FunctionalPropertyGenerator) multiTypeFuncs() []*codegen.Method {
 	return methods
 }
 
+// cloneMethod returns the method deep copying this property. Iterators have an
+// unexported method so their parent property can set the parent and index of
+// the copy.
+func (p *FunctionalPropertyGenerator) cloneMethod() *codegen.Method {
+	this := func() *jen.Statement { return jen.Id(codegen.This()) }
+	code := []jen.Code{
+		jen.Id("c").Op(":=").Op("&").Id(p.StructName()).Values(jen.Dict{
+			jen.Id(aliasMember): this().Dot(aliasMember),
+		}),
+	}
+	for i, kind := range p.kinds {
+		code = append(code, kind.cloneCode(jen.Id("c").Dot(p.memberName(i)), this().Dot(p.memberName(i))))
+		if !kind.Nilable {
+			code = append(code, jen.Id("c").Dot(p.hasMemberName(i)).Op("=").Add(this().Dot(p.hasMemberName(i))))
+		}
+	}
+	code = append(code, jen.Id("c").Dot(unknownMemberName).Op("=").Id(cloneUnknownFunctionName).Call(this().Dot(unknownMemberName)))
+	if !p.hasURIKind() {
+		code = append(code, cloneURLCode(jen.Id("c").Dot(iriMember), this().Dot(iriMember)))
+	}
+	code = append(code, jen.Return(jen.Id("c")))
+	if p.asIterator {
+		return codegen.NewCommentedValueMethod(
+			p.GetPrivatePackage().Path(),
+			iteratorCloneMethod,
+			p.StructName(),
+			/*params=*/ nil,
+			[]jen.Code{jen.Op("*").Id(p.StructName())},
+			code,
+			fmt.Sprintf("%s returns a deep copy of this iterator, which has no parent property.", iteratorCloneMethod))
+	}
+	return codegen.NewCommentedValueMethod(
+		p.GetPrivatePackage().Path(),
+		cloneMethod,
+		p.StructName(),
+		/*params=*/ nil,
+		[]jen.Code{jen.Qual(p.GetPublicPackage().Path(), p.InterfaceName())},
+		code,
+		fmt.Sprintf("%s returns a deep copy of this property. Modifying the copy does not modify this property.", cloneMethod))
+}
+
 // unknownMemberDef returns the definition of a struct member that handles
 // a property whose type is unknown.
 func (p *FunctionalPropertyGenerator) unknownMemberDef() jen.Code {
@@ -88,6 +88,8 @@ func (p *NonFunctionalPropertyGenerator) Definitions() (*codegen.Struct, *codege
 		funcs = append(funcs, deser)
 		funcs = append(funcs, p.ConstructorFn())
 		methods = append(methods, p.funcs()...)
+		methods = append(methods, p.cloneMethod())
+		methods = append(methods, p.serializeEachMethod())
 		property := codegen.NewStruct(
 			fmt.Sprintf("%s is the non-functional property %q. It is permitted to have one or more values, and of different value types.", p.StructName(), p.PropertyName()),
 			p.StructName(),
@@ -103,6 +105,68 @@ func (p *NonFunctionalPropertyGenerator) Definitions() (*codegen.Struct, *codege
 	return p.cachedIter, p.cachedStruct
 }
 
+// cloneMethod returns the method deep copying this property and each of its
+// values.
+func (p *NonFunctionalPropertyGenerator) cloneMethod() *codegen.Method {
+	return codegen.NewCommentedValueMethod(
+		p.GetPrivatePackage().Path(),
+		cloneMethod,
+		p.StructName(),
+		/*params=*/ nil,
+		[]jen.Code{jen.Qual(p.GetPublicPackage().Path(), p.InterfaceName())},
+		[]jen.Code{
+			jen.Id("c").Op(":=").Op("&").Id(p.StructName()).Values(jen.Dict{
+				jen.Id(aliasMember): jen.Id(codegen.This()).Dot(aliasMember),
+			}),
+			jen.If(jen.Id(codegen.This()).Dot(propertiesName).Op("!=").Nil()).Block(
+				jen.Id("c").Dot(propertiesName).Op("=").Make(
+					jen.Index().Op("*").Id(p.iteratorTypeName().CamelName),
+					jen.Len(jen.Id(codegen.This()).Dot(propertiesName)),
+				),
+			),
+			jen.For(
+				jen.List(jen.Id("i"), jen.Id("it")).Op(":=").Range().Id(codegen.This()).Dot(propertiesName),
+			).Block(
+				jen.Id("cit").Op(":=").Id("it").Dot(iteratorCloneMethod).Call(),
+				jen.Id("cit").Dot(parentMemberName).Op("=").Id("c"),
+				jen.Id("cit").Dot(myIndexMemberName).Op("=").Id("i"),
+				jen.Id("c").Dot(propertiesName).Index(jen.Id("i")).Op("=").Id("cit"),
+			),
+			jen.Return(jen.Id("c")),
+		},
+		fmt.Sprintf("%s returns a deep copy of this property and each of its values. Modifying the copy does not modify this property.", cloneMethod))
+}
+
+// serializeEachMethod returns the method serializing the values of this
+// property one at a time.
+func (p *NonFunctionalPropertyGenerator) serializeEachMethod() *codegen.Method {
+	return codegen.NewCommentedValueMethod(
+		p.GetPrivatePackage().Path(),
+		serializeEachMethod,
+		p.StructName(),
+		[]jen.Code{jen.Id("fn").Func().Params(jen.Interface()).Error()},
+		[]jen.Code{jen.Error()},
+		[]jen.Code{
+			jen.For(
+				jen.List(jen.Id("_"), jen.Id("iterator")).Op(":=").Range().Id(codegen.This()).Dot(propertiesName),
+			).Block(
+				jen.If(
+					jen.List(jen.Id("b"), jen.Err()).Op(":=").Id("iterator").Dot(serializeIteratorMethod).Call(),
+					jen.Err().Op("!=").Nil(),
+				).Block(
+					jen.Return(jen.Err()),
+				).Else().If(
+					jen.Err().Op(":=").Id("fn").Call(jen.Id("b")),
+					jen.Err().Op("!=").Nil(),
+				).Block(
+					jen.Return(jen.Err()),
+				),
+			),
+			jen.Return(jen.Nil()),
+		},
+		fmt.Sprintf("%s serializes each value of this property in order, calling fn with each, and stops at the first error. Unlike %s, it never holds the serialized values all at once, so large properties can be encoded as a stream.", serializeEachMethod, serializeMethod))
+}
+
 // iteratorInterfaceName gets the interface name for the iterator.
 func (p *NonFunctionalPropertyGenerator) iteratorInterfaceName() string {
 	return strings.Title(p.iteratorTypeName().CamelName)
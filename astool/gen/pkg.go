@@ -152,6 +152,7 @@ const (
 	managerInterfaceName           = "privateManager"
 	setManagerFunctionName         = "SetManager"
 	setTypePropertyConstructorName = "SetTypePropertyConstructor"
+	cloneUnknownFunctionName       = "cloneUnknown"
 )
 
 // TypePackageGenerator manages generating one-time files needed for types.
@@ -192,7 +193,7 @@ func (t *TypePackageGenerator) PrivateDefinitions(tgs []*TypeGenerator) ([]*jen.
 	s, i, f := privateManagerHookDefinitions(pkg, tgs, nil)
 	interfaces := []*codegen.Interface{i, ContextInterface(pkg)}
 	cv, setCv := privateTypePropertyConstructor(pkg, toPublicConstructor(t.typeVocabName, t.m, t.typeProperty))
-	return []*jen.Statement{s, cv}, interfaces, []*codegen.Function{f, setCv}
+	return []*jen.Statement{s, cv}, interfaces, []*codegen.Function{f, setCv, cloneUnknownDefinition(pkg)}
 }
 
 // PropertyPackageGenerator manages generating one-time files needed for
@@ -209,8 +210,10 @@ func NewPropertyPackageGenerator() *PropertyPackageGenerator {
 //
 // Precondition: The passed-in generators are the complete set of type
 // generators within a package. len(pgs) > 0
-func (p *PropertyPackageGenerator) PrivateDefinitions(pgs []*PropertyGenerator) (*jen.Statement, *codegen.Interface, *codegen.Function) {
-	return privateManagerHookDefinitions(pgs[0].GetPrivatePackage(), nil, pgs)
+func (p *PropertyPackageGenerator) PrivateDefinitions(pgs []*PropertyGenerator) (*jen.Statement, *codegen.Interface, []*codegen.Function) {
+	pkg := pgs[0].GetPrivatePackage()
+	s, i, f := privateManagerHookDefinitions(pkg, nil, pgs)
+	return s, i, []*codegen.Function{f, cloneUnknownDefinition(pkg)}
 }
 
 // PackageGenerator maanges generating one-time files needed for both type and
@@ -266,7 +269,7 @@ func (t *PackageGenerator) PrivateDefinitions(tgs []*TypeGenerator, pgs []*Prope
 	s, i, f := privateManagerHookDefinitions(pkg, tgs, pgs)
 	interfaces := []*codegen.Interface{i, ContextInterface(pkg)}
 	cv, setCv := privateTypePropertyConstructor(pkg, toPublicConstructor(t.typeVocabName, t.m, t.typeProperty))
-	return []*jen.Statement{s, cv}, interfaces, []*codegen.Function{f, setCv}
+	return []*jen.Statement{s, cv}, interfaces, []*codegen.Function{f, setCv, cloneUnknownDefinition(pkg)}
 }
 
 // privateTypePropertyConstructor creates common code needed by types to hook
@@ -289,6 +292,38 @@ func privateTypePropertyConstructor(pkg Package, typePropertyConstructor *codege
 	return
 }
 
+// cloneUnknownDefinition creates the function that deep copies the unknown
+// values of types and properties, which are decoded JSON values.
+func cloneUnknownDefinition(pkg Package) *codegen.Function {
+	return codegen.NewCommentedFunction(
+		pkg.Path(),
+		cloneUnknownFunctionName,
+		[]jen.Code{jen.Id("i").Interface()},
+		[]jen.Code{jen.Interface()},
+		[]jen.Code{
+			jen.Switch(jen.Id("v").Op(":=").Id("i").Assert(jen.Type())).Block(
+				jen.Case(jen.Map(jen.String()).Interface()).Block(
+					jen.Id("m").Op(":=").Make(jen.Map(jen.String()).Interface(), jen.Len(jen.Id("v"))),
+					jen.For(jen.List(jen.Id("k"), jen.Id("e")).Op(":=").Range().Id("v")).Block(
+						jen.Id("m").Index(jen.Id("k")).Op("=").Id(cloneUnknownFunctionName).Call(jen.Id("e")),
+					),
+					jen.Return(jen.Id("m")),
+				),
+				jen.Case(jen.Index().Interface()).Block(
+					jen.Id("s").Op(":=").Make(jen.Index().Interface(), jen.Len(jen.Id("v"))),
+					jen.For(jen.List(jen.Id("idx"), jen.Id("e")).Op(":=").Range().Id("v")).Block(
+						jen.Id("s").Index(jen.Id("idx")).Op("=").Id(cloneUnknownFunctionName).Call(jen.Id("e")),
+					),
+					jen.Return(jen.Id("s")),
+				),
+				jen.Default().Block(
+					jen.Return(jen.Id("i")),
+				),
+			),
+		},
+		fmt.Sprintf("%s deep copies an unknown value decoded from JSON. Maps and slices are copied, and all other values are immutable and returned as-is.", cloneUnknownFunctionName))
+}
+
 // privateManagerHookDefinitions creates common code needed by types and
 // properties to properly hook in the manager at initialization time.
 func privateManagerHookDefinitions(pkg Package, tgs []*TypeGenerator, pgs []*PropertyGenerator) (mgrVar *jen.Statement, mgrI *codegen.Interface, setMgrFn *codegen.Function) {
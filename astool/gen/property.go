@@ -29,8 +29,11 @@ const (
 	lessMethod                = "Less"
 	kindIndexMethod           = "KindIndex"
 	serializeMethod           = "Serialize"
+	serializeEachMethod       = "SerializeEach"
 	deserializeMethod         = "Deserialize"
 	nameMethod                = "Name"
+	cloneMethod               = "Clone"
+	iteratorCloneMethod       = "clone"
 	serializeIteratorMethod   = "serialize"
 	deserializeIteratorMethod = "deserialize"
 	hasLanguageMethod         = "HasLanguage"
@@ -181,6 +184,36 @@ func (k Kind) deserializeFnCode(m, ctx *jen.Statement) *jen.Statement {
 	}
 }
 
+// cloneCode creates the code deep copying this Kind's member from src into
+// dst, depending on whether the Kind is a value or a type.
+func (k Kind) cloneCode(dst, src *jen.Statement) *jen.Statement {
+	if !k.isValue() {
+		return jen.If(src.Clone().Op("!=").Nil()).Block(
+			dst.Clone().Op("=").Add(src.Clone()).Dot(cloneMethod).Call().Assert(k.ConcreteKind.Clone()),
+		)
+	} else if k.IsURI {
+		return cloneURLCode(dst, src)
+	} else if k.Nilable {
+		// Kludge: rdf:langString is the only nilable value that is not
+		// a URI, and is a map[string]string.
+		return jen.If(src.Clone().Op("!=").Nil()).Block(
+			dst.Clone().Op("=").Make(k.ConcreteKind.Clone(), jen.Len(src.Clone())),
+			jen.For(jen.List(jen.Id("k"), jen.Id("v")).Op(":=").Range().Add(src.Clone())).Block(
+				dst.Clone().Index(jen.Id("k")).Op("=").Id("v"),
+			),
+		)
+	}
+	return dst.Clone().Op("=").Add(src.Clone())
+}
+
+// cloneURLCode creates the code copying a *url.URL from src into dst.
+func cloneURLCode(dst, src *jen.Statement) *jen.Statement {
+	return jen.If(src.Clone().Op("!=").Nil()).Block(
+		jen.Id("u").Op(":=").Op("*").Add(src.Clone()),
+		dst.Clone().Op("=").Op("&").Id("u"),
+	)
+}
+
 // isValue returns true if this Kind is a value, or false if it is a type.
 func (k Kind) isValue() bool {
 	// LessFn is not nil, this means it is a value.
@@ -219,6 +252,12 @@ func (p *PropertyGenerator) VocabName() string {
 	return p.vocabName
 }
 
+// VocabURI returns the URI of this property's vocabulary, which is nil for the
+// JSON-LD keywords.
+func (p *PropertyGenerator) VocabURI() *url.URL {
+	return p.vocabURI
+}
+
 // GetKinds gets this property's kinds.
 func (p *PropertyGenerator) GetKinds() []Kind {
 	return p.kinds
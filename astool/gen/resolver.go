@@ -888,6 +888,42 @@ func (r *ResolverGenerator) toAliasFunction() *codegen.Function {
 				),
 				jen.Return(),
 			),
+			jen.Id("definesTermsFn").Op(":=").Func().Parens(
+				jen.List(
+					jen.Id("c").Map(jen.String()).Interface(),
+					jen.Id("prefix").String(),
+				),
+			).Bool().Block(
+				jen.For(
+					jen.List(
+						jen.Id("_"),
+						jen.Id("val"),
+					).Op(":=").Range().Id("c"),
+				).Block(
+					jen.If(
+						jen.List(
+							jen.Id("d"),
+							jen.Id("ok"),
+						).Op(":=").Id("val").Assert(jen.Map(jen.String()).Interface()),
+						jen.Id("ok"),
+					).Block(
+						jen.Id("val").Op("=").Id("d").Index(jen.Lit("@id")),
+					),
+					jen.If(
+						jen.List(
+							jen.Id("s"),
+							jen.Id("ok"),
+						).Op(":=").Id("val").Assert(jen.String()),
+						jen.Id("ok").Op("&&").Qual("strings", "HasPrefix").Call(
+							jen.Id("s"),
+							jen.Id("prefix").Op("+").Lit(":"),
+						),
+					).Block(
+						jen.Return(jen.True()),
+					),
+				),
+				jen.Return(jen.False()),
+			),
 			jen.Switch(jen.Id("v").Op(":=").Id("i").Assert(jen.Type())).Block(
 				jen.Case(jen.String()).Block(
 					jen.Commentf("Single entry, no alias."),
@@ -928,6 +964,17 @@ func (r *ResolverGenerator) toAliasFunction() *codegen.Function {
 								jen.Id("val"),
 							).Op(":=").Range().Id("r"),
 						).Block(
+							jen.Commentf("An unaliased vocabulary takes precedence, as its terms"),
+							jen.Commentf("may then be used without a prefix."),
+							jen.If(
+								jen.List(
+									jen.Id("existing"),
+									jen.Id("ok"),
+								).Op(":=").Id("m").Index(jen.Id("k")),
+								jen.Id("ok").Op("&&").Len(jen.Id("existing")).Op("==").Lit(0),
+							).Block(
+								jen.Continue(),
+							),
 							jen.Id("m").Index(
 								jen.Id("k"),
 							).Op("=").Id("val"),
@@ -948,6 +995,53 @@ func (r *ResolverGenerator) toAliasFunction() *codegen.Function {
 								jen.Id("m").Index(
 									jen.Id("k"),
 								).Op("=").Id("conc"),
+								jen.Commentf("A term defining the IRI of a vocabulary is its alias,"),
+								jen.Commentf("unless other terms are defined with it as a prefix,"),
+								jen.Commentf("as those are then used without a prefix."),
+								jen.Id("alias").Op(":=").Id("k"),
+								jen.If(
+									jen.Id("k").Op("==").Lit("@vocab"),
+								).Block(
+									jen.Id("alias").Op("=").Lit(""),
+								).Else().If(
+									jen.Qual("strings", "HasPrefix").Call(
+										jen.Id("k"),
+										jen.Lit("@"),
+									).Op("||").Id("definesTermsFn").Call(
+										jen.Id("v"),
+										jen.Id("k"),
+									),
+								).Block(
+									jen.Continue(),
+								),
+								jen.If(
+									jen.List(
+										jen.Id("ok"),
+										jen.Id("http"),
+										jen.Id("https"),
+									).Op(":=").Id("toHttpHttpsFn").Call(
+										jen.Qual("strings", "TrimSuffix").Call(
+											jen.Id("conc"),
+											jen.Lit("#"),
+										),
+									),
+									jen.Id("ok"),
+								).Block(
+									jen.If(
+										jen.List(
+											jen.Id("existing"),
+											jen.Id("ok"),
+										).Op(":=").Id("m").Index(jen.Id("http")),
+										jen.Op("!").Id("ok").Op("||").Len(jen.Id("existing")).Op(">").Lit(0),
+									).Block(
+										jen.Id("m").Index(
+											jen.Id("http"),
+										).Op("=").Id("alias"),
+										jen.Id("m").Index(
+											jen.Id("https"),
+										).Op("=").Id("alias"),
+									),
+								),
 							),
 						),
 					),
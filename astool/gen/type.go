@@ -12,6 +12,8 @@ import (
 
 const (
 	typeInterfaceName          = "Type"
+	propertyInterfaceName      = "PropertyInterface"
+	eachPropertyMethod         = "EachProperty"
 	typePropertyConstructor    = "typePropertyConstructor"
 	jsonLDContextInterfaceName = "jsonldContexter"
 	extendedByMethod           = "IsExtendedBy"
@@ -22,6 +24,7 @@ const (
 	typeNameMethod             = "GetTypeName"
 	vocabURIMethod             = "VocabularyURI"
 	serializeMethodName        = "Serialize"
+	serializeWithoutMethodName = "SerializeWithout"
 	deserializeFnName          = "Deserialize"
 	compareLessMethod          = "LessThan"
 	getUnknownMethod           = "GetUnknownProperties"
@@ -91,10 +94,65 @@ func TypeInterface(pkg Package) *codegen.Interface {
 			Ret:     []jen.Code{jen.Map(jen.String()).Interface(), jen.Error()},
 			Comment: fmt.Sprintf("%s converts this into an interface representation suitable for marshalling into a text or binary format.", serializeMethodName),
 		},
+		{
+			Name:    serializeWithoutMethodName,
+			Params:  []jen.Code{jen.Id("names").Op("...").String()},
+			Ret:     []jen.Code{jen.Map(jen.String()).Interface(), jen.Error()},
+			Comment: fmt.Sprintf("%s is like %s, but leaves out the known properties with the names, as well as unknown properties with the same names, so that they can be serialized separately.", serializeWithoutMethodName, serializeMethodName),
+		},
+		{
+			Name:    eachPropertyMethod,
+			Params:  []jen.Code{eachPropertyFnParam(pkg)},
+			Ret:     nil,
+			Comment: fmt.Sprintf("%s calls fn with the name and value of each property that is set, in a stable order. Unknown properties are not included.", eachPropertyMethod),
+		},
+		{
+			Name:    cloneMethod,
+			Params:  nil,
+			Ret:     []jen.Code{jen.Qual(pkg.Path(), typeInterfaceName)},
+			Comment: fmt.Sprintf("%s returns a deep copy of this type. Modifying the copy does not modify this type.", cloneMethod),
+		},
 	}
 	return codegen.NewInterface(pkg.Path(), typeInterfaceName, funcs, comment)
 }
 
+// PropertyInterface returns the Property Interface that all ActivityStreams
+// properties satisfy, so they can be handled without knowing their concrete
+// type.
+func PropertyInterface(pkg Package) *codegen.Interface {
+	comment := fmt.Sprintf("%s represents any ActivityStreams property.", propertyInterfaceName)
+	funcs := []codegen.FunctionSignature{
+		{
+			Name:    nameMethod,
+			Params:  nil,
+			Ret:     []jen.Code{jen.String()},
+			Comment: fmt.Sprintf("%s returns the name of this property.", nameMethod),
+		},
+		{
+			Name:    contextMethod,
+			Params:  nil,
+			Ret:     []jen.Code{jen.Map(jen.String()).String()},
+			Comment: fmt.Sprintf("%s returns the JSONLD URIs required in the context string for this property and the specific values that are set. The value in the map is the alias used to import the property's value or values.", contextMethod),
+		},
+		{
+			Name:    serializeMethod,
+			Params:  nil,
+			Ret:     []jen.Code{jen.Interface(), jen.Error()},
+			Comment: fmt.Sprintf("%s converts this into an interface representation suitable for marshalling into a text or binary format.", serializeMethod),
+		},
+	}
+	return codegen.NewInterface(pkg.Path(), propertyInterfaceName, funcs, comment)
+}
+
+// eachPropertyFnParam returns the callback parameter of the EachProperty
+// method.
+func eachPropertyFnParam(pkg Package) jen.Code {
+	return jen.Id("fn").Func().Params(
+		jen.Id("name").String(),
+		jen.Id("value").Qual(pkg.Path(), propertyInterfaceName),
+	)
+}
+
 // ContextInterface returns a jsonldContexter interface that is needed for
 // ActivityStream types to recursively determine what context strings need to
 // exist in a JSON-LD @context value for linked-data peers to parse.
@@ -121,6 +179,7 @@ func ContextInterface(pkg Package) *codegen.Interface {
 // Property represents a property of an ActivityStreams type.
 type Property interface {
 	VocabName() string
+	VocabURI() *url.URL
 	GetPublicPackage() Package
 	PropertyName() string
 	StructName() string
@@ -381,7 +440,9 @@ func (t *TypeGenerator) InterfaceDefinition(pkg Package) *codegen.Interface {
 func (t *TypeGenerator) Definition() *codegen.Struct {
 	t.cacheOnce.Do(func() {
 		members := t.members()
-		ser := t.serializationMethod()
+		ser, serWithout := t.serializationMethods()
+		each := t.eachPropertyMethod()
+		clone := t.cloneMethod()
 		less := t.lessMethod()
 		get := t.getUnknownMethod()
 		deser := t.deserializationFn()
@@ -399,6 +460,9 @@ func (t *TypeGenerator) Definition() *codegen.Struct {
 					t.vocabURIDefinition(),
 					extendsMethod,
 					ser,
+					serWithout,
+					each,
+					clone,
 					less,
 					get,
 				},
@@ -705,15 +769,17 @@ func (t *TypeGenerator) disjointWithDefinition() *codegen.Function {
 		fmt.Sprintf("%s returns true if the other provided type is disjoint with the %s type.", t.disjointWithFnName(), t.TypeName()))
 }
 
-// serializationMethod returns the method needed to serialize a TypeGenerator as
-// a property.
-func (t *TypeGenerator) serializationMethod() (ser *codegen.Method) {
+// serializationMethods returns the methods needed to serialize a TypeGenerator
+// as a property, in full or without some of its properties.
+func (t *TypeGenerator) serializationMethods() (ser, serWithout *codegen.Method) {
 	serCode := jen.Commentf("Begin: Serialize known properties").Line()
 	for _, prop := range t.allProperties() {
 		serCode.Add(
 			jen.Commentf("Maybe serialize property %q", prop.PropertyName()).Line(),
 			jen.If(
-				jen.Id(codegen.This()).Dot(t.memberName(prop)).Op("!=").Nil(),
+				jen.Id(codegen.This()).Dot(t.memberName(prop)).Op("!=").Nil().Op("&&").Op("!").Id("omit").Index(
+					jen.Id(codegen.This()).Dot(t.memberName(prop)).Dot(nameMethod).Call(),
+				),
 			).Block(
 				jen.If(
 					jen.List(
@@ -743,7 +809,7 @@ func (t *TypeGenerator) serializationMethod() (ser *codegen.Method) {
 				jen.Id("_"),
 				jen.Id("has"),
 			).Op(":=").Id("m").Index(jen.Id("k")),
-			jen.Op("!").Id("has"),
+			jen.Op("!").Id("has").Op("&&").Op("!").Id("omit").Index(jen.Id("k")),
 		).Block(
 			jen.Id("m").Index(jen.Id("k")).Op("=").Id("v"),
 		),
@@ -765,6 +831,16 @@ func (t *TypeGenerator) serializationMethod() (ser *codegen.Method) {
 			jen.Id("m").Index(jen.Lit("type")).Op("=").Id("typeName"),
 		)
 	}
+	omitCode := jen.Var().Id("omit").Map(jen.String()).Bool().Line().If(
+		jen.Len(jen.Id("names")).Op(">").Lit(0),
+	).Block(
+		jen.Id("omit").Op("=").Make(jen.Map(jen.String()).Bool(), jen.Len(jen.Id("names"))),
+		jen.For(
+			jen.List(jen.Id("_"), jen.Id("name")).Op(":=").Range().Id("names"),
+		).Block(
+			jen.Id("omit").Index(jen.Id("name")).Op("=").True(),
+		),
+	)
 	ser = codegen.NewCommentedValueMethod(
 		t.PrivatePackage().Path(),
 		serializeMethodName,
@@ -772,12 +848,82 @@ func (t *TypeGenerator) serializationMethod() (ser *codegen.Method) {
 		/*params=*/ nil,
 		[]jen.Code{jen.Map(jen.String()).Interface(), jen.Error()},
 		[]jen.Code{
+			jen.Return(jen.Id(codegen.This()).Dot(serializeWithoutMethodName).Call()),
+		},
+		fmt.Sprintf("%s converts this into an interface representation suitable for marshalling into a text or binary format.", serializeMethodName))
+	serWithout = codegen.NewCommentedValueMethod(
+		t.PrivatePackage().Path(),
+		serializeWithoutMethodName,
+		t.StructName(),
+		[]jen.Code{jen.Id("names").Op("...").String()},
+		[]jen.Code{jen.Map(jen.String()).Interface(), jen.Error()},
+		[]jen.Code{
+			omitCode,
 			header,
 			serCode,
 			unknownCode,
 			jen.Return(jen.Id("m"), jen.Nil()),
 		},
-		fmt.Sprintf("%s converts this into an interface representation suitable for marshalling into a text or binary format.", serializeMethodName))
+		fmt.Sprintf("%s is like %s, but leaves out the known properties with the names, as well as unknown properties with the same names, so that they can be serialized separately.", serializeWithoutMethodName, serializeMethodName))
+	return
+}
+
+// eachPropertyMethod returns the method that calls a function with each of the
+// known properties that are set on a type.
+func (t *TypeGenerator) eachPropertyMethod() (each *codegen.Method) {
+	var code []jen.Code
+	for _, prop := range t.allProperties() {
+		code = append(code, jen.If(
+			jen.Id(codegen.This()).Dot(t.memberName(prop)).Op("!=").Nil(),
+		).Block(
+			jen.Id("fn").Call(
+				jen.Id(codegen.This()).Dot(t.memberName(prop)).Dot(nameMethod).Call(),
+				jen.Id(codegen.This()).Dot(t.memberName(prop)),
+			),
+		))
+	}
+	each = codegen.NewCommentedValueMethod(
+		t.PrivatePackage().Path(),
+		eachPropertyMethod,
+		t.StructName(),
+		[]jen.Code{eachPropertyFnParam(t.PublicPackage())},
+		/*ret=*/ nil,
+		code,
+		fmt.Sprintf("%s calls fn with the name and value of each property that is set, in a stable order. Unknown properties are not included.", eachPropertyMethod))
+	return
+}
+
+// cloneMethod returns the method that deep copies a type and its properties.
+func (t *TypeGenerator) cloneMethod() (clone *codegen.Method) {
+	code := []jen.Code{
+		jen.Id("c").Op(":=").Op("&").Id(t.StructName()).Values(jen.Dict{
+			jen.Id(aliasMember): jen.Id(codegen.This()).Dot(aliasMember),
+		}),
+	}
+	for _, prop := range t.allProperties() {
+		code = append(code, jen.If(
+			jen.Id(codegen.This()).Dot(t.memberName(prop)).Op("!=").Nil(),
+		).Block(
+			jen.Id("c").Dot(t.memberName(prop)).Op("=").Id(codegen.This()).Dot(t.memberName(prop)).Dot(cloneMethod).Call(),
+		))
+	}
+	code = append(code,
+		jen.If(
+			jen.Id(codegen.This()).Dot(unknownMember).Op("!=").Nil(),
+		).Block(
+			jen.Id("c").Dot(unknownMember).Op("=").Id(cloneUnknownFunctionName).Call(
+				jen.Id(codegen.This()).Dot(unknownMember),
+			).Assert(jen.Map(jen.String()).Interface()),
+		),
+		jen.Return(jen.Id("c")))
+	clone = codegen.NewCommentedValueMethod(
+		t.PrivatePackage().Path(),
+		cloneMethod,
+		t.StructName(),
+		/*params=*/ nil,
+		[]jen.Code{jen.Qual(t.PublicPackage().Path(), typeInterfaceName)},
+		code,
+		fmt.Sprintf("%s returns a deep copy of this type. Modifying the copy does not modify this type.", cloneMethod))
 	return
 }
 
@@ -884,26 +1030,63 @@ func (t *TypeGenerator) deserializationFn() (deser *codegen.Function) {
 			).Line())
 	}
 	deserCode = deserCode.Commentf("End: Known property deserialization").Line()
+	// Known properties are prefixed with the alias of their own vocabulary,
+	// as their deserialization expects, so a property of the same name in
+	// another vocabulary remains unknown.
+	prefixes := jen.Empty()
+	prefixVars := make(map[string]string)
+	for _, prop := range t.allProperties() {
+		u := prop.VocabURI()
+		if u == nil {
+			continue
+		} else if _, ok := prefixVars[u.String()]; ok {
+			continue
+		}
+		prefixVar := strings.ToLower(prop.VocabName()) + "Prefix"
+		prefixVars[u.String()] = prefixVar
+		prefixes = prefixes.Add(
+			jen.Id(prefixVar).Op(":=").Lit(""),
+			jen.Line(),
+			jen.If(
+				jen.List(
+					jen.Id("a"),
+					jen.Id("ok"),
+				).Op(":=").Id("aliasMap").Index(jen.Lit(u.String())),
+				jen.Id("ok").Op("&&").Len(jen.Id("a")).Op(">").Lit(0),
+			).Block(
+				jen.Id(prefixVar).Op("=").Id("a").Op("+").Lit(":"),
+			),
+			jen.Line(),
+		)
+	}
+	knownName := func(prop Property, name string) *jen.Statement {
+		if u := prop.VocabURI(); u != nil {
+			return jen.Id(prefixVars[u.String()]).Op("+").Lit(name)
+		}
+		return jen.Lit(name)
+	}
 	knownProps := jen.Commentf("Begin: Code that ensures a property name is unknown").Line()
 	for i, prop := range t.allProperties() {
 		if i > 0 {
 			knownProps = knownProps.Else()
 		}
 		knownProps = knownProps.If(
-			jen.Id("k").Op("==").Lit(prop.PropertyName()),
+			jen.Id("k").Op("==").Add(knownName(prop, prop.PropertyName())),
 		).Block(
 			jen.Continue(),
 		)
 		if prop.HasNaturalLanguageMap() {
 			knownProps = knownProps.Else().If(
-				jen.Id("k").Op("==").Lit(prop.PropertyName() + "Map"),
+				jen.Id("k").Op("==").Add(knownName(prop, prop.PropertyName()+"Map")),
 			).Block(
 				jen.Continue(),
 			)
 		}
 	}
 	knownProps = knownProps.Commentf("End: Code that ensures a property name is unknown").Line()
-	unknownCode := jen.Commentf("Begin: Unknown deserialization").Line().For(
+	unknownCode := jen.Commentf("Begin: Unknown deserialization").Line().Commentf(
+		"Known properties are prefixed with the alias of their vocabulary, if any.",
+	).Line().Add(prefixes).For(
 		jen.List(
 			jen.Id("k"),
 			jen.Id("v"),
@@ -328,7 +328,7 @@ func (d *dateTime) Apply(key string, value interface{}, ctx *rdf.ParsingContext)
 				jen.Qual("time", "Time"),
 				[]jen.Code{
 					jen.Return(
-						jen.Id(codegen.This()).Dot("Format").Call(jen.Qual("time", "RFC3339")),
+						jen.Id(codegen.This()).Dot("Format").Call(jen.Qual("time", "RFC3339Nano")),
 						jen.Nil(),
 					),
 				}),
@@ -346,30 +346,43 @@ func (d *dateTime) Apply(key string, value interface{}, ctx *rdf.ParsingContext)
 						).Op(":=").Id(codegen.This()).Assert(jen.String()),
 						jen.Id("ok"),
 					).Block(
-						jen.List(
-							jen.Id("tmp"),
-							jen.Err(),
-						).Op("=").Qual("time", "Parse").Call(
-							jen.Qual("time", "RFC3339"),
-							jen.Id("s"),
-						),
+						jen.Commentf("Peers may separate the date and time with a space, omit the"),
+						jen.Commentf("seconds, or omit the colon in the offset."),
 						jen.If(
-							jen.Err().Op("!=").Nil(),
+							jen.Len(jen.Id("s")).Op(">").Lit(10).Op("&&").Id("s").Index(jen.Lit(10)).Op("==").LitRune(' '),
+						).Block(
+							jen.Id("s").Op("=").Id("s").Index(jen.Empty(), jen.Lit(10)).Op("+").Lit("T").Op("+").Id("s").Index(jen.Lit(11), jen.Empty()),
+						),
+						jen.For(
+							jen.List(
+								jen.Id("_"),
+								jen.Id("layout"),
+							).Op(":=").Range().Index().String().Values(
+								jen.Qual("time", "RFC3339Nano"),
+								jen.Lit("2006-01-02T15:04Z07:00"),
+								jen.Lit("2006-01-02T15:04:05.999999999Z0700"),
+								jen.Lit("2006-01-02T15:04Z0700"),
+							),
 						).Block(
 							jen.List(
 								jen.Id("tmp"),
 								jen.Err(),
 							).Op("=").Qual("time", "Parse").Call(
-								jen.Lit("2006-01-02T15:04Z07:00"),
+								jen.Id("layout"),
 								jen.Id("s"),
 							),
 							jen.If(
-								jen.Err().Op("!=").Nil(),
+								jen.Err().Op("==").Nil(),
 							).Block(
-								jen.Err().Op("=").Qual("fmt", "Errorf").Call(
-									jen.Lit("%v cannot be interpreted as xsd:datetime"),
-									jen.Id(codegen.This()),
-								),
+								jen.Break(),
+							),
+						),
+						jen.If(
+							jen.Err().Op("!=").Nil(),
+						).Block(
+							jen.Err().Op("=").Qual("fmt", "Errorf").Call(
+								jen.Lit("%q cannot be interpreted as an RFC3339 xsd:datetime"),
+								jen.Id(codegen.This()),
 							),
 						),
 					).Else().Block(
@@ -458,6 +471,16 @@ func (f *float) Apply(key string, value interface{}, ctx *rdf.ParsingContext) (b
 							jen.Id("f"),
 							jen.Nil(),
 						),
+					).Else().If(
+						jen.List(
+							jen.Id("n"),
+							jen.Id("ok"),
+						).Op(":=").Id(codegen.This()).Assert(jen.Qual("encoding/json", "Number")),
+						jen.Id("ok"),
+					).Block(
+						jen.Return(
+							jen.Id("n").Dot("Float64").Call(),
+						),
 					).Else().Block(
 						jen.Return(
 							jen.Lit(0),
@@ -662,6 +685,40 @@ func (b *boolean) Apply(key string, value interface{}, ctx *rdf.ParsingContext)
 								),
 							),
 						),
+					).Else().If(
+						jen.List(
+							jen.Id("n"),
+							jen.Id("ok"),
+						).Op(":=").Id(codegen.This()).Assert(jen.Qual("encoding/json", "Number")),
+						jen.Id("ok"),
+					).Block(
+						jen.List(
+							jen.Id("f"),
+							jen.Err(),
+						).Op(":=").Id("n").Dot("Float64").Call(),
+						jen.If(
+							jen.Err().Op("==").Nil().Op("&&").Id("f").Op("==").Lit(0),
+						).Block(
+							jen.Return(
+								jen.False(),
+								jen.Nil(),
+							),
+						).Else().If(
+							jen.Err().Op("==").Nil().Op("&&").Id("f").Op("==").Lit(1),
+						).Block(
+							jen.Return(
+								jen.True(),
+								jen.Nil(),
+							),
+						).Else().Block(
+							jen.Return(
+								jen.False(),
+								jen.Qual("fmt", "Errorf").Call(
+									jen.Lit("%v cannot be interpreted as a bool number for xsd:boolean"),
+									jen.Id(codegen.This()),
+								),
+							),
+						),
 					).Else().Block(
 						jen.Return(
 							jen.False(),
@@ -763,6 +820,54 @@ func (n *nonNegativeInteger) Apply(key string, value interface{}, ctx *rdf.Parsi
 								),
 							),
 						),
+					).Else().If(
+						jen.List(
+							jen.Id("i"),
+							jen.Id("ok"),
+						).Op(":=").Id(codegen.This()).Assert(jen.Qual("encoding/json", "Number")),
+						jen.Id("ok"),
+					).Block(
+						jen.List(
+							jen.Id("n"),
+							jen.Err(),
+						).Op(":=").Id("i").Dot("Int64").Call(),
+						jen.If(
+							jen.Err().Op("!=").Nil(),
+						).Block(
+							jen.Comment("Integral values may still be written in float or exponent form, such as 1.0 or 1e3."),
+							jen.List(
+								jen.Id("f"),
+								jen.Id("ferr"),
+							).Op(":=").Id("i").Dot("Float64").Call(),
+							jen.If(
+								jen.Id("ferr").Op("!=").Nil().Op("||").Id("f").Op("!=").Qual("math", "Trunc").Call(jen.Id("f")).Op("||").Id("f").Op(">=").Qual("math", "MaxInt64").Op("||").Id("f").Op("<").Qual("math", "MinInt64"),
+							).Block(
+								jen.Return(
+									jen.Lit(0),
+									jen.Qual("fmt", "Errorf").Call(
+										jen.Lit("%v cannot be interpreted as an integer for xsd:nonNegativeInteger"),
+										jen.Id(codegen.This()),
+									),
+								),
+							),
+							jen.Id("n").Op("=").Int64().Call(jen.Id("f")),
+						),
+						jen.If(
+							jen.Id("n").Op(">=").Lit(0),
+						).Block(
+							jen.Return(
+								jen.Int().Call(jen.Id("n")),
+								jen.Nil(),
+							),
+						).Else().Block(
+							jen.Return(
+								jen.Lit(0),
+								jen.Qual("fmt", "Errorf").Call(
+									jen.Lit("%v is a negative integer for xsd:nonNegativeInteger"),
+									jen.Id(codegen.This()),
+								),
+							),
+						),
 					).Else().Block(
 						jen.Return(
 							jen.Lit(0),
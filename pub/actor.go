@@ -2,6 +2,7 @@ package pub
 
 import (
 	"context"
+	"fmt"
 	"github.com/go-fed/activity/streams/vocab"
 	"net/http"
 	"net/url"
@@ -124,4 +125,90 @@ type FederatingActor interface {
 	// method will guaranteed work for non-custom Actors. For custom actors,
 	// care should be used to not call this method if only C2S is supported.
 	Send(c context.Context, outbox *url.URL, t vocab.Type) (Activity, error)
+	// ResolveDeliveryTargets determines the inboxes that Send would
+	// deliver the activity to, without delivering it, such as to audit a
+	// delivery to a large audience.
+	//
+	// The provided url must be the outbox of the sender. The whole
+	// resolution of recipients occurs: the actors and collections the
+	// activity is addressed to are resolved, dereferencing them from peers
+	// if needed, the inboxes are determined by an InboxResolver, and they
+	// are deduplicated without the sender's own inbox. The activity must
+	// already have been wrapped in a Create if needed, and is not
+	// modified. A DeliveryPolicer is applied, but MaxDeliveryRecipients
+	// is not, so that large deliveries may be audited.
+	//
+	// The DelegateActor must be a DeliveryTargetsResolver.
+	ResolveDeliveryTargets(c context.Context, outbox *url.URL, activity Activity) ([]*url.URL, error)
+	// DeliverToInbox signs and sends the activity to exactly the inbox,
+	// such as to push to a relay, to retry an inbox whose delivery failed,
+	// or in integration tests.
+	//
+	// The provided url must be the outbox of the sender, whose key signs
+	// the request. The recipients of the activity are not resolved, and
+	// it is neither given new ids nor added to the outbox. Its 'bto' and
+	// 'bcc' are stripped from the delivered copy. Nothing is sent if a
+	// DeliveryPolicer skips the activity or filters out the inbox.
+	//
+	// The DelegateActor must be an InboxDeliverer.
+	DeliverToInbox(c context.Context, outbox, inbox *url.URL, activity Activity) error
+	// ProcessInboxCollection replays the activities of a collection into
+	// the inbox in order, such as the outbox of an account being migrated
+	// or backfilled, and returns how many were processed.
+	//
+	// Each activity goes through the same steps as one received by
+	// PostInbox: it is authorized, added to the inbox unless already
+	// there, handled by the callbacks, and forwarded. The pages of the
+	// collection are walked as by WalkCollection.
+	//
+	// An activity embedded in the collection is trusted to come from the
+	// origin of the collection's 'id'. Activities only referenced by their
+	// IRI, or embedded but on another origin, are dereferenced instead and
+	// must have that IRI as their 'id'. Either way, the authors of an
+	// activity must be on the origin it was obtained from. Unless the
+	// context has an actor set by WithAuthenticatedActor, the activity is
+	// authorized as if signed by that origin.
+	//
+	// The DelegateActor must be a TransportCreator to dereference the
+	// pages and activities.
+	//
+	// If continueOnError is false, processing stops at the first activity
+	// that fails, returning its error. Otherwise the remaining activities
+	// are processed and the errors are returned together as an
+	// *InboxCollectionError.
+	ProcessInboxCollection(c context.Context, inbox *url.URL, collection vocab.ActivityStreamsOrderedCollection, continueOnError bool) (processed int, err error)
+	// PostSharedInbox returns true if the request was handled as an
+	// ActivityPub POST to the shared inbox of the server, which does not
+	// belong to a single actor. If false, the request was not an
+	// ActivityPub request and may still be handled by the caller in
+	// another way.
+	//
+	// The request is authenticated and the activity authorized as for
+	// PostInbox. Then its local recipients are determined, as described by
+	// SharedInboxResolver, and it goes through the side effects and inbox
+	// forwarding of PostInbox once for each of their inboxes. Each inbox
+	// is handled as if the activity was received in it, so an activity
+	// also delivered to a personal inbox is not handled twice for it.
+	//
+	// The request and data of your application will be interpreted as
+	// having an HTTPS protocol scheme.
+	PostSharedInbox(c context.Context, w http.ResponseWriter, r *http.Request) (bool, error)
+	// PostSharedInboxScheme is similar to PostSharedInbox, except clients
+	// are able to specify which protocol scheme to handle the incoming
+	// request and the data stored within the application (HTTP, HTTPS,
+	// etc).
+	PostSharedInboxScheme(c context.Context, w http.ResponseWriter, r *http.Request, scheme string) (bool, error)
+}
+
+// InboxCollectionError is returned by ProcessInboxCollection when it continued
+// past the activities that failed to be processed.
+type InboxCollectionError struct {
+	// Errors are the errors of the activities that failed, in the order
+	// of the collection. Each names the position and id of its activity.
+	Errors []error
+}
+
+// Error describes the number of failed activities and the first error.
+func (e *InboxCollectionError) Error() string {
+	return fmt.Sprintf("%d activities of the collection failed, the first with: %s", len(e.Errors), e.Errors[0])
 }
@@ -0,0 +1,190 @@
+package pub
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net/url"
+	"path"
+
+	"github.com/go-fed/activity/streams"
+	"github.com/go-fed/activity/streams/vocab"
+)
+
+// ActorBuilder builds the document of a local actor with the properties peers
+// require to federate with it: 'inbox', 'outbox', 'followers', 'following',
+// 'preferredUsername', 'publicKey', and 'endpoints.sharedInbox'.
+//
+// Every IRI is derived from the BaseIRI and the Username:
+//
+//	actor:       {BaseIRI}/users/{Username}
+//	inbox:       {actor}/inbox
+//	outbox:      {actor}/outbox
+//	followers:   {actor}/followers
+//	following:   {actor}/following
+//	publicKey:   {actor}#main-key
+//	sharedInbox: {BaseIRI}/inbox
+//
+// The application must serve these IRIs, and sign its requests with the
+// private key of the PublicKey under the 'publicKey' IRI as keyId.
+type ActorBuilder struct {
+	// BaseIRI is the IRI of the server, such as "https://example.com".
+	BaseIRI *url.URL
+	// Username is the 'preferredUsername' of the actor.
+	Username string
+	// PublicKey is the public key of the actor, which is either an
+	// *rsa.PublicKey or an ed25519.PublicKey.
+	PublicKey crypto.PublicKey
+	// ManuallyApprovesFollowers marks the actor as locked, so that peers
+	// show its Follow requests as pending. Use it together with
+	// OnFollowPendingApproval.
+	ManuallyApprovesFollowers bool
+}
+
+// actorBuilderTarget is an actor type the ActorBuilder populates.
+type actorBuilderTarget interface {
+	vocab.Type
+	SetActivityStreamsInbox(i vocab.ActivityStreamsInboxProperty)
+	SetActivityStreamsOutbox(i vocab.ActivityStreamsOutboxProperty)
+	SetActivityStreamsFollowers(i vocab.ActivityStreamsFollowersProperty)
+	SetActivityStreamsFollowing(i vocab.ActivityStreamsFollowingProperty)
+	SetActivityStreamsPreferredUsername(i vocab.ActivityStreamsPreferredUsernameProperty)
+	SetW3IDSecurityV1PublicKey(i vocab.W3IDSecurityV1PublicKeyProperty)
+	SetActivityStreamsManuallyApprovesFollowers(i vocab.ActivityStreamsManuallyApprovesFollowersProperty)
+	SetActivityStreamsEndpoints(i vocab.ActivityStreamsEndpointsProperty)
+}
+
+// ActorIRI returns the IRI of the actor.
+func (b ActorBuilder) ActorIRI() (*url.URL, error) {
+	if b.BaseIRI == nil || !b.BaseIRI.IsAbs() {
+		return nil, fmt.Errorf("actor builder requires an absolute base IRI")
+	}
+	if len(b.Username) == 0 {
+		return nil, fmt.Errorf("actor builder requires a username")
+	}
+	return b.resolve("users", b.Username), nil
+}
+
+// KeyId returns the IRI of the actor's public key, which is the keyId its
+// requests are signed with.
+func (b ActorBuilder) KeyId() (*url.URL, error) {
+	actorIRI, err := b.ActorIRI()
+	if err != nil {
+		return nil, err
+	}
+	keyId := *actorIRI
+	keyId.Fragment = "main-key"
+	return &keyId, nil
+}
+
+// Person builds the actor as a Person.
+func (b ActorBuilder) Person() (vocab.ActivityStreamsPerson, error) {
+	p := streams.NewActivityStreamsPerson()
+	if err := b.build(p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Service builds the actor as a Service, which is suited for automated actors
+// such as bots or the actor of the server itself.
+func (b ActorBuilder) Service() (vocab.ActivityStreamsService, error) {
+	s := streams.NewActivityStreamsService()
+	if err := b.build(s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// build populates the actor.
+func (b ActorBuilder) build(a actorBuilderTarget) error {
+	actorIRI, err := b.ActorIRI()
+	if err != nil {
+		return err
+	}
+	keyId, err := b.KeyId()
+	if err != nil {
+		return err
+	}
+	pemKey, err := PublicKeyPEM(b.PublicKey)
+	if err != nil {
+		return err
+	}
+	id := streams.NewJSONLDIdProperty()
+	id.Set(actorIRI)
+	a.SetJSONLDId(id)
+	inbox := streams.NewActivityStreamsInboxProperty()
+	inbox.SetIRI(b.resolve("users", b.Username, "inbox"))
+	a.SetActivityStreamsInbox(inbox)
+	outbox := streams.NewActivityStreamsOutboxProperty()
+	outbox.SetIRI(b.resolve("users", b.Username, "outbox"))
+	a.SetActivityStreamsOutbox(outbox)
+	followers := streams.NewActivityStreamsFollowersProperty()
+	followers.SetIRI(b.resolve("users", b.Username, "followers"))
+	a.SetActivityStreamsFollowers(followers)
+	following := streams.NewActivityStreamsFollowingProperty()
+	following.SetIRI(b.resolve("users", b.Username, "following"))
+	a.SetActivityStreamsFollowing(following)
+	username := streams.NewActivityStreamsPreferredUsernameProperty()
+	username.SetXMLSchemaString(b.Username)
+	a.SetActivityStreamsPreferredUsername(username)
+	if b.ManuallyApprovesFollowers {
+		locked := streams.NewActivityStreamsManuallyApprovesFollowersProperty()
+		locked.Set(true)
+		a.SetActivityStreamsManuallyApprovesFollowers(locked)
+	}
+	// The key is embedded so that peers do not need to dereference it.
+	pk := streams.NewW3IDSecurityV1PublicKey()
+	pkId := streams.NewJSONLDIdProperty()
+	pkId.Set(keyId)
+	pk.SetJSONLDId(pkId)
+	owner := streams.NewW3IDSecurityV1OwnerProperty()
+	owner.Set(actorIRI)
+	pk.SetW3IDSecurityV1Owner(owner)
+	pkPem := streams.NewW3IDSecurityV1PublicKeyPemProperty()
+	pkPem.Set(pemKey)
+	pk.SetW3IDSecurityV1PublicKeyPem(pkPem)
+	pkProp := streams.NewW3IDSecurityV1PublicKeyProperty()
+	pkProp.AppendW3IDSecurityV1PublicKey(pk)
+	a.SetW3IDSecurityV1PublicKey(pkProp)
+	sharedInbox := streams.NewActivityStreamsSharedInboxProperty()
+	sharedInbox.Set(b.resolve("inbox"))
+	e := streams.NewActivityStreamsEndpoints()
+	e.SetActivityStreamsSharedInbox(sharedInbox)
+	endpoints := streams.NewActivityStreamsEndpointsProperty()
+	endpoints.Set(e)
+	a.SetActivityStreamsEndpoints(endpoints)
+	return nil
+}
+
+// resolve returns the IRI of the path below the BaseIRI.
+func (b ActorBuilder) resolve(elem ...string) *url.URL {
+	u := *b.BaseIRI
+	u.Path = path.Join(append([]string{"/", u.Path}, elem...)...)
+	u.RawPath = ""
+	u.RawQuery = ""
+	u.Fragment = ""
+	return &u
+}
+
+// PublicKeyPEM encodes an *rsa.PublicKey or an ed25519.PublicKey as a PKIX
+// "PUBLIC KEY" PEM block, which is the 'publicKeyPem' format peers expect.
+//
+// ActorBuilder uses it to populate the 'publicKey' of the actor.
+func PublicKeyPEM(k crypto.PublicKey) (string, error) {
+	switch k.(type) {
+	case *rsa.PublicKey, ed25519.PublicKey:
+	case nil:
+		return "", fmt.Errorf("a public key is required")
+	default:
+		return "", fmt.Errorf("public key must be an *rsa.PublicKey or an ed25519.PublicKey, got %T", k)
+	}
+	der, err := x509.MarshalPKIXPublicKey(k)
+	if err != nil {
+		return "", fmt.Errorf("cannot encode public key: %s", err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})), nil
+}
@@ -0,0 +1,176 @@
+package pub
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/go-fed/activity/streams"
+	"github.com/go-test/deep"
+)
+
+func TestActorBuilder(t *testing.T) {
+	k, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edPub, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Run("BuildsPerson", func(t *testing.T) {
+		b := ActorBuilder{
+			BaseIRI:   mustParse("https://example.com"),
+			Username:  "sally",
+			PublicKey: &k.PublicKey,
+		}
+		p, err := b.Person()
+		assertEqual(t, err, nil)
+		m, err := streams.Serialize(p)
+		assertEqual(t, err, nil)
+		// Round trip through JSON to compare the plain values.
+		raw, err := json.Marshal(m)
+		assertEqual(t, err, nil)
+		var got map[string]interface{}
+		assertEqual(t, json.Unmarshal(raw, &got), nil)
+		expect := map[string]interface{}{
+			"@context": []interface{}{
+				"https://www.w3.org/ns/activitystreams",
+				"https://w3id.org/security/v1",
+			},
+			"id":                "https://example.com/users/sally",
+			"type":              "Person",
+			"inbox":             "https://example.com/users/sally/inbox",
+			"outbox":            "https://example.com/users/sally/outbox",
+			"followers":         "https://example.com/users/sally/followers",
+			"following":         "https://example.com/users/sally/following",
+			"preferredUsername": "sally",
+			"publicKey": map[string]interface{}{
+				"id":           "https://example.com/users/sally#main-key",
+				"owner":        "https://example.com/users/sally",
+				"publicKeyPem": testPublicKeyPem(t, k),
+			},
+			"endpoints": map[string]interface{}{
+				"sharedInbox": "https://example.com/inbox",
+			},
+		}
+		// The order of the vocabularies in the context is not
+		// deterministic.
+		if ctx, ok := got["@context"].([]interface{}); ok && len(ctx) == 2 && ctx[0] != expect["@context"].([]interface{})[0] {
+			ctx[0], ctx[1] = ctx[1], ctx[0]
+		}
+		if diff := deep.Equal(got, expect); diff != nil {
+			t.Fatal(diff)
+		}
+	})
+	t.Run("KeyIsResolvable", func(t *testing.T) {
+		b := ActorBuilder{
+			BaseIRI:   mustParse("https://example.com"),
+			Username:  "sally",
+			PublicKey: &k.PublicKey,
+		}
+		p, err := b.Person()
+		assertEqual(t, err, nil)
+		keyId, err := b.KeyId()
+		assertEqual(t, err, nil)
+		pk, owner, err := findPublicKey(p, keyId)
+		assertEqual(t, err, nil)
+		assertEqual(t, owner.String(), "https://example.com/users/sally")
+		pem, err := getPublicKeyPem(pk, keyId)
+		assertEqual(t, err, nil)
+		assertEqual(t, pem, testPublicKeyPem(t, k))
+	})
+	t.Run("BuildsServiceWithEd25519KeyBelowBasePath", func(t *testing.T) {
+		b := ActorBuilder{
+			BaseIRI:   mustParse("https://example.com/social/"),
+			Username:  "relay",
+			PublicKey: edPub,
+		}
+		s, err := b.Service()
+		assertEqual(t, err, nil)
+		assertEqual(t, s.GetJSONLDId().Get().String(), "https://example.com/social/users/relay")
+		assertEqual(t, s.GetActivityStreamsInbox().GetIRI().String(), "https://example.com/social/users/relay/inbox")
+		assertEqual(t, s.GetActivityStreamsEndpoints().Get().GetActivityStreamsSharedInbox().Get().String(), "https://example.com/social/inbox")
+		pem := s.GetW3IDSecurityV1PublicKey().At(0).Get().GetW3IDSecurityV1PublicKeyPem().Get()
+		assertNotEqual(t, pem, "")
+		assertEqual(t, s.GetActivityStreamsManuallyApprovesFollowers(), nil)
+	})
+	t.Run("BuildsLockedPerson", func(t *testing.T) {
+		b := ActorBuilder{
+			BaseIRI:                   mustParse("https://example.com"),
+			Username:                  "sally",
+			PublicKey:                 &k.PublicKey,
+			ManuallyApprovesFollowers: true,
+		}
+		p, err := b.Person()
+		assertEqual(t, err, nil)
+		m, err := streams.Serialize(p)
+		assertEqual(t, err, nil)
+		assertEqual(t, m["manuallyApprovesFollowers"], true)
+	})
+	t.Run("Errors", func(t *testing.T) {
+		for name, b := range map[string]ActorBuilder{
+			"NoBaseIRI":       {Username: "sally", PublicKey: &k.PublicKey},
+			"RelativeBaseIRI": {BaseIRI: mustParse("/social"), Username: "sally", PublicKey: &k.PublicKey},
+			"NoUsername":      {BaseIRI: mustParse("https://example.com"), PublicKey: &k.PublicKey},
+			"NoPublicKey":     {BaseIRI: mustParse("https://example.com"), Username: "sally"},
+			"PrivateKey":      {BaseIRI: mustParse("https://example.com"), Username: "sally", PublicKey: k},
+		} {
+			t.Run(name, func(t *testing.T) {
+				_, err := b.Person()
+				assertNotEqual(t, err, nil)
+			})
+		}
+	})
+}
+
+func TestPublicKeyPEM(t *testing.T) {
+	k, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edPub, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Run("RoundTripsRSA", func(t *testing.T) {
+		s, err := PublicKeyPEM(&k.PublicKey)
+		assertEqual(t, err, nil)
+		if !strings.HasPrefix(s, "-----BEGIN PUBLIC KEY-----\n") || !strings.HasSuffix(s, "-----END PUBLIC KEY-----\n") {
+			t.Fatalf("unexpected PEM: %s", s)
+		}
+		parsed, err := parsePublicKeyPem(s)
+		assertEqual(t, err, nil)
+		if !isSameRSAKey(&k.PublicKey, parsed) {
+			t.Fatalf("parsed the wrong key")
+		}
+	})
+	t.Run("RoundTripsEd25519", func(t *testing.T) {
+		s, err := PublicKeyPEM(edPub)
+		assertEqual(t, err, nil)
+		parsed, err := parsePublicKeyPem(s)
+		assertEqual(t, err, nil)
+		if p, ok := parsed.(ed25519.PublicKey); !ok || !p.Equal(edPub) {
+			t.Fatalf("parsed the wrong key")
+		}
+	})
+	t.Run("ErrorsWithoutKey", func(t *testing.T) {
+		_, err := PublicKeyPEM(nil)
+		assertNotEqual(t, err, nil)
+	})
+	t.Run("ErrorsWithOtherKeys", func(t *testing.T) {
+		_, err := PublicKeyPEM(&ecKey.PublicKey)
+		assertNotEqual(t, err, nil)
+		_, err = PublicKeyPEM(k)
+		assertNotEqual(t, err, nil)
+	})
+}
@@ -0,0 +1,181 @@
+package pub
+
+import (
+	"fmt"
+	"runtime/debug"
+	"time"
+)
+
+// ActorOption configures optional behavior of the Actors created by
+// NewSocialActor, NewFederatingActor, and NewActor.
+type ActorOption func(a *sideEffectActor)
+
+// newSideEffectActor applies the options to the sideEffectActor.
+func newSideEffectActor(a *sideEffectActor, opts []ActorOption) *sideEffectActor {
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
+}
+
+// WithCallbackPanicRecovery recovers from panics in the callbacks handling an
+// activity, including the wrapped callbacks, the 'other' callbacks, and
+// DefaultCallback. A panic is converted to a *CallbackPanicError, which is
+// returned from PostInbox or PostOutbox like any other error.
+//
+// Without it, a panic is not recovered, which may be preferred during
+// development.
+func WithCallbackPanicRecovery() ActorOption {
+	return func(a *sideEffectActor) {
+		a.recoverPanics = true
+	}
+}
+
+// WithLogger logs the decisions made while handling activities to the Logger,
+// such as the type of each received activity, the number of inboxes resolved
+// for a delivery, and whether an activity is forwarded.
+func WithLogger(l Logger) ActorOption {
+	return func(a *sideEffectActor) {
+		a.logger = l
+	}
+}
+
+// WithRequireAuthorDomainMatch rejects an activity received in an inbox if one
+// of its authors is on another domain than the actor that signed the request,
+// unless the activity has a valid Linked Data Signature made by that author.
+// This prevents a server, such as a relay, from spoofing the activities of
+// actors on other servers. It is disabled by default, as relays forward the
+// activities of other servers without such signatures.
+//
+// The signing actor is the one set with WithAuthenticatedActor in
+// AuthenticatePostInbox, which is required. The authors are the 'actor' of the
+// activity and the 'attributedTo' of its embedded objects. The 'creator' of a
+// Linked Data Signature is resolved with the KeyResolver, and must be owned by
+// the author. The LDCanonicalizer is usually a URDNA2015Canonicalizer. If it
+// is nil, Linked Data Signatures are not verified and every activity with an
+// author on another domain is rejected.
+func WithRequireAuthorDomainMatch(k *KeyResolver, canon LDCanonicalizer) ActorOption {
+	return func(a *sideEffectActor) {
+		a.requireAuthorDomainMatch = true
+		a.authorKeys = k
+		a.authorCanon = canon
+	}
+}
+
+// UnhandledActivityPolicy determines what happens to an activity received in
+// an inbox that none of the callbacks handle.
+type UnhandledActivityPolicy int
+
+const (
+	// UnhandledActivityDefault passes the activity to the DefaultCallback of
+	// the FederatingProtocol.
+	UnhandledActivityDefault UnhandledActivityPolicy = iota
+	// UnhandledActivityIgnore accepts the activity without any side
+	// effect. It is still added to the inbox.
+	UnhandledActivityIgnore
+	// UnhandledActivityReject refuses the activity, so that PostInbox
+	// responds with a 422 Unprocessable Entity status. It has already been
+	// added to the inbox.
+	UnhandledActivityReject
+	// UnhandledActivityStoreOnly accepts the activity and creates it in
+	// the Database, if it does not already exist, without any other side
+	// effect.
+	UnhandledActivityStoreOnly
+)
+
+// WithUnhandledActivityPolicy applies the UnhandledActivityPolicy to the
+// activities received in an inbox that none of the callbacks handle, instead
+// of passing them to the DefaultCallback.
+func WithUnhandledActivityPolicy(p UnhandledActivityPolicy) ActorOption {
+	return func(a *sideEffectActor) {
+		a.unhandled = p
+	}
+}
+
+// WithCachePolicy sets the Cache-Control and ETag headers of the responses of
+// GetInbox and GetOutbox as determined by the CachePolicy, and responds with
+// 304 Not Modified to requests that already have the current version. These
+// responses are only cached privately, for the credentials of the requester.
+// Without it, they are not cached.
+func WithCachePolicy(p CachePolicy) ActorOption {
+	return func(a *sideEffectActor) {
+		a.cachePolicy = p
+	}
+}
+
+// WithSeenStore consults the SeenStore before handling an activity received in
+// an inbox, marking its id as seen in that inbox for the ttl. An activity that
+// was already seen in the inbox is accepted without being added to it again
+// nor having any side effect. If handling the activity fails, its id is
+// unmarked, so that a peer retrying the delivery has it handled again.
+//
+// Activities received in the shared inbox with PostSharedInbox are marked in
+// the inbox of each local recipient as they are handled for it. So if handling
+// fails for some of them, a retried delivery is only handled for those.
+func WithSeenStore(s SeenStore, ttl time.Duration) ActorOption {
+	return func(a *sideEffectActor) {
+		a.seen = s
+		a.seenTTL = ttl
+	}
+}
+
+// WithConsistentCreateAddressing ensures a Create activity posted to the
+// outbox is addressed to the same recipients as its object, in each of the
+// 'to', 'bto', 'cc', 'bcc', and 'audience' properties, so that the object is
+// not visible to a different audience than the activity announcing it.
+//
+// A Create that is not addressed at all is given the addressing of its
+// object. Otherwise, PostOutbox returns ErrCreateAddressingMismatch, and a
+// Bad Request response is set. Without it, the Create callback of the
+// SocialProtocol merges their addressing instead, which suits applications
+// diverging them on purpose.
+func WithConsistentCreateAddressing() ActorOption {
+	return func(a *sideEffectActor) {
+		a.consistentCreateAddressing = true
+	}
+}
+
+// WithJSONLimits rejects the activities posted to an inbox or outbox whose JSON
+// nests objects and arrays deeper than maxDepth, or has an array of more than
+// maxArrayLength elements, with a 422 Unprocessable Entity status. These are
+// checked before the JSON is resolved, which would otherwise process such
+// documents recursively. A limit that is zero or negative is not enforced.
+//
+// It complements limiting the size of request bodies, such as with
+// http.MaxBytesReader, which does not catch a small but pathological document.
+func WithJSONLimits(maxDepth, maxArrayLength int) ActorOption {
+	return func(a *sideEffectActor) {
+		a.maxJSONDepth = maxDepth
+		a.maxJSONArrayLength = maxArrayLength
+	}
+}
+
+// CallbackPanicError is returned when a callback panicked while handling an
+// activity and WithCallbackPanicRecovery is used.
+type CallbackPanicError struct {
+	// Value is the value the callback panicked with.
+	Value interface{}
+	// Stack is the stack trace of the goroutine at the time of the panic.
+	Stack []byte
+}
+
+// Error describes the value the callback panicked with.
+func (e *CallbackPanicError) Error() string {
+	return fmt.Sprintf("callback panicked: %v", e.Value)
+}
+
+// callCallback calls the callback, recovering from a panic if enabled.
+func (a *sideEffectActor) callCallback(fn func() error) (err error) {
+	if !a.recoverPanics {
+		return fn()
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = &CallbackPanicError{
+				Value: r,
+				Stack: debug.Stack(),
+			}
+		}
+	}()
+	return fn()
+}
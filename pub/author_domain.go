@@ -0,0 +1,106 @@
+package pub
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/go-fed/activity/streams"
+)
+
+// authorDomainMatches determines whether the authors of the activity are on
+// the domain of the actor that signed the request, or otherwise whether the
+// activity has a valid Linked Data Signature by the authors on other domains.
+func (a *sideEffectActor) authorDomainMatches(c context.Context, activity Activity) (bool, error) {
+	signer, ok := AuthenticatedActorFromContext(c)
+	if !ok {
+		return false, fmt.Errorf("cannot match the domain of the authors: AuthenticatePostInbox did not set the authenticated actor")
+	}
+	var others []*url.URL
+	for _, author := range activityAuthors(activity) {
+		if author.Host != signer.Host {
+			others = append(others, author)
+		}
+	}
+	if len(others) == 0 {
+		return true, nil
+	}
+	if a.authorKeys == nil || a.authorCanon == nil {
+		a.debug("author domain does not match signer", "id", idOf(activity), "signer", signer)
+		return false, nil
+	}
+	owner, err := a.ldSignatureOwner(c, activity)
+	if err != nil {
+		a.debug("author domain does not match signer and linked data signature is not verified", "id", idOf(activity), "signer", signer, "error", err)
+		return false, nil
+	}
+	for _, author := range others {
+		if author.String() != owner.String() {
+			a.debug("author domain does not match signer nor linked data signature", "id", idOf(activity), "signer", signer, "author", author)
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// ldSignatureOwner verifies the Linked Data Signature of the activity and
+// returns the owner of its key, which must be on the origin of its 'creator'.
+func (a *sideEffectActor) ldSignatureOwner(c context.Context, activity Activity) (*url.URL, error) {
+	m, err := streams.Serialize(activity)
+	if err != nil {
+		return nil, err
+	}
+	keyId, err := ldSignatureCreator(m)
+	if err != nil {
+		return nil, err
+	}
+	inboxIRI, _ := RequestURLFromContext(c)
+	tp, err := a.common.NewTransport(c, inboxIRI, goFedUserAgent())
+	if err != nil {
+		return nil, err
+	}
+	key, err := a.authorKeys.Resolve(c, tp, keyId)
+	if err != nil {
+		return nil, err
+	}
+	if err = VerifyLDSignatureJSON(m, key, a.authorCanon); err != nil {
+		return nil, err
+	}
+	owner, err := a.authorKeys.Owner(c, tp, keyId)
+	if err != nil {
+		return nil, err
+	} else if !sameOrigin(owner, keyId) {
+		return nil, fmt.Errorf("creator %s is owned by %s on another origin", keyId, owner)
+	}
+	return owner, nil
+}
+
+// activityAuthors returns the 'actor' of the activity and the 'attributedTo'
+// of its embedded objects.
+func activityAuthors(activity Activity) (authors []*url.URL) {
+	if actor := activity.GetActivityStreamsActor(); actor != nil {
+		for iter := actor.Begin(); iter != actor.End(); iter = iter.Next() {
+			if id, err := ToId(iter); err == nil {
+				authors = append(authors, id)
+			}
+		}
+	}
+	o, ok := activity.(objecter)
+	if !ok || o.GetActivityStreamsObject() == nil {
+		return
+	}
+	op := o.GetActivityStreamsObject()
+	for iter := op.Begin(); iter != op.End(); iter = iter.Next() {
+		at, ok := iter.GetType().(attributedToer)
+		if !ok || at.GetActivityStreamsAttributedTo() == nil {
+			continue
+		}
+		attrTo := at.GetActivityStreamsAttributedTo()
+		for a := attrTo.Begin(); a != attrTo.End(); a = a.Next() {
+			if id, err := ToId(a); err == nil {
+				authors = append(authors, id)
+			}
+		}
+	}
+	return
+}
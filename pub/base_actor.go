@@ -2,7 +2,6 @@ package pub
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"github.com/go-fed/activity/streams"
 	"github.com/go-fed/activity/streams/vocab"
@@ -61,17 +60,20 @@ type baseActorFederating struct {
 //
 // Do not try to use NewSocialActor and NewFederatingActor together to cover
 // both the Social and Federating parts of the protocol. Instead, use NewActor.
+//
+// Additional options, such as WithCallbackPanicRecovery, may be provided.
 func NewSocialActor(c CommonBehavior,
 	c2s SocialProtocol,
 	db Database,
-	clock Clock) Actor {
+	clock Clock,
+	opts ...ActorOption) Actor {
 	return &baseActor{
-		delegate: &sideEffectActor{
+		delegate: newSideEffectActor(&sideEffectActor{
 			common: c,
 			c2s:    c2s,
 			db:     db,
 			clock:  clock,
-		},
+		}, opts),
 		enableSocialProtocol: true,
 		clock:                clock,
 	}
@@ -89,18 +91,21 @@ func NewSocialActor(c CommonBehavior,
 //
 // Do not try to use NewSocialActor and NewFederatingActor together to cover
 // both the Social and Federating parts of the protocol. Instead, use NewActor.
+//
+// Additional options, such as WithCallbackPanicRecovery, may be provided.
 func NewFederatingActor(c CommonBehavior,
 	s2s FederatingProtocol,
 	db Database,
-	clock Clock) FederatingActor {
+	clock Clock,
+	opts ...ActorOption) FederatingActor {
 	return &baseActorFederating{
 		baseActor{
-			delegate: &sideEffectActor{
+			delegate: newSideEffectActor(&sideEffectActor{
 				common: c,
 				s2s:    s2s,
 				db:     db,
 				clock:  clock,
-			},
+			}, opts),
 			enableFederatedProtocol: true,
 			clock:                   clock,
 		},
@@ -116,20 +121,23 @@ func NewFederatingActor(c CommonBehavior,
 // It leverages as much of go-fed as possible to ensure the implementation is
 // compliant with the ActivityPub specification, while providing enough freedom
 // to be productive without shooting one's self in the foot.
+//
+// Additional options, such as WithCallbackPanicRecovery, may be provided.
 func NewActor(c CommonBehavior,
 	c2s SocialProtocol,
 	s2s FederatingProtocol,
 	db Database,
-	clock Clock) FederatingActor {
+	clock Clock,
+	opts ...ActorOption) FederatingActor {
 	return &baseActorFederating{
 		baseActor{
-			delegate: &sideEffectActor{
+			delegate: newSideEffectActor(&sideEffectActor{
 				common: c,
 				c2s:    c2s,
 				s2s:    s2s,
 				db:     db,
 				clock:  clock,
-			},
+			}, opts),
 			enableSocialProtocol:    true,
 			enableFederatedProtocol: true,
 			clock:                   clock,
@@ -185,6 +193,13 @@ func (b *baseActor) PostInboxScheme(c context.Context, w http.ResponseWriter, r
 		w.WriteHeader(http.StatusMethodNotAllowed)
 		return true, nil
 	}
+	// Resolve which local actor the request targets, if supported.
+	c, err := b.resolveRequestActor(c, r)
+	if err != nil {
+		return true, err
+	}
+	inboxId := requestId(r, scheme)
+	c = withRequestURL(c, inboxId)
 	// Check the peer request is authentic.
 	c, authenticated, err := b.delegate.AuthenticatePostInbox(c, w, r)
 	if err != nil {
@@ -192,49 +207,121 @@ func (b *baseActor) PostInboxScheme(c context.Context, w http.ResponseWriter, r
 	} else if !authenticated {
 		return true, nil
 	}
+	c, activity, err := b.receiveInboxActivity(c, w, r)
+	if err != nil || activity == nil {
+		return true, err
+	}
+	// Drop the activity if it is of no relevance to local actors.
+	if relevant, err := b.hasLocalRecipients(c, activity); err != nil {
+		return true, err
+	} else if !relevant {
+		w.WriteHeader(http.StatusOK)
+		return true, nil
+	}
+	if responded, err := b.postInboxActivity(c, w, inboxId, activity); err != nil || responded {
+		return true, err
+	}
+	// Request has been processed. Begin responding to the request.
+	//
+	// Simply respond with an OK status to the peer.
+	w.WriteHeader(http.StatusOK)
+	return true, nil
+}
+
+// receiveInboxActivity reads the activity of an authenticated POST request to
+// an inbox, applies the request body hooks, and checks its authorization.
+//
+// If the activity is nil and the error is nil, a response has already been
+// written.
+func (b *baseActor) receiveInboxActivity(c context.Context, w http.ResponseWriter, r *http.Request) (context.Context, Activity, error) {
 	// Begin processing the request, but have not yet applied
 	// authorization (ex: blocks). Obtain the activity reject unknown
 	// activities.
 	raw, err := ioutil.ReadAll(r.Body)
 	if err != nil {
-		return true, err
+		return c, nil, err
+	}
+	if err = b.checkJSONLimits(raw); err != nil {
+		if _, ok := err.(*JSONLimitError); ok {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			return c, nil, nil
+		}
+		return c, nil, err
 	}
 	var m map[string]interface{}
-	if err = json.Unmarshal(raw, &m); err != nil {
-		return true, err
+	if err = unmarshalJSON(raw, &m); err != nil {
+		return c, nil, err
 	}
+	normalizePublicAddressing(m)
 	asValue, err := streams.ToType(c, m)
 	if err != nil && !streams.IsUnmatchedErr(err) {
-		return true, err
+		return c, nil, err
 	} else if streams.IsUnmatchedErr(err) {
 		// Respond with bad request -- we do not understand the type.
 		w.WriteHeader(http.StatusBadRequest)
-		return true, nil
+		return c, nil, nil
 	}
 	activity, ok := asValue.(Activity)
 	if !ok {
-		return true, fmt.Errorf("activity streams value is not an Activity: %T", asValue)
+		return c, nil, fmt.Errorf("activity streams value is not an Activity: %T", asValue)
 	}
 	if activity.GetJSONLDId() == nil {
 		w.WriteHeader(http.StatusBadRequest)
-		return true, nil
+		return c, nil, nil
 	}
+	c = withActivity(c, activity)
 	// Allow server implementations to set context data with a hook.
 	c, err = b.delegate.PostInboxRequestBodyHook(c, r, activity)
 	if err != nil {
-		return true, err
+		return c, nil, err
+	}
+	if h, ok := b.delegate.(RawBodyHooker); ok {
+		c, err = h.PostInboxRawBodyHook(c, r, raw, activity)
+		if err != nil {
+			return c, nil, err
+		}
 	}
 	// Check authorization of the activity.
 	authorized, err := b.delegate.AuthorizePostInbox(c, w, activity)
 	if err != nil {
-		return true, err
+		return c, nil, err
 	} else if !authorized {
+		return c, nil, nil
+	}
+	return c, activity, nil
+}
+
+// checkJSONLimits checks the JSON against the limits of the delegate, if it is
+// a JSONLimiter.
+func (b *baseActor) checkJSONLimits(raw []byte) error {
+	l, ok := b.delegate.(JSONLimiter)
+	if !ok {
+		return nil
+	}
+	maxDepth, maxArrayLength := l.JSONLimits()
+	return checkJSONLimits(raw, maxDepth, maxArrayLength)
+}
+
+// hasLocalRecipients returns whether the activity received in an inbox is
+// relevant to local actors, as determined by the delegate if it is a
+// LocalRecipientsChecker.
+func (b *baseActor) hasLocalRecipients(c context.Context, activity Activity) (bool, error) {
+	l, ok := b.delegate.(LocalRecipientsChecker)
+	if !ok {
 		return true, nil
 	}
+	return l.HasLocalRecipients(c, activity)
+}
+
+// postInboxActivity posts the activity to the inbox, triggering its side
+// effects, and then its inbox forwarding.
+//
+// If responded is true, the activity was rejected and a response has already
+// been written.
+func (b *baseActor) postInboxActivity(c context.Context, w http.ResponseWriter, inboxId *url.URL, activity Activity) (responded bool, err error) {
 	// Post the activity to the actor's inbox and trigger side effects for
 	// that particular Activity type. It is up to the delegate to resolve
 	// the given map.
-	inboxId := requestId(r, scheme)
 	err = b.delegate.PostInbox(c, inboxId, activity)
 	if err != nil {
 		// Special case: We know it is a bad request if the object or
@@ -244,19 +331,15 @@ func (b *baseActor) PostInboxScheme(c context.Context, w http.ResponseWriter, r
 		if err == ErrObjectRequired || err == ErrTargetRequired {
 			w.WriteHeader(http.StatusBadRequest)
 			return true, nil
+		} else if err == ErrUnhandledActivity {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			return true, nil
 		}
-		return true, err
+		return false, err
 	}
 	// Our side effects are complete, now delegate determining whether to
 	// do inbox forwarding, as well as the action to do it.
-	if err := b.delegate.InboxForwarding(c, inboxId, activity); err != nil {
-		return true, err
-	}
-	// Request has been processed. Begin responding to the request.
-	//
-	// Simply respond with an OK status to the peer.
-	w.WriteHeader(http.StatusOK)
-	return true, nil
+	return false, b.delegate.InboxForwarding(c, inboxId, activity)
 }
 
 // GetInbox implements the generic algorithm for handling a GET request to an
@@ -267,6 +350,11 @@ func (b *baseActor) GetInbox(c context.Context, w http.ResponseWriter, r *http.R
 	if !isActivityPubGet(r) {
 		return false, nil
 	}
+	// Resolve which local actor the request targets, if supported.
+	c, err := b.resolveRequestActor(c, r)
+	if err != nil {
+		return true, err
+	}
 	// Delegate authenticating and authorizing the request.
 	c, authenticated, err := b.delegate.AuthenticateGetInbox(c, w, r)
 	if err != nil {
@@ -286,23 +374,20 @@ func (b *baseActor) GetInbox(c context.Context, w http.ResponseWriter, r *http.R
 	}
 	// Request has been processed. Begin responding to the request.
 	//
-	// Serialize the OrderedCollection.
-	m, err := streams.Serialize(oc)
-	if err != nil {
-		return true, err
-	}
-	raw, err := json.Marshal(m)
-	if err != nil {
-		return true, err
+	// Respond without the OrderedCollection if the requester has its
+	// current version.
+	if cp, ok := b.delegate.(CachePolicy); ok {
+		notModified, err := addCacheHeaders(c, w, r, cp, oc, true)
+		if err != nil {
+			return true, err
+		} else if notModified {
+			w.WriteHeader(http.StatusNotModified)
+			return true, nil
+		}
 	}
-	// Write the response.
-	addResponseHeaders(w.Header(), b.clock, raw)
-	w.WriteHeader(http.StatusOK)
-	n, err := w.Write(raw)
-	if err != nil {
+	// Serialize the OrderedCollection and write the response.
+	if err = writeSerializedResponse(w, b.clock, http.StatusOK, oc); err != nil {
 		return true, err
-	} else if n != len(raw) {
-		return true, fmt.Errorf("ResponseWriter.Write wrote %d of %d bytes", n, len(raw))
 	}
 	return true, nil
 }
@@ -332,6 +417,13 @@ func (b *baseActor) PostOutboxScheme(c context.Context, w http.ResponseWriter, r
 		w.WriteHeader(http.StatusMethodNotAllowed)
 		return true, nil
 	}
+	// Resolve which local actor the request targets, if supported.
+	c, err := b.resolveRequestActor(c, r)
+	if err != nil {
+		return true, err
+	}
+	outboxId := requestId(r, scheme)
+	c = withRequestURL(c, outboxId)
 	// Delegate authenticating and authorizing the request.
 	c, authenticated, err := b.delegate.AuthenticatePostOutbox(c, w, r)
 	if err != nil {
@@ -344,10 +436,18 @@ func (b *baseActor) PostOutboxScheme(c context.Context, w http.ResponseWriter, r
 	if err != nil {
 		return true, err
 	}
+	if err = b.checkJSONLimits(raw); err != nil {
+		if _, ok := err.(*JSONLimitError); ok {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			return true, nil
+		}
+		return true, err
+	}
 	var m map[string]interface{}
-	if err = json.Unmarshal(raw, &m); err != nil {
+	if err = unmarshalJSON(raw, &m); err != nil {
 		return true, err
 	}
+	normalizePublicAddressing(m)
 	// Note that converting to a Type will NOT successfully convert types
 	// not known to go-fed. This prevents accidentally wrapping an Activity
 	// type unknown to go-fed in a Create below. Instead,
@@ -365,15 +465,22 @@ func (b *baseActor) PostOutboxScheme(c context.Context, w http.ResponseWriter, r
 	if err != nil {
 		return true, err
 	}
+	// Allow server implementations to resolve mentions and other tags.
+	if p, ok := b.delegate.(TagProcessor); ok {
+		if err = p.ProcessTags(c, asValue); err != nil {
+			return true, err
+		}
+	}
 	// The HTTP request steps are complete, complete the rest of the outbox
 	// and delivery process.
-	outboxId := requestId(r, scheme)
 	activity, err := b.deliver(c, outboxId, asValue, m)
 	// Special case: We know it is a bad request if the object or
-	// target properties needed to be populated, but weren't.
+	// target properties needed to be populated, but weren't, or if the
+	// activity is addressed inconsistently or to no one it can be
+	// delivered to.
 	//
 	// Send the rejection to the client.
-	if err == ErrObjectRequired || err == ErrTargetRequired {
+	if err == ErrObjectRequired || err == ErrTargetRequired || err == ErrCreateAddressingMismatch || err == ErrUndeliverableAddressing {
 		w.WriteHeader(http.StatusBadRequest)
 		return true, nil
 	} else if err != nil {
@@ -393,6 +500,11 @@ func (b *baseActor) GetOutbox(c context.Context, w http.ResponseWriter, r *http.
 	if !isActivityPubGet(r) {
 		return false, nil
 	}
+	// Resolve which local actor the request targets, if supported.
+	c, err := b.resolveRequestActor(c, r)
+	if err != nil {
+		return true, err
+	}
 	// Delegate authenticating and authorizing the request.
 	c, authenticated, err := b.delegate.AuthenticateGetOutbox(c, w, r)
 	if err != nil {
@@ -407,24 +519,21 @@ func (b *baseActor) GetOutbox(c context.Context, w http.ResponseWriter, r *http.
 	}
 	// Request has been processed. Begin responding to the request.
 	//
-	// Serialize the OrderedCollection.
-	m, err := streams.Serialize(oc)
-	if err != nil {
-		return true, err
+	// Respond without the OrderedCollection if the requester has its
+	// current version.
+	if cp, ok := b.delegate.(CachePolicy); ok {
+		notModified, err := addCacheHeaders(c, w, r, cp, oc, true)
+		if err != nil {
+			return true, err
+		} else if notModified {
+			w.WriteHeader(http.StatusNotModified)
+			return true, nil
+		}
 	}
-	raw, err := json.Marshal(m)
-	if err != nil {
+	// Serialize the OrderedCollection and write the response.
+	if err = writeSerializedResponse(w, b.clock, http.StatusOK, oc); err != nil {
 		return true, err
 	}
-	// Write the response.
-	addResponseHeaders(w.Header(), b.clock, raw)
-	w.WriteHeader(http.StatusOK)
-	n, err := w.Write(raw)
-	if err != nil {
-		return true, err
-	} else if n != len(raw) {
-		return true, fmt.Errorf("ResponseWriter.Write wrote %d of %d bytes", n, len(raw))
-	}
 	return true, nil
 }
 
@@ -455,10 +564,24 @@ func (b *baseActor) deliver(c context.Context, outbox *url.URL, asValue vocab.Ty
 		err = fmt.Errorf("activity streams value is not an Activity: %T", asValue)
 		return
 	}
+	c = withActivity(c, activity)
 	// Delegate generating new IDs for the activity and all new objects.
 	if err = b.delegate.AddNewIDs(c, activity); err != nil {
 		return
 	}
+	// Allow server implementations to set instance-wide properties.
+	if d, ok := b.delegate.(OutboundDecorator); ok {
+		if err = d.DecorateOutbound(c, activity); err != nil {
+			return
+		}
+	}
+	// Reject an activity that would be stored but cannot be delivered to
+	// anyone. Block activities are never delivered.
+	if b.enableFederatedProtocol && !isLocalOnly(c) && !streams.IsOrExtendsActivityStreamsBlock(activity) {
+		if err = validateAddressing(activity); err != nil {
+			return
+		}
+	}
 	// Post the activity to the actor's outbox and trigger side effects for
 	// that particular Activity type.
 	//
@@ -488,7 +611,191 @@ func (b *baseActor) deliver(c context.Context, outbox *url.URL, asValue vocab.Ty
 	return
 }
 
+// resolveRequestActor sets the local actor targeted by the request on the
+// context, if the delegate is an ActorResolver that resolves one.
+func (b *baseActor) resolveRequestActor(c context.Context, r *http.Request) (context.Context, error) {
+	ar, ok := b.delegate.(ActorResolver)
+	if !ok {
+		return c, nil
+	}
+	actorIRI, err := ar.ActorForRequest(c, r)
+	if err != nil {
+		return c, err
+	} else if actorIRI != nil {
+		c = withRequestActor(c, actorIRI)
+	}
+	return c, nil
+}
+
 // Send is programmatically accessible if the federated protocol is enabled.
 func (b *baseActorFederating) Send(c context.Context, outbox *url.URL, t vocab.Type) (Activity, error) {
 	return b.deliver(c, outbox, t, nil)
 }
+
+// ProcessInboxCollection is programmatically accessible if the federated
+// protocol is enabled.
+func (b *baseActorFederating) ProcessInboxCollection(c context.Context, inbox *url.URL, collection vocab.ActivityStreamsOrderedCollection, continueOnError bool) (processed int, err error) {
+	tc, ok := b.delegate.(TransportCreator)
+	if !ok {
+		return 0, fmt.Errorf("delegate actor %T cannot dereference the collection", b.delegate)
+	}
+	c = withRequestURL(c, inbox)
+	tport, err := tc.NewTransport(c, inbox, goFedUserAgent())
+	if err != nil {
+		return 0, err
+	}
+	var source *url.URL
+	if id := collection.GetJSONLDId(); id != nil {
+		source = id.Get()
+	}
+	var errs []error
+	i := 0
+	err = WalkCollection(c, tport, collection, func(item IdProperty) error {
+		defer func() { i++ }()
+		if err := b.processInboxItem(c, tport, inbox, source, item); err != nil {
+			id, _ := ToId(item)
+			err = fmt.Errorf("activity %d (%v): %s", i, id, err)
+			if !continueOnError {
+				return err
+			}
+			errs = append(errs, err)
+			return nil
+		}
+		processed++
+		return nil
+	})
+	if err != nil && !continueOnError {
+		return
+	} else if err != nil {
+		errs = append(errs, err)
+	}
+	err = nil
+	if len(errs) > 0 {
+		err = &InboxCollectionError{Errors: errs}
+	}
+	return
+}
+
+// processInboxItem applies the inbox side effects and inbox forwarding to an
+// item of a collection obtained from the source, as if it had been received
+// in the inbox.
+//
+// An item only given by its IRI, or embedded but not on the origin of the
+// source, is dereferenced from its own origin. Its authors must then be on
+// the origin it was obtained from.
+func (b *baseActorFederating) processInboxItem(c context.Context, tport Transport, inbox, source *url.URL, item IdProperty) error {
+	t := item.GetType()
+	if t == nil && !item.IsIRI() {
+		return fmt.Errorf("item is neither an Activity nor an IRI")
+	} else if t != nil && t.GetJSONLDId() == nil {
+		return fmt.Errorf("activity has no id")
+	}
+	from := source
+	if id, err := ToId(item); err != nil {
+		return err
+	} else if t == nil || source == nil || !sameOrigin(id, source) {
+		if t, err = dereferenceOwnType(c, tport, id); err != nil {
+			return err
+		}
+		from = id
+	}
+	activity, ok := t.(Activity)
+	if !ok {
+		return fmt.Errorf("item is not an Activity: %T", t)
+	}
+	for _, author := range activityAuthors(activity) {
+		if !sameOrigin(author, from) {
+			return fmt.Errorf("author %s is not on the origin of %s", author, from)
+		}
+	}
+	c = withActivity(c, activity)
+	if _, ok := AuthenticatedActorFromContext(c); !ok {
+		c = WithAuthenticatedActor(c, from)
+	}
+	w := &statusRecorder{}
+	if authorized, err := b.delegate.AuthorizePostInbox(c, w, activity); err != nil {
+		return err
+	} else if !authorized {
+		return fmt.Errorf("activity is not authorized: status %d", w.status)
+	}
+	if responded, err := b.postInboxActivity(c, w, inbox, activity); err != nil {
+		return err
+	} else if responded {
+		return fmt.Errorf("activity is rejected: status %d", w.status)
+	}
+	return nil
+}
+
+// PostSharedInbox handles a POST request to the shared inbox, applying the
+// activity to the inbox of each of its local recipients.
+//
+// Only supports serving data with identifiers having the HTTPS scheme.
+func (b *baseActorFederating) PostSharedInbox(c context.Context, w http.ResponseWriter, r *http.Request) (bool, error) {
+	return b.PostSharedInboxScheme(c, w, r, "https")
+}
+
+// PostSharedInboxScheme handles a POST request to the shared inbox, applying
+// the activity to the inbox of each of its local recipients.
+//
+// Specifying the "scheme" allows for retrieving ActivityStreams content with
+// identifiers such as HTTP, HTTPS, or other protocol schemes.
+func (b *baseActorFederating) PostSharedInboxScheme(c context.Context, w http.ResponseWriter, r *http.Request, scheme string) (bool, error) {
+	// Do nothing if it is not an ActivityPub POST request.
+	if !isActivityPubPost(r) {
+		return false, nil
+	}
+	resolver, ok := b.delegate.(SharedInboxResolver)
+	if !ok {
+		return true, fmt.Errorf("delegate actor %T does not support the shared inbox", b.delegate)
+	}
+	c = withRequestURL(c, requestId(r, scheme))
+	// Check the peer request is authentic.
+	c, authenticated, err := b.delegate.AuthenticatePostInbox(c, w, r)
+	if err != nil {
+		return true, err
+	} else if !authenticated {
+		return true, nil
+	}
+	c, activity, err := b.receiveInboxActivity(c, w, r)
+	if err != nil || activity == nil {
+		return true, err
+	}
+	// Drop the activity if it is of no relevance to local actors.
+	if relevant, err := b.hasLocalRecipients(c, activity); err != nil {
+		return true, err
+	} else if !relevant {
+		w.WriteHeader(http.StatusOK)
+		return true, nil
+	}
+	inboxes, err := resolver.LocalInboxes(c, activity)
+	if err != nil {
+		return true, err
+	}
+	for _, inbox := range inboxes {
+		if responded, err := b.postInboxActivity(withRequestURL(c, inbox), w, inbox, activity); err != nil || responded {
+			return true, err
+		}
+	}
+	w.WriteHeader(http.StatusOK)
+	return true, nil
+}
+
+// ResolveDeliveryTargets is programmatically accessible if the federated
+// protocol is enabled.
+func (b *baseActorFederating) ResolveDeliveryTargets(c context.Context, outbox *url.URL, activity Activity) ([]*url.URL, error) {
+	r, ok := b.delegate.(DeliveryTargetsResolver)
+	if !ok {
+		return nil, fmt.Errorf("delegate actor %T cannot resolve delivery targets", b.delegate)
+	}
+	return r.ResolveDeliveryTargets(c, outbox, activity)
+}
+
+// DeliverToInbox is programmatically accessible if the federated protocol is
+// enabled.
+func (b *baseActorFederating) DeliverToInbox(c context.Context, outbox, inbox *url.URL, activity Activity) error {
+	d, ok := b.delegate.(InboxDeliverer)
+	if !ok {
+		return fmt.Errorf("delegate actor %T cannot deliver to an inbox", b.delegate)
+	}
+	return d.DeliverToInbox(c, outbox, inbox, activity)
+}
@@ -1,14 +1,18 @@
 package pub
 
 import (
+	"bytes"
 	"context"
+	"github.com/go-fed/activity/streams"
 	"github.com/go-fed/activity/streams/vocab"
 	"github.com/golang/mock/gomock"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strings"
 	"testing"
+	"time"
 )
 
 // TestBaseActorSocialProtocol tests the Actor returned with NewCustomActor
@@ -17,6 +21,7 @@ func TestBaseActorSocialProtocol(t *testing.T) {
 	// Set up test case
 	setupData()
 	ctx := context.Background()
+	outboxCtx := withRequestURL(ctx, mustParse(testMyOutboxIRI))
 	setupFn := func(ctl *gomock.Controller) (delegate *MockDelegateActor, clock *MockClock, a Actor) {
 		delegate = NewMockDelegateActor(ctl)
 		clock = NewMockClock(ctl)
@@ -152,7 +157,7 @@ func TestBaseActorSocialProtocol(t *testing.T) {
 		delegate, _, a := setupFn(ctl)
 		resp := httptest.NewRecorder()
 		req := toAPRequest(toPostOutboxRequest(testCreateNoId))
-		delegate.EXPECT().AuthenticatePostOutbox(ctx, resp, req).DoAndReturn(func(ctx context.Context, resp http.ResponseWriter, req *http.Request) (context.Context, bool, error) {
+		delegate.EXPECT().AuthenticatePostOutbox(outboxCtx, resp, req).DoAndReturn(func(ctx context.Context, resp http.ResponseWriter, req *http.Request) (context.Context, bool, error) {
 			resp.WriteHeader(http.StatusForbidden)
 			return ctx, false, nil
 		})
@@ -170,7 +175,7 @@ func TestBaseActorSocialProtocol(t *testing.T) {
 		delegate, _, a := setupFn(ctl)
 		resp := httptest.NewRecorder()
 		req := toAPRequest(toPostOutboxUnknownRequest())
-		delegate.EXPECT().AuthenticatePostOutbox(ctx, resp, req).Return(ctx, true, nil)
+		delegate.EXPECT().AuthenticatePostOutbox(outboxCtx, resp, req).Return(outboxCtx, true, nil)
 		// Run the test
 		handled, err := a.PostOutbox(ctx, resp, req)
 		// Verify results
@@ -185,14 +190,14 @@ func TestBaseActorSocialProtocol(t *testing.T) {
 		delegate, _, a := setupFn(ctl)
 		resp := httptest.NewRecorder()
 		req := toAPRequest(toPostOutboxRequest(testCreateNoId))
-		delegate.EXPECT().AuthenticatePostOutbox(ctx, resp, req).Return(ctx, true, nil)
-		delegate.EXPECT().PostOutboxRequestBodyHook(ctx, req, toDeserializedForm(testCreateNoId)).Return(ctx, nil)
-		delegate.EXPECT().AddNewIDs(ctx, toDeserializedForm(testCreateNoId)).DoAndReturn(func(c context.Context, activity Activity) error {
+		delegate.EXPECT().AuthenticatePostOutbox(outboxCtx, resp, req).Return(outboxCtx, true, nil)
+		delegate.EXPECT().PostOutboxRequestBodyHook(outboxCtx, req, toDeserializedForm(testCreateNoId)).Return(outboxCtx, nil)
+		delegate.EXPECT().AddNewIDs(withActivity(outboxCtx, toDeserializedForm(testCreateNoId).(Activity)), toDeserializedForm(testCreateNoId)).DoAndReturn(func(c context.Context, activity Activity) error {
 			withNewId(activity)
 			return nil
 		})
 		delegate.EXPECT().PostOutbox(
-			ctx,
+			withActivity(outboxCtx, withNewId(toDeserializedForm(testCreateNoId))),
 			withNewId(toDeserializedForm(testCreateNoId)),
 			mustParse(testMyOutboxIRI),
 			mustSerialize(testCreateNoId),
@@ -213,17 +218,17 @@ func TestBaseActorSocialProtocol(t *testing.T) {
 		delegate, _, a := setupFn(ctl)
 		resp := httptest.NewRecorder()
 		req := toAPRequest(toPostOutboxRequest(testMyNote))
-		delegate.EXPECT().AuthenticatePostOutbox(ctx, resp, req).Return(ctx, true, nil)
-		delegate.EXPECT().PostOutboxRequestBodyHook(ctx, req, toDeserializedForm(testMyNote)).Return(ctx, nil)
-		delegate.EXPECT().WrapInCreate(ctx, toDeserializedForm(testMyNote), mustParse(testMyOutboxIRI)).DoAndReturn(func(c context.Context, t vocab.Type, u *url.URL) (vocab.ActivityStreamsCreate, error) {
+		delegate.EXPECT().AuthenticatePostOutbox(outboxCtx, resp, req).Return(outboxCtx, true, nil)
+		delegate.EXPECT().PostOutboxRequestBodyHook(outboxCtx, req, toDeserializedForm(testMyNote)).Return(outboxCtx, nil)
+		delegate.EXPECT().WrapInCreate(outboxCtx, toDeserializedForm(testMyNote), mustParse(testMyOutboxIRI)).DoAndReturn(func(c context.Context, t vocab.Type, u *url.URL) (vocab.ActivityStreamsCreate, error) {
 			return wrappedInCreate(t), nil
 		})
-		delegate.EXPECT().AddNewIDs(ctx, wrappedInCreate(toDeserializedForm(testMyNote))).DoAndReturn(func(c context.Context, activity Activity) error {
+		delegate.EXPECT().AddNewIDs(withActivity(outboxCtx, wrappedInCreate(toDeserializedForm(testMyNote))), wrappedInCreate(toDeserializedForm(testMyNote))).DoAndReturn(func(c context.Context, activity Activity) error {
 			withNewId(activity)
 			return nil
 		})
 		delegate.EXPECT().PostOutbox(
-			ctx,
+			withActivity(outboxCtx, withNewId(wrappedInCreate(toDeserializedForm(testMyNote)))),
 			withNewId(wrappedInCreate(toDeserializedForm(testMyNote))),
 			mustParse(testMyOutboxIRI),
 			mustSerialize(toDeserializedForm(testMyNote)),
@@ -235,6 +240,127 @@ func TestBaseActorSocialProtocol(t *testing.T) {
 		assertEqual(t, handled, true)
 		assertEqual(t, resp.Code, http.StatusCreated)
 	})
+	t.Run("PostOutboxProcessesTagsBeforeWrappingAndNewIDs", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		delegate, clock, _ := setupFn(ctl)
+		processor := NewMockTagProcessor(ctl)
+		a := NewCustomActor(
+			tagProcessorDelegateActor{delegate, processor},
+			/*enableSocialProtocol=*/ true,
+			/*enableFederatedProtocol=*/ false,
+			clock)
+		resp := httptest.NewRecorder()
+		req := toAPRequest(toPostOutboxRequest(testMyNote))
+		delegate.EXPECT().AuthenticatePostOutbox(outboxCtx, resp, req).Return(outboxCtx, true, nil)
+		delegate.EXPECT().PostOutboxRequestBodyHook(outboxCtx, req, toDeserializedForm(testMyNote)).Return(outboxCtx, nil)
+		gomock.InOrder(
+			processor.EXPECT().ProcessTags(outboxCtx, toDeserializedForm(testMyNote)).Return(nil),
+			delegate.EXPECT().WrapInCreate(outboxCtx, toDeserializedForm(testMyNote), mustParse(testMyOutboxIRI)).DoAndReturn(func(c context.Context, t vocab.Type, u *url.URL) (vocab.ActivityStreamsCreate, error) {
+				return wrappedInCreate(t), nil
+			}),
+			delegate.EXPECT().AddNewIDs(withActivity(outboxCtx, wrappedInCreate(toDeserializedForm(testMyNote))), wrappedInCreate(toDeserializedForm(testMyNote))).DoAndReturn(func(c context.Context, activity Activity) error {
+				withNewId(activity)
+				return nil
+			}),
+		)
+		delegate.EXPECT().PostOutbox(
+			withActivity(outboxCtx, withNewId(wrappedInCreate(toDeserializedForm(testMyNote)))),
+			withNewId(wrappedInCreate(toDeserializedForm(testMyNote))),
+			mustParse(testMyOutboxIRI),
+			mustSerialize(toDeserializedForm(testMyNote)),
+		).Return(true, nil)
+		// Run the test
+		handled, err := a.PostOutbox(ctx, resp, req)
+		// Verify results
+		assertEqual(t, err, nil)
+		assertEqual(t, handled, true)
+		assertEqual(t, resp.Code, http.StatusCreated)
+	})
+	t.Run("PostOutboxProcessTagsErrorStopsProcessing", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		delegate, clock, _ := setupFn(ctl)
+		processor := NewMockTagProcessor(ctl)
+		a := NewCustomActor(
+			tagProcessorDelegateActor{delegate, processor},
+			/*enableSocialProtocol=*/ true,
+			/*enableFederatedProtocol=*/ false,
+			clock)
+		resp := httptest.NewRecorder()
+		req := toAPRequest(toPostOutboxRequest(testMyNote))
+		delegate.EXPECT().AuthenticatePostOutbox(outboxCtx, resp, req).Return(outboxCtx, true, nil)
+		delegate.EXPECT().PostOutboxRequestBodyHook(outboxCtx, req, toDeserializedForm(testMyNote)).Return(outboxCtx, nil)
+		processor.EXPECT().ProcessTags(outboxCtx, toDeserializedForm(testMyNote)).Return(testErr)
+		// Run the test
+		handled, err := a.PostOutbox(ctx, resp, req)
+		// Verify results
+		assertEqual(t, err, testErr)
+		assertEqual(t, handled, true)
+	})
+	t.Run("PostOutboxDecoratesAfterNewIDs", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		delegate, clock, _ := setupFn(ctl)
+		decorator := NewMockOutboundDecorator(ctl)
+		a := NewCustomActor(
+			outboundDecoratorDelegateActor{delegate, decorator},
+			/*enableSocialProtocol=*/ true,
+			/*enableFederatedProtocol=*/ false,
+			clock)
+		resp := httptest.NewRecorder()
+		req := toAPRequest(toPostOutboxRequest(testMyCreate))
+		delegate.EXPECT().AuthenticatePostOutbox(outboxCtx, resp, req).Return(outboxCtx, true, nil)
+		delegate.EXPECT().PostOutboxRequestBodyHook(outboxCtx, req, toDeserializedForm(testMyCreate)).Return(outboxCtx, nil)
+		gomock.InOrder(
+			delegate.EXPECT().AddNewIDs(withActivity(outboxCtx, toDeserializedForm(testMyCreate).(Activity)), toDeserializedForm(testMyCreate)).DoAndReturn(func(c context.Context, activity Activity) error {
+				withNewId(activity)
+				return nil
+			}),
+			decorator.EXPECT().DecorateOutbound(
+				withActivity(outboxCtx, withNewId(toDeserializedForm(testMyCreate)).(Activity)),
+				withNewId(toDeserializedForm(testMyCreate)),
+			).Return(nil),
+			delegate.EXPECT().PostOutbox(
+				withActivity(outboxCtx, withNewId(toDeserializedForm(testMyCreate)).(Activity)),
+				withNewId(toDeserializedForm(testMyCreate)),
+				mustParse(testMyOutboxIRI),
+				mustSerialize(testMyCreate),
+			).Return(true, nil),
+		)
+		// Run the test
+		handled, err := a.PostOutbox(ctx, resp, req)
+		// Verify results
+		assertEqual(t, err, nil)
+		assertEqual(t, handled, true)
+		assertEqual(t, resp.Code, http.StatusCreated)
+	})
+	t.Run("PostOutboxDecorateOutboundErrorStopsProcessing", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		delegate, clock, _ := setupFn(ctl)
+		decorator := NewMockOutboundDecorator(ctl)
+		a := NewCustomActor(
+			outboundDecoratorDelegateActor{delegate, decorator},
+			/*enableSocialProtocol=*/ true,
+			/*enableFederatedProtocol=*/ false,
+			clock)
+		resp := httptest.NewRecorder()
+		req := toAPRequest(toPostOutboxRequest(testMyCreate))
+		delegate.EXPECT().AuthenticatePostOutbox(outboxCtx, resp, req).Return(outboxCtx, true, nil)
+		delegate.EXPECT().PostOutboxRequestBodyHook(outboxCtx, req, toDeserializedForm(testMyCreate)).Return(outboxCtx, nil)
+		delegate.EXPECT().AddNewIDs(withActivity(outboxCtx, toDeserializedForm(testMyCreate).(Activity)), toDeserializedForm(testMyCreate)).Return(nil)
+		decorator.EXPECT().DecorateOutbound(withActivity(outboxCtx, toDeserializedForm(testMyCreate).(Activity)), toDeserializedForm(testMyCreate)).Return(testErr)
+		// Run the test
+		handled, err := a.PostOutbox(ctx, resp, req)
+		// Verify results
+		assertEqual(t, err, testErr)
+		assertEqual(t, handled, true)
+	})
 	t.Run("PostOutboxBadRequestForErrObjectRequired", func(t *testing.T) {
 		// Setup
 		ctl := gomock.NewController(t)
@@ -242,14 +368,14 @@ func TestBaseActorSocialProtocol(t *testing.T) {
 		delegate, _, a := setupFn(ctl)
 		resp := httptest.NewRecorder()
 		req := toAPRequest(toPostOutboxRequest(testCreateNoId))
-		delegate.EXPECT().AuthenticatePostOutbox(ctx, resp, req).Return(ctx, true, nil)
-		delegate.EXPECT().PostOutboxRequestBodyHook(ctx, req, toDeserializedForm(testCreateNoId)).Return(ctx, nil)
-		delegate.EXPECT().AddNewIDs(ctx, toDeserializedForm(testCreateNoId)).DoAndReturn(func(c context.Context, activity Activity) error {
+		delegate.EXPECT().AuthenticatePostOutbox(outboxCtx, resp, req).Return(outboxCtx, true, nil)
+		delegate.EXPECT().PostOutboxRequestBodyHook(outboxCtx, req, toDeserializedForm(testCreateNoId)).Return(outboxCtx, nil)
+		delegate.EXPECT().AddNewIDs(withActivity(outboxCtx, toDeserializedForm(testCreateNoId).(Activity)), toDeserializedForm(testCreateNoId)).DoAndReturn(func(c context.Context, activity Activity) error {
 			withNewId(activity)
 			return nil
 		})
 		delegate.EXPECT().PostOutbox(
-			ctx,
+			withActivity(outboxCtx, withNewId(toDeserializedForm(testCreateNoId))),
 			withNewId(toDeserializedForm(testCreateNoId)),
 			mustParse(testMyOutboxIRI),
 			mustSerialize(testCreateNoId),
@@ -268,14 +394,14 @@ func TestBaseActorSocialProtocol(t *testing.T) {
 		delegate, _, a := setupFn(ctl)
 		resp := httptest.NewRecorder()
 		req := toAPRequest(toPostOutboxRequest(testCreateNoId))
-		delegate.EXPECT().AuthenticatePostOutbox(ctx, resp, req).Return(ctx, true, nil)
-		delegate.EXPECT().PostOutboxRequestBodyHook(ctx, req, toDeserializedForm(testCreateNoId)).Return(ctx, nil)
-		delegate.EXPECT().AddNewIDs(ctx, toDeserializedForm(testCreateNoId)).DoAndReturn(func(c context.Context, activity Activity) error {
+		delegate.EXPECT().AuthenticatePostOutbox(outboxCtx, resp, req).Return(outboxCtx, true, nil)
+		delegate.EXPECT().PostOutboxRequestBodyHook(outboxCtx, req, toDeserializedForm(testCreateNoId)).Return(outboxCtx, nil)
+		delegate.EXPECT().AddNewIDs(withActivity(outboxCtx, toDeserializedForm(testCreateNoId).(Activity)), toDeserializedForm(testCreateNoId)).DoAndReturn(func(c context.Context, activity Activity) error {
 			withNewId(activity)
 			return nil
 		})
 		delegate.EXPECT().PostOutbox(
-			ctx,
+			withActivity(outboxCtx, withNewId(toDeserializedForm(testCreateNoId))),
 			withNewId(toDeserializedForm(testCreateNoId)),
 			mustParse(testMyOutboxIRI),
 			mustSerialize(testCreateNoId),
@@ -343,6 +469,86 @@ func TestBaseActorSocialProtocol(t *testing.T) {
 		assertEqual(t, err, nil)
 		assertByteEqual(t, b, []byte(testOrderedCollectionUniqueElemsString))
 	})
+	t.Run("GetOutboxRespondsNotModifiedWithCachePolicy", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		delegate := NewMockDelegateActor(ctl)
+		a := NewCustomActor(
+			cachingDelegateActor{delegate, `"v1"`},
+			/*enableSocialProtocol=*/ true,
+			/*enableFederatedProtocol=*/ false,
+			NewMockClock(ctl))
+		resp := httptest.NewRecorder()
+		req := toAPRequest(toGetOutboxRequest())
+		req.Header.Set(ifNoneMatchHeader, `W/"v1"`)
+		delegate.EXPECT().AuthenticateGetOutbox(ctx, resp, req).Return(ctx, true, nil)
+		delegate.EXPECT().GetOutbox(ctx, req).Return(testOrderedCollectionUniqueElems, nil)
+		// Run the test
+		handled, err := a.GetOutbox(ctx, resp, req)
+		// Verify results
+		assertEqual(t, err, nil)
+		assertEqual(t, handled, true)
+		assertEqual(t, resp.Code, http.StatusNotModified)
+		assertEqual(t, resp.Header().Get(cacheControlHeader), "private, max-age=60")
+		assertEqual(t, resp.Header().Get(varyHeader), "Authorization, Signature")
+		assertEqual(t, resp.Header().Get(etagHeader), `"v1"`)
+		assertEqual(t, resp.Body.Len(), 0)
+	})
+}
+
+// cachingDelegateActor is a DelegateActor implementing a CachePolicy that
+// gives every value the same entity tag.
+type cachingDelegateActor struct {
+	*MockDelegateActor
+	etag string
+}
+
+func (d cachingDelegateActor) CacheControl(c context.Context, t vocab.Type) (time.Duration, string, error) {
+	return time.Minute, d.etag, nil
+}
+
+// jsonLimitingDelegateActor is a DelegateActor implementing a JSONLimiter
+// with a maximum depth.
+type jsonLimitingDelegateActor struct {
+	*MockDelegateActor
+	maxDepth int
+}
+
+func (d jsonLimitingDelegateActor) JSONLimits() (int, int) {
+	return d.maxDepth, 0
+}
+
+// transportCreatingDelegateActor is a DelegateActor that is also a
+// TransportCreator of the Transport.
+type transportCreatingDelegateActor struct {
+	*MockDelegateActor
+	tport Transport
+}
+
+func (d transportCreatingDelegateActor) NewTransport(c context.Context, actorBoxIRI *url.URL, gofedAgent string) (Transport, error) {
+	return d.tport, nil
+}
+
+// deliveryTargetsResolverDelegateActor is a DelegateActor that is also a
+// DeliveryTargetsResolver.
+type deliveryTargetsResolverDelegateActor struct {
+	*MockDelegateActor
+	*MockDeliveryTargetsResolver
+}
+
+// inboxDelivererDelegateActor is a DelegateActor that is also an
+// InboxDeliverer.
+type inboxDelivererDelegateActor struct {
+	*MockDelegateActor
+	*MockInboxDeliverer
+}
+
+// localRecipientsDelegateActor is a DelegateActor that is also a
+// LocalRecipientsChecker.
+type localRecipientsDelegateActor struct {
+	*MockDelegateActor
+	*MockLocalRecipientsChecker
 }
 
 // TestBaseActorFederatingProtocol tests the Actor returned with
@@ -351,6 +557,8 @@ func TestBaseActorFederatingProtocol(t *testing.T) {
 	// Set up test case
 	setupData()
 	ctx := context.Background()
+	inboxCtx := withRequestURL(ctx, mustParse(testMyInboxIRI))
+	inboxActivityCtx := withActivity(inboxCtx, toDeserializedForm(testCreate).(Activity))
 	setupFn := func(ctl *gomock.Controller) (delegate *MockDelegateActor, clock *MockClock, a Actor) {
 		delegate = NewMockDelegateActor(ctl)
 		clock = NewMockClock(ctl)
@@ -383,7 +591,7 @@ func TestBaseActorFederatingProtocol(t *testing.T) {
 		delegate, _, a := setupFn(ctl)
 		resp := httptest.NewRecorder()
 		req := toAPRequest(toPostInboxRequest(testCreate))
-		delegate.EXPECT().AuthenticatePostInbox(ctx, resp, req).DoAndReturn(func(ctx context.Context, resp http.ResponseWriter, req *http.Request) (context.Context, bool, error) {
+		delegate.EXPECT().AuthenticatePostInbox(inboxCtx, resp, req).DoAndReturn(func(ctx context.Context, resp http.ResponseWriter, req *http.Request) (context.Context, bool, error) {
 			resp.WriteHeader(http.StatusForbidden)
 			return ctx, false, nil
 		})
@@ -401,7 +609,7 @@ func TestBaseActorFederatingProtocol(t *testing.T) {
 		delegate, _, a := setupFn(ctl)
 		resp := httptest.NewRecorder()
 		req := toAPRequest(toPostInboxUnknownRequest())
-		delegate.EXPECT().AuthenticatePostInbox(ctx, resp, req).Return(ctx, true, nil)
+		delegate.EXPECT().AuthenticatePostInbox(inboxCtx, resp, req).Return(inboxCtx, true, nil)
 		// Run the test
 		handled, err := a.PostInbox(ctx, resp, req)
 		// Verify results
@@ -416,7 +624,8 @@ func TestBaseActorFederatingProtocol(t *testing.T) {
 		delegate, _, a := setupFn(ctl)
 		resp := httptest.NewRecorder()
 		req := toAPRequest(toPostOutboxRequest(testCreateNoId))
-		delegate.EXPECT().AuthenticatePostInbox(ctx, resp, req).Return(ctx, true, nil)
+		outboxCtx := withRequestURL(ctx, mustParse(testMyOutboxIRI))
+		delegate.EXPECT().AuthenticatePostInbox(outboxCtx, resp, req).Return(outboxCtx, true, nil)
 		// Run the test
 		handled, err := a.PostInbox(ctx, resp, req)
 		// Verify results
@@ -431,9 +640,9 @@ func TestBaseActorFederatingProtocol(t *testing.T) {
 		delegate, _, a := setupFn(ctl)
 		resp := httptest.NewRecorder()
 		req := toAPRequest(toPostInboxRequest(testCreate))
-		delegate.EXPECT().AuthenticatePostInbox(ctx, resp, req).Return(ctx, true, nil)
-		delegate.EXPECT().PostInboxRequestBodyHook(ctx, req, toDeserializedForm(testCreate)).Return(ctx, nil)
-		delegate.EXPECT().AuthorizePostInbox(ctx, resp, toDeserializedForm(testCreate)).DoAndReturn(func(ctx context.Context, resp http.ResponseWriter, activity Activity) (bool, error) {
+		delegate.EXPECT().AuthenticatePostInbox(inboxCtx, resp, req).Return(inboxCtx, true, nil)
+		delegate.EXPECT().PostInboxRequestBodyHook(inboxActivityCtx, req, toDeserializedForm(testCreate)).Return(inboxActivityCtx, nil)
+		delegate.EXPECT().AuthorizePostInbox(inboxActivityCtx, resp, toDeserializedForm(testCreate)).DoAndReturn(func(ctx context.Context, resp http.ResponseWriter, activity Activity) (bool, error) {
 			resp.WriteHeader(http.StatusForbidden)
 			return false, nil
 		})
@@ -451,11 +660,110 @@ func TestBaseActorFederatingProtocol(t *testing.T) {
 		delegate, _, a := setupFn(ctl)
 		resp := httptest.NewRecorder()
 		req := toAPRequest(toPostInboxRequest(testCreate))
-		delegate.EXPECT().AuthenticatePostInbox(ctx, resp, req).Return(ctx, true, nil)
-		delegate.EXPECT().PostInboxRequestBodyHook(ctx, req, toDeserializedForm(testCreate)).Return(ctx, nil)
-		delegate.EXPECT().AuthorizePostInbox(ctx, resp, toDeserializedForm(testCreate)).Return(true, nil)
-		delegate.EXPECT().PostInbox(ctx, mustParse(testMyInboxIRI), toDeserializedForm(testCreate)).Return(nil)
-		delegate.EXPECT().InboxForwarding(ctx, mustParse(testMyInboxIRI), toDeserializedForm(testCreate)).Return(nil)
+		delegate.EXPECT().AuthenticatePostInbox(inboxCtx, resp, req).Return(inboxCtx, true, nil)
+		delegate.EXPECT().PostInboxRequestBodyHook(inboxActivityCtx, req, toDeserializedForm(testCreate)).Return(inboxActivityCtx, nil)
+		delegate.EXPECT().AuthorizePostInbox(inboxActivityCtx, resp, toDeserializedForm(testCreate)).Return(true, nil)
+		delegate.EXPECT().PostInbox(inboxActivityCtx, mustParse(testMyInboxIRI), toDeserializedForm(testCreate)).Return(nil)
+		delegate.EXPECT().InboxForwarding(inboxActivityCtx, mustParse(testMyInboxIRI), toDeserializedForm(testCreate)).Return(nil)
+		// Run the test
+		handled, err := a.PostInbox(ctx, resp, req)
+		// Verify results
+		assertEqual(t, err, nil)
+		assertEqual(t, handled, true)
+		assertEqual(t, resp.Code, http.StatusOK)
+	})
+	t.Run("PostInboxDropsActivityWithoutLocalRecipients", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		delegate := NewMockDelegateActor(ctl)
+		checker := NewMockLocalRecipientsChecker(ctl)
+		a := NewCustomActor(
+			localRecipientsDelegateActor{delegate, checker},
+			/*enableSocialProtocol=*/ false,
+			/*enableFederatedProtocol=*/ true,
+			NewMockClock(ctl))
+		resp := httptest.NewRecorder()
+		req := toAPRequest(toPostInboxRequest(testCreate))
+		delegate.EXPECT().AuthenticatePostInbox(inboxCtx, resp, req).Return(inboxCtx, true, nil)
+		delegate.EXPECT().PostInboxRequestBodyHook(inboxActivityCtx, req, toDeserializedForm(testCreate)).Return(inboxActivityCtx, nil)
+		delegate.EXPECT().AuthorizePostInbox(inboxActivityCtx, resp, toDeserializedForm(testCreate)).Return(true, nil)
+		checker.EXPECT().HasLocalRecipients(inboxActivityCtx, toDeserializedForm(testCreate)).Return(false, nil)
+		// Run the test
+		handled, err := a.PostInbox(ctx, resp, req)
+		// Verify results
+		assertEqual(t, err, nil)
+		assertEqual(t, handled, true)
+		assertEqual(t, resp.Code, http.StatusOK)
+	})
+	t.Run("PostInboxReturnsHasLocalRecipientsError", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		delegate := NewMockDelegateActor(ctl)
+		checker := NewMockLocalRecipientsChecker(ctl)
+		a := NewCustomActor(
+			localRecipientsDelegateActor{delegate, checker},
+			/*enableSocialProtocol=*/ false,
+			/*enableFederatedProtocol=*/ true,
+			NewMockClock(ctl))
+		resp := httptest.NewRecorder()
+		req := toAPRequest(toPostInboxRequest(testCreate))
+		delegate.EXPECT().AuthenticatePostInbox(inboxCtx, resp, req).Return(inboxCtx, true, nil)
+		delegate.EXPECT().PostInboxRequestBodyHook(inboxActivityCtx, req, toDeserializedForm(testCreate)).Return(inboxActivityCtx, nil)
+		delegate.EXPECT().AuthorizePostInbox(inboxActivityCtx, resp, toDeserializedForm(testCreate)).Return(true, nil)
+		checker.EXPECT().HasLocalRecipients(inboxActivityCtx, toDeserializedForm(testCreate)).Return(false, testErr)
+		// Run the test
+		handled, err := a.PostInbox(ctx, resp, req)
+		// Verify results
+		assertEqual(t, err, testErr)
+		assertEqual(t, handled, true)
+	})
+	t.Run("PostInboxRejectsJSONExceedingLimits", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		delegate := NewMockDelegateActor(ctl)
+		a := NewCustomActor(
+			jsonLimitingDelegateActor{delegate, 32},
+			/*enableSocialProtocol=*/ false,
+			/*enableFederatedProtocol=*/ true,
+			NewMockClock(ctl))
+		resp := httptest.NewRecorder()
+		body := `{"type":"Note","content":` + strings.Repeat("[", 10000) + strings.Repeat("]", 10000) + `}`
+		req := toAPRequest(httptest.NewRequest("POST", testMyInboxIRI, strings.NewReader(body)))
+		delegate.EXPECT().AuthenticatePostInbox(inboxCtx, resp, req).Return(inboxCtx, true, nil)
+		// Run the test
+		handled, err := a.PostInbox(ctx, resp, req)
+		// Verify results
+		assertEqual(t, err, nil)
+		assertEqual(t, handled, true)
+		assertEqual(t, resp.Code, http.StatusUnprocessableEntity)
+	})
+	t.Run("PostInboxCallsRawBodyHookWithReceivedBytes", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		delegate, clock, _ := setupFn(ctl)
+		hooker := NewMockRawBodyHooker(ctl)
+		a := NewCustomActor(
+			rawBodyHookerDelegateActor{delegate, hooker},
+			/*enableSocialProtocol=*/ false,
+			/*enableFederatedProtocol=*/ true,
+			clock)
+		resp := httptest.NewRecorder()
+		req := toAPRequest(toPostInboxRequest(testCreate))
+		raw, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Body = ioutil.NopCloser(bytes.NewReader(raw))
+		delegate.EXPECT().AuthenticatePostInbox(inboxCtx, resp, req).Return(inboxCtx, true, nil)
+		delegate.EXPECT().PostInboxRequestBodyHook(inboxActivityCtx, req, toDeserializedForm(testCreate)).Return(inboxActivityCtx, nil)
+		hooker.EXPECT().PostInboxRawBodyHook(inboxActivityCtx, req, raw, toDeserializedForm(testCreate)).Return(inboxActivityCtx, nil)
+		delegate.EXPECT().AuthorizePostInbox(inboxActivityCtx, resp, toDeserializedForm(testCreate)).Return(true, nil)
+		delegate.EXPECT().PostInbox(inboxActivityCtx, mustParse(testMyInboxIRI), toDeserializedForm(testCreate)).Return(nil)
+		delegate.EXPECT().InboxForwarding(inboxActivityCtx, mustParse(testMyInboxIRI), toDeserializedForm(testCreate)).Return(nil)
 		// Run the test
 		handled, err := a.PostInbox(ctx, resp, req)
 		// Verify results
@@ -463,6 +771,28 @@ func TestBaseActorFederatingProtocol(t *testing.T) {
 		assertEqual(t, handled, true)
 		assertEqual(t, resp.Code, http.StatusOK)
 	})
+	t.Run("PostInboxRawBodyHookErrorStopsProcessing", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		delegate, clock, _ := setupFn(ctl)
+		hooker := NewMockRawBodyHooker(ctl)
+		a := NewCustomActor(
+			rawBodyHookerDelegateActor{delegate, hooker},
+			/*enableSocialProtocol=*/ false,
+			/*enableFederatedProtocol=*/ true,
+			clock)
+		resp := httptest.NewRecorder()
+		req := toAPRequest(toPostInboxRequest(testCreate))
+		delegate.EXPECT().AuthenticatePostInbox(inboxCtx, resp, req).Return(inboxCtx, true, nil)
+		delegate.EXPECT().PostInboxRequestBodyHook(inboxActivityCtx, req, toDeserializedForm(testCreate)).Return(inboxActivityCtx, nil)
+		hooker.EXPECT().PostInboxRawBodyHook(inboxActivityCtx, req, gomock.Any(), toDeserializedForm(testCreate)).Return(inboxActivityCtx, testErr)
+		// Run the test
+		handled, err := a.PostInbox(ctx, resp, req)
+		// Verify results
+		assertEqual(t, err, testErr)
+		assertEqual(t, handled, true)
+	})
 	t.Run("PostInboxBadRequestForErrObjectRequired", func(t *testing.T) {
 		// Setup
 		ctl := gomock.NewController(t)
@@ -470,10 +800,10 @@ func TestBaseActorFederatingProtocol(t *testing.T) {
 		delegate, _, a := setupFn(ctl)
 		resp := httptest.NewRecorder()
 		req := toAPRequest(toPostInboxRequest(testCreate))
-		delegate.EXPECT().AuthenticatePostInbox(ctx, resp, req).Return(ctx, true, nil)
-		delegate.EXPECT().PostInboxRequestBodyHook(ctx, req, toDeserializedForm(testCreate)).Return(ctx, nil)
-		delegate.EXPECT().AuthorizePostInbox(ctx, resp, toDeserializedForm(testCreate)).Return(true, nil)
-		delegate.EXPECT().PostInbox(ctx, mustParse(testMyInboxIRI), toDeserializedForm(testCreate)).Return(ErrObjectRequired)
+		delegate.EXPECT().AuthenticatePostInbox(inboxCtx, resp, req).Return(inboxCtx, true, nil)
+		delegate.EXPECT().PostInboxRequestBodyHook(inboxActivityCtx, req, toDeserializedForm(testCreate)).Return(inboxActivityCtx, nil)
+		delegate.EXPECT().AuthorizePostInbox(inboxActivityCtx, resp, toDeserializedForm(testCreate)).Return(true, nil)
+		delegate.EXPECT().PostInbox(inboxActivityCtx, mustParse(testMyInboxIRI), toDeserializedForm(testCreate)).Return(ErrObjectRequired)
 		// Run the test
 		handled, err := a.PostInbox(ctx, resp, req)
 		// Verify results
@@ -481,6 +811,24 @@ func TestBaseActorFederatingProtocol(t *testing.T) {
 		assertEqual(t, handled, true)
 		assertEqual(t, resp.Code, http.StatusBadRequest)
 	})
+	t.Run("PostInboxUnprocessableEntityForErrUnhandledActivity", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		delegate, _, a := setupFn(ctl)
+		resp := httptest.NewRecorder()
+		req := toAPRequest(toPostInboxRequest(testCreate))
+		delegate.EXPECT().AuthenticatePostInbox(inboxCtx, resp, req).Return(inboxCtx, true, nil)
+		delegate.EXPECT().PostInboxRequestBodyHook(inboxActivityCtx, req, toDeserializedForm(testCreate)).Return(inboxActivityCtx, nil)
+		delegate.EXPECT().AuthorizePostInbox(inboxActivityCtx, resp, toDeserializedForm(testCreate)).Return(true, nil)
+		delegate.EXPECT().PostInbox(inboxActivityCtx, mustParse(testMyInboxIRI), toDeserializedForm(testCreate)).Return(ErrUnhandledActivity)
+		// Run the test
+		handled, err := a.PostInbox(ctx, resp, req)
+		// Verify results
+		assertEqual(t, err, nil)
+		assertEqual(t, handled, true)
+		assertEqual(t, resp.Code, http.StatusUnprocessableEntity)
+	})
 	t.Run("PostInboxBadRequestForErrTargetRequired", func(t *testing.T) {
 		// Setup
 		ctl := gomock.NewController(t)
@@ -488,10 +836,10 @@ func TestBaseActorFederatingProtocol(t *testing.T) {
 		delegate, _, a := setupFn(ctl)
 		resp := httptest.NewRecorder()
 		req := toAPRequest(toPostInboxRequest(testCreate))
-		delegate.EXPECT().AuthenticatePostInbox(ctx, resp, req).Return(ctx, true, nil)
-		delegate.EXPECT().PostInboxRequestBodyHook(ctx, req, toDeserializedForm(testCreate)).Return(ctx, nil)
-		delegate.EXPECT().AuthorizePostInbox(ctx, resp, toDeserializedForm(testCreate)).Return(true, nil)
-		delegate.EXPECT().PostInbox(ctx, mustParse(testMyInboxIRI), toDeserializedForm(testCreate)).Return(ErrTargetRequired)
+		delegate.EXPECT().AuthenticatePostInbox(inboxCtx, resp, req).Return(inboxCtx, true, nil)
+		delegate.EXPECT().PostInboxRequestBodyHook(inboxActivityCtx, req, toDeserializedForm(testCreate)).Return(inboxActivityCtx, nil)
+		delegate.EXPECT().AuthorizePostInbox(inboxActivityCtx, resp, toDeserializedForm(testCreate)).Return(true, nil)
+		delegate.EXPECT().PostInbox(inboxActivityCtx, mustParse(testMyInboxIRI), toDeserializedForm(testCreate)).Return(ErrTargetRequired)
 		// Run the test
 		handled, err := a.PostInbox(ctx, resp, req)
 		// Verify results
@@ -658,6 +1006,287 @@ func TestBaseActorFederatingProtocol(t *testing.T) {
 		assertEqual(t, err, nil)
 		assertByteEqual(t, b, []byte(testOrderedCollectionUniqueElemsString))
 	})
+	t.Run("ResolveDeliveryTargetsRequiresDeliveryTargetsResolver", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, _, a := setupFn(ctl)
+		act := toDeserializedForm(testCreate).(Activity)
+		// Run the test
+		r, err := a.(FederatingActor).ResolveDeliveryTargets(ctx, mustParse(testMyOutboxIRI), act)
+		// Verify results
+		assertNotEqual(t, err, nil)
+		assertEqual(t, len(r), 0)
+	})
+	t.Run("ResolveDeliveryTargetsDelegates", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		delegate, clock, _ := setupFn(ctl)
+		resolver := NewMockDeliveryTargetsResolver(ctl)
+		a := NewCustomActor(
+			deliveryTargetsResolverDelegateActor{delegate, resolver},
+			/*enableSocialProtocol=*/ false,
+			/*enableFederatedProtocol=*/ true,
+			clock)
+		act := toDeserializedForm(testCreate).(Activity)
+		expect := []*url.URL{mustParse(testFederatedInboxIRI)}
+		resolver.EXPECT().ResolveDeliveryTargets(ctx, mustParse(testMyOutboxIRI), act).Return(expect, nil)
+		// Run the test
+		r, err := a.(FederatingActor).ResolveDeliveryTargets(ctx, mustParse(testMyOutboxIRI), act)
+		// Verify results
+		assertEqual(t, err, nil)
+		assertEqual(t, len(r), 1)
+		assertEqual(t, r[0].String(), testFederatedInboxIRI)
+	})
+	t.Run("DeliverToInboxRequiresInboxDeliverer", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, _, a := setupFn(ctl)
+		act := toDeserializedForm(testCreate).(Activity)
+		// Run the test
+		err := a.(FederatingActor).DeliverToInbox(ctx, mustParse(testMyOutboxIRI), mustParse(testFederatedInboxIRI), act)
+		// Verify results
+		assertNotEqual(t, err, nil)
+	})
+	t.Run("DeliverToInboxDelegates", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		delegate, clock, _ := setupFn(ctl)
+		deliverer := NewMockInboxDeliverer(ctl)
+		a := NewCustomActor(
+			inboxDelivererDelegateActor{delegate, deliverer},
+			/*enableSocialProtocol=*/ false,
+			/*enableFederatedProtocol=*/ true,
+			clock)
+		act := toDeserializedForm(testCreate).(Activity)
+		deliverer.EXPECT().DeliverToInbox(ctx, mustParse(testMyOutboxIRI), mustParse(testFederatedInboxIRI), act).Return(testErr)
+		// Run the test
+		err := a.(FederatingActor).DeliverToInbox(ctx, mustParse(testMyOutboxIRI), mustParse(testFederatedInboxIRI), act)
+		// Verify results
+		assertEqual(t, err, testErr)
+	})
+	testOutboxIRI := "https://other.example.com/dakota/outbox"
+	newCollection := func(id string, add func(items vocab.ActivityStreamsOrderedItemsProperty)) vocab.ActivityStreamsOrderedCollection {
+		oc := streams.NewActivityStreamsOrderedCollection()
+		idProp := streams.NewJSONLDIdProperty()
+		idProp.Set(mustParse(id))
+		oc.SetJSONLDId(idProp)
+		items := streams.NewActivityStreamsOrderedItemsProperty()
+		add(items)
+		oc.SetActivityStreamsOrderedItems(items)
+		return oc
+	}
+	newCreate := func(id, actor string) vocab.ActivityStreamsCreate {
+		create := streams.NewActivityStreamsCreate()
+		idProp := streams.NewJSONLDIdProperty()
+		idProp.Set(mustParse(id))
+		create.SetJSONLDId(idProp)
+		actorProp := streams.NewActivityStreamsActorProperty()
+		actorProp.AppendIRI(mustParse(actor))
+		create.SetActivityStreamsActor(actorProp)
+		return create
+	}
+	setupCollectionFn := func(ctl *gomock.Controller) (delegate *MockDelegateActor, tport *MockTransport, a Actor) {
+		delegate = NewMockDelegateActor(ctl)
+		tport = NewMockTransport(ctl)
+		a = NewCustomActor(
+			transportCreatingDelegateActor{delegate, tport},
+			/*enableSocialProtocol=*/ false,
+			/*enableFederatedProtocol=*/ true,
+			NewMockClock(ctl))
+		return
+	}
+	expectProcessed := func(delegate *MockDelegateActor, activity interface{}) *gomock.Call {
+		return delegate.EXPECT().AuthorizePostInbox(gomock.Any(), gomock.Any(), activity).DoAndReturn(
+			func(c context.Context, w http.ResponseWriter, activity Activity) (bool, error) {
+				delegate.EXPECT().PostInbox(c, mustParse(testMyInboxIRI), activity).DoAndReturn(
+					func(c context.Context, inbox *url.URL, activity Activity) error {
+						delegate.EXPECT().InboxForwarding(c, mustParse(testMyInboxIRI), activity)
+						return nil
+					})
+				return true, nil
+			})
+	}
+	t.Run("ProcessInboxCollectionRequiresTransportCreator", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, _, a := setupFn(ctl)
+		oc := newCollection(testOutboxIRI, func(items vocab.ActivityStreamsOrderedItemsProperty) {
+			items.AppendActivityStreamsCreate(testCreate)
+		})
+		// Run the test
+		n, err := a.(FederatingActor).ProcessInboxCollection(ctx, mustParse(testMyInboxIRI), oc, false)
+		// Verify results
+		assertEqual(t, n, 0)
+		assertNotEqual(t, err, nil)
+	})
+	t.Run("ProcessInboxCollectionAuthorizesPostsAndForwards", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		delegate, _, a := setupCollectionFn(ctl)
+		oc := newCollection(testOutboxIRI, func(items vocab.ActivityStreamsOrderedItemsProperty) {
+			items.AppendActivityStreamsCreate(testCreate)
+		})
+		delegate.EXPECT().AuthorizePostInbox(gomock.Any(), gomock.Any(), testCreate).DoAndReturn(
+			func(c context.Context, w http.ResponseWriter, activity Activity) (bool, error) {
+				u, ok := RequestURLFromContext(c)
+				assertEqual(t, ok, true)
+				assertEqual(t, u.String(), testMyInboxIRI)
+				signer, ok := AuthenticatedActorFromContext(c)
+				assertEqual(t, ok, true)
+				assertEqual(t, signer.String(), testOutboxIRI)
+				return true, nil
+			})
+		delegate.EXPECT().PostInbox(gomock.Any(), mustParse(testMyInboxIRI), testCreate)
+		delegate.EXPECT().InboxForwarding(gomock.Any(), mustParse(testMyInboxIRI), testCreate)
+		// Run the test
+		n, err := a.(FederatingActor).ProcessInboxCollection(ctx, mustParse(testMyInboxIRI), oc, false)
+		// Verify results
+		assertEqual(t, err, nil)
+		assertEqual(t, n, 1)
+	})
+	t.Run("ProcessInboxCollectionDereferencesIRIs", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		delegate, tport, a := setupCollectionFn(ctl)
+		create := newCreate(testFederatedActivityIRI2, testFederatedActorIRI)
+		oc := newCollection(testOutboxIRI, func(items vocab.ActivityStreamsOrderedItemsProperty) {
+			items.AppendIRI(mustParse(testFederatedActivityIRI2))
+		})
+		tport.EXPECT().Dereference(gomock.Any(), mustParse(testFederatedActivityIRI2)).Return(mustSerializeToBytes(create), nil)
+		expectProcessed(delegate, toDeserializedForm(create))
+		// Run the test
+		n, err := a.(FederatingActor).ProcessInboxCollection(ctx, mustParse(testMyInboxIRI), oc, false)
+		// Verify results
+		assertEqual(t, err, nil)
+		assertEqual(t, n, 1)
+	})
+	t.Run("ProcessInboxCollectionDereferencesActivitiesOnOtherOrigins", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		delegate, tport, a := setupCollectionFn(ctl)
+		oc := newCollection("https://evil.example.com/outbox", func(items vocab.ActivityStreamsOrderedItemsProperty) {
+			items.AppendActivityStreamsCreate(testCreate)
+		})
+		tport.EXPECT().Dereference(gomock.Any(), mustParse(testFederatedActivityIRI)).Return(mustSerializeToBytes(testCreate), nil)
+		expectProcessed(delegate, toDeserializedForm(testCreate))
+		// Run the test
+		n, err := a.(FederatingActor).ProcessInboxCollection(ctx, mustParse(testMyInboxIRI), oc, false)
+		// Verify results
+		assertEqual(t, err, nil)
+		assertEqual(t, n, 1)
+	})
+	t.Run("ProcessInboxCollectionRejectsDereferencedActivityWithOtherId", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, tport, a := setupCollectionFn(ctl)
+		oc := newCollection(testOutboxIRI, func(items vocab.ActivityStreamsOrderedItemsProperty) {
+			items.AppendIRI(mustParse(testFederatedActivityIRI2))
+		})
+		tport.EXPECT().Dereference(gomock.Any(), mustParse(testFederatedActivityIRI2)).Return(mustSerializeToBytes(testCreate), nil)
+		// Run the test
+		n, err := a.(FederatingActor).ProcessInboxCollection(ctx, mustParse(testMyInboxIRI), oc, false)
+		// Verify results
+		assertEqual(t, n, 0)
+		assertNotEqual(t, err, nil)
+	})
+	t.Run("ProcessInboxCollectionRejectsAuthorsOnOtherOrigins", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, _, a := setupCollectionFn(ctl)
+		oc := newCollection(testOutboxIRI, func(items vocab.ActivityStreamsOrderedItemsProperty) {
+			items.AppendActivityStreamsCreate(newCreate(testFederatedActivityIRI2, testPersonIRI))
+		})
+		// Run the test
+		n, err := a.(FederatingActor).ProcessInboxCollection(ctx, mustParse(testMyInboxIRI), oc, false)
+		// Verify results
+		assertEqual(t, n, 0)
+		assertNotEqual(t, err, nil)
+	})
+	t.Run("ProcessInboxCollectionWalksPages", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		delegate, tport, a := setupCollectionFn(ctl)
+		pageIRI := testOutboxIRI + "?page=1"
+		oc := newCollection(testOutboxIRI, func(items vocab.ActivityStreamsOrderedItemsProperty) {})
+		first := streams.NewActivityStreamsFirstProperty()
+		first.SetIRI(mustParse(pageIRI))
+		oc.SetActivityStreamsFirst(first)
+		page := streams.NewActivityStreamsOrderedCollectionPage()
+		pageId := streams.NewJSONLDIdProperty()
+		pageId.Set(mustParse(pageIRI))
+		page.SetJSONLDId(pageId)
+		pageItems := streams.NewActivityStreamsOrderedItemsProperty()
+		pageItems.AppendActivityStreamsCreate(testCreate)
+		page.SetActivityStreamsOrderedItems(pageItems)
+		tport.EXPECT().Dereference(gomock.Any(), mustParse(pageIRI)).Return(mustSerializeToBytes(page), nil)
+		expectProcessed(delegate, gomock.Any())
+		// Run the test
+		n, err := a.(FederatingActor).ProcessInboxCollection(ctx, mustParse(testMyInboxIRI), oc, false)
+		// Verify results
+		assertEqual(t, err, nil)
+		assertEqual(t, n, 1)
+	})
+	t.Run("ProcessInboxCollectionStopsAtFirstError", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		delegate, tport, a := setupCollectionFn(ctl)
+		oc := newCollection(testOutboxIRI, func(items vocab.ActivityStreamsOrderedItemsProperty) {
+			items.AppendActivityStreamsCreate(testCreate)
+			items.AppendIRI(mustParse(testFederatedActivityIRI2))
+			items.AppendActivityStreamsCreate(testCreate2)
+		})
+		expectProcessed(delegate, testCreate)
+		tport.EXPECT().Dereference(gomock.Any(), mustParse(testFederatedActivityIRI2)).Return(nil, testErr)
+		// Run the test
+		n, err := a.(FederatingActor).ProcessInboxCollection(ctx, mustParse(testMyInboxIRI), oc, false)
+		// Verify results
+		assertEqual(t, n, 1)
+		assertNotEqual(t, err, nil)
+	})
+	t.Run("ProcessInboxCollectionContinuesPastErrors", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		delegate, tport, a := setupCollectionFn(ctl)
+		oc := newCollection(testOutboxIRI, func(items vocab.ActivityStreamsOrderedItemsProperty) {
+			items.AppendActivityStreamsCreate(testCreate)
+			items.AppendIRI(mustParse(testFederatedActivityIRI2))
+			items.AppendActivityStreamsCreate(testCreate2)
+		})
+		create := newCreate(testFederatedActivityIRI2, testFederatedActorIRI)
+		gomock.InOrder(
+			delegate.EXPECT().AuthorizePostInbox(gomock.Any(), gomock.Any(), testCreate).Return(true, nil),
+			delegate.EXPECT().PostInbox(gomock.Any(), mustParse(testMyInboxIRI), testCreate).Return(testErr),
+			tport.EXPECT().Dereference(gomock.Any(), mustParse(testFederatedActivityIRI2)).Return(mustSerializeToBytes(create), nil),
+			expectProcessed(delegate, toDeserializedForm(create)),
+			delegate.EXPECT().AuthorizePostInbox(gomock.Any(), gomock.Any(), testCreate2).DoAndReturn(
+				func(c context.Context, w http.ResponseWriter, activity Activity) (bool, error) {
+					w.WriteHeader(http.StatusForbidden)
+					return false, nil
+				}),
+		)
+		// Run the test
+		n, err := a.(FederatingActor).ProcessInboxCollection(ctx, mustParse(testMyInboxIRI), oc, true)
+		// Verify results
+		assertEqual(t, n, 1)
+		cErr, ok := err.(*InboxCollectionError)
+		if !ok {
+			t.Fatalf("expected an *InboxCollectionError, got %v", err)
+		}
+		assertEqual(t, len(cErr.Errors), 2)
+	})
 }
 
 // TestBaseActor tests the Actor returned with NewCustomActor and having both
@@ -666,6 +1295,9 @@ func TestBaseActor(t *testing.T) {
 	// Set up test case
 	setupData()
 	ctx := context.Background()
+	inboxCtx := withRequestURL(ctx, mustParse(testMyInboxIRI))
+	inboxActivityCtx := withActivity(inboxCtx, toDeserializedForm(testCreate).(Activity))
+	outboxCtx := withRequestURL(ctx, mustParse(testMyOutboxIRI))
 	setupFn := func(ctl *gomock.Controller) (delegate *MockDelegateActor, clock *MockClock, a Actor) {
 		delegate = NewMockDelegateActor(ctl)
 		clock = NewMockClock(ctl)
@@ -684,11 +1316,11 @@ func TestBaseActor(t *testing.T) {
 		delegate, _, a := setupFn(ctl)
 		resp := httptest.NewRecorder()
 		req := toAPRequest(toPostInboxRequest(testCreate))
-		delegate.EXPECT().AuthenticatePostInbox(ctx, resp, req).Return(ctx, true, nil)
-		delegate.EXPECT().PostInboxRequestBodyHook(ctx, req, toDeserializedForm(testCreate)).Return(ctx, nil)
-		delegate.EXPECT().AuthorizePostInbox(ctx, resp, toDeserializedForm(testCreate)).Return(true, nil)
-		delegate.EXPECT().PostInbox(ctx, mustParse(testMyInboxIRI), toDeserializedForm(testCreate)).Return(nil)
-		delegate.EXPECT().InboxForwarding(ctx, mustParse(testMyInboxIRI), toDeserializedForm(testCreate)).Return(nil)
+		delegate.EXPECT().AuthenticatePostInbox(inboxCtx, resp, req).Return(inboxCtx, true, nil)
+		delegate.EXPECT().PostInboxRequestBodyHook(inboxActivityCtx, req, toDeserializedForm(testCreate)).Return(inboxActivityCtx, nil)
+		delegate.EXPECT().AuthorizePostInbox(inboxActivityCtx, resp, toDeserializedForm(testCreate)).Return(true, nil)
+		delegate.EXPECT().PostInbox(inboxActivityCtx, mustParse(testMyInboxIRI), toDeserializedForm(testCreate)).Return(nil)
+		delegate.EXPECT().InboxForwarding(inboxActivityCtx, mustParse(testMyInboxIRI), toDeserializedForm(testCreate)).Return(nil)
 		// Run the test
 		handled, err := a.PostInbox(ctx, resp, req)
 		// Verify results
@@ -703,14 +1335,14 @@ func TestBaseActor(t *testing.T) {
 		delegate, _, a := setupFn(ctl)
 		resp := httptest.NewRecorder()
 		req := toAPRequest(toPostOutboxRequest(testCreateNoId))
-		delegate.EXPECT().AuthenticatePostOutbox(ctx, resp, req).Return(ctx, true, nil)
-		delegate.EXPECT().PostOutboxRequestBodyHook(ctx, req, toDeserializedForm(testCreateNoId)).Return(ctx, nil)
-		delegate.EXPECT().AddNewIDs(ctx, toDeserializedForm(testCreateNoId)).DoAndReturn(func(c context.Context, activity Activity) error {
+		delegate.EXPECT().AuthenticatePostOutbox(outboxCtx, resp, req).Return(outboxCtx, true, nil)
+		delegate.EXPECT().PostOutboxRequestBodyHook(outboxCtx, req, toDeserializedForm(testCreateNoId)).Return(outboxCtx, nil)
+		delegate.EXPECT().AddNewIDs(withActivity(outboxCtx, toDeserializedForm(testCreateNoId).(Activity)), toDeserializedForm(testCreateNoId)).DoAndReturn(func(c context.Context, activity Activity) error {
 			withNewId(activity)
 			return nil
 		})
 		delegate.EXPECT().PostOutbox(
-			ctx,
+			withActivity(outboxCtx, withNewId(toDeserializedForm(testCreateNoId))),
 			withNewId(toDeserializedForm(testCreateNoId)),
 			mustParse(testMyOutboxIRI),
 			mustSerialize(testCreateNoId),
@@ -731,19 +1363,19 @@ func TestBaseActor(t *testing.T) {
 		delegate, _, a := setupFn(ctl)
 		resp := httptest.NewRecorder()
 		req := toAPRequest(toPostOutboxRequest(testCreateNoId))
-		delegate.EXPECT().AuthenticatePostOutbox(ctx, resp, req).Return(ctx, true, nil)
-		delegate.EXPECT().PostOutboxRequestBodyHook(ctx, req, toDeserializedForm(testCreateNoId)).Return(ctx, nil)
-		delegate.EXPECT().AddNewIDs(ctx, toDeserializedForm(testCreateNoId)).DoAndReturn(func(c context.Context, activity Activity) error {
+		delegate.EXPECT().AuthenticatePostOutbox(outboxCtx, resp, req).Return(outboxCtx, true, nil)
+		delegate.EXPECT().PostOutboxRequestBodyHook(outboxCtx, req, toDeserializedForm(testCreateNoId)).Return(outboxCtx, nil)
+		delegate.EXPECT().AddNewIDs(withActivity(outboxCtx, toDeserializedForm(testCreateNoId).(Activity)), toDeserializedForm(testCreateNoId)).DoAndReturn(func(c context.Context, activity Activity) error {
 			withNewId(activity)
 			return nil
 		})
 		delegate.EXPECT().PostOutbox(
-			ctx,
+			withActivity(outboxCtx, withNewId(toDeserializedForm(testCreateNoId))),
 			withNewId(toDeserializedForm(testCreateNoId)),
 			mustParse(testMyOutboxIRI),
 			mustSerialize(testCreateNoId),
 		).Return(true, nil)
-		delegate.EXPECT().Deliver(ctx, mustParse(testMyOutboxIRI), withNewId(toDeserializedForm(testCreateNoId))).Return(nil)
+		delegate.EXPECT().Deliver(withActivity(outboxCtx, withNewId(toDeserializedForm(testCreateNoId))), mustParse(testMyOutboxIRI), withNewId(toDeserializedForm(testCreateNoId))).Return(nil)
 		// Run the test
 		handled, err := a.PostOutbox(ctx, resp, req)
 		// Verify results
@@ -753,4 +1385,450 @@ func TestBaseActor(t *testing.T) {
 		respV := resp.Result()
 		assertEqual(t, respV.Header.Get(locationHeader), testNewActivityIRI)
 	})
+	t.Run("PostOutboxRejectsUndeliverableAddressing", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		delegate, _, a := setupFn(ctl)
+		unaddressed := toDeserializedForm(testCreateNoId).(vocab.ActivityStreamsCreate)
+		to := streams.NewActivityStreamsToProperty()
+		to.AppendIRI(mustParse(PublicActivityPubIRI))
+		unaddressed.SetActivityStreamsTo(to)
+		resp := httptest.NewRecorder()
+		req := toAPRequest(toPostOutboxRequest(unaddressed))
+		delegate.EXPECT().AuthenticatePostOutbox(outboxCtx, resp, req).Return(outboxCtx, true, nil)
+		delegate.EXPECT().PostOutboxRequestBodyHook(outboxCtx, req, toDeserializedForm(unaddressed)).Return(outboxCtx, nil)
+		delegate.EXPECT().AddNewIDs(withActivity(outboxCtx, toDeserializedForm(unaddressed).(Activity)), toDeserializedForm(unaddressed)).DoAndReturn(func(c context.Context, activity Activity) error {
+			withNewId(activity)
+			return nil
+		})
+		// Run the test
+		handled, err := a.PostOutbox(ctx, resp, req)
+		// Verify results
+		assertEqual(t, err, nil)
+		assertEqual(t, handled, true)
+		assertEqual(t, resp.Code, http.StatusBadRequest)
+	})
+}
+
+// rawBodyHookerDelegateActor is a DelegateActor that is also a RawBodyHooker.
+type rawBodyHookerDelegateActor struct {
+	*MockDelegateActor
+	*MockRawBodyHooker
+}
+
+// tagProcessorDelegateActor is a DelegateActor that is also a TagProcessor.
+type tagProcessorDelegateActor struct {
+	*MockDelegateActor
+	*MockTagProcessor
+}
+
+// outboundDecoratorDelegateActor is a DelegateActor that is also an
+// OutboundDecorator.
+type outboundDecoratorDelegateActor struct {
+	*MockDelegateActor
+	*MockOutboundDecorator
+}
+
+// actorResolverDelegateActor is a DelegateActor that is also an ActorResolver.
+type actorResolverDelegateActor struct {
+	*MockDelegateActor
+	*MockActorResolver
+}
+
+// TestBaseActorActorResolver tests the Actor returned with NewCustomActor
+// when the delegate resolves the local actor of requests.
+func TestBaseActorActorResolver(t *testing.T) {
+	// Set up test case
+	setupData()
+	ctx := context.Background()
+	setupFn := func(ctl *gomock.Controller) (delegate *MockDelegateActor, resolver *MockActorResolver, a Actor) {
+		delegate = NewMockDelegateActor(ctl)
+		resolver = NewMockActorResolver(ctl)
+		a = NewCustomActor(
+			actorResolverDelegateActor{delegate, resolver},
+			/*enableSocialProtocol=*/ true,
+			/*enableFederatedProtocol=*/ true,
+			NewMockClock(ctl))
+		return
+	}
+	// Run tests
+	t.Run("SetsResolvedActorOnContext", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		delegate, resolver, a := setupFn(ctl)
+		resp := httptest.NewRecorder()
+		req := toAPRequest(toGetInboxRequest())
+		resolver.EXPECT().ActorForRequest(ctx, req).Return(mustParse(testPersonIRI), nil)
+		delegate.EXPECT().AuthenticateGetInbox(gomock.Any(), resp, req).DoAndReturn(
+			func(c context.Context, w http.ResponseWriter, r *http.Request) (context.Context, bool, error) {
+				assertEqual(t, requestActor(c).String(), testPersonIRI)
+				return c, false, nil
+			})
+		// Run the test
+		handled, err := a.GetInbox(ctx, resp, req)
+		// Verify results
+		assertEqual(t, err, nil)
+		assertEqual(t, handled, true)
+	})
+	t.Run("LeavesContextIfNoActorResolved", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		delegate, resolver, a := setupFn(ctl)
+		resp := httptest.NewRecorder()
+		req := toAPRequest(toGetOutboxRequest())
+		resolver.EXPECT().ActorForRequest(ctx, req).Return(nil, nil)
+		delegate.EXPECT().AuthenticateGetOutbox(ctx, resp, req).Return(ctx, false, nil)
+		// Run the test
+		handled, err := a.GetOutbox(ctx, resp, req)
+		// Verify results
+		assertEqual(t, err, nil)
+		assertEqual(t, handled, true)
+	})
+	t.Run("ReturnsResolverError", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, resolver, a := setupFn(ctl)
+		resp := httptest.NewRecorder()
+		req := toAPRequest(toPostInboxRequest(testCreate))
+		resolver.EXPECT().ActorForRequest(ctx, req).Return(nil, testErr)
+		// Run the test
+		handled, err := a.PostInbox(ctx, resp, req)
+		// Verify results
+		assertEqual(t, err, testErr)
+		assertEqual(t, handled, true)
+	})
+}
+
+// TestBaseActorContextAccessors tests the values the Actor sets on the
+// context are obtainable with the exported accessors.
+func TestBaseActorContextAccessors(t *testing.T) {
+	// Set up test case
+	setupData()
+	ctx := context.Background()
+	setupFn := func(ctl *gomock.Controller) (delegate *MockDelegateActor, a Actor) {
+		delegate = NewMockDelegateActor(ctl)
+		a = NewCustomActor(
+			delegate,
+			/*enableSocialProtocol=*/ true,
+			/*enableFederatedProtocol=*/ false,
+			NewMockClock(ctl))
+		return
+	}
+	// Run tests
+	t.Run("PostInboxSetsRequestURLAndActivity", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		delegate := NewMockDelegateActor(ctl)
+		a := NewCustomActor(
+			delegate,
+			/*enableSocialProtocol=*/ false,
+			/*enableFederatedProtocol=*/ true,
+			NewMockClock(ctl))
+		resp := httptest.NewRecorder()
+		req := toAPRequest(toPostInboxRequest(testCreate))
+		delegate.EXPECT().AuthenticatePostInbox(gomock.Any(), resp, req).DoAndReturn(
+			func(c context.Context, w http.ResponseWriter, r *http.Request) (context.Context, bool, error) {
+				u, ok := RequestURLFromContext(c)
+				assertEqual(t, ok, true)
+				assertEqual(t, u.String(), testMyInboxIRI)
+				_, ok = ActivityFromContext(c)
+				assertEqual(t, ok, false)
+				return WithAuthenticatedActor(c, mustParse(testFederatedActorIRI)), true, nil
+			})
+		delegate.EXPECT().PostInboxRequestBodyHook(gomock.Any(), req, toDeserializedForm(testCreate)).DoAndReturn(
+			func(c context.Context, r *http.Request, activity Activity) (context.Context, error) {
+				got, ok := ActivityFromContext(c)
+				assertEqual(t, ok, true)
+				assertEqual(t, got, activity)
+				return c, nil
+			})
+		delegate.EXPECT().AuthorizePostInbox(gomock.Any(), resp, toDeserializedForm(testCreate)).Return(true, nil)
+		delegate.EXPECT().PostInbox(gomock.Any(), mustParse(testMyInboxIRI), toDeserializedForm(testCreate)).DoAndReturn(
+			func(c context.Context, inboxIRI *url.URL, activity Activity) error {
+				u, ok := RequestURLFromContext(c)
+				assertEqual(t, ok, true)
+				assertEqual(t, u.String(), testMyInboxIRI)
+				got, ok := ActivityFromContext(c)
+				assertEqual(t, ok, true)
+				assertEqual(t, got, activity)
+				actorIRI, ok := AuthenticatedActorFromContext(c)
+				assertEqual(t, ok, true)
+				assertEqual(t, actorIRI.String(), testFederatedActorIRI)
+				return nil
+			})
+		delegate.EXPECT().InboxForwarding(gomock.Any(), mustParse(testMyInboxIRI), toDeserializedForm(testCreate)).Return(nil)
+		// Run the test
+		handled, err := a.PostInbox(ctx, resp, req)
+		// Verify results
+		assertEqual(t, err, nil)
+		assertEqual(t, handled, true)
+		assertEqual(t, resp.Code, http.StatusOK)
+	})
+	t.Run("PostOutboxSetsActivityAfterWrapping", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		delegate, a := setupFn(ctl)
+		resp := httptest.NewRecorder()
+		req := toAPRequest(toPostOutboxRequest(testMyNote))
+		delegate.EXPECT().AuthenticatePostOutbox(gomock.Any(), resp, req).DoAndReturn(
+			func(c context.Context, w http.ResponseWriter, r *http.Request) (context.Context, bool, error) {
+				u, ok := RequestURLFromContext(c)
+				assertEqual(t, ok, true)
+				assertEqual(t, u.String(), testMyOutboxIRI)
+				return c, true, nil
+			})
+		delegate.EXPECT().PostOutboxRequestBodyHook(gomock.Any(), req, toDeserializedForm(testMyNote)).DoAndReturn(
+			func(c context.Context, r *http.Request, data vocab.Type) (context.Context, error) {
+				_, ok := ActivityFromContext(c)
+				assertEqual(t, ok, false)
+				return c, nil
+			})
+		delegate.EXPECT().WrapInCreate(gomock.Any(), toDeserializedForm(testMyNote), mustParse(testMyOutboxIRI)).DoAndReturn(func(c context.Context, t vocab.Type, u *url.URL) (vocab.ActivityStreamsCreate, error) {
+			return wrappedInCreate(t), nil
+		})
+		delegate.EXPECT().AddNewIDs(gomock.Any(), wrappedInCreate(toDeserializedForm(testMyNote))).DoAndReturn(func(c context.Context, activity Activity) error {
+			withNewId(activity)
+			return nil
+		})
+		delegate.EXPECT().PostOutbox(
+			gomock.Any(),
+			withNewId(wrappedInCreate(toDeserializedForm(testMyNote))),
+			mustParse(testMyOutboxIRI),
+			mustSerialize(toDeserializedForm(testMyNote)),
+		).DoAndReturn(func(c context.Context, activity Activity, outboxIRI *url.URL, m map[string]interface{}) (bool, error) {
+			got, ok := ActivityFromContext(c)
+			assertEqual(t, ok, true)
+			assertEqual(t, got, activity)
+			assertEqual(t, got.GetTypeName(), "Create")
+			_, ok = AuthenticatedActorFromContext(c)
+			assertEqual(t, ok, false)
+			return true, nil
+		})
+		// Run the test
+		handled, err := a.PostOutbox(ctx, resp, req)
+		// Verify results
+		assertEqual(t, err, nil)
+		assertEqual(t, handled, true)
+		assertEqual(t, resp.Code, http.StatusCreated)
+	})
+}
+
+// sharedInboxDelegateActor is a DelegateActor that is also a
+// SharedInboxResolver.
+type sharedInboxDelegateActor struct {
+	*MockDelegateActor
+	*MockSharedInboxResolver
+}
+
+// localRecipientsSharedInboxDelegateActor is a sharedInboxDelegateActor that
+// is also a LocalRecipientsChecker.
+type localRecipientsSharedInboxDelegateActor struct {
+	sharedInboxDelegateActor
+	*MockLocalRecipientsChecker
+}
+
+// TestBaseActorSharedInbox tests the PostSharedInbox of the Actor returned
+// with NewCustomActor.
+func TestBaseActorSharedInbox(t *testing.T) {
+	// Set up test case
+	setupData()
+	ctx := context.Background()
+	const otherInboxIRI = "https://example.com/sally/inbox"
+	setupFn := func(ctl *gomock.Controller) (delegate *MockDelegateActor, resolver *MockSharedInboxResolver, a FederatingActor) {
+		delegate = NewMockDelegateActor(ctl)
+		resolver = NewMockSharedInboxResolver(ctl)
+		a = NewCustomActor(
+			sharedInboxDelegateActor{delegate, resolver},
+			/*enableSocialProtocol=*/ false,
+			/*enableFederatedProtocol=*/ true,
+			NewMockClock(ctl)).(FederatingActor)
+		return
+	}
+	// Run tests
+	t.Run("IgnoresNonActivityPubRequest", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, _, a := setupFn(ctl)
+		resp := httptest.NewRecorder()
+		req := toPostInboxRequest(testCreate)
+		// Run the test
+		handled, err := a.PostSharedInbox(ctx, resp, req)
+		// Verify results
+		assertEqual(t, err, nil)
+		assertEqual(t, handled, false)
+	})
+	t.Run("PostsToEachLocalInbox", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		delegate, resolver, a := setupFn(ctl)
+		resp := httptest.NewRecorder()
+		req := toAPRequest(toPostInboxRequest(testCreate))
+		activity := toDeserializedForm(testCreate)
+		delegate.EXPECT().AuthenticatePostInbox(gomock.Any(), resp, req).DoAndReturn(
+			func(c context.Context, w http.ResponseWriter, r *http.Request) (context.Context, bool, error) {
+				u, ok := RequestURLFromContext(c)
+				assertEqual(t, ok, true)
+				assertEqual(t, u.String(), testMyInboxIRI)
+				return c, true, nil
+			})
+		delegate.EXPECT().PostInboxRequestBodyHook(gomock.Any(), req, activity).DoAndReturn(
+			func(c context.Context, r *http.Request, activity Activity) (context.Context, error) {
+				return c, nil
+			})
+		delegate.EXPECT().AuthorizePostInbox(gomock.Any(), resp, activity).Return(true, nil)
+		resolver.EXPECT().LocalInboxes(gomock.Any(), activity).Return([]*url.URL{mustParse(testMyInboxIRI), mustParse(otherInboxIRI)}, nil)
+		for _, inbox := range []string{testMyInboxIRI, otherInboxIRI} {
+			inbox := inbox
+			delegate.EXPECT().PostInbox(gomock.Any(), mustParse(inbox), activity).DoAndReturn(
+				func(c context.Context, inboxIRI *url.URL, activity Activity) error {
+					u, ok := RequestURLFromContext(c)
+					assertEqual(t, ok, true)
+					assertEqual(t, u.String(), inbox)
+					return nil
+				})
+			delegate.EXPECT().InboxForwarding(gomock.Any(), mustParse(inbox), activity).Return(nil)
+		}
+		// Run the test
+		handled, err := a.PostSharedInbox(ctx, resp, req)
+		// Verify results
+		assertEqual(t, err, nil)
+		assertEqual(t, handled, true)
+		assertEqual(t, resp.Code, http.StatusOK)
+	})
+	t.Run("DropsActivityWithoutLocalRecipients", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		delegate := NewMockDelegateActor(ctl)
+		checker := NewMockLocalRecipientsChecker(ctl)
+		a := NewCustomActor(
+			localRecipientsSharedInboxDelegateActor{
+				sharedInboxDelegateActor{delegate, NewMockSharedInboxResolver(ctl)},
+				checker,
+			},
+			/*enableSocialProtocol=*/ false,
+			/*enableFederatedProtocol=*/ true,
+			NewMockClock(ctl)).(FederatingActor)
+		resp := httptest.NewRecorder()
+		req := toAPRequest(toPostInboxRequest(testCreate))
+		activity := toDeserializedForm(testCreate)
+		delegate.EXPECT().AuthenticatePostInbox(gomock.Any(), resp, req).DoAndReturn(
+			func(c context.Context, w http.ResponseWriter, r *http.Request) (context.Context, bool, error) {
+				return c, true, nil
+			})
+		delegate.EXPECT().PostInboxRequestBodyHook(gomock.Any(), req, activity).DoAndReturn(
+			func(c context.Context, r *http.Request, activity Activity) (context.Context, error) {
+				return c, nil
+			})
+		delegate.EXPECT().AuthorizePostInbox(gomock.Any(), resp, activity).Return(true, nil)
+		checker.EXPECT().HasLocalRecipients(gomock.Any(), activity).Return(false, nil)
+		// Run the test
+		handled, err := a.PostSharedInbox(ctx, resp, req)
+		// Verify results
+		assertEqual(t, err, nil)
+		assertEqual(t, handled, true)
+		assertEqual(t, resp.Code, http.StatusOK)
+	})
+	t.Run("ReturnsLocalInboxesError", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		delegate, resolver, a := setupFn(ctl)
+		resp := httptest.NewRecorder()
+		req := toAPRequest(toPostInboxRequest(testCreate))
+		activity := toDeserializedForm(testCreate)
+		delegate.EXPECT().AuthenticatePostInbox(gomock.Any(), resp, req).DoAndReturn(
+			func(c context.Context, w http.ResponseWriter, r *http.Request) (context.Context, bool, error) {
+				return c, true, nil
+			})
+		delegate.EXPECT().PostInboxRequestBodyHook(gomock.Any(), req, activity).DoAndReturn(
+			func(c context.Context, r *http.Request, activity Activity) (context.Context, error) {
+				return c, nil
+			})
+		delegate.EXPECT().AuthorizePostInbox(gomock.Any(), resp, activity).Return(true, nil)
+		resolver.EXPECT().LocalInboxes(gomock.Any(), activity).Return(nil, testErr)
+		// Run the test
+		handled, err := a.PostSharedInbox(ctx, resp, req)
+		// Verify results
+		assertEqual(t, err, testErr)
+		assertEqual(t, handled, true)
+	})
+	t.Run("UsesScheme", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		delegate, resolver, a := setupFn(ctl)
+		resp := httptest.NewRecorder()
+		req := toAPRequest(toPostInboxRequest(testCreate))
+		activity := toDeserializedForm(testCreate)
+		delegate.EXPECT().AuthenticatePostInbox(gomock.Any(), resp, req).DoAndReturn(
+			func(c context.Context, w http.ResponseWriter, r *http.Request) (context.Context, bool, error) {
+				u, ok := RequestURLFromContext(c)
+				assertEqual(t, ok, true)
+				assertEqual(t, u.Scheme, "http")
+				return c, true, nil
+			})
+		delegate.EXPECT().PostInboxRequestBodyHook(gomock.Any(), req, activity).DoAndReturn(
+			func(c context.Context, r *http.Request, activity Activity) (context.Context, error) {
+				return c, nil
+			})
+		delegate.EXPECT().AuthorizePostInbox(gomock.Any(), resp, activity).Return(true, nil)
+		resolver.EXPECT().LocalInboxes(gomock.Any(), activity).Return(nil, nil)
+		// Run the test
+		handled, err := a.PostSharedInboxScheme(ctx, resp, req, "http")
+		// Verify results
+		assertEqual(t, err, nil)
+		assertEqual(t, handled, true)
+		assertEqual(t, resp.Code, http.StatusOK)
+	})
+	t.Run("ReturnsErrorOfFailingLocalInbox", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		delegate, resolver, a := setupFn(ctl)
+		resp := httptest.NewRecorder()
+		req := toAPRequest(toPostInboxRequest(testCreate))
+		activity := toDeserializedForm(testCreate)
+		delegate.EXPECT().AuthenticatePostInbox(gomock.Any(), resp, req).DoAndReturn(
+			func(c context.Context, w http.ResponseWriter, r *http.Request) (context.Context, bool, error) {
+				return c, true, nil
+			})
+		delegate.EXPECT().PostInboxRequestBodyHook(gomock.Any(), req, activity).DoAndReturn(
+			func(c context.Context, r *http.Request, activity Activity) (context.Context, error) {
+				return c, nil
+			})
+		delegate.EXPECT().AuthorizePostInbox(gomock.Any(), resp, activity).Return(true, nil)
+		resolver.EXPECT().LocalInboxes(gomock.Any(), activity).Return([]*url.URL{mustParse(testMyInboxIRI), mustParse(otherInboxIRI)}, nil)
+		delegate.EXPECT().PostInbox(gomock.Any(), mustParse(testMyInboxIRI), activity).Return(testErr)
+		// Run the test
+		handled, err := a.PostSharedInbox(ctx, resp, req)
+		// Verify results
+		assertEqual(t, err, testErr)
+		assertEqual(t, handled, true)
+	})
+	t.Run("ErrorsWithoutSharedInboxResolver", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		a := NewCustomActor(
+			NewMockDelegateActor(ctl),
+			/*enableSocialProtocol=*/ false,
+			/*enableFederatedProtocol=*/ true,
+			NewMockClock(ctl)).(FederatingActor)
+		resp := httptest.NewRecorder()
+		req := toAPRequest(toPostInboxRequest(testCreate))
+		// Run the test
+		handled, err := a.PostSharedInbox(ctx, resp, req)
+		// Verify results
+		assertNotEqual(t, err, nil)
+		assertEqual(t, handled, true)
+	})
 }
@@ -0,0 +1,126 @@
+package pub
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-fed/activity/streams/vocab"
+)
+
+const (
+	cacheControlHeader = "Cache-Control"
+	etagHeader         = "ETag"
+	ifNoneMatchHeader  = "If-None-Match"
+	varyHeader         = "Vary"
+	// privateVary lists the request headers that identify the requester of
+	// a private value.
+	privateVary = "Authorization, Signature"
+)
+
+// CachePolicy determines whether clients may cache the ActivityStreams values
+// served by an Actor's GetInbox and GetOutbox, and by a HandlerFunc.
+//
+// When a value has an entity tag, a request whose If-None-Match header matches
+// it is responded to with 304 Not Modified and no body.
+//
+// It may be set with WithCachePolicy and WithHandlerCachePolicy, or be
+// implemented by a DelegateActor. Without one, nothing is cached. Since the
+// contents of an inbox or outbox depend on the requester, their responses are
+// only cached privately, and vary by the Authorization and Signature headers.
+type CachePolicy interface {
+	// CacheControl returns how long clients may cache the value, and its
+	// entity tag, such as one derived from the version or 'totalItems' of
+	// a collection.
+	//
+	// A zero maxAge requires clients to revalidate the value before every
+	// use, and an empty etag omits the ETag header. The etag is quoted and
+	// may be weak, such as `"v2"` or `W/"v2"`.
+	//
+	CacheControl(c context.Context, t vocab.Type) (maxAge time.Duration, etag string, err error)
+}
+
+// CollectionCachePolicy is a CachePolicy for collections and their pages.
+//
+// Each type may be cached for its MaxAge. The entity tag is weak and derived
+// from the 'id', 'totalItems', and the ids of the items of the value, so that
+// it changes whenever an item is added or removed. Values of other types are
+// not cached.
+type CollectionCachePolicy struct {
+	// MaxAge is how long each type of value may be cached, by its type
+	// name such as "OrderedCollection" or "OrderedCollectionPage".
+	MaxAge map[string]time.Duration
+}
+
+var _ CachePolicy = CollectionCachePolicy{}
+
+// CacheControl returns the MaxAge of the type of the value, and its entity
+// tag.
+func (p CollectionCachePolicy) CacheControl(c context.Context, t vocab.Type) (maxAge time.Duration, etag string, err error) {
+	maxAge, ok := p.MaxAge[t.GetTypeName()]
+	if !ok {
+		return 0, "", nil
+	}
+	items, _, _ := collectionItems(t)
+	h := sha256.New()
+	if id, err := GetId(t); err == nil {
+		fmt.Fprintln(h, id)
+	}
+	if ti, ok := t.(totalItemser); ok {
+		if p := ti.GetActivityStreamsTotalItems(); p != nil && p.IsXMLSchemaNonNegativeInteger() {
+			fmt.Fprintln(h, p.Get())
+		}
+	}
+	for _, item := range items {
+		if id, err := ToId(item); err == nil {
+			fmt.Fprintln(h, id)
+		}
+	}
+	return maxAge, fmt.Sprintf(`W/"%x"`, h.Sum(nil)[:16]), nil
+}
+
+// addCacheHeaders sets the Cache-Control and ETag headers the CachePolicy
+// determines for the value, and returns whether the request already has the
+// current version of it.
+//
+// A private value depends on the requester, so shared caches must not store
+// it and private ones must not reuse it for other credentials.
+func addCacheHeaders(c context.Context, w http.ResponseWriter, r *http.Request, p CachePolicy, t vocab.Type, private bool) (notModified bool, err error) {
+	maxAge, etag, err := p.CacheControl(c, t)
+	if err != nil {
+		return false, err
+	}
+	var directive string
+	if maxAge > 0 {
+		directive = fmt.Sprintf("max-age=%d", int64(maxAge/time.Second))
+	} else if len(etag) > 0 {
+		directive = "no-cache"
+	}
+	if len(directive) > 0 && private {
+		w.Header().Set(cacheControlHeader, "private, "+directive)
+		w.Header().Add(varyHeader, privateVary)
+	} else if len(directive) > 0 {
+		w.Header().Set(cacheControlHeader, directive)
+	}
+	if len(etag) == 0 {
+		return false, nil
+	}
+	w.Header().Set(etagHeader, etag)
+	return etagMatches(r.Header.Get(ifNoneMatchHeader), etag), nil
+}
+
+// etagMatches determines whether the If-None-Match header matches the entity
+// tag, using the weak comparison required by RFC 7232.
+func etagMatches(ifNoneMatch, etag string) bool {
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
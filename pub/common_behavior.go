@@ -87,3 +87,23 @@ type CommonBehavior interface {
 	// garbage collected.
 	NewTransport(c context.Context, actorBoxIRI *url.URL, gofedAgent string) (t Transport, err error)
 }
+
+// ActorResolver may optionally be implemented by a CommonBehavior or a
+// DelegateActor to serve many local actors, such as users or group and service
+// actors sharing an inbox, from a single Actor.
+//
+// When implemented, ActorForRequest is called for every ActivityPub request to
+// an inbox or outbox, before authentication. The resolved actor is then used by
+// the library's side effects, such as determining ownership, inbox forwarding,
+// and responding to Follows, instead of calling the Database's ActorForInbox
+// or ActorForOutbox.
+type ActorResolver interface {
+	// ActorForRequest returns the local actor targeted by the request, for
+	// example based on its path.
+	//
+	// Returning a nil actorIRI and nil error falls back on the Database's
+	// ActorForInbox and ActorForOutbox. If an error is returned, it is
+	// passed back to the caller of the Actor's method, and the
+	// implementation must not write a response to the ResponseWriter.
+	ActorForRequest(c context.Context, r *http.Request) (actorIRI *url.URL, err error)
+}
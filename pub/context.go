@@ -0,0 +1,138 @@
+package pub
+
+import (
+	"context"
+	"net/url"
+)
+
+// contextKey is the type of the keys of values the library sets on the
+// context.
+type contextKey int
+
+const (
+	// requestActorKey is the key of the local actor targeted by a request,
+	// as resolved by an ActorResolver.
+	requestActorKey contextKey = iota
+	// requestURLKey is the key of the IRI of the inbox or outbox a
+	// request was made to.
+	requestURLKey
+	// activityKey is the key of the activity being handled.
+	activityKey
+	// authenticatedActorKey is the key of the actor the application
+	// authenticated as having made the request.
+	authenticatedActorKey
+	// localOnlyKey is the key of whether an activity is only meant for this
+	// server.
+	localOnlyKey
+)
+
+// withRequestActor returns a context with the local actor targeted by the
+// request.
+func withRequestActor(c context.Context, actorIRI *url.URL) context.Context {
+	return context.WithValue(c, requestActorKey, actorIRI)
+}
+
+// requestActor returns the local actor targeted by the request, or nil if no
+// ActorResolver resolved one.
+func requestActor(c context.Context) *url.URL {
+	actorIRI, _ := c.Value(requestActorKey).(*url.URL)
+	return actorIRI
+}
+
+// actorForInbox returns the local actor targeted by the request if resolved
+// by an ActorResolver, otherwise it asks the Database for the actor of the
+// inbox.
+func actorForInbox(c context.Context, db Database, inboxIRI *url.URL) (*url.URL, error) {
+	if actorIRI := requestActor(c); actorIRI != nil {
+		return actorIRI, nil
+	}
+	return db.ActorForInbox(c, inboxIRI)
+}
+
+// actorForOutbox returns the local actor targeted by the request if resolved
+// by an ActorResolver, otherwise it asks the Database for the actor of the
+// outbox.
+func actorForOutbox(c context.Context, db Database, outboxIRI *url.URL) (*url.URL, error) {
+	if actorIRI := requestActor(c); actorIRI != nil {
+		return actorIRI, nil
+	}
+	return db.ActorForOutbox(c, outboxIRI)
+}
+
+// withRequestURL returns a context with the IRI of the inbox or outbox the
+// request was made to.
+func withRequestURL(c context.Context, u *url.URL) context.Context {
+	return context.WithValue(c, requestURLKey, u)
+}
+
+// withActivity returns a context with the activity being handled.
+func withActivity(c context.Context, activity Activity) context.Context {
+	return context.WithValue(c, activityKey, activity)
+}
+
+// RequestURLFromContext returns the IRI of the inbox or outbox that a POST
+// request was made to, built with the scheme given to PostInboxScheme or
+// PostOutboxScheme.
+//
+// It is present in every call made by the Actor while handling a POST request
+// to an inbox or outbox, beginning with the Authenticate method of the
+// protocol. It is not present when handling GET requests, nor in calls made
+// by Send.
+func RequestURLFromContext(c context.Context) (*url.URL, bool) {
+	u, ok := c.Value(requestURLKey).(*url.URL)
+	return u, ok
+}
+
+// ActivityFromContext returns the activity being handled by the Actor.
+//
+// When handling a POST request to an inbox, it is the received activity. It
+// is present beginning with the PostInboxRequestBodyHook, including in
+// AuthorizePostInbox, the callbacks, and the inbox forwarding.
+//
+// When handling a POST request to an outbox or when calling Send, it is the
+// activity to be delivered, after any wrapping in a Create. It is present
+// beginning with AddNewIDs, including in the callbacks of the SocialProtocol
+// and during delivery. It is not present in PostOutboxRequestBodyHook nor in
+// WrapInCreate, as the value may not yet be an activity.
+func ActivityFromContext(c context.Context) (Activity, bool) {
+	activity, ok := c.Value(activityKey).(Activity)
+	return activity, ok
+}
+
+// WithAuthenticatedActor returns a context with the actor that was
+// authenticated as having made the request.
+//
+// The library cannot know who made a request, so applications call it in
+// AuthenticatePostInbox, AuthenticateGetInbox, AuthenticatePostOutbox, or
+// AuthenticateGetOutbox and return the resulting context. The Actor then
+// passes it to every later call made while handling the request.
+func WithAuthenticatedActor(c context.Context, actorIRI *url.URL) context.Context {
+	return context.WithValue(c, authenticatedActorKey, actorIRI)
+}
+
+// AuthenticatedActorFromContext returns the actor set by WithAuthenticatedActor
+// while authenticating the request.
+//
+// It is present in every call made after the Authenticate method of the
+// protocol returned, if the application set it there.
+func AuthenticatedActorFromContext(c context.Context) (*url.URL, bool) {
+	actorIRI, ok := c.Value(authenticatedActorKey).(*url.URL)
+	return actorIRI, ok
+}
+
+// WithLocalOnly returns a context marking the activity being sent as only
+// meant for this server, such as a private note or an activity addressed to
+// local actors by other means.
+//
+// When given to Send or PostOutbox, the activity still goes through the
+// outbox and its side effects, but is not delivered to any peer, and its
+// addressing is not required.
+func WithLocalOnly(c context.Context) context.Context {
+	return context.WithValue(c, localOnlyKey, true)
+}
+
+// isLocalOnly determines whether the activity was marked with WithLocalOnly.
+func isLocalOnly(c context.Context) bool {
+	localOnly, _ := c.Value(localOnlyKey).(bool)
+	return localOnly
+}
@@ -72,6 +72,12 @@ type Database interface {
 	Exists(c context.Context, id *url.URL) (exists bool, err error)
 	// Get returns the database entry for the specified id.
 	//
+	// When serving requests with the HandlerFunc, entries that never
+	// existed must be returned as a nil value and nil error, which results
+	// in ErrNotFound so the caller can respond with 404 Not Found. Entries
+	// that were deleted should be returned as a Tombstone, which is served
+	// with 410 Gone.
+	//
 	// The library makes this call only after acquiring a lock first.
 	Get(c context.Context, id *url.URL) (value vocab.Type, err error)
 	// Create adds a new entry to the database which must be able to be
@@ -119,8 +125,15 @@ type Database interface {
 	// implementation does not need to set the 'id' property and simply
 	// needs to determine the value.
 	//
+	// It is called for every activity and every wrapped object that needs
+	// a new id, before the activity is stored or delivered. The type is
+	// provided so the implementation is free to choose its own IRI scheme,
+	// such as a different path for Notes than for Create activities.
+	//
 	// The go-fed library will handle setting the 'id' property on the
-	// activity or object provided with the value returned.
+	// activity or object provided with the value returned. If the returned
+	// id already exists in the database, the library returns an error
+	// instead of overwriting the existing entry.
 	NewID(c context.Context, t vocab.Type) (id *url.URL, err error)
 	// Followers obtains the Followers Collection for an actor with the
 	// given id.
@@ -144,3 +157,76 @@ type Database interface {
 	// The library makes this call only after acquiring a lock first.
 	Liked(c context.Context, actorIRI *url.URL) (liked vocab.ActivityStreamsCollection, err error)
 }
+
+// CollectionAppender may optionally be implemented by a Database to add items
+// to an inbox or outbox without the library reading, modifying, and saving the
+// entire OrderedCollection. This allows implementations to back it with an
+// atomic database operation.
+//
+// When the Database implements CollectionAppender, the library calls
+// AppendToCollection instead of GetInbox and SetInbox, or GetOutbox and
+// SetOutbox.
+type CollectionAppender interface {
+	// AppendToCollection adds the itemID as the newest item of the
+	// OrderedCollection at collectionID. Since inboxes and outboxes are
+	// ordered in reverse chronological order, the item must be returned
+	// before all items added previously.
+	//
+	// It must be atomic: concurrent calls for the same collection must
+	// never lose an item, even if the implementation does not rely on the
+	// library's lock. The item must not be added as an independent
+	// database entry. Separate calls to Create will do that.
+	//
+	// The library makes this call only after acquiring a lock on the
+	// collectionID first. For inboxes, InboxContains is called before to
+	// ensure the item is not already present.
+	AppendToCollection(c context.Context, collectionID, itemID *url.URL) error
+}
+
+// CollectionChecker may optionally be implemented by a Database to determine
+// whether a collection contains an item without the library reading the
+// entire collection. This allows implementations to back it with an indexed
+// query, such as when checking whether an actor is a follower.
+//
+// When the Database implements CollectionChecker, InCollection and
+// IsVisibleTo use it instead of Get.
+type CollectionChecker interface {
+	// InCollection returns true if the Collection or OrderedCollection at
+	// collection contains the item.
+	//
+	// The library makes this call only after acquiring a lock on the
+	// collection first.
+	InCollection(c context.Context, collection, item *url.URL) (bool, error)
+}
+
+// FollowState is the state of a Follow sent by a local actor that the
+// followed peer responded to without a final Accept or Reject.
+type FollowState int
+
+const (
+	// FollowTentativelyAccepted is the state of a Follow the peer
+	// responded to with a TentativeAccept.
+	FollowTentativelyAccepted FollowState = iota + 1
+	// FollowTentativelyRejected is the state of a Follow the peer
+	// responded to with a TentativeReject.
+	FollowTentativelyRejected
+)
+
+// FollowStateRecorder may optionally be implemented by a Database to record
+// the tentative responses of peers to the Follow requests sent by local
+// actors, such as to show a request as being reviewed.
+//
+// When the Database implements FollowStateRecorder, a TentativeAccept or
+// TentativeReject of such a Follow is verified as an Accept of it is, and its
+// state is recorded with SetFollowState. Nothing is added to the 'following'
+// collection until a final Accept is received.
+type FollowStateRecorder interface {
+	// SetFollowState records the state of the Follow with the followId,
+	// sent by a local actor, as responded to by the peers. The peers are
+	// the 'actor' of the response, and were each an 'object' of the
+	// Follow.
+	//
+	// The library makes this call only after acquiring a lock on the
+	// followId first.
+	SetFollowState(c context.Context, followId *url.URL, peers []*url.URL, state FollowState) error
+}
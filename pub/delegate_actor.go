@@ -125,7 +125,8 @@ type DelegateActor interface {
 	// to determine whether to do the forwarding algorithm.
 	//
 	// If the error is ErrObjectRequired or ErrTargetRequired, then a Bad
-	// Request status is sent in the response.
+	// Request status is sent in the response. If the error is
+	// ErrUnhandledActivity, then an Unprocessable Entity status is sent.
 	PostInbox(c context.Context, inboxIRI *url.URL, activity Activity) error
 	// InboxForwarding delegates inbox forwarding logic when a POST request
 	// is received in the Actor's inbox.
@@ -246,3 +247,40 @@ type DelegateActor interface {
 	// API is enabled.
 	GetInbox(c context.Context, r *http.Request) (vocab.ActivityStreamsOrderedCollectionPage, error)
 }
+
+// TransportCreator may optionally be implemented by a DelegateActor to create
+// the Transport with which a FederatingActor dereferences peer data outside of
+// a request, such as the pages and items of ProcessInboxCollection.
+//
+// The DelegateActor of NewActor and NewFederatingActor implements it with
+// CommonBehavior.NewTransport.
+type TransportCreator interface {
+	// NewTransport returns a new Transport on behalf of the actor with the
+	// inbox or outbox, as CommonBehavior.NewTransport does.
+	NewTransport(c context.Context, actorBoxIRI *url.URL, gofedAgent string) (t Transport, err error)
+}
+
+// DeliveryTargetsResolver may optionally be implemented by a DelegateActor to
+// determine the inboxes for FederatingActor.ResolveDeliveryTargets.
+//
+// The DelegateActor of NewActor and NewFederatingActor implements it.
+type DeliveryTargetsResolver interface {
+	// ResolveDeliveryTargets determines the inboxes the activity would be
+	// delivered to by Deliver, without delivering it.
+	//
+	// The provided url is the outbox of the sender.
+	ResolveDeliveryTargets(c context.Context, outbox *url.URL, activity Activity) ([]*url.URL, error)
+}
+
+// InboxDeliverer may optionally be implemented by a DelegateActor to send an
+// activity for FederatingActor.DeliverToInbox.
+//
+// The DelegateActor of NewActor and NewFederatingActor implements it.
+type InboxDeliverer interface {
+	// DeliverToInbox sends the activity to only the inbox, without
+	// resolving its recipients. Its hidden recipients must be stripped
+	// before it is sent.
+	//
+	// The provided url is the outbox of the sender.
+	DeliverToInbox(c context.Context, outbox, inbox *url.URL, activity Activity) error
+}
@@ -0,0 +1,90 @@
+package pub
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/go-fed/activity/streams/vocab"
+)
+
+// Endpoints are the IRIs in the 'endpoints' of an actor, which clients and
+// servers use to interact with it beyond its inbox and outbox. An IRI is nil
+// if the actor does not provide the endpoint.
+type Endpoints struct {
+	// SharedInbox receives the activities delivered to many actors of
+	// the server at once.
+	SharedInbox *url.URL
+	// OAuthAuthorizationEndpoint is where a user authorizes a client to
+	// act on their behalf with OAuth 2.0.
+	OAuthAuthorizationEndpoint *url.URL
+	// OAuthTokenEndpoint is where a client obtains an OAuth 2.0 access
+	// token.
+	OAuthTokenEndpoint *url.URL
+	// ProvideClientKey is where a user authorizes the public key of a
+	// client.
+	ProvideClientKey *url.URL
+	// SignClientKey is where the key of a client is signed by the key of
+	// the actor.
+	SignClientKey *url.URL
+	// UploadMedia is where a client uploads media.
+	UploadMedia *url.URL
+}
+
+// uriProperty is a functional property whose value is an IRI.
+type uriProperty interface {
+	IsXMLSchemaAnyURI() bool
+	Get() *url.URL
+	IsIRI() bool
+	GetIRI() *url.URL
+}
+
+// GetEndpoints returns the 'endpoints' of the actor. Returns an error if one of
+// them is not an absolute IRI, or if the 'endpoints' are only referenced by
+// IRI instead of being embedded in the actor.
+func GetEndpoints(actor vocab.Type) (e Endpoints, err error) {
+	ep, ok := actor.(endpointser)
+	if !ok {
+		return
+	}
+	p := ep.GetActivityStreamsEndpoints()
+	if p == nil {
+		return
+	} else if !p.IsActivityStreamsEndpoints() {
+		err = fmt.Errorf("endpoints of the actor are not embedded")
+		return
+	}
+	v := p.Get()
+	for _, f := range []struct {
+		name string
+		p    uriProperty
+		iri  **url.URL
+	}{
+		{"sharedInbox", v.GetActivityStreamsSharedInbox(), &e.SharedInbox},
+		{"oauthAuthorizationEndpoint", v.GetActivityStreamsOauthAuthorizationEndpoint(), &e.OAuthAuthorizationEndpoint},
+		{"oauthTokenEndpoint", v.GetActivityStreamsOauthTokenEndpoint(), &e.OAuthTokenEndpoint},
+		{"provideClientKey", v.GetActivityStreamsProvideClientKey(), &e.ProvideClientKey},
+		{"signClientKey", v.GetActivityStreamsSignClientKey(), &e.SignClientKey},
+		{"uploadMedia", v.GetActivityStreamsUploadMedia(), &e.UploadMedia},
+	} {
+		if *f.iri, err = endpointIRI(f.name, f.p); err != nil {
+			return Endpoints{}, err
+		}
+	}
+	return
+}
+
+// endpointIRI returns the IRI of the endpoint, ensuring it is absolute.
+func endpointIRI(name string, p uriProperty) (*url.URL, error) {
+	var u *url.URL
+	if p == nil {
+		return nil, nil
+	} else if p.IsXMLSchemaAnyURI() {
+		u = p.Get()
+	} else if p.IsIRI() {
+		u = p.GetIRI()
+	}
+	if u == nil || !u.IsAbs() {
+		return nil, fmt.Errorf("endpoint %s is not an absolute IRI: %v", name, u)
+	}
+	return u, nil
+}
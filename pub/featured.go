@@ -0,0 +1,96 @@
+package pub
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/go-fed/activity/streams/vocab"
+)
+
+// WalkCollection calls fn with each member of the Collection or
+// OrderedCollection, in order. Pages of the collection that are not embedded
+// are dereferenced with the Transport, and each page is visited at most once.
+//
+// Walking stops at the first error returned by fn or encountered while
+// dereferencing a page.
+func WalkCollection(c context.Context, t Transport, collection vocab.Type, fn func(item IdProperty) error) error {
+	items, pages, ok := collectionItems(collection)
+	if !ok {
+		return fmt.Errorf("cannot walk %s: it is neither a Collection nor an OrderedCollection", collection.GetTypeName())
+	}
+	seen := make(map[string]bool)
+	if id, err := GetId(collection); err == nil {
+		seen[id.String()] = true
+	}
+	for {
+		for _, item := range items {
+			if err := fn(item); err != nil {
+				return err
+			}
+		}
+		var next *url.URL
+		for len(pages) > 0 && next == nil {
+			if p := pages[0]; !seen[p.String()] {
+				seen[p.String()] = true
+				next = p
+			}
+			pages = pages[1:]
+		}
+		if next == nil {
+			return nil
+		}
+		page, err := dereferenceType(c, t, next)
+		if err != nil {
+			return err
+		}
+		var more []*url.URL
+		items, more, _ = collectionItems(page)
+		pages = append(more, pages...)
+	}
+}
+
+// Featured walks the 'featured' collection of the posts pinned by the actor,
+// calling fn with each of them. The collection is dereferenced with the
+// Transport when the actor only has its IRI.
+//
+// Nothing is walked if the actor has no 'featured' collection.
+func Featured(c context.Context, t Transport, actor vocab.Type, fn func(item IdProperty) error) error {
+	f, ok := actor.(featureder)
+	if !ok {
+		return nil
+	}
+	return walkActorCollection(c, t, f.GetTootFeatured(), fn)
+}
+
+// FeaturedTags walks the 'featuredTags' collection of the hashtags featured
+// by the actor, calling fn with each of them. The collection is dereferenced
+// with the Transport when the actor only has its IRI.
+//
+// Nothing is walked if the actor has no 'featuredTags' collection.
+func FeaturedTags(c context.Context, t Transport, actor vocab.Type, fn func(item IdProperty) error) error {
+	f, ok := actor.(featuredTagser)
+	if !ok {
+		return nil
+	}
+	return walkActorCollection(c, t, f.GetTootFeaturedTags(), fn)
+}
+
+// walkActorCollection walks the collection that is the value of an actor's
+// property, dereferencing it if it is an IRI.
+func walkActorCollection(c context.Context, t Transport, p IdProperty, fn func(item IdProperty) error) error {
+	if p == nil {
+		return nil
+	}
+	col := p.GetType()
+	if col == nil && p.IsIRI() {
+		var err error
+		if col, err = dereferenceType(c, t, p.GetIRI()); err != nil {
+			return err
+		}
+	}
+	if col == nil {
+		return nil
+	}
+	return WalkCollection(c, t, col, fn)
+}
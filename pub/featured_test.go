@@ -0,0 +1,157 @@
+package pub
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/go-fed/activity/streams"
+	"github.com/go-fed/activity/streams/vocab"
+	"github.com/golang/mock/gomock"
+)
+
+const (
+	testFeaturedActor      = "https://example.com/users/sally"
+	testFeaturedCollection = testFeaturedActor + "/collections/featured"
+	testFeaturedPage1      = testFeaturedCollection + "?page=1"
+	testFeaturedPage2      = testFeaturedCollection + "?page=2"
+	testFeaturedNote1      = testFeaturedActor + "/statuses/1"
+	testFeaturedNote2      = testFeaturedActor + "/statuses/2"
+	testFeaturedNote3      = testFeaturedActor + "/statuses/3"
+)
+
+// mustToTestType deserializes the JSON into a value.
+func mustToTestType(t *testing.T, s string) vocab.Type {
+	var m map[string]interface{}
+	if err := json.Unmarshal([]byte(s), &m); err != nil {
+		t.Fatal(err)
+	}
+	v, err := streams.ToType(context.Background(), m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+// collectIds returns a walking function appending the ids of the items.
+func collectIds(ids *[]string) func(IdProperty) error {
+	return func(item IdProperty) error {
+		id, err := ToId(item)
+		if err != nil {
+			return err
+		}
+		*ids = append(*ids, id.String())
+		return nil
+	}
+}
+
+func TestFeatured(t *testing.T) {
+	ctx := context.Background()
+	actorWithFeatured := func(featured string) vocab.Type {
+		return mustToTestType(t, fmt.Sprintf(`{
+  "@context": ["https://www.w3.org/ns/activitystreams", {"toot": "http://joinmastodon.org/ns#", "featured": {"@id": "toot:featured", "@type": "@id"}}],
+  "id": %q,
+  "type": "Person",
+  "featured": %s
+}`, testFeaturedActor, featured))
+	}
+	t.Run("WalksEmbeddedCollection", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		tp := NewMockTransport(ctl)
+		actor := actorWithFeatured(fmt.Sprintf(`{"id": %q, "type": "OrderedCollection", "orderedItems": [{"id": %q, "type": "Note"}, %q]}`,
+			testFeaturedCollection, testFeaturedNote1, testFeaturedNote2))
+		var ids []string
+		err := Featured(ctx, tp, actor, collectIds(&ids))
+		assertEqual(t, err, nil)
+		assertEqual(t, fmt.Sprint(ids), fmt.Sprint([]string{testFeaturedNote1, testFeaturedNote2}))
+	})
+	t.Run("DereferencesCollectionAndPages", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		tp := NewMockTransport(ctl)
+		actor := actorWithFeatured(fmt.Sprintf("%q", testFeaturedCollection))
+		tp.EXPECT().Dereference(ctx, mustParse(testFeaturedCollection)).Return([]byte(fmt.Sprintf(
+			`{"@context": "https://www.w3.org/ns/activitystreams", "id": %q, "type": "OrderedCollection", "totalItems": 3, "first": %q}`,
+			testFeaturedCollection, testFeaturedPage1)), nil)
+		tp.EXPECT().Dereference(ctx, mustParse(testFeaturedPage1)).Return([]byte(fmt.Sprintf(
+			`{"@context": "https://www.w3.org/ns/activitystreams", "id": %q, "type": "OrderedCollectionPage", "orderedItems": [%q, %q], "next": %q}`,
+			testFeaturedPage1, testFeaturedNote1, testFeaturedNote2, testFeaturedPage2)), nil)
+		tp.EXPECT().Dereference(ctx, mustParse(testFeaturedPage2)).Return([]byte(fmt.Sprintf(
+			`{"@context": "https://www.w3.org/ns/activitystreams", "id": %q, "type": "OrderedCollectionPage", "orderedItems": [%q], "next": %q}`,
+			testFeaturedPage2, testFeaturedNote3, testFeaturedPage1)), nil)
+		var ids []string
+		err := Featured(ctx, tp, actor, collectIds(&ids))
+		assertEqual(t, err, nil)
+		assertEqual(t, fmt.Sprint(ids), fmt.Sprint([]string{testFeaturedNote1, testFeaturedNote2, testFeaturedNote3}))
+	})
+	t.Run("StopsOnError", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		tp := NewMockTransport(ctl)
+		actor := actorWithFeatured(fmt.Sprintf(`{"type": "OrderedCollection", "orderedItems": [%q, %q]}`,
+			testFeaturedNote1, testFeaturedNote2))
+		calls := 0
+		err := Featured(ctx, tp, actor, func(IdProperty) error {
+			calls++
+			return fmt.Errorf("test error")
+		})
+		assertNotEqual(t, err, nil)
+		assertEqual(t, calls, 1)
+	})
+	t.Run("ErrorIfDereferenceFails", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		tp := NewMockTransport(ctl)
+		actor := actorWithFeatured(fmt.Sprintf("%q", testFeaturedCollection))
+		tp.EXPECT().Dereference(ctx, mustParse(testFeaturedCollection)).Return(nil, fmt.Errorf("test error"))
+		err := Featured(ctx, tp, actor, func(IdProperty) error { return nil })
+		assertNotEqual(t, err, nil)
+	})
+	t.Run("NothingIfNoFeatured", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		tp := NewMockTransport(ctl)
+		actor := streams.NewActivityStreamsPerson()
+		err := Featured(ctx, tp, actor, func(IdProperty) error {
+			t.Fatal("unexpected item")
+			return nil
+		})
+		assertEqual(t, err, nil)
+		err = Featured(ctx, tp, streams.NewActivityStreamsNote(), func(IdProperty) error {
+			t.Fatal("unexpected item")
+			return nil
+		})
+		assertEqual(t, err, nil)
+	})
+}
+
+func TestFeaturedTags(t *testing.T) {
+	ctx := context.Background()
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+	tp := NewMockTransport(ctl)
+	tags := testFeaturedActor + "/collections/tags"
+	actor := mustToTestType(t, fmt.Sprintf(`{
+  "@context": ["https://www.w3.org/ns/activitystreams", {"toot": "http://joinmastodon.org/ns#", "featuredTags": {"@id": "toot:featuredTags", "@type": "@id"}}],
+  "id": %q,
+  "type": "Person",
+  "featuredTags": %q
+}`, testFeaturedActor, tags))
+	tp.EXPECT().Dereference(ctx, mustParse(tags)).Return([]byte(fmt.Sprintf(
+		`{"@context": "https://www.w3.org/ns/activitystreams", "id": %q, "type": "Collection", "items": [{"id": "https://example.com/tags/go", "type": "Hashtag", "name": "#go"}]}`,
+		tags)), nil)
+	var ids []string
+	err := FeaturedTags(ctx, tp, actor, collectIds(&ids))
+	assertEqual(t, err, nil)
+	assertEqual(t, fmt.Sprint(ids), fmt.Sprint([]string{"https://example.com/tags/go"}))
+}
+
+func TestWalkCollectionErrorIfNotACollection(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+	tp := NewMockTransport(ctl)
+	err := WalkCollection(context.Background(), tp, streams.NewActivityStreamsNote(), func(IdProperty) error { return nil })
+	assertNotEqual(t, err, nil)
+}
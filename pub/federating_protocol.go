@@ -104,6 +104,17 @@ type FederatingProtocol interface {
 	//
 	// Zero or negative numbers indicate infinite recursion.
 	MaxDeliveryRecursionDepth(c context.Context) int
+	// MaxDeliveryRecipients determines the maximum number of inboxes an
+	// activity is delivered to. It bounds the breadth of a delivery, while
+	// MaxDeliveryRecursionDepth bounds its depth.
+	//
+	// It is applied to the inboxes the activity is delivered to once they
+	// are deduplicated, so delivering to sharedInbox endpoints can bring a
+	// large number of recipients under the limit. If the limit is
+	// exceeded, an error is returned before any delivery begins.
+	//
+	// Zero or negative numbers indicate no limit.
+	MaxDeliveryRecipients(c context.Context) int
 	// FilterForwarding allows the implementation to apply business logic
 	// such as blocks, spam filtering, and so on to a list of potential
 	// Collections and OrderedCollections of recipients when inbox
@@ -122,3 +133,124 @@ type FederatingProtocol interface {
 	// API is enabled.
 	GetInbox(c context.Context, r *http.Request) (vocab.ActivityStreamsOrderedCollectionPage, error)
 }
+
+// RawBodyHooker may optionally be implemented by a FederatingProtocol or a
+// DelegateActor to obtain the bytes of a federated request body exactly as
+// they were received.
+//
+// When implemented, PostInboxRawBodyHook is called immediately after
+// PostInboxRequestBodyHook.
+type RawBodyHooker interface {
+	// Hook callback after parsing the request body for a federated request
+	// to the Actor's inbox, providing the raw bytes of the body alongside
+	// the Activity parsed from them.
+	//
+	// The rawBody is the body as read from the request, before any
+	// parsing. It can be hashed or stored, for example to deduplicate
+	// payloads or to reproduce interoperability problems, without
+	// re-serializing the Activity which would alter the bytes. The
+	// implementation must not modify the rawBody.
+	//
+	// Only called if the Federated Protocol is enabled.
+	//
+	// Warning: Neither authentication nor authorization has taken place at
+	// this time. Doing anything beyond setting contextual information or
+	// recording the payload is strongly discouraged.
+	//
+	// If an error is returned, it is passed back to the caller of
+	// PostInbox. In this case, the implementation must not write a
+	// response to the ResponseWriter as is expected that the caller to
+	// PostInbox will do so when handling the error.
+	PostInboxRawBodyHook(c context.Context, r *http.Request, rawBody []byte, activity Activity) (context.Context, error)
+}
+
+// DeliveryPolicer may optionally be implemented by a FederatingProtocol to
+// apply a delivery policy to each kind of activity, such as not federating
+// Likes or only delivering Announces to followers.
+type DeliveryPolicer interface {
+	// DeliveryPolicy determines how the activity is delivered.
+	//
+	// If skip is true, the activity is not delivered to any peer. It has
+	// already been stored in the database and added to the outbox.
+	//
+	// Otherwise, a non-nil recipientsFilter is given the inboxes the
+	// activity would be delivered to, and returns those it is delivered
+	// to. It is applied before MaxDeliveryRecipients, and must not modify
+	// the given slice.
+	//
+	// It is applied likewise by ResolveDeliveryTargets, and by
+	// DeliverToInbox, which skips an inbox the filter does not return.
+	//
+	// Only called if the Federated Protocol is enabled.
+	//
+	// If an error is returned, the activity is not delivered and the error
+	// is passed back to the caller of Deliver, ResolveDeliveryTargets, or
+	// DeliverToInbox.
+	DeliveryPolicy(c context.Context, a Activity) (skip bool, recipientsFilter func([]*url.URL) []*url.URL, err error)
+}
+
+// InboxResolver may optionally be implemented by a FederatingProtocol to
+// reduce the number of deliveries of an activity, for example by delivering to
+// a sharedInbox instead of many personal inboxes.
+//
+// Without it, an activity is delivered to the personal inbox of each of its
+// recipients.
+type InboxResolver interface {
+	// ResolveInboxIRIs determines the inboxes an activity is delivered
+	// to, once the actors it is addressed to have been resolved.
+	//
+	// The receivers were addressed openly in 'to', 'cc', or 'audience'.
+	// The hiddenReceivers were addressed in 'bto' or 'bcc' and must only
+	// be delivered to their personal inboxes, otherwise they would be
+	// revealed to the other actors sharing an inbox.
+	//
+	// The returned inboxes are deduplicated by the library afterwards.
+	// The ResolveSharedInboxIRIs function is provided to opt into
+	// delivering to sharedInbox endpoints.
+	//
+	// Only called if the Federated Protocol is enabled.
+	ResolveInboxIRIs(c context.Context, t Transport, receivers, hiddenReceivers []Recipient) (inboxes []*url.URL, err error)
+}
+
+// SharedInboxResolver may optionally be implemented by a FederatingProtocol to
+// determine the local recipients of an activity received in the shared inbox
+// by PostSharedInbox.
+//
+// Without it, the local recipients are the actors owned by the Database that
+// the activity addresses directly in 'to', 'bto', 'cc', 'bcc', or
+// 'audience'. Local followers of a peer's followers collection are not found
+// this way, so applications delivering the posts of followed peers to their
+// followers must implement it.
+type SharedInboxResolver interface {
+	// LocalInboxes returns the inboxes of the local actors the activity
+	// received in the shared inbox is for.
+	//
+	// Only called if the Federated Protocol is enabled.
+	//
+	// If an error is returned, it is passed back to the caller of
+	// PostSharedInbox.
+	LocalInboxes(c context.Context, activity Activity) (inboxes []*url.URL, err error)
+}
+
+// LocalRecipientsChecker may optionally be implemented by a FederatingProtocol
+// to accept and drop, without side effects, activities received in an inbox
+// that are of no relevance to any local actor, such as those a relay or a
+// shared inbox delivers for remote actors only.
+//
+// Without it, every activity received is processed.
+type LocalRecipientsChecker interface {
+	// HasLocalRecipients returns false if the activity received in an
+	// inbox or in the shared inbox has no local recipients. It is called
+	// once the activity is authenticated and authorized, and should be
+	// cheap, as it runs for every activity received.
+	//
+	// If false is returned, neither the side effects nor the inbox
+	// forwarding are applied, and the peer is responded to with an OK
+	// status.
+	//
+	// Only called if the Federated Protocol is enabled.
+	//
+	// If an error is returned, it is passed back to the caller of
+	// PostInbox or PostSharedInbox.
+	HasLocalRecipients(c context.Context, activity Activity) (bool, error)
+}
@@ -2,7 +2,6 @@ package pub
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"github.com/go-fed/activity/streams"
 	"github.com/go-fed/activity/streams/vocab"
@@ -18,11 +17,18 @@ const (
 	// is received.
 	OnFollowDoNothing OnFollowBehavior = iota
 	// OnFollowAutomaticallyAccept triggers the side effect of sending an
-	// Accept of this Follow request in response.
+	// Accept of this Follow request in response. A duplicate Follow is
+	// accepted again without adding its actor to the followers twice.
 	OnFollowAutomaticallyAccept
 	// OnFollowAutomaticallyAccept triggers the side effect of sending a
 	// Reject of this Follow request in response.
 	OnFollowAutomaticallyReject
+	// OnFollowPendingApproval holds the Follow request pending, as done by
+	// actors with 'manuallyApprovesFollowers'. The Follow is created in the
+	// database if it is not already there, and no response is sent. It is
+	// up to the application to keep track of the pending requests, and to
+	// respond to them with ApproveFollow or DenyFollow.
+	OnFollowPendingApproval
 )
 
 // FederatingWrappedCallbacks lists the callback functions that already have
@@ -47,11 +53,36 @@ type FederatingWrappedCallbacks struct {
 	// Update calls Update on the federated entry from the database, with a
 	// new value.
 	Update func(context.Context, vocab.ActivityStreamsUpdate) error
+	// Keys is the KeyResolver verifying the HTTP Signatures of peers. If
+	// set, the wrapping function for Update also detects an Update of an
+	// actor in the database whose 'publicKey' changed, as a peer rotating
+	// its key does, and updates the keys cached by the KeyResolver with
+	// UpdateKeys. Otherwise, the new signatures of the actor fail to verify
+	// until its key is fetched again.
+	//
+	// Such an Update must be authenticated, with WithAuthenticatedActor in
+	// AuthenticatePostInbox, as sent by the actor itself, which verified
+	// its signature with the key cached before the rotation. Otherwise an
+	// error is returned and the actor is not updated.
+	Keys *KeyResolver
 	// Delete handles additional side effects for the Delete ActivityStreams
 	// type, specific to the application using go-fed.
 	//
 	// Delete removes the federated entry from the database.
 	Delete func(context.Context, vocab.ActivityStreamsDelete) error
+	// ActorDeleted handles a Delete whose 'object' is its own 'actor',
+	// which is how a peer announces the deletion of a whole account, so
+	// that the application can purge the content of the actor, remove it
+	// from followers and following collections, and forget its cached
+	// profile and keys.
+	//
+	// The wrapping function removes the actor's entry from the database,
+	// as for any other deleted object, and then calls ActorDeleted once for
+	// each deleted actor. If set, Delete is only called for the Delete if
+	// it also has other objects.
+	//
+	// If nil, these Delete activities are passed to Delete as before.
+	ActorDeleted func(c context.Context, a vocab.ActivityStreamsDelete, actor *url.URL) error
 	// Follow handles additional side effects for the Follow ActivityStreams
 	// type, specific to the application using go-fed.
 	//
@@ -68,6 +99,10 @@ type FederatingWrappedCallbacks struct {
 	// 'Follow'. If so, then the 'actor' is added to the original 'actor's
 	// 'following' collection.
 	//
+	// If EnableRelays is true and the 'Follow' was a subscription to a
+	// relay, its 'object' being the Public collection, then the relay is
+	// not added to the 'following' collection.
+	//
 	// Otherwise, no side effects are done by go-fed.
 	Accept func(context.Context, vocab.ActivityStreamsAccept) error
 	// Reject handles additional side effects for the Reject ActivityStreams
@@ -78,6 +113,23 @@ type FederatingWrappedCallbacks struct {
 	// forward with adding the 'actor' to the original 'actor's 'following'
 	// collection by the client application.
 	Reject func(context.Context, vocab.ActivityStreamsReject) error
+	// AcceptOther handles an Accept whose 'object' is not a Follow, such as
+	// the Accept of an Invite, Join, or Offer when responding to an event.
+	// It is called once for each such 'object', with its value, which is
+	// dereferenced if the Accept only had its IRI.
+	//
+	// The wrapping function provides no default side effects for these
+	// objects, and the Follow logic of Accept is never applied to them.
+	//
+	// If nil, these Accept activities are passed to Accept as before.
+	// Otherwise Accept is only called if the 'object' also has a Follow.
+	AcceptOther func(context.Context, vocab.ActivityStreamsAccept, vocab.Type) error
+	// RejectOther handles a Reject whose 'object' is not a Follow, the same
+	// as AcceptOther does for an Accept.
+	//
+	// If nil, these Reject activities are passed to Reject as before.
+	// Otherwise Reject is only called if the 'object' also has a Follow.
+	RejectOther func(context.Context, vocab.ActivityStreamsReject, vocab.Type) error
 	// Add handles additional side effects for the Add ActivityStreams
 	// type, specific to the application using go-fed.
 	//
@@ -98,6 +150,15 @@ type FederatingWrappedCallbacks struct {
 	// The wrapping function will add the activity to the "likes" collection
 	// on all 'object' targets owned by this server.
 	Like func(context.Context, vocab.ActivityStreamsLike) error
+	// EmojiReact handles additional side effects for a Like
+	// ActivityStreams type that carries an emoji reaction in its
+	// 'content', specific to the application using go-fed.
+	//
+	// The wrapping function is the same as for Like. If set, it is called
+	// instead of Like for Likes carrying an emoji reaction. Likes without
+	// an emoji, or whose emoji reaction is malformed, are still passed to
+	// Like.
+	EmojiReact func(context.Context, vocab.ActivityStreamsLike, EmojiReaction) error
 	// Announce handles additional side effects for the Announce
 	// ActivityStreams type, specific to the application using go-fed.
 	//
@@ -123,6 +184,105 @@ type FederatingWrappedCallbacks struct {
 	// received from a federated peer, as delivering Blocks explicitly
 	// deviates from the original ActivityPub specification.
 	Block func(context.Context, vocab.ActivityStreamsBlock) error
+	// TentativeAccept handles additional side effects for the
+	// TentativeAccept ActivityStreams type, specific to the application
+	// using go-fed.
+	//
+	// If the Database is a FollowStateRecorder, the wrapping function
+	// verifies a 'Follow' sent by the local actor in the 'object' as for
+	// 'Accept', and records it as tentatively accepted. Unlike an
+	// 'Accept', a tentative acceptance of a 'Follow' does not add the
+	// 'actor' to the original 'actor's 'following' collection. That only
+	// happens once a final 'Accept' is received.
+	//
+	// If nil and the Database is not a FollowStateRecorder,
+	// TentativeAccept activities are passed to the DefaultCallback as
+	// before.
+	TentativeAccept func(context.Context, vocab.ActivityStreamsTentativeAccept) error
+	// TentativeReject handles additional side effects for the
+	// TentativeReject ActivityStreams type, specific to the application
+	// using go-fed.
+	//
+	// If the Database is a FollowStateRecorder, the wrapping function
+	// verifies a 'Follow' sent by the local actor in the 'object' as for
+	// 'Accept', and records it as tentatively rejected.
+	//
+	// If nil and the Database is not a FollowStateRecorder,
+	// TentativeReject activities are passed to the DefaultCallback as
+	// before.
+	TentativeReject func(context.Context, vocab.ActivityStreamsTentativeReject) error
+	// Listen handles additional side effects for the Listen
+	// ActivityStreams type, specific to the application using go-fed.
+	//
+	// The wrapping function provides no default side effects.
+	//
+	// If nil, Listen activities are passed to the DefaultCallback as
+	// before.
+	Listen func(context.Context, vocab.ActivityStreamsListen) error
+	// View handles additional side effects for the View ActivityStreams
+	// type, specific to the application using go-fed.
+	//
+	// The wrapping function provides no default side effects.
+	//
+	// If nil, View activities are passed to the DefaultCallback as
+	// before.
+	View func(context.Context, vocab.ActivityStreamsView) error
+	// Read handles additional side effects for the Read ActivityStreams
+	// type, specific to the application using go-fed.
+	//
+	// The wrapping function provides no default side effects.
+	//
+	// If nil, Read activities are passed to the DefaultCallback as
+	// before.
+	Read func(context.Context, vocab.ActivityStreamsRead) error
+	// Move handles additional side effects for the Move ActivityStreams
+	// type, specific to the application using go-fed, which is how an
+	// account announces its migration to another actor.
+	//
+	// The wrapping function verifies the migration before calling Move.
+	// The 'actor' must be the 'object' being moved, the origin, and the
+	// 'target' is dereferenced, requiring the document served to have the
+	// target's 'id', to ensure it lists the origin in its 'alsoKnownAs'
+	// property. Otherwise an error is returned, so that an
+	// actor cannot claim to have become an arbitrary other actor. Moving
+	// the followers of the origin, such as by sending a Follow to the
+	// 'target' on behalf of each local follower, is left to Move.
+	//
+	// If nil, Move activities are passed to the DefaultCallback as
+	// before.
+	Move func(context.Context, vocab.ActivityStreamsMove) error
+	// EnableRelays enables support for ActivityPub relays. A server
+	// subscribes to a relay by sending a Follow whose 'object' is the
+	// Public collection to the relay's actor, and in return receives the
+	// activities of the whole relay wrapped in Announces.
+	//
+	// When enabled, the wrapping functions for Follow, Accept, and
+	// Announce apply relay semantics instead of their default behaviors
+	// as described by RelayFollow, Accept, and RelayAnnounce. IsRelay
+	// must be set.
+	EnableRelays bool
+	// IsRelay determines whether the actor is a relay this server is
+	// subscribed to. It is only used when EnableRelays is true.
+	IsRelay func(c context.Context, actorIRI *url.URL) (bool, error)
+	// RelayFollow handles additional side effects for a Follow whose
+	// 'object' is the Public collection, which is a peer subscribing to
+	// this server as a relay. It is only used when EnableRelays is true.
+	//
+	// The wrapping function provides no default side effects and ignores
+	// the OnFollow setting. If RelayFollow is nil, then Follow is called
+	// instead.
+	RelayFollow func(context.Context, vocab.ActivityStreamsFollow) error
+	// RelayAnnounce handles an Announce whose 'actor' is a relay, which is
+	// how relays distribute activities. It is only used when EnableRelays
+	// is true.
+	//
+	// The wrapping function does not treat the Announce as a boost, so it
+	// is not added to any "shares" collection and Announce is not called.
+	// Instead, each 'object' is dereferenced from its 'id', even if it is
+	// embedded, and must have that 'id'. Each is then passed to
+	// RelayAnnounce for the application to process as if it was received
+	// directly.
+	RelayAnnounce func(c context.Context, a vocab.ActivityStreamsAnnounce, relayed vocab.Type) error
 
 	// Sidechannel data -- this is set at request handling time. These must
 	// be set before the callbacks are used.
@@ -138,6 +298,15 @@ type FederatingWrappedCallbacks struct {
 	deliver func(c context.Context, outboxIRI *url.URL, activity Activity) error
 	// newTransport creates a new Transport.
 	newTransport func(c context.Context, actorBoxIRI *url.URL, gofedAgent string) (t Transport, err error)
+	// logger receives debug messages, if set.
+	logger Logger
+}
+
+// debug logs the message to the logger, if set.
+func (w FederatingWrappedCallbacks) debug(msg string, keysAndValues ...interface{}) {
+	if w.logger != nil {
+		w.logger.Debug(msg, keysAndValues...)
+	}
 }
 
 // callbacks returns the WrappedCallbacks members into a single interface slice
@@ -158,6 +327,13 @@ func (w FederatingWrappedCallbacks) callbacks(fns []interface{}) []interface{} {
 	enableAnnounce := true
 	enableUndo := true
 	enableBlock := true
+	_, recordsFollowState := w.db.(FollowStateRecorder)
+	enableTentativeAccept := w.TentativeAccept != nil || recordsFollowState
+	enableTentativeReject := w.TentativeReject != nil || recordsFollowState
+	enableListen := w.Listen != nil
+	enableView := w.View != nil
+	enableRead := w.Read != nil
+	enableMove := w.Move != nil
 	for _, fn := range fns {
 		switch fn.(type) {
 		default:
@@ -186,6 +362,18 @@ func (w FederatingWrappedCallbacks) callbacks(fns []interface{}) []interface{} {
 			enableUndo = false
 		case func(context.Context, vocab.ActivityStreamsBlock) error:
 			enableBlock = false
+		case func(context.Context, vocab.ActivityStreamsTentativeAccept) error:
+			enableTentativeAccept = false
+		case func(context.Context, vocab.ActivityStreamsTentativeReject) error:
+			enableTentativeReject = false
+		case func(context.Context, vocab.ActivityStreamsListen) error:
+			enableListen = false
+		case func(context.Context, vocab.ActivityStreamsView) error:
+			enableView = false
+		case func(context.Context, vocab.ActivityStreamsRead) error:
+			enableRead = false
+		case func(context.Context, vocab.ActivityStreamsMove) error:
+			enableMove = false
 		}
 	}
 	if enableCreate {
@@ -224,6 +412,24 @@ func (w FederatingWrappedCallbacks) callbacks(fns []interface{}) []interface{} {
 	if enableBlock {
 		fns = append(fns, w.block)
 	}
+	if enableTentativeAccept {
+		fns = append(fns, w.tentativeAccept)
+	}
+	if enableTentativeReject {
+		fns = append(fns, w.tentativeReject)
+	}
+	if enableListen {
+		fns = append(fns, w.listen)
+	}
+	if enableView {
+		fns = append(fns, w.view)
+	}
+	if enableRead {
+		fns = append(fns, w.read)
+	}
+	if enableMove {
+		fns = append(fns, w.move)
+	}
 	return fns
 }
 
@@ -248,7 +454,7 @@ func (w FederatingWrappedCallbacks) create(c context.Context, a vocab.ActivitySt
 				return err
 			}
 			var m map[string]interface{}
-			if err = json.Unmarshal(b, &m); err != nil {
+			if err = unmarshalJSON(b, &m); err != nil {
 				return err
 			}
 			t, err = streams.ToType(c, m)
@@ -308,9 +514,18 @@ func (w FederatingWrappedCallbacks) update(c context.Context, a vocab.ActivitySt
 			return err
 		}
 		defer w.db.Unlock(c, id)
+		var rotated []*url.URL
+		if w.Keys != nil {
+			if rotated, err = w.rotatedKeys(c, id, t); err != nil {
+				return err
+			}
+		}
 		if err := w.db.Update(c, t); err != nil {
 			return err
 		}
+		if len(rotated) > 0 {
+			return w.Keys.UpdateKeys(t, rotated)
+		}
 		return nil
 	}
 	for iter := op.Begin(); iter != op.End(); iter = iter.Next() {
@@ -324,6 +539,30 @@ func (w FederatingWrappedCallbacks) update(c context.Context, a vocab.ActivitySt
 	return nil
 }
 
+// rotatedKeys returns the ids of the keys changed by the Update of the value,
+// if it is an actor in the database, ensuring the Update was authenticated as
+// sent by the actor. The database must be locked for the id.
+func (w FederatingWrappedCallbacks) rotatedKeys(c context.Context, id *url.URL, t vocab.Type) ([]*url.URL, error) {
+	if _, ok := t.(publicKeyer); !ok {
+		return nil, nil
+	}
+	if exists, err := w.db.Exists(c, id); err != nil || !exists {
+		return nil, err
+	}
+	stored, err := w.db.Get(c, id)
+	if err != nil {
+		return nil, err
+	}
+	changed := ChangedPublicKeys(stored, t)
+	if len(changed) == 0 {
+		return nil, nil
+	}
+	if actor, ok := AuthenticatedActorFromContext(c); !ok || actor.String() != id.String() {
+		return nil, fmt.Errorf("update changing the keys of %s was not authenticated as sent by it", id)
+	}
+	return changed, nil
+}
+
 // deleteFn implements the federating Delete activity side effects.
 func (w FederatingWrappedCallbacks) deleteFn(c context.Context, a vocab.ActivityStreamsDelete) error {
 	op := a.GetActivityStreamsObject()
@@ -355,18 +594,72 @@ func (w FederatingWrappedCallbacks) deleteFn(c context.Context, a vocab.Activity
 			return err
 		}
 	}
+	if w.ActorDeleted != nil {
+		deleted, err := selfDeletedActors(a)
+		if err != nil {
+			return err
+		}
+		for _, actor := range deleted {
+			if err := w.ActorDeleted(c, a, actor); err != nil {
+				return err
+			}
+		}
+		if len(deleted) == op.Len() {
+			return nil
+		}
+	}
 	if w.Delete != nil {
 		return w.Delete(c, a)
 	}
 	return nil
 }
 
+// selfDeletedActors returns the objects of the Delete that are also its
+// actors, which are deleting themselves.
+func selfDeletedActors(a vocab.ActivityStreamsDelete) (deleted []*url.URL, err error) {
+	actors := a.GetActivityStreamsActor()
+	if actors == nil {
+		return nil, nil
+	}
+	isActor := make(map[string]bool, actors.Len())
+	for iter := actors.Begin(); iter != actors.End(); iter = iter.Next() {
+		id, err := ToId(iter)
+		if err != nil {
+			return nil, err
+		}
+		isActor[id.String()] = true
+	}
+	op := a.GetActivityStreamsObject()
+	for iter := op.Begin(); iter != op.End(); iter = iter.Next() {
+		id, err := ToId(iter)
+		if err != nil {
+			return nil, err
+		}
+		if isActor[id.String()] {
+			deleted = append(deleted, id)
+		}
+	}
+	return deleted, nil
+}
+
 // follow implements the federating Follow activity side effects.
 func (w FederatingWrappedCallbacks) follow(c context.Context, a vocab.ActivityStreamsFollow) error {
 	op := a.GetActivityStreamsObject()
 	if op == nil || op.Len() == 0 {
 		return ErrObjectRequired
 	}
+	if w.EnableRelays {
+		if isRelay, err := isRelayFollow(a); err != nil {
+			return err
+		} else if isRelay {
+			if w.RelayFollow != nil {
+				return w.RelayFollow(c, a)
+			} else if w.Follow != nil {
+				return w.Follow(c, a)
+			}
+			return nil
+		}
+	}
 	// Check that we own at least one of the 'object' properties, and ensure
 	// it is to the actor that owns this inbox.
 	//
@@ -376,7 +669,7 @@ func (w FederatingWrappedCallbacks) follow(c context.Context, a vocab.ActivitySt
 		return err
 	}
 	// WARNING: Unlock not deferred.
-	actorIRI, err := w.db.ActorForInbox(c, w.inboxIRI)
+	actorIRI, err := actorForInbox(c, w.db, w.inboxIRI)
 	if err != nil {
 		w.db.Unlock(c, w.inboxIRI)
 		return err
@@ -396,66 +689,32 @@ func (w FederatingWrappedCallbacks) follow(c context.Context, a vocab.ActivitySt
 			}
 		}
 	}
-	if isMe {
+	if isMe && w.OnFollow == OnFollowPendingApproval {
+		// Keep the Follow until the user approves or denies it.
+		if err := storeActivity(c, w.db, a); err != nil {
+			return err
+		}
+	} else if isMe {
 		// Prepare the response.
-		var response Activity
+		var accept bool
 		if w.OnFollow == OnFollowAutomaticallyAccept {
-			response = streams.NewActivityStreamsAccept()
-		} else if w.OnFollow == OnFollowAutomaticallyReject {
-			response = streams.NewActivityStreamsReject()
-		} else {
+			accept = true
+		} else if w.OnFollow != OnFollowAutomaticallyReject {
 			return fmt.Errorf("unknown OnFollowBehavior: %d", w.OnFollow)
 		}
-		// Set us as the 'actor'.
-		me := streams.NewActivityStreamsActorProperty()
-		response.SetActivityStreamsActor(me)
-		me.AppendIRI(actorIRI)
-		// Set the Follow as the 'object' property.
-		op := streams.NewActivityStreamsObjectProperty()
-		response.SetActivityStreamsObject(op)
-		op.AppendActivityStreamsFollow(a)
-		// Add all actors on the original Follow to the 'to' property.
-		recipients := make([]*url.URL, 0)
-		to := streams.NewActivityStreamsToProperty()
-		response.SetActivityStreamsTo(to)
-		followActors := a.GetActivityStreamsActor()
-		for iter := followActors.Begin(); iter != followActors.End(); iter = iter.Next() {
-			id, err := ToId(iter)
-			if err != nil {
-				return err
-			}
-			to.AppendIRI(id)
-			recipients = append(recipients, id)
+		response, recipients, err := followResponse(actorIRI, a, accept)
+		if err != nil {
+			return err
 		}
-		if w.OnFollow == OnFollowAutomaticallyAccept {
+		if accept {
 			// If automatically accepting, then also update our
 			// followers collection with the new actors.
 			//
 			// If automatically rejecting, do not update the
 			// followers collection.
-			if err := w.db.Lock(c, actorIRI); err != nil {
+			if err := addFollowers(c, w.db, actorIRI, recipients); err != nil {
 				return err
 			}
-			// WARNING: Unlock not deferred.
-			followers, err := w.db.Followers(c, actorIRI)
-			if err != nil {
-				w.db.Unlock(c, actorIRI)
-				return err
-			}
-			items := followers.GetActivityStreamsItems()
-			if items == nil {
-				items = streams.NewActivityStreamsItemsProperty()
-				followers.SetActivityStreamsItems(items)
-			}
-			for _, elem := range recipients {
-				items.PrependIRI(elem)
-			}
-			if err = w.db.Update(c, followers); err != nil {
-				w.db.Unlock(c, actorIRI)
-				return err
-			}
-			w.db.Unlock(c, actorIRI)
-			// Unlock must be called by now and every branch above.
 		}
 		// Lock without defer!
 		w.db.Lock(c, w.inboxIRI)
@@ -487,7 +746,7 @@ func (w FederatingWrappedCallbacks) accept(c context.Context, a vocab.ActivitySt
 			return err
 		}
 		// WARNING: Unlock not deferred.
-		actorIRI, err := w.db.ActorForInbox(c, w.inboxIRI)
+		actorIRI, err := actorForInbox(c, w.db, w.inboxIRI)
 		if err != nil {
 			w.db.Unlock(c, w.inboxIRI)
 			return err
@@ -499,31 +758,21 @@ func (w FederatingWrappedCallbacks) accept(c context.Context, a vocab.ActivitySt
 		//
 		// TODO: Handle Accept multiple Follow.
 		var maybeMyFollowIRI *url.URL
+		var hasFollow bool
+		var others []vocab.Type
 		for iter := op.Begin(); iter != op.End(); iter = iter.Next() {
-			t := iter.GetType()
-			if t == nil && iter.IsIRI() {
-				// Attempt to dereference the IRI instead
-				tport, err := w.newTransport(c, w.inboxIRI, goFedUserAgent())
-				if err != nil {
-					return err
-				}
-				b, err := tport.Dereference(c, iter.GetIRI())
-				if err != nil {
-					return err
-				}
-				var m map[string]interface{}
-				if err = json.Unmarshal(b, &m); err != nil {
-					return err
-				}
-				t, err = streams.ToType(c, m)
-				if err != nil {
-					return err
-				}
-			} else if t == nil {
-				return fmt.Errorf("cannot handle federated create: object is neither a value nor IRI")
+			t, err := w.objectType(c, iter)
+			if err != nil {
+				return err
 			}
-			// Ensure it is a Follow.
+			// Ensure it is a Follow, keeping other objects for
+			// AcceptOther.
 			if !streams.IsOrExtendsActivityStreamsFollow(t) {
+				others = append(others, t)
+				continue
+			}
+			hasFollow = true
+			if maybeMyFollowIRI != nil {
 				continue
 			}
 			follow, ok := t.(Activity)
@@ -535,19 +784,12 @@ func (w FederatingWrappedCallbacks) accept(c context.Context, a vocab.ActivitySt
 				return err
 			}
 			// Ensure that we are one of the actors on the Follow.
-			actors := follow.GetActivityStreamsActor()
-			for iter := actors.Begin(); iter != actors.End(); iter = iter.Next() {
-				id, err := ToId(iter)
-				if err != nil {
-					return err
-				}
-				if id.String() == actorIRI.String() {
-					maybeMyFollowIRI = followId
-					break
-				}
+			if hasActor(follow, actorIRI) {
+				maybeMyFollowIRI = followId
 			}
-			// Continue breaking if we found ourselves
-			if maybeMyFollowIRI != nil {
+			// Continue breaking if we found ourselves, unless the
+			// remaining objects are needed for AcceptOther.
+			if maybeMyFollowIRI != nil && w.AcceptOther == nil {
 				break
 			}
 		}
@@ -566,68 +808,33 @@ func (w FederatingWrappedCallbacks) accept(c context.Context, a vocab.ActivitySt
 			//
 			// Use an anonymous function to properly scope the
 			// database lock, immediately call it.
+			var isRelay bool
 			err = func() error {
 				if err := w.db.Lock(c, maybeMyFollowIRI); err != nil {
 					return err
 				}
 				defer w.db.Unlock(c, maybeMyFollowIRI)
-				t, err := w.db.Get(c, maybeMyFollowIRI)
+				follow, err := w.storedFollow(c, a, maybeMyFollowIRI, actorIRI)
 				if err != nil {
 					return err
 				}
-				if !streams.IsOrExtendsActivityStreamsFollow(t) {
-					return fmt.Errorf("peer gave an Accept wrapping a Follow but provided a non-Follow id")
-				}
-				follow, ok := t.(Activity)
-				if !ok {
-					return fmt.Errorf("a Follow in an Accept does not satisfy the Activity interface")
-				}
-				// Ensure that we are one of the actors on the Follow.
-				ok = false
-				actors := follow.GetActivityStreamsActor()
-				for iter := actors.Begin(); iter != actors.End(); iter = iter.Next() {
-					id, err := ToId(iter)
-					if err != nil {
-						return err
-					}
-					if id.String() == actorIRI.String() {
-						ok = true
-						break
-					}
-				}
-				if !ok {
-					return fmt.Errorf("peer gave an Accept wrapping a Follow but we are not the actor on that Follow")
-				}
-				// Build map of original Accept actors
-				acceptActors := make(map[string]bool)
-				for iter := activityActors.Begin(); iter != activityActors.End(); iter = iter.Next() {
-					id, err := ToId(iter)
-					if err != nil {
+				// A relay accepts a Follow of the Public collection
+				// instead of itself.
+				if w.EnableRelays {
+					if isRelay, err = isRelayFollow(follow); err != nil {
 						return err
+					} else if isRelay {
+						return nil
 					}
-					acceptActors[id.String()] = false
 				}
-				// Verify all actor(s) were on the original Follow.
-				followObj := follow.GetActivityStreamsObject()
-				for iter := followObj.Begin(); iter != followObj.End(); iter = iter.Next() {
-					id, err := ToId(iter)
-					if err != nil {
-						return err
-					}
-					if _, ok := acceptActors[id.String()]; ok {
-						acceptActors[id.String()] = true
-					}
-				}
-				for _, found := range acceptActors {
-					if !found {
-						return fmt.Errorf("peer gave an Accept wrapping a Follow but was not an object in the original Follow")
-					}
-				}
-				return nil
+				return checkFollowObjects(a, follow)
 			}()
 			if err != nil {
 				return err
 			}
+			if isRelay {
+				return w.acceptCallbacks(c, a, hasFollow, others)
+			}
 			// Add the peer to our following collection.
 			if err := w.db.Lock(c, actorIRI); err != nil {
 				return err
@@ -658,6 +865,85 @@ func (w FederatingWrappedCallbacks) accept(c context.Context, a vocab.ActivitySt
 			w.db.Unlock(c, actorIRI)
 			// Unlock must be called by now and every branch above.
 		}
+		return w.acceptCallbacks(c, a, hasFollow, others)
+	}
+	if w.Accept != nil {
+		return w.Accept(c, a)
+	}
+	return nil
+}
+
+// storedFollow obtains the Follow with the id from the database, verifying
+// that the peer responding to it did not fabricate it: it must be a Follow
+// whose 'actor' includes the local actor.
+//
+// The library must hold the lock on the followId.
+func (w FederatingWrappedCallbacks) storedFollow(c context.Context, response Activity, followId, actorIRI *url.URL) (Activity, error) {
+	name := response.GetTypeName()
+	t, err := w.db.Get(c, followId)
+	if err != nil {
+		return nil, err
+	}
+	if !streams.IsOrExtendsActivityStreamsFollow(t) {
+		return nil, fmt.Errorf("peer gave an %s wrapping a Follow but provided a non-Follow id", name)
+	}
+	follow, ok := t.(Activity)
+	if !ok {
+		return nil, fmt.Errorf("a Follow in an %s does not satisfy the Activity interface", name)
+	}
+	// Ensure that we are one of the actors on the Follow.
+	if !hasActor(follow, actorIRI) {
+		return nil, fmt.Errorf("peer gave an %s wrapping a Follow but we are not the actor on that Follow", name)
+	}
+	return follow, nil
+}
+
+// checkFollowObjects verifies that every 'actor' of the response to the
+// Follow was an 'object' of the original Follow.
+func checkFollowObjects(response, follow Activity) error {
+	// Build map of original response actors
+	responseActors := make(map[string]bool)
+	activityActors := response.GetActivityStreamsActor()
+	for iter := activityActors.Begin(); iter != activityActors.End(); iter = iter.Next() {
+		id, err := ToId(iter)
+		if err != nil {
+			return err
+		}
+		responseActors[id.String()] = false
+	}
+	// Verify all actor(s) were on the original Follow.
+	followObj := follow.GetActivityStreamsObject()
+	if followObj != nil {
+		for iter := followObj.Begin(); iter != followObj.End(); iter = iter.Next() {
+			id, err := ToId(iter)
+			if err != nil {
+				return err
+			}
+			if _, ok := responseActors[id.String()]; ok {
+				responseActors[id.String()] = true
+			}
+		}
+	}
+	for _, found := range responseActors {
+		if !found {
+			return fmt.Errorf("peer gave an %s wrapping a Follow but was not an object in the original Follow", response.GetTypeName())
+		}
+	}
+	return nil
+}
+
+// acceptCallbacks calls AcceptOther with each 'object' of the Accept that is
+// not a Follow, if set, then Accept unless AcceptOther handled every 'object'.
+func (w FederatingWrappedCallbacks) acceptCallbacks(c context.Context, a vocab.ActivityStreamsAccept, hasFollow bool, others []vocab.Type) error {
+	if w.AcceptOther != nil && len(others) > 0 {
+		for _, t := range others {
+			if err := w.AcceptOther(c, a, t); err != nil {
+				return err
+			}
+		}
+		if !hasFollow {
+			return nil
+		}
 	}
 	if w.Accept != nil {
 		return w.Accept(c, a)
@@ -667,12 +953,59 @@ func (w FederatingWrappedCallbacks) accept(c context.Context, a vocab.ActivitySt
 
 // reject implements the federating Reject activity side effects.
 func (w FederatingWrappedCallbacks) reject(c context.Context, a vocab.ActivityStreamsReject) error {
+	op := a.GetActivityStreamsObject()
+	if w.RejectOther != nil && op != nil && op.Len() > 0 {
+		var hasFollow bool
+		var others []vocab.Type
+		for iter := op.Begin(); iter != op.End(); iter = iter.Next() {
+			t, err := w.objectType(c, iter)
+			if err != nil {
+				return err
+			}
+			if streams.IsOrExtendsActivityStreamsFollow(t) {
+				hasFollow = true
+			} else {
+				others = append(others, t)
+			}
+		}
+		for _, t := range others {
+			if err := w.RejectOther(c, a, t); err != nil {
+				return err
+			}
+		}
+		if !hasFollow {
+			return nil
+		}
+	}
 	if w.Reject != nil {
 		return w.Reject(c, a)
 	}
 	return nil
 }
 
+// objectType returns the value of an 'object', dereferencing it if it is an
+// IRI.
+func (w FederatingWrappedCallbacks) objectType(c context.Context, iter vocab.ActivityStreamsObjectPropertyIterator) (vocab.Type, error) {
+	if t := iter.GetType(); t != nil {
+		return t, nil
+	} else if !iter.IsIRI() {
+		return nil, fmt.Errorf("object is neither a value nor IRI")
+	}
+	tport, err := w.newTransport(c, w.inboxIRI, goFedUserAgent())
+	if err != nil {
+		return nil, err
+	}
+	b, err := tport.Dereference(c, iter.GetIRI())
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]interface{}
+	if err = unmarshalJSON(b, &m); err != nil {
+		return nil, err
+	}
+	return streams.ToType(c, m)
+}
+
 // add implements the federating Add activity side effects.
 func (w FederatingWrappedCallbacks) add(c context.Context, a vocab.ActivityStreamsAdd) error {
 	op := a.GetActivityStreamsObject()
@@ -721,6 +1054,14 @@ func (w FederatingWrappedCallbacks) like(c context.Context, a vocab.ActivityStre
 	if err != nil {
 		return err
 	}
+	var reaction *EmojiReaction
+	if w.EmojiReact != nil {
+		// A malformed reaction does not prevent handling the Like.
+		if reaction, err = GetEmojiReaction(a); err != nil {
+			w.debug("handling emoji reaction as a plain like", "id", id, "error", err)
+			reaction = nil
+		}
+	}
 	// Create anonymous loop function to be able to properly scope the defer
 	// for the database lock at each iteration.
 	loopFn := func(iter vocab.ActivityStreamsObjectPropertyIterator) error {
@@ -789,7 +1130,9 @@ func (w FederatingWrappedCallbacks) like(c context.Context, a vocab.ActivityStre
 			return err
 		}
 	}
-	if w.Like != nil {
+	if reaction != nil {
+		return w.EmojiReact(c, a, *reaction)
+	} else if w.Like != nil {
 		return w.Like(c, a)
 	}
 	return nil
@@ -801,6 +1144,13 @@ func (w FederatingWrappedCallbacks) announce(c context.Context, a vocab.Activity
 	if err != nil {
 		return err
 	}
+	if w.EnableRelays {
+		if isRelay, err := w.isRelayAnnounce(c, a); err != nil {
+			return err
+		} else if isRelay {
+			return w.relayAnnounce(c, a)
+		}
+	}
 	op := a.GetActivityStreamsObject()
 	// Create anonymous loop function to be able to properly scope the defer
 	// for the database lock at each iteration.
@@ -878,6 +1228,83 @@ func (w FederatingWrappedCallbacks) announce(c context.Context, a vocab.Activity
 	return nil
 }
 
+// isRelayAnnounce determines whether an Announce was sent by a relay.
+func (w FederatingWrappedCallbacks) isRelayAnnounce(c context.Context, a vocab.ActivityStreamsAnnounce) (bool, error) {
+	if w.IsRelay == nil {
+		return false, fmt.Errorf("relays are enabled but IsRelay is nil")
+	}
+	actors := a.GetActivityStreamsActor()
+	if actors == nil || actors.Len() == 0 {
+		return false, nil
+	}
+	for iter := actors.Begin(); iter != actors.End(); iter = iter.Next() {
+		id, err := ToId(iter)
+		if err != nil {
+			return false, err
+		}
+		if isRelay, err := w.IsRelay(c, id); err != nil {
+			return false, err
+		} else if !isRelay {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// relayAnnounce unwraps the 'object' values of an Announce from a relay,
+// passing each to the RelayAnnounce callback.
+//
+// The relay vouches for none of them, so each is dereferenced from its own
+// 'id', and must have that 'id', even if the relay embedded it.
+func (w FederatingWrappedCallbacks) relayAnnounce(c context.Context, a vocab.ActivityStreamsAnnounce) error {
+	op := a.GetActivityStreamsObject()
+	if op == nil || op.Len() == 0 {
+		return ErrObjectRequired
+	}
+	var tport Transport
+	for iter := op.Begin(); iter != op.End(); iter = iter.Next() {
+		id, err := ToId(iter)
+		if err != nil {
+			return fmt.Errorf("cannot handle relayed announce: %s", err)
+		}
+		if tport == nil {
+			tport, err = w.newTransport(c, w.inboxIRI, goFedUserAgent())
+			if err != nil {
+				return err
+			}
+		}
+		t, err := dereferenceOwnType(c, tport, id)
+		if err != nil {
+			return err
+		}
+		if w.RelayAnnounce != nil {
+			if err := w.RelayAnnounce(c, a, t); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// isRelayFollow determines whether a Follow is a subscription to a relay,
+// which is when its 'object' is the Public collection.
+func isRelayFollow(follow Activity) (bool, error) {
+	op := follow.GetActivityStreamsObject()
+	if op == nil {
+		return false, nil
+	}
+	for iter := op.Begin(); iter != op.End(); iter = iter.Next() {
+		id, err := ToId(iter)
+		if err != nil {
+			return false, err
+		}
+		if IsPublicIRI(id) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 // undo implements the federating Undo activity side effects.
 func (w FederatingWrappedCallbacks) undo(c context.Context, a vocab.ActivityStreamsUndo) error {
 	op := a.GetActivityStreamsObject()
@@ -905,3 +1332,188 @@ func (w FederatingWrappedCallbacks) block(c context.Context, a vocab.ActivityStr
 	}
 	return nil
 }
+
+// tentativeAccept implements the federating TentativeAccept activity side
+// effects.
+func (w FederatingWrappedCallbacks) tentativeAccept(c context.Context, a vocab.ActivityStreamsTentativeAccept) error {
+	if err := w.recordFollowState(c, a, FollowTentativelyAccepted); err != nil {
+		return err
+	}
+	if w.TentativeAccept != nil {
+		return w.TentativeAccept(c, a)
+	}
+	return nil
+}
+
+// tentativeReject implements the federating TentativeReject activity side
+// effects.
+func (w FederatingWrappedCallbacks) tentativeReject(c context.Context, a vocab.ActivityStreamsTentativeReject) error {
+	if err := w.recordFollowState(c, a, FollowTentativelyRejected); err != nil {
+		return err
+	}
+	if w.TentativeReject != nil {
+		return w.TentativeReject(c, a)
+	}
+	return nil
+}
+
+// recordFollowState records the state of each Follow sent by the local actor
+// that is an 'object' of the tentative response, if the Database is a
+// FollowStateRecorder. Each Follow is verified as for an Accept.
+func (w FederatingWrappedCallbacks) recordFollowState(c context.Context, response Activity, state FollowState) error {
+	recorder, ok := w.db.(FollowStateRecorder)
+	op := response.GetActivityStreamsObject()
+	if !ok || op == nil || op.Len() == 0 {
+		return nil
+	}
+	// Get this actor's id.
+	if err := w.db.Lock(c, w.inboxIRI); err != nil {
+		return err
+	}
+	actorIRI, err := actorForInbox(c, w.db, w.inboxIRI)
+	w.db.Unlock(c, w.inboxIRI)
+	if err != nil {
+		return err
+	}
+	for iter := op.Begin(); iter != op.End(); iter = iter.Next() {
+		t, err := w.objectType(c, iter)
+		if err != nil {
+			return err
+		}
+		if !streams.IsOrExtendsActivityStreamsFollow(t) || !hasActor(t, actorIRI) {
+			continue
+		}
+		followId, err := GetId(t)
+		if err != nil {
+			return err
+		}
+		if err = w.recordFollowStateOf(c, recorder, response, followId, actorIRI, state); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recordFollowStateOf verifies the stored Follow with the id against the
+// response, and records its state.
+func (w FederatingWrappedCallbacks) recordFollowStateOf(c context.Context, recorder FollowStateRecorder, response Activity, followId, actorIRI *url.URL, state FollowState) error {
+	actors := response.GetActivityStreamsActor()
+	if actors == nil || actors.Len() == 0 {
+		return fmt.Errorf("an %s with a Follow has no actors", response.GetTypeName())
+	}
+	if err := w.db.Lock(c, followId); err != nil {
+		return err
+	}
+	defer w.db.Unlock(c, followId)
+	follow, err := w.storedFollow(c, response, followId, actorIRI)
+	if err != nil {
+		return err
+	} else if err = checkFollowObjects(response, follow); err != nil {
+		return err
+	}
+	var peers []*url.URL
+	for iter := actors.Begin(); iter != actors.End(); iter = iter.Next() {
+		id, err := ToId(iter)
+		if err != nil {
+			return err
+		}
+		peers = append(peers, id)
+	}
+	return recorder.SetFollowState(c, followId, peers, state)
+}
+
+// listen implements the federating Listen activity side effects.
+func (w FederatingWrappedCallbacks) listen(c context.Context, a vocab.ActivityStreamsListen) error {
+	if w.Listen != nil {
+		return w.Listen(c, a)
+	}
+	return nil
+}
+
+// view implements the federating View activity side effects.
+func (w FederatingWrappedCallbacks) view(c context.Context, a vocab.ActivityStreamsView) error {
+	if w.View != nil {
+		return w.View(c, a)
+	}
+	return nil
+}
+
+// read implements the federating Read activity side effects.
+func (w FederatingWrappedCallbacks) read(c context.Context, a vocab.ActivityStreamsRead) error {
+	if w.Read != nil {
+		return w.Read(c, a)
+	}
+	return nil
+}
+
+// move implements the federating Move activity side effects.
+func (w FederatingWrappedCallbacks) move(c context.Context, a vocab.ActivityStreamsMove) error {
+	op := a.GetActivityStreamsObject()
+	if op == nil || op.Len() == 0 {
+		return ErrObjectRequired
+	} else if op.Len() > 1 {
+		return fmt.Errorf("a Move must have exactly one object to move")
+	}
+	target := a.GetActivityStreamsTarget()
+	if target == nil || target.Len() == 0 {
+		return ErrTargetRequired
+	} else if target.Len() > 1 {
+		return fmt.Errorf("a Move must have exactly one target")
+	}
+	origin, err := ToId(op.At(0))
+	if err != nil {
+		return err
+	}
+	targetIRI, err := ToId(target.At(0))
+	if err != nil {
+		return err
+	}
+	// Only the origin may move itself.
+	actors := a.GetActivityStreamsActor()
+	if actors == nil || actors.Len() != 1 {
+		return fmt.Errorf("a Move must have exactly one actor")
+	} else if actor, err := ToId(actors.At(0)); err != nil {
+		return err
+	} else if actor.String() != origin.String() {
+		return fmt.Errorf("actor %s cannot move %s", actor, origin)
+	}
+	// Always dereference the target, as an embedded value is provided by
+	// the origin and not the target.
+	tport, err := w.newTransport(c, w.inboxIRI, goFedUserAgent())
+	if err != nil {
+		return err
+	}
+	t, err := dereferenceOwnType(c, tport, targetIRI)
+	if err != nil {
+		return err
+	}
+	if !isAlsoKnownAs(t, origin) {
+		return fmt.Errorf("move target %s does not list %s in alsoKnownAs", targetIRI, origin)
+	}
+	return w.Move(c, a)
+}
+
+// isAlsoKnownAs determines whether the actor lists the IRI in its
+// 'alsoKnownAs' property.
+func isAlsoKnownAs(actor vocab.Type, iri *url.URL) bool {
+	aker, ok := actor.(alsoKnownAser)
+	if !ok {
+		return false
+	}
+	aka := aker.GetActivityStreamsAlsoKnownAs()
+	if aka == nil {
+		return false
+	}
+	for iter := aka.Begin(); iter != aka.End(); iter = iter.Next() {
+		var u *url.URL
+		if iter.IsXMLSchemaAnyURI() {
+			u = iter.Get()
+		} else if iter.IsIRI() {
+			u = iter.GetIRI()
+		}
+		if u != nil && u.String() == iri.String() {
+			return true
+		}
+	}
+	return false
+}
@@ -2,8 +2,11 @@ package pub
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/rsa"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/go-fed/activity/streams"
 	"github.com/go-fed/activity/streams/vocab"
@@ -221,6 +224,83 @@ func TestFederatedCallbacks(t *testing.T) {
 			t.Fatalf("could not find overridden function")
 		}
 	})
+	t.Run("DoesNotWrapOptionalTypesWhenUnset", func(t *testing.T) {
+		var w FederatingWrappedCallbacks
+		for _, f := range w.callbacks(nil) {
+			switch f.(type) {
+			case func(context.Context, vocab.ActivityStreamsTentativeAccept) error,
+				func(context.Context, vocab.ActivityStreamsTentativeReject) error,
+				func(context.Context, vocab.ActivityStreamsListen) error,
+				func(context.Context, vocab.ActivityStreamsView) error,
+				func(context.Context, vocab.ActivityStreamsRead) error,
+				func(context.Context, vocab.ActivityStreamsMove) error:
+				t.Fatalf("unexpected wrapped function %T", f)
+			}
+		}
+	})
+	t.Run("WrapsOptionalTypesWhenSet", func(t *testing.T) {
+		called := make(map[string]bool)
+		var w FederatingWrappedCallbacks
+		w.TentativeAccept = func(context.Context, vocab.ActivityStreamsTentativeAccept) error {
+			called["TentativeAccept"] = true
+			return nil
+		}
+		w.TentativeReject = func(context.Context, vocab.ActivityStreamsTentativeReject) error {
+			called["TentativeReject"] = true
+			return nil
+		}
+		w.Listen = func(context.Context, vocab.ActivityStreamsListen) error {
+			called["Listen"] = true
+			return nil
+		}
+		w.View = func(context.Context, vocab.ActivityStreamsView) error {
+			called["View"] = true
+			return nil
+		}
+		w.Read = func(context.Context, vocab.ActivityStreamsRead) error {
+			called["Read"] = true
+			return nil
+		}
+		for _, f := range w.callbacks(nil) {
+			switch fn := f.(type) {
+			case func(context.Context, vocab.ActivityStreamsTentativeAccept) error:
+				fn(nil, streams.NewActivityStreamsTentativeAccept())
+			case func(context.Context, vocab.ActivityStreamsTentativeReject) error:
+				fn(nil, streams.NewActivityStreamsTentativeReject())
+			case func(context.Context, vocab.ActivityStreamsListen) error:
+				fn(nil, streams.NewActivityStreamsListen())
+			case func(context.Context, vocab.ActivityStreamsView) error:
+				fn(nil, streams.NewActivityStreamsView())
+			case func(context.Context, vocab.ActivityStreamsRead) error:
+				fn(nil, streams.NewActivityStreamsRead())
+			}
+		}
+		for _, name := range []string{"TentativeAccept", "TentativeReject", "Listen", "View", "Read"} {
+			if !called[name] {
+				t.Errorf("wrapped %s was not called", name)
+			}
+		}
+	})
+	t.Run("OverridesListen", func(t *testing.T) {
+		ok := false
+		o := func(context.Context, vocab.ActivityStreamsListen) error {
+			ok = true
+			return nil
+		}
+		var w FederatingWrappedCallbacks
+		w.Listen = func(context.Context, vocab.ActivityStreamsListen) error {
+			t.Fatalf("wrapped Listen called instead of the overriding function")
+			return nil
+		}
+		for _, f := range w.callbacks([]interface{}{o}) {
+			if fn, ok := f.(func(context.Context, vocab.ActivityStreamsListen) error); ok {
+				fn(nil, nil)
+			}
+		}
+		if !ok {
+			t.Fatalf("could not find overridden function")
+		}
+	})
 }
 
 func TestFederatedCreate(t *testing.T) {
@@ -427,6 +507,70 @@ func TestFederatedUpdate(t *testing.T) {
 			t.Fatalf("expected error, got none")
 		}
 	})
+	newActorUpdateFn := func(t *testing.T, k *rsa.PrivateKey) (vocab.ActivityStreamsUpdate, vocab.Type) {
+		actor := mustToTestType(t, string(newTestActorWithKey(t, k)))
+		u := streams.NewActivityStreamsUpdate()
+		id := streams.NewJSONLDIdProperty()
+		id.Set(mustParse(testKeyResolverActor + "/update/1"))
+		u.SetJSONLDId(id)
+		ap := streams.NewActivityStreamsActorProperty()
+		ap.AppendIRI(mustParse(testKeyResolverActor))
+		u.SetActivityStreamsActor(ap)
+		op := streams.NewActivityStreamsObjectProperty()
+		if err := op.AppendType(actor); err != nil {
+			t.Fatal(err)
+		}
+		u.SetActivityStreamsObject(op)
+		return u, actor
+	}
+	t.Run("UpdatesRotatedKeyOfActor", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		w, mockDB := setupFn(ctl)
+		oldKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		assertEqual(t, err, nil)
+		newKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		assertEqual(t, err, nil)
+		cl := NewMockClock(ctl)
+		cl.EXPECT().Now().Return(now()).AnyTimes()
+		tp := NewMockTransport(ctl)
+		tp.EXPECT().Dereference(ctx, mustParse(testKeyResolverActor)).Return(newTestActorWithKey(t, oldKey), nil)
+		w.Keys = NewKeyResolver(cl, time.Minute)
+		_, err = w.Keys.Resolve(ctx, tp, mustParse(testKeyResolverKeyId))
+		assertEqual(t, err, nil)
+		u, actor := newActorUpdateFn(t, newKey)
+		authCtx := WithAuthenticatedActor(ctx, mustParse(testKeyResolverActor))
+		mockDB.EXPECT().Lock(authCtx, mustParse(testKeyResolverActor))
+		mockDB.EXPECT().Exists(authCtx, mustParse(testKeyResolverActor)).Return(true, nil)
+		mockDB.EXPECT().Get(authCtx, mustParse(testKeyResolverActor)).Return(mustToTestType(t, string(newTestActorWithKey(t, oldKey))), nil)
+		mockDB.EXPECT().Update(authCtx, actor)
+		mockDB.EXPECT().Unlock(authCtx, mustParse(testKeyResolverActor))
+		err = w.update(authCtx, u)
+		assertEqual(t, err, nil)
+		key, err := w.Keys.Resolve(ctx, tp, mustParse(testKeyResolverKeyId))
+		assertEqual(t, err, nil)
+		if !isSameRSAKey(&newKey.PublicKey, key) {
+			t.Fatalf("did not update the rotated key")
+		}
+	})
+	t.Run("ErrorIfKeyRotationNotAuthenticatedByActor", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		w, mockDB := setupFn(ctl)
+		oldKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		assertEqual(t, err, nil)
+		newKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		assertEqual(t, err, nil)
+		w.Keys = NewKeyResolver(NewMockClock(ctl), time.Minute)
+		u, _ := newActorUpdateFn(t, newKey)
+		authCtx := WithAuthenticatedActor(ctx, mustParse(testFederatedActorIRI))
+		mockDB.EXPECT().Lock(authCtx, mustParse(testKeyResolverActor))
+		mockDB.EXPECT().Exists(authCtx, mustParse(testKeyResolverActor)).Return(true, nil)
+		mockDB.EXPECT().Get(authCtx, mustParse(testKeyResolverActor)).Return(mustToTestType(t, string(newTestActorWithKey(t, oldKey))), nil)
+		mockDB.EXPECT().Unlock(authCtx, mustParse(testKeyResolverActor))
+		err = w.update(authCtx, u)
+		assertNotEqual(t, err, nil)
+	})
 	t.Run("CallsCustomCallback", func(t *testing.T) {
 		ctl := gomock.NewController(t)
 		defer ctl.Finish()
@@ -552,6 +696,104 @@ func TestFederatedDelete(t *testing.T) {
 		assertEqual(t, ctx, gotc)
 		assertEqual(t, d, got)
 	})
+	const deletedActorIRI = "https://example.com/users/deleted"
+	newActorDeleteFn := func() vocab.ActivityStreamsDelete {
+		d := newDeleteFn()
+		actor := streams.NewActivityStreamsActorProperty()
+		actor.AppendIRI(mustParse(deletedActorIRI))
+		d.SetActivityStreamsActor(actor)
+		op := streams.NewActivityStreamsObjectProperty()
+		op.AppendIRI(mustParse(deletedActorIRI))
+		d.SetActivityStreamsObject(op)
+		return d
+	}
+	t.Run("CallsActorDeletedInsteadOfDelete", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		w, mockDB := setupFn(ctl)
+		mockDB.EXPECT().Lock(ctx, mustParse(deletedActorIRI))
+		mockDB.EXPECT().Delete(ctx, mustParse(deletedActorIRI))
+		mockDB.EXPECT().Unlock(ctx, mustParse(deletedActorIRI))
+		d := newActorDeleteFn()
+		var got []string
+		w.ActorDeleted = func(ctx context.Context, v vocab.ActivityStreamsDelete, actor *url.URL) error {
+			assertEqual(t, v, d)
+			got = append(got, actor.String())
+			return nil
+		}
+		w.Delete = func(ctx context.Context, v vocab.ActivityStreamsDelete) error {
+			t.Fatalf("Delete called for an actor deleting itself")
+			return nil
+		}
+		err := w.deleteFn(ctx, d)
+		assertEqual(t, err, nil)
+		assertEqual(t, len(got), 1)
+		assertEqual(t, got[0], deletedActorIRI)
+	})
+	t.Run("CallsDeleteForOtherObjectsOfActorDelete", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		w, mockDB := setupFn(ctl)
+		mockDB.EXPECT().Lock(ctx, mustParse(deletedActorIRI))
+		mockDB.EXPECT().Delete(ctx, mustParse(deletedActorIRI))
+		mockDB.EXPECT().Unlock(ctx, mustParse(deletedActorIRI))
+		mockDB.EXPECT().Lock(ctx, mustParse(testNoteId1))
+		mockDB.EXPECT().Delete(ctx, mustParse(testNoteId1))
+		mockDB.EXPECT().Unlock(ctx, mustParse(testNoteId1))
+		d := newActorDeleteFn()
+		d.GetActivityStreamsObject().AppendIRI(mustParse(testNoteId1))
+		actorDeleted, deleted := 0, 0
+		w.ActorDeleted = func(ctx context.Context, v vocab.ActivityStreamsDelete, actor *url.URL) error {
+			actorDeleted++
+			return nil
+		}
+		w.Delete = func(ctx context.Context, v vocab.ActivityStreamsDelete) error {
+			deleted++
+			return nil
+		}
+		err := w.deleteFn(ctx, d)
+		assertEqual(t, err, nil)
+		assertEqual(t, actorDeleted, 1)
+		assertEqual(t, deleted, 1)
+	})
+	t.Run("DoesNotCallActorDeletedForObject", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		w, mockDB := setupFn(ctl)
+		mockDB.EXPECT().Lock(ctx, mustParse(testNoteId1))
+		mockDB.EXPECT().Delete(ctx, mustParse(testNoteId1))
+		mockDB.EXPECT().Unlock(ctx, mustParse(testNoteId1))
+		d := newDeleteFn()
+		w.ActorDeleted = func(ctx context.Context, v vocab.ActivityStreamsDelete, actor *url.URL) error {
+			t.Fatalf("ActorDeleted called for a deleted object")
+			return nil
+		}
+		deleted := false
+		w.Delete = func(ctx context.Context, v vocab.ActivityStreamsDelete) error {
+			deleted = true
+			return nil
+		}
+		err := w.deleteFn(ctx, d)
+		assertEqual(t, err, nil)
+		assertEqual(t, deleted, true)
+	})
+	t.Run("PassesActorDeleteToDeleteWithoutActorDeleted", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		w, mockDB := setupFn(ctl)
+		mockDB.EXPECT().Lock(ctx, mustParse(deletedActorIRI))
+		mockDB.EXPECT().Delete(ctx, mustParse(deletedActorIRI))
+		mockDB.EXPECT().Unlock(ctx, mustParse(deletedActorIRI))
+		d := newActorDeleteFn()
+		deleted := false
+		w.Delete = func(ctx context.Context, v vocab.ActivityStreamsDelete) error {
+			deleted = true
+			return nil
+		}
+		err := w.deleteFn(ctx, d)
+		assertEqual(t, err, nil)
+		assertEqual(t, deleted, true)
+	})
 }
 
 func TestFederatedFollow(t *testing.T) {
@@ -608,6 +850,20 @@ func TestFederatedFollow(t *testing.T) {
 			t.Fatalf("got error %s", err)
 		}
 	})
+	t.Run("UsesActorResolvedForRequest", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		w, mockDB := setupFn(ctl)
+		w.OnFollow = OnFollowDoNothing
+		actorCtx := withRequestActor(ctx, mustParse(testFederatedActorIRI2))
+		mockDB.EXPECT().Lock(actorCtx, mustParse(testMyInboxIRI))
+		mockDB.EXPECT().Unlock(actorCtx, mustParse(testMyInboxIRI))
+		f := newFollowFn()
+		err := w.follow(actorCtx, f)
+		if err != nil {
+			t.Fatalf("got error %s", err)
+		}
+	})
 	t.Run("OnFollowAutomaticallyAcceptUpdatesFollowers", func(t *testing.T) {
 		ctl := gomock.NewController(t)
 		defer ctl.Finish()
@@ -677,6 +933,53 @@ func TestFederatedFollow(t *testing.T) {
 			t.Fatalf("got error %s", err)
 		}
 	})
+	t.Run("OnFollowAutomaticallyAcceptDuplicateFollowDeliversWithoutUpdatingFollowers", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		w, mockDB := setupFn(ctl)
+		w.OnFollow = OnFollowAutomaticallyAccept
+		w.addNewIds = func(c context.Context, activity Activity) error {
+			return nil
+		}
+		delivered := false
+		w.deliver = func(c context.Context, outboxIRI *url.URL, activity Activity) error {
+			delivered = true
+			accept, ok := activity.(vocab.ActivityStreamsAccept)
+			if !ok {
+				t.Fatalf("expected Accept, got %T", activity)
+			}
+			to := accept.GetActivityStreamsTo()
+			if to == nil || to.Len() != 1 || to.At(0).GetIRI().String() != testFederatedActorIRI {
+				t.Fatalf("expected Accept addressed to the follower")
+			}
+			return nil
+		}
+		followers := streams.NewActivityStreamsCollection()
+		items := streams.NewActivityStreamsItemsProperty()
+		items.AppendIRI(mustParse(testFederatedActorIRI))
+		followers.SetActivityStreamsItems(items)
+		mockDB.EXPECT().Lock(ctx, mustParse(testMyInboxIRI))
+		mockDB.EXPECT().ActorForInbox(ctx, mustParse(testMyInboxIRI)).Return(
+			mustParse(testFederatedActorIRI2), nil)
+		mockDB.EXPECT().Unlock(ctx, mustParse(testMyInboxIRI))
+		mockDB.EXPECT().Lock(ctx, mustParse(testFederatedActorIRI2))
+		mockDB.EXPECT().Followers(ctx, mustParse(testFederatedActorIRI2)).Return(
+			followers, nil)
+		mockDB.EXPECT().Unlock(ctx, mustParse(testFederatedActorIRI2))
+		mockDB.EXPECT().Lock(ctx, mustParse(testMyInboxIRI))
+		mockDB.EXPECT().OutboxForInbox(ctx, mustParse(testMyInboxIRI)).Return(
+			mustParse(testMyOutboxIRI), nil)
+		mockDB.EXPECT().Unlock(ctx, mustParse(testMyInboxIRI))
+		f := newFollowFn()
+		err := w.follow(ctx, f)
+		if err != nil {
+			t.Fatalf("got error %s", err)
+		} else if !delivered {
+			t.Fatalf("expected the Accept to be delivered")
+		} else if items.Len() != 1 {
+			t.Fatalf("expected the follower once, got %d items", items.Len())
+		}
+	})
 	t.Run("OnFollowAutomaticallyRejectDelivers", func(t *testing.T) {
 		ctl := gomock.NewController(t)
 		defer ctl.Finish()
@@ -705,6 +1008,35 @@ func TestFederatedFollow(t *testing.T) {
 			t.Fatalf("got error %s", err)
 		}
 	})
+	t.Run("OnFollowPendingApprovalStoresWithoutResponding", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		w, mockDB := setupFn(ctl)
+		w.OnFollow = OnFollowPendingApproval
+		w.deliver = func(c context.Context, outboxIRI *url.URL, activity Activity) error {
+			t.Fatalf("expected no response to be delivered, got %T", activity)
+			return nil
+		}
+		f := newFollowFn()
+		mockDB.EXPECT().Lock(ctx, mustParse(testMyInboxIRI))
+		mockDB.EXPECT().ActorForInbox(ctx, mustParse(testMyInboxIRI)).Return(
+			mustParse(testFederatedActorIRI2), nil)
+		mockDB.EXPECT().Unlock(ctx, mustParse(testMyInboxIRI))
+		mockDB.EXPECT().Lock(ctx, mustParse(testNewActivityIRI))
+		mockDB.EXPECT().Exists(ctx, mustParse(testNewActivityIRI)).Return(false, nil)
+		mockDB.EXPECT().Create(ctx, f)
+		mockDB.EXPECT().Unlock(ctx, mustParse(testNewActivityIRI))
+		var got vocab.ActivityStreamsFollow
+		w.Follow = func(ctx context.Context, v vocab.ActivityStreamsFollow) error {
+			got = v
+			return nil
+		}
+		err := w.follow(ctx, f)
+		if err != nil {
+			t.Fatalf("got error %s", err)
+		}
+		assertEqual(t, f, got)
+	})
 	t.Run("CallsCustomCallback", func(t *testing.T) {
 		ctl := gomock.NewController(t)
 		defer ctl.Finish()
@@ -729,6 +1061,54 @@ func TestFederatedFollow(t *testing.T) {
 		assertEqual(t, ctx, gotc)
 		assertEqual(t, f, got)
 	})
+	t.Run("RelayFollowCalledForFollowOfPublic", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		w, _ := setupFn(ctl)
+		w.EnableRelays = true
+		w.OnFollow = OnFollowAutomaticallyAccept
+		f := newFollowFn()
+		op := streams.NewActivityStreamsObjectProperty()
+		op.AppendIRI(mustParse(PublicActivityPubIRI))
+		f.SetActivityStreamsObject(op)
+		var gotc context.Context
+		var got vocab.ActivityStreamsFollow
+		w.RelayFollow = func(ctx context.Context, v vocab.ActivityStreamsFollow) error {
+			gotc = ctx
+			got = v
+			return nil
+		}
+		w.Follow = func(ctx context.Context, v vocab.ActivityStreamsFollow) error {
+			t.Fatalf("Follow called for a relay subscription")
+			return nil
+		}
+		err := w.follow(ctx, f)
+		if err != nil {
+			t.Fatalf("got error %s", err)
+		}
+		assertEqual(t, ctx, gotc)
+		assertEqual(t, f, got)
+	})
+	t.Run("FollowCalledForFollowOfPublicWithoutRelayFollow", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		w, _ := setupFn(ctl)
+		w.EnableRelays = true
+		f := newFollowFn()
+		op := streams.NewActivityStreamsObjectProperty()
+		op.AppendIRI(mustParse(PublicActivityPubIRI))
+		f.SetActivityStreamsObject(op)
+		called := false
+		w.Follow = func(ctx context.Context, v vocab.ActivityStreamsFollow) error {
+			called = true
+			return nil
+		}
+		err := w.follow(ctx, f)
+		if err != nil {
+			t.Fatalf("got error %s", err)
+		}
+		assertEqual(t, called, true)
+	})
 }
 
 func TestFederatedAccept(t *testing.T) {
@@ -843,79 +1223,443 @@ func TestFederatedAccept(t *testing.T) {
 		mockDB.EXPECT().Unlock(ctx, mustParse(testFederatedActivityIRI))
 		a := newAcceptFn()
 		err := w.accept(ctx, a)
-		if err == nil {
-			t.Fatalf("expected error, got none")
+		if err == nil {
+			t.Fatalf("expected error, got none")
+		}
+	})
+	t.Run("UpdatesFollowingCollection", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		w, mockDB, _ := setupFn(ctl)
+		followers := streams.NewActivityStreamsCollection()
+		expectFollowers := streams.NewActivityStreamsCollection()
+		expectItems := streams.NewActivityStreamsItemsProperty()
+		expectItems.AppendIRI(mustParse(testFederatedActorIRI))
+		expectFollowers.SetActivityStreamsItems(expectItems)
+		mockDB.EXPECT().Lock(ctx, mustParse(testMyInboxIRI))
+		mockDB.EXPECT().ActorForInbox(ctx, mustParse(testMyInboxIRI)).Return(
+			mustParse(testFederatedActorIRI2), nil)
+		mockDB.EXPECT().Unlock(ctx, mustParse(testMyInboxIRI))
+		mockDB.EXPECT().Lock(ctx, mustParse(testFederatedActivityIRI))
+		mockDB.EXPECT().Get(ctx, mustParse(testFederatedActivityIRI)).Return(
+			testFollow, nil)
+		mockDB.EXPECT().Unlock(ctx, mustParse(testFederatedActivityIRI))
+		mockDB.EXPECT().Lock(ctx, mustParse(testFederatedActorIRI2))
+		mockDB.EXPECT().Following(ctx, mustParse(testFederatedActorIRI2)).Return(
+			followers, nil)
+		mockDB.EXPECT().Update(ctx, expectFollowers)
+		mockDB.EXPECT().Unlock(ctx, mustParse(testFederatedActorIRI2))
+		a := newAcceptFn()
+		err := w.accept(ctx, a)
+		if err != nil {
+			t.Fatalf("got error %s", err)
+		}
+	})
+	t.Run("CallsCustomCallback", func(t *testing.T) {
+		a := newAcceptFn()
+		a.SetActivityStreamsObject(nil)
+		var w FederatingWrappedCallbacks
+		var gotc context.Context
+		var got vocab.ActivityStreamsAccept
+		w.Accept = func(ctx context.Context, v vocab.ActivityStreamsAccept) error {
+			gotc = ctx
+			got = v
+			return nil
+		}
+		err := w.accept(ctx, a)
+		if err != nil {
+			t.Fatalf("got error %s", err)
+		}
+		assertEqual(t, ctx, gotc)
+		assertEqual(t, a, got)
+	})
+	newInviteFn := func() vocab.ActivityStreamsInvite {
+		i := streams.NewActivityStreamsInvite()
+		id := streams.NewJSONLDIdProperty()
+		id.Set(mustParse(testFederatedActivityIRI))
+		i.SetJSONLDId(id)
+		op := streams.NewActivityStreamsObjectProperty()
+		op.AppendIRI(mustParse(testNoteId1))
+		i.SetActivityStreamsObject(op)
+		return i
+	}
+	t.Run("CallsAcceptOtherWithNonFollowObject", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		w, mockDB, _ := setupFn(ctl)
+		mockDB.EXPECT().Lock(ctx, mustParse(testMyInboxIRI))
+		mockDB.EXPECT().ActorForInbox(ctx, mustParse(testMyInboxIRI)).Return(
+			mustParse(testFederatedActorIRI2), nil)
+		mockDB.EXPECT().Unlock(ctx, mustParse(testMyInboxIRI))
+		var got vocab.Type
+		w.AcceptOther = func(ctx context.Context, v vocab.ActivityStreamsAccept, o vocab.Type) error {
+			got = o
+			return nil
+		}
+		acceptCalled := false
+		w.Accept = func(ctx context.Context, v vocab.ActivityStreamsAccept) error {
+			acceptCalled = true
+			return nil
+		}
+		invite := newInviteFn()
+		a := newAcceptFn()
+		op := streams.NewActivityStreamsObjectProperty()
+		op.AppendActivityStreamsInvite(invite)
+		a.SetActivityStreamsObject(op)
+		err := w.accept(ctx, a)
+		if err != nil {
+			t.Fatalf("got error %s", err)
+		}
+		assertEqual(t, got, vocab.Type(invite))
+		assertEqual(t, acceptCalled, false)
+	})
+	t.Run("CallsAcceptOtherWithDereferencedObject", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		w, mockDB, mockTp := setupFn(ctl)
+		mockDB.EXPECT().Lock(ctx, mustParse(testMyInboxIRI))
+		mockDB.EXPECT().ActorForInbox(ctx, mustParse(testMyInboxIRI)).Return(
+			mustParse(testFederatedActorIRI2), nil)
+		mockDB.EXPECT().Unlock(ctx, mustParse(testMyInboxIRI))
+		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActivityIRI)).Return(
+			mustSerializeToBytes(newInviteFn()), nil)
+		var got vocab.Type
+		w.AcceptOther = func(ctx context.Context, v vocab.ActivityStreamsAccept, o vocab.Type) error {
+			got = o
+			return nil
+		}
+		a := newAcceptFn()
+		op := streams.NewActivityStreamsObjectProperty()
+		op.AppendIRI(mustParse(testFederatedActivityIRI))
+		a.SetActivityStreamsObject(op)
+		err := w.accept(ctx, a)
+		if err != nil {
+			t.Fatalf("got error %s", err)
+		}
+		_, ok := got.(vocab.ActivityStreamsInvite)
+		assertEqual(t, ok, true)
+	})
+	t.Run("CallsAcceptOtherAndAcceptForMixedObjects", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		w, mockDB, _ := setupFn(ctl)
+		followers := streams.NewActivityStreamsCollection()
+		mockDB.EXPECT().Lock(ctx, mustParse(testMyInboxIRI))
+		mockDB.EXPECT().ActorForInbox(ctx, mustParse(testMyInboxIRI)).Return(
+			mustParse(testFederatedActorIRI2), nil)
+		mockDB.EXPECT().Unlock(ctx, mustParse(testMyInboxIRI))
+		mockDB.EXPECT().Lock(ctx, mustParse(testFederatedActivityIRI))
+		mockDB.EXPECT().Get(ctx, mustParse(testFederatedActivityIRI)).Return(
+			testFollow, nil)
+		mockDB.EXPECT().Unlock(ctx, mustParse(testFederatedActivityIRI))
+		mockDB.EXPECT().Lock(ctx, mustParse(testFederatedActorIRI2))
+		mockDB.EXPECT().Following(ctx, mustParse(testFederatedActorIRI2)).Return(
+			followers, nil)
+		mockDB.EXPECT().Update(ctx, followers)
+		mockDB.EXPECT().Unlock(ctx, mustParse(testFederatedActorIRI2))
+		otherCalls := 0
+		w.AcceptOther = func(ctx context.Context, v vocab.ActivityStreamsAccept, o vocab.Type) error {
+			otherCalls++
+			return nil
+		}
+		acceptCalled := false
+		w.Accept = func(ctx context.Context, v vocab.ActivityStreamsAccept) error {
+			acceptCalled = true
+			return nil
+		}
+		a := newAcceptFn()
+		a.GetActivityStreamsObject().AppendActivityStreamsInvite(newInviteFn())
+		err := w.accept(ctx, a)
+		if err != nil {
+			t.Fatalf("got error %s", err)
+		}
+		assertEqual(t, otherCalls, 1)
+		assertEqual(t, acceptCalled, true)
+	})
+	newRelayFollowFn := func() vocab.ActivityStreamsFollow {
+		f := streams.NewActivityStreamsFollow()
+		id := streams.NewJSONLDIdProperty()
+		id.Set(mustParse(testFederatedActivityIRI))
+		f.SetJSONLDId(id)
+		actor := streams.NewActivityStreamsActorProperty()
+		actor.AppendIRI(mustParse(testFederatedActorIRI2))
+		f.SetActivityStreamsActor(actor)
+		op := streams.NewActivityStreamsObjectProperty()
+		op.AppendIRI(mustParse(PublicActivityPubIRI))
+		f.SetActivityStreamsObject(op)
+		return f
+	}
+	t.Run("RelayAcceptDoesNotUpdateFollowingCollection", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		w, mockDB, _ := setupFn(ctl)
+		w.EnableRelays = true
+		relayFollow := newRelayFollowFn()
+		mockDB.EXPECT().Lock(ctx, mustParse(testMyInboxIRI))
+		mockDB.EXPECT().ActorForInbox(ctx, mustParse(testMyInboxIRI)).Return(
+			mustParse(testFederatedActorIRI2), nil)
+		mockDB.EXPECT().Unlock(ctx, mustParse(testMyInboxIRI))
+		mockDB.EXPECT().Lock(ctx, mustParse(testFederatedActivityIRI))
+		mockDB.EXPECT().Get(ctx, mustParse(testFederatedActivityIRI)).Return(
+			relayFollow, nil)
+		mockDB.EXPECT().Unlock(ctx, mustParse(testFederatedActivityIRI))
+		called := false
+		w.Accept = func(ctx context.Context, v vocab.ActivityStreamsAccept) error {
+			called = true
+			return nil
+		}
+		a := newAcceptFn()
+		op := streams.NewActivityStreamsObjectProperty()
+		op.AppendActivityStreamsFollow(relayFollow)
+		a.SetActivityStreamsObject(op)
+		err := w.accept(ctx, a)
+		if err != nil {
+			t.Fatalf("got error %s", err)
 		}
+		assertEqual(t, called, true)
 	})
-	t.Run("UpdatesFollowingCollection", func(t *testing.T) {
+	t.Run("ErrorForRelayAcceptIfRelaysDisabled", func(t *testing.T) {
 		ctl := gomock.NewController(t)
 		defer ctl.Finish()
 		w, mockDB, _ := setupFn(ctl)
-		followers := streams.NewActivityStreamsCollection()
-		expectFollowers := streams.NewActivityStreamsCollection()
-		expectItems := streams.NewActivityStreamsItemsProperty()
-		expectItems.AppendIRI(mustParse(testFederatedActorIRI))
-		expectFollowers.SetActivityStreamsItems(expectItems)
+		relayFollow := newRelayFollowFn()
 		mockDB.EXPECT().Lock(ctx, mustParse(testMyInboxIRI))
 		mockDB.EXPECT().ActorForInbox(ctx, mustParse(testMyInboxIRI)).Return(
 			mustParse(testFederatedActorIRI2), nil)
 		mockDB.EXPECT().Unlock(ctx, mustParse(testMyInboxIRI))
 		mockDB.EXPECT().Lock(ctx, mustParse(testFederatedActivityIRI))
 		mockDB.EXPECT().Get(ctx, mustParse(testFederatedActivityIRI)).Return(
-			testFollow, nil)
+			relayFollow, nil)
 		mockDB.EXPECT().Unlock(ctx, mustParse(testFederatedActivityIRI))
-		mockDB.EXPECT().Lock(ctx, mustParse(testFederatedActorIRI2))
-		mockDB.EXPECT().Following(ctx, mustParse(testFederatedActorIRI2)).Return(
-			followers, nil)
-		mockDB.EXPECT().Update(ctx, expectFollowers)
-		mockDB.EXPECT().Unlock(ctx, mustParse(testFederatedActorIRI2))
 		a := newAcceptFn()
+		op := streams.NewActivityStreamsObjectProperty()
+		op.AppendActivityStreamsFollow(relayFollow)
+		a.SetActivityStreamsObject(op)
 		err := w.accept(ctx, a)
-		if err != nil {
-			t.Fatalf("got error %s", err)
+		if err == nil {
+			t.Fatalf("expected error, got none")
 		}
 	})
+}
+
+func TestFederatedReject(t *testing.T) {
+	ctx := context.Background()
 	t.Run("CallsCustomCallback", func(t *testing.T) {
-		a := newAcceptFn()
-		a.SetActivityStreamsObject(nil)
+		r := streams.NewActivityStreamsReject()
 		var w FederatingWrappedCallbacks
 		var gotc context.Context
-		var got vocab.ActivityStreamsAccept
-		w.Accept = func(ctx context.Context, v vocab.ActivityStreamsAccept) error {
+		var got vocab.ActivityStreamsReject
+		w.Reject = func(ctx context.Context, v vocab.ActivityStreamsReject) error {
 			gotc = ctx
 			got = v
 			return nil
 		}
-		err := w.accept(ctx, a)
+		err := w.reject(ctx, r)
 		if err != nil {
 			t.Fatalf("got error %s", err)
 		}
 		assertEqual(t, ctx, gotc)
-		assertEqual(t, a, got)
+		assertEqual(t, r, got)
 	})
-}
-
-func TestFederatedReject(t *testing.T) {
-	ctx := context.Background()
-	t.Run("CallsCustomCallback", func(t *testing.T) {
+	t.Run("CallsRejectOtherWithNonFollowObject", func(t *testing.T) {
+		offer := streams.NewActivityStreamsOffer()
 		r := streams.NewActivityStreamsReject()
+		op := streams.NewActivityStreamsObjectProperty()
+		op.AppendActivityStreamsOffer(offer)
+		r.SetActivityStreamsObject(op)
 		var w FederatingWrappedCallbacks
-		var gotc context.Context
-		var got vocab.ActivityStreamsReject
+		var got vocab.Type
+		w.RejectOther = func(ctx context.Context, v vocab.ActivityStreamsReject, o vocab.Type) error {
+			got = o
+			return nil
+		}
+		rejectCalled := false
 		w.Reject = func(ctx context.Context, v vocab.ActivityStreamsReject) error {
-			gotc = ctx
-			got = v
+			rejectCalled = true
 			return nil
 		}
 		err := w.reject(ctx, r)
 		if err != nil {
 			t.Fatalf("got error %s", err)
 		}
-		assertEqual(t, ctx, gotc)
-		assertEqual(t, r, got)
+		assertEqual(t, got, vocab.Type(offer))
+		assertEqual(t, rejectCalled, false)
+	})
+	t.Run("CallsRejectForFollowObject", func(t *testing.T) {
+		r := streams.NewActivityStreamsReject()
+		op := streams.NewActivityStreamsObjectProperty()
+		op.AppendActivityStreamsFollow(testFollow)
+		r.SetActivityStreamsObject(op)
+		var w FederatingWrappedCallbacks
+		otherCalled := false
+		w.RejectOther = func(ctx context.Context, v vocab.ActivityStreamsReject, o vocab.Type) error {
+			otherCalled = true
+			return nil
+		}
+		rejectCalled := false
+		w.Reject = func(ctx context.Context, v vocab.ActivityStreamsReject) error {
+			rejectCalled = true
+			return nil
+		}
+		err := w.reject(ctx, r)
+		if err != nil {
+			t.Fatalf("got error %s", err)
+		}
+		assertEqual(t, otherCalled, false)
+		assertEqual(t, rejectCalled, true)
+	})
+}
+
+func TestFederatedTentativeResponses(t *testing.T) {
+	setupData()
+	ctx := context.Background()
+	newResponse := func(actorIRI string, tentativeAccept bool) Activity {
+		var r Activity
+		if tentativeAccept {
+			r = streams.NewActivityStreamsTentativeAccept()
+		} else {
+			r = streams.NewActivityStreamsTentativeReject()
+		}
+		actor := streams.NewActivityStreamsActorProperty()
+		actor.AppendIRI(mustParse(actorIRI))
+		r.SetActivityStreamsActor(actor)
+		op := streams.NewActivityStreamsObjectProperty()
+		op.AppendActivityStreamsFollow(testFollow)
+		r.SetActivityStreamsObject(op)
+		return r
+	}
+	handle := func(w FederatingWrappedCallbacks, r Activity) error {
+		switch v := r.(type) {
+		case vocab.ActivityStreamsTentativeAccept:
+			return w.tentativeAccept(ctx, v)
+		case vocab.ActivityStreamsTentativeReject:
+			return w.tentativeReject(ctx, v)
+		}
+		return nil
+	}
+	setupFn := func(ctl *gomock.Controller) (w FederatingWrappedCallbacks, mockDB *MockDatabase, db *followStateDatabase) {
+		mockDB = NewMockDatabase(ctl)
+		db = &followStateDatabase{MockDatabase: mockDB}
+		w.inboxIRI = mustParse(testMyInboxIRI)
+		w.db = db
+		return
+	}
+	for _, test := range []struct {
+		name            string
+		tentativeAccept bool
+		state           FollowState
+	}{
+		{"TentativeAccept", true, FollowTentativelyAccepted},
+		{"TentativeReject", false, FollowTentativelyRejected},
+	} {
+		t.Run(test.name+"RecordsFollowState", func(t *testing.T) {
+			ctl := gomock.NewController(t)
+			defer ctl.Finish()
+			w, mockDB, db := setupFn(ctl)
+			mockDB.EXPECT().Lock(ctx, mustParse(testMyInboxIRI))
+			mockDB.EXPECT().ActorForInbox(ctx, mustParse(testMyInboxIRI)).Return(
+				mustParse(testFederatedActorIRI2), nil)
+			mockDB.EXPECT().Unlock(ctx, mustParse(testMyInboxIRI))
+			mockDB.EXPECT().Lock(ctx, mustParse(testFederatedActivityIRI))
+			mockDB.EXPECT().Get(ctx, mustParse(testFederatedActivityIRI)).Return(
+				testFollow, nil)
+			mockDB.EXPECT().Unlock(ctx, mustParse(testFederatedActivityIRI))
+			err := handle(w, newResponse(testFederatedActorIRI, test.tentativeAccept))
+			assertEqual(t, err, nil)
+			assertEqual(t, len(db.recorded), 1)
+			assertEqual(t, db.recorded[0].followId.String(), testFederatedActivityIRI)
+			assertEqual(t, len(db.recorded[0].peers), 1)
+			assertEqual(t, db.recorded[0].peers[0].String(), testFederatedActorIRI)
+			assertEqual(t, db.recorded[0].state, test.state)
+		})
+		t.Run(test.name+"IgnoresFollowObjectsNotContainingMe", func(t *testing.T) {
+			ctl := gomock.NewController(t)
+			defer ctl.Finish()
+			w, mockDB, db := setupFn(ctl)
+			mockDB.EXPECT().Lock(ctx, mustParse(testMyInboxIRI))
+			mockDB.EXPECT().ActorForInbox(ctx, mustParse(testMyInboxIRI)).Return(
+				mustParse(testFederatedActorIRI3), nil)
+			mockDB.EXPECT().Unlock(ctx, mustParse(testMyInboxIRI))
+			err := handle(w, newResponse(testFederatedActorIRI, test.tentativeAccept))
+			assertEqual(t, err, nil)
+			assertEqual(t, len(db.recorded), 0)
+		})
+		t.Run(test.name+"ErrorIfPeerWasNotFollowed", func(t *testing.T) {
+			ctl := gomock.NewController(t)
+			defer ctl.Finish()
+			w, mockDB, db := setupFn(ctl)
+			mockDB.EXPECT().Lock(ctx, mustParse(testMyInboxIRI))
+			mockDB.EXPECT().ActorForInbox(ctx, mustParse(testMyInboxIRI)).Return(
+				mustParse(testFederatedActorIRI2), nil)
+			mockDB.EXPECT().Unlock(ctx, mustParse(testMyInboxIRI))
+			mockDB.EXPECT().Lock(ctx, mustParse(testFederatedActivityIRI))
+			mockDB.EXPECT().Get(ctx, mustParse(testFederatedActivityIRI)).Return(
+				testFollow, nil)
+			mockDB.EXPECT().Unlock(ctx, mustParse(testFederatedActivityIRI))
+			err := handle(w, newResponse(testFederatedActorIRI3, test.tentativeAccept))
+			assertNotEqual(t, err, nil)
+			assertEqual(t, len(db.recorded), 0)
+		})
+		t.Run(test.name+"ErrorIfPeerLiedAboutOurFollowId", func(t *testing.T) {
+			ctl := gomock.NewController(t)
+			defer ctl.Finish()
+			w, mockDB, db := setupFn(ctl)
+			mockDB.EXPECT().Lock(ctx, mustParse(testMyInboxIRI))
+			mockDB.EXPECT().ActorForInbox(ctx, mustParse(testMyInboxIRI)).Return(
+				mustParse(testFederatedActorIRI2), nil)
+			mockDB.EXPECT().Unlock(ctx, mustParse(testMyInboxIRI))
+			mockDB.EXPECT().Lock(ctx, mustParse(testFederatedActivityIRI))
+			mockDB.EXPECT().Get(ctx, mustParse(testFederatedActivityIRI)).Return(
+				testListen, nil)
+			mockDB.EXPECT().Unlock(ctx, mustParse(testFederatedActivityIRI))
+			err := handle(w, newResponse(testFederatedActorIRI, test.tentativeAccept))
+			assertNotEqual(t, err, nil)
+			assertEqual(t, len(db.recorded), 0)
+		})
+		t.Run(test.name+"DoesNothingWithoutRecorder", func(t *testing.T) {
+			ctl := gomock.NewController(t)
+			defer ctl.Finish()
+			var w FederatingWrappedCallbacks
+			w.db = NewMockDatabase(ctl)
+			err := handle(w, newResponse(testFederatedActorIRI, test.tentativeAccept))
+			assertEqual(t, err, nil)
+		})
+	}
+	t.Run("WrapsTentativeTypesWithRecorder", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		w, _, _ := setupFn(ctl)
+		found := 0
+		for _, f := range w.callbacks(nil) {
+			switch f.(type) {
+			case func(context.Context, vocab.ActivityStreamsTentativeAccept) error,
+				func(context.Context, vocab.ActivityStreamsTentativeReject) error:
+				found++
+			}
+		}
+		assertEqual(t, found, 2)
 	})
 }
 
+// followStateDatabase is a Database that is also a FollowStateRecorder,
+// keeping the states it records.
+type followStateDatabase struct {
+	*MockDatabase
+	recorded []recordedFollowState
+}
+
+// recordedFollowState is a call to SetFollowState.
+type recordedFollowState struct {
+	followId *url.URL
+	peers    []*url.URL
+	state    FollowState
+}
+
+func (d *followStateDatabase) SetFollowState(c context.Context, followId *url.URL, peers []*url.URL, state FollowState) error {
+	d.recorded = append(d.recorded, recordedFollowState{followId, peers, state})
+	return nil
+}
+
 func TestFederatedAdd(t *testing.T) {
 	newAddFn := func() vocab.ActivityStreamsAdd {
 		a := streams.NewActivityStreamsAdd()
@@ -1492,6 +2236,87 @@ func TestFederatedLike(t *testing.T) {
 		assertEqual(t, ctx, gotc)
 		assertEqual(t, l, got)
 	})
+	t.Run("CallsEmojiReactCallbackForReaction", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		w, mockDB := setupFn(ctl)
+		mockDB.EXPECT().Lock(ctx, mustParse(testNoteId1))
+		mockDB.EXPECT().Owns(ctx, mustParse(testNoteId1)).Return(false, nil)
+		mockDB.EXPECT().Unlock(ctx, mustParse(testNoteId1))
+		w.Like = func(ctx context.Context, v vocab.ActivityStreamsLike) error {
+			t.Fatalf("Like called for an emoji reaction")
+			return nil
+		}
+		var got EmojiReaction
+		w.EmojiReact = func(ctx context.Context, v vocab.ActivityStreamsLike, r EmojiReaction) error {
+			got = r
+			return nil
+		}
+		l := newLikeFn()
+		content := streams.NewActivityStreamsContentProperty()
+		content.AppendXMLSchemaString("🔥")
+		l.SetActivityStreamsContent(content)
+		err := w.like(ctx, l)
+		if err != nil {
+			t.Fatalf("got error %s", err)
+		}
+		assertEqual(t, got.Content, "🔥")
+	})
+	t.Run("CallsLikeCallbackWithoutReaction", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		w, mockDB := setupFn(ctl)
+		mockDB.EXPECT().Lock(ctx, mustParse(testNoteId1))
+		mockDB.EXPECT().Owns(ctx, mustParse(testNoteId1)).Return(false, nil)
+		mockDB.EXPECT().Unlock(ctx, mustParse(testNoteId1))
+		var got vocab.ActivityStreamsLike
+		w.Like = func(ctx context.Context, v vocab.ActivityStreamsLike) error {
+			got = v
+			return nil
+		}
+		w.EmojiReact = func(ctx context.Context, v vocab.ActivityStreamsLike, r EmojiReaction) error {
+			t.Fatalf("EmojiReact called for a plain Like")
+			return nil
+		}
+		l := newLikeFn()
+		err := w.like(ctx, l)
+		if err != nil {
+			t.Fatalf("got error %s", err)
+		}
+		assertEqual(t, l, got)
+	})
+	t.Run("HandlesInvalidCustomEmojiAsPlainLike", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		w, mockDB := setupFn(ctl)
+		l := &recordingLogger{}
+		w.logger = l
+		mockDB.EXPECT().Lock(ctx, mustParse(testNoteId1))
+		mockDB.EXPECT().Owns(ctx, mustParse(testNoteId1)).Return(false, nil)
+		mockDB.EXPECT().Unlock(ctx, mustParse(testNoteId1))
+		likeCalled := false
+		w.Like = func(ctx context.Context, v vocab.ActivityStreamsLike) error {
+			likeCalled = true
+			return nil
+		}
+		w.EmojiReact = func(ctx context.Context, v vocab.ActivityStreamsLike, r EmojiReaction) error {
+			t.Fatalf("EmojiReact called for an invalid reaction")
+			return nil
+		}
+		like := newLikeFn()
+		content := streams.NewActivityStreamsContentProperty()
+		content.AppendXMLSchemaString(":blobcat:")
+		like.SetActivityStreamsContent(content)
+		tag := streams.NewActivityStreamsTagProperty()
+		tag.AppendTootEmoji(newTestEmoji(":blobcat:", "ftp://other.example.com/blobcat.png"))
+		like.SetActivityStreamsTag(tag)
+		err := w.like(ctx, like)
+		if err != nil {
+			t.Fatalf("got error %s", err)
+		}
+		assertEqual(t, likeCalled, true)
+		assertEqual(t, len(l.msgs), 1)
+	})
 }
 
 func TestFederatedAnnounce(t *testing.T) {
@@ -1658,6 +2483,119 @@ func TestFederatedAnnounce(t *testing.T) {
 		assertEqual(t, ctx, gotc)
 		assertEqual(t, a, got)
 	})
+	t.Run("RelayAnnounceRefetchesEmbeddedObject", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		w, _ := setupFn(ctl)
+		mockTp := NewMockTransport(ctl)
+		w.inboxIRI = mustParse(testMyInboxIRI)
+		w.newTransport = func(c context.Context, a *url.URL, s string) (Transport, error) {
+			return mockTp, nil
+		}
+		w.EnableRelays = true
+		w.IsRelay = func(c context.Context, actorIRI *url.URL) (bool, error) {
+			return actorIRI.String() == testFederatedActorIRI, nil
+		}
+		w.Announce = func(ctx context.Context, v vocab.ActivityStreamsAnnounce) error {
+			t.Fatalf("Announce called for a relayed activity")
+			return nil
+		}
+		var got []vocab.Type
+		w.RelayAnnounce = func(ctx context.Context, v vocab.ActivityStreamsAnnounce, relayed vocab.Type) error {
+			got = append(got, relayed)
+			return nil
+		}
+		mockTp.EXPECT().Dereference(ctx, mustParse(testNoteId1)).Return(
+			mustSerializeToBytes(testFederatedNote), nil)
+		a := newAnnounceFn()
+		err := w.announce(ctx, a)
+		if err != nil {
+			t.Fatalf("got error %s", err)
+		}
+		assertEqual(t, len(got), 1)
+		assertByteEqual(t, mustSerializeToBytes(got[0]), mustSerializeToBytes(testFederatedNote))
+	})
+	t.Run("RelayAnnounceDereferencesObjectIRI", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		w, _ := setupFn(ctl)
+		mockTp := NewMockTransport(ctl)
+		w.inboxIRI = mustParse(testMyInboxIRI)
+		w.newTransport = func(c context.Context, a *url.URL, s string) (Transport, error) {
+			return mockTp, nil
+		}
+		w.EnableRelays = true
+		w.IsRelay = func(c context.Context, actorIRI *url.URL) (bool, error) {
+			return true, nil
+		}
+		var got []vocab.Type
+		w.RelayAnnounce = func(ctx context.Context, v vocab.ActivityStreamsAnnounce, relayed vocab.Type) error {
+			got = append(got, relayed)
+			return nil
+		}
+		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActivityIRI)).Return(
+			mustSerializeToBytes(testCreate), nil)
+		a := newAnnounceFn()
+		op := streams.NewActivityStreamsObjectProperty()
+		op.AppendIRI(mustParse(testFederatedActivityIRI))
+		a.SetActivityStreamsObject(op)
+		err := w.announce(ctx, a)
+		if err != nil {
+			t.Fatalf("got error %s", err)
+		}
+		assertEqual(t, len(got), 1)
+		assertByteEqual(t, mustSerializeToBytes(got[0]), mustSerializeToBytes(testCreate))
+	})
+	t.Run("RelayAnnounceRejectsObjectWithOtherId", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		w, _ := setupFn(ctl)
+		mockTp := NewMockTransport(ctl)
+		w.inboxIRI = mustParse(testMyInboxIRI)
+		w.newTransport = func(c context.Context, a *url.URL, s string) (Transport, error) {
+			return mockTp, nil
+		}
+		w.EnableRelays = true
+		w.IsRelay = func(c context.Context, actorIRI *url.URL) (bool, error) {
+			return true, nil
+		}
+		w.RelayAnnounce = func(ctx context.Context, v vocab.ActivityStreamsAnnounce, relayed vocab.Type) error {
+			t.Fatalf("RelayAnnounce called for a spoofed object")
+			return nil
+		}
+		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActivityIRI2)).Return(
+			mustSerializeToBytes(testCreate), nil)
+		a := newAnnounceFn()
+		op := streams.NewActivityStreamsObjectProperty()
+		op.AppendIRI(mustParse(testFederatedActivityIRI2))
+		a.SetActivityStreamsObject(op)
+		err := w.announce(ctx, a)
+		if err == nil {
+			t.Fatalf("expected error")
+		}
+	})
+	t.Run("NonRelayAnnounceIsShared", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		w, mockDB := setupFn(ctl)
+		w.EnableRelays = true
+		w.IsRelay = func(c context.Context, actorIRI *url.URL) (bool, error) {
+			return false, nil
+		}
+		w.RelayAnnounce = func(ctx context.Context, v vocab.ActivityStreamsAnnounce, relayed vocab.Type) error {
+			t.Fatalf("RelayAnnounce called for a boost")
+			return nil
+		}
+		mockDB.EXPECT().Lock(ctx, mustParse(testNoteId1))
+		mockDB.EXPECT().Owns(ctx, mustParse(testNoteId1)).Return(
+			false, nil)
+		mockDB.EXPECT().Unlock(ctx, mustParse(testNoteId1))
+		a := newAnnounceFn()
+		err := w.announce(ctx, a)
+		if err != nil {
+			t.Fatalf("got error %s", err)
+		}
+	})
 }
 
 func TestFederatedUndo(t *testing.T) {
@@ -1832,3 +2770,117 @@ func TestFederatedBlock(t *testing.T) {
 		assertEqual(t, b, got)
 	})
 }
+
+func TestFederatedMove(t *testing.T) {
+	newMoveFn := func() vocab.ActivityStreamsMove {
+		m := streams.NewActivityStreamsMove()
+		id := streams.NewJSONLDIdProperty()
+		id.Set(mustParse(testFederatedActivityIRI))
+		m.SetJSONLDId(id)
+		actor := streams.NewActivityStreamsActorProperty()
+		actor.AppendIRI(mustParse(testFederatedActorIRI))
+		m.SetActivityStreamsActor(actor)
+		op := streams.NewActivityStreamsObjectProperty()
+		op.AppendIRI(mustParse(testFederatedActorIRI))
+		m.SetActivityStreamsObject(op)
+		target := streams.NewActivityStreamsTargetProperty()
+		target.AppendIRI(mustParse(testFederatedActorIRI2))
+		m.SetActivityStreamsTarget(target)
+		return m
+	}
+	newTargetFn := func(aliases ...string) vocab.ActivityStreamsPerson {
+		p := streams.NewActivityStreamsPerson()
+		id := streams.NewJSONLDIdProperty()
+		id.Set(mustParse(testFederatedActorIRI2))
+		p.SetJSONLDId(id)
+		aka := streams.NewActivityStreamsAlsoKnownAsProperty()
+		for _, alias := range aliases {
+			aka.AppendXMLSchemaAnyURI(mustParse(alias))
+		}
+		p.SetActivityStreamsAlsoKnownAs(aka)
+		return p
+	}
+	ctx := context.Background()
+	setupFn := func(ctl *gomock.Controller) (w FederatingWrappedCallbacks, mockTp *MockTransport, called *bool) {
+		mockTp = NewMockTransport(ctl)
+		w.inboxIRI = mustParse(testMyInboxIRI)
+		w.newTransport = func(c context.Context, a *url.URL, s string) (Transport, error) {
+			return mockTp, nil
+		}
+		called = new(bool)
+		w.Move = func(c context.Context, m vocab.ActivityStreamsMove) error {
+			*called = true
+			return nil
+		}
+		return
+	}
+	t.Run("CallsMoveIfTargetAliasesOrigin", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		w, mockTp, called := setupFn(ctl)
+		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActorIRI2)).Return(
+			mustSerializeToBytes(newTargetFn(testFederatedActorIRI3, testFederatedActorIRI)), nil)
+		err := w.move(ctx, newMoveFn())
+		assertEqual(t, err, nil)
+		assertEqual(t, *called, true)
+	})
+	t.Run("ErrorIfTargetDoesNotAliasOrigin", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		w, mockTp, called := setupFn(ctl)
+		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActorIRI2)).Return(
+			mustSerializeToBytes(newTargetFn(testFederatedActorIRI3)), nil)
+		err := w.move(ctx, newMoveFn())
+		assertNotEqual(t, err, nil)
+		assertEqual(t, *called, false)
+	})
+	t.Run("ErrorIfTargetHasAnotherId", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		w, mockTp, called := setupFn(ctl)
+		other := newTargetFn(testFederatedActorIRI)
+		id := streams.NewJSONLDIdProperty()
+		id.Set(mustParse(testFederatedActorIRI4))
+		other.SetJSONLDId(id)
+		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActorIRI2)).Return(
+			mustSerializeToBytes(other), nil)
+		err := w.move(ctx, newMoveFn())
+		assertNotEqual(t, err, nil)
+		assertEqual(t, *called, false)
+	})
+	t.Run("DereferencesEmbeddedTarget", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		w, mockTp, called := setupFn(ctl)
+		m := newMoveFn()
+		target := streams.NewActivityStreamsTargetProperty()
+		target.AppendActivityStreamsPerson(newTargetFn(testFederatedActorIRI))
+		m.SetActivityStreamsTarget(target)
+		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActorIRI2)).Return(
+			mustSerializeToBytes(newTargetFn()), nil)
+		err := w.move(ctx, m)
+		assertNotEqual(t, err, nil)
+		assertEqual(t, *called, false)
+	})
+	t.Run("ErrorIfActorIsNotOrigin", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		w, _, called := setupFn(ctl)
+		m := newMoveFn()
+		actor := streams.NewActivityStreamsActorProperty()
+		actor.AppendIRI(mustParse(testFederatedActorIRI3))
+		m.SetActivityStreamsActor(actor)
+		err := w.move(ctx, m)
+		assertNotEqual(t, err, nil)
+		assertEqual(t, *called, false)
+	})
+	t.Run("ErrorIfNoTarget", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		w, _, _ := setupFn(ctl)
+		m := newMoveFn()
+		m.SetActivityStreamsTarget(nil)
+		err := w.move(ctx, m)
+		assertEqual(t, err, ErrTargetRequired)
+	})
+}
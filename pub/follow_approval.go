@@ -0,0 +1,177 @@
+package pub
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/go-fed/activity/streams"
+	"github.com/go-fed/activity/streams/vocab"
+)
+
+// ApproveFollow approves a Follow request held pending by
+// OnFollowPendingApproval. The actors of the Follow are added to the followers
+// of the actor of the outbox, and an Accept of the Follow is sent from the
+// outbox, which is returned.
+func ApproveFollow(c context.Context, db Database, a FederatingActor, outbox *url.URL, follow vocab.ActivityStreamsFollow) (Activity, error) {
+	return respondToFollow(c, db, a, outbox, follow, true)
+}
+
+// DenyFollow denies a Follow request held pending by OnFollowPendingApproval.
+// A Reject of the Follow is sent from the outbox, which is returned, and the
+// followers are left unchanged.
+func DenyFollow(c context.Context, db Database, a FederatingActor, outbox *url.URL, follow vocab.ActivityStreamsFollow) (Activity, error) {
+	return respondToFollow(c, db, a, outbox, follow, false)
+}
+
+// AcceptFollow returns an Accept of the Follow by the followed actor, for
+// applications sending it themselves. It has the followed actor as its
+// 'actor', the Follow as its 'object', and is addressed 'to' the actors of the
+// Follow, which is how a peer recognizes the Follow it sent was accepted.
+//
+// An error is returned if the Follow has no 'id' or no 'actor'.
+func AcceptFollow(followed *url.URL, follow vocab.ActivityStreamsFollow) (vocab.ActivityStreamsAccept, error) {
+	response, err := buildFollowResponse(followed, follow, true)
+	if err != nil {
+		return nil, err
+	}
+	return response.(vocab.ActivityStreamsAccept), nil
+}
+
+// RejectFollow returns a Reject of the Follow by the followed actor, built the
+// same way as AcceptFollow.
+func RejectFollow(followed *url.URL, follow vocab.ActivityStreamsFollow) (vocab.ActivityStreamsReject, error) {
+	response, err := buildFollowResponse(followed, follow, false)
+	if err != nil {
+		return nil, err
+	}
+	return response.(vocab.ActivityStreamsReject), nil
+}
+
+// buildFollowResponse ensures the Follow can be responded to before preparing
+// the Accept or Reject of it.
+func buildFollowResponse(followed *url.URL, follow vocab.ActivityStreamsFollow, accept bool) (Activity, error) {
+	if id := follow.GetJSONLDId(); id == nil || id.Get() == nil {
+		return nil, fmt.Errorf("follow requires an id")
+	} else if actors := follow.GetActivityStreamsActor(); actors == nil || actors.Len() == 0 {
+		return nil, fmt.Errorf("follow requires an actor")
+	}
+	response, _, err := followResponse(followed, follow, accept)
+	return response, err
+}
+
+// respondToFollow sends an Accept or Reject of the Follow from the outbox.
+func respondToFollow(c context.Context, db Database, a FederatingActor, outbox *url.URL, follow vocab.ActivityStreamsFollow, accept bool) (Activity, error) {
+	if err := db.Lock(c, outbox); err != nil {
+		return nil, err
+	}
+	actorIRI, err := db.ActorForOutbox(c, outbox)
+	db.Unlock(c, outbox)
+	if err != nil {
+		return nil, err
+	}
+	response, recipients, err := followResponse(actorIRI, follow, accept)
+	if err != nil {
+		return nil, err
+	}
+	if accept {
+		if err := addFollowers(c, db, actorIRI, recipients); err != nil {
+			return nil, err
+		}
+	}
+	return a.Send(c, outbox, response)
+}
+
+// followResponse prepares the Accept or Reject of the Follow by the actor,
+// addressed to the actors of the Follow, which are also returned.
+func followResponse(actorIRI *url.URL, follow vocab.ActivityStreamsFollow, accept bool) (response Activity, recipients []*url.URL, err error) {
+	if accept {
+		response = streams.NewActivityStreamsAccept()
+	} else {
+		response = streams.NewActivityStreamsReject()
+	}
+	// Set us as the 'actor'.
+	me := streams.NewActivityStreamsActorProperty()
+	response.SetActivityStreamsActor(me)
+	me.AppendIRI(actorIRI)
+	// Set the Follow as the 'object' property.
+	op := streams.NewActivityStreamsObjectProperty()
+	response.SetActivityStreamsObject(op)
+	op.AppendActivityStreamsFollow(follow)
+	// Add all actors on the original Follow to the 'to' property.
+	to := streams.NewActivityStreamsToProperty()
+	response.SetActivityStreamsTo(to)
+	followActors := follow.GetActivityStreamsActor()
+	if followActors == nil {
+		return nil, nil, fmt.Errorf("follow requires an actor")
+	}
+	for iter := followActors.Begin(); iter != followActors.End(); iter = iter.Next() {
+		id, err := ToId(iter)
+		if err != nil {
+			return nil, nil, err
+		}
+		to.AppendIRI(id)
+		recipients = append(recipients, id)
+	}
+	return response, recipients, nil
+}
+
+// addFollowers adds the actors to the followers collection of the actor.
+//
+// A duplicate Follow is accepted again, in case the peer did not receive the
+// earlier Accept, but its actors are not added to the followers a second time.
+func addFollowers(c context.Context, db Database, actorIRI *url.URL, actors []*url.URL) error {
+	if err := db.Lock(c, actorIRI); err != nil {
+		return err
+	}
+	defer db.Unlock(c, actorIRI)
+	followers, err := db.Followers(c, actorIRI)
+	if err != nil {
+		return err
+	}
+	items := followers.GetActivityStreamsItems()
+	if items == nil {
+		items = streams.NewActivityStreamsItemsProperty()
+		followers.SetActivityStreamsItems(items)
+	}
+	existing := make(map[string]bool, items.Len())
+	for iter := items.Begin(); iter != items.End(); iter = iter.Next() {
+		id, err := ToId(iter)
+		if err != nil {
+			return err
+		}
+		existing[id.String()] = true
+	}
+	added := false
+	for _, elem := range actors {
+		if existing[elem.String()] {
+			continue
+		}
+		existing[elem.String()] = true
+		items.PrependIRI(elem)
+		added = true
+	}
+	if !added {
+		return nil
+	}
+	return db.Update(c, followers)
+}
+
+// storeActivity creates the activity in the database, unless it already
+// exists.
+func storeActivity(c context.Context, db Database, activity Activity) error {
+	id := activity.GetJSONLDId()
+	if id == nil {
+		return fmt.Errorf("activity requires an id to be stored")
+	}
+	if err := db.Lock(c, id.Get()); err != nil {
+		return err
+	}
+	defer db.Unlock(c, id.Get())
+	if exists, err := db.Exists(c, id.Get()); err != nil {
+		return err
+	} else if exists {
+		return nil
+	}
+	return db.Create(c, activity)
+}
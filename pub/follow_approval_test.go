@@ -0,0 +1,167 @@
+package pub
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/go-fed/activity/streams"
+	"github.com/go-fed/activity/streams/vocab"
+	"github.com/golang/mock/gomock"
+)
+
+// sendingActor is a FederatingActor recording the values it is asked to send.
+type sendingActor struct {
+	FederatingActor
+	outbox *url.URL
+	sent   vocab.Type
+}
+
+func (a *sendingActor) Send(c context.Context, outbox *url.URL, t vocab.Type) (Activity, error) {
+	a.outbox = outbox
+	a.sent = t
+	return t.(Activity), nil
+}
+
+func TestRespondToPendingFollow(t *testing.T) {
+	ctx := context.Background()
+	newFollow := func() vocab.ActivityStreamsFollow {
+		f := streams.NewActivityStreamsFollow()
+		id := streams.NewJSONLDIdProperty()
+		id.Set(mustParse(testNewActivityIRI))
+		f.SetJSONLDId(id)
+		actor := streams.NewActivityStreamsActorProperty()
+		actor.AppendIRI(mustParse(testFederatedActorIRI))
+		f.SetActivityStreamsActor(actor)
+		op := streams.NewActivityStreamsObjectProperty()
+		op.AppendIRI(mustParse(testFederatedActorIRI2))
+		f.SetActivityStreamsObject(op)
+		return f
+	}
+	expectActor := func(mockDB *MockDatabase) {
+		mockDB.EXPECT().Lock(ctx, mustParse(testMyOutboxIRI))
+		mockDB.EXPECT().ActorForOutbox(ctx, mustParse(testMyOutboxIRI)).Return(
+			mustParse(testFederatedActorIRI2), nil)
+		mockDB.EXPECT().Unlock(ctx, mustParse(testMyOutboxIRI))
+	}
+	assertResponse := func(t *testing.T, a *sendingActor, f vocab.ActivityStreamsFollow) {
+		assertEqual(t, a.outbox.String(), testMyOutboxIRI)
+		response, ok := a.sent.(Activity)
+		if !ok {
+			t.Fatalf("expected an Activity to be sent, got %T", a.sent)
+		}
+		actor := response.GetActivityStreamsActor()
+		if actor == nil || actor.Len() != 1 || actor.At(0).GetIRI().String() != testFederatedActorIRI2 {
+			t.Fatalf("expected the followed actor as 'actor'")
+		}
+		to := response.GetActivityStreamsTo()
+		if to == nil || to.Len() != 1 || to.At(0).GetIRI().String() != testFederatedActorIRI {
+			t.Fatalf("expected the response addressed to the follower")
+		}
+		op := response.GetActivityStreamsObject()
+		if op == nil || op.Len() != 1 || op.At(0).GetActivityStreamsFollow() != f {
+			t.Fatalf("expected the Follow as 'object'")
+		}
+	}
+	t.Run("ApproveFollowAddsFollowerAndSendsAccept", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		mockDB := NewMockDatabase(ctl)
+		a := &sendingActor{}
+		f := newFollow()
+		followers := streams.NewActivityStreamsCollection()
+		expectActor(mockDB)
+		mockDB.EXPECT().Lock(ctx, mustParse(testFederatedActorIRI2))
+		mockDB.EXPECT().Followers(ctx, mustParse(testFederatedActorIRI2)).Return(
+			followers, nil)
+		mockDB.EXPECT().Update(ctx, followers)
+		mockDB.EXPECT().Unlock(ctx, mustParse(testFederatedActorIRI2))
+		_, err := ApproveFollow(ctx, mockDB, a, mustParse(testMyOutboxIRI), f)
+		if err != nil {
+			t.Fatalf("got error %s", err)
+		}
+		if !streams.IsOrExtendsActivityStreamsAccept(a.sent) {
+			t.Fatalf("expected an Accept, got %T", a.sent)
+		}
+		assertResponse(t, a, f)
+		items := followers.GetActivityStreamsItems()
+		if items == nil || items.Len() != 1 || items.At(0).GetIRI().String() != testFederatedActorIRI {
+			t.Fatalf("expected the follower to be added")
+		}
+	})
+	t.Run("DenyFollowSendsReject", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		mockDB := NewMockDatabase(ctl)
+		a := &sendingActor{}
+		f := newFollow()
+		expectActor(mockDB)
+		_, err := DenyFollow(ctx, mockDB, a, mustParse(testMyOutboxIRI), f)
+		if err != nil {
+			t.Fatalf("got error %s", err)
+		}
+		if !streams.IsOrExtendsActivityStreamsReject(a.sent) {
+			t.Fatalf("expected a Reject, got %T", a.sent)
+		}
+		assertResponse(t, a, f)
+	})
+}
+
+func TestAcceptRejectFollow(t *testing.T) {
+	newFollow := func() vocab.ActivityStreamsFollow {
+		f := streams.NewActivityStreamsFollow()
+		id := streams.NewJSONLDIdProperty()
+		id.Set(mustParse(testNewActivityIRI))
+		f.SetJSONLDId(id)
+		actor := streams.NewActivityStreamsActorProperty()
+		actor.AppendIRI(mustParse(testFederatedActorIRI))
+		f.SetActivityStreamsActor(actor)
+		op := streams.NewActivityStreamsObjectProperty()
+		op.AppendIRI(mustParse(testFederatedActorIRI2))
+		f.SetActivityStreamsObject(op)
+		return f
+	}
+	assertResponse := func(t *testing.T, response Activity, f vocab.ActivityStreamsFollow) {
+		actor := response.GetActivityStreamsActor()
+		if actor == nil || actor.Len() != 1 || actor.At(0).GetIRI().String() != testFederatedActorIRI2 {
+			t.Fatalf("expected the followed actor as 'actor'")
+		}
+		to := response.GetActivityStreamsTo()
+		if to == nil || to.Len() != 1 || to.At(0).GetIRI().String() != testFederatedActorIRI {
+			t.Fatalf("expected the response addressed to the follower")
+		}
+		op := response.GetActivityStreamsObject()
+		if op == nil || op.Len() != 1 || op.At(0).GetActivityStreamsFollow() != f {
+			t.Fatalf("expected the Follow as 'object'")
+		}
+	}
+	t.Run("AcceptFollow", func(t *testing.T) {
+		f := newFollow()
+		accept, err := AcceptFollow(mustParse(testFederatedActorIRI2), f)
+		assertEqual(t, err, nil)
+		assertResponse(t, accept, f)
+	})
+	t.Run("RejectFollow", func(t *testing.T) {
+		f := newFollow()
+		reject, err := RejectFollow(mustParse(testFederatedActorIRI2), f)
+		assertEqual(t, err, nil)
+		assertResponse(t, reject, f)
+	})
+	t.Run("ErrorIfFollowHasNoId", func(t *testing.T) {
+		f := newFollow()
+		f.SetJSONLDId(nil)
+		_, err := AcceptFollow(mustParse(testFederatedActorIRI2), f)
+		assertNotEqual(t, err, nil)
+		_, err = RejectFollow(mustParse(testFederatedActorIRI2), f)
+		assertNotEqual(t, err, nil)
+	})
+	t.Run("ErrorIfFollowHasNoActor", func(t *testing.T) {
+		f := newFollow()
+		f.SetActivityStreamsActor(nil)
+		_, err := AcceptFollow(mustParse(testFederatedActorIRI2), f)
+		assertNotEqual(t, err, nil)
+		f.SetActivityStreamsActor(streams.NewActivityStreamsActorProperty())
+		_, err = RejectFollow(mustParse(testFederatedActorIRI2), f)
+		assertNotEqual(t, err, nil)
+	})
+}
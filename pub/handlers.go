@@ -2,14 +2,16 @@ package pub
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
-	"fmt"
 	"net/http"
 
 	"github.com/go-fed/activity/streams"
 )
 
+// ErrNotFound is returned by a HandlerFunc when the Database has no entry for
+// the requested id, which callers typically respond to with 404 Not Found. It
+// is not returned for deleted entries the Database provides as a Tombstone,
+// which are instead served with 410 Gone.
 var ErrNotFound = errors.New("go-fed/activity: ActivityStreams data not found")
 
 // HandlerFunc determines whether an incoming HTTP request is an ActivityStreams
@@ -29,6 +31,26 @@ var ErrNotFound = errors.New("go-fed/activity: ActivityStreams data not found")
 // Callers are responsible for authorized access to this resource.
 type HandlerFunc func(c context.Context, w http.ResponseWriter, r *http.Request) (isASRequest bool, err error)
 
+// HandlerOption configures optional behavior of the HandlerFunc created by
+// NewActivityStreamsHandler and NewActivityStreamsHandlerScheme.
+type HandlerOption func(h *handlerOptions)
+
+// handlerOptions are the optional behaviors of a HandlerFunc.
+type handlerOptions struct {
+	// cachePolicy determines the caching headers of responses, if set.
+	cachePolicy CachePolicy
+}
+
+// WithHandlerCachePolicy sets the Cache-Control and ETag headers of responses
+// as determined by the CachePolicy, and responds with 304 Not Modified to
+// requests that already have the current version, such as for the followers
+// and following collections. Without it, responses are not cached.
+func WithHandlerCachePolicy(p CachePolicy) HandlerOption {
+	return func(h *handlerOptions) {
+		h.cachePolicy = p
+	}
+}
+
 // NewActivityStreamsHandler creates a HandlerFunc to serve ActivityStreams
 // requests which are coming from other clients or servers that wish to obtain
 // an ActivityStreams representation of data.
@@ -38,8 +60,8 @@ type HandlerFunc func(c context.Context, w http.ResponseWriter, r *http.Request)
 // Tombstone Activities as well.
 //
 // Defaults to supporting content to be retrieved by HTTPS only.
-func NewActivityStreamsHandler(db Database, clock Clock) HandlerFunc {
-	return NewActivityStreamsHandlerScheme(db, clock, "https")
+func NewActivityStreamsHandler(db Database, clock Clock, opts ...HandlerOption) HandlerFunc {
+	return NewActivityStreamsHandlerScheme(db, clock, "https", opts...)
 }
 
 // NewActivityStreamsHandlerScheme creates a HandlerFunc to serve
@@ -55,8 +77,13 @@ func NewActivityStreamsHandler(db Database, clock Clock) HandlerFunc {
 // identifiers such as HTTP, HTTPS, or other protocol schemes.
 //
 // Returns ErrNotFound when the database does not retrieve any data and no
-// errors occurred during retrieval.
-func NewActivityStreamsHandlerScheme(db Database, clock Clock, scheme string) HandlerFunc {
+// errors occurred during retrieval, so that objects which never existed may be
+// distinguished from deleted ones.
+func NewActivityStreamsHandlerScheme(db Database, clock Clock, scheme string, opts ...HandlerOption) HandlerFunc {
+	var o handlerOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
 	return func(c context.Context, w http.ResponseWriter, r *http.Request) (isASRequest bool, err error) {
 		// Do nothing if it is not an ActivityPub GET request
 		if !isActivityPubGet(r) {
@@ -84,30 +111,24 @@ func NewActivityStreamsHandlerScheme(db Database, clock Clock, scheme string) Ha
 		}
 		// Remove sensitive fields.
 		clearSensitiveFields(t)
-		// Serialize the fetched value.
-		m, err := streams.Serialize(t)
-		if err != nil {
-			return
-		}
-		raw, err := json.Marshal(m)
-		if err != nil {
-			return
+		// Respond without the value if the requester has its current
+		// version.
+		if o.cachePolicy != nil {
+			var notModified bool
+			notModified, err = addCacheHeaders(c, w, r, o.cachePolicy, t, false)
+			if err != nil {
+				return
+			} else if notModified {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
 		}
-		// Construct the response.
-		addResponseHeaders(w.Header(), clock, raw)
-		// Write the response.
+		// Serialize the fetched value and write the response.
+		status := http.StatusOK
 		if streams.IsOrExtendsActivityStreamsTombstone(t) {
-			w.WriteHeader(http.StatusGone)
-		} else {
-			w.WriteHeader(http.StatusOK)
-		}
-		n, err := w.Write(raw)
-		if err != nil {
-			return
-		} else if n != len(raw) {
-			err = fmt.Errorf("only wrote %d of %d bytes", n, len(raw))
-			return
+			status = http.StatusGone
 		}
+		err = writeSerializedResponse(w, clock, status, t)
 		return
 	}
 }
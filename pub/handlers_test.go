@@ -6,8 +6,11 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/go-fed/activity/streams/vocab"
 	"github.com/golang/mock/gomock"
 )
 
@@ -52,6 +55,23 @@ func TestActivityStreamsHandler(t *testing.T) {
 		assertEqual(t, err, testErr)
 		assertEqual(t, len(resp.Result().Header), 0)
 	})
+	t.Run("ReturnsErrNotFoundWhenDatabaseHasNoEntry", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		mockDb, _, hf := setupFn(ctl)
+		resp := httptest.NewRecorder()
+		req := toAPRequest(httptest.NewRequest("GET", testNoteId1, nil))
+		// Mock
+		mockDb.EXPECT().Lock(ctx, mustParse(testNoteId1))
+		mockDb.EXPECT().Get(ctx, mustParse(testNoteId1)).Return(nil, nil)
+		mockDb.EXPECT().Unlock(ctx, mustParse(testNoteId1))
+		// Run & Verify
+		isAPReq, err := hf(ctx, resp, req)
+		assertEqual(t, isAPReq, true)
+		assertEqual(t, err, ErrNotFound)
+		assertEqual(t, len(resp.Result().Header), 0)
+	})
 	t.Run("ServesTombstoneWithStatusGone", func(t *testing.T) {
 		// Setup
 		ctl := gomock.NewController(t)
@@ -101,5 +121,86 @@ func TestActivityStreamsHandler(t *testing.T) {
 		b, err := ioutil.ReadAll(respV.Body)
 		assertEqual(t, err, nil)
 		assertByteEqual(t, b, mustSerializeToBytes(testMyNote))
+		assertEqual(t, respV.Header.Get(cacheControlHeader), "")
+		assertEqual(t, respV.Header.Get(etagHeader), "")
+	})
+}
+
+// TestActivityStreamsHandlerCachePolicy tests the caching headers of the
+// handler serving ActivityPub requests.
+func TestActivityStreamsHandlerCachePolicy(t *testing.T) {
+	setupData()
+	ctx := context.Background()
+	policy := CollectionCachePolicy{
+		MaxAge: map[string]time.Duration{
+			"OrderedCollectionPage": 5 * time.Minute,
+		},
+	}
+	setupFn := func(ctl *gomock.Controller, served vocab.Type) (clock *MockClock, hf HandlerFunc) {
+		db := NewMockDatabase(ctl)
+		clock = NewMockClock(ctl)
+		db.EXPECT().Lock(ctx, mustParse(testNoteId1))
+		db.EXPECT().Get(ctx, mustParse(testNoteId1)).Return(served, nil)
+		db.EXPECT().Unlock(ctx, mustParse(testNoteId1))
+		hf = NewActivityStreamsHandler(db, clock, WithHandlerCachePolicy(policy))
+		return
+	}
+	t.Run("SetsCacheHeaders", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		clock, hf := setupFn(ctl, testOrderedCollectionUniqueElems)
+		clock.EXPECT().Now().Return(now())
+		resp := httptest.NewRecorder()
+		req := toAPRequest(httptest.NewRequest("GET", testNoteId1, nil))
+		_, err := hf(ctx, resp, req)
+		assertEqual(t, err, nil)
+		assertEqual(t, resp.Code, http.StatusOK)
+		assertEqual(t, resp.Header().Get(cacheControlHeader), "max-age=300")
+		assertEqual(t, resp.Header().Get(varyHeader), "")
+		assertEqual(t, strings.HasPrefix(resp.Header().Get(etagHeader), `W/"`), true)
+	})
+	t.Run("RespondsNotModifiedOnMatchingETag", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, hf := setupFn(ctl, testOrderedCollectionUniqueElems)
+		_, etag, err := policy.CacheControl(ctx, testOrderedCollectionUniqueElems)
+		assertEqual(t, err, nil)
+		resp := httptest.NewRecorder()
+		req := toAPRequest(httptest.NewRequest("GET", testNoteId1, nil))
+		req.Header.Set(ifNoneMatchHeader, `"other", `+etag)
+		_, err = hf(ctx, resp, req)
+		assertEqual(t, err, nil)
+		assertEqual(t, resp.Code, http.StatusNotModified)
+		assertEqual(t, resp.Header().Get(etagHeader), etag)
+		assertEqual(t, resp.Body.Len(), 0)
+	})
+	t.Run("ServesContentOnStaleETag", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, etag, err := policy.CacheControl(ctx, testOrderedCollectionUniqueElems)
+		assertEqual(t, err, nil)
+		clock, hf := setupFn(ctl, testOrderedCollectionDupedElems)
+		clock.EXPECT().Now().Return(now())
+		resp := httptest.NewRecorder()
+		req := toAPRequest(httptest.NewRequest("GET", testNoteId1, nil))
+		req.Header.Set(ifNoneMatchHeader, etag)
+		_, err = hf(ctx, resp, req)
+		assertEqual(t, err, nil)
+		assertEqual(t, resp.Code, http.StatusOK)
+		assertNotEqual(t, resp.Header().Get(etagHeader), etag)
+	})
+	t.Run("DoesNotCacheOtherTypes", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		clock, hf := setupFn(ctl, testMyNote)
+		clock.EXPECT().Now().Return(now())
+		resp := httptest.NewRecorder()
+		req := toAPRequest(httptest.NewRequest("GET", testNoteId1, nil))
+		req.Header.Set(ifNoneMatchHeader, "*")
+		_, err := hf(ctx, resp, req)
+		assertEqual(t, err, nil)
+		assertEqual(t, resp.Code, http.StatusOK)
+		assertEqual(t, resp.Header().Get(cacheControlHeader), "")
+		assertEqual(t, resp.Header().Get(etagHeader), "")
 	})
 }
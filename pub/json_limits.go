@@ -0,0 +1,80 @@
+package pub
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// JSONLimiter may optionally be implemented by a DelegateActor to bound the
+// structure of the JSON posted to an inbox or an outbox. The JSON is checked
+// before it is decoded and resolved into a value, so that a document that is
+// small but deeply nested, or that has enormous arrays, is rejected before it
+// is processed recursively.
+//
+// The DelegateActor of NewSocialActor, NewFederatingActor, and NewActor
+// implements it with the limits set by WithJSONLimits.
+type JSONLimiter interface {
+	// JSONLimits returns the maximum nesting depth of objects and arrays,
+	// and the maximum number of elements of any array. A limit that is
+	// zero or negative is not enforced.
+	JSONLimits() (maxDepth, maxArrayLength int)
+}
+
+// JSONLimitError is returned when JSON exceeds a limit of a JSONLimiter.
+// PostInbox and PostOutbox respond to it with a 422 Unprocessable Entity
+// status.
+type JSONLimitError struct {
+	// Limit is the exceeded limit, either "depth" or "array length".
+	Limit string
+	// Max is the value of the exceeded limit.
+	Max int
+}
+
+// Error describes the exceeded limit.
+func (e *JSONLimitError) Error() string {
+	return fmt.Sprintf("json %s exceeds the limit of %d", e.Limit, e.Max)
+}
+
+// checkJSONLimits reads the JSON token by token, without recursion, and
+// returns a *JSONLimitError as soon as it exceeds the maximum depth or array
+// length. Zero or negative limits are not enforced. Malformed JSON is left for
+// the decoder to report.
+func checkJSONLimits(b []byte, maxDepth, maxArrayLength int) error {
+	if maxDepth <= 0 && maxArrayLength <= 0 {
+		return nil
+	}
+	// arrays holds, for each level of nesting, the number of elements read
+	// so far if it is an array, or -1 if it is an object.
+	var arrays []int
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return nil
+		}
+		delim, isDelim := tok.(json.Delim)
+		if isDelim && (delim == '}' || delim == ']') {
+			arrays = arrays[:len(arrays)-1]
+			continue
+		}
+		if n := len(arrays); n > 0 && arrays[n-1] >= 0 {
+			arrays[n-1]++
+			if maxArrayLength > 0 && arrays[n-1] > maxArrayLength {
+				return &JSONLimitError{Limit: "array length", Max: maxArrayLength}
+			}
+		}
+		if !isDelim {
+			continue
+		}
+		if delim == '[' {
+			arrays = append(arrays, 0)
+		} else {
+			arrays = append(arrays, -1)
+		}
+		if maxDepth > 0 && len(arrays) > maxDepth {
+			return &JSONLimitError{Limit: "depth", Max: maxDepth}
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package pub
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCheckJSONLimits(t *testing.T) {
+	nested := strings.Repeat("[", 100000) + strings.Repeat("]", 100000)
+	long := "[" + strings.Repeat("1,", 999) + "1]"
+	tests := []struct {
+		name           string
+		json           string
+		maxDepth       int
+		maxArrayLength int
+		expectLimit    string
+	}{
+		{
+			name:           "within limits",
+			json:           `{"type":"Note","to":["a","b"],"tag":[{"type":"Mention"}]}`,
+			maxDepth:       3,
+			maxArrayLength: 2,
+		},
+		{
+			name:           "exceeds depth",
+			json:           nested,
+			maxDepth:       32,
+			maxArrayLength: 1000,
+			expectLimit:    "depth",
+		},
+		{
+			name:        "exceeds depth of objects",
+			json:        `{"a":{"b":{"c":{}}}}`,
+			maxDepth:    3,
+			expectLimit: "depth",
+		},
+		{
+			name:           "exceeds array length",
+			json:           long,
+			maxDepth:       32,
+			maxArrayLength: 999,
+			expectLimit:    "array length",
+		},
+		{
+			name:           "object members are not array elements",
+			json:           `{"a":1,"b":2,"c":3}`,
+			maxArrayLength: 1,
+		},
+		{
+			name: "zero limits are not enforced",
+			json: nested,
+		},
+		{
+			name:           "malformed json is left to the decoder",
+			json:           `{"a":[1,}`,
+			maxDepth:       2,
+			maxArrayLength: 2,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := checkJSONLimits([]byte(test.json), test.maxDepth, test.maxArrayLength)
+			if len(test.expectLimit) == 0 {
+				assertEqual(t, err, nil)
+				return
+			}
+			limitErr, ok := err.(*JSONLimitError)
+			if !ok {
+				t.Fatalf("expected *JSONLimitError, got %v", err)
+			}
+			assertEqual(t, limitErr.Limit, test.expectLimit)
+		})
+	}
+}
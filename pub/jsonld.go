@@ -0,0 +1,995 @@
+package pub
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-fed/activity/streams"
+)
+
+// The IRIs used when converting JSON-LD to RDF.
+const (
+	rdfType       = "http://www.w3.org/1999/02/22-rdf-syntax-ns#type"
+	rdfFirst      = "http://www.w3.org/1999/02/22-rdf-syntax-ns#first"
+	rdfRest       = "http://www.w3.org/1999/02/22-rdf-syntax-ns#rest"
+	rdfNil        = "http://www.w3.org/1999/02/22-rdf-syntax-ns#nil"
+	rdfLangString = "http://www.w3.org/1999/02/22-rdf-syntax-ns#langString"
+	xsdBoolean    = "http://www.w3.org/2001/XMLSchema#boolean"
+	xsdDouble     = "http://www.w3.org/2001/XMLSchema#double"
+	xsdInteger    = "http://www.w3.org/2001/XMLSchema#integer"
+	xsdString     = "http://www.w3.org/2001/XMLSchema#string"
+)
+
+// ldKeywords are the JSON-LD 1.0 keywords.
+var ldKeywords = map[string]bool{
+	"@context":   true,
+	"@id":        true,
+	"@value":     true,
+	"@language":  true,
+	"@type":      true,
+	"@container": true,
+	"@list":      true,
+	"@set":       true,
+	"@reverse":   true,
+	"@index":     true,
+	"@base":      true,
+	"@vocab":     true,
+	"@graph":     true,
+}
+
+// ldTermDef is the definition of a term in an active JSON-LD context.
+type ldTermDef struct {
+	id          string
+	reverse     bool
+	typ         string
+	container   string
+	language    string
+	hasLanguage bool
+}
+
+// ldContext is an active JSON-LD context. A term mapped to a nil definition
+// is explicitly undefined, and is not expanded with the vocabulary mapping.
+type ldContext struct {
+	vocab       string
+	hasVocab    bool
+	language    string
+	hasLanguage bool
+	terms       map[string]*ldTermDef
+}
+
+// clone returns a copy of the context that may be modified.
+func (a *ldContext) clone() *ldContext {
+	c := *a
+	c.terms = make(map[string]*ldTermDef, len(a.terms))
+	for k, v := range a.terms {
+		c.terms[k] = v
+	}
+	return &c
+}
+
+// jsonLDProcessor converts JSON-LD 1.0 documents to RDF, loading the remote
+// contexts they reference with a ContextLoader.
+type jsonLDProcessor struct {
+	c      context.Context
+	loader *streams.ContextLoader
+	// bnodes issues the blank node identifiers of the RDF dataset.
+	bnodes *bnodeIssuer
+}
+
+// toRDF expands the JSON-LD document and returns its RDF dataset. Relative
+// IRIs and blank node predicates are not representable and are dropped.
+func (p *jsonLDProcessor) toRDF(doc map[string]interface{}) ([]rdfQuad, error) {
+	p.bnodes = newBnodeIssuer("_:b")
+	expanded, err := p.expand(&ldContext{terms: map[string]*ldTermDef{}}, "", doc)
+	if err != nil {
+		return nil, err
+	}
+	if m, ok := expanded.(map[string]interface{}); ok {
+		if g, ok := m["@graph"]; ok && len(m) == 1 {
+			expanded = g
+		}
+	}
+	nodeMap := map[string]map[string]map[string]interface{}{
+		"@default": {},
+	}
+	if expanded != nil {
+		if err = p.generateNodeMap(asLDArray(expanded), nodeMap, "@default", "", nil, "", nil); err != nil {
+			return nil, err
+		}
+	}
+	var quads []rdfQuad
+	for _, graphName := range sortedKeys(nodeMap) {
+		var g rdfNode
+		if graphName != "@default" {
+			if !isAbsoluteOrBlank(graphName) {
+				continue
+			}
+			g = iriOrBnode(graphName)
+		}
+		graph := nodeMap[graphName]
+		for _, subject := range sortedKeys(graph) {
+			if !isAbsoluteOrBlank(subject) {
+				continue
+			}
+			s := iriOrBnode(subject)
+			node := graph[subject]
+			for _, property := range sortedKeys(node) {
+				if property == "@type" {
+					for _, t := range node[property].([]interface{}) {
+						if ts, ok := t.(string); ok && isAbsoluteOrBlank(ts) {
+							quads = append(quads, rdfQuad{s, rdfNode{kind: rdfIRI, value: rdfType}, iriOrBnode(ts), g})
+						}
+					}
+					continue
+				} else if ldKeywords[property] || strings.HasPrefix(property, "_:") || !isAbsoluteIRI(property) {
+					continue
+				}
+				pred := rdfNode{kind: rdfIRI, value: property}
+				for _, item := range node[property].([]interface{}) {
+					im, _ := item.(map[string]interface{})
+					if list, ok := im["@list"]; ok {
+						head, listQuads := p.listToRDF(list.([]interface{}), g)
+						quads = append(quads, listQuads...)
+						quads = append(quads, rdfQuad{s, pred, head, g})
+					} else if o, ok := objectToRDF(im); ok {
+						quads = append(quads, rdfQuad{s, pred, o, g})
+					}
+				}
+			}
+		}
+	}
+	return quads, nil
+}
+
+// listToRDF returns the head of the RDF list of the items, and the quads
+// making up the list.
+func (p *jsonLDProcessor) listToRDF(items []interface{}, g rdfNode) (rdfNode, []rdfQuad) {
+	if len(items) == 0 {
+		return rdfNode{kind: rdfIRI, value: rdfNil}, nil
+	}
+	ids := make([]rdfNode, len(items))
+	for i := range items {
+		ids[i] = rdfNode{kind: rdfBlank, value: p.bnodes.issue("")}
+	}
+	var quads []rdfQuad
+	for i, item := range items {
+		im, _ := item.(map[string]interface{})
+		if o, ok := objectToRDF(im); ok {
+			quads = append(quads, rdfQuad{ids[i], rdfNode{kind: rdfIRI, value: rdfFirst}, o, g})
+		}
+		rest := rdfNode{kind: rdfIRI, value: rdfNil}
+		if i+1 < len(ids) {
+			rest = ids[i+1]
+		}
+		quads = append(quads, rdfQuad{ids[i], rdfNode{kind: rdfIRI, value: rdfRest}, rest, g})
+	}
+	return ids[0], quads
+}
+
+// objectToRDF converts a node reference or value object to an RDF node, or
+// returns false if it cannot be represented.
+func objectToRDF(item map[string]interface{}) (rdfNode, bool) {
+	if _, ok := item["@value"]; !ok {
+		id, ok := item["@id"].(string)
+		if !ok || !isAbsoluteOrBlank(id) {
+			return rdfNode{}, false
+		}
+		return iriOrBnode(id), true
+	}
+	value := item["@value"]
+	datatype, _ := item["@type"].(string)
+	n := rdfNode{kind: rdfLiteral}
+	switch v := value.(type) {
+	case bool:
+		n.value = strconv.FormatBool(v)
+		if len(datatype) == 0 {
+			datatype = xsdBoolean
+		}
+	case float64, json.Number:
+		f, integer, err := ldNumber(v)
+		if err != nil {
+			return rdfNode{}, false
+		}
+		if integer && datatype != xsdDouble {
+			n.value = integerLexical(v, f)
+			if len(datatype) == 0 {
+				datatype = xsdInteger
+			}
+		} else {
+			n.value = canonicalDouble(f)
+			if len(datatype) == 0 {
+				datatype = xsdDouble
+			}
+		}
+	case string:
+		n.value = v
+		if lang, ok := item["@language"].(string); ok {
+			datatype = rdfLangString
+			n.language = lang
+		} else if len(datatype) == 0 {
+			datatype = xsdString
+		}
+	default:
+		return rdfNode{}, false
+	}
+	n.datatype = datatype
+	return n, true
+}
+
+// ldNumber returns the value of a JSON number and whether JSON-LD treats it
+// as an integer.
+func ldNumber(v interface{}) (f float64, integer bool, err error) {
+	switch n := v.(type) {
+	case float64:
+		f = n
+	case json.Number:
+		if f, err = n.Float64(); err != nil {
+			return
+		}
+	}
+	integer = f == math.Trunc(f) && math.Abs(f) < 1e21
+	return
+}
+
+// integerLexical returns the lexical form of an integer JSON number, keeping
+// the digits of large integers when they were received as a json.Number.
+func integerLexical(v interface{}, f float64) string {
+	if n, ok := v.(json.Number); ok && !strings.ContainsAny(string(n), ".eE") {
+		return strings.TrimPrefix(string(n), "+")
+	}
+	return strconv.FormatFloat(f, 'f', 0, 64)
+}
+
+// canonicalDouble returns the canonical lexical form of an xsd:double, such
+// as "1.1E0".
+func canonicalDouble(f float64) string {
+	s := strconv.FormatFloat(f, 'e', 15, 64)
+	i := strings.IndexByte(s, 'e')
+	mantissa := strings.TrimRight(s[:i], "0")
+	if strings.HasSuffix(mantissa, ".") {
+		mantissa += "0"
+	}
+	exp, _ := strconv.Atoi(s[i+1:])
+	return mantissa + "E" + strconv.Itoa(exp)
+}
+
+// processContext returns the active context updated with the local context.
+// The remote contexts being loaded are tracked to detect cycles.
+func (p *jsonLDProcessor) processContext(active *ldContext, local interface{}, remote []string) (*ldContext, error) {
+	result := active.clone()
+	for _, ctx := range asLDArray(local) {
+		switch v := ctx.(type) {
+		case nil:
+			result = &ldContext{terms: map[string]*ldTermDef{}}
+		case string:
+			for _, r := range remote {
+				if r == v {
+					return nil, fmt.Errorf("json-ld context %s includes itself", v)
+				}
+			}
+			doc, err := p.loader.Load(p.c, v)
+			if err != nil {
+				return nil, err
+			}
+			c, ok := doc["@context"]
+			if !ok {
+				return nil, fmt.Errorf("json-ld context %s has no @context", v)
+			}
+			if result, err = p.processContext(result, c, append(remote, v)); err != nil {
+				return nil, err
+			}
+		case map[string]interface{}:
+			if vocab, ok := v["@vocab"]; ok {
+				if vocab == nil {
+					result.vocab, result.hasVocab = "", false
+				} else if s, ok := vocab.(string); ok && isAbsoluteOrBlank(s) {
+					result.vocab, result.hasVocab = s, true
+				} else {
+					return nil, fmt.Errorf("invalid json-ld @vocab: %v", vocab)
+				}
+			}
+			if lang, ok := v["@language"]; ok {
+				if lang == nil {
+					result.language, result.hasLanguage = "", false
+				} else if s, ok := lang.(string); ok {
+					result.language, result.hasLanguage = strings.ToLower(s), true
+				} else {
+					return nil, fmt.Errorf("invalid json-ld @language: %v", lang)
+				}
+			}
+			defined := make(map[string]bool)
+			for _, term := range sortedKeys(v) {
+				if term == "@base" || term == "@vocab" || term == "@language" {
+					continue
+				}
+				if err := p.createTermDefinition(result, v, term, defined); err != nil {
+					return nil, err
+				}
+			}
+		default:
+			return nil, fmt.Errorf("invalid json-ld local context: %v", ctx)
+		}
+	}
+	return result, nil
+}
+
+// createTermDefinition defines the term of the local context in the active
+// context. The terms being or already defined are tracked to detect cycles.
+func (p *jsonLDProcessor) createTermDefinition(active *ldContext, local map[string]interface{}, term string, defined map[string]bool) error {
+	if done, ok := defined[term]; ok {
+		if done {
+			return nil
+		}
+		return fmt.Errorf("json-ld term %q is defined cyclically", term)
+	}
+	defined[term] = false
+	if ldKeywords[term] {
+		return fmt.Errorf("json-ld keyword %s cannot be redefined", term)
+	}
+	delete(active.terms, term)
+	value := local[term]
+	if s, ok := value.(string); ok {
+		value = map[string]interface{}{"@id": s}
+	}
+	if value == nil {
+		active.terms[term] = nil
+		defined[term] = true
+		return nil
+	}
+	m, ok := value.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("invalid json-ld definition of term %q", term)
+	}
+	if id, ok := m["@id"]; ok && id == nil {
+		active.terms[term] = nil
+		defined[term] = true
+		return nil
+	}
+	def := &ldTermDef{}
+	if t, ok := m["@type"]; ok {
+		s, ok := t.(string)
+		if !ok {
+			return fmt.Errorf("invalid json-ld @type of term %q", term)
+		}
+		typ, ok, err := p.expandIRI(active, s, false, true, local, defined)
+		if err != nil {
+			return err
+		} else if !ok || (typ != "@id" && typ != "@vocab" && !isAbsoluteIRI(typ)) {
+			return fmt.Errorf("invalid json-ld @type of term %q: %s", term, s)
+		}
+		def.typ = typ
+	}
+	if r, ok := m["@reverse"]; ok {
+		if _, ok := m["@id"]; ok {
+			return fmt.Errorf("json-ld term %q has both @id and @reverse", term)
+		}
+		s, ok := r.(string)
+		if !ok {
+			return fmt.Errorf("invalid json-ld @reverse of term %q", term)
+		}
+		id, ok, err := p.expandIRI(active, s, false, true, local, defined)
+		if err != nil {
+			return err
+		} else if !ok || !isAbsoluteOrBlank(id) {
+			return fmt.Errorf("invalid json-ld @reverse of term %q: %s", term, s)
+		}
+		def.id = id
+		def.reverse = true
+	} else if id, ok := m["@id"]; ok && id != term {
+		s, ok := id.(string)
+		if !ok {
+			return fmt.Errorf("invalid json-ld @id of term %q", term)
+		}
+		expanded, ok, err := p.expandIRI(active, s, false, true, local, defined)
+		if err != nil {
+			return err
+		} else if !ok || (!ldKeywords[expanded] && !isAbsoluteOrBlank(expanded)) || expanded == "@context" {
+			return fmt.Errorf("invalid json-ld @id of term %q: %s", term, s)
+		}
+		def.id = expanded
+	} else if i := strings.IndexByte(term, ':'); i >= 0 {
+		prefix, suffix := term[:i], term[i+1:]
+		if _, ok := local[prefix]; ok {
+			if err := p.createTermDefinition(active, local, prefix, defined); err != nil {
+				return err
+			}
+		}
+		if pd := active.terms[prefix]; pd != nil {
+			def.id = pd.id + suffix
+		} else {
+			def.id = term
+		}
+	} else if active.hasVocab {
+		def.id = active.vocab + term
+	} else {
+		return fmt.Errorf("json-ld term %q has no IRI", term)
+	}
+	if c, ok := m["@container"]; ok {
+		s, _ := c.(string)
+		switch s {
+		case "@list", "@set", "@index", "@language":
+		default:
+			return fmt.Errorf("invalid json-ld @container of term %q: %v", term, c)
+		}
+		if def.reverse && s != "@set" && s != "@index" {
+			return fmt.Errorf("invalid json-ld @container of reverse term %q: %v", term, c)
+		}
+		def.container = s
+	}
+	if lang, ok := m["@language"]; ok && len(def.typ) == 0 {
+		if lang == nil {
+			def.hasLanguage = true
+		} else if s, ok := lang.(string); ok {
+			def.language, def.hasLanguage = strings.ToLower(s), true
+		} else {
+			return fmt.Errorf("invalid json-ld @language of term %q", term)
+		}
+	}
+	active.terms[term] = def
+	defined[term] = true
+	return nil
+}
+
+// expandIRI expands a term, compact IRI, or relative IRI. It returns false if
+// the value expands to null. Relative IRIs are left relative, as documents are
+// processed without a base IRI.
+func (p *jsonLDProcessor) expandIRI(active *ldContext, value string, documentRelative, vocab bool, local map[string]interface{}, defined map[string]bool) (string, bool, error) {
+	if ldKeywords[value] {
+		return value, true, nil
+	}
+	if local != nil {
+		if _, ok := local[value]; ok && !defined[value] {
+			if err := p.createTermDefinition(active, local, value, defined); err != nil {
+				return "", false, err
+			}
+		}
+	}
+	if vocab {
+		if def, ok := active.terms[value]; ok {
+			if def == nil {
+				return "", false, nil
+			}
+			return def.id, true, nil
+		}
+	}
+	if i := strings.IndexByte(value, ':'); i >= 0 {
+		prefix, suffix := value[:i], value[i+1:]
+		if prefix == "_" || strings.HasPrefix(suffix, "//") {
+			return value, true, nil
+		}
+		if local != nil {
+			if _, ok := local[prefix]; ok && !defined[prefix] {
+				if err := p.createTermDefinition(active, local, prefix, defined); err != nil {
+					return "", false, err
+				}
+			}
+		}
+		if def := active.terms[prefix]; def != nil {
+			return def.id + suffix, true, nil
+		}
+		return value, true, nil
+	}
+	if vocab && active.hasVocab {
+		return active.vocab + value, true, nil
+	}
+	return value, true, nil
+}
+
+// expand expands the element of the JSON-LD document, in which it is the value
+// of the active property. The active property is empty at the top level.
+func (p *jsonLDProcessor) expand(active *ldContext, activeProperty string, element interface{}) (interface{}, error) {
+	switch v := element.(type) {
+	case nil:
+		return nil, nil
+	case []interface{}:
+		var container string
+		if def := active.terms[activeProperty]; def != nil {
+			container = def.container
+		}
+		result := []interface{}{}
+		for _, item := range v {
+			expanded, err := p.expand(active, activeProperty, item)
+			if err != nil {
+				return nil, err
+			}
+			if (activeProperty == "@list" || container == "@list") && isLDListOrArray(expanded) {
+				return nil, fmt.Errorf("json-ld lists of lists are not supported")
+			}
+			if arr, ok := expanded.([]interface{}); ok {
+				result = append(result, arr...)
+			} else if expanded != nil {
+				result = append(result, expanded)
+			}
+		}
+		return result, nil
+	case map[string]interface{}:
+		return p.expandObject(active, activeProperty, v)
+	default:
+		if len(activeProperty) == 0 || activeProperty == "@graph" {
+			return nil, nil
+		}
+		return p.expandValue(active, activeProperty, v)
+	}
+}
+
+// expandObject expands a JSON object of the JSON-LD document.
+func (p *jsonLDProcessor) expandObject(active *ldContext, activeProperty string, element map[string]interface{}) (interface{}, error) {
+	var err error
+	if ctx, ok := element["@context"]; ok {
+		if active, err = p.processContext(active, ctx, nil); err != nil {
+			return nil, err
+		}
+	}
+	result := make(map[string]interface{})
+	for _, key := range sortedKeys(element) {
+		if key == "@context" {
+			continue
+		}
+		value := element[key]
+		property, ok, err := p.expandIRI(active, key, false, true, nil, nil)
+		if err != nil {
+			return nil, err
+		} else if !ok || (!strings.Contains(property, ":") && !ldKeywords[property]) {
+			continue
+		}
+		if ldKeywords[property] {
+			if activeProperty == "@reverse" {
+				return nil, fmt.Errorf("json-ld @reverse map has keyword %s", property)
+			} else if _, ok := result[property]; ok {
+				return nil, fmt.Errorf("json-ld object has colliding keyword %s", property)
+			}
+			var expanded interface{}
+			switch property {
+			case "@id":
+				s, ok := value.(string)
+				if !ok {
+					return nil, fmt.Errorf("invalid json-ld @id: %v", value)
+				}
+				if expanded, _, err = p.expandIRI(active, s, true, false, nil, nil); err != nil {
+					return nil, err
+				}
+			case "@type":
+				var types []interface{}
+				for _, t := range asLDArray(value) {
+					s, ok := t.(string)
+					if !ok {
+						return nil, fmt.Errorf("invalid json-ld @type: %v", value)
+					}
+					typ, ok, err := p.expandIRI(active, s, true, true, nil, nil)
+					if err != nil {
+						return nil, err
+					} else if ok {
+						types = append(types, typ)
+					}
+				}
+				expanded = types
+			case "@graph":
+				if expanded, err = p.expand(active, "@graph", value); err != nil {
+					return nil, err
+				}
+			case "@value":
+				switch value.(type) {
+				case nil, string, bool, float64, json.Number:
+				default:
+					return nil, fmt.Errorf("invalid json-ld @value: %v", value)
+				}
+				result["@value"] = value
+				continue
+			case "@language":
+				s, ok := value.(string)
+				if !ok {
+					return nil, fmt.Errorf("invalid json-ld @language: %v", value)
+				}
+				expanded = strings.ToLower(s)
+			case "@index":
+				s, ok := value.(string)
+				if !ok {
+					return nil, fmt.Errorf("invalid json-ld @index: %v", value)
+				}
+				expanded = s
+			case "@list":
+				if len(activeProperty) == 0 || activeProperty == "@graph" {
+					continue
+				}
+				if expanded, err = p.expand(active, activeProperty, value); err != nil {
+					return nil, err
+				}
+				expanded = asLDArray(expanded)
+				for _, item := range expanded.([]interface{}) {
+					if isLDListOrArray(item) {
+						return nil, fmt.Errorf("json-ld lists of lists are not supported")
+					}
+				}
+			case "@set":
+				if expanded, err = p.expand(active, activeProperty, value); err != nil {
+					return nil, err
+				}
+			case "@reverse":
+				if _, ok := value.(map[string]interface{}); !ok {
+					return nil, fmt.Errorf("invalid json-ld @reverse: %v", value)
+				}
+				r, err := p.expand(active, "@reverse", value)
+				if err != nil {
+					return nil, err
+				}
+				rm, _ := r.(map[string]interface{})
+				if nested, ok := rm["@reverse"].(map[string]interface{}); ok {
+					for prop, items := range nested {
+						addLDValues(result, prop, items)
+					}
+				}
+				for prop, items := range rm {
+					if prop == "@reverse" {
+						continue
+					}
+					for _, item := range asLDArray(items) {
+						if isLDValueOrList(item) {
+							return nil, fmt.Errorf("invalid json-ld reverse property value")
+						}
+						addLDReverse(result, prop, item)
+					}
+				}
+				continue
+			default:
+				continue
+			}
+			if expanded != nil {
+				result[property] = expanded
+			}
+			continue
+		}
+		def := active.terms[key]
+		var container string
+		if def != nil {
+			container = def.container
+		}
+		var expanded interface{}
+		if vm, ok := value.(map[string]interface{}); ok && container == "@language" {
+			items := []interface{}{}
+			for _, lang := range sortedKeys(vm) {
+				for _, item := range asLDArray(vm[lang]) {
+					s, ok := item.(string)
+					if !ok {
+						return nil, fmt.Errorf("invalid json-ld language map value: %v", item)
+					}
+					items = append(items, map[string]interface{}{"@value": s, "@language": strings.ToLower(lang)})
+				}
+			}
+			expanded = items
+		} else if vm, ok := value.(map[string]interface{}); ok && container == "@index" {
+			items := []interface{}{}
+			for _, index := range sortedKeys(vm) {
+				indexed, err := p.expand(active, key, asLDArray(vm[index]))
+				if err != nil {
+					return nil, err
+				}
+				for _, item := range asLDArray(indexed) {
+					if im, ok := item.(map[string]interface{}); ok {
+						if _, ok := im["@index"]; !ok {
+							im["@index"] = index
+						}
+					}
+					items = append(items, item)
+				}
+			}
+			expanded = items
+		} else if expanded, err = p.expand(active, key, value); err != nil {
+			return nil, err
+		}
+		if expanded == nil {
+			continue
+		}
+		if container == "@list" && !isLDList(expanded) {
+			expanded = map[string]interface{}{"@list": asLDArray(expanded)}
+		}
+		if def != nil && def.reverse {
+			for _, item := range asLDArray(expanded) {
+				if isLDValueOrList(item) {
+					return nil, fmt.Errorf("invalid json-ld reverse property value")
+				}
+				addLDReverse(result, property, item)
+			}
+			continue
+		}
+		addLDValues(result, property, expanded)
+	}
+	if value, ok := result["@value"]; ok {
+		for k := range result {
+			switch k {
+			case "@value", "@language", "@type", "@index":
+			default:
+				return nil, fmt.Errorf("invalid json-ld value object with %s", k)
+			}
+		}
+		_, hasLang := result["@language"]
+		types, hasType := result["@type"]
+		if hasLang && hasType {
+			return nil, fmt.Errorf("json-ld value object has both @language and @type")
+		}
+		if value == nil {
+			return nil, nil
+		}
+		if _, ok := value.(string); !ok && hasLang {
+			return nil, fmt.Errorf("json-ld value object with @language is not a string")
+		}
+		if hasType {
+			arr, _ := types.([]interface{})
+			if len(arr) != 1 {
+				return nil, fmt.Errorf("invalid json-ld typed value")
+			}
+			result["@type"] = arr[0]
+		}
+	} else if set, ok := result["@set"]; ok {
+		if len(result) > 2 || (len(result) == 2 && result["@index"] == nil) {
+			return nil, fmt.Errorf("invalid json-ld set object")
+		}
+		return set, nil
+	} else if _, ok := result["@list"]; ok {
+		if len(result) > 2 || (len(result) == 2 && result["@index"] == nil) {
+			return nil, fmt.Errorf("invalid json-ld list object")
+		}
+	}
+	if _, ok := result["@language"]; ok && len(result) == 1 {
+		return nil, nil
+	}
+	if len(activeProperty) == 0 || activeProperty == "@graph" {
+		_, hasValue := result["@value"]
+		_, hasList := result["@list"]
+		_, hasId := result["@id"]
+		if len(result) == 0 || hasValue || hasList || (hasId && len(result) == 1) {
+			return nil, nil
+		}
+	}
+	return result, nil
+}
+
+// expandValue expands a scalar value of the active property.
+func (p *jsonLDProcessor) expandValue(active *ldContext, activeProperty string, value interface{}) (interface{}, error) {
+	def := active.terms[activeProperty]
+	if s, ok := value.(string); ok && def != nil && (def.typ == "@id" || def.typ == "@vocab") {
+		id, ok, err := p.expandIRI(active, s, true, def.typ == "@vocab", nil, nil)
+		if err != nil || !ok {
+			return nil, err
+		}
+		return map[string]interface{}{"@id": id}, nil
+	}
+	result := map[string]interface{}{"@value": value}
+	if def != nil && len(def.typ) > 0 && def.typ != "@id" && def.typ != "@vocab" {
+		result["@type"] = def.typ
+	} else if _, ok := value.(string); ok {
+		if def != nil && def.hasLanguage {
+			if len(def.language) > 0 {
+				result["@language"] = def.language
+			}
+		} else if active.hasLanguage {
+			result["@language"] = active.language
+		}
+	}
+	return result, nil
+}
+
+// generateNodeMap flattens the expanded element into the node map, labeling
+// every blank node. The active subject is either a subject identifier or,
+// for reverse properties, a node reference.
+func (p *jsonLDProcessor) generateNodeMap(element interface{}, nodeMap map[string]map[string]map[string]interface{}, activeGraph, activeSubject string, reverseSubject map[string]interface{}, activeProperty string, list map[string]interface{}) error {
+	if arr, ok := element.([]interface{}); ok {
+		for _, item := range arr {
+			if err := p.generateNodeMap(item, nodeMap, activeGraph, activeSubject, reverseSubject, activeProperty, list); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	el, ok := element.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("invalid expanded json-ld element: %v", element)
+	}
+	graph, ok := nodeMap[activeGraph]
+	if !ok {
+		graph = make(map[string]map[string]interface{})
+		nodeMap[activeGraph] = graph
+	}
+	if types, ok := el["@type"]; ok {
+		if arr, ok := types.([]interface{}); ok {
+			for i, t := range arr {
+				if s, ok := t.(string); ok && strings.HasPrefix(s, "_:") {
+					arr[i] = p.bnodes.issue(s)
+				}
+			}
+		} else if s, ok := types.(string); ok && strings.HasPrefix(s, "_:") {
+			el["@type"] = p.bnodes.issue(s)
+		}
+	}
+	if _, ok := el["@value"]; ok {
+		if list == nil {
+			addLDUnique(graph[activeSubject], activeProperty, el)
+		} else {
+			list["@list"] = append(list["@list"].([]interface{}), el)
+		}
+		return nil
+	}
+	if items, ok := el["@list"]; ok {
+		result := map[string]interface{}{"@list": []interface{}{}}
+		if err := p.generateNodeMap(items, nodeMap, activeGraph, activeSubject, reverseSubject, activeProperty, result); err != nil {
+			return err
+		}
+		graph[activeSubject][activeProperty] = append(asLDArray(graph[activeSubject][activeProperty]), result)
+		return nil
+	}
+	var id string
+	if s, ok := el["@id"].(string); ok {
+		id = s
+		if strings.HasPrefix(id, "_:") {
+			id = p.bnodes.issue(id)
+		}
+	} else {
+		id = p.bnodes.issue("")
+	}
+	node, ok := graph[id]
+	if !ok {
+		node = map[string]interface{}{"@id": id}
+		graph[id] = node
+	}
+	if reverseSubject != nil {
+		addLDUnique(node, activeProperty, reverseSubject)
+	} else if len(activeProperty) > 0 {
+		ref := map[string]interface{}{"@id": id}
+		if list == nil {
+			addLDUnique(graph[activeSubject], activeProperty, ref)
+		} else {
+			list["@list"] = append(list["@list"].([]interface{}), ref)
+		}
+	}
+	if types, ok := el["@type"]; ok {
+		for _, t := range asLDArray(types) {
+			addLDUnique(node, "@type", t)
+		}
+	}
+	if index, ok := el["@index"]; ok {
+		if existing, ok := node["@index"]; ok && existing != index {
+			return fmt.Errorf("json-ld node %s has conflicting indexes", id)
+		}
+		node["@index"] = index
+	}
+	if r, ok := el["@reverse"].(map[string]interface{}); ok {
+		ref := map[string]interface{}{"@id": id}
+		for _, prop := range sortedKeys(r) {
+			for _, item := range asLDArray(r[prop]) {
+				if err := p.generateNodeMap(item, nodeMap, activeGraph, "", ref, prop, nil); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	if g, ok := el["@graph"]; ok {
+		if err := p.generateNodeMap(g, nodeMap, id, "", nil, "", nil); err != nil {
+			return err
+		}
+	}
+	for _, prop := range sortedKeys(el) {
+		switch prop {
+		case "@id", "@type", "@index", "@reverse", "@graph":
+			continue
+		}
+		value := el[prop]
+		if strings.HasPrefix(prop, "_:") {
+			prop = p.bnodes.issue(prop)
+		}
+		if _, ok := node[prop]; !ok {
+			node[prop] = []interface{}{}
+		}
+		if err := p.generateNodeMap(value, nodeMap, activeGraph, id, nil, prop, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addLDValues appends the values to the property of the expanded object.
+func addLDValues(m map[string]interface{}, property string, values interface{}) {
+	m[property] = append(asLDArray(m[property]), asLDArray(values)...)
+}
+
+// addLDReverse appends the value to the reverse property of the expanded
+// object.
+func addLDReverse(m map[string]interface{}, property string, value interface{}) {
+	r, ok := m["@reverse"].(map[string]interface{})
+	if !ok {
+		r = make(map[string]interface{})
+		m["@reverse"] = r
+	}
+	addLDValues(r, property, value)
+}
+
+// addLDUnique appends the value to the property of the node, unless it is
+// already there.
+func addLDUnique(node map[string]interface{}, property string, value interface{}) {
+	values := asLDArray(node[property])
+	for _, v := range values {
+		if reflect.DeepEqual(v, value) {
+			node[property] = values
+			return
+		}
+	}
+	node[property] = append(values, value)
+}
+
+// asLDArray returns the value as an array, which is empty for nil.
+func asLDArray(v interface{}) []interface{} {
+	switch a := v.(type) {
+	case nil:
+		return []interface{}{}
+	case []interface{}:
+		return a
+	default:
+		return []interface{}{v}
+	}
+}
+
+// isLDList returns whether the expanded value is a list object.
+func isLDList(v interface{}) bool {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	_, ok = m["@list"]
+	return ok
+}
+
+// isLDListOrArray returns whether the expanded value is a list object or an
+// array.
+func isLDListOrArray(v interface{}) bool {
+	_, ok := v.([]interface{})
+	return ok || isLDList(v)
+}
+
+// isLDValueOrList returns whether the expanded value is a value or list
+// object.
+func isLDValueOrList(v interface{}) bool {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	_, isValue := m["@value"]
+	_, isList := m["@list"]
+	return isValue || isList
+}
+
+// isAbsoluteIRI returns whether the value is an absolute IRI, having a
+// scheme.
+func isAbsoluteIRI(s string) bool {
+	i := strings.IndexByte(s, ':')
+	if i <= 0 || strings.HasPrefix(s, "_:") {
+		return false
+	}
+	for j, r := range s[:i] {
+		isLetter := (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+		if !isLetter && (j == 0 || !((r >= '0' && r <= '9') || r == '+' || r == '-' || r == '.')) {
+			return false
+		}
+	}
+	return true
+}
+
+// isAbsoluteOrBlank returns whether the value is an absolute IRI or a blank
+// node identifier.
+func isAbsoluteOrBlank(s string) bool {
+	return strings.HasPrefix(s, "_:") || isAbsoluteIRI(s)
+}
+
+// sortedKeys returns the keys of the map in lexicographical order.
+func sortedKeys(m interface{}) []string {
+	v := reflect.ValueOf(m)
+	keys := make([]string, 0, v.Len())
+	for _, k := range v.MapKeys() {
+		keys = append(keys, k.String())
+	}
+	sort.Strings(keys)
+	return keys
+}
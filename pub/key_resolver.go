@@ -0,0 +1,464 @@
+package pub
+
+import (
+	"context"
+	"crypto"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-fed/activity/streams"
+	"github.com/go-fed/activity/streams/vocab"
+	"github.com/go-fed/httpsig"
+)
+
+const (
+	// DefaultKeyCacheTTL is how long a KeyResolver caches public keys when
+	// no TTL is specified.
+	DefaultKeyCacheTTL = time.Hour
+)
+
+// KeyResolver resolves the public keys identified by the 'keyId' of HTTP
+// Signatures, caching them in memory.
+//
+// It is meant to be used when implementing AuthenticatePostInbox and
+// AuthenticateGetInbox, and is safe to use concurrently.
+type KeyResolver struct {
+	clock Clock
+	ttl   time.Duration
+	mu    sync.Mutex
+	keys  map[string]cachedKey
+}
+
+// cachedKey is a public key in a KeyResolver's cache.
+type cachedKey struct {
+	key     crypto.PublicKey
+	owner   *url.URL
+	expires time.Time
+}
+
+// NewKeyResolver returns a new KeyResolver caching keys for the ttl. A zero ttl
+// uses DefaultKeyCacheTTL.
+func NewKeyResolver(clock Clock, ttl time.Duration) *KeyResolver {
+	if ttl == 0 {
+		ttl = DefaultKeyCacheTTL
+	}
+	return &KeyResolver{
+		clock: clock,
+		ttl:   ttl,
+		keys:  make(map[string]cachedKey),
+	}
+}
+
+// Resolve returns the public key with the keyId, from the cache if present.
+//
+// Otherwise, the keyId is dereferenced with the Transport, without its
+// fragment. The result may be either an actor whose 'publicKey' property
+// contains the key with a matching 'id', as is the case for the fragment
+// keyIds used by Mastodon, or a standalone key document. The key's
+// 'publicKeyPem' is parsed and cached along with its owner.
+//
+// The owner of a key embedded in an actor is that actor, and the key's
+// 'owner', if any, must agree. A standalone key document must have an 'owner'
+// or a 'controller'. Either way, the owner must be on the same origin as the
+// keyId. Unless the document fetched is the owner itself, the owner is
+// dereferenced in turn and must list the key in its 'publicKey' property, so
+// that a document cannot claim a key for an actor that does not vouch for it.
+func (k *KeyResolver) Resolve(c context.Context, t Transport, keyId *url.URL) (crypto.PublicKey, error) {
+	ck, err := k.resolve(c, t, keyId)
+	if err != nil {
+		return nil, err
+	}
+	return ck.key, nil
+}
+
+// Owner returns the actor owning the key with the keyId, resolving the key as
+// Resolve does if it is not cached.
+func (k *KeyResolver) Owner(c context.Context, t Transport, keyId *url.URL) (*url.URL, error) {
+	ck, err := k.resolve(c, t, keyId)
+	if err != nil {
+		return nil, err
+	}
+	return ck.owner, nil
+}
+
+// Invalidate removes the key with the keyId from the cache.
+func (k *KeyResolver) Invalidate(keyId *url.URL) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	delete(k.keys, keyId.String())
+}
+
+// Verify verifies the HTTP Signature with the public key of its keyId.
+//
+// If verification fails using a cached key, the peer may have rotated its
+// key. So the key is invalidated and fetched again once, before verifying a
+// second time. This is not done when a *VerificationError reports a failure
+// unrelated to the key, such as an expired signature.
+func (k *KeyResolver) Verify(c context.Context, t Transport, v httpsig.Verifier, algo httpsig.Algorithm) error {
+	keyId, err := url.Parse(v.KeyId())
+	if err != nil {
+		return err
+	}
+	key, cached := k.get(keyId)
+	if !cached {
+		ck, err := k.fetch(c, t, keyId)
+		if err != nil {
+			return err
+		}
+		key = ck.key
+	}
+	err = v.Verify(key, algo)
+	if err == nil || !cached {
+		return err
+	} else if vErr, ok := err.(*VerificationError); ok && vErr.Reason != ReasonBadSignature {
+		return err
+	}
+	k.Invalidate(keyId)
+	ck, err := k.fetch(c, t, keyId)
+	if err != nil {
+		return err
+	}
+	return v.Verify(ck.key, algo)
+}
+
+// VerifyOwner is like Verify, but also requires the key to be owned by the
+// actor, such as the 'actor' of the activity being received. Otherwise, a peer
+// could sign with its own key an activity attributed to another actor.
+func (k *KeyResolver) VerifyOwner(c context.Context, t Transport, v httpsig.Verifier, algo httpsig.Algorithm, actor *url.URL) error {
+	if err := k.Verify(c, t, v, algo); err != nil {
+		return err
+	}
+	keyId, err := url.Parse(v.KeyId())
+	if err != nil {
+		return err
+	}
+	owner, err := k.Owner(c, t, keyId)
+	if err != nil {
+		return err
+	} else if owner.String() != actor.String() {
+		return fmt.Errorf("key %s is owned by %s, not %s", keyId, owner, actor)
+	}
+	return nil
+}
+
+// resolve obtains the key from the cache if present, fetching it otherwise.
+func (k *KeyResolver) resolve(c context.Context, t Transport, keyId *url.URL) (cachedKey, error) {
+	if ck, ok := k.getCached(keyId); ok {
+		return ck, nil
+	}
+	return k.fetch(c, t, keyId)
+}
+
+// get obtains an unexpired key from the cache.
+func (k *KeyResolver) get(keyId *url.URL) (crypto.PublicKey, bool) {
+	ck, ok := k.getCached(keyId)
+	return ck.key, ok
+}
+
+// getCached obtains an unexpired cache entry.
+func (k *KeyResolver) getCached(keyId *url.URL) (cachedKey, bool) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	ck, ok := k.keys[keyId.String()]
+	if !ok {
+		return cachedKey{}, false
+	} else if !k.clock.Now().Before(ck.expires) {
+		delete(k.keys, keyId.String())
+		return cachedKey{}, false
+	}
+	return ck, true
+}
+
+// fetch dereferences and parses the key, caching it.
+func (k *KeyResolver) fetch(c context.Context, t Transport, keyId *url.URL) (cachedKey, error) {
+	u := *keyId
+	u.Fragment = ""
+	v, err := dereferenceType(c, t, &u)
+	if err != nil {
+		return cachedKey{}, err
+	}
+	pk, owner, err := findPublicKey(v, keyId)
+	if err != nil {
+		return cachedKey{}, err
+	} else if !sameOrigin(owner, keyId) {
+		return cachedKey{}, fmt.Errorf("key %s is claimed by %s on another origin", keyId, owner)
+	}
+	if owner.String() != u.String() {
+		// The document fetched is not the owner itself, such as a
+		// standalone key document, so the owner must vouch for the key.
+		ownerT, err := dereferenceOwnType(c, t, owner)
+		if err != nil {
+			return cachedKey{}, err
+		} else if !listsPublicKey(ownerT, keyId) {
+			return cachedKey{}, fmt.Errorf("owner %s of key %s does not list it as a publicKey", owner, keyId)
+		}
+	}
+	pemKey, err := getPublicKeyPem(pk, keyId)
+	if err != nil {
+		return cachedKey{}, err
+	}
+	key, err := parsePublicKeyPem(pemKey)
+	if err != nil {
+		return cachedKey{}, err
+	}
+	return k.cache(keyId, key, owner), nil
+}
+
+// cache stores the key and its owner for the ttl.
+func (k *KeyResolver) cache(keyId *url.URL, key crypto.PublicKey, owner *url.URL) cachedKey {
+	ck := cachedKey{
+		key:     key,
+		owner:   owner,
+		expires: k.clock.Now().Add(k.ttl),
+	}
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.keys[keyId.String()] = ck
+	return ck
+}
+
+// UpdateKeys caches the keys with the keyIds as they are embedded in the
+// 'publicKey' property of the actor, such as when an Update of the actor
+// rotates its key, as detected by ChangedPublicKeys. A key the actor no longer
+// has is removed from the cache.
+//
+// Only a keyId within the actor's own document, such as its fragment, is
+// cached, as Resolve would find it there. Any other keyId, such as one of
+// another actor that the Update claims, is removed from the cache instead, so
+// that Resolve fetches it again from its keyId.
+func (k *KeyResolver) UpdateKeys(actor vocab.Type, keyIds []*url.URL) error {
+	for _, keyId := range keyIds {
+		pk, owner, err := findPublicKey(actor, keyId)
+		if err != nil || !isKeyOfDocument(keyId, owner) {
+			k.Invalidate(keyId)
+			continue
+		}
+		pemKey, err := getPublicKeyPem(pk, keyId)
+		if err != nil {
+			return err
+		}
+		key, err := parsePublicKeyPem(pemKey)
+		if err != nil {
+			return err
+		}
+		k.cache(keyId, key, owner)
+	}
+	return nil
+}
+
+// isKeyOfDocument determines whether the keyId is in the document of the
+// owner: without its fragment, it is the owner's id.
+func isKeyOfDocument(keyId, owner *url.URL) bool {
+	u := *keyId
+	u.Fragment = ""
+	return sameOrigin(owner, keyId) && u.String() == owner.String()
+}
+
+// ChangedPublicKeys returns the ids of the keys embedded in the 'publicKey'
+// property of the stored or the updated actor whose 'publicKeyPem' differs
+// between the two, including keys that were added or removed. Keys that are
+// only referenced by IRI are not compared.
+//
+// A peer rotating its key sends an Update of its actor with the new key, so
+// this detects the keys to update with the KeyResolver's UpdateKeys.
+func ChangedPublicKeys(stored, updated vocab.Type) []*url.URL {
+	before, after := embeddedPublicKeyPems(stored), embeddedPublicKeyPems(updated)
+	var changed []*url.URL
+	for id, pem := range after {
+		if b, ok := before[id]; !ok || b.pem != pem.pem {
+			changed = append(changed, pem.id)
+		}
+	}
+	for id, pem := range before {
+		if _, ok := after[id]; !ok {
+			changed = append(changed, pem.id)
+		}
+	}
+	sort.Slice(changed, func(i, j int) bool {
+		return changed[i].String() < changed[j].String()
+	})
+	return changed
+}
+
+// embeddedPublicKeyPem is the 'publicKeyPem' of a key with its id.
+type embeddedPublicKeyPem struct {
+	id  *url.URL
+	pem string
+}
+
+// embeddedPublicKeyPems returns the 'publicKeyPem' of the keys embedded in
+// the 'publicKey' property of the value, keyed by their id.
+func embeddedPublicKeyPems(v vocab.Type) map[string]embeddedPublicKeyPem {
+	pems := make(map[string]embeddedPublicKeyPem)
+	pker, ok := v.(publicKeyer)
+	if !ok {
+		return pems
+	}
+	pkp := pker.GetW3IDSecurityV1PublicKey()
+	if pkp == nil {
+		return pems
+	}
+	for iter := pkp.Begin(); iter != pkp.End(); iter = iter.Next() {
+		if !iter.IsW3IDSecurityV1PublicKey() {
+			continue
+		}
+		pk := iter.Get()
+		id := pk.GetJSONLDId()
+		if id == nil || id.Get() == nil {
+			continue
+		}
+		var pem string
+		if p := pk.GetW3IDSecurityV1PublicKeyPem(); p != nil && p.IsXMLSchemaString() {
+			pem = p.Get()
+		}
+		pems[id.Get().String()] = embeddedPublicKeyPem{id: id.Get(), pem: pem}
+	}
+	return pems
+}
+
+// dereferenceType dereferences the IRI into a value.
+func dereferenceType(c context.Context, t Transport, iri *url.URL) (vocab.Type, error) {
+	b, err := t.Dereference(c, iri)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]interface{}
+	if err = unmarshalJSON(b, &m); err != nil {
+		return nil, err
+	}
+	return streams.ToType(c, m)
+}
+
+// dereferenceOwnType dereferences the IRI into a value, requiring its 'id' to
+// be that IRI. Otherwise, the document served could claim to be any other.
+func dereferenceOwnType(c context.Context, t Transport, iri *url.URL) (vocab.Type, error) {
+	v, err := dereferenceType(c, t, iri)
+	if err != nil {
+		return nil, err
+	}
+	id, err := GetId(v)
+	if err != nil {
+		return nil, err
+	} else if id.String() != iri.String() {
+		return nil, fmt.Errorf("dereferenced %s has the id %s", iri, id)
+	}
+	return v, nil
+}
+
+// sameOrigin determines whether the IRIs have the same scheme and host.
+func sameOrigin(a, b *url.URL) bool {
+	return strings.EqualFold(a.Scheme, b.Scheme) && strings.EqualFold(a.Host, b.Host)
+}
+
+// findPublicKey obtains the key with the keyId and its owner. The key is
+// either the value itself, owned by its 'owner' or 'controller', or in the
+// 'publicKey' property of an actor owning it.
+func findPublicKey(v vocab.Type, keyId *url.URL) (vocab.W3IDSecurityV1PublicKey, *url.URL, error) {
+	if pk, ok := v.(vocab.W3IDSecurityV1PublicKey); ok {
+		if id := pk.GetJSONLDId(); id == nil || id.Get().String() != keyId.String() {
+			return nil, nil, fmt.Errorf("dereferenced key does not have the id %s", keyId)
+		}
+		owner := publicKeyOwner(pk)
+		if owner == nil {
+			return nil, nil, fmt.Errorf("key %s has neither an owner nor a controller", keyId)
+		}
+		return pk, owner, nil
+	}
+	pker, ok := v.(publicKeyer)
+	if !ok {
+		return nil, nil, fmt.Errorf("dereferenced %s is neither a key nor has a publicKey: %T", keyId, v)
+	}
+	actor, err := GetId(v)
+	if err != nil {
+		return nil, nil, err
+	}
+	pkp := pker.GetW3IDSecurityV1PublicKey()
+	if pkp == nil {
+		return nil, nil, fmt.Errorf("dereferenced %s has no publicKey", keyId)
+	}
+	for iter := pkp.Begin(); iter != pkp.End(); iter = iter.Next() {
+		if !iter.IsW3IDSecurityV1PublicKey() {
+			continue
+		}
+		pk := iter.Get()
+		if id := pk.GetJSONLDId(); id == nil || id.Get().String() != keyId.String() {
+			continue
+		}
+		if owner := publicKeyOwner(pk); owner != nil && owner.String() != actor.String() {
+			return nil, nil, fmt.Errorf("key %s in actor %s is owned by %s", keyId, actor, owner)
+		}
+		return pk, actor, nil
+	}
+	return nil, nil, fmt.Errorf("dereferenced %s has no publicKey with a matching id", keyId)
+}
+
+// publicKeyOwner obtains the 'owner' of a key, or its 'controller' as used by
+// newer security vocabularies. Returns nil if it has neither.
+func publicKeyOwner(pk vocab.W3IDSecurityV1PublicKey) *url.URL {
+	if o := pk.GetW3IDSecurityV1Owner(); o != nil && o.IsXMLSchemaAnyURI() {
+		return o.Get()
+	} else if o != nil && o.IsIRI() {
+		return o.GetIRI()
+	}
+	if s, ok := streams.UnknownProperties(pk)["controller"].(string); ok {
+		if owner, err := url.Parse(s); err == nil {
+			return owner
+		}
+	}
+	return nil
+}
+
+// listsPublicKey determines whether the value has the keyId in its
+// 'publicKey' property, either as an IRI or as the 'id' of a key.
+func listsPublicKey(v vocab.Type, keyId *url.URL) bool {
+	pker, ok := v.(publicKeyer)
+	if !ok {
+		return false
+	}
+	pkp := pker.GetW3IDSecurityV1PublicKey()
+	if pkp == nil {
+		return false
+	}
+	for iter := pkp.Begin(); iter != pkp.End(); iter = iter.Next() {
+		if iter.IsIRI() && iter.GetIRI().String() == keyId.String() {
+			return true
+		} else if iter.IsW3IDSecurityV1PublicKey() {
+			if id := iter.Get().GetJSONLDId(); id != nil && id.Get().String() == keyId.String() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// getPublicKeyPem obtains the 'publicKeyPem' of a key.
+func getPublicKeyPem(pk vocab.W3IDSecurityV1PublicKey, keyId *url.URL) (string, error) {
+	pem := pk.GetW3IDSecurityV1PublicKeyPem()
+	if pem == nil || !pem.IsXMLSchemaString() {
+		return "", fmt.Errorf("public key %s has no publicKeyPem", keyId)
+	}
+	return pem.Get(), nil
+}
+
+// parsePublicKeyPem parses a PEM encoded public key, in either the PKIX or the
+// PKCS #1 format.
+func parsePublicKeyPem(s string) (crypto.PublicKey, error) {
+	block, _ := pem.Decode([]byte(s))
+	if block == nil {
+		return nil, fmt.Errorf("could not decode publicKeyPem: no PEM block")
+	}
+	switch block.Type {
+	case "PUBLIC KEY":
+		return x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		return x509.ParsePKCS1PublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("could not decode publicKeyPem: unknown PEM block type %q", block.Type)
+	}
+}
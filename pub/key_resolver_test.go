@@ -0,0 +1,428 @@
+package pub
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/go-fed/httpsig"
+	"github.com/golang/mock/gomock"
+)
+
+const (
+	testKeyResolverActor = "https://example.com/users/sally"
+	testKeyResolverKeyId = testKeyResolverActor + "#main-key"
+)
+
+// newTestActorWithKey returns the serialized actor with the public key.
+func newTestActorWithKey(t *testing.T, k *rsa.PrivateKey) []byte {
+	return newTestActorWithKeyId(t, k, testKeyResolverKeyId, testKeyResolverActor)
+}
+
+// newTestActorWithKeyId returns the serialized actor with the public key
+// having the keyId and owner.
+func newTestActorWithKeyId(t *testing.T, k *rsa.PrivateKey, keyId, owner string) []byte {
+	return mustMarshalTestJSON(t, map[string]interface{}{
+		"@context": []interface{}{
+			"https://www.w3.org/ns/activitystreams",
+			"https://w3id.org/security/v1",
+		},
+		"id":    testKeyResolverActor,
+		"type":  "Person",
+		"inbox": testKeyResolverActor + "/inbox",
+		"publicKey": map[string]interface{}{
+			"id":           keyId,
+			"owner":        owner,
+			"publicKeyPem": testPublicKeyPem(t, k),
+		},
+	})
+}
+
+// newTestActorListingKeyId returns the serialized actor whose 'publicKey' is
+// the IRI of a standalone key document.
+func newTestActorListingKeyId(t *testing.T, keyId string) []byte {
+	return mustMarshalTestJSON(t, map[string]interface{}{
+		"@context": []interface{}{
+			"https://www.w3.org/ns/activitystreams",
+			"https://w3id.org/security/v1",
+		},
+		"id":        testKeyResolverActor,
+		"type":      "Person",
+		"inbox":     testKeyResolverActor + "/inbox",
+		"publicKey": keyId,
+	})
+}
+
+// newTestKeyDocument returns the serialized standalone key document owned by
+// the owner.
+func newTestKeyDocument(t *testing.T, k *rsa.PrivateKey, keyId, owner string) []byte {
+	return mustMarshalTestJSON(t, map[string]interface{}{
+		"@context":     "https://w3id.org/security/v1",
+		"id":           keyId,
+		"type":         "PublicKey",
+		"owner":        owner,
+		"publicKeyPem": testPublicKeyPem(t, k),
+	})
+}
+
+// testPublicKeyPem returns the PEM encoded public key.
+func testPublicKeyPem(t *testing.T, k *rsa.PrivateKey) string {
+	der, err := x509.MarshalPKIXPublicKey(&k.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+// mustMarshalTestJSON marshals the value to JSON.
+func mustMarshalTestJSON(t *testing.T, v interface{}) []byte {
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+// newTestSignedRequest returns a request signed by the key.
+func newTestSignedRequest(t *testing.T, k *rsa.PrivateKey) *http.Request {
+	return newTestSignedRequestWithKeyId(t, k, testKeyResolverKeyId)
+}
+
+// newTestSignedRequestWithKeyId returns a request signed by the key with the
+// keyId.
+func newTestSignedRequestWithKeyId(t *testing.T, k *rsa.PrivateKey, keyId string) *http.Request {
+	r := httptest.NewRequest("POST", testMyInboxIRI, nil)
+	r.Header.Set("Date", nowDateHeader())
+	s, _, err := httpsig.NewSigner([]httpsig.Algorithm{httpsig.RSA_SHA256}, httpsig.DigestSha256, []string{"date"}, httpsig.Signature)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.SignRequest(k, keyId, r, nil); err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+func TestKeyResolver(t *testing.T) {
+	ctx := context.Background()
+	k1, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	k2, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	setupFn := func(ctl *gomock.Controller) (c *MockClock, tp *MockTransport, kr *KeyResolver) {
+		c = NewMockClock(ctl)
+		tp = NewMockTransport(ctl)
+		kr = NewKeyResolver(c, time.Minute)
+		return
+	}
+	t.Run("FetchesActorAndCachesKey", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		c, tp, kr := setupFn(ctl)
+		c.EXPECT().Now().Return(now()).AnyTimes()
+		tp.EXPECT().Dereference(ctx, mustParse(testKeyResolverActor)).Return(newTestActorWithKey(t, k1), nil)
+		for i := 0; i < 2; i++ {
+			key, err := kr.Resolve(ctx, tp, mustParse(testKeyResolverKeyId))
+			assertEqual(t, err, nil)
+			if !isSameRSAKey(&k1.PublicKey, key) {
+				t.Fatalf("resolved the wrong key")
+			}
+		}
+	})
+	t.Run("RefetchesAfterTTL", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		c, tp, kr := setupFn(ctl)
+		c.EXPECT().Now().Return(now())
+		c.EXPECT().Now().Return(now().Add(time.Minute)).Times(2)
+		tp.EXPECT().Dereference(ctx, mustParse(testKeyResolverActor)).Return(newTestActorWithKey(t, k1), nil)
+		tp.EXPECT().Dereference(ctx, mustParse(testKeyResolverActor)).Return(newTestActorWithKey(t, k2), nil)
+		_, err := kr.Resolve(ctx, tp, mustParse(testKeyResolverKeyId))
+		assertEqual(t, err, nil)
+		key, err := kr.Resolve(ctx, tp, mustParse(testKeyResolverKeyId))
+		assertEqual(t, err, nil)
+		if !isSameRSAKey(&k2.PublicKey, key) {
+			t.Fatalf("resolved the expired key")
+		}
+	})
+	t.Run("ErrorIfNoMatchingKey", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, tp, kr := setupFn(ctl)
+		tp.EXPECT().Dereference(ctx, mustParse(testKeyResolverActor)).Return(newTestActorWithKey(t, k1), nil)
+		_, err := kr.Resolve(ctx, tp, mustParse(testKeyResolverActor+"#other-key"))
+		assertNotEqual(t, err, nil)
+	})
+	t.Run("VerifiesWithFetchedKey", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		c, tp, kr := setupFn(ctl)
+		c.EXPECT().Now().Return(now()).AnyTimes()
+		tp.EXPECT().Dereference(ctx, mustParse(testKeyResolverActor)).Return(newTestActorWithKey(t, k1), nil)
+		v, err := httpsig.NewVerifier(newTestSignedRequest(t, k1))
+		assertEqual(t, err, nil)
+		err = kr.Verify(ctx, tp, v, httpsig.RSA_SHA256)
+		assertEqual(t, err, nil)
+	})
+	t.Run("RefetchesOnceAfterKeyRotation", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		c, tp, kr := setupFn(ctl)
+		c.EXPECT().Now().Return(now()).AnyTimes()
+		tp.EXPECT().Dereference(ctx, mustParse(testKeyResolverActor)).Return(newTestActorWithKey(t, k1), nil)
+		tp.EXPECT().Dereference(ctx, mustParse(testKeyResolverActor)).Return(newTestActorWithKey(t, k2), nil)
+		_, err := kr.Resolve(ctx, tp, mustParse(testKeyResolverKeyId))
+		assertEqual(t, err, nil)
+		v, err := httpsig.NewVerifier(newTestSignedRequest(t, k2))
+		assertEqual(t, err, nil)
+		err = kr.Verify(ctx, tp, v, httpsig.RSA_SHA256)
+		assertEqual(t, err, nil)
+		key, err := kr.Resolve(ctx, tp, mustParse(testKeyResolverKeyId))
+		assertEqual(t, err, nil)
+		if !isSameRSAKey(&k2.PublicKey, key) {
+			t.Fatalf("did not cache the rotated key")
+		}
+	})
+	t.Run("DoesNotRefetchFreshKeyOnFailure", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		c, tp, kr := setupFn(ctl)
+		c.EXPECT().Now().Return(now()).AnyTimes()
+		tp.EXPECT().Dereference(ctx, mustParse(testKeyResolverActor)).Return(newTestActorWithKey(t, k1), nil)
+		v, err := httpsig.NewVerifier(newTestSignedRequest(t, k2))
+		assertEqual(t, err, nil)
+		err = kr.Verify(ctx, tp, v, httpsig.RSA_SHA256)
+		assertNotEqual(t, err, nil)
+	})
+	t.Run("OwnerOfFragmentKeyId", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		c, tp, kr := setupFn(ctl)
+		c.EXPECT().Now().Return(now()).AnyTimes()
+		tp.EXPECT().Dereference(ctx, mustParse(testKeyResolverActor)).Return(newTestActorWithKey(t, k1), nil)
+		owner, err := kr.Owner(ctx, tp, mustParse(testKeyResolverKeyId))
+		assertEqual(t, err, nil)
+		assertEqual(t, owner.String(), testKeyResolverActor)
+	})
+	t.Run("OwnerOfPathKeyIdServedByActor", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		c, tp, kr := setupFn(ctl)
+		keyId := testKeyResolverActor + "/main-key"
+		c.EXPECT().Now().Return(now()).AnyTimes()
+		tp.EXPECT().Dereference(ctx, mustParse(keyId)).Return(newTestActorWithKeyId(t, k1, keyId, testKeyResolverActor), nil)
+		tp.EXPECT().Dereference(ctx, mustParse(testKeyResolverActor)).Return(newTestActorWithKeyId(t, k1, keyId, testKeyResolverActor), nil)
+		key, err := kr.Resolve(ctx, tp, mustParse(keyId))
+		assertEqual(t, err, nil)
+		if !isSameRSAKey(&k1.PublicKey, key) {
+			t.Fatalf("resolved the wrong key")
+		}
+		owner, err := kr.Owner(ctx, tp, mustParse(keyId))
+		assertEqual(t, err, nil)
+		assertEqual(t, owner.String(), testKeyResolverActor)
+	})
+	t.Run("OwnerOfStandaloneKeyDocument", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		c, tp, kr := setupFn(ctl)
+		keyId := "https://example.com/keys/1"
+		c.EXPECT().Now().Return(now()).AnyTimes()
+		tp.EXPECT().Dereference(ctx, mustParse(keyId)).Return(newTestKeyDocument(t, k1, keyId, testKeyResolverActor), nil)
+		tp.EXPECT().Dereference(ctx, mustParse(testKeyResolverActor)).Return(newTestActorListingKeyId(t, keyId), nil)
+		key, err := kr.Resolve(ctx, tp, mustParse(keyId))
+		assertEqual(t, err, nil)
+		if !isSameRSAKey(&k1.PublicKey, key) {
+			t.Fatalf("resolved the wrong key")
+		}
+		owner, err := kr.Owner(ctx, tp, mustParse(keyId))
+		assertEqual(t, err, nil)
+		assertEqual(t, owner.String(), testKeyResolverActor)
+	})
+	t.Run("ErrorIfOwnerDoesNotListStandaloneKey", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, tp, kr := setupFn(ctl)
+		keyId := "https://example.com/keys/1"
+		tp.EXPECT().Dereference(ctx, mustParse(keyId)).Return(newTestKeyDocument(t, k1, keyId, testKeyResolverActor), nil)
+		tp.EXPECT().Dereference(ctx, mustParse(testKeyResolverActor)).Return(newTestActorListingKeyId(t, "https://example.com/keys/2"), nil)
+		_, err := kr.Resolve(ctx, tp, mustParse(keyId))
+		assertNotEqual(t, err, nil)
+	})
+	t.Run("ErrorIfEmbeddedKeyOwnedByAnotherActor", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, tp, kr := setupFn(ctl)
+		tp.EXPECT().Dereference(ctx, mustParse(testKeyResolverActor)).Return(newTestActorWithKeyId(t, k1, testKeyResolverKeyId, testFederatedActorIRI), nil)
+		_, err := kr.Resolve(ctx, tp, mustParse(testKeyResolverKeyId))
+		assertNotEqual(t, err, nil)
+	})
+	t.Run("ErrorIfActorOnAnotherOriginClaimsKey", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, tp, kr := setupFn(ctl)
+		keyId := "https://evil.example.com/users/mallory#main-key"
+		tp.EXPECT().Dereference(ctx, mustParse("https://evil.example.com/users/mallory")).Return(newTestActorWithKeyId(t, k1, keyId, testKeyResolverActor), nil).Times(2)
+		_, err := kr.Resolve(ctx, tp, mustParse(keyId))
+		assertNotEqual(t, err, nil)
+		v, err := httpsig.NewVerifier(newTestSignedRequestWithKeyId(t, k1, keyId))
+		assertEqual(t, err, nil)
+		err = kr.VerifyOwner(ctx, tp, v, httpsig.RSA_SHA256, mustParse(testKeyResolverActor))
+		assertNotEqual(t, err, nil)
+	})
+	t.Run("ErrorIfClaimedOwnerDoesNotListKey", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, tp, kr := setupFn(ctl)
+		keyId := "https://example.com/users/mallory/main-key"
+		tp.EXPECT().Dereference(ctx, mustParse(keyId)).Return(newTestActorWithKeyId(t, k1, keyId, testKeyResolverActor), nil)
+		tp.EXPECT().Dereference(ctx, mustParse(testKeyResolverActor)).Return(newTestActorWithKey(t, k2), nil)
+		_, err := kr.Resolve(ctx, tp, mustParse(keyId))
+		assertNotEqual(t, err, nil)
+	})
+	t.Run("VerifyOwnerWithStandaloneKeyDocument", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		c, tp, kr := setupFn(ctl)
+		keyId := "https://example.com/keys/1"
+		c.EXPECT().Now().Return(now()).AnyTimes()
+		tp.EXPECT().Dereference(ctx, mustParse(keyId)).Return(newTestKeyDocument(t, k1, keyId, testKeyResolverActor), nil)
+		tp.EXPECT().Dereference(ctx, mustParse(testKeyResolverActor)).Return(newTestActorListingKeyId(t, keyId), nil)
+		v, err := httpsig.NewVerifier(newTestSignedRequestWithKeyId(t, k1, keyId))
+		assertEqual(t, err, nil)
+		err = kr.VerifyOwner(ctx, tp, v, httpsig.RSA_SHA256, mustParse(testKeyResolverActor))
+		assertEqual(t, err, nil)
+	})
+	t.Run("UpdateKeysReplacesCachedKey", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		c, tp, kr := setupFn(ctl)
+		c.EXPECT().Now().Return(now()).AnyTimes()
+		tp.EXPECT().Dereference(ctx, mustParse(testKeyResolverActor)).Return(newTestActorWithKey(t, k1), nil)
+		_, err := kr.Resolve(ctx, tp, mustParse(testKeyResolverKeyId))
+		assertEqual(t, err, nil)
+		err = kr.UpdateKeys(mustToTestType(t, string(newTestActorWithKey(t, k2))), []*url.URL{mustParse(testKeyResolverKeyId)})
+		assertEqual(t, err, nil)
+		key, err := kr.Resolve(ctx, tp, mustParse(testKeyResolverKeyId))
+		assertEqual(t, err, nil)
+		if !isSameRSAKey(&k2.PublicKey, key) {
+			t.Fatalf("resolved the rotated key")
+		}
+	})
+	for _, test := range []struct {
+		name  string
+		actor string
+	}{
+		{"UpdateKeysIgnoresKeyIdOnAnotherOrigin", "https://evil.example.com/users/mallory"},
+		{"UpdateKeysIgnoresKeyIdOfAnotherActor", "https://example.com/users/mallory"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			ctl := gomock.NewController(t)
+			defer ctl.Finish()
+			c, tp, kr := setupFn(ctl)
+			c.EXPECT().Now().Return(now()).AnyTimes()
+			tp.EXPECT().Dereference(ctx, mustParse(testKeyResolverActor)).Return(newTestActorWithKey(t, k1), nil).Times(2)
+			_, err := kr.Resolve(ctx, tp, mustParse(testKeyResolverKeyId))
+			assertEqual(t, err, nil)
+			attacker := mustMarshalTestJSON(t, map[string]interface{}{
+				"@context": []interface{}{
+					"https://www.w3.org/ns/activitystreams",
+					"https://w3id.org/security/v1",
+				},
+				"id":    test.actor,
+				"type":  "Person",
+				"inbox": test.actor + "/inbox",
+				"publicKey": map[string]interface{}{
+					"id":           testKeyResolverKeyId,
+					"owner":        test.actor,
+					"publicKeyPem": testPublicKeyPem(t, k2),
+				},
+			})
+			err = kr.UpdateKeys(mustToTestType(t, string(attacker)), []*url.URL{mustParse(testKeyResolverKeyId)})
+			assertEqual(t, err, nil)
+			key, err := kr.Resolve(ctx, tp, mustParse(testKeyResolverKeyId))
+			assertEqual(t, err, nil)
+			if !isSameRSAKey(&k1.PublicKey, key) {
+				t.Fatalf("resolved the key claimed by %s", test.actor)
+			}
+		})
+	}
+	t.Run("VerifyOwnerErrorsForAnotherActor", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		c, tp, kr := setupFn(ctl)
+		c.EXPECT().Now().Return(now()).AnyTimes()
+		tp.EXPECT().Dereference(ctx, mustParse(testKeyResolverActor)).Return(newTestActorWithKey(t, k1), nil)
+		v, err := httpsig.NewVerifier(newTestSignedRequest(t, k1))
+		assertEqual(t, err, nil)
+		err = kr.VerifyOwner(ctx, tp, v, httpsig.RSA_SHA256, mustParse(testFederatedActorIRI))
+		assertNotEqual(t, err, nil)
+	})
+}
+
+func TestChangedPublicKeys(t *testing.T) {
+	k1, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	k2, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stored := mustToTestType(t, string(newTestActorWithKey(t, k1)))
+	t.Run("NoneIfUnchanged", func(t *testing.T) {
+		changed := ChangedPublicKeys(stored, mustToTestType(t, string(newTestActorWithKey(t, k1))))
+		assertEqual(t, len(changed), 0)
+	})
+	t.Run("RotatedKey", func(t *testing.T) {
+		changed := ChangedPublicKeys(stored, mustToTestType(t, string(newTestActorWithKey(t, k2))))
+		assertEqual(t, len(changed), 1)
+		assertEqual(t, changed[0].String(), testKeyResolverKeyId)
+	})
+	t.Run("ReplacedKeyId", func(t *testing.T) {
+		keyId := testKeyResolverActor + "#key-2"
+		changed := ChangedPublicKeys(stored, mustToTestType(t, string(newTestActorWithKeyId(t, k2, keyId, testKeyResolverActor))))
+		assertEqual(t, len(changed), 2)
+		assertEqual(t, changed[0].String(), keyId)
+		assertEqual(t, changed[1].String(), testKeyResolverKeyId)
+	})
+	t.Run("IgnoresKeyReferencedByIRI", func(t *testing.T) {
+		changed := ChangedPublicKeys(mustToTestType(t, string(newTestActorListingKeyId(t, testKeyResolverKeyId))), mustToTestType(t, string(newTestActorListingKeyId(t, testKeyResolverKeyId))))
+		assertEqual(t, len(changed), 0)
+	})
+}
+
+func TestParsePublicKeyPem(t *testing.T) {
+	k, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkcs1 := pem.EncodeToMemory(&pem.Block{Type: "RSA PUBLIC KEY", Bytes: x509.MarshalPKCS1PublicKey(&k.PublicKey)})
+	key, err := parsePublicKeyPem(string(pkcs1))
+	assertEqual(t, err, nil)
+	if !isSameRSAKey(&k.PublicKey, key) {
+		t.Fatalf("parsed the wrong key")
+	}
+	_, err = parsePublicKeyPem("not a pem")
+	assertNotEqual(t, err, nil)
+	_, err = parsePublicKeyPem(string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte{}})))
+	assertNotEqual(t, err, nil)
+}
+
+// isSameRSAKey returns true if the key is the RSA public key.
+func isSameRSAKey(want *rsa.PublicKey, got crypto.PublicKey) bool {
+	k, ok := got.(*rsa.PublicKey)
+	return ok && k.E == want.E && k.N.Cmp(want.N) == 0
+}
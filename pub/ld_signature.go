@@ -0,0 +1,156 @@
+package pub
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+
+	"github.com/go-fed/activity/streams"
+	"github.com/go-fed/activity/streams/vocab"
+)
+
+const (
+	// rsaSignature2017 is the Linked Data Signature suite used by Mastodon
+	// and compatible software.
+	rsaSignature2017 = "RsaSignature2017"
+	// identityContext is the JSON-LD context of the signature options for
+	// the RsaSignature2017 suite.
+	identityContext = "https://w3id.org/identity/v1"
+	// The properties of an embedded Linked Data Signature.
+	signatureProperty      = "signature"
+	signatureValueProperty = "signatureValue"
+	creatorProperty        = "creator"
+	contextProperty        = "@context"
+	typeProperty           = "type"
+	idProperty             = "id"
+)
+
+// LDCanonicalizer canonicalizes JSON-LD documents for verifying Linked Data
+// Signatures.
+//
+// For the RsaSignature2017 suite, the canonicalization must be the URDNA2015
+// algorithm producing N-Quads. The URDNA2015Canonicalizer of this library is
+// used by default, and applications may provide another JSON-LD processor.
+type LDCanonicalizer interface {
+	// Canonicalize returns the URDNA2015 canonical N-Quads of the JSON-LD
+	// document. It must not modify the document.
+	Canonicalize(doc map[string]interface{}) ([]byte, error)
+}
+
+// VerifyLDSignature verifies the Linked Data Signature of the RsaSignature2017
+// suite embedded in the 'signature' property of an activity, such as those
+// sent by Mastodon. The activity is canonicalized with a
+// URDNA2015Canonicalizer loading only the bundled JSON-LD contexts.
+//
+// This allows trusting activities whose HTTP Signature was made by another
+// server than the author's, such as when received via inbox forwarding or a
+// relay. The key must be the *rsa.PublicKey of the signature's 'creator'. It
+// is up to the caller to dereference the creator and ensure it belongs to the
+// author of the activity.
+//
+// The activity is serialized before being canonicalized, which loses any
+// JSON-LD context not known to this library. Use VerifyLDSignatureJSON on the
+// payload as it was received when that matters.
+func VerifyLDSignature(activity vocab.Type, key crypto.PublicKey) error {
+	m, err := streams.Serialize(activity)
+	if err != nil {
+		return err
+	}
+	return VerifyLDSignatureJSON(m, key, nil)
+}
+
+// VerifyLDSignatureJSON verifies the Linked Data Signature of the
+// RsaSignature2017 suite embedded in the 'signature' property of a JSON-LD
+// document. See VerifyLDSignature. If the LDCanonicalizer is nil, a
+// URDNA2015Canonicalizer loading only the bundled JSON-LD contexts is used.
+func VerifyLDSignatureJSON(m map[string]interface{}, key crypto.PublicKey, canon LDCanonicalizer) error {
+	if canon == nil {
+		canon = NewURDNA2015Canonicalizer(nil)
+	}
+	pubKey, ok := key.(*rsa.PublicKey)
+	if !ok {
+		return fmt.Errorf("linked data signature requires an RSA public key, got %T", key)
+	}
+	sig, ok := m[signatureProperty].(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("activity has no linked data signature")
+	}
+	if t, _ := sig[typeProperty].(string); t != rsaSignature2017 {
+		return fmt.Errorf("unsupported linked data signature type: %v", sig[typeProperty])
+	}
+	value, ok := sig[signatureValueProperty].(string)
+	if !ok {
+		return fmt.Errorf("linked data signature has no %s", signatureValueProperty)
+	}
+	b, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return fmt.Errorf("cannot decode linked data signature %s: %s", signatureValueProperty, err)
+	}
+	toVerify, err := ldSignatureData(m, sig, canon)
+	if err != nil {
+		return err
+	}
+	h := sha256.Sum256(toVerify)
+	if err := rsa.VerifyPKCS1v15(pubKey, crypto.SHA256, h[:], b); err != nil {
+		return fmt.Errorf("linked data signature verification failed: %s", err)
+	}
+	return nil
+}
+
+// ldSignatureData constructs the data that was signed: the hex SHA-256 hash of
+// the canonicalized signature options, followed by the hex SHA-256 hash of the
+// canonicalized document without its signature.
+func ldSignatureData(m, sig map[string]interface{}, canon LDCanonicalizer) ([]byte, error) {
+	options := make(map[string]interface{}, len(sig))
+	for k, v := range sig {
+		if k == typeProperty || k == idProperty || k == signatureValueProperty {
+			continue
+		}
+		options[k] = v
+	}
+	options[contextProperty] = identityContext
+	doc := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		if k == signatureProperty {
+			continue
+		}
+		doc[k] = v
+	}
+	optionsHash, err := canonicalHash(options, canon)
+	if err != nil {
+		return nil, err
+	}
+	docHash, err := canonicalHash(doc, canon)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(optionsHash + docHash), nil
+}
+
+// canonicalHash returns the hex SHA-256 hash of the canonicalized document.
+func canonicalHash(doc map[string]interface{}, canon LDCanonicalizer) (string, error) {
+	b, err := canon.Canonicalize(doc)
+	if err != nil {
+		return "", err
+	}
+	h := sha256.Sum256(b)
+	return hex.EncodeToString(h[:]), nil
+}
+
+// ldSignatureCreator returns the IRI of the key that made the Linked Data
+// Signature embedded in the JSON-LD document.
+func ldSignatureCreator(m map[string]interface{}) (*url.URL, error) {
+	sig, ok := m[signatureProperty].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("activity has no linked data signature")
+	}
+	creator, ok := sig[creatorProperty].(string)
+	if !ok {
+		return nil, fmt.Errorf("linked data signature has no %s", creatorProperty)
+	}
+	return url.Parse(creator)
+}
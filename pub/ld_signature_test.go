@@ -0,0 +1,207 @@
+package pub
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/go-fed/activity/streams"
+)
+
+// jsonCanonicalizer is a stand-in for a URDNA2015 canonicalizer, relying on
+// the sorted keys of encoding/json.
+type jsonCanonicalizer struct{}
+
+func (jsonCanonicalizer) Canonicalize(doc map[string]interface{}) ([]byte, error) {
+	return json.Marshal(doc)
+}
+
+// errCanonicalizer fails to canonicalize.
+type errCanonicalizer struct{}
+
+func (errCanonicalizer) Canonicalize(doc map[string]interface{}) ([]byte, error) {
+	return nil, testErr
+}
+
+func TestVerifyLDSignature(t *testing.T) {
+	k, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherK, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecK, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	newSignedWith := func(sigType string, canon LDCanonicalizer) map[string]interface{} {
+		m := map[string]interface{}{
+			"@context": "https://www.w3.org/ns/activitystreams",
+			"id":       testFederatedActivityIRI,
+			"type":     "Create",
+			"actor":    testFederatedActorIRI,
+			"object":   testNoteId1,
+		}
+		sig := map[string]interface{}{
+			"type":    sigType,
+			"creator": testFederatedActorIRI + "#main-key",
+			"created": "2019-09-14T11:39:40Z",
+		}
+		m[signatureProperty] = sig
+		b, err := ldSignatureData(m, sig, canon)
+		if err != nil {
+			t.Fatal(err)
+		}
+		h := sha256.Sum256(b)
+		s, err := rsa.SignPKCS1v15(rand.Reader, k, crypto.SHA256, h[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		sig[signatureValueProperty] = base64.StdEncoding.EncodeToString(s)
+		return m
+	}
+	newSigned := func(sigType string) map[string]interface{} {
+		return newSignedWith(sigType, jsonCanonicalizer{})
+	}
+	tests := []struct {
+		name    string
+		m       func() map[string]interface{}
+		key     crypto.PublicKey
+		canon   LDCanonicalizer
+		wantErr bool
+	}{
+		{
+			name:  "valid signature",
+			m:     func() map[string]interface{} { return newSigned(rsaSignature2017) },
+			key:   &k.PublicKey,
+			canon: jsonCanonicalizer{},
+		},
+		{
+			name: "tampered document",
+			m: func() map[string]interface{} {
+				m := newSigned(rsaSignature2017)
+				m["object"] = testNoteId2
+				return m
+			},
+			key:     &k.PublicKey,
+			canon:   jsonCanonicalizer{},
+			wantErr: true,
+		},
+		{
+			name: "tampered signature options",
+			m: func() map[string]interface{} {
+				m := newSigned(rsaSignature2017)
+				m[signatureProperty].(map[string]interface{})["creator"] = testFederatedActorIRI2 + "#main-key"
+				return m
+			},
+			key:     &k.PublicKey,
+			canon:   jsonCanonicalizer{},
+			wantErr: true,
+		},
+		{
+			name:    "wrong key",
+			m:       func() map[string]interface{} { return newSigned(rsaSignature2017) },
+			key:     &otherK.PublicKey,
+			canon:   jsonCanonicalizer{},
+			wantErr: true,
+		},
+		{
+			name:    "non-RSA key",
+			m:       func() map[string]interface{} { return newSigned(rsaSignature2017) },
+			key:     &ecK.PublicKey,
+			canon:   jsonCanonicalizer{},
+			wantErr: true,
+		},
+		{
+			name:    "unsupported signature type",
+			m:       func() map[string]interface{} { return newSigned("Ed25519Signature2018") },
+			key:     &k.PublicKey,
+			canon:   jsonCanonicalizer{},
+			wantErr: true,
+		},
+		{
+			name: "no signature",
+			m: func() map[string]interface{} {
+				m := newSigned(rsaSignature2017)
+				delete(m, signatureProperty)
+				return m
+			},
+			key:     &k.PublicKey,
+			canon:   jsonCanonicalizer{},
+			wantErr: true,
+		},
+		{
+			name: "malformed signature value",
+			m: func() map[string]interface{} {
+				m := newSigned(rsaSignature2017)
+				m[signatureProperty].(map[string]interface{})[signatureValueProperty] = "not base64!"
+				return m
+			},
+			key:     &k.PublicKey,
+			canon:   jsonCanonicalizer{},
+			wantErr: true,
+		},
+		{
+			name:  "default canonicalizer",
+			m:     func() map[string]interface{} { return newSignedWith(rsaSignature2017, NewURDNA2015Canonicalizer(nil)) },
+			key:   &k.PublicKey,
+			canon: nil,
+		},
+		{
+			name: "tampered document with default canonicalizer",
+			m: func() map[string]interface{} {
+				m := newSignedWith(rsaSignature2017, NewURDNA2015Canonicalizer(nil))
+				m["object"] = testNoteId2
+				return m
+			},
+			key:     &k.PublicKey,
+			canon:   nil,
+			wantErr: true,
+		},
+		{
+			name:    "canonicalization error",
+			m:       func() map[string]interface{} { return newSigned(rsaSignature2017) },
+			key:     &k.PublicKey,
+			canon:   errCanonicalizer{},
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := VerifyLDSignatureJSON(test.m(), test.key, test.canon)
+			if test.wantErr && err == nil {
+				t.Fatalf("expected an error")
+			} else if !test.wantErr && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+		})
+	}
+	t.Run("verifies a deserialized activity", func(t *testing.T) {
+		a, err := streams.ToType(context.Background(), newSignedWith(rsaSignature2017, NewURDNA2015Canonicalizer(nil)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := VerifyLDSignature(a, &k.PublicKey); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	})
+	t.Run("rejects a modified deserialized activity", func(t *testing.T) {
+		a, err := streams.ToType(context.Background(), newSignedWith(rsaSignature2017, NewURDNA2015Canonicalizer(nil)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		a.(Activity).GetActivityStreamsObject().AppendIRI(mustParse(testNoteId2))
+		if err := VerifyLDSignature(a, &k.PublicKey); err == nil {
+			t.Fatalf("expected an error")
+		}
+	})
+}
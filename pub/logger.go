@@ -0,0 +1,30 @@
+package pub
+
+import (
+	"net/url"
+
+	"github.com/go-fed/activity/streams/vocab"
+)
+
+// Logger receives debug messages about the decisions made while handling
+// activities, such as the type of a received activity, the number of inboxes
+// a delivery resolved to, the outcome of each delivery, whether an activity
+// is forwarded, and the results of verifying HTTP Signatures.
+//
+// It is set with WithLogger, WithTransportLogger, and WithVerifierLogger.
+// Nothing is logged when no Logger is set.
+type Logger interface {
+	// Debug logs the message with the alternating keys and values giving
+	// its details. The keys are strings.
+	//
+	// It may be called concurrently.
+	Debug(msg string, keysAndValues ...interface{})
+}
+
+// idOf returns the 'id' of the value to log, or nil if it has none.
+func idOf(t vocab.Type) *url.URL {
+	if id := t.GetJSONLDId(); id != nil {
+		return id.Get()
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package pub
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/go-fed/activity/streams"
+	"github.com/go-fed/httpsig"
+	"github.com/golang/mock/gomock"
+)
+
+// recordingLogger is a Logger recording the messages logged.
+type recordingLogger struct {
+	mu   sync.Mutex
+	msgs []string
+}
+
+// Debug records the message.
+func (l *recordingLogger) Debug(msg string, keysAndValues ...interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.msgs = append(l.msgs, msg)
+}
+
+func TestLogger(t *testing.T) {
+	ctx := context.Background()
+	t.Run("ActorLogsForwardingDecision", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		db := NewMockDatabase(ctl)
+		l := &recordingLogger{}
+		a := &sideEffectActor{db: db, logger: l}
+		act := streams.NewActivityStreamsCreate()
+		id := streams.NewJSONLDIdProperty()
+		id.Set(mustParse(testFederatedActivityIRI))
+		act.SetJSONLDId(id)
+		// Mock
+		db.EXPECT().Lock(ctx, mustParse(testFederatedActivityIRI))
+		db.EXPECT().Exists(ctx, mustParse(testFederatedActivityIRI)).Return(false, nil)
+		db.EXPECT().Create(ctx, act)
+		db.EXPECT().Unlock(ctx, mustParse(testFederatedActivityIRI))
+		// Run
+		err := a.InboxForwarding(ctx, mustParse(testMyInboxIRI), act)
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, len(l.msgs), 1)
+		assertEqual(t, l.msgs[0], "not forwarding activity: addressed to no local collection")
+	})
+	t.Run("TransportLogsDeliveryOutcome", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		c := NewMockClock(ctl)
+		hc := NewMockHttpClient(ctl)
+		ps := NewMockSigner(ctl)
+		l := &recordingLogger{}
+		tp := NewHttpSigTransport(hc, testAppAgent, c, NewMockSigner(ctl), ps, testPubKeyId, testPrivKey, WithTransportLogger(l))
+		ok := httptest.NewRecorder()
+		ok.WriteHeader(http.StatusAccepted)
+		failed := httptest.NewRecorder()
+		failed.WriteHeader(http.StatusGone)
+		// Mock
+		c.EXPECT().Now().Return(now()).Times(2)
+		ps.EXPECT().SignRequest(testPrivKey, testPubKeyId, gomock.Any(), nil).Times(2)
+		hc.EXPECT().Do(gomock.Any()).Return(ok.Result(), nil)
+		hc.EXPECT().Do(gomock.Any()).Return(failed.Result(), nil)
+		// Run
+		err := tp.Deliver(ctx, testRespBody, mustParse(testFederatedInboxIRI))
+		assertEqual(t, err, nil)
+		err = tp.Deliver(ctx, testRespBody, mustParse(testFederatedInboxIRI2))
+		assertNotEqual(t, err, nil)
+		// Verify
+		assertEqual(t, len(l.msgs), 2)
+		assertEqual(t, l.msgs[0], "delivered")
+		assertEqual(t, l.msgs[1], "delivery failed")
+	})
+	t.Run("VerifierLogsResult", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		k, err := rsa.GenerateKey(rand.Reader, 2048)
+		assertEqual(t, err, nil)
+		other, err := rsa.GenerateKey(rand.Reader, 2048)
+		assertEqual(t, err, nil)
+		c := NewMockClock(ctl)
+		l := &recordingLogger{}
+		h := NewHttpSigVerifier(c, 0, 0, WithVerifierLogger(l))
+		signed := []string{httpsig.RequestTarget, "date"}
+		// Mock
+		c.EXPECT().Now().Return(now()).Times(2)
+		// Run
+		v, err := h.NewVerifier(mustSignedRequest(t, k, now(), signed))
+		assertEqual(t, err, nil)
+		assertEqual(t, v.Verify(&k.PublicKey, httpsig.RSA_SHA256), nil)
+		v, err = h.NewVerifier(mustSignedRequest(t, k, now(), signed))
+		assertEqual(t, err, nil)
+		assertNotEqual(t, v.Verify(&other.PublicKey, httpsig.RSA_SHA256), nil)
+		// Verify
+		assertEqual(t, len(l.msgs), 2)
+		assertEqual(t, l.msgs[0], "signature verified")
+		assertEqual(t, l.msgs[1], "signature verification failed")
+	})
+}
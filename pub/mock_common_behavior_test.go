@@ -97,3 +97,41 @@ func (mr *MockCommonBehaviorMockRecorder) NewTransport(c, actorBoxIRI, gofedAgen
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewTransport", reflect.TypeOf((*MockCommonBehavior)(nil).NewTransport), c, actorBoxIRI, gofedAgent)
 }
+
+// MockActorResolver is a mock of ActorResolver interface
+type MockActorResolver struct {
+	ctrl     *gomock.Controller
+	recorder *MockActorResolverMockRecorder
+}
+
+// MockActorResolverMockRecorder is the mock recorder for MockActorResolver
+type MockActorResolverMockRecorder struct {
+	mock *MockActorResolver
+}
+
+// NewMockActorResolver creates a new mock instance
+func NewMockActorResolver(ctrl *gomock.Controller) *MockActorResolver {
+	mock := &MockActorResolver{ctrl: ctrl}
+	mock.recorder = &MockActorResolverMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockActorResolver) EXPECT() *MockActorResolverMockRecorder {
+	return m.recorder
+}
+
+// ActorForRequest mocks base method
+func (m *MockActorResolver) ActorForRequest(c context.Context, r *http.Request) (*url.URL, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActorForRequest", c, r)
+	ret0, _ := ret[0].(*url.URL)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ActorForRequest indicates an expected call of ActorForRequest
+func (mr *MockActorResolverMockRecorder) ActorForRequest(c, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActorForRequest", reflect.TypeOf((*MockActorResolver)(nil).ActorForRequest), c, r)
+}
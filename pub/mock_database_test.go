@@ -343,3 +343,78 @@ func (mr *MockDatabaseMockRecorder) Update(c, asType interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockDatabase)(nil).Update), c, asType)
 }
+
+// MockCollectionAppender is a mock of CollectionAppender interface.
+type MockCollectionAppender struct {
+	ctrl     *gomock.Controller
+	recorder *MockCollectionAppenderMockRecorder
+}
+
+// MockCollectionAppenderMockRecorder is the mock recorder for MockCollectionAppender.
+type MockCollectionAppenderMockRecorder struct {
+	mock *MockCollectionAppender
+}
+
+// NewMockCollectionAppender creates a new mock instance.
+func NewMockCollectionAppender(ctrl *gomock.Controller) *MockCollectionAppender {
+	mock := &MockCollectionAppender{ctrl: ctrl}
+	mock.recorder = &MockCollectionAppenderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCollectionAppender) EXPECT() *MockCollectionAppenderMockRecorder {
+	return m.recorder
+}
+
+// AppendToCollection mocks base method.
+func (m *MockCollectionAppender) AppendToCollection(c context.Context, collectionID, itemID *url.URL) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AppendToCollection", c, collectionID, itemID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AppendToCollection indicates an expected call of AppendToCollection.
+func (mr *MockCollectionAppenderMockRecorder) AppendToCollection(c, collectionID, itemID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AppendToCollection", reflect.TypeOf((*MockCollectionAppender)(nil).AppendToCollection), c, collectionID, itemID)
+}
+
+// MockCollectionChecker is a mock of CollectionChecker interface.
+type MockCollectionChecker struct {
+	ctrl     *gomock.Controller
+	recorder *MockCollectionCheckerMockRecorder
+}
+
+// MockCollectionCheckerMockRecorder is the mock recorder for MockCollectionChecker.
+type MockCollectionCheckerMockRecorder struct {
+	mock *MockCollectionChecker
+}
+
+// NewMockCollectionChecker creates a new mock instance.
+func NewMockCollectionChecker(ctrl *gomock.Controller) *MockCollectionChecker {
+	mock := &MockCollectionChecker{ctrl: ctrl}
+	mock.recorder = &MockCollectionCheckerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCollectionChecker) EXPECT() *MockCollectionCheckerMockRecorder {
+	return m.recorder
+}
+
+// InCollection mocks base method.
+func (m *MockCollectionChecker) InCollection(c context.Context, collection, item *url.URL) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InCollection", c, collection, item)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InCollection indicates an expected call of InCollection.
+func (mr *MockCollectionCheckerMockRecorder) InCollection(c, collection, item interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InCollection", reflect.TypeOf((*MockCollectionChecker)(nil).InCollection), c, collection, item)
+}
@@ -260,3 +260,78 @@ func (mr *MockDelegateActorMockRecorder) GetInbox(c, r interface{}) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInbox", reflect.TypeOf((*MockDelegateActor)(nil).GetInbox), c, r)
 }
+
+// MockDeliveryTargetsResolver is a mock of DeliveryTargetsResolver interface
+type MockDeliveryTargetsResolver struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeliveryTargetsResolverMockRecorder
+}
+
+// MockDeliveryTargetsResolverMockRecorder is the mock recorder for MockDeliveryTargetsResolver
+type MockDeliveryTargetsResolverMockRecorder struct {
+	mock *MockDeliveryTargetsResolver
+}
+
+// NewMockDeliveryTargetsResolver creates a new mock instance
+func NewMockDeliveryTargetsResolver(ctrl *gomock.Controller) *MockDeliveryTargetsResolver {
+	mock := &MockDeliveryTargetsResolver{ctrl: ctrl}
+	mock.recorder = &MockDeliveryTargetsResolverMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockDeliveryTargetsResolver) EXPECT() *MockDeliveryTargetsResolverMockRecorder {
+	return m.recorder
+}
+
+// ResolveDeliveryTargets mocks base method
+func (m *MockDeliveryTargetsResolver) ResolveDeliveryTargets(c context.Context, outbox *url.URL, activity Activity) ([]*url.URL, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveDeliveryTargets", c, outbox, activity)
+	ret0, _ := ret[0].([]*url.URL)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveDeliveryTargets indicates an expected call of ResolveDeliveryTargets
+func (mr *MockDeliveryTargetsResolverMockRecorder) ResolveDeliveryTargets(c, outbox, activity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveDeliveryTargets", reflect.TypeOf((*MockDeliveryTargetsResolver)(nil).ResolveDeliveryTargets), c, outbox, activity)
+}
+
+// MockInboxDeliverer is a mock of InboxDeliverer interface
+type MockInboxDeliverer struct {
+	ctrl     *gomock.Controller
+	recorder *MockInboxDelivererMockRecorder
+}
+
+// MockInboxDelivererMockRecorder is the mock recorder for MockInboxDeliverer
+type MockInboxDelivererMockRecorder struct {
+	mock *MockInboxDeliverer
+}
+
+// NewMockInboxDeliverer creates a new mock instance
+func NewMockInboxDeliverer(ctrl *gomock.Controller) *MockInboxDeliverer {
+	mock := &MockInboxDeliverer{ctrl: ctrl}
+	mock.recorder = &MockInboxDelivererMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockInboxDeliverer) EXPECT() *MockInboxDelivererMockRecorder {
+	return m.recorder
+}
+
+// DeliverToInbox mocks base method
+func (m *MockInboxDeliverer) DeliverToInbox(c context.Context, outbox, inbox *url.URL, activity Activity) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeliverToInbox", c, outbox, inbox, activity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeliverToInbox indicates an expected call of DeliverToInbox
+func (mr *MockInboxDelivererMockRecorder) DeliverToInbox(c, outbox, inbox, activity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeliverToInbox", reflect.TypeOf((*MockInboxDeliverer)(nil).DeliverToInbox), c, outbox, inbox, activity)
+}
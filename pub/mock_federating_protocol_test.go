@@ -140,6 +140,20 @@ func (mr *MockFederatingProtocolMockRecorder) MaxDeliveryRecursionDepth(c interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MaxDeliveryRecursionDepth", reflect.TypeOf((*MockFederatingProtocol)(nil).MaxDeliveryRecursionDepth), c)
 }
 
+// MaxDeliveryRecipients mocks base method
+func (m *MockFederatingProtocol) MaxDeliveryRecipients(c context.Context) int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MaxDeliveryRecipients", c)
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// MaxDeliveryRecipients indicates an expected call of MaxDeliveryRecipients
+func (mr *MockFederatingProtocolMockRecorder) MaxDeliveryRecipients(c interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MaxDeliveryRecipients", reflect.TypeOf((*MockFederatingProtocol)(nil).MaxDeliveryRecipients), c)
+}
+
 // FilterForwarding mocks base method
 func (m *MockFederatingProtocol) FilterForwarding(c context.Context, potentialRecipients []*url.URL, a Activity) ([]*url.URL, error) {
 	m.ctrl.T.Helper()
@@ -169,3 +183,194 @@ func (mr *MockFederatingProtocolMockRecorder) GetInbox(c, r interface{}) *gomock
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInbox", reflect.TypeOf((*MockFederatingProtocol)(nil).GetInbox), c, r)
 }
+
+// MockRawBodyHooker is a mock of RawBodyHooker interface
+type MockRawBodyHooker struct {
+	ctrl     *gomock.Controller
+	recorder *MockRawBodyHookerMockRecorder
+}
+
+// MockRawBodyHookerMockRecorder is the mock recorder for MockRawBodyHooker
+type MockRawBodyHookerMockRecorder struct {
+	mock *MockRawBodyHooker
+}
+
+// NewMockRawBodyHooker creates a new mock instance
+func NewMockRawBodyHooker(ctrl *gomock.Controller) *MockRawBodyHooker {
+	mock := &MockRawBodyHooker{ctrl: ctrl}
+	mock.recorder = &MockRawBodyHookerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockRawBodyHooker) EXPECT() *MockRawBodyHookerMockRecorder {
+	return m.recorder
+}
+
+// PostInboxRawBodyHook mocks base method
+func (m *MockRawBodyHooker) PostInboxRawBodyHook(c context.Context, r *http.Request, rawBody []byte, activity Activity) (context.Context, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostInboxRawBodyHook", c, r, rawBody, activity)
+	ret0, _ := ret[0].(context.Context)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PostInboxRawBodyHook indicates an expected call of PostInboxRawBodyHook
+func (mr *MockRawBodyHookerMockRecorder) PostInboxRawBodyHook(c, r, rawBody, activity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostInboxRawBodyHook", reflect.TypeOf((*MockRawBodyHooker)(nil).PostInboxRawBodyHook), c, r, rawBody, activity)
+}
+
+// MockDeliveryPolicer is a mock of DeliveryPolicer interface
+type MockDeliveryPolicer struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeliveryPolicerMockRecorder
+}
+
+// MockDeliveryPolicerMockRecorder is the mock recorder for MockDeliveryPolicer
+type MockDeliveryPolicerMockRecorder struct {
+	mock *MockDeliveryPolicer
+}
+
+// NewMockDeliveryPolicer creates a new mock instance
+func NewMockDeliveryPolicer(ctrl *gomock.Controller) *MockDeliveryPolicer {
+	mock := &MockDeliveryPolicer{ctrl: ctrl}
+	mock.recorder = &MockDeliveryPolicerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockDeliveryPolicer) EXPECT() *MockDeliveryPolicerMockRecorder {
+	return m.recorder
+}
+
+// DeliveryPolicy mocks base method
+func (m *MockDeliveryPolicer) DeliveryPolicy(c context.Context, a Activity) (bool, func([]*url.URL) []*url.URL, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeliveryPolicy", c, a)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(func([]*url.URL) []*url.URL)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// DeliveryPolicy indicates an expected call of DeliveryPolicy
+func (mr *MockDeliveryPolicerMockRecorder) DeliveryPolicy(c, a interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeliveryPolicy", reflect.TypeOf((*MockDeliveryPolicer)(nil).DeliveryPolicy), c, a)
+}
+
+// MockInboxResolver is a mock of InboxResolver interface
+type MockInboxResolver struct {
+	ctrl     *gomock.Controller
+	recorder *MockInboxResolverMockRecorder
+}
+
+// MockInboxResolverMockRecorder is the mock recorder for MockInboxResolver
+type MockInboxResolverMockRecorder struct {
+	mock *MockInboxResolver
+}
+
+// NewMockInboxResolver creates a new mock instance
+func NewMockInboxResolver(ctrl *gomock.Controller) *MockInboxResolver {
+	mock := &MockInboxResolver{ctrl: ctrl}
+	mock.recorder = &MockInboxResolverMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockInboxResolver) EXPECT() *MockInboxResolverMockRecorder {
+	return m.recorder
+}
+
+// ResolveInboxIRIs mocks base method
+func (m *MockInboxResolver) ResolveInboxIRIs(c context.Context, t Transport, receivers, hiddenReceivers []Recipient) ([]*url.URL, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveInboxIRIs", c, t, receivers, hiddenReceivers)
+	ret0, _ := ret[0].([]*url.URL)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveInboxIRIs indicates an expected call of ResolveInboxIRIs
+func (mr *MockInboxResolverMockRecorder) ResolveInboxIRIs(c, t, receivers, hiddenReceivers interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveInboxIRIs", reflect.TypeOf((*MockInboxResolver)(nil).ResolveInboxIRIs), c, t, receivers, hiddenReceivers)
+}
+
+// MockSharedInboxResolver is a mock of SharedInboxResolver interface
+type MockSharedInboxResolver struct {
+	ctrl     *gomock.Controller
+	recorder *MockSharedInboxResolverMockRecorder
+}
+
+// MockSharedInboxResolverMockRecorder is the mock recorder for MockSharedInboxResolver
+type MockSharedInboxResolverMockRecorder struct {
+	mock *MockSharedInboxResolver
+}
+
+// NewMockSharedInboxResolver creates a new mock instance
+func NewMockSharedInboxResolver(ctrl *gomock.Controller) *MockSharedInboxResolver {
+	mock := &MockSharedInboxResolver{ctrl: ctrl}
+	mock.recorder = &MockSharedInboxResolverMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockSharedInboxResolver) EXPECT() *MockSharedInboxResolverMockRecorder {
+	return m.recorder
+}
+
+// LocalInboxes mocks base method
+func (m *MockSharedInboxResolver) LocalInboxes(c context.Context, activity Activity) ([]*url.URL, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LocalInboxes", c, activity)
+	ret0, _ := ret[0].([]*url.URL)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LocalInboxes indicates an expected call of LocalInboxes
+func (mr *MockSharedInboxResolverMockRecorder) LocalInboxes(c, activity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LocalInboxes", reflect.TypeOf((*MockSharedInboxResolver)(nil).LocalInboxes), c, activity)
+}
+
+// MockLocalRecipientsChecker is a mock of LocalRecipientsChecker interface
+type MockLocalRecipientsChecker struct {
+	ctrl     *gomock.Controller
+	recorder *MockLocalRecipientsCheckerMockRecorder
+}
+
+// MockLocalRecipientsCheckerMockRecorder is the mock recorder for MockLocalRecipientsChecker
+type MockLocalRecipientsCheckerMockRecorder struct {
+	mock *MockLocalRecipientsChecker
+}
+
+// NewMockLocalRecipientsChecker creates a new mock instance
+func NewMockLocalRecipientsChecker(ctrl *gomock.Controller) *MockLocalRecipientsChecker {
+	mock := &MockLocalRecipientsChecker{ctrl: ctrl}
+	mock.recorder = &MockLocalRecipientsCheckerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockLocalRecipientsChecker) EXPECT() *MockLocalRecipientsCheckerMockRecorder {
+	return m.recorder
+}
+
+// HasLocalRecipients mocks base method
+func (m *MockLocalRecipientsChecker) HasLocalRecipients(c context.Context, activity Activity) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasLocalRecipients", c, activity)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasLocalRecipients indicates an expected call of HasLocalRecipients
+func (mr *MockLocalRecipientsCheckerMockRecorder) HasLocalRecipients(c, activity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasLocalRecipients", reflect.TypeOf((*MockLocalRecipientsChecker)(nil).HasLocalRecipients), c, activity)
+}
@@ -95,3 +95,77 @@ func (mr *MockSocialProtocolMockRecorder) DefaultCallback(c, activity interface{
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DefaultCallback", reflect.TypeOf((*MockSocialProtocol)(nil).DefaultCallback), c, activity)
 }
+
+// MockTagProcessor is a mock of TagProcessor interface
+type MockTagProcessor struct {
+	ctrl     *gomock.Controller
+	recorder *MockTagProcessorMockRecorder
+}
+
+// MockTagProcessorMockRecorder is the mock recorder for MockTagProcessor
+type MockTagProcessorMockRecorder struct {
+	mock *MockTagProcessor
+}
+
+// NewMockTagProcessor creates a new mock instance
+func NewMockTagProcessor(ctrl *gomock.Controller) *MockTagProcessor {
+	mock := &MockTagProcessor{ctrl: ctrl}
+	mock.recorder = &MockTagProcessorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockTagProcessor) EXPECT() *MockTagProcessorMockRecorder {
+	return m.recorder
+}
+
+// ProcessTags mocks base method
+func (m *MockTagProcessor) ProcessTags(c context.Context, object vocab.Type) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessTags", c, object)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProcessTags indicates an expected call of ProcessTags
+func (mr *MockTagProcessorMockRecorder) ProcessTags(c, object interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessTags", reflect.TypeOf((*MockTagProcessor)(nil).ProcessTags), c, object)
+}
+
+// MockOutboundDecorator is a mock of OutboundDecorator interface
+type MockOutboundDecorator struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboundDecoratorMockRecorder
+}
+
+// MockOutboundDecoratorMockRecorder is the mock recorder for MockOutboundDecorator
+type MockOutboundDecoratorMockRecorder struct {
+	mock *MockOutboundDecorator
+}
+
+// NewMockOutboundDecorator creates a new mock instance
+func NewMockOutboundDecorator(ctrl *gomock.Controller) *MockOutboundDecorator {
+	mock := &MockOutboundDecorator{ctrl: ctrl}
+	mock.recorder = &MockOutboundDecoratorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockOutboundDecorator) EXPECT() *MockOutboundDecoratorMockRecorder {
+	return m.recorder
+}
+
+// DecorateOutbound mocks base method
+func (m *MockOutboundDecorator) DecorateOutbound(c context.Context, t vocab.Type) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DecorateOutbound", c, t)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DecorateOutbound indicates an expected call of DecorateOutbound
+func (mr *MockOutboundDecoratorMockRecorder) DecorateOutbound(c, t interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecorateOutbound", reflect.TypeOf((*MockOutboundDecorator)(nil).DecorateOutbound), c, t)
+}
@@ -0,0 +1,276 @@
+package pub
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/go-fed/activity/streams"
+	"github.com/go-fed/activity/streams/vocab"
+)
+
+const (
+	// DefaultProfileRefreshBackoff is how long a ProfileRefresher stops
+	// refetching from a host after its first failure. It doubles with each
+	// further consecutive failure, up to the refresh interval.
+	DefaultProfileRefreshBackoff = time.Minute
+)
+
+// ResolvedActor is the profile of a peer's actor obtained by a
+// ProfileRefresher.
+type ResolvedActor struct {
+	// IRI is the id of the actor.
+	IRI *url.URL
+	// Actor is the fetched profile, such as a Person or Service. It is nil
+	// when Deleted is true.
+	Actor vocab.Type
+	// Endpoints are the 'endpoints' of the actor, whose IRIs are all
+	// absolute. A profile with invalid endpoints fails to be fetched.
+	Endpoints Endpoints
+	// Deleted is true when the peer responded with 410 Gone or a
+	// Tombstone, in which case the actor is no longer refreshed.
+	Deleted bool
+	// Fetched is when the profile was fetched.
+	Fetched time.Time
+}
+
+// ProfileRefreshFunc is called by a ProfileRefresher with each actor it
+// refreshed, so the application may update its cached profile, or remove it
+// when the actor was deleted.
+type ProfileRefreshFunc func(c context.Context, actor ResolvedActor)
+
+// ProfileRefresher periodically refetches the profiles of peers' actors, so
+// that cached display names, avatars, and keys are kept fresh.
+//
+// Each actor is refetched once per refresh interval, plus a random jitter so
+// that refetches are spread out over time instead of happening all at once.
+// Requests are rate limited per host if a RateLimiter is provided. A host that
+// fails to serve a profile is backed off: no profile is fetched from it until
+// DefaultProfileRefreshBackoff has passed, which doubles with each further
+// consecutive failure up to the refresh interval.
+//
+// It is safe to use concurrently, though refreshes are done one at a time as
+// a Transport may not be used concurrently.
+type ProfileRefresher struct {
+	clock    Clock
+	t        Transport
+	interval time.Duration
+	jitter   time.Duration
+	limiter  RateLimiter
+	fn       ProfileRefreshFunc
+	// refreshMu ensures only one refresh happens at a time.
+	refreshMu sync.Mutex
+	mu        sync.Mutex
+	due       map[string]time.Time
+	hosts     map[string]*hostBackoff
+	rand      *rand.Rand
+}
+
+// hostBackoff is the state of a host failing to serve profiles.
+type hostBackoff struct {
+	failures int
+	until    time.Time
+}
+
+// NewProfileRefresher returns a ProfileRefresher refetching profiles with
+// the Transport every interval, delayed by up to jitter, and reporting them to
+// the ProfileRefreshFunc.
+//
+// The interval must be positive and the jitter must not be negative. The
+// RateLimiter may be nil, such as when the Transport already applies one.
+func NewProfileRefresher(clock Clock, t Transport, interval, jitter time.Duration, limiter RateLimiter, fn ProfileRefreshFunc) (*ProfileRefresher, error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("profile refresh interval must be positive: %v", interval)
+	} else if jitter < 0 {
+		return nil, fmt.Errorf("profile refresh jitter must not be negative: %v", jitter)
+	}
+	return &ProfileRefresher{
+		clock:    clock,
+		t:        t,
+		interval: interval,
+		jitter:   jitter,
+		limiter:  limiter,
+		fn:       fn,
+		due:      make(map[string]time.Time),
+		hosts:    make(map[string]*hostBackoff),
+		rand:     rand.New(rand.NewSource(time.Now().UnixNano())),
+	}, nil
+}
+
+// Add schedules the actors to be refreshed, beginning within the jitter. An
+// actor that is already scheduled keeps its schedule.
+func (p *ProfileRefresher) Add(actorIRIs ...*url.URL) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := p.clock.Now()
+	for _, iri := range actorIRIs {
+		if _, ok := p.due[iri.String()]; !ok {
+			p.due[iri.String()] = now.Add(p.randomJitter())
+		}
+	}
+}
+
+// Remove stops refreshing the actors.
+func (p *ProfileRefresher) Remove(actorIRIs ...*url.URL) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, iri := range actorIRIs {
+		delete(p.due, iri.String())
+	}
+}
+
+// Run calls RefreshDue every tick until the context is done, returning the
+// context's error.
+func (p *ProfileRefresher) Run(c context.Context, tick time.Duration) error {
+	ticker := time.NewTicker(tick)
+	defer ticker.Stop()
+	for {
+		if err := p.RefreshDue(c); err != nil {
+			return err
+		}
+		select {
+		case <-ticker.C:
+		case <-c.Done():
+			return c.Err()
+		}
+	}
+}
+
+// RefreshDue refetches every actor whose refresh is due, except for those on
+// hosts being backed off, which are refreshed once the backoff has passed.
+//
+// Failing to fetch a profile is not an error, as the host is backed off
+// instead. An error is only returned if the context is done while waiting on
+// the RateLimiter.
+func (p *ProfileRefresher) RefreshDue(c context.Context) error {
+	p.refreshMu.Lock()
+	defer p.refreshMu.Unlock()
+	for _, iri := range p.dueActors() {
+		if p.isBackedOff(iri.Host) {
+			continue
+		}
+		if p.limiter != nil {
+			if err := p.limiter.Wait(c, iri.Host); err != nil {
+				return err
+			}
+		}
+		actor, err := p.fetch(c, iri)
+		if err != nil {
+			p.fail(iri.Host)
+			continue
+		}
+		p.succeed(iri)
+		if actor.Deleted {
+			p.Remove(iri)
+		}
+		p.fn(c, actor)
+	}
+	return nil
+}
+
+// dueActors returns the actors whose refresh is due.
+func (p *ProfileRefresher) dueActors() []*url.URL {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := p.clock.Now()
+	var iris []*url.URL
+	for s, due := range p.due {
+		if due.After(now) {
+			continue
+		}
+		iri, err := url.Parse(s)
+		if err != nil {
+			continue
+		}
+		iris = append(iris, iri)
+	}
+	return iris
+}
+
+// isBackedOff determines whether no profiles may be fetched from the host.
+func (p *ProfileRefresher) isBackedOff(host string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	b, ok := p.hosts[host]
+	return ok && p.clock.Now().Before(b.until)
+}
+
+// fail backs off the host after it failed to serve a profile.
+func (p *ProfileRefresher) fail(host string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	b, ok := p.hosts[host]
+	if !ok {
+		b = &hostBackoff{}
+		p.hosts[host] = b
+	}
+	b.failures++
+	backoff := DefaultProfileRefreshBackoff
+	for i := 1; i < b.failures && backoff < p.interval; i++ {
+		backoff *= 2
+	}
+	if backoff > p.interval {
+		backoff = p.interval
+	}
+	b.until = p.clock.Now().Add(backoff)
+}
+
+// succeed schedules the next refresh of the actor and stops backing off its
+// host.
+func (p *ProfileRefresher) succeed(iri *url.URL) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.hosts, iri.Host)
+	if _, ok := p.due[iri.String()]; ok {
+		p.due[iri.String()] = p.clock.Now().Add(p.interval + p.randomJitter())
+	}
+}
+
+// randomJitter returns a random duration within the jitter. Must be called
+// while holding the lock.
+func (p *ProfileRefresher) randomJitter() time.Duration {
+	if p.jitter == 0 {
+		return 0
+	}
+	return time.Duration(p.rand.Int63n(int64(p.jitter)))
+}
+
+// fetch dereferences the actor's profile, determining whether it was deleted.
+func (p *ProfileRefresher) fetch(c context.Context, iri *url.URL) (ResolvedActor, error) {
+	ra := ResolvedActor{IRI: iri}
+	b, err := p.t.Dereference(c, iri)
+	ra.Fetched = p.clock.Now()
+	if sErr, ok := err.(*StatusError); ok && sErr.StatusCode == http.StatusGone {
+		ra.Deleted = true
+		return ra, nil
+	} else if err != nil {
+		return ra, err
+	}
+	var m map[string]interface{}
+	if err = unmarshalJSON(b, &m); err != nil {
+		return ra, err
+	}
+	t, err := streams.ToType(c, m)
+	if err != nil {
+		return ra, err
+	}
+	id, err := GetId(t)
+	if err != nil {
+		return ra, err
+	} else if id.String() != iri.String() {
+		return ra, fmt.Errorf("profile of %s has a different id: %s", iri, id)
+	}
+	if streams.IsOrExtendsActivityStreamsTombstone(t) {
+		ra.Deleted = true
+		return ra, nil
+	}
+	if ra.Endpoints, err = GetEndpoints(t); err != nil {
+		return ra, err
+	}
+	ra.Actor = t
+	return ra, nil
+}
@@ -0,0 +1,241 @@
+package pub
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/go-fed/activity/streams"
+	"github.com/golang/mock/gomock"
+)
+
+func TestNewProfileRefresher(t *testing.T) {
+	if _, err := NewProfileRefresher(nil, nil, 0, 0, nil, nil); err == nil {
+		t.Errorf("expected error for a zero interval")
+	}
+	if _, err := NewProfileRefresher(nil, nil, time.Hour, -time.Second, nil, nil); err == nil {
+		t.Errorf("expected error for a negative jitter")
+	}
+}
+
+func TestProfileRefresher(t *testing.T) {
+	// Set up test case
+	setupData()
+	ctx := context.Background()
+	const interval = time.Hour
+	setupFn := func(ctl *gomock.Controller, l RateLimiter) (current *time.Time, tp *MockTransport, got *[]ResolvedActor, p *ProfileRefresher) {
+		start := now()
+		current = &start
+		c := NewMockClock(ctl)
+		c.EXPECT().Now().DoAndReturn(func() time.Time { return *current }).AnyTimes()
+		tp = NewMockTransport(ctl)
+		got = &[]ResolvedActor{}
+		p, err := NewProfileRefresher(c, tp, interval, 0, l, func(c context.Context, actor ResolvedActor) {
+			*got = append(*got, actor)
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	// Run tests
+	t.Run("RefreshesDueActorsEachInterval", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		current, tp, got, p := setupFn(ctl, nil)
+		tp.EXPECT().Dereference(ctx, mustParse(testPersonIRI)).Return(mustSerializeToBytes(testPerson), nil).Times(2)
+		p.Add(mustParse(testPersonIRI))
+		// Run the test
+		err := p.RefreshDue(ctx)
+		assertEqual(t, err, nil)
+		err = p.RefreshDue(ctx)
+		assertEqual(t, err, nil)
+		*current = current.Add(interval)
+		err = p.RefreshDue(ctx)
+		// Verify results
+		assertEqual(t, err, nil)
+		assertEqual(t, len(*got), 2)
+		assertEqual(t, (*got)[0].IRI.String(), testPersonIRI)
+		assertEqual(t, (*got)[0].Deleted, false)
+		assertEqual(t, (*got)[0].Actor.GetTypeName(), "Person")
+		assertEqual(t, (*got)[1].Fetched.Equal(now().Add(interval)), true)
+	})
+	t.Run("SignalsDeletionForGone", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		current, tp, got, p := setupFn(ctl, nil)
+		tp.EXPECT().Dereference(ctx, mustParse(testPersonIRI)).Return(nil, &StatusError{
+			Method:     "GET",
+			IRI:        mustParse(testPersonIRI),
+			StatusCode: http.StatusGone,
+			Status:     "410 Gone",
+		})
+		p.Add(mustParse(testPersonIRI))
+		// Run the test
+		err := p.RefreshDue(ctx)
+		assertEqual(t, err, nil)
+		*current = current.Add(interval)
+		err = p.RefreshDue(ctx)
+		// Verify results
+		assertEqual(t, err, nil)
+		assertEqual(t, len(*got), 1)
+		assertEqual(t, (*got)[0].Deleted, true)
+		assertEqual(t, (*got)[0].Actor, nil)
+	})
+	t.Run("SignalsDeletionForTombstone", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, tp, got, p := setupFn(ctl, nil)
+		tomb := streams.NewActivityStreamsTombstone()
+		id := streams.NewJSONLDIdProperty()
+		id.Set(mustParse(testPersonIRI))
+		tomb.SetJSONLDId(id)
+		tp.EXPECT().Dereference(ctx, mustParse(testPersonIRI)).Return(mustSerializeToBytes(tomb), nil)
+		p.Add(mustParse(testPersonIRI))
+		// Run the test
+		err := p.RefreshDue(ctx)
+		// Verify results
+		assertEqual(t, err, nil)
+		assertEqual(t, len(*got), 1)
+		assertEqual(t, (*got)[0].Deleted, true)
+	})
+	t.Run("IgnoresProfileWithDifferentId", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, tp, got, p := setupFn(ctl, nil)
+		tp.EXPECT().Dereference(ctx, mustParse(testPersonIRI)).Return(mustSerializeToBytes(testService), nil)
+		p.Add(mustParse(testPersonIRI))
+		// Run the test
+		err := p.RefreshDue(ctx)
+		// Verify results
+		assertEqual(t, err, nil)
+		assertEqual(t, len(*got), 0)
+	})
+	t.Run("ResolvesEndpoints", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, tp, got, p := setupFn(ctl, nil)
+		profile := fmt.Sprintf(`{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": %q,
+  "type": "Person",
+  "endpoints": {
+    "sharedInbox": "https://example.com/inbox",
+    "oauthAuthorizationEndpoint": "https://example.com/oauth/authorize",
+    "oauthTokenEndpoint": "https://example.com/oauth/token",
+    "uploadMedia": "https://example.com/media"
+  }
+}`, testPersonIRI)
+		tp.EXPECT().Dereference(ctx, mustParse(testPersonIRI)).Return([]byte(profile), nil)
+		p.Add(mustParse(testPersonIRI))
+		// Run the test
+		err := p.RefreshDue(ctx)
+		// Verify results
+		assertEqual(t, err, nil)
+		assertEqual(t, len(*got), 1)
+		e := (*got)[0].Endpoints
+		assertEqual(t, e.SharedInbox.String(), "https://example.com/inbox")
+		assertEqual(t, e.OAuthAuthorizationEndpoint.String(), "https://example.com/oauth/authorize")
+		assertEqual(t, e.OAuthTokenEndpoint.String(), "https://example.com/oauth/token")
+		assertEqual(t, e.UploadMedia.String(), "https://example.com/media")
+		assertEqual(t, e.ProvideClientKey, (*url.URL)(nil))
+		assertEqual(t, e.SignClientKey, (*url.URL)(nil))
+	})
+	t.Run("IgnoresProfileWithRelativeEndpoint", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, tp, got, p := setupFn(ctl, nil)
+		profile := fmt.Sprintf(`{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": %q,
+  "type": "Person",
+  "endpoints": {"sharedInbox": "/inbox"}
+}`, testPersonIRI)
+		tp.EXPECT().Dereference(ctx, mustParse(testPersonIRI)).Return([]byte(profile), nil)
+		p.Add(mustParse(testPersonIRI))
+		// Run the test
+		err := p.RefreshDue(ctx)
+		// Verify results
+		assertEqual(t, err, nil)
+		assertEqual(t, len(*got), 0)
+	})
+	t.Run("BacksOffFailingHost", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		current, tp, got, p := setupFn(ctl, nil)
+		gomock.InOrder(
+			tp.EXPECT().Dereference(ctx, mustParse(testPersonIRI)).Return(nil, testErr).Times(2),
+			tp.EXPECT().Dereference(ctx, mustParse(testPersonIRI)).Return(mustSerializeToBytes(testPerson), nil),
+		)
+		p.Add(mustParse(testPersonIRI))
+		// Run the test
+		err := p.RefreshDue(ctx)
+		assertEqual(t, err, nil)
+		// Backed off for one minute.
+		err = p.RefreshDue(ctx)
+		assertEqual(t, err, nil)
+		*current = current.Add(DefaultProfileRefreshBackoff)
+		err = p.RefreshDue(ctx)
+		assertEqual(t, err, nil)
+		// Backed off for two minutes.
+		*current = current.Add(DefaultProfileRefreshBackoff)
+		err = p.RefreshDue(ctx)
+		assertEqual(t, err, nil)
+		assertEqual(t, len(*got), 0)
+		*current = current.Add(DefaultProfileRefreshBackoff)
+		err = p.RefreshDue(ctx)
+		// Verify results
+		assertEqual(t, err, nil)
+		assertEqual(t, len(*got), 1)
+	})
+	t.Run("WaitsOnRateLimiter", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		l := &recordingRateLimiter{}
+		_, tp, _, p := setupFn(ctl, l)
+		tp.EXPECT().Dereference(ctx, mustParse(testPersonIRI)).Return(mustSerializeToBytes(testPerson), nil)
+		p.Add(mustParse(testPersonIRI))
+		// Run the test
+		err := p.RefreshDue(ctx)
+		// Verify results
+		assertEqual(t, err, nil)
+		assertEqual(t, len(l.hosts), 1)
+		assertEqual(t, l.hosts[0], "maybe.example.com")
+	})
+	t.Run("ReturnsRateLimiterError", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		l := &recordingRateLimiter{err: context.Canceled}
+		_, _, _, p := setupFn(ctl, l)
+		p.Add(mustParse(testPersonIRI))
+		// Run the test
+		err := p.RefreshDue(ctx)
+		// Verify results
+		assertEqual(t, err, context.Canceled)
+	})
+	t.Run("RemovedActorIsNotRefreshed", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, _, got, p := setupFn(ctl, nil)
+		p.Add(mustParse(testPersonIRI))
+		p.Remove(mustParse(testPersonIRI))
+		// Run the test
+		err := p.RefreshDue(ctx)
+		// Verify results
+		assertEqual(t, err, nil)
+		assertEqual(t, len(*got), 0)
+	})
+}
@@ -42,6 +42,16 @@ type orderedItemser interface {
 	SetActivityStreamsOrderedItems(vocab.ActivityStreamsOrderedItemsProperty)
 }
 
+// firster is an ActivityStreams type with a 'first' property
+type firster interface {
+	GetActivityStreamsFirst() vocab.ActivityStreamsFirstProperty
+}
+
+// nexter is an ActivityStreams type with a 'next' property
+type nexter interface {
+	GetActivityStreamsNext() vocab.ActivityStreamsNextProperty
+}
+
 // publisheder is an ActivityStreams type with a 'published' property
 type publisheder interface {
 	GetActivityStreamsPublished() vocab.ActivityStreamsPublishedProperty
@@ -115,3 +125,33 @@ type actorer interface {
 type appendIRIer interface {
 	AppendIRI(v *url.URL)
 }
+
+// alsoKnownAser is an ActivityStreams type with an 'alsoKnownAs' property
+type alsoKnownAser interface {
+	GetActivityStreamsAlsoKnownAs() vocab.ActivityStreamsAlsoKnownAsProperty
+}
+
+// publicKeyer is an ActivityStreams type with a 'publicKey' property
+type publicKeyer interface {
+	GetW3IDSecurityV1PublicKey() vocab.W3IDSecurityV1PublicKeyProperty
+}
+
+// featureder is an ActivityStreams type with a 'featured' property
+type featureder interface {
+	GetTootFeatured() vocab.TootFeaturedProperty
+}
+
+// featuredTagser is an ActivityStreams type with a 'featuredTags' property
+type featuredTagser interface {
+	GetTootFeaturedTags() vocab.TootFeaturedTagsProperty
+}
+
+// totalItemser is an ActivityStreams type with a 'totalItems' property
+type totalItemser interface {
+	GetActivityStreamsTotalItems() vocab.ActivityStreamsTotalItemsProperty
+}
+
+// endpointser is an ActivityStreams type with an 'endpoints' property
+type endpointser interface {
+	GetActivityStreamsEndpoints() vocab.ActivityStreamsEndpointsProperty
+}
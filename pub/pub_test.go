@@ -244,6 +244,9 @@ func setupData() {
 		actor := streams.NewActivityStreamsActorProperty()
 		actor.AppendIRI(mustParse(testFederatedActorIRI))
 		testCreateNoId.SetActivityStreamsActor(actor)
+		to := streams.NewActivityStreamsToProperty()
+		to.AppendIRI(mustParse(testFederatedActorIRI2))
+		testCreateNoId.SetActivityStreamsTo(to)
 		op := streams.NewActivityStreamsObjectProperty()
 		op.AppendActivityStreamsNote(testFederatedNote)
 		testCreateNoId.SetActivityStreamsObject(op)
@@ -697,6 +700,16 @@ func newObjectWithId(id string) vocab.ActivityStreamsObject {
 	return obj
 }
 
+// newObjectAttributedTo creates a generic Object with a given id that is
+// attributed to the actor.
+func newObjectAttributedTo(id, actor string) vocab.ActivityStreamsObject {
+	obj := newObjectWithId(id)
+	attrTo := streams.NewActivityStreamsAttributedToProperty()
+	attrTo.AppendIRI(mustParse(actor))
+	obj.SetActivityStreamsAttributedTo(attrTo)
+	return obj
+}
+
 // newActivityWithId creates a generic Activity with a given id.
 func newActivityWithId(id string) vocab.ActivityStreamsActivity {
 	a := streams.NewActivityStreamsActivity()
@@ -0,0 +1,105 @@
+package pub
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// RateLimiter limits the rate of requests sent to peers, such as by a
+// HttpSigTransport.
+type RateLimiter interface {
+	// Wait blocks until a request may be sent to the host, or the context
+	// is done, in which case the context's error is returned.
+	Wait(c context.Context, host string) error
+}
+
+// HostRateLimiter is a RateLimiter applying a separate token bucket to each
+// host.
+//
+// It is safe to use concurrently, and is meant to be shared by all of the
+// Transports of an application so that the limit applies to the server as a
+// whole.
+type HostRateLimiter struct {
+	clock   Clock
+	rate    float64
+	burst   float64
+	mu      sync.Mutex
+	buckets map[string]*tokenBucket
+}
+
+// tokenBucket is the state of a single host's bucket.
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// NewHostRateLimiter returns a HostRateLimiter permitting rate requests per
+// second to each host, with bursts of up to burst requests.
+//
+// The rate must be positive, and the burst must be at least one.
+func NewHostRateLimiter(clock Clock, rate float64, burst int) (*HostRateLimiter, error) {
+	if rate <= 0 {
+		return nil, fmt.Errorf("rate limit must be positive: %v", rate)
+	} else if burst < 1 {
+		return nil, fmt.Errorf("rate limit burst must be at least one: %d", burst)
+	}
+	return &HostRateLimiter{
+		clock:   clock,
+		rate:    rate,
+		burst:   float64(burst),
+		buckets: make(map[string]*tokenBucket),
+	}, nil
+}
+
+// Wait takes a token from the host's bucket, blocking until one is available
+// or the context is done.
+func (l *HostRateLimiter) Wait(c context.Context, host string) error {
+	delay := l.reserve(host)
+	if delay <= 0 {
+		return nil
+	}
+	t := time.NewTimer(delay)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-c.Done():
+		l.cancel(host)
+		return c.Err()
+	}
+}
+
+// reserve takes a token from the host's bucket, returning how long to wait
+// before the token is available.
+func (l *HostRateLimiter) reserve(host string) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.clock.Now()
+	b, ok := l.buckets[host]
+	if !ok {
+		b = &tokenBucket{tokens: l.burst, last: now}
+		l.buckets[host] = b
+	} else if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens += elapsed.Seconds() * l.rate
+		if b.tokens > l.burst {
+			b.tokens = l.burst
+		}
+		b.last = now
+	}
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / l.rate * float64(time.Second))
+}
+
+// cancel returns a reserved token to the host's bucket.
+func (l *HostRateLimiter) cancel(host string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if b, ok := l.buckets[host]; ok && b.tokens < l.burst {
+		b.tokens++
+	}
+}
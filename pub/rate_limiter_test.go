@@ -0,0 +1,88 @@
+package pub
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestNewHostRateLimiter(t *testing.T) {
+	if _, err := NewHostRateLimiter(nil, 0, 1); err == nil {
+		t.Errorf("expected error for a zero rate")
+	}
+	if _, err := NewHostRateLimiter(nil, 1, 0); err == nil {
+		t.Errorf("expected error for a zero burst")
+	}
+}
+
+func TestHostRateLimiter(t *testing.T) {
+	ctx := context.Background()
+	setupFn := func(ctl *gomock.Controller, rate float64, burst int) (c *MockClock, l *HostRateLimiter) {
+		c = NewMockClock(ctl)
+		l, err := NewHostRateLimiter(c, rate, burst)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	t.Run("PermitsBurstImmediately", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		c, l := setupFn(ctl, 1, 3)
+		c.EXPECT().Now().Return(now()).Times(3)
+		for i := 0; i < 3; i++ {
+			assertEqual(t, l.reserve("example.com"), time.Duration(0))
+		}
+	})
+	t.Run("DelaysWhenBucketEmpty", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		c, l := setupFn(ctl, 2, 1)
+		c.EXPECT().Now().Return(now()).Times(3)
+		assertEqual(t, l.reserve("example.com"), time.Duration(0))
+		assertEqual(t, l.reserve("example.com"), 500*time.Millisecond)
+		assertEqual(t, l.reserve("example.com"), time.Second)
+	})
+	t.Run("RefillsOverTime", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		c, l := setupFn(ctl, 2, 1)
+		c.EXPECT().Now().Return(now())
+		c.EXPECT().Now().Return(now().Add(500 * time.Millisecond))
+		assertEqual(t, l.reserve("example.com"), time.Duration(0))
+		assertEqual(t, l.reserve("example.com"), time.Duration(0))
+	})
+	t.Run("KeysBucketsByHost", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		c, l := setupFn(ctl, 1, 1)
+		c.EXPECT().Now().Return(now()).Times(2)
+		assertEqual(t, l.reserve("example.com"), time.Duration(0))
+		assertEqual(t, l.reserve("other.example.com"), time.Duration(0))
+	})
+	t.Run("WaitBlocksUntilPermitted", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		c, l := setupFn(ctl, 100, 1)
+		c.EXPECT().Now().Return(now()).Times(2)
+		assertEqual(t, l.Wait(ctx, "example.com"), nil)
+		start := time.Now()
+		assertEqual(t, l.Wait(ctx, "example.com"), nil)
+		if elapsed := time.Since(start); elapsed < 10*time.Millisecond {
+			t.Errorf("expected Wait to block, returned after %s", elapsed)
+		}
+	})
+	t.Run("WaitHonorsContextAndReturnsToken", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		c, l := setupFn(ctl, 1, 1)
+		c.EXPECT().Now().Return(now()).Times(3)
+		assertEqual(t, l.Wait(ctx, "example.com"), nil)
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+		assertEqual(t, l.Wait(cancelled, "example.com"), context.Canceled)
+		assertEqual(t, l.reserve("example.com"), time.Second)
+	})
+}
@@ -0,0 +1,141 @@
+package pub
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/go-fed/activity/streams/vocab"
+)
+
+const (
+	// misskeyReactionProperty is the non-standard property Misskey uses to
+	// carry the reaction of a Like, in addition to 'content'.
+	misskeyReactionProperty = "_misskey_reaction"
+)
+
+// EmojiReaction is the emoji carried by a Like that is used as a reaction, as
+// sent by servers such as Misskey and Pleroma.
+type EmojiReaction struct {
+	// Content is the reaction: either a unicode emoji, or the shortcode of
+	// a custom emoji surrounded by colons such as ":blobcat:".
+	Content string
+	// Emoji is the custom emoji in the 'tag' property matching the
+	// shortcode in Content. It is nil for unicode emoji, or if the peer
+	// did not provide the custom emoji.
+	Emoji vocab.TootEmoji
+	// IconURL is the validated URL of the custom emoji's Image. It is nil
+	// if Emoji is nil.
+	IconURL *url.URL
+}
+
+// IsCustom returns true if the reaction is a custom emoji shortcode instead
+// of a unicode emoji.
+func (e EmojiReaction) IsCustom() bool {
+	return isEmojiShortcode(e.Content)
+}
+
+// GetEmojiReaction obtains the emoji reaction carried by a Like, returning nil
+// if the Like is a plain Like without an emoji.
+//
+// The reaction is read from the 'content' property, falling back on Misskey's
+// '_misskey_reaction'. A custom emoji shortcode is matched against the Emoji
+// values in the 'tag' property, whose icon must be an Image with an absolute
+// http or https URL, otherwise an error is returned.
+func GetEmojiReaction(a vocab.ActivityStreamsLike) (*EmojiReaction, error) {
+	content := getReactionContent(a)
+	if len(content) == 0 {
+		return nil, nil
+	}
+	r := &EmojiReaction{Content: content}
+	if !r.IsCustom() {
+		return r, nil
+	}
+	tags := a.GetActivityStreamsTag()
+	if tags == nil {
+		return r, nil
+	}
+	for iter := tags.Begin(); iter != tags.End(); iter = iter.Next() {
+		if !iter.IsTootEmoji() {
+			continue
+		}
+		emoji := iter.GetTootEmoji()
+		if !emojiHasName(emoji, content) {
+			continue
+		}
+		u, err := getEmojiIconURL(emoji)
+		if err != nil {
+			return nil, err
+		}
+		r.Emoji = emoji
+		r.IconURL = u
+		break
+	}
+	return r, nil
+}
+
+// getReactionContent obtains the trimmed reaction string of a Like.
+func getReactionContent(a vocab.ActivityStreamsLike) string {
+	if content := a.GetActivityStreamsContent(); content != nil {
+		for iter := content.Begin(); iter != content.End(); iter = iter.Next() {
+			if iter.IsXMLSchemaString() {
+				if s := strings.TrimSpace(iter.GetXMLSchemaString()); len(s) > 0 {
+					return s
+				}
+			}
+		}
+	}
+	if s, ok := a.GetUnknownProperties()[misskeyReactionProperty].(string); ok {
+		return strings.TrimSpace(s)
+	}
+	return ""
+}
+
+// isEmojiShortcode returns true if the string is a custom emoji shortcode
+// surrounded by colons.
+func isEmojiShortcode(s string) bool {
+	return len(s) > 2 && strings.HasPrefix(s, ":") && strings.HasSuffix(s, ":")
+}
+
+// emojiHasName returns true if the custom emoji has the shortcode as its name.
+//
+// Peers differ on whether the name is surrounded by colons, so both forms are
+// accepted.
+func emojiHasName(e vocab.TootEmoji, shortcode string) bool {
+	name := e.GetActivityStreamsName()
+	if name == nil {
+		return false
+	}
+	want := strings.Trim(shortcode, ":")
+	for iter := name.Begin(); iter != name.End(); iter = iter.Next() {
+		if iter.IsXMLSchemaString() && strings.Trim(iter.GetXMLSchemaString(), ":") == want {
+			return true
+		}
+	}
+	return false
+}
+
+// getEmojiIconURL obtains the URL of the Image icon of a custom emoji,
+// ensuring it is an absolute http or https URL.
+func getEmojiIconURL(e vocab.TootEmoji) (*url.URL, error) {
+	icon := e.GetActivityStreamsIcon()
+	if icon == nil || icon.Len() == 0 || !icon.At(0).IsActivityStreamsImage() {
+		return nil, fmt.Errorf("custom emoji has no Image icon")
+	}
+	urls := icon.At(0).GetActivityStreamsImage().GetActivityStreamsUrl()
+	if urls == nil || urls.Len() == 0 {
+		return nil, fmt.Errorf("custom emoji Image has no url")
+	}
+	var u *url.URL
+	if iter := urls.At(0); iter.IsXMLSchemaAnyURI() {
+		u = iter.GetXMLSchemaAnyURI()
+	} else if iter.IsIRI() {
+		u = iter.GetIRI()
+	} else {
+		return nil, fmt.Errorf("custom emoji Image url is not a URL")
+	}
+	if !u.IsAbs() || (u.Scheme != "https" && u.Scheme != "http") || len(u.Host) == 0 {
+		return nil, fmt.Errorf("custom emoji Image url is not an absolute http or https URL: %s", u)
+	}
+	return u, nil
+}
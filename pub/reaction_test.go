@@ -0,0 +1,99 @@
+package pub
+
+import (
+	"testing"
+
+	"github.com/go-fed/activity/streams"
+	"github.com/go-fed/activity/streams/vocab"
+)
+
+const (
+	testEmojiIconURL = "https://other.example.com/emoji/blobcat.png"
+)
+
+// newTestEmoji returns a custom emoji with the name and icon URL.
+func newTestEmoji(name, iconURL string) vocab.TootEmoji {
+	e := streams.NewTootEmoji()
+	n := streams.NewActivityStreamsNameProperty()
+	n.AppendXMLSchemaString(name)
+	e.SetActivityStreamsName(n)
+	img := streams.NewActivityStreamsImage()
+	u := streams.NewActivityStreamsUrlProperty()
+	u.AppendIRI(mustParse(iconURL))
+	img.SetActivityStreamsUrl(u)
+	icon := streams.NewActivityStreamsIconProperty()
+	icon.AppendActivityStreamsImage(img)
+	e.SetActivityStreamsIcon(icon)
+	return e
+}
+
+// newTestReactionLike returns a Like with the content and custom emoji tags.
+func newTestReactionLike(content string, emoji ...vocab.TootEmoji) vocab.ActivityStreamsLike {
+	l := streams.NewActivityStreamsLike()
+	c := streams.NewActivityStreamsContentProperty()
+	c.AppendXMLSchemaString(content)
+	l.SetActivityStreamsContent(c)
+	if len(emoji) > 0 {
+		tag := streams.NewActivityStreamsTagProperty()
+		for _, e := range emoji {
+			tag.AppendTootEmoji(e)
+		}
+		l.SetActivityStreamsTag(tag)
+	}
+	return l
+}
+
+func TestGetEmojiReaction(t *testing.T) {
+	t.Run("ReturnsNilForPlainLike", func(t *testing.T) {
+		r, err := GetEmojiReaction(streams.NewActivityStreamsLike())
+		assertEqual(t, err, nil)
+		assertEqual(t, r == nil, true)
+	})
+	t.Run("ReturnsUnicodeEmoji", func(t *testing.T) {
+		r, err := GetEmojiReaction(newTestReactionLike("🔥"))
+		assertEqual(t, err, nil)
+		assertEqual(t, r.Content, "🔥")
+		assertEqual(t, r.IsCustom(), false)
+		assertEqual(t, r.Emoji == nil, true)
+	})
+	t.Run("ReturnsCustomEmojiFromTag", func(t *testing.T) {
+		e := newTestEmoji(":blobcat:", testEmojiIconURL)
+		r, err := GetEmojiReaction(newTestReactionLike(":blobcat:", newTestEmoji(":other:", testEmojiIconURL), e))
+		assertEqual(t, err, nil)
+		assertEqual(t, r.Content, ":blobcat:")
+		assertEqual(t, r.IsCustom(), true)
+		assertEqual(t, r.Emoji, e)
+		assertEqual(t, r.IconURL.String(), testEmojiIconURL)
+	})
+	t.Run("MatchesCustomEmojiNameWithoutColons", func(t *testing.T) {
+		e := newTestEmoji("blobcat", testEmojiIconURL)
+		r, err := GetEmojiReaction(newTestReactionLike(":blobcat:", e))
+		assertEqual(t, err, nil)
+		assertEqual(t, r.Emoji, e)
+	})
+	t.Run("ReturnsCustomShortcodeWithoutMatchingTag", func(t *testing.T) {
+		r, err := GetEmojiReaction(newTestReactionLike(":blobcat:"))
+		assertEqual(t, err, nil)
+		assertEqual(t, r.Content, ":blobcat:")
+		assertEqual(t, r.Emoji == nil, true)
+		assertEqual(t, r.IconURL == nil, true)
+	})
+	t.Run("FallsBackOnMisskeyReaction", func(t *testing.T) {
+		l := streams.NewActivityStreamsLike()
+		l.GetUnknownProperties()[misskeyReactionProperty] = "👍"
+		r, err := GetEmojiReaction(l)
+		assertEqual(t, err, nil)
+		assertEqual(t, r.Content, "👍")
+	})
+	t.Run("ErrorIfCustomEmojiURLNotHTTP", func(t *testing.T) {
+		e := newTestEmoji(":blobcat:", "javascript:alert(1)")
+		_, err := GetEmojiReaction(newTestReactionLike(":blobcat:", e))
+		assertNotEqual(t, err, nil)
+	})
+	t.Run("ErrorIfCustomEmojiHasNoImage", func(t *testing.T) {
+		e := newTestEmoji(":blobcat:", testEmojiIconURL)
+		e.SetActivityStreamsIcon(nil)
+		_, err := GetEmojiReaction(newTestReactionLike(":blobcat:", e))
+		assertNotEqual(t, err, nil)
+	})
+}
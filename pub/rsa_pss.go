@@ -0,0 +1,177 @@
+package pub
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/go-fed/httpsig"
+)
+
+const (
+	// PS256 signs HTTP Signatures with RSASSA-PSS and SHA-256, using the
+	// same RSA keys as httpsig.RSA_SHA256.
+	PS256 httpsig.Algorithm = "rsa-pss-sha256"
+	// PS512 signs HTTP Signatures with RSASSA-PSS and SHA-512, using the
+	// same RSA keys as httpsig.RSA_SHA512.
+	PS512 httpsig.Algorithm = "rsa-pss-sha512"
+)
+
+// hs2019 is the algorithm declared by peers that leave it to the key, which
+// for an RSA key may be RSASSA-PSS with SHA-512.
+const hs2019 = "hs2019"
+
+// pssHashes are the hashes of the RSASSA-PSS algorithms, which the httpsig
+// library does not support.
+var pssHashes = map[httpsig.Algorithm]crypto.Hash{
+	PS256: crypto.SHA256,
+	PS512: crypto.SHA512,
+}
+
+// pssSigner is a httpsig.Signer using RSASSA-PSS. It does not compute the
+// Digest, which a validatingSigner sets before signing.
+type pssSigner struct {
+	algo    httpsig.Algorithm
+	hash    crypto.Hash
+	headers []string
+	scheme  httpsig.SignatureScheme
+}
+
+// newPSSSigner returns a pssSigner for the algorithm, or false if it is not an
+// RSASSA-PSS algorithm.
+func newPSSSigner(algo httpsig.Algorithm, headers []string, scheme httpsig.SignatureScheme) (*pssSigner, bool) {
+	hash, ok := pssHashes[algo]
+	if !ok {
+		return nil, false
+	}
+	if len(headers) == 0 {
+		headers = []string{dateHeaderName}
+	}
+	return &pssSigner{
+		algo:    algo,
+		hash:    hash,
+		headers: headers,
+		scheme:  scheme,
+	}, true
+}
+
+// SignRequest signs the request with the *rsa.PrivateKey.
+func (p *pssSigner) SignRequest(pKey crypto.PrivateKey, pubKeyId string, r *http.Request, body []byte) error {
+	return p.sign(pKey, pubKeyId, r.Header, requestTarget(r))
+}
+
+// SignResponse signs the response with the *rsa.PrivateKey.
+func (p *pssSigner) SignResponse(pKey crypto.PrivateKey, pubKeyId string, w http.ResponseWriter, body []byte) error {
+	return p.sign(pKey, pubKeyId, w.Header(), "")
+}
+
+// sign adds the signature of the headers to the Signature or Authorization
+// header.
+func (p *pssSigner) sign(pKey crypto.PrivateKey, pubKeyId string, h http.Header, target string) error {
+	k, ok := pKey.(*rsa.PrivateKey)
+	if !ok {
+		return fmt.Errorf("http signature algorithm %s requires an *rsa.PrivateKey, got %T", p.algo, pKey)
+	}
+	s, err := pssSignatureString(h, "", p.headers, target, nil)
+	if err != nil {
+		return err
+	}
+	sig, err := rsa.SignPSS(rand.Reader, k, p.hash, hashOf(p.hash, s), &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+	if err != nil {
+		return err
+	}
+	value := fmt.Sprintf("keyId=%q,%s=%q,%s=%q,signature=%q", pubKeyId, sigAlgorithmParam, p.algo, sigHeadersParam, strings.Join(p.headers, " "), base64.StdEncoding.EncodeToString(sig))
+	if p.scheme == httpsig.Authorization {
+		value = sigAuthScheme + value
+	}
+	h.Add(string(p.scheme), value)
+	return nil
+}
+
+// verifyPSS verifies the RSASSA-PSS signature with the RSA public key, against
+// each of the request targets it may have been signed with.
+func (v *httpSigVerifier) verifyPSS(pKey crypto.PublicKey, hash crypto.Hash) error {
+	k, ok := pKey.(*rsa.PublicKey)
+	if !ok {
+		return fmt.Errorf("rsassa-pss requires an *rsa.PublicKey, got %T", pKey)
+	}
+	sig, err := base64.StdEncoding.DecodeString(v.params["signature"])
+	if err != nil {
+		return err
+	}
+	headers := strings.Fields(v.params[sigHeadersParam])
+	if len(headers) == 0 {
+		headers = []string{dateHeaderName}
+	}
+	for _, target := range v.targets {
+		s, err := pssSignatureString(v.header, v.host, headers, target, v.params)
+		if err != nil {
+			return err
+		}
+		if err = rsa.VerifyPSS(k, hash, hashOf(hash, s), sig, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthAuto}); err == nil {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid rsassa-pss http signature")
+}
+
+// pssSignatureString builds the string signed by a HTTP Signature over the
+// headers, like the httpsig library does. The host is used for the 'host'
+// header if it is absent, and the params for '(created)' and '(expires)'.
+func pssSignatureString(h http.Header, host string, headers []string, target string, params map[string]string) (string, error) {
+	lines := make([]string, 0, len(headers))
+	for _, name := range headers {
+		name = strings.ToLower(name)
+		var value string
+		switch name {
+		case httpsig.RequestTarget:
+			if len(target) == 0 {
+				return "", fmt.Errorf("cannot sign %q on anything other than a request", name)
+			}
+			value = target
+		case sigCreatedHeader, sigExpiresHeader:
+			v, ok := params[strings.Trim(name, "()")]
+			if !ok {
+				return "", fmt.Errorf("http signature covers %s but its parameter is missing", name)
+			}
+			value = v
+		default:
+			values := h[http.CanonicalHeaderKey(name)]
+			if len(values) == 0 && name == hostHeaderName && len(host) > 0 {
+				values = []string{host}
+			}
+			if len(values) == 0 {
+				return "", fmt.Errorf("missing header %q", name)
+			}
+			trimmed := make([]string, len(values))
+			for i, v := range values {
+				trimmed[i] = strings.TrimSpace(v)
+			}
+			value = strings.Join(trimmed, ", ")
+		}
+		lines = append(lines, name+": "+value)
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// requestTarget returns the '(request-target)' of the request.
+func requestTarget(r *http.Request) string {
+	target := strings.ToLower(r.Method) + " " + r.URL.Path
+	if len(r.URL.RawQuery) > 0 {
+		target += "?" + r.URL.RawQuery
+	}
+	return target
+}
+
+// hashOf hashes the signature string.
+func hashOf(hash crypto.Hash, s string) []byte {
+	h := hash.New()
+	h.Write([]byte(s))
+	return h.Sum(nil)
+}
@@ -0,0 +1,85 @@
+package pub
+
+import (
+	"context"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// SeenStore records the ids of the activities received in each inbox, so
+// that an activity delivered more than once has its side effects applied only
+// once.
+//
+// Unlike the Database, it is ephemeral: entries expire, and losing them only
+// lets a duplicate through, which the Database then catches when adding the
+// activity to the inbox. It may be backed by a cache such as Redis, and is set
+// with WithSeenStore.
+type SeenStore interface {
+	// MarkSeen records the id as seen in the inbox for the ttl, and
+	// returns whether it had already been seen there and has not yet
+	// expired.
+	//
+	// It must be atomic, so that of several concurrent calls with the same
+	// inbox and id only one returns false.
+	MarkSeen(c context.Context, inboxIRI, id *url.URL, ttl time.Duration) (alreadySeen bool, err error)
+	// Unmark forgets that the id was seen in the inbox, such as when
+	// handling the activity failed, so that it is handled again if
+	// delivered again.
+	Unmark(c context.Context, inboxIRI, id *url.URL) error
+}
+
+// MemorySeenStore is a SeenStore keeping the inboxes and ids in memory.
+//
+// It is safe to use concurrently. Expired ids are removed at most once per
+// ttl, when an id is marked.
+type MemorySeenStore struct {
+	clock     Clock
+	mu        sync.Mutex
+	expiry    map[string]time.Time
+	nextSweep time.Time
+}
+
+var _ SeenStore = &MemorySeenStore{}
+
+// NewMemorySeenStore returns an empty MemorySeenStore.
+func NewMemorySeenStore(clock Clock) *MemorySeenStore {
+	return &MemorySeenStore{
+		clock:  clock,
+		expiry: make(map[string]time.Time),
+	}
+}
+
+// MarkSeen records the id as seen in the inbox until the ttl elapses.
+func (s *MemorySeenStore) MarkSeen(c context.Context, inboxIRI, id *url.URL, ttl time.Duration) (alreadySeen bool, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.clock.Now()
+	if now.After(s.nextSweep) {
+		for k, e := range s.expiry {
+			if !now.Before(e) {
+				delete(s.expiry, k)
+			}
+		}
+		s.nextSweep = now.Add(ttl)
+	}
+	key := seenKey(inboxIRI, id)
+	if e, ok := s.expiry[key]; ok && now.Before(e) {
+		return true, nil
+	}
+	s.expiry[key] = now.Add(ttl)
+	return false, nil
+}
+
+// Unmark forgets the id seen in the inbox.
+func (s *MemorySeenStore) Unmark(c context.Context, inboxIRI, id *url.URL) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.expiry, seenKey(inboxIRI, id))
+	return nil
+}
+
+// seenKey is the key of the id seen in the inbox.
+func seenKey(inboxIRI, id *url.URL) string {
+	return inboxIRI.String() + " " + id.String()
+}
@@ -0,0 +1,74 @@
+package pub
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestMemorySeenStore(t *testing.T) {
+	ctx := context.Background()
+	inbox := mustParse(testMyInboxIRI)
+	id := mustParse(testFederatedActivityIRI)
+	t.Run("MarksIdSeenUntilExpiry", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		c := NewMockClock(ctl)
+		s := NewMemorySeenStore(c)
+		gomock.InOrder(
+			c.EXPECT().Now().Return(now()),
+			c.EXPECT().Now().Return(now().Add(time.Minute)),
+			c.EXPECT().Now().Return(now().Add(time.Hour)),
+		)
+		seen, err := s.MarkSeen(ctx, inbox, id, time.Hour)
+		assertEqual(t, seen, false)
+		assertEqual(t, err, nil)
+		seen, err = s.MarkSeen(ctx, inbox, id, time.Hour)
+		assertEqual(t, seen, true)
+		assertEqual(t, err, nil)
+		seen, err = s.MarkSeen(ctx, inbox, id, time.Hour)
+		assertEqual(t, seen, false)
+		assertEqual(t, err, nil)
+	})
+	t.Run("MarksIdPerInbox", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		c := NewMockClock(ctl)
+		s := NewMemorySeenStore(c)
+		c.EXPECT().Now().Return(now()).Times(2)
+		seen, err := s.MarkSeen(ctx, inbox, id, time.Hour)
+		assertEqual(t, seen, false)
+		assertEqual(t, err, nil)
+		seen, err = s.MarkSeen(ctx, mustParse("https://example.com/sally/inbox"), id, time.Hour)
+		assertEqual(t, seen, false)
+		assertEqual(t, err, nil)
+	})
+	t.Run("UnmarksId", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		c := NewMockClock(ctl)
+		s := NewMemorySeenStore(c)
+		c.EXPECT().Now().Return(now()).Times(2)
+		s.MarkSeen(ctx, inbox, id, time.Hour)
+		err := s.Unmark(ctx, inbox, id)
+		assertEqual(t, err, nil)
+		seen, err := s.MarkSeen(ctx, inbox, id, time.Hour)
+		assertEqual(t, seen, false)
+		assertEqual(t, err, nil)
+	})
+	t.Run("RemovesExpiredIds", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		c := NewMockClock(ctl)
+		s := NewMemorySeenStore(c)
+		gomock.InOrder(
+			c.EXPECT().Now().Return(now()),
+			c.EXPECT().Now().Return(now().Add(2*time.Hour)),
+		)
+		s.MarkSeen(ctx, inbox, id, time.Hour)
+		s.MarkSeen(ctx, inbox, mustParse(testFederatedActivityIRI2), time.Hour)
+		assertEqual(t, len(s.expiry), 1)
+	})
+}
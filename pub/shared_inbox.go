@@ -0,0 +1,204 @@
+package pub
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/go-fed/activity/streams/vocab"
+)
+
+const (
+	endpointsProperty   = "endpoints"
+	sharedInboxProperty = "sharedInbox"
+)
+
+// Recipient is an actor an activity is delivered to, along with the actor's
+// personal inbox.
+type Recipient struct {
+	// ActorIRI is the id of the actor.
+	ActorIRI *url.URL
+	// InboxIRI is the personal inbox of the actor.
+	InboxIRI *url.URL
+}
+
+// ResolveSharedInboxIRIs is an implementation of InboxResolver's
+// ResolveInboxIRIs that delivers to sharedInbox endpoints where possible.
+//
+// The receivers are grouped by the host of their actor IRI. For every host
+// with more than one receiver, one actor is dereferenced to read its
+// 'endpoints.sharedInbox'. If present, the shared inbox replaces the personal
+// inboxes of those receivers, otherwise their personal inboxes are used.
+// Hidden receivers are always delivered to their personal inboxes.
+//
+// Dereferencing failures are not errors; the personal inboxes are used
+// instead.
+func ResolveSharedInboxIRIs(c context.Context, t Transport, receivers, hiddenReceivers []Recipient) (inboxes []*url.URL, err error) {
+	inboxes = collapseToSharedInboxes(receivers, hiddenReceivers, func(rs []Recipient) *url.URL {
+		return dereferenceSharedInbox(c, t, rs[0].ActorIRI)
+	})
+	return
+}
+
+// FollowerInboxes walks the followers collection with WalkCollection and
+// returns the deduplicated inboxes its members are delivered to, collapsed to
+// shared inboxes as by ResolveSharedInboxIRIs.
+//
+// Followers that are not embedded are dereferenced with the Transport, and the
+// shared inbox of a host is read from the 'endpoints' of its first follower.
+// Together with DiffInboxes, it allows maintaining a precomputed list of
+// delivery targets incrementally.
+//
+// A follower that cannot be resolved, for example because its server is
+// unreachable or it is gone, is skipped. The inboxes of the other followers
+// are returned along with a *FollowerInboxesError. Failing to walk the
+// collection itself returns no inboxes.
+func FollowerInboxes(c context.Context, t Transport, followers vocab.Type) (inboxes []*url.URL, err error) {
+	var receivers []Recipient
+	var errs []error
+	shared := make(map[string]*url.URL)
+	err = WalkCollection(c, t, followers, func(item IdProperty) error {
+		r, sharedInbox, err := resolveFollower(c, t, item)
+		if err != nil {
+			errs = append(errs, err)
+			return nil
+		}
+		receivers = append(receivers, r)
+		if sharedInbox != nil {
+			shared[r.ActorIRI.String()] = sharedInbox
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	inboxes = collapseToSharedInboxes(receivers, nil, func(rs []Recipient) *url.URL {
+		return shared[rs[0].ActorIRI.String()]
+	})
+	inboxes = dedupeIRIs(inboxes, nil)
+	if len(errs) > 0 {
+		return inboxes, &FollowerInboxesError{Errors: errs}
+	}
+	return inboxes, nil
+}
+
+// resolveFollower returns the Recipient and shared inbox, if any, of a member
+// of a followers collection, dereferencing it if it is not embedded.
+func resolveFollower(c context.Context, t Transport, item IdProperty) (r Recipient, sharedInbox *url.URL, err error) {
+	actor := item.GetType()
+	if actor == nil {
+		var id *url.URL
+		if id, err = ToId(item); err != nil {
+			return
+		}
+		if actor, err = dereferenceType(c, t, id); err != nil {
+			err = fmt.Errorf("follower %s: %s", id, err)
+			return
+		}
+	}
+	if r.ActorIRI, err = GetId(actor); err != nil {
+		return
+	}
+	if r.InboxIRI, err = getInbox(actor); err != nil {
+		err = fmt.Errorf("follower %s: %s", r.ActorIRI, err)
+		return
+	}
+	if e, err := GetEndpoints(actor); err == nil {
+		sharedInbox = e.SharedInbox
+	}
+	return
+}
+
+// FollowerInboxesError is returned by FollowerInboxes along with the inboxes
+// of the followers that were resolved.
+type FollowerInboxesError struct {
+	// Errors are the errors of the followers that could not be resolved,
+	// in the order of the collection.
+	Errors []error
+}
+
+// Error describes the number of unresolved followers and the first error.
+func (e *FollowerInboxesError) Error() string {
+	return fmt.Sprintf("%d followers could not be resolved, the first with: %s", len(e.Errors), e.Errors[0])
+}
+
+// DiffInboxes compares two snapshots of a set of inboxes, such as those
+// returned by FollowerInboxes at two points in time, and returns the inboxes
+// only in the new snapshot and those only in the old one. Each is
+// deduplicated and in the order of its snapshot.
+func DiffInboxes(old, new []*url.URL) (added, removed []*url.URL) {
+	newSet := make(map[string]bool, len(new))
+	for _, u := range new {
+		newSet[u.String()] = true
+	}
+	added = dedupeIRIs(new, old)
+	for _, u := range dedupeIRIs(old, nil) {
+		if !newSet[u.String()] {
+			removed = append(removed, u)
+		}
+	}
+	return
+}
+
+// collapseToSharedInboxes groups the receivers by the host of their actor IRI
+// and determines the inboxes to deliver to.
+//
+// The sharedInbox function is only called with the receivers of a host having
+// more than one receiver, and returns their shared inbox or nil if there is
+// none. The hidden receivers are never grouped nor passed to it, so they are
+// always delivered to their personal inboxes and do not count towards the
+// receivers of a host: delivering to a shared inbox on their behalf would
+// reveal them to every actor sharing it.
+func collapseToSharedInboxes(receivers, hiddenReceivers []Recipient, sharedInbox func(rs []Recipient) *url.URL) (inboxes []*url.URL) {
+	var hosts []string
+	byHost := make(map[string][]Recipient)
+	for _, r := range receivers {
+		host := r.ActorIRI.Host
+		if _, ok := byHost[host]; !ok {
+			hosts = append(hosts, host)
+		}
+		byHost[host] = append(byHost[host], r)
+	}
+	for _, host := range hosts {
+		rs := byHost[host]
+		if len(rs) > 1 {
+			if shared := sharedInbox(rs); shared != nil {
+				inboxes = append(inboxes, shared)
+				continue
+			}
+		}
+		for _, r := range rs {
+			inboxes = append(inboxes, r.InboxIRI)
+		}
+	}
+	for _, r := range hiddenReceivers {
+		inboxes = append(inboxes, r.InboxIRI)
+	}
+	return
+}
+
+// dereferenceSharedInbox obtains the 'endpoints.sharedInbox' of an actor,
+// returning nil if the actor cannot be dereferenced or has none.
+func dereferenceSharedInbox(c context.Context, t Transport, actorIRI *url.URL) *url.URL {
+	b, err := t.Dereference(c, actorIRI)
+	if err != nil {
+		return nil
+	}
+	var m map[string]interface{}
+	if err = unmarshalJSON(b, &m); err != nil {
+		return nil
+	}
+	endpoints, ok := m[endpointsProperty].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	s, ok := endpoints[sharedInboxProperty].(string)
+	if !ok {
+		return nil
+	}
+	u, err := url.Parse(s)
+	if err != nil || !u.IsAbs() {
+		return nil
+	}
+	return u
+}
@@ -0,0 +1,264 @@
+package pub
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+const (
+	testSharedInboxIRI     = "https://other.example.com/inbox"
+	testLoneActorIRI       = "https://maybe.example.com/lone"
+	testLoneInboxIRI       = "https://maybe.example.com/lone/inbox"
+	testFederatedInboxIRI3 = "https://other.example.com/sam/inbox"
+)
+
+// mustActorWithSharedInbox serializes an actor with an 'endpoints.sharedInbox'
+// or panics.
+func mustActorWithSharedInbox(actorIRI, inboxIRI, sharedInboxIRI string) []byte {
+	b, err := json.Marshal(map[string]interface{}{
+		"@context": "https://www.w3.org/ns/activitystreams",
+		"type":     "Person",
+		"id":       actorIRI,
+		"inbox":    inboxIRI,
+		"endpoints": map[string]interface{}{
+			"sharedInbox": sharedInboxIRI,
+		},
+	})
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func TestResolveSharedInboxIRIs(t *testing.T) {
+	ctx := context.Background()
+	dakota := Recipient{
+		ActorIRI: mustParse(testFederatedActorIRI),
+		InboxIRI: mustParse(testFederatedInboxIRI),
+	}
+	addison := Recipient{
+		ActorIRI: mustParse(testFederatedActorIRI2),
+		InboxIRI: mustParse(testFederatedInboxIRI2),
+	}
+	lone := Recipient{
+		ActorIRI: mustParse(testLoneActorIRI),
+		InboxIRI: mustParse(testLoneInboxIRI),
+	}
+	t.Run("CollapsesReceiversOnSameHost", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		tp := NewMockTransport(ctl)
+		// Mock
+		tp.EXPECT().Dereference(ctx, mustParse(testFederatedActorIRI)).Return(
+			mustActorWithSharedInbox(testFederatedActorIRI, testFederatedInboxIRI, testSharedInboxIRI), nil)
+		// Run
+		inboxes, err := ResolveSharedInboxIRIs(ctx, tp, []Recipient{dakota, lone, addison}, nil)
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, len(inboxes), 2)
+		assertEqual(t, inboxes[0].String(), testSharedInboxIRI)
+		assertEqual(t, inboxes[1].String(), testLoneInboxIRI)
+	})
+	t.Run("KeepsHiddenReceiversOnPersonalInboxes", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		tp := NewMockTransport(ctl)
+		// Run
+		inboxes, err := ResolveSharedInboxIRIs(ctx, tp, nil, []Recipient{dakota, addison})
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, len(inboxes), 2)
+		assertEqual(t, inboxes[0].String(), testFederatedInboxIRI)
+		assertEqual(t, inboxes[1].String(), testFederatedInboxIRI2)
+	})
+	t.Run("UsesPersonalInboxesWithoutSharedInbox", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		tp := NewMockTransport(ctl)
+		// Mock
+		tp.EXPECT().Dereference(ctx, mustParse(testFederatedActorIRI)).Return(
+			mustSerializeToBytes(testFederatedPerson1), nil)
+		// Run
+		inboxes, err := ResolveSharedInboxIRIs(ctx, tp, []Recipient{dakota, addison}, nil)
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, len(inboxes), 2)
+		assertEqual(t, inboxes[0].String(), testFederatedInboxIRI)
+		assertEqual(t, inboxes[1].String(), testFederatedInboxIRI2)
+	})
+	t.Run("UsesPersonalInboxesIfDereferenceFails", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		tp := NewMockTransport(ctl)
+		// Mock
+		tp.EXPECT().Dereference(ctx, mustParse(testFederatedActorIRI)).Return(
+			nil, fmt.Errorf("test error"))
+		// Run
+		inboxes, err := ResolveSharedInboxIRIs(ctx, tp, []Recipient{dakota, addison}, nil)
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, len(inboxes), 2)
+		assertEqual(t, inboxes[0].String(), testFederatedInboxIRI)
+		assertEqual(t, inboxes[1].String(), testFederatedInboxIRI2)
+	})
+}
+
+func TestCollapseToSharedInboxes(t *testing.T) {
+	dakota := Recipient{
+		ActorIRI: mustParse(testFederatedActorIRI),
+		InboxIRI: mustParse(testFederatedInboxIRI),
+	}
+	addison := Recipient{
+		ActorIRI: mustParse(testFederatedActorIRI2),
+		InboxIRI: mustParse(testFederatedInboxIRI2),
+	}
+	sam := Recipient{
+		ActorIRI: mustParse(testFederatedActorIRI3),
+		InboxIRI: mustParse(testFederatedInboxIRI3),
+	}
+	shared := func(rs []Recipient) *url.URL {
+		return mustParse(testSharedInboxIRI)
+	}
+	t.Run("NeverCollapsesHiddenReceiverOnSameHost", func(t *testing.T) {
+		// Setup
+		var grouped []Recipient
+		// Run
+		inboxes := collapseToSharedInboxes([]Recipient{dakota, addison}, []Recipient{sam}, func(rs []Recipient) *url.URL {
+			grouped = append(grouped, rs...)
+			return shared(rs)
+		})
+		// Verify
+		assertEqual(t, len(inboxes), 2)
+		assertEqual(t, inboxes[0].String(), testSharedInboxIRI)
+		assertEqual(t, inboxes[1].String(), testFederatedInboxIRI3)
+		assertEqual(t, len(grouped), 2)
+		assertEqual(t, grouped[0].ActorIRI.String(), testFederatedActorIRI)
+		assertEqual(t, grouped[1].ActorIRI.String(), testFederatedActorIRI2)
+	})
+	t.Run("HiddenReceiversDoNotCountTowardsHost", func(t *testing.T) {
+		// Setup
+		called := false
+		// Run
+		inboxes := collapseToSharedInboxes([]Recipient{dakota}, []Recipient{addison, sam}, func(rs []Recipient) *url.URL {
+			called = true
+			return shared(rs)
+		})
+		// Verify
+		assertEqual(t, called, false)
+		assertEqual(t, len(inboxes), 3)
+		assertEqual(t, inboxes[0].String(), testFederatedInboxIRI)
+		assertEqual(t, inboxes[1].String(), testFederatedInboxIRI2)
+		assertEqual(t, inboxes[2].String(), testFederatedInboxIRI3)
+	})
+	t.Run("UsesPersonalInboxesWithoutSharedInbox", func(t *testing.T) {
+		// Run
+		inboxes := collapseToSharedInboxes([]Recipient{dakota, addison}, nil, func(rs []Recipient) *url.URL {
+			return nil
+		})
+		// Verify
+		assertEqual(t, len(inboxes), 2)
+		assertEqual(t, inboxes[0].String(), testFederatedInboxIRI)
+		assertEqual(t, inboxes[1].String(), testFederatedInboxIRI2)
+	})
+}
+
+func TestFollowerInboxes(t *testing.T) {
+	ctx := context.Background()
+	followers := mustToTestType(t, fmt.Sprintf(`{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "https://example.com/addison/followers",
+  "type": "OrderedCollection",
+  "orderedItems": [
+    %q,
+    {"type": "Person", "id": %q, "inbox": %q},
+    %q,
+    %q
+  ]
+}`, testFederatedActorIRI, testLoneActorIRI, testLoneInboxIRI, testFederatedActorIRI2, testFederatedActorIRI))
+	t.Run("CollapsesToSharedInboxes", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		tp := NewMockTransport(ctl)
+		// Mock
+		tp.EXPECT().Dereference(ctx, mustParse(testFederatedActorIRI)).Return(
+			mustActorWithSharedInbox(testFederatedActorIRI, testFederatedInboxIRI, testSharedInboxIRI), nil).Times(2)
+		tp.EXPECT().Dereference(ctx, mustParse(testFederatedActorIRI2)).Return(
+			mustActorWithSharedInbox(testFederatedActorIRI2, testFederatedInboxIRI2, testSharedInboxIRI), nil)
+		// Run
+		inboxes, err := FollowerInboxes(ctx, tp, followers)
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, len(inboxes), 2)
+		assertEqual(t, inboxes[0].String(), testSharedInboxIRI)
+		assertEqual(t, inboxes[1].String(), testLoneInboxIRI)
+	})
+	t.Run("SkipsFollowerIfDereferenceFails", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		tp := NewMockTransport(ctl)
+		// Mock
+		tp.EXPECT().Dereference(ctx, mustParse(testFederatedActorIRI)).Return(nil, testErr).Times(2)
+		tp.EXPECT().Dereference(ctx, mustParse(testFederatedActorIRI2)).Return(
+			mustActorWithSharedInbox(testFederatedActorIRI2, testFederatedInboxIRI2, testSharedInboxIRI), nil)
+		// Run
+		inboxes, err := FollowerInboxes(ctx, tp, followers)
+		// Verify
+		fErr, ok := err.(*FollowerInboxesError)
+		assertEqual(t, ok, true)
+		assertEqual(t, len(fErr.Errors), 2)
+		assertEqual(t, len(inboxes), 2)
+		assertEqual(t, inboxes[0].String(), testLoneInboxIRI)
+		assertEqual(t, inboxes[1].String(), testFederatedInboxIRI2)
+	})
+	t.Run("ErrorIfCollectionCannotBeWalked", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		tp := NewMockTransport(ctl)
+		paged := mustToTestType(t, `{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "https://example.com/addison/followers",
+  "type": "OrderedCollection",
+  "first": "https://example.com/addison/followers?page=1"
+}`)
+		// Mock
+		tp.EXPECT().Dereference(ctx, mustParse("https://example.com/addison/followers?page=1")).Return(nil, testErr)
+		// Run
+		inboxes, err := FollowerInboxes(ctx, tp, paged)
+		// Verify
+		assertEqual(t, err, testErr)
+		assertEqual(t, len(inboxes), 0)
+	})
+}
+
+func TestDiffInboxes(t *testing.T) {
+	old := []*url.URL{
+		mustParse(testFederatedInboxIRI),
+		mustParse(testSharedInboxIRI),
+		mustParse(testFederatedInboxIRI),
+	}
+	new := []*url.URL{
+		mustParse(testSharedInboxIRI),
+		mustParse(testLoneInboxIRI),
+		mustParse(testLoneInboxIRI),
+	}
+	added, removed := DiffInboxes(old, new)
+	assertEqual(t, len(added), 1)
+	assertEqual(t, added[0].String(), testLoneInboxIRI)
+	assertEqual(t, len(removed), 1)
+	assertEqual(t, removed[0].String(), testFederatedInboxIRI)
+	added, removed = DiffInboxes(new, new)
+	assertEqual(t, len(added), 0)
+	assertEqual(t, len(removed), 0)
+}
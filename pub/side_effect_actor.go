@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/go-fed/activity/streams"
 	"github.com/go-fed/activity/streams/vocab"
@@ -14,6 +15,15 @@ import (
 // sideEffectActor must satisfy the DelegateActor interface.
 var _ DelegateActor = &sideEffectActor{}
 
+// sideEffectActor creates the Transports of ProcessInboxCollection.
+var _ TransportCreator = &sideEffectActor{}
+
+// sideEffectActor resolves the inboxes of ResolveDeliveryTargets.
+var _ DeliveryTargetsResolver = &sideEffectActor{}
+
+// sideEffectActor sends the activities of DeliverToInbox.
+var _ InboxDeliverer = &sideEffectActor{}
+
 // sideEffectActor is a DelegateActor that handles the ActivityPub
 // implementation side effects, but requires a more opinionated application to
 // be written.
@@ -27,6 +37,40 @@ type sideEffectActor struct {
 	c2s    SocialProtocol
 	db     Database
 	clock  Clock
+	// recoverPanics converts panics in callbacks into errors.
+	recoverPanics bool
+	// unhandled determines what happens to activities received in the inbox
+	// that no callback handles.
+	unhandled UnhandledActivityPolicy
+	// logger receives debug messages, if set.
+	logger Logger
+	// requireAuthorDomainMatch rejects activities received in the inbox
+	// whose authors are on another domain than the signing actor, unless
+	// they are verified with the authorKeys and authorCanon.
+	requireAuthorDomainMatch bool
+	authorKeys               *KeyResolver
+	authorCanon              LDCanonicalizer
+	// cachePolicy determines the caching headers of GetInbox and
+	// GetOutbox responses, if set.
+	cachePolicy CachePolicy
+	// seen deduplicates the activities received in the inbox for the
+	// seenTTL, if set.
+	seen    SeenStore
+	seenTTL time.Duration
+	// consistentCreateAddressing rejects Create activities posted to the
+	// outbox that are addressed differently than their objects.
+	consistentCreateAddressing bool
+	// maxJSONDepth and maxJSONArrayLength bound the JSON posted to an
+	// inbox or outbox, if positive.
+	maxJSONDepth       int
+	maxJSONArrayLength int
+}
+
+// debug logs the message if a Logger is set.
+func (a *sideEffectActor) debug(msg string, keysAndValues ...interface{}) {
+	if a.logger != nil {
+		a.logger.Debug(msg, keysAndValues...)
+	}
 }
 
 // PostInboxRequestBodyHook defers to the delegate.
@@ -34,11 +78,64 @@ func (a *sideEffectActor) PostInboxRequestBodyHook(c context.Context, r *http.Re
 	return a.s2s.PostInboxRequestBodyHook(c, r, activity)
 }
 
+// ActorForRequest defers to the delegate, if it implements ActorResolver.
+func (a *sideEffectActor) ActorForRequest(c context.Context, r *http.Request) (*url.URL, error) {
+	if ar, ok := a.common.(ActorResolver); ok {
+		return ar.ActorForRequest(c, r)
+	}
+	return nil, nil
+}
+
+// PostInboxRawBodyHook defers to the delegate, if it implements RawBodyHooker.
+func (a *sideEffectActor) PostInboxRawBodyHook(c context.Context, r *http.Request, rawBody []byte, activity Activity) (context.Context, error) {
+	if h, ok := a.s2s.(RawBodyHooker); ok {
+		return h.PostInboxRawBodyHook(c, r, rawBody, activity)
+	}
+	return c, nil
+}
+
+// NewTransport defers to the CommonBehavior.
+func (a *sideEffectActor) NewTransport(c context.Context, actorBoxIRI *url.URL, gofedAgent string) (Transport, error) {
+	return a.common.NewTransport(c, actorBoxIRI, gofedAgent)
+}
+
+// CacheControl defers to the CachePolicy, if one is set.
+func (a *sideEffectActor) CacheControl(c context.Context, t vocab.Type) (maxAge time.Duration, etag string, err error) {
+	if a.cachePolicy != nil {
+		return a.cachePolicy.CacheControl(c, t)
+	}
+	return 0, "", nil
+}
+
+// JSONLimits returns the limits set with WithJSONLimits.
+func (a *sideEffectActor) JSONLimits() (maxDepth, maxArrayLength int) {
+	return a.maxJSONDepth, a.maxJSONArrayLength
+}
+
 // PostOutboxRequestBodyHook defers to the delegate.
 func (a *sideEffectActor) PostOutboxRequestBodyHook(c context.Context, r *http.Request, data vocab.Type) (context.Context, error) {
 	return a.c2s.PostOutboxRequestBodyHook(c, r, data)
 }
 
+// ProcessTags defers to the delegate, if it implements TagProcessor.
+func (a *sideEffectActor) ProcessTags(c context.Context, object vocab.Type) error {
+	if p, ok := a.c2s.(TagProcessor); ok {
+		return p.ProcessTags(c, object)
+	}
+	return nil
+}
+
+// DecorateOutbound defers to the SocialProtocol or else the
+// FederatingProtocol, if either implements OutboundDecorator.
+func (a *sideEffectActor) DecorateOutbound(c context.Context, t vocab.Type) error {
+	if d, ok := a.c2s.(OutboundDecorator); ok {
+		return d.DecorateOutbound(c, t)
+	} else if d, ok := a.s2s.(OutboundDecorator); ok {
+		return d.DecorateOutbound(c, t)
+	}
+	return nil
+}
+
 // AuthenticatePostInbox defers to the delegate to authenticate the request.
 func (a *sideEffectActor) AuthenticatePostInbox(c context.Context, w http.ResponseWriter, r *http.Request) (out context.Context, authenticated bool, err error) {
 	return a.s2s.AuthenticatePostInbox(c, w, r)
@@ -98,6 +195,16 @@ func (a *sideEffectActor) AuthorizePostInbox(c context.Context, w http.ResponseW
 		w.WriteHeader(http.StatusForbidden)
 		return
 	}
+	// Determine if the authors are on the domain of the signing actor.
+	if a.requireAuthorDomainMatch {
+		var matches bool
+		if matches, err = a.authorDomainMatches(c, activity); err != nil {
+			return
+		} else if !matches {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+	}
 	authorized = true
 	return
 }
@@ -105,11 +212,32 @@ func (a *sideEffectActor) AuthorizePostInbox(c context.Context, w http.ResponseW
 // PostInbox handles the side effects of determining whether to block the peer's
 // request, adding the activity to the actor's inbox, and triggering side
 // effects based on the activity's type.
-func (a *sideEffectActor) PostInbox(c context.Context, inboxIRI *url.URL, activity Activity) error {
+//
+// If a SeenStore is set, the activity is marked as seen in the inbox before
+// handling it, and unmarked if handling it fails. This includes each inbox an
+// activity received in the shared inbox is handled for.
+func (a *sideEffectActor) PostInbox(c context.Context, inboxIRI *url.URL, activity Activity) (err error) {
+	if id := activity.GetJSONLDId(); a.seen != nil && id != nil {
+		var alreadySeen bool
+		if alreadySeen, err = a.seen.MarkSeen(c, inboxIRI, id.Get(), a.seenTTL); err != nil {
+			return err
+		} else if alreadySeen {
+			a.debug("received activity already seen", "type", activity.GetTypeName(), "id", id.Get(), "inbox", inboxIRI)
+			return nil
+		}
+		defer func() {
+			if err == nil {
+				return
+			} else if uErr := a.seen.Unmark(c, inboxIRI, id.Get()); uErr != nil {
+				a.debug("cannot unmark activity as seen", "id", id.Get(), "inbox", inboxIRI, "error", uErr)
+			}
+		}()
+	}
 	isNew, err := a.addToInboxIfNew(c, inboxIRI, activity)
 	if err != nil {
 		return err
 	}
+	a.debug("received activity", "type", activity.GetTypeName(), "id", idOf(activity), "inbox", inboxIRI, "new", isNew)
 	if isNew {
 		wrapped, other, err := a.s2s.FederatingCallbacks(c)
 		if err != nil {
@@ -121,22 +249,105 @@ func (a *sideEffectActor) PostInbox(c context.Context, inboxIRI *url.URL, activi
 		wrapped.newTransport = a.common.NewTransport
 		wrapped.deliver = a.Deliver
 		wrapped.addNewIds = a.AddNewIDs
+		wrapped.logger = a.logger
 		res, err := streams.NewTypeResolver(wrapped.callbacks(other)...)
 		if err != nil {
 			return err
 		}
-		if err = res.Resolve(c, activity); err != nil && !streams.IsUnmatchedErr(err) {
+		err = a.callCallback(func() error {
+			return res.Resolve(c, activity)
+		})
+		if err != nil && !streams.IsUnmatchedErr(err) {
 			return err
 		} else if streams.IsUnmatchedErr(err) {
-			err = a.s2s.DefaultCallback(c, activity)
-			if err != nil {
-				return err
-			}
+			a.debug("no callback handles activity", "type", activity.GetTypeName(), "policy", a.unhandled)
+			return a.handleUnhandledInbox(c, activity)
 		}
 	}
 	return nil
 }
 
+// LocalInboxes determines the inboxes of the local recipients of an activity
+// received in the shared inbox, deferring to the delegate if it implements
+// SharedInboxResolver.
+func (a *sideEffectActor) LocalInboxes(c context.Context, activity Activity) ([]*url.URL, error) {
+	var inboxes []*url.URL
+	var err error
+	if r, ok := a.s2s.(SharedInboxResolver); ok {
+		inboxes, err = r.LocalInboxes(c, activity)
+	} else {
+		inboxes, err = a.addressedLocalInboxes(c, activity)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return dedupeIRIs(inboxes, nil), nil
+}
+
+// HasLocalRecipients defers to the delegate if it implements
+// LocalRecipientsChecker, and otherwise returns true so that every activity is
+// processed.
+func (a *sideEffectActor) HasLocalRecipients(c context.Context, activity Activity) (bool, error) {
+	if r, ok := a.s2s.(LocalRecipientsChecker); ok {
+		return r.HasLocalRecipients(c, activity)
+	}
+	return true, nil
+}
+
+// addressedLocalInboxes returns the inboxes of the local actors the activity
+// is addressed to.
+func (a *sideEffectActor) addressedLocalInboxes(c context.Context, activity Activity) (inboxes []*url.URL, err error) {
+	iris, err := addressedIRIs(activity)
+	if err != nil {
+		return nil, err
+	}
+	for _, iri := range iris {
+		if IsPublicIRI(iri) {
+			continue
+		}
+		var t vocab.Type
+		t, err = a.getOwned(c, iri)
+		if err != nil {
+			return nil, err
+		} else if t == nil {
+			continue
+		}
+		if inbox, err := getInbox(t); err == nil {
+			inboxes = append(inboxes, inbox)
+		}
+	}
+	return
+}
+
+// getOwned returns the database entry for the id, or nil if it is not owned.
+func (a *sideEffectActor) getOwned(c context.Context, id *url.URL) (vocab.Type, error) {
+	if err := a.db.Lock(c, id); err != nil {
+		return nil, err
+	}
+	defer a.db.Unlock(c, id)
+	if owns, err := a.db.Owns(c, id); err != nil || !owns {
+		return nil, err
+	}
+	return a.db.Get(c, id)
+}
+
+// handleUnhandledInbox applies the UnhandledActivityPolicy to an activity
+// received in the inbox that no callback handled.
+func (a *sideEffectActor) handleUnhandledInbox(c context.Context, activity Activity) error {
+	switch a.unhandled {
+	case UnhandledActivityIgnore:
+		return nil
+	case UnhandledActivityReject:
+		return ErrUnhandledActivity
+	case UnhandledActivityStoreOnly:
+		return storeActivity(c, a.db, activity)
+	default:
+		return a.callCallback(func() error {
+			return a.s2s.DefaultCallback(c, activity)
+		})
+	}
+}
+
 // InboxForwarding implements the 3-part inbox forwarding algorithm specified in
 // the ActivityPub specification. Does not modify the Activity, but may send
 // outbound requests as a side effect.
@@ -233,8 +444,7 @@ func (a *sideEffectActor) InboxForwarding(c context.Context, inboxIRI *url.URL,
 	//
 	// Load the unfiltered IRIs.
 	var colIRIs []*url.URL
-	col := make(map[string]itemser)
-	oCol := make(map[string]orderedItemser)
+	col := make(map[string][]IdProperty)
 	for _, iri := range myIRIs {
 		err = a.db.Lock(c, iri)
 		if err != nil {
@@ -245,17 +455,9 @@ func (a *sideEffectActor) InboxForwarding(c context.Context, inboxIRI *url.URL,
 		if err != nil {
 			return err
 		}
-		if streams.IsOrExtendsActivityStreamsOrderedCollection(t) {
-			if im, ok := t.(orderedItemser); ok {
-				oCol[iri.String()] = im
-				colIRIs = append(colIRIs, iri)
-				defer a.db.Unlock(c, iri)
-			} else {
-				a.db.Unlock(c, iri)
-			}
-		} else if streams.IsOrExtendsActivityStreamsCollection(t) {
-			if im, ok := t.(itemser); ok {
-				col[iri.String()] = im
+		if streams.IsOrExtendsActivityStreamsOrderedCollection(t) || streams.IsOrExtendsActivityStreamsCollection(t) {
+			if items, _, ok := collectionItems(t); ok {
+				col[iri.String()] = items
 				colIRIs = append(colIRIs, iri)
 				defer a.db.Unlock(c, iri)
 			} else {
@@ -268,19 +470,20 @@ func (a *sideEffectActor) InboxForwarding(c context.Context, inboxIRI *url.URL,
 	// If we own none of the Collection IRIs in 'to', 'cc', or 'audience'
 	// then no need to do inbox forwarding. We have nothing to forward to.
 	if len(colIRIs) == 0 {
+		a.debug("not forwarding activity: addressed to no local collection", "id", id.Get())
 		return nil
 	}
 	// 3. The values of 'inReplyTo', 'object', 'target', or 'tag' are owned
 	//    by this server. This is only a boolean trigger: As soon as we get
 	//    a hit that we own something, then we should do inbox forwarding.
-	maxDepth := a.s2s.MaxInboxForwardingRecursionDepth(c)
-	ownsValue, err := a.hasInboxForwardingValues(c, inboxIRI, activity, maxDepth, 0)
+	triggered, err := a.triggersInboxForwarding(c, inboxIRI, activity)
 	if err != nil {
 		return err
 	}
 	// If we don't own any of the 'inReplyTo', 'object', 'target', or 'tag'
 	// values, then no need to do inbox forwarding.
-	if !ownsValue {
+	if !triggered {
+		a.debug("not forwarding activity: references no local value", "id", id.Get())
 		return nil
 	}
 	// Do the inbox forwarding since the above conditions hold true. Support
@@ -292,28 +495,15 @@ func (a *sideEffectActor) InboxForwarding(c context.Context, inboxIRI *url.URL,
 	}
 	recipients := make([]*url.URL, 0, len(toSend))
 	for _, iri := range toSend {
-		if c, ok := col[iri.String()]; ok {
-			if it := c.GetActivityStreamsItems(); it != nil {
-				for iter := it.Begin(); iter != it.End(); iter = iter.Next() {
-					id, err := ToId(iter)
-					if err != nil {
-						return err
-					}
-					recipients = append(recipients, id)
-				}
-			}
-		} else if oc, ok := oCol[iri.String()]; ok {
-			if oit := oc.GetActivityStreamsOrderedItems(); oit != nil {
-				for iter := oit.Begin(); iter != oit.End(); iter = iter.Next() {
-					id, err := ToId(iter)
-					if err != nil {
-						return err
-					}
-					recipients = append(recipients, id)
-				}
+		for _, item := range col[iri.String()] {
+			id, err := ToId(item)
+			if err != nil {
+				return err
 			}
+			recipients = append(recipients, id)
 		}
 	}
+	a.debug("forwarding activity", "id", id.Get(), "collections", len(toSend), "recipients", len(recipients))
 	return a.deliverToRecipients(c, inboxIRI, activity, recipients)
 }
 
@@ -323,6 +513,11 @@ func (a *sideEffectActor) InboxForwarding(c context.Context, inboxIRI *url.URL,
 // This implementation assumes all types are meant to be delivered except for
 // the ActivityStreams Block type.
 func (a *sideEffectActor) PostOutbox(c context.Context, activity Activity, outboxIRI *url.URL, rawJSON map[string]interface{}) (deliverable bool, err error) {
+	if a.consistentCreateAddressing && streams.IsOrExtendsActivityStreamsCreate(activity) {
+		if err = checkCreateAddressing(activity); err != nil {
+			return
+		}
+	}
 	// TODO: Determine this if c2s is nil
 	deliverable = true
 	if a.c2s != nil {
@@ -345,11 +540,16 @@ func (a *sideEffectActor) PostOutbox(c context.Context, activity Activity, outbo
 		if err != nil {
 			return
 		}
-		if err = res.Resolve(c, activity); err != nil && !streams.IsUnmatchedErr(err) {
+		err = a.callCallback(func() error {
+			return res.Resolve(c, activity)
+		})
+		if err != nil && !streams.IsUnmatchedErr(err) {
 			return
 		} else if streams.IsUnmatchedErr(err) {
 			deliverable = true
-			err = a.c2s.DefaultCallback(c, activity)
+			err = a.callCallback(func() error {
+				return a.c2s.DefaultCallback(c, activity)
+			})
 			if err != nil {
 				return
 			}
@@ -363,8 +563,12 @@ func (a *sideEffectActor) PostOutbox(c context.Context, activity Activity, outbo
 
 // AddNewIDs creates new 'id' entries on an activity and its objects if it is a
 // Create activity.
+//
+// Every id is obtained from the Database's NewID and must not already exist in
+// the database, otherwise an error is returned instead of overwriting the
+// existing entry.
 func (a *sideEffectActor) AddNewIDs(c context.Context, activity Activity) error {
-	id, err := a.db.NewID(c, activity)
+	id, err := a.newID(c, activity)
 	if err != nil {
 		return err
 	}
@@ -382,7 +586,7 @@ func (a *sideEffectActor) AddNewIDs(c context.Context, activity Activity) error
 				if t == nil {
 					return fmt.Errorf("cannot add new id for object in Create: object is not embedded as a value literal")
 				}
-				id, err = a.db.NewID(c, t)
+				id, err = a.newID(c, t)
 				if err != nil {
 					return err
 				}
@@ -395,16 +599,117 @@ func (a *sideEffectActor) AddNewIDs(c context.Context, activity Activity) error
 	return nil
 }
 
+// newID obtains a new id for the value from the Database, ensuring that it
+// does not collide with an existing entry.
+func (a *sideEffectActor) newID(c context.Context, t vocab.Type) (*url.URL, error) {
+	id, err := a.db.NewID(c, t)
+	if err != nil {
+		return nil, err
+	}
+	err = a.db.Lock(c, id)
+	if err != nil {
+		return nil, err
+	}
+	// WARNING: Unlock is not deferred
+	exists, err := a.db.Exists(c, id)
+	a.db.Unlock(c, id)
+	// Unlock by this point
+	if err != nil {
+		return nil, err
+	} else if exists {
+		return nil, fmt.Errorf("new id %q for %s collides with an existing entry", id, t.GetTypeName())
+	}
+	return id, nil
+}
+
 // deliver will complete the peer-to-peer sending of a federated message to
-// another server.
+// another server. The delivered copy has its hidden recipients stripped.
 //
 // Must be called if at least the federated protocol is supported.
 func (a *sideEffectActor) Deliver(c context.Context, outboxIRI *url.URL, activity Activity) error {
-	recipients, err := a.prepare(c, outboxIRI, activity)
+	if isLocalOnly(c) {
+		return nil
+	}
+	skip, filter, err := a.deliveryPolicy(c, activity)
+	if err != nil || skip {
+		return err
+	}
+	if err := validateAddressing(activity); err != nil {
+		return err
+	}
+	recipients, err := a.prepare(c, outboxIRI, activity, filter)
 	if err != nil {
 		return err
 	}
-	return a.deliverToRecipients(c, outboxIRI, activity, recipients)
+	a.debug("delivering activity", "type", activity.GetTypeName(), "id", idOf(activity), "inboxes", len(recipients))
+	delivered, err := stripHiddenRecipients(activity)
+	if err != nil {
+		return err
+	}
+	return a.deliverToRecipients(c, outboxIRI, delivered, recipients)
+}
+
+// ResolveDeliveryTargets determines the inboxes the activity would be delivered
+// to, without delivering it. The delivery policy applies as it does for
+// Deliver, so no inbox is returned for an activity it skips.
+func (a *sideEffectActor) ResolveDeliveryTargets(c context.Context, outboxIRI *url.URL, activity Activity) ([]*url.URL, error) {
+	skip, filter, err := a.deliveryPolicy(c, activity)
+	if err != nil || skip {
+		return nil, err
+	}
+	r, err := a.resolveDeliveryTargets(c, outboxIRI, activity)
+	if err != nil {
+		return nil, err
+	} else if filter != nil {
+		r = filter(r)
+	}
+	return r, nil
+}
+
+// DeliverToInbox sends the activity to only the inbox, without resolving its
+// recipients. The delivered copy has its hidden recipients stripped.
+//
+// The delivery policy applies as it does for Deliver, so nothing is sent if it
+// skips the activity or filters out the inbox.
+func (a *sideEffectActor) DeliverToInbox(c context.Context, outboxIRI, inboxIRI *url.URL, activity Activity) error {
+	skip, filter, err := a.deliveryPolicy(c, activity)
+	if err != nil || skip {
+		return err
+	} else if filter != nil && len(filter([]*url.URL{inboxIRI})) == 0 {
+		a.debug("skipping delivery to inbox by policy", "type", activity.GetTypeName(), "id", idOf(activity), "inbox", inboxIRI)
+		return nil
+	}
+	delivered, err := stripHiddenRecipients(activity)
+	if err != nil {
+		return err
+	}
+	m, err := streams.Serialize(delivered)
+	if err != nil {
+		return err
+	}
+	b, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	tp, err := a.common.NewTransport(c, outboxIRI, goFedUserAgent())
+	if err != nil {
+		return err
+	}
+	return tp.Deliver(c, b, inboxIRI)
+}
+
+// deliveryPolicy defers to the FederatingProtocol if it is a DeliveryPolicer,
+// and otherwise delivers every activity to all its recipients.
+func (a *sideEffectActor) deliveryPolicy(c context.Context, activity Activity) (skip bool, filter func([]*url.URL) []*url.URL, err error) {
+	p, ok := a.s2s.(DeliveryPolicer)
+	if !ok {
+		return false, nil, nil
+	}
+	skip, filter, err = p.DeliveryPolicy(c, activity)
+	if err == nil && skip {
+		a.debug("skipping delivery by policy", "type", activity.GetTypeName(), "id", idOf(activity))
+	}
+	return
 }
 
 // WrapInCreate wraps an object with a Create activity.
@@ -414,7 +719,7 @@ func (a *sideEffectActor) WrapInCreate(c context.Context, obj vocab.Type, outbox
 		return
 	}
 	// WARNING: No deferring the Unlock
-	actorIRI, err := a.db.ActorForOutbox(c, outboxIRI)
+	actorIRI, err := actorForOutbox(c, a.db, outboxIRI)
 	if err != nil {
 		a.db.Unlock(c, outboxIRI)
 		return
@@ -444,6 +749,8 @@ func (a *sideEffectActor) deliverToRecipients(c context.Context, boxIRI *url.URL
 
 // addToOutbox adds the activity to the outbox and creates the activity in the
 // internal database as its own entry.
+//
+// The Database's AppendToCollection is preferred if it is a CollectionAppender.
 func (a *sideEffectActor) addToOutbox(c context.Context, outboxIRI *url.URL, activity Activity) error {
 	// Set the activity in the database first.
 	id := activity.GetJSONLDId()
@@ -467,6 +774,9 @@ func (a *sideEffectActor) addToOutbox(c context.Context, outboxIRI *url.URL, act
 		return err
 	}
 	defer a.db.Unlock(c, outboxIRI)
+	if ca, ok := a.db.(CollectionAppender); ok {
+		return ca.AppendToCollection(c, outboxIRI, id.Get())
+	}
 	outbox, err := a.db.GetOutbox(c, outboxIRI)
 	if err != nil {
 		return err
@@ -488,6 +798,8 @@ func (a *sideEffectActor) addToOutbox(c context.Context, outboxIRI *url.URL, act
 //
 // It does not add the activity to this database's know federated data.
 //
+// The Database's AppendToCollection is preferred if it is a CollectionAppender.
+//
 // Returns true when the activity is novel.
 func (a *sideEffectActor) addToInboxIfNew(c context.Context, inboxIRI *url.URL, activity Activity) (isNew bool, err error) {
 	// Acquire a lock to read the inbox. Defer release.
@@ -507,6 +819,10 @@ func (a *sideEffectActor) addToInboxIfNew(c context.Context, inboxIRI *url.URL,
 	}
 	// It is a new id, acquire the inbox.
 	isNew = true
+	if ca, ok := a.db.(CollectionAppender); ok {
+		err = ca.AppendToCollection(c, inboxIRI, id.Get())
+		return
+	}
 	inbox, err := a.db.GetInbox(c, inboxIRI)
 	if err != nil {
 		return
@@ -523,12 +839,40 @@ func (a *sideEffectActor) addToInboxIfNew(c context.Context, inboxIRI *url.URL,
 	return
 }
 
+// triggersInboxForwarding determines whether an activity received in the
+// inbox genuinely targets the local actor owning the inbox, so that inbox
+// forwarding should occur.
+//
+// The activity must not have been originated by the local actor, and one of
+// the values applicable to inbox forwarding must be owned by the local actor.
+// This prevents an activity from inducing forwarding to collections by merely
+// referring to values owned by other actors on this server.
+func (a *sideEffectActor) triggersInboxForwarding(c context.Context, inboxIRI *url.URL, activity Activity) (bool, error) {
+	err := a.db.Lock(c, inboxIRI)
+	if err != nil {
+		return false, err
+	}
+	// WARNING: Unlock is not deferred
+	actorIRI, err := actorForInbox(c, a.db, inboxIRI)
+	a.db.Unlock(c, inboxIRI)
+	// Unlock by this point
+	if err != nil {
+		return false, err
+	}
+	if hasActor(activity, actorIRI) {
+		return false, nil
+	}
+	maxDepth := a.s2s.MaxInboxForwardingRecursionDepth(c)
+	return a.hasInboxForwardingValues(c, inboxIRI, actorIRI, activity, maxDepth, 0)
+}
+
 // Given an ActivityStreams value, recursively examines ownership of the id or
-// href and the ones on properties applicable to inbox forwarding.
+// href and the ones on properties applicable to inbox forwarding. Only values
+// owned by the local actor count.
 //
 // Recursion may be limited by providing a 'maxDepth' greater than zero. A
 // value of zero or a negative number will result in infinite recursion.
-func (a *sideEffectActor) hasInboxForwardingValues(c context.Context, inboxIRI *url.URL, val vocab.Type, maxDepth, currDepth int) (bool, error) {
+func (a *sideEffectActor) hasInboxForwardingValues(c context.Context, inboxIRI, actorIRI *url.URL, val vocab.Type, maxDepth, currDepth int) (bool, error) {
 	// Stop recurring if we are exceeding the maximum depth and the maximum
 	// is a positive number.
 	if maxDepth > 0 && currDepth >= maxDepth {
@@ -539,20 +883,11 @@ func (a *sideEffectActor) hasInboxForwardingValues(c context.Context, inboxIRI *
 	types, iris := getInboxForwardingValues(val)
 	// For IRIs, simply check if we own them.
 	for _, iri := range iris {
-		err := a.db.Lock(c, iri)
-		if err != nil {
-			return false, err
-		}
-		// WARNING: Unlock is not deferred
-		if owns, err := a.db.Owns(c, iri); err != nil {
-			a.db.Unlock(c, iri)
+		if owns, err := a.isOwnedByActor(c, iri, actorIRI); err != nil {
 			return false, err
 		} else if owns {
-			a.db.Unlock(c, iri)
 			return true, nil
 		}
-		a.db.Unlock(c, iri)
-		// Unlock by this point and in every branch above
 	}
 	// For embedded literals, check the id.
 	for _, val := range types {
@@ -560,20 +895,11 @@ func (a *sideEffectActor) hasInboxForwardingValues(c context.Context, inboxIRI *
 		if err != nil {
 			return false, err
 		}
-		err = a.db.Lock(c, id)
-		if err != nil {
-			return false, err
-		}
-		// WARNING: Unlock is not deferred
-		if owns, err := a.db.Owns(c, id); err != nil {
-			a.db.Unlock(c, id)
+		if owns, err := a.isOwnedByActor(c, id, actorIRI); err != nil {
 			return false, err
 		} else if owns {
-			a.db.Unlock(c, id)
 			return true, nil
 		}
-		a.db.Unlock(c, id)
-		// Unlock by this point and in every branch above
 	}
 	// Recur Preparation: Try fetching the IRIs so we can recur into them.
 	for _, iri := range iris {
@@ -589,7 +915,7 @@ func (a *sideEffectActor) hasInboxForwardingValues(c context.Context, inboxIRI *
 			continue
 		}
 		var m map[string]interface{}
-		if err = json.Unmarshal(b, &m); err != nil {
+		if err = unmarshalJSON(b, &m); err != nil {
 			return false, err
 		}
 		t, err := streams.ToType(c, m)
@@ -602,7 +928,7 @@ func (a *sideEffectActor) hasInboxForwardingValues(c context.Context, inboxIRI *
 	}
 	// Recur.
 	for _, nextVal := range types {
-		if has, err := a.hasInboxForwardingValues(c, inboxIRI, nextVal, maxDepth, currDepth+1); err != nil {
+		if has, err := a.hasInboxForwardingValues(c, inboxIRI, actorIRI, nextVal, maxDepth, currDepth+1); err != nil {
 			return false, err
 		} else if has {
 			return true, nil
@@ -611,13 +937,64 @@ func (a *sideEffectActor) hasInboxForwardingValues(c context.Context, inboxIRI *
 	return false, nil
 }
 
+// isOwnedByActor determines whether the value with the id is owned by this
+// server and belongs to the actor: either it is the actor, or the actor is its
+// 'attributedTo' or 'actor'.
+//
+// The value is always obtained from the database, as an embedded value could
+// claim to belong to any actor.
+func (a *sideEffectActor) isOwnedByActor(c context.Context, id, actorIRI *url.URL) (bool, error) {
+	if id.String() == actorIRI.String() {
+		return true, nil
+	}
+	err := a.db.Lock(c, id)
+	if err != nil {
+		return false, err
+	}
+	// WARNING: Unlock is not deferred
+	if owns, err := a.db.Owns(c, id); err != nil {
+		a.db.Unlock(c, id)
+		return false, err
+	} else if !owns {
+		a.db.Unlock(c, id)
+		return false, nil
+	}
+	t, err := a.db.Get(c, id)
+	a.db.Unlock(c, id)
+	// Unlock by this point and in every branch above
+	if err != nil {
+		return false, err
+	}
+	return isAttributedToActor(t, actorIRI) || hasActor(t, actorIRI), nil
+}
+
 // prepare takes a deliverableObject and returns a list of the proper recipient
-// target URIs. Additionally, the deliverableObject will have any hidden
-// hidden recipients ("bto" and "bcc") stripped from it.
+// target URIs. The hidden recipients ("bto" and "bcc") are stripped from a copy
+// of it by stripHiddenRecipients before delivery.
+//
+// A non-nil filter narrows the recipients before MaxDeliveryRecipients is
+// applied.
 //
 // Only call if both the social and federated protocol are supported.
-func (a *sideEffectActor) prepare(c context.Context, outboxIRI *url.URL, activity Activity) (r []*url.URL, err error) {
+func (a *sideEffectActor) prepare(c context.Context, outboxIRI *url.URL, activity Activity, filter func([]*url.URL) []*url.URL) (r []*url.URL, err error) {
+	r, err = a.resolveDeliveryTargets(c, outboxIRI, activity)
+	if err != nil {
+		return nil, err
+	}
+	if filter != nil {
+		r = filter(r)
+	}
+	if max := a.s2s.MaxDeliveryRecipients(c); max > 0 && len(r) > max {
+		return nil, fmt.Errorf("activity would be delivered to %d inboxes, exceeding the maximum of %d", len(r), max)
+	}
+	return r, nil
+}
+
+// resolveDeliveryTargets resolves the recipients of the activity to the
+// deduplicated inboxes it is delivered to, excluding the sender's inbox.
+func (a *sideEffectActor) resolveDeliveryTargets(c context.Context, outboxIRI *url.URL, activity Activity) (r []*url.URL, err error) {
 	// Get inboxes of recipients
+	var receivers, hiddenReceivers []*url.URL
 	if to := activity.GetActivityStreamsTo(); to != nil {
 		for iter := to.Begin(); iter != to.End(); iter = iter.Next() {
 			var val *url.URL
@@ -625,7 +1002,7 @@ func (a *sideEffectActor) prepare(c context.Context, outboxIRI *url.URL, activit
 			if err != nil {
 				return
 			}
-			r = append(r, val)
+			receivers = append(receivers, val)
 		}
 	}
 	if bto := activity.GetActivityStreamsBto(); bto != nil {
@@ -635,7 +1012,7 @@ func (a *sideEffectActor) prepare(c context.Context, outboxIRI *url.URL, activit
 			if err != nil {
 				return
 			}
-			r = append(r, val)
+			hiddenReceivers = append(hiddenReceivers, val)
 		}
 	}
 	if cc := activity.GetActivityStreamsCc(); cc != nil {
@@ -645,7 +1022,7 @@ func (a *sideEffectActor) prepare(c context.Context, outboxIRI *url.URL, activit
 			if err != nil {
 				return
 			}
-			r = append(r, val)
+			receivers = append(receivers, val)
 		}
 	}
 	if bcc := activity.GetActivityStreamsBcc(); bcc != nil {
@@ -655,7 +1032,7 @@ func (a *sideEffectActor) prepare(c context.Context, outboxIRI *url.URL, activit
 			if err != nil {
 				return
 			}
-			r = append(r, val)
+			hiddenReceivers = append(hiddenReceivers, val)
 		}
 	}
 	if audience := activity.GetActivityStreamsAudience(); audience != nil {
@@ -665,7 +1042,7 @@ func (a *sideEffectActor) prepare(c context.Context, outboxIRI *url.URL, activit
 			if err != nil {
 				return
 			}
-			r = append(r, val)
+			receivers = append(receivers, val)
 		}
 	}
 	// 1. When an object is being delivered to the originating actor's
@@ -676,64 +1053,32 @@ func (a *sideEffectActor) prepare(c context.Context, outboxIRI *url.URL, activit
 	// 2. If an object is addressed to the Public special collection, a
 	//    server MAY deliver that object to all known sharedInbox endpoints
 	//    on the network.
-	r = filterURLs(r, IsPublic)
-
-	// first check if the implemented database logic can return any inboxes
-	// from our list of actor IRIs.
-	foundInboxesFromDB := []*url.URL{}
-	foundActorsFromDB := []*url.URL{}
-	for _, actorIRI := range r {
-		// BEGIN LOCK
-		err = a.db.Lock(c, actorIRI)
-		if err != nil {
-			return
-		}
-
-		inbox, err := a.db.InboxForActor(c, actorIRI)
-		if err != nil {
-			// bail on error
-			a.db.Unlock(c, actorIRI)
-			return nil, err
-		}
-		if inbox != nil {
-			// we have a hit
-			foundInboxesFromDB = append(foundInboxesFromDB, inbox)
-			foundActorsFromDB = append(foundActorsFromDB, actorIRI)
-		}
-
-		// END LOCK
-		a.db.Unlock(c, actorIRI)
-		if err != nil {
-			return nil, err
-		}
-	}
-
-	// for every actor we found an inbox for in the db, we should
-	// remove it from the list of actors we still need to dereference
-	for _, actorIRI := range foundActorsFromDB {
-		r = removeOne(r, actorIRI)
+	receivers = filterURLs(receivers, IsPublic)
+	hiddenReceivers = filterURLs(hiddenReceivers, IsPublic)
+	// A hidden receiver that is also addressed openly is not hidden.
+	for _, actorIRI := range receivers {
+		hiddenReceivers = removeOne(hiddenReceivers, actorIRI)
 	}
 
-	// look for any actors' inboxes that weren't already discovered above;
-	// find these by making dereference calls to remote instances
+	// Determine the inboxes of the receivers. Any actors whose inboxes are
+	// not known by the database are dereferenced from remote instances.
 	t, err := a.common.NewTransport(c, outboxIRI, goFedUserAgent())
 	if err != nil {
 		return nil, err
 	}
-	foundActorsFromRemote, err := a.resolveActors(c, t, r, 0, a.s2s.MaxDeliveryRecursionDepth(c))
+	maxDepth := a.s2s.MaxDeliveryRecursionDepth(c)
+	recipients, err := a.resolveRecipients(c, t, receivers, maxDepth)
 	if err != nil {
 		return nil, err
 	}
-	foundInboxesFromRemote, err := getInboxes(foundActorsFromRemote)
+	hiddenRecipients, err := a.resolveRecipients(c, t, hiddenReceivers, maxDepth)
+	if err != nil {
+		return nil, err
+	}
+	targets, err := a.resolveInboxIRIs(c, t, recipients, hiddenRecipients)
 	if err != nil {
 		return nil, err
 	}
-
-	// combine this list of dereferenced inbox IRIs with the inboxes we already
-	// found in the db, to make a complete list of target IRIs
-	targets := []*url.URL{}
-	targets = append(targets, foundInboxesFromDB...)
-	targets = append(targets, foundInboxesFromRemote...)
 
 	// Get inboxes of sender.
 	err = a.db.Lock(c, outboxIRI)
@@ -741,7 +1086,7 @@ func (a *sideEffectActor) prepare(c context.Context, outboxIRI *url.URL, activit
 		return
 	}
 	// WARNING: No deferring the Unlock
-	actorIRI, err := a.db.ActorForOutbox(c, outboxIRI)
+	actorIRI, err := actorForOutbox(c, a.db, outboxIRI)
 	if err != nil {
 		a.db.Unlock(c, outboxIRI)
 		return
@@ -765,9 +1110,78 @@ func (a *sideEffectActor) prepare(c context.Context, outboxIRI *url.URL, activit
 	if err != nil {
 		return nil, err
 	}
-	r = dedupeIRIs(targets, []*url.URL{ignore})
-	stripHiddenRecipients(activity)
-	return r, nil
+	return dedupeIRIs(targets, []*url.URL{ignore}), nil
+}
+
+// resolveInboxIRIs determines the inboxes to deliver to from the resolved
+// recipients. The FederatingProtocol may reduce them if it is an
+// InboxResolver, otherwise the personal inbox of each recipient is used.
+func (a *sideEffectActor) resolveInboxIRIs(c context.Context, t Transport, recipients, hiddenRecipients []Recipient) (inboxes []*url.URL, err error) {
+	if r, ok := a.s2s.(InboxResolver); ok {
+		return r.ResolveInboxIRIs(c, t, recipients, hiddenRecipients)
+	}
+	for _, r := range append(recipients, hiddenRecipients...) {
+		inboxes = append(inboxes, r.InboxIRI)
+	}
+	return
+}
+
+// resolveRecipients determines the inbox of each actor. It first checks if
+// the database knows the inbox of the actor, otherwise it dereferences the
+// actor from its remote instance, expanding any Collections or
+// OrderedCollections into their actors.
+func (a *sideEffectActor) resolveRecipients(c context.Context, t Transport, r []*url.URL, maxDepth int) (recipients []Recipient, err error) {
+	// first check if the implemented database logic can return any inboxes
+	// from our list of actor IRIs.
+	var remote []*url.URL
+	for _, actorIRI := range r {
+		// BEGIN LOCK
+		err = a.db.Lock(c, actorIRI)
+		if err != nil {
+			return
+		}
+		var inbox *url.URL
+		inbox, err = a.db.InboxForActor(c, actorIRI)
+		// END LOCK
+		a.db.Unlock(c, actorIRI)
+		if err != nil {
+			// bail on error
+			return
+		}
+		if inbox != nil {
+			// we have a hit
+			recipients = append(recipients, Recipient{
+				ActorIRI: actorIRI,
+				InboxIRI: inbox,
+			})
+		} else {
+			remote = append(remote, actorIRI)
+		}
+	}
+
+	// look for any actors' inboxes that weren't already discovered above;
+	// find these by making dereference calls to remote instances
+	var actors []vocab.Type
+	actors, err = a.resolveActors(c, t, remote, 0, maxDepth)
+	if err != nil {
+		return
+	}
+	for _, actor := range actors {
+		var id, inbox *url.URL
+		id, err = GetId(actor)
+		if err != nil {
+			return
+		}
+		inbox, err = getInbox(actor)
+		if err != nil {
+			return
+		}
+		recipients = append(recipients, Recipient{
+			ActorIRI: id,
+			InboxIRI: inbox,
+		})
+	}
+	return
 }
 
 // resolveActors takes a list of Actor id URIs and returns them as concrete
@@ -819,7 +1233,7 @@ func (a *sideEffectActor) dereferenceForResolvingInboxes(c context.Context, t Tr
 		return
 	}
 	var m map[string]interface{}
-	if err = json.Unmarshal(resp, &m); err != nil {
+	if err = unmarshalJSON(resp, &m); err != nil {
 		return
 	}
 	actor, err = streams.ToType(c, m)
@@ -827,30 +1241,18 @@ func (a *sideEffectActor) dereferenceForResolvingInboxes(c context.Context, t Tr
 		return
 	}
 	// Attempt to see if the 'actor' is really some sort of type that has
-	// an 'items' or 'orderedItems' property.
-	if v, ok := actor.(itemser); ok {
-		if i := v.GetActivityStreamsItems(); i != nil {
-			for iter := i.Begin(); iter != i.End(); iter = iter.Next() {
-				var id *url.URL
-				id, err = ToId(iter)
-				if err != nil {
-					return
-				}
-				moreActorIRIs = append(moreActorIRIs, id)
-			}
-		}
-		actor = nil
-	} else if v, ok := actor.(orderedItemser); ok {
-		if i := v.GetActivityStreamsOrderedItems(); i != nil {
-			for iter := i.Begin(); iter != i.End(); iter = iter.Next() {
-				var id *url.URL
-				id, err = ToId(iter)
-				if err != nil {
-					return
-				}
-				moreActorIRIs = append(moreActorIRIs, id)
+	// an 'items' or 'orderedItems' property. Pages of the collection are
+	// resolved like its members.
+	if items, pages, ok := collectionItems(actor); ok {
+		for _, item := range items {
+			var id *url.URL
+			id, err = ToId(item)
+			if err != nil {
+				return
 			}
+			moreActorIRIs = append(moreActorIRIs, id)
 		}
+		moreActorIRIs = append(moreActorIRIs, pages...)
 		actor = nil
 	}
 	return
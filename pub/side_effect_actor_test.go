@@ -2,7 +2,13 @@ package pub
 
 import (
 	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
 	"fmt"
+	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"testing"
@@ -48,6 +54,120 @@ func TestPassThroughMethods(t *testing.T) {
 		assertEqual(t, b, true)
 		assertEqual(t, err, testErr)
 	})
+	t.Run("ActorForRequest", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		c, _, _, _, _, a := setupFn(ctl)
+		resolver := NewMockActorResolver(ctl)
+		a.(*sideEffectActor).common = actorResolverCommonBehavior{c, resolver}
+		req := toAPRequest(toGetInboxRequest())
+		resolver.EXPECT().ActorForRequest(ctx, req).Return(mustParse(testPersonIRI), nil)
+		// Run
+		actorIRI, err := a.(*sideEffectActor).ActorForRequest(ctx, req)
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, actorIRI.String(), testPersonIRI)
+	})
+	t.Run("ActorForRequestNotImplemented", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, _, _, _, _, a := setupFn(ctl)
+		req := toAPRequest(toGetInboxRequest())
+		// Run
+		actorIRI, err := a.(*sideEffectActor).ActorForRequest(ctx, req)
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, actorIRI, (*url.URL)(nil))
+	})
+	t.Run("PostInboxRawBodyHook", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, fp, _, _, _, a := setupFn(ctl)
+		hooker := NewMockRawBodyHooker(ctl)
+		a.(*sideEffectActor).s2s = rawBodyHookerFederatingProtocol{fp, hooker}
+		req := toAPRequest(toPostInboxRequest(testCreate))
+		raw := []byte("{}")
+		hooker.EXPECT().PostInboxRawBodyHook(ctx, req, raw, testCreate).Return(ctx, testErr)
+		// Run
+		_, err := a.(*sideEffectActor).PostInboxRawBodyHook(ctx, req, raw, testCreate)
+		// Verify
+		assertEqual(t, err, testErr)
+	})
+	t.Run("PostInboxRawBodyHookNotImplemented", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, _, _, _, _, a := setupFn(ctl)
+		req := toAPRequest(toPostInboxRequest(testCreate))
+		// Run
+		c, err := a.(*sideEffectActor).PostInboxRawBodyHook(ctx, req, []byte("{}"), testCreate)
+		// Verify
+		assertEqual(t, c, ctx)
+		assertEqual(t, err, nil)
+	})
+	t.Run("ProcessTags", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, _, sp, _, _, a := setupFn(ctl)
+		processor := NewMockTagProcessor(ctl)
+		a.(*sideEffectActor).c2s = tagProcessorSocialProtocol{sp, processor}
+		processor.EXPECT().ProcessTags(ctx, testMyNote).Return(testErr)
+		// Run
+		err := a.(*sideEffectActor).ProcessTags(ctx, testMyNote)
+		// Verify
+		assertEqual(t, err, testErr)
+	})
+	t.Run("ProcessTagsNotImplemented", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, _, _, _, _, a := setupFn(ctl)
+		// Run
+		err := a.(*sideEffectActor).ProcessTags(ctx, testMyNote)
+		// Verify
+		assertEqual(t, err, nil)
+	})
+	t.Run("DecorateOutbound", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, _, sp, _, _, a := setupFn(ctl)
+		decorator := NewMockOutboundDecorator(ctl)
+		a.(*sideEffectActor).c2s = outboundDecoratorSocialProtocol{sp, decorator}
+		decorator.EXPECT().DecorateOutbound(ctx, testMyCreate).Return(testErr)
+		// Run
+		err := a.(*sideEffectActor).DecorateOutbound(ctx, testMyCreate)
+		// Verify
+		assertEqual(t, err, testErr)
+	})
+	t.Run("DecorateOutboundFederatingProtocol", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, fp, _, _, _, a := setupFn(ctl)
+		decorator := NewMockOutboundDecorator(ctl)
+		a.(*sideEffectActor).c2s = nil
+		a.(*sideEffectActor).s2s = outboundDecoratorFederatingProtocol{fp, decorator}
+		decorator.EXPECT().DecorateOutbound(ctx, testMyCreate).Return(testErr)
+		// Run
+		err := a.(*sideEffectActor).DecorateOutbound(ctx, testMyCreate)
+		// Verify
+		assertEqual(t, err, testErr)
+	})
+	t.Run("DecorateOutboundNotImplemented", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, _, _, _, _, a := setupFn(ctl)
+		// Run
+		err := a.(*sideEffectActor).DecorateOutbound(ctx, testMyCreate)
+		// Verify
+		assertEqual(t, err, nil)
+	})
 	t.Run("AuthenticateGetInbox", func(t *testing.T) {
 		// Setup
 		ctl := gomock.NewController(t)
@@ -187,6 +307,277 @@ func TestAuthorizePostInbox(t *testing.T) {
 	})
 }
 
+// TestAuthorizePostInboxRequireAuthorDomainMatch tests rejecting activities
+// whose authors are not on the domain of the signing actor.
+func TestAuthorizePostInboxRequireAuthorDomainMatch(t *testing.T) {
+	ctx := context.Background()
+	k, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherK, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const relayActor = "https://relay.example/actor"
+	// newActivityWithCreator returns a Create by the author, signed with
+	// the key of the creator if the key is not nil.
+	newActivityWithCreator := func(author string, key *rsa.PrivateKey, creator string) Activity {
+		m := map[string]interface{}{
+			"@context": "https://www.w3.org/ns/activitystreams",
+			"id":       author + "/activity/1",
+			"type":     "Create",
+			"actor":    author,
+			"object": map[string]interface{}{
+				"id":           author + "/note/1",
+				"type":         "Note",
+				"attributedTo": author,
+			},
+		}
+		if key != nil {
+			v, err := streams.ToType(ctx, m)
+			if err != nil {
+				t.Fatal(err)
+			}
+			signed := mustSerialize(v)
+			sig := map[string]interface{}{
+				"type":    rsaSignature2017,
+				"creator": creator,
+				"created": "2019-09-14T11:39:40Z",
+			}
+			signed[signatureProperty] = sig
+			b, err := ldSignatureData(signed, sig, jsonCanonicalizer{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			h := sha256.Sum256(b)
+			s, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, h[:])
+			if err != nil {
+				t.Fatal(err)
+			}
+			sig[signatureValueProperty] = base64.StdEncoding.EncodeToString(s)
+			m = signed
+		}
+		v, err := streams.ToType(ctx, m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return v.(Activity)
+	}
+	newActivity := func(author string, key *rsa.PrivateKey) Activity {
+		return newActivityWithCreator(author, key, testKeyResolverKeyId)
+	}
+	setupFn := func(ctl *gomock.Controller, canon LDCanonicalizer) (c *MockCommonBehavior, fp *MockFederatingProtocol, tp *MockTransport, a DelegateActor) {
+		c = NewMockCommonBehavior(ctl)
+		fp = NewMockFederatingProtocol(ctl)
+		tp = NewMockTransport(ctl)
+		cl := NewMockClock(ctl)
+		cl.EXPECT().Now().Return(now()).AnyTimes()
+		a = &sideEffectActor{
+			common:                   c,
+			s2s:                      fp,
+			requireAuthorDomainMatch: true,
+			authorKeys:               NewKeyResolver(cl, time.Minute),
+			authorCanon:              canon,
+		}
+		return
+	}
+	signedBy := func(actor string) context.Context {
+		return withRequestURL(WithAuthenticatedActor(ctx, mustParse(actor)), mustParse(testMyInboxIRI))
+	}
+	t.Run("AuthorizesSameDomain", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, fp, _, a := setupFn(ctl, jsonCanonicalizer{})
+		activity := newActivity(testKeyResolverActor, nil)
+		c := signedBy("https://example.com/users/other")
+		fp.EXPECT().Blocked(c, []*url.URL{mustParse(testKeyResolverActor)}).Return(false, nil)
+		resp := httptest.NewRecorder()
+		b, err := a.AuthorizePostInbox(c, resp, activity)
+		assertEqual(t, b, true)
+		assertEqual(t, err, nil)
+	})
+	t.Run("RejectsOtherDomainWithoutSignature", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, fp, _, a := setupFn(ctl, jsonCanonicalizer{})
+		activity := newActivity(testKeyResolverActor, nil)
+		c := signedBy(relayActor)
+		fp.EXPECT().Blocked(c, []*url.URL{mustParse(testKeyResolverActor)}).Return(false, nil)
+		resp := httptest.NewRecorder()
+		b, err := a.AuthorizePostInbox(c, resp, activity)
+		assertEqual(t, b, false)
+		assertEqual(t, err, nil)
+		assertEqual(t, resp.Code, http.StatusForbidden)
+	})
+	t.Run("RejectsOtherDomainOfObjectAuthor", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, fp, _, a := setupFn(ctl, nil)
+		activity := newActivity(relayActor, nil)
+		note := activity.(vocab.ActivityStreamsCreate).GetActivityStreamsObject().At(0).GetType().(vocab.ActivityStreamsNote)
+		attrTo := streams.NewActivityStreamsAttributedToProperty()
+		attrTo.AppendIRI(mustParse(testKeyResolverActor))
+		note.SetActivityStreamsAttributedTo(attrTo)
+		c := signedBy(relayActor)
+		fp.EXPECT().Blocked(c, []*url.URL{mustParse(relayActor)}).Return(false, nil)
+		resp := httptest.NewRecorder()
+		b, err := a.AuthorizePostInbox(c, resp, activity)
+		assertEqual(t, b, false)
+		assertEqual(t, err, nil)
+	})
+	t.Run("AuthorizesOtherDomainWithAuthorSignature", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		cb, fp, tp, a := setupFn(ctl, jsonCanonicalizer{})
+		activity := newActivity(testKeyResolverActor, k)
+		c := signedBy(relayActor)
+		fp.EXPECT().Blocked(c, []*url.URL{mustParse(testKeyResolverActor)}).Return(false, nil)
+		cb.EXPECT().NewTransport(c, mustParse(testMyInboxIRI), goFedUserAgent()).Return(tp, nil)
+		tp.EXPECT().Dereference(c, mustParse(testKeyResolverActor)).Return(newTestActorWithKey(t, k), nil)
+		resp := httptest.NewRecorder()
+		b, err := a.AuthorizePostInbox(c, resp, activity)
+		assertEqual(t, b, true)
+		assertEqual(t, err, nil)
+	})
+	t.Run("RejectsOtherDomainWithInvalidSignature", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		cb, fp, tp, a := setupFn(ctl, jsonCanonicalizer{})
+		activity := newActivity(testKeyResolverActor, otherK)
+		c := signedBy(relayActor)
+		fp.EXPECT().Blocked(c, []*url.URL{mustParse(testKeyResolverActor)}).Return(false, nil)
+		cb.EXPECT().NewTransport(c, mustParse(testMyInboxIRI), goFedUserAgent()).Return(tp, nil)
+		tp.EXPECT().Dereference(c, mustParse(testKeyResolverActor)).Return(newTestActorWithKey(t, k), nil)
+		resp := httptest.NewRecorder()
+		b, err := a.AuthorizePostInbox(c, resp, activity)
+		assertEqual(t, b, false)
+		assertEqual(t, err, nil)
+		assertEqual(t, resp.Code, http.StatusForbidden)
+	})
+	t.Run("RejectsSignatureOfAnotherOwner", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		cb, fp, tp, a := setupFn(ctl, jsonCanonicalizer{})
+		author := "https://elsewhere.example/users/sam"
+		activity := newActivity(author, k)
+		c := signedBy(relayActor)
+		fp.EXPECT().Blocked(c, []*url.URL{mustParse(author)}).Return(false, nil)
+		cb.EXPECT().NewTransport(c, mustParse(testMyInboxIRI), goFedUserAgent()).Return(tp, nil)
+		tp.EXPECT().Dereference(c, mustParse(testKeyResolverActor)).Return(newTestActorWithKey(t, k), nil)
+		resp := httptest.NewRecorder()
+		b, err := a.AuthorizePostInbox(c, resp, activity)
+		assertEqual(t, b, false)
+		assertEqual(t, err, nil)
+	})
+	t.Run("RejectsSignatureWithCrossOriginKeyDocument", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		cb, fp, tp, a := setupFn(ctl, jsonCanonicalizer{})
+		keyId := relayActor + "#main-key"
+		activity := newActivityWithCreator(testKeyResolverActor, k, keyId)
+		c := signedBy(relayActor)
+		fp.EXPECT().Blocked(c, []*url.URL{mustParse(testKeyResolverActor)}).Return(false, nil)
+		cb.EXPECT().NewTransport(c, mustParse(testMyInboxIRI), goFedUserAgent()).Return(tp, nil)
+		tp.EXPECT().Dereference(c, mustParse(relayActor)).Return(newTestActorWithKeyId(t, k, keyId, testKeyResolverActor), nil)
+		resp := httptest.NewRecorder()
+		b, err := a.AuthorizePostInbox(c, resp, activity)
+		assertEqual(t, b, false)
+		assertEqual(t, err, nil)
+		assertEqual(t, resp.Code, http.StatusForbidden)
+	})
+	t.Run("ErrorIfNoAuthenticatedActor", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, fp, _, a := setupFn(ctl, jsonCanonicalizer{})
+		activity := newActivity(testKeyResolverActor, nil)
+		fp.EXPECT().Blocked(ctx, []*url.URL{mustParse(testKeyResolverActor)}).Return(false, nil)
+		resp := httptest.NewRecorder()
+		b, err := a.AuthorizePostInbox(ctx, resp, activity)
+		assertEqual(t, b, false)
+		assertNotEqual(t, err, nil)
+	})
+}
+
+// actorResolverCommonBehavior is a CommonBehavior that is also an
+// ActorResolver.
+type actorResolverCommonBehavior struct {
+	*MockCommonBehavior
+	*MockActorResolver
+}
+
+// rawBodyHookerFederatingProtocol is a FederatingProtocol that is also a
+// RawBodyHooker.
+type rawBodyHookerFederatingProtocol struct {
+	*MockFederatingProtocol
+	*MockRawBodyHooker
+}
+
+// deliveryPolicerFederatingProtocol is a FederatingProtocol that is also a
+// DeliveryPolicer.
+type deliveryPolicerFederatingProtocol struct {
+	*MockFederatingProtocol
+	*MockDeliveryPolicer
+}
+
+// inboxResolverFederatingProtocol is a FederatingProtocol that is also an
+// InboxResolver.
+type inboxResolverFederatingProtocol struct {
+	*MockFederatingProtocol
+	*MockInboxResolver
+}
+
+// sharedInboxFederatingProtocol is a FederatingProtocol that is also a
+// SharedInboxResolver.
+type sharedInboxFederatingProtocol struct {
+	*MockFederatingProtocol
+	*MockSharedInboxResolver
+}
+
+// localRecipientsFederatingProtocol is a FederatingProtocol that is also a
+// LocalRecipientsChecker.
+type localRecipientsFederatingProtocol struct {
+	*MockFederatingProtocol
+	*MockLocalRecipientsChecker
+}
+
+// tagProcessorSocialProtocol is a SocialProtocol that is also a TagProcessor.
+type tagProcessorSocialProtocol struct {
+	*MockSocialProtocol
+	*MockTagProcessor
+}
+
+// outboundDecoratorSocialProtocol is a SocialProtocol that is also an
+// OutboundDecorator.
+type outboundDecoratorSocialProtocol struct {
+	*MockSocialProtocol
+	*MockOutboundDecorator
+}
+
+// outboundDecoratorFederatingProtocol is a FederatingProtocol that is also an
+// OutboundDecorator.
+type outboundDecoratorFederatingProtocol struct {
+	*MockFederatingProtocol
+	*MockOutboundDecorator
+}
+
+// failingSeenStore is a SeenStore that always fails.
+type failingSeenStore struct{}
+
+func (failingSeenStore) MarkSeen(c context.Context, inboxIRI, id *url.URL, ttl time.Duration) (bool, error) {
+	return false, testErr
+}
+
+func (failingSeenStore) Unmark(c context.Context, inboxIRI, id *url.URL) error {
+	return testErr
+}
+
+// collectionAppenderDatabase is a Database that is also a CollectionAppender.
+type collectionAppenderDatabase struct {
+	*MockDatabase
+	*MockCollectionAppender
+}
+
 // TestPostInbox ensures that the main application side effects of receiving a
 // federated message occur.
 func TestPostInbox(t *testing.T) {
@@ -228,6 +619,27 @@ func TestPostInbox(t *testing.T) {
 		// Verify
 		assertEqual(t, err, nil)
 	})
+	t.Run("AppendsToInboxWithCollectionAppender", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, fp, _, db, _, a := setupFn(ctl)
+		ca := NewMockCollectionAppender(ctl)
+		a.(*sideEffectActor).db = collectionAppenderDatabase{db, ca}
+		inboxIRI := mustParse(testMyInboxIRI)
+		gomock.InOrder(
+			db.EXPECT().Lock(ctx, inboxIRI),
+			db.EXPECT().InboxContains(ctx, inboxIRI, mustParse(testFederatedActivityIRI)).Return(false, nil),
+			ca.EXPECT().AppendToCollection(ctx, inboxIRI, mustParse(testFederatedActivityIRI)).Return(nil),
+			db.EXPECT().Unlock(ctx, inboxIRI),
+		)
+		fp.EXPECT().FederatingCallbacks(ctx).Return(FederatingWrappedCallbacks{}, nil, nil)
+		fp.EXPECT().DefaultCallback(ctx, testListen).Return(nil)
+		// Run
+		err := a.PostInbox(ctx, inboxIRI, testListen)
+		// Verify
+		assertEqual(t, err, nil)
+	})
 	t.Run("DoesNotAddToInboxNorDoSideEffectsIfDuplicate", func(t *testing.T) {
 		// Setup
 		ctl := gomock.NewController(t)
@@ -244,6 +656,70 @@ func TestPostInbox(t *testing.T) {
 		// Verify
 		assertEqual(t, err, nil)
 	})
+	t.Run("HandlesActivityOnlyOnceWithSeenStore", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, fp, _, db, cl, a := setupFn(ctl)
+		a.(*sideEffectActor).seen = NewMemorySeenStore(cl)
+		a.(*sideEffectActor).seenTTL = time.Hour
+		inboxIRI := mustParse(testMyInboxIRI)
+		cl.EXPECT().Now().Return(now()).Times(2)
+		gomock.InOrder(
+			db.EXPECT().Lock(ctx, inboxIRI),
+			db.EXPECT().InboxContains(ctx, inboxIRI, mustParse(testFederatedActivityIRI)).Return(false, nil),
+			db.EXPECT().GetInbox(ctx, inboxIRI).Return(testEmptyOrderedCollection, nil),
+			db.EXPECT().SetInbox(ctx, testOrderedCollectionWithFederatedId).Return(nil),
+			db.EXPECT().Unlock(ctx, inboxIRI),
+		)
+		fp.EXPECT().FederatingCallbacks(ctx).Return(FederatingWrappedCallbacks{}, nil, nil)
+		fp.EXPECT().DefaultCallback(ctx, testListen).Return(nil)
+		// Run
+		err := a.PostInbox(ctx, inboxIRI, testListen)
+		assertEqual(t, err, nil)
+		err = a.PostInbox(ctx, inboxIRI, testListen)
+		// Verify
+		assertEqual(t, err, nil)
+	})
+	t.Run("UnmarksSeenActivityIfHandlingFails", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, fp, _, db, cl, a := setupFn(ctl)
+		seen := NewMemorySeenStore(cl)
+		a.(*sideEffectActor).seen = seen
+		a.(*sideEffectActor).seenTTL = time.Hour
+		inboxIRI := mustParse(testMyInboxIRI)
+		cl.EXPECT().Now().Return(now()).Times(2)
+		gomock.InOrder(
+			db.EXPECT().Lock(ctx, inboxIRI),
+			db.EXPECT().InboxContains(ctx, inboxIRI, mustParse(testFederatedActivityIRI)).Return(false, nil),
+			db.EXPECT().GetInbox(ctx, inboxIRI).Return(testEmptyOrderedCollection, nil),
+			db.EXPECT().SetInbox(ctx, testOrderedCollectionWithFederatedId).Return(nil),
+			db.EXPECT().Unlock(ctx, inboxIRI),
+		)
+		fp.EXPECT().FederatingCallbacks(ctx).Return(FederatingWrappedCallbacks{}, nil, nil)
+		fp.EXPECT().DefaultCallback(ctx, testListen).Return(testErr)
+		// Run
+		err := a.PostInbox(ctx, inboxIRI, testListen)
+		// Verify
+		assertEqual(t, err, testErr)
+		alreadySeen, err := seen.MarkSeen(ctx, inboxIRI, mustParse(testFederatedActivityIRI), time.Hour)
+		assertEqual(t, err, nil)
+		assertEqual(t, alreadySeen, false)
+	})
+	t.Run("ReturnsSeenStoreError", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, _, _, _, _, a := setupFn(ctl)
+		a.(*sideEffectActor).seen = failingSeenStore{}
+		inboxIRI := mustParse(testMyInboxIRI)
+		// Run
+		err := a.PostInbox(ctx, inboxIRI, testListen)
+		// Verify
+		assertEqual(t, err, testErr)
+	})
 	t.Run("AddsToInbox", func(t *testing.T) {
 		// Setup
 		ctl := gomock.NewController(t)
@@ -290,11 +766,12 @@ func TestPostInbox(t *testing.T) {
 		assertEqual(t, err, nil)
 		assertEqual(t, pass, true)
 	})
-	t.Run("ResolvesToOverriddenFunction", func(t *testing.T) {
+	t.Run("RecoversCallbackPanicWhenEnabled", func(t *testing.T) {
 		// Setup
 		ctl := gomock.NewController(t)
 		defer ctl.Finish()
 		_, fp, _, db, _, a := setupFn(ctl)
+		a.(*sideEffectActor).recoverPanics = true
 		inboxIRI := mustParse(testMyInboxIRI)
 		gomock.InOrder(
 			db.EXPECT().Lock(ctx, inboxIRI),
@@ -303,24 +780,25 @@ func TestPostInbox(t *testing.T) {
 			db.EXPECT().SetInbox(ctx, testOrderedCollectionWithFederatedId).Return(nil),
 			db.EXPECT().Unlock(ctx, inboxIRI),
 		)
-		pass := false
 		fp.EXPECT().FederatingCallbacks(ctx).Return(FederatingWrappedCallbacks{}, []interface{}{
-			func(c context.Context, a vocab.ActivityStreamsCreate) error {
-				pass = true
-				return nil
+			func(c context.Context, a vocab.ActivityStreamsListen) error {
+				panic("test panic")
 			},
 		}, nil)
 		// Run
-		err := a.PostInbox(ctx, inboxIRI, testCreate)
+		err := a.PostInbox(ctx, inboxIRI, testListen)
 		// Verify
-		assertEqual(t, err, nil)
-		assertEqual(t, pass, true)
+		pErr, ok := err.(*CallbackPanicError)
+		assertEqual(t, ok, true)
+		assertEqual(t, pErr.Value, "test panic")
+		assertEqual(t, len(pErr.Stack) > 0, true)
 	})
-	t.Run("ResolvesToDefaultFunction", func(t *testing.T) {
+	t.Run("IgnoresUnhandledWithPolicy", func(t *testing.T) {
 		// Setup
 		ctl := gomock.NewController(t)
 		defer ctl.Finish()
 		_, fp, _, db, _, a := setupFn(ctl)
+		a.(*sideEffectActor).unhandled = UnhandledActivityIgnore
 		inboxIRI := mustParse(testMyInboxIRI)
 		gomock.InOrder(
 			db.EXPECT().Lock(ctx, inboxIRI),
@@ -329,36 +807,289 @@ func TestPostInbox(t *testing.T) {
 			db.EXPECT().SetInbox(ctx, testOrderedCollectionWithFederatedId).Return(nil),
 			db.EXPECT().Unlock(ctx, inboxIRI),
 		)
-		pass := false
-		fp.EXPECT().FederatingCallbacks(ctx).Return(FederatingWrappedCallbacks{
-			Create: func(c context.Context, a vocab.ActivityStreamsCreate) error {
-				pass = true
-				return nil
-			},
-		}, nil, nil)
-		db.EXPECT().Lock(ctx, mustParse(testNoteId1))
-		db.EXPECT().Create(ctx, testFederatedNote)
-		db.EXPECT().Unlock(ctx, mustParse(testNoteId1))
+		fp.EXPECT().FederatingCallbacks(ctx).Return(FederatingWrappedCallbacks{}, nil, nil)
 		// Run
-		err := a.PostInbox(ctx, inboxIRI, testCreate)
+		err := a.PostInbox(ctx, inboxIRI, testListen)
 		// Verify
 		assertEqual(t, err, nil)
-		assertEqual(t, pass, true)
 	})
-}
-
-// TestInboxForwarding ensures that the inbox forwarding logic is correct.
-func TestInboxForwarding(t *testing.T) {
-	ctx := context.Background()
-	setupFn := func(ctl *gomock.Controller) (c *MockCommonBehavior, fp *MockFederatingProtocol, sp *MockSocialProtocol, db *MockDatabase, cl *MockClock, a DelegateActor) {
-		setupData()
-		c = NewMockCommonBehavior(ctl)
-		fp = NewMockFederatingProtocol(ctl)
-		sp = NewMockSocialProtocol(ctl)
-		db = NewMockDatabase(ctl)
-		cl = NewMockClock(ctl)
-		a = &sideEffectActor{
-			common: c,
+	t.Run("RejectsUnhandledWithPolicy", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, fp, _, db, _, a := setupFn(ctl)
+		a.(*sideEffectActor).unhandled = UnhandledActivityReject
+		inboxIRI := mustParse(testMyInboxIRI)
+		gomock.InOrder(
+			db.EXPECT().Lock(ctx, inboxIRI),
+			db.EXPECT().InboxContains(ctx, inboxIRI, mustParse(testFederatedActivityIRI)).Return(false, nil),
+			db.EXPECT().GetInbox(ctx, inboxIRI).Return(testEmptyOrderedCollection, nil),
+			db.EXPECT().SetInbox(ctx, testOrderedCollectionWithFederatedId).Return(nil),
+			db.EXPECT().Unlock(ctx, inboxIRI),
+		)
+		fp.EXPECT().FederatingCallbacks(ctx).Return(FederatingWrappedCallbacks{}, nil, nil)
+		// Run
+		err := a.PostInbox(ctx, inboxIRI, testListen)
+		// Verify
+		assertEqual(t, err, ErrUnhandledActivity)
+	})
+	t.Run("StoresUnhandledWithPolicy", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, fp, _, db, _, a := setupFn(ctl)
+		a.(*sideEffectActor).unhandled = UnhandledActivityStoreOnly
+		inboxIRI := mustParse(testMyInboxIRI)
+		gomock.InOrder(
+			db.EXPECT().Lock(ctx, inboxIRI),
+			db.EXPECT().InboxContains(ctx, inboxIRI, mustParse(testFederatedActivityIRI)).Return(false, nil),
+			db.EXPECT().GetInbox(ctx, inboxIRI).Return(testEmptyOrderedCollection, nil),
+			db.EXPECT().SetInbox(ctx, testOrderedCollectionWithFederatedId).Return(nil),
+			db.EXPECT().Unlock(ctx, inboxIRI),
+		)
+		fp.EXPECT().FederatingCallbacks(ctx).Return(FederatingWrappedCallbacks{}, nil, nil)
+		gomock.InOrder(
+			db.EXPECT().Lock(ctx, mustParse(testFederatedActivityIRI)),
+			db.EXPECT().Exists(ctx, mustParse(testFederatedActivityIRI)).Return(false, nil),
+			db.EXPECT().Create(ctx, testListen),
+			db.EXPECT().Unlock(ctx, mustParse(testFederatedActivityIRI)),
+		)
+		// Run
+		err := a.PostInbox(ctx, inboxIRI, testListen)
+		// Verify
+		assertEqual(t, err, nil)
+	})
+	t.Run("RecoversDefaultCallbackPanicWhenEnabled", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, fp, _, db, _, a := setupFn(ctl)
+		a.(*sideEffectActor).recoverPanics = true
+		inboxIRI := mustParse(testMyInboxIRI)
+		gomock.InOrder(
+			db.EXPECT().Lock(ctx, inboxIRI),
+			db.EXPECT().InboxContains(ctx, inboxIRI, mustParse(testFederatedActivityIRI)).Return(false, nil),
+			db.EXPECT().GetInbox(ctx, inboxIRI).Return(testEmptyOrderedCollection, nil),
+			db.EXPECT().SetInbox(ctx, testOrderedCollectionWithFederatedId).Return(nil),
+			db.EXPECT().Unlock(ctx, inboxIRI),
+		)
+		fp.EXPECT().FederatingCallbacks(ctx).Return(FederatingWrappedCallbacks{}, nil, nil)
+		fp.EXPECT().DefaultCallback(ctx, testListen).Do(func(c context.Context, a Activity) {
+			panic("test panic")
+		})
+		// Run
+		err := a.PostInbox(ctx, inboxIRI, testListen)
+		// Verify
+		_, ok := err.(*CallbackPanicError)
+		assertEqual(t, ok, true)
+	})
+	t.Run("DoesNotRecoverCallbackPanicByDefault", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, fp, _, db, _, a := setupFn(ctl)
+		inboxIRI := mustParse(testMyInboxIRI)
+		gomock.InOrder(
+			db.EXPECT().Lock(ctx, inboxIRI),
+			db.EXPECT().InboxContains(ctx, inboxIRI, mustParse(testFederatedActivityIRI)).Return(false, nil),
+			db.EXPECT().GetInbox(ctx, inboxIRI).Return(testEmptyOrderedCollection, nil),
+			db.EXPECT().SetInbox(ctx, testOrderedCollectionWithFederatedId).Return(nil),
+			db.EXPECT().Unlock(ctx, inboxIRI),
+		)
+		fp.EXPECT().FederatingCallbacks(ctx).Return(FederatingWrappedCallbacks{}, []interface{}{
+			func(c context.Context, a vocab.ActivityStreamsListen) error {
+				panic("test panic")
+			},
+		}, nil)
+		// Run & Verify
+		defer func() {
+			assertEqual(t, recover(), "test panic")
+		}()
+		a.PostInbox(ctx, inboxIRI, testListen)
+	})
+	t.Run("ResolvesToOverriddenFunction", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, fp, _, db, _, a := setupFn(ctl)
+		inboxIRI := mustParse(testMyInboxIRI)
+		gomock.InOrder(
+			db.EXPECT().Lock(ctx, inboxIRI),
+			db.EXPECT().InboxContains(ctx, inboxIRI, mustParse(testFederatedActivityIRI)).Return(false, nil),
+			db.EXPECT().GetInbox(ctx, inboxIRI).Return(testEmptyOrderedCollection, nil),
+			db.EXPECT().SetInbox(ctx, testOrderedCollectionWithFederatedId).Return(nil),
+			db.EXPECT().Unlock(ctx, inboxIRI),
+		)
+		pass := false
+		fp.EXPECT().FederatingCallbacks(ctx).Return(FederatingWrappedCallbacks{}, []interface{}{
+			func(c context.Context, a vocab.ActivityStreamsCreate) error {
+				pass = true
+				return nil
+			},
+		}, nil)
+		// Run
+		err := a.PostInbox(ctx, inboxIRI, testCreate)
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, pass, true)
+	})
+	t.Run("ResolvesToDefaultFunction", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, fp, _, db, _, a := setupFn(ctl)
+		inboxIRI := mustParse(testMyInboxIRI)
+		gomock.InOrder(
+			db.EXPECT().Lock(ctx, inboxIRI),
+			db.EXPECT().InboxContains(ctx, inboxIRI, mustParse(testFederatedActivityIRI)).Return(false, nil),
+			db.EXPECT().GetInbox(ctx, inboxIRI).Return(testEmptyOrderedCollection, nil),
+			db.EXPECT().SetInbox(ctx, testOrderedCollectionWithFederatedId).Return(nil),
+			db.EXPECT().Unlock(ctx, inboxIRI),
+		)
+		pass := false
+		fp.EXPECT().FederatingCallbacks(ctx).Return(FederatingWrappedCallbacks{
+			Create: func(c context.Context, a vocab.ActivityStreamsCreate) error {
+				pass = true
+				return nil
+			},
+		}, nil, nil)
+		db.EXPECT().Lock(ctx, mustParse(testNoteId1))
+		db.EXPECT().Create(ctx, testFederatedNote)
+		db.EXPECT().Unlock(ctx, mustParse(testNoteId1))
+		// Run
+		err := a.PostInbox(ctx, inboxIRI, testCreate)
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, pass, true)
+	})
+}
+
+// TestLocalInboxes ensures the local recipients of activities received in the
+// shared inbox are determined correctly.
+func TestLocalInboxes(t *testing.T) {
+	ctx := context.Background()
+	setupFn := func(ctl *gomock.Controller) (fp *MockFederatingProtocol, db *MockDatabase, cl *MockClock, a *sideEffectActor) {
+		setupData()
+		fp = NewMockFederatingProtocol(ctl)
+		db = NewMockDatabase(ctl)
+		cl = NewMockClock(ctl)
+		a = &sideEffectActor{
+			common: NewMockCommonBehavior(ctl),
+			s2s:    fp,
+			c2s:    NewMockSocialProtocol(ctl),
+			db:     db,
+			clock:  cl,
+		}
+		return
+	}
+	newActivity := func() Activity {
+		create := streams.NewActivityStreamsCreate()
+		id := streams.NewJSONLDIdProperty()
+		id.Set(mustParse(testFederatedActivityIRI))
+		create.SetJSONLDId(id)
+		to := streams.NewActivityStreamsToProperty()
+		to.AppendIRI(mustParse(testPersonIRI))
+		to.AppendIRI(mustParse(PublicActivityPubIRI))
+		create.SetActivityStreamsTo(to)
+		cc := streams.NewActivityStreamsCcProperty()
+		cc.AppendIRI(mustParse(testFederatedActorIRI))
+		cc.AppendIRI(mustParse(testPersonIRI))
+		create.SetActivityStreamsCc(cc)
+		return create
+	}
+	// Run tests
+	t.Run("ReturnsInboxesOfAddressedLocalActors", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, db, _, a := setupFn(ctl)
+		db.EXPECT().Lock(ctx, mustParse(testPersonIRI)).Times(2)
+		db.EXPECT().Owns(ctx, mustParse(testPersonIRI)).Return(true, nil).Times(2)
+		db.EXPECT().Get(ctx, mustParse(testPersonIRI)).Return(testMyPerson, nil).Times(2)
+		db.EXPECT().Unlock(ctx, mustParse(testPersonIRI)).Times(2)
+		db.EXPECT().Lock(ctx, mustParse(testFederatedActorIRI))
+		db.EXPECT().Owns(ctx, mustParse(testFederatedActorIRI)).Return(false, nil)
+		db.EXPECT().Unlock(ctx, mustParse(testFederatedActorIRI))
+		// Run
+		inboxes, err := a.LocalInboxes(ctx, newActivity())
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, len(inboxes), 1)
+		assertEqual(t, inboxes[0].String(), testMyInboxIRI)
+	})
+	t.Run("DefersToSharedInboxResolver", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		fp, _, _, a := setupFn(ctl)
+		resolver := NewMockSharedInboxResolver(ctl)
+		a.s2s = sharedInboxFederatingProtocol{fp, resolver}
+		activity := newActivity()
+		resolver.EXPECT().LocalInboxes(ctx, activity).Return([]*url.URL{mustParse(testMyInboxIRI), mustParse(testMyInboxIRI)}, nil)
+		// Run
+		inboxes, err := a.LocalInboxes(ctx, activity)
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, len(inboxes), 1)
+		assertEqual(t, inboxes[0].String(), testMyInboxIRI)
+	})
+}
+
+// TestHasLocalRecipients tests whether received activities are relevant to
+// local actors.
+func TestHasLocalRecipients(t *testing.T) {
+	ctx := context.Background()
+	setupFn := func(ctl *gomock.Controller) (fp *MockFederatingProtocol, a *sideEffectActor) {
+		setupData()
+		fp = NewMockFederatingProtocol(ctl)
+		a = &sideEffectActor{
+			common: NewMockCommonBehavior(ctl),
+			s2s:    fp,
+			c2s:    NewMockSocialProtocol(ctl),
+			db:     NewMockDatabase(ctl),
+			clock:  NewMockClock(ctl),
+		}
+		return
+	}
+	t.Run("DefaultsToTrue", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, a := setupFn(ctl)
+		// Run
+		relevant, err := a.HasLocalRecipients(ctx, testListen)
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, relevant, true)
+	})
+	t.Run("DefersToLocalRecipientsChecker", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		fp, a := setupFn(ctl)
+		checker := NewMockLocalRecipientsChecker(ctl)
+		a.s2s = localRecipientsFederatingProtocol{fp, checker}
+		checker.EXPECT().HasLocalRecipients(ctx, testListen).Return(false, testErr)
+		// Run
+		relevant, err := a.HasLocalRecipients(ctx, testListen)
+		// Verify
+		assertEqual(t, err, testErr)
+		assertEqual(t, relevant, false)
+	})
+}
+
+// TestInboxForwarding ensures that the inbox forwarding logic is correct.
+func TestInboxForwarding(t *testing.T) {
+	ctx := context.Background()
+	setupFn := func(ctl *gomock.Controller) (c *MockCommonBehavior, fp *MockFederatingProtocol, sp *MockSocialProtocol, db *MockDatabase, cl *MockClock, a DelegateActor) {
+		setupData()
+		c = NewMockCommonBehavior(ctl)
+		fp = NewMockFederatingProtocol(ctl)
+		sp = NewMockSocialProtocol(ctl)
+		db = NewMockDatabase(ctl)
+		cl = NewMockClock(ctl)
+		a = &sideEffectActor{
+			common: c,
 			s2s:    fp,
 			c2s:    sp,
 			db:     db,
@@ -561,6 +1292,9 @@ func TestInboxForwarding(t *testing.T) {
 			db.EXPECT().Get(ctx, mustParse(testAudienceIRI)).Return(testOrderedCollectionOfActors, nil),
 			db.EXPECT().Lock(ctx, mustParse(testAudienceIRI2)),
 			db.EXPECT().Get(ctx, mustParse(testAudienceIRI2)).Return(testCollectionOfActors, nil),
+			db.EXPECT().Lock(ctx, mustParse(testMyInboxIRI)),
+			db.EXPECT().ActorForInbox(ctx, mustParse(testMyInboxIRI)).Return(mustParse(testPersonIRI), nil),
+			db.EXPECT().Unlock(ctx, mustParse(testMyInboxIRI)),
 			fp.EXPECT().MaxInboxForwardingRecursionDepth(ctx).Return(0),
 			// hasInboxForwardingValues
 			db.EXPECT().Lock(ctx, mustParse(testTagIRI)),
@@ -608,10 +1342,14 @@ func TestInboxForwarding(t *testing.T) {
 			db.EXPECT().Get(ctx, mustParse(testAudienceIRI)).Return(testOrderedCollectionOfActors, nil),
 			db.EXPECT().Lock(ctx, mustParse(testAudienceIRI2)),
 			db.EXPECT().Get(ctx, mustParse(testAudienceIRI2)).Return(testCollectionOfActors, nil),
+			db.EXPECT().Lock(ctx, mustParse(testMyInboxIRI)),
+			db.EXPECT().ActorForInbox(ctx, mustParse(testMyInboxIRI)).Return(mustParse(testPersonIRI), nil),
+			db.EXPECT().Unlock(ctx, mustParse(testMyInboxIRI)),
 			fp.EXPECT().MaxInboxForwardingRecursionDepth(ctx).Return(0),
 			// hasInboxForwardingValues
 			db.EXPECT().Lock(ctx, mustParse(testTagIRI)),
 			db.EXPECT().Owns(ctx, mustParse(testTagIRI)).Return(true, nil),
+			db.EXPECT().Get(ctx, mustParse(testTagIRI)).Return(newObjectAttributedTo(testTagIRI, testPersonIRI), nil),
 			db.EXPECT().Unlock(ctx, mustParse(testTagIRI)),
 			// after hasInboxForwardingValues
 			fp.EXPECT().FilterForwarding(
@@ -668,6 +1406,9 @@ func TestInboxForwarding(t *testing.T) {
 			db.EXPECT().Get(ctx, mustParse(testAudienceIRI)).Return(testOrderedCollectionOfActors, nil),
 			db.EXPECT().Lock(ctx, mustParse(testAudienceIRI2)),
 			db.EXPECT().Get(ctx, mustParse(testAudienceIRI2)).Return(testCollectionOfActors, nil),
+			db.EXPECT().Lock(ctx, mustParse(testMyInboxIRI)),
+			db.EXPECT().ActorForInbox(ctx, mustParse(testMyInboxIRI)).Return(mustParse(testPersonIRI), nil),
+			db.EXPECT().Unlock(ctx, mustParse(testMyInboxIRI)),
 			fp.EXPECT().MaxInboxForwardingRecursionDepth(ctx).Return(0),
 			// hasInboxForwardingValues
 			db.EXPECT().Lock(ctx, mustParse(testNoteId1)),
@@ -675,6 +1416,7 @@ func TestInboxForwarding(t *testing.T) {
 			db.EXPECT().Unlock(ctx, mustParse(testNoteId1)),
 			db.EXPECT().Lock(ctx, mustParse(inReplyToIRI)),
 			db.EXPECT().Owns(ctx, mustParse(inReplyToIRI)).Return(true, nil),
+			db.EXPECT().Get(ctx, mustParse(inReplyToIRI)).Return(newObjectAttributedTo(inReplyToIRI, testPersonIRI), nil),
 			db.EXPECT().Unlock(ctx, mustParse(inReplyToIRI)),
 			// after hasInboxForwardingValues
 			fp.EXPECT().FilterForwarding(
@@ -734,6 +1476,9 @@ func TestInboxForwarding(t *testing.T) {
 			db.EXPECT().Get(ctx, mustParse(testAudienceIRI)).Return(testOrderedCollectionOfActors, nil),
 			db.EXPECT().Lock(ctx, mustParse(testAudienceIRI2)),
 			db.EXPECT().Get(ctx, mustParse(testAudienceIRI2)).Return(testCollectionOfActors, nil),
+			db.EXPECT().Lock(ctx, mustParse(testMyInboxIRI)),
+			db.EXPECT().ActorForInbox(ctx, mustParse(testMyInboxIRI)).Return(mustParse(testPersonIRI), nil),
+			db.EXPECT().Unlock(ctx, mustParse(testMyInboxIRI)),
 			fp.EXPECT().MaxInboxForwardingRecursionDepth(ctx).Return(0),
 			// hasInboxForwardingValues
 			db.EXPECT().Lock(ctx, mustParse(testTagIRI)),
@@ -751,6 +1496,7 @@ func TestInboxForwarding(t *testing.T) {
 			tagTPort2.EXPECT().Dereference(ctx, mustParse(testTagIRI2)).Return(mustSerializeToBytes(newActivityWithId(testTagIRI2)), nil),
 			db.EXPECT().Lock(ctx, mustParse(inReplyToIRI)),
 			db.EXPECT().Owns(ctx, mustParse(inReplyToIRI)).Return(true, nil),
+			db.EXPECT().Get(ctx, mustParse(inReplyToIRI)).Return(newObjectAttributedTo(inReplyToIRI, testPersonIRI), nil),
 			db.EXPECT().Unlock(ctx, mustParse(inReplyToIRI)),
 			// after hasInboxForwardingValues
 			fp.EXPECT().FilterForwarding(
@@ -806,6 +1552,9 @@ func TestInboxForwarding(t *testing.T) {
 			db.EXPECT().Get(ctx, mustParse(testAudienceIRI)).Return(testOrderedCollectionOfActors, nil),
 			db.EXPECT().Lock(ctx, mustParse(testAudienceIRI2)),
 			db.EXPECT().Get(ctx, mustParse(testAudienceIRI2)).Return(testCollectionOfActors, nil),
+			db.EXPECT().Lock(ctx, mustParse(testMyInboxIRI)),
+			db.EXPECT().ActorForInbox(ctx, mustParse(testMyInboxIRI)).Return(mustParse(testPersonIRI), nil),
+			db.EXPECT().Unlock(ctx, mustParse(testMyInboxIRI)),
 			fp.EXPECT().MaxInboxForwardingRecursionDepth(ctx).Return(1),
 			// hasInboxForwardingValues
 			db.EXPECT().Lock(ctx, mustParse(testNoteId1)),
@@ -822,6 +1571,130 @@ func TestInboxForwarding(t *testing.T) {
 	})
 }
 
+func TestTriggersInboxForwarding(t *testing.T) {
+	ctx := context.Background()
+	const (
+		myNoteIRI    = "https://example.com/addison/note/1"
+		otherNoteIRI = "https://example.com/sam/note/1"
+		replyIRI     = "https://other.example.com/dakota/note/1"
+	)
+	setupFn := func(ctl *gomock.Controller) (c *MockCommonBehavior, fp *MockFederatingProtocol, db *MockDatabase, a *sideEffectActor) {
+		c = NewMockCommonBehavior(ctl)
+		fp = NewMockFederatingProtocol(ctl)
+		db = NewMockDatabase(ctl)
+		a = &sideEffectActor{
+			common: c,
+			s2s:    fp,
+			db:     db,
+		}
+		return
+	}
+	// replyFn creates a Create of a reply to the note, by the actor.
+	replyFn := func(actorIRI, inReplyTo string) vocab.ActivityStreamsCreate {
+		reply := newObjectWithId(replyIRI)
+		irt := streams.NewActivityStreamsInReplyToProperty()
+		irt.AppendIRI(mustParse(inReplyTo))
+		reply.SetActivityStreamsInReplyTo(irt)
+		create := streams.NewActivityStreamsCreate()
+		actor := streams.NewActivityStreamsActorProperty()
+		actor.AppendIRI(mustParse(actorIRI))
+		create.SetActivityStreamsActor(actor)
+		op := streams.NewActivityStreamsObjectProperty()
+		op.AppendActivityStreamsObject(reply)
+		create.SetActivityStreamsObject(op)
+		return create
+	}
+	expectLocalActorFn := func(db *MockDatabase) {
+		db.EXPECT().Lock(ctx, mustParse(testMyInboxIRI))
+		db.EXPECT().ActorForInbox(ctx, mustParse(testMyInboxIRI)).Return(mustParse(testPersonIRI), nil)
+		db.EXPECT().Unlock(ctx, mustParse(testMyInboxIRI))
+	}
+	expectNotOwnedFn := func(db *MockDatabase, iri string) {
+		db.EXPECT().Lock(ctx, mustParse(iri))
+		db.EXPECT().Owns(ctx, mustParse(iri)).Return(false, nil)
+		db.EXPECT().Unlock(ctx, mustParse(iri))
+	}
+	t.Run("TriggersForReplyToLocalActorPost", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, fp, db, a := setupFn(ctl)
+		// Mock
+		expectLocalActorFn(db)
+		fp.EXPECT().MaxInboxForwardingRecursionDepth(ctx).Return(0)
+		expectNotOwnedFn(db, replyIRI)
+		db.EXPECT().Lock(ctx, mustParse(myNoteIRI))
+		db.EXPECT().Owns(ctx, mustParse(myNoteIRI)).Return(true, nil)
+		db.EXPECT().Get(ctx, mustParse(myNoteIRI)).Return(newObjectAttributedTo(myNoteIRI, testPersonIRI), nil)
+		db.EXPECT().Unlock(ctx, mustParse(myNoteIRI))
+		// Run
+		triggered, err := a.triggersInboxForwarding(ctx, mustParse(testMyInboxIRI), replyFn(testFederatedActorIRI, myNoteIRI))
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, triggered, true)
+	})
+	t.Run("DoesNotTriggerForReplyToOtherLocalActorPost", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		c, fp, db, a := setupFn(ctl)
+		tp := NewMockTransport(ctl)
+		// Mock
+		expectLocalActorFn(db)
+		fp.EXPECT().MaxInboxForwardingRecursionDepth(ctx).Return(0)
+		expectNotOwnedFn(db, replyIRI)
+		db.EXPECT().Lock(ctx, mustParse(otherNoteIRI))
+		db.EXPECT().Owns(ctx, mustParse(otherNoteIRI)).Return(true, nil)
+		db.EXPECT().Get(ctx, mustParse(otherNoteIRI)).Return(newObjectAttributedTo(otherNoteIRI, testFederatedActorIRI3), nil)
+		db.EXPECT().Unlock(ctx, mustParse(otherNoteIRI))
+		c.EXPECT().NewTransport(ctx, mustParse(testMyInboxIRI), goFedUserAgent()).Return(tp, nil)
+		tp.EXPECT().Dereference(ctx, mustParse(otherNoteIRI)).Return(nil, fmt.Errorf("test error"))
+		// Run
+		triggered, err := a.triggersInboxForwarding(ctx, mustParse(testMyInboxIRI), replyFn(testFederatedActorIRI, otherNoteIRI))
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, triggered, false)
+	})
+	t.Run("DoesNotTrustEmbeddedAttribution", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, fp, db, a := setupFn(ctl)
+		create := streams.NewActivityStreamsCreate()
+		actor := streams.NewActivityStreamsActorProperty()
+		actor.AppendIRI(mustParse(testFederatedActorIRI))
+		create.SetActivityStreamsActor(actor)
+		op := streams.NewActivityStreamsObjectProperty()
+		op.AppendActivityStreamsObject(newObjectAttributedTo(otherNoteIRI, testPersonIRI))
+		create.SetActivityStreamsObject(op)
+		// Mock
+		expectLocalActorFn(db)
+		fp.EXPECT().MaxInboxForwardingRecursionDepth(ctx).Return(0)
+		db.EXPECT().Lock(ctx, mustParse(otherNoteIRI))
+		db.EXPECT().Owns(ctx, mustParse(otherNoteIRI)).Return(true, nil)
+		db.EXPECT().Get(ctx, mustParse(otherNoteIRI)).Return(newObjectAttributedTo(otherNoteIRI, testFederatedActorIRI3), nil)
+		db.EXPECT().Unlock(ctx, mustParse(otherNoteIRI))
+		// Run
+		triggered, err := a.triggersInboxForwarding(ctx, mustParse(testMyInboxIRI), create)
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, triggered, false)
+	})
+	t.Run("DoesNotTriggerIfLocalActorIsOriginator", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, _, db, a := setupFn(ctl)
+		// Mock
+		expectLocalActorFn(db)
+		// Run
+		triggered, err := a.triggersInboxForwarding(ctx, mustParse(testMyInboxIRI), replyFn(testPersonIRI, myNoteIRI))
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, triggered, false)
+	})
+}
+
 // TestPostOutbox ensures that the main application side effects of receiving a
 // social protocol message occur.
 func TestPostOutbox(t *testing.T) {
@@ -865,11 +1738,126 @@ func TestPostOutbox(t *testing.T) {
 		assertEqual(t, err, nil)
 		assertEqual(t, deliverable, true)
 	})
-	t.Run("AddsToOutbox", func(t *testing.T) {
+	t.Run("RecoversCallbackPanicWhenEnabled", func(t *testing.T) {
 		// Setup
 		ctl := gomock.NewController(t)
 		defer ctl.Finish()
-		_, _, sp, db, _, a := setupFn(ctl)
+		_, _, sp, _, _, a := setupFn(ctl)
+		a.(*sideEffectActor).recoverPanics = true
+		outboxIRI := mustParse(testMyOutboxIRI)
+		sp.EXPECT().SocialCallbacks(ctx).Return(SocialWrappedCallbacks{}, []interface{}{
+			func(c context.Context, a vocab.ActivityStreamsListen) error {
+				panic("test panic")
+			},
+		}, nil)
+		// Run
+		_, err := a.PostOutbox(ctx, testMyListen, outboxIRI, mustSerialize(testMyListen))
+		// Verify
+		_, ok := err.(*CallbackPanicError)
+		assertEqual(t, ok, true)
+	})
+	newCreate := func(activityTo, noteTo string) vocab.ActivityStreamsCreate {
+		note := streams.NewActivityStreamsNote()
+		to := streams.NewActivityStreamsToProperty()
+		to.AppendIRI(mustParse(noteTo))
+		note.SetActivityStreamsTo(to)
+		cc := streams.NewActivityStreamsCcProperty()
+		cc.AppendIRI(mustParse(PublicActivityPubIRI))
+		note.SetActivityStreamsCc(cc)
+		create := streams.NewActivityStreamsCreate()
+		op := streams.NewActivityStreamsObjectProperty()
+		op.AppendActivityStreamsNote(note)
+		create.SetActivityStreamsObject(op)
+		if len(activityTo) > 0 {
+			to := streams.NewActivityStreamsToProperty()
+			to.AppendIRI(mustParse(activityTo))
+			create.SetActivityStreamsTo(to)
+			cc := streams.NewActivityStreamsCcProperty()
+			cc.AppendIRI(mustParse(PublicActivityPubIRI))
+			create.SetActivityStreamsCc(cc)
+		}
+		return create
+	}
+	t.Run("CopiesObjectAddressingToUnaddressedCreate", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, _, sp, _, _, a := setupFn(ctl)
+		a.(*sideEffectActor).consistentCreateAddressing = true
+		outboxIRI := mustParse(testMyOutboxIRI)
+		create := newCreate("", testFederatedActorIRI)
+		sp.EXPECT().SocialCallbacks(ctx).Return(SocialWrappedCallbacks{}, []interface{}{
+			func(c context.Context, a vocab.ActivityStreamsCreate) error {
+				ids, err := addressingIds(a)
+				assertEqual(t, err, nil)
+				assertEqual(t, len(ids["to"]), 1)
+				assertEqual(t, ids["to"][0].String(), testFederatedActorIRI)
+				assertEqual(t, len(ids["cc"]), 1)
+				assertEqual(t, ids["cc"][0].String(), PublicActivityPubIRI)
+				return testErr
+			},
+		}, nil)
+		// Run
+		_, err := a.PostOutbox(ctx, create, outboxIRI, mustSerialize(create))
+		// Verify
+		assertEqual(t, err, testErr)
+	})
+	t.Run("RejectsCreateAddressedDifferentlyThanObject", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, _, _, _, _, a := setupFn(ctl)
+		a.(*sideEffectActor).consistentCreateAddressing = true
+		outboxIRI := mustParse(testMyOutboxIRI)
+		create := newCreate(testFederatedActorIRI2, testFederatedActorIRI)
+		// Run
+		_, err := a.PostOutbox(ctx, create, outboxIRI, mustSerialize(create))
+		// Verify
+		assertEqual(t, err, ErrCreateAddressingMismatch)
+	})
+	t.Run("AcceptsCreateAddressedLikeObject", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, _, sp, _, _, a := setupFn(ctl)
+		a.(*sideEffectActor).consistentCreateAddressing = true
+		outboxIRI := mustParse(testMyOutboxIRI)
+		create := newCreate(testFederatedActorIRI, testFederatedActorIRI)
+		sp.EXPECT().SocialCallbacks(ctx).Return(SocialWrappedCallbacks{}, nil, testErr)
+		// Run
+		_, err := a.PostOutbox(ctx, create, outboxIRI, mustSerialize(create))
+		// Verify
+		assertEqual(t, err, testErr)
+	})
+	t.Run("AppendsToOutboxWithCollectionAppender", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, _, sp, db, _, a := setupFn(ctl)
+		ca := NewMockCollectionAppender(ctl)
+		a.(*sideEffectActor).db = collectionAppenderDatabase{db, ca}
+		outboxIRI := mustParse(testMyOutboxIRI)
+		gomock.InOrder(
+			db.EXPECT().Lock(ctx, mustParse(testNewActivityIRI)),
+			db.EXPECT().Create(ctx, testMyListen),
+			db.EXPECT().Unlock(ctx, mustParse(testNewActivityIRI)),
+			db.EXPECT().Lock(ctx, outboxIRI),
+			ca.EXPECT().AppendToCollection(ctx, outboxIRI, mustParse(testNewActivityIRI)).Return(nil),
+			db.EXPECT().Unlock(ctx, outboxIRI),
+		)
+		sp.EXPECT().SocialCallbacks(ctx).Return(SocialWrappedCallbacks{}, nil, nil)
+		sp.EXPECT().DefaultCallback(ctx, testMyListen).Return(nil)
+		// Run
+		deliverable, err := a.PostOutbox(ctx, testMyListen, outboxIRI, mustSerialize(testMyListen))
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, deliverable, true)
+	})
+	t.Run("AddsToOutbox", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, _, sp, db, _, a := setupFn(ctl)
 		outboxIRI := mustParse(testMyOutboxIRI)
 		gomock.InOrder(
 			db.EXPECT().Lock(ctx, mustParse(testNewActivityIRI)),
@@ -940,189 +1928,626 @@ func TestPostOutbox(t *testing.T) {
 			},
 		}, nil)
 		// Run
-		deliverable, err := a.PostOutbox(ctx, testMyCreate, outboxIRI, mustSerialize(testMyCreate))
+		deliverable, err := a.PostOutbox(ctx, testMyCreate, outboxIRI, mustSerialize(testMyCreate))
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, deliverable, true)
+		assertEqual(t, pass, true)
+	})
+	t.Run("ResolvesToDefaultFunction", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, _, sp, db, _, a := setupFn(ctl)
+		outboxIRI := mustParse(testMyInboxIRI)
+		gomock.InOrder(
+			db.EXPECT().Lock(ctx, mustParse(testNewActivityIRI)),
+			db.EXPECT().Create(ctx, testMyCreate),
+			db.EXPECT().Unlock(ctx, mustParse(testNewActivityIRI)),
+			db.EXPECT().Lock(ctx, outboxIRI),
+			db.EXPECT().GetOutbox(ctx, outboxIRI).Return(testEmptyOrderedCollection, nil),
+			db.EXPECT().SetOutbox(ctx, testOrderedCollectionWithNewId).Return(nil),
+			db.EXPECT().Unlock(ctx, outboxIRI),
+		)
+		pass := false
+		sp.EXPECT().SocialCallbacks(ctx).Return(SocialWrappedCallbacks{
+			Create: func(c context.Context, a vocab.ActivityStreamsCreate) error {
+				pass = true
+				return nil
+			},
+		}, nil, nil)
+		db.EXPECT().Lock(ctx, mustParse(testNoteId1))
+		db.EXPECT().Create(ctx, testMyNote)
+		db.EXPECT().Unlock(ctx, mustParse(testNoteId1))
+		// Run
+		deliverable, err := a.PostOutbox(ctx, testMyCreate, outboxIRI, mustSerialize(testMyCreate))
+		// Verify
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, deliverable, true)
+		assertEqual(t, pass, true)
+	})
+}
+
+// TestAddNewIDs ensures that new 'id' properties are set on an activity and all
+// of its 'object' property values if it is a Create activity.
+func TestAddNewIDs(t *testing.T) {
+	ctx := context.Background()
+	setupFn := func(ctl *gomock.Controller) (c *MockCommonBehavior, fp *MockFederatingProtocol, sp *MockSocialProtocol, db *MockDatabase, cl *MockClock, a DelegateActor) {
+		setupData()
+		c = NewMockCommonBehavior(ctl)
+		fp = NewMockFederatingProtocol(ctl)
+		sp = NewMockSocialProtocol(ctl)
+		db = NewMockDatabase(ctl)
+		cl = NewMockClock(ctl)
+		a = &sideEffectActor{
+			common: c,
+			s2s:    fp,
+			c2s:    sp,
+			db:     db,
+			clock:  cl,
+		}
+		return
+	}
+	t.Run("AddsIdToActivityWithoutId", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, _, _, db, _, a := setupFn(ctl)
+		db.EXPECT().NewID(ctx, testMyListenNoId).Return(mustParse(testNewActivityIRI2), nil)
+		db.EXPECT().Lock(ctx, mustParse(testNewActivityIRI2))
+		db.EXPECT().Exists(ctx, mustParse(testNewActivityIRI2)).Return(false, nil)
+		db.EXPECT().Unlock(ctx, mustParse(testNewActivityIRI2))
+		// Run
+		err := a.AddNewIDs(ctx, testMyListenNoId)
+		// Verify
+		assertEqual(t, err, nil)
+		resultId := testMyListenNoId.GetJSONLDId()
+		assertNotEqual(t, resultId, nil)
+		assertEqual(t, resultId.Get().String(), mustParse(testNewActivityIRI2).String())
+	})
+	t.Run("AddsIdToActivityWithId", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, _, _, db, _, a := setupFn(ctl)
+		db.EXPECT().NewID(ctx, testMyListen).Return(mustParse(testNewActivityIRI2), nil)
+		db.EXPECT().Lock(ctx, mustParse(testNewActivityIRI2))
+		db.EXPECT().Exists(ctx, mustParse(testNewActivityIRI2)).Return(false, nil)
+		db.EXPECT().Unlock(ctx, mustParse(testNewActivityIRI2))
+		// Run
+		err := a.AddNewIDs(ctx, testMyListen)
+		// Verify
+		assertEqual(t, err, nil)
+		resultId := testMyListen.GetJSONLDId()
+		assertNotEqual(t, resultId, nil)
+		assertEqual(t, resultId.Get().String(), mustParse(testNewActivityIRI2).String())
+	})
+	t.Run("AddsIdsToObjectsIfCreateActivity", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, _, _, db, _, a := setupFn(ctl)
+		db.EXPECT().NewID(ctx, testMyCreate).Return(mustParse(testNewActivityIRI2), nil)
+		db.EXPECT().Lock(ctx, mustParse(testNewActivityIRI2))
+		db.EXPECT().Exists(ctx, mustParse(testNewActivityIRI2)).Return(false, nil)
+		db.EXPECT().Unlock(ctx, mustParse(testNewActivityIRI2))
+		db.EXPECT().NewID(ctx, testMyNote).Return(mustParse(testNewActivityIRI3), nil)
+		db.EXPECT().Lock(ctx, mustParse(testNewActivityIRI3))
+		db.EXPECT().Exists(ctx, mustParse(testNewActivityIRI3)).Return(false, nil)
+		db.EXPECT().Unlock(ctx, mustParse(testNewActivityIRI3))
+		// Run
+		err := a.AddNewIDs(ctx, testMyCreate)
+		// Verify
+		assertEqual(t, err, nil)
+		op := testMyCreate.GetActivityStreamsObject()
+		assertNotEqual(t, op, nil)
+		assertEqual(t, op.Len(), 1)
+		n := op.At(0).GetActivityStreamsNote()
+		assertNotEqual(t, n, nil)
+		noteId := n.GetJSONLDId()
+		assertNotEqual(t, noteId, nil)
+		assertEqual(t, noteId.Get().String(), mustParse(testNewActivityIRI3).String())
+	})
+	t.Run("DoesNotAddIdsToObjectsIfNotCreateActivity", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, _, _, db, _, a := setupFn(ctl)
+		db.EXPECT().NewID(ctx, testMyListenNoId).Return(mustParse(testNewActivityIRI2), nil)
+		db.EXPECT().Lock(ctx, mustParse(testNewActivityIRI2))
+		db.EXPECT().Exists(ctx, mustParse(testNewActivityIRI2)).Return(false, nil)
+		db.EXPECT().Unlock(ctx, mustParse(testNewActivityIRI2))
+		// Run
+		err := a.AddNewIDs(ctx, testMyListenNoId)
+		// Verify
+		assertEqual(t, err, nil)
+		op := testMyListenNoId.GetActivityStreamsObject()
+		assertNotEqual(t, op, nil)
+		assertEqual(t, op.Len(), 1)
+		n := op.At(0).GetActivityStreamsNote()
+		assertNotEqual(t, n, nil)
+		noteId := n.GetJSONLDId()
+		assertEqual(t, noteId, nil)
+	})
+	t.Run("ReturnsErrorIfActivityIdCollides", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, _, _, db, _, a := setupFn(ctl)
+		db.EXPECT().NewID(ctx, testMyListenNoId).Return(mustParse(testNewActivityIRI2), nil)
+		db.EXPECT().Lock(ctx, mustParse(testNewActivityIRI2))
+		db.EXPECT().Exists(ctx, mustParse(testNewActivityIRI2)).Return(true, nil)
+		db.EXPECT().Unlock(ctx, mustParse(testNewActivityIRI2))
+		// Run
+		err := a.AddNewIDs(ctx, testMyListenNoId)
+		// Verify
+		assertNotEqual(t, err, nil)
+		assertEqual(t, testMyListenNoId.GetJSONLDId(), nil)
+	})
+	t.Run("ReturnsErrorIfObjectIdCollides", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, _, _, db, _, a := setupFn(ctl)
+		db.EXPECT().NewID(ctx, testMyCreate).Return(mustParse(testNewActivityIRI2), nil)
+		db.EXPECT().Lock(ctx, mustParse(testNewActivityIRI2))
+		db.EXPECT().Exists(ctx, mustParse(testNewActivityIRI2)).Return(false, nil)
+		db.EXPECT().Unlock(ctx, mustParse(testNewActivityIRI2))
+		db.EXPECT().NewID(ctx, testMyNote).Return(mustParse(testNoteId1), nil)
+		db.EXPECT().Lock(ctx, mustParse(testNoteId1))
+		db.EXPECT().Exists(ctx, mustParse(testNoteId1)).Return(true, nil)
+		db.EXPECT().Unlock(ctx, mustParse(testNoteId1))
+		// Run
+		err := a.AddNewIDs(ctx, testMyCreate)
+		// Verify
+		assertNotEqual(t, err, nil)
+	})
+}
+
+// TestDeliver ensures federated delivery of an activity happens correctly to
+// the ActivityPub specification.
+func TestDeliver(t *testing.T) {
+	baseActivityFn := func() vocab.ActivityStreamsCreate {
+		act := streams.NewActivityStreamsCreate()
+		id := streams.NewJSONLDIdProperty()
+		id.Set(mustParse(testNewActivityIRI))
+		act.SetJSONLDId(id)
+		op := streams.NewActivityStreamsObjectProperty()
+		note := streams.NewActivityStreamsNote()
+		op.AppendActivityStreamsNote(note)
+		act.SetActivityStreamsObject(op)
+		return act
+	}
+	ctx := context.Background()
+	setupFn := func(ctl *gomock.Controller) (c *MockCommonBehavior, fp *MockFederatingProtocol, sp *MockSocialProtocol, db *MockDatabase, cl *MockClock, a DelegateActor) {
+		setupData()
+		c = NewMockCommonBehavior(ctl)
+		fp = NewMockFederatingProtocol(ctl)
+		sp = NewMockSocialProtocol(ctl)
+		db = NewMockDatabase(ctl)
+		cl = NewMockClock(ctl)
+		a = &sideEffectActor{
+			common: c,
+			s2s:    fp,
+			c2s:    sp,
+			db:     db,
+			clock:  cl,
+		}
+		return
+	}
+	t.Run("SendToRecipientsInTo", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		c, mockFp, _, mockDb, _, a := setupFn(ctl)
+		mockTp := NewMockTransport(ctl)
+		act := baseActivityFn()
+		to := streams.NewActivityStreamsToProperty()
+		to.AppendIRI(mustParse(testFederatedActorIRI))
+		to.AppendIRI(mustParse(testFederatedActorIRI2))
+		act.SetActivityStreamsTo(to)
+		expectRecip := []*url.URL{
+			mustParse(testFederatedInboxIRI),
+			mustParse(testFederatedInboxIRI2),
+		}
+		// Mock
+		c.EXPECT().NewTransport(ctx, mustParse(testMyOutboxIRI), goFedUserAgent()).Return(
+			mockTp, nil)
+		mockFp.EXPECT().MaxDeliveryRecursionDepth(ctx).Return(1)
+		mockDb.EXPECT().Lock(ctx, mustParse(testFederatedActorIRI))
+		mockDb.EXPECT().InboxForActor(ctx, mustParse(testFederatedActorIRI)).Return(nil, nil)
+		mockDb.EXPECT().Unlock(ctx, mustParse(testFederatedActorIRI))
+		mockDb.EXPECT().Lock(ctx, mustParse(testFederatedActorIRI2))
+		mockDb.EXPECT().InboxForActor(ctx, mustParse(testFederatedActorIRI2)).Return(nil, nil)
+		mockDb.EXPECT().Unlock(ctx, mustParse(testFederatedActorIRI2))
+		mockFp.EXPECT().MaxDeliveryRecipients(ctx).Return(0)
+		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActorIRI)).Return(
+			mustSerializeToBytes(testFederatedPerson1), nil)
+		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActorIRI2)).Return(
+			mustSerializeToBytes(testFederatedPerson2), nil)
+		mockDb.EXPECT().Lock(ctx, mustParse(testMyOutboxIRI))
+		mockDb.EXPECT().ActorForOutbox(ctx, mustParse(testMyOutboxIRI)).Return(
+			mustParse(testPersonIRI), nil)
+		mockDb.EXPECT().Unlock(ctx, mustParse(testMyOutboxIRI))
+		mockDb.EXPECT().Lock(ctx, mustParse(testPersonIRI))
+		mockDb.EXPECT().Get(ctx, mustParse(testPersonIRI)).Return(
+			testMyPerson, nil)
+		mockDb.EXPECT().Unlock(ctx, mustParse(testPersonIRI))
+		c.EXPECT().NewTransport(ctx, mustParse(testMyOutboxIRI), goFedUserAgent()).Return(
+			mockTp, nil)
+		mockTp.EXPECT().BatchDeliver(ctx, mustSerializeToBytes(act), expectRecip)
+		// Run & Verify
+		err := a.Deliver(ctx, mustParse(testMyOutboxIRI), act)
+		assertEqual(t, err, nil)
+	})
+	t.Run("SkipsDeliveryByPolicy", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, mockFp, _, _, _, a := setupFn(ctl)
+		policer := NewMockDeliveryPolicer(ctl)
+		a.(*sideEffectActor).s2s = deliveryPolicerFederatingProtocol{mockFp, policer}
+		act := baseActivityFn()
+		to := streams.NewActivityStreamsToProperty()
+		to.AppendIRI(mustParse(testFederatedActorIRI))
+		act.SetActivityStreamsTo(to)
+		// Mock
+		policer.EXPECT().DeliveryPolicy(ctx, act).Return(true, nil, nil)
+		// Run & Verify
+		err := a.Deliver(ctx, mustParse(testMyOutboxIRI), act)
+		assertEqual(t, err, nil)
+	})
+	t.Run("ReturnsDeliveryPolicyError", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, mockFp, _, _, _, a := setupFn(ctl)
+		policer := NewMockDeliveryPolicer(ctl)
+		a.(*sideEffectActor).s2s = deliveryPolicerFederatingProtocol{mockFp, policer}
+		act := baseActivityFn()
+		to := streams.NewActivityStreamsToProperty()
+		to.AppendIRI(mustParse(testFederatedActorIRI))
+		act.SetActivityStreamsTo(to)
+		// Mock
+		policer.EXPECT().DeliveryPolicy(ctx, act).Return(false, nil, testErr)
+		// Run & Verify
+		err := a.Deliver(ctx, mustParse(testMyOutboxIRI), act)
+		assertEqual(t, err, testErr)
+	})
+	t.Run("SkipsDeliveryByPolicyBeforeValidatingAddressing", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, mockFp, _, _, _, a := setupFn(ctl)
+		policer := NewMockDeliveryPolicer(ctl)
+		a.(*sideEffectActor).s2s = deliveryPolicerFederatingProtocol{mockFp, policer}
+		act := baseActivityFn()
+		// Mock
+		policer.EXPECT().DeliveryPolicy(ctx, act).Return(true, nil, nil)
+		// Run & Verify
+		err := a.Deliver(ctx, mustParse(testMyOutboxIRI), act)
+		assertEqual(t, err, nil)
+	})
+	t.Run("ResolveDeliveryTargetsSkippedByPolicy", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, mockFp, _, _, _, a := setupFn(ctl)
+		policer := NewMockDeliveryPolicer(ctl)
+		a.(*sideEffectActor).s2s = deliveryPolicerFederatingProtocol{mockFp, policer}
+		act := baseActivityFn()
+		to := streams.NewActivityStreamsToProperty()
+		to.AppendIRI(mustParse(testFederatedActorIRI))
+		act.SetActivityStreamsTo(to)
+		// Mock
+		policer.EXPECT().DeliveryPolicy(ctx, act).Return(true, nil, nil)
+		// Run & Verify
+		r, err := a.(*sideEffectActor).ResolveDeliveryTargets(ctx, mustParse(testMyOutboxIRI), act)
+		assertEqual(t, err, nil)
+		assertEqual(t, len(r), 0)
+	})
+	t.Run("DeliverToInboxSkippedByPolicy", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, mockFp, _, _, _, a := setupFn(ctl)
+		policer := NewMockDeliveryPolicer(ctl)
+		a.(*sideEffectActor).s2s = deliveryPolicerFederatingProtocol{mockFp, policer}
+		act := baseActivityFn()
+		// Mock
+		policer.EXPECT().DeliveryPolicy(ctx, act).Return(true, nil, nil)
+		// Run & Verify
+		err := a.(*sideEffectActor).DeliverToInbox(ctx, mustParse(testMyOutboxIRI), mustParse(testFederatedInboxIRI), act)
+		assertEqual(t, err, nil)
+	})
+	t.Run("DeliverToInboxSkipsInboxFilteredByPolicy", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		_, mockFp, _, _, _, a := setupFn(ctl)
+		policer := NewMockDeliveryPolicer(ctl)
+		a.(*sideEffectActor).s2s = deliveryPolicerFederatingProtocol{mockFp, policer}
+		act := baseActivityFn()
+		filter := func(r []*url.URL) []*url.URL {
+			return nil
+		}
+		// Mock
+		policer.EXPECT().DeliveryPolicy(ctx, act).Return(false, filter, nil)
+		// Run & Verify
+		err := a.(*sideEffectActor).DeliverToInbox(ctx, mustParse(testMyOutboxIRI), mustParse(testFederatedInboxIRI), act)
+		assertEqual(t, err, nil)
+	})
+	t.Run("NarrowsRecipientsByPolicyBeforeMaxDeliveryRecipients", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		c, mockFp, _, mockDb, _, a := setupFn(ctl)
+		policer := NewMockDeliveryPolicer(ctl)
+		a.(*sideEffectActor).s2s = deliveryPolicerFederatingProtocol{mockFp, policer}
+		mockTp := NewMockTransport(ctl)
+		act := baseActivityFn()
+		to := streams.NewActivityStreamsToProperty()
+		to.AppendIRI(mustParse(testFederatedActorIRI))
+		to.AppendIRI(mustParse(testFederatedActorIRI2))
+		act.SetActivityStreamsTo(to)
+		var filtered []*url.URL
+		filter := func(r []*url.URL) []*url.URL {
+			filtered = r
+			return []*url.URL{r[1]}
+		}
+		expectRecip := []*url.URL{
+			mustParse(testFederatedInboxIRI2),
+		}
+		// Mock
+		policer.EXPECT().DeliveryPolicy(ctx, act).Return(false, filter, nil)
+		c.EXPECT().NewTransport(ctx, mustParse(testMyOutboxIRI), goFedUserAgent()).Return(
+			mockTp, nil)
+		mockFp.EXPECT().MaxDeliveryRecursionDepth(ctx).Return(1)
+		mockDb.EXPECT().Lock(ctx, mustParse(testFederatedActorIRI))
+		mockDb.EXPECT().InboxForActor(ctx, mustParse(testFederatedActorIRI)).Return(nil, nil)
+		mockDb.EXPECT().Unlock(ctx, mustParse(testFederatedActorIRI))
+		mockDb.EXPECT().Lock(ctx, mustParse(testFederatedActorIRI2))
+		mockDb.EXPECT().InboxForActor(ctx, mustParse(testFederatedActorIRI2)).Return(nil, nil)
+		mockDb.EXPECT().Unlock(ctx, mustParse(testFederatedActorIRI2))
+		mockFp.EXPECT().MaxDeliveryRecipients(ctx).Return(1)
+		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActorIRI)).Return(
+			mustSerializeToBytes(testFederatedPerson1), nil)
+		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActorIRI2)).Return(
+			mustSerializeToBytes(testFederatedPerson2), nil)
+		mockDb.EXPECT().Lock(ctx, mustParse(testMyOutboxIRI))
+		mockDb.EXPECT().ActorForOutbox(ctx, mustParse(testMyOutboxIRI)).Return(
+			mustParse(testPersonIRI), nil)
+		mockDb.EXPECT().Unlock(ctx, mustParse(testMyOutboxIRI))
+		mockDb.EXPECT().Lock(ctx, mustParse(testPersonIRI))
+		mockDb.EXPECT().Get(ctx, mustParse(testPersonIRI)).Return(
+			testMyPerson, nil)
+		mockDb.EXPECT().Unlock(ctx, mustParse(testPersonIRI))
+		c.EXPECT().NewTransport(ctx, mustParse(testMyOutboxIRI), goFedUserAgent()).Return(
+			mockTp, nil)
+		mockTp.EXPECT().BatchDeliver(ctx, mustSerializeToBytes(act), expectRecip)
+		// Run
+		err := a.Deliver(ctx, mustParse(testMyOutboxIRI), act)
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, len(filtered), 2)
+	})
+	t.Run("ResolvesDeliveryTargetsWithoutDelivering", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		c, mockFp, _, mockDb, _, a := setupFn(ctl)
+		mockTp := NewMockTransport(ctl)
+		act := baseActivityFn()
+		to := streams.NewActivityStreamsToProperty()
+		to.AppendIRI(mustParse(testFederatedActorIRI))
+		to.AppendIRI(mustParse(testFederatedActorIRI2))
+		act.SetActivityStreamsTo(to)
+		expectRecip := []*url.URL{
+			mustParse(testFederatedInboxIRI),
+			mustParse(testFederatedInboxIRI2),
+		}
+		// Mock
+		c.EXPECT().NewTransport(ctx, mustParse(testMyOutboxIRI), goFedUserAgent()).Return(
+			mockTp, nil)
+		mockFp.EXPECT().MaxDeliveryRecursionDepth(ctx).Return(1)
+		mockDb.EXPECT().Lock(ctx, mustParse(testFederatedActorIRI))
+		mockDb.EXPECT().InboxForActor(ctx, mustParse(testFederatedActorIRI)).Return(nil, nil)
+		mockDb.EXPECT().Unlock(ctx, mustParse(testFederatedActorIRI))
+		mockDb.EXPECT().Lock(ctx, mustParse(testFederatedActorIRI2))
+		mockDb.EXPECT().InboxForActor(ctx, mustParse(testFederatedActorIRI2)).Return(nil, nil)
+		mockDb.EXPECT().Unlock(ctx, mustParse(testFederatedActorIRI2))
+		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActorIRI)).Return(
+			mustSerializeToBytes(testFederatedPerson1), nil)
+		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActorIRI2)).Return(
+			mustSerializeToBytes(testFederatedPerson2), nil)
+		mockDb.EXPECT().Lock(ctx, mustParse(testMyOutboxIRI))
+		mockDb.EXPECT().ActorForOutbox(ctx, mustParse(testMyOutboxIRI)).Return(
+			mustParse(testPersonIRI), nil)
+		mockDb.EXPECT().Unlock(ctx, mustParse(testMyOutboxIRI))
+		mockDb.EXPECT().Lock(ctx, mustParse(testPersonIRI))
+		mockDb.EXPECT().Get(ctx, mustParse(testPersonIRI)).Return(
+			testMyPerson, nil)
+		mockDb.EXPECT().Unlock(ctx, mustParse(testPersonIRI))
+		// Run
+		r, err := a.(*sideEffectActor).ResolveDeliveryTargets(ctx, mustParse(testMyOutboxIRI), act)
 		// Verify
 		assertEqual(t, err, nil)
-		assertEqual(t, deliverable, true)
-		assertEqual(t, pass, true)
+		assertEqual(t, len(r), len(expectRecip))
+		for i := range expectRecip {
+			assertEqual(t, r[i].String(), expectRecip[i].String())
+		}
 	})
-	t.Run("ResolvesToDefaultFunction", func(t *testing.T) {
+	t.Run("NeverDeliversBccViaSharedInbox", func(t *testing.T) {
 		// Setup
 		ctl := gomock.NewController(t)
 		defer ctl.Finish()
-		_, _, sp, db, _, a := setupFn(ctl)
-		outboxIRI := mustParse(testMyInboxIRI)
-		gomock.InOrder(
-			db.EXPECT().Lock(ctx, mustParse(testNewActivityIRI)),
-			db.EXPECT().Create(ctx, testMyCreate),
-			db.EXPECT().Unlock(ctx, mustParse(testNewActivityIRI)),
-			db.EXPECT().Lock(ctx, outboxIRI),
-			db.EXPECT().GetOutbox(ctx, outboxIRI).Return(testEmptyOrderedCollection, nil),
-			db.EXPECT().SetOutbox(ctx, testOrderedCollectionWithNewId).Return(nil),
-			db.EXPECT().Unlock(ctx, outboxIRI),
-		)
-		pass := false
-		sp.EXPECT().SocialCallbacks(ctx).Return(SocialWrappedCallbacks{
-			Create: func(c context.Context, a vocab.ActivityStreamsCreate) error {
-				pass = true
-				return nil
-			},
-		}, nil, nil)
-		db.EXPECT().Lock(ctx, mustParse(testNoteId1))
-		db.EXPECT().Create(ctx, testMyNote)
-		db.EXPECT().Unlock(ctx, mustParse(testNoteId1))
+		c, mockFp, _, mockDb, _, a := setupFn(ctl)
+		mockTp := NewMockTransport(ctl)
+		resolver := NewMockInboxResolver(ctl)
+		a.(*sideEffectActor).s2s = inboxResolverFederatingProtocol{mockFp, resolver}
+		act := baseActivityFn()
+		to := streams.NewActivityStreamsToProperty()
+		to.AppendIRI(mustParse(testFederatedActorIRI))
+		to.AppendIRI(mustParse(testFederatedActorIRI2))
+		act.SetActivityStreamsTo(to)
+		bcc := streams.NewActivityStreamsBccProperty()
+		bcc.AppendIRI(mustParse(testFederatedActorIRI3))
+		act.SetActivityStreamsBcc(bcc)
+		expectAct := baseActivityFn()
+		expectAct.SetActivityStreamsTo(to)
+		expectRecip := []*url.URL{
+			mustParse(testSharedInboxIRI),
+			mustParse(testFederatedInboxIRI3),
+		}
+		// Mock
+		c.EXPECT().NewTransport(ctx, mustParse(testMyOutboxIRI), goFedUserAgent()).Return(
+			mockTp, nil).Times(2)
+		mockFp.EXPECT().MaxDeliveryRecursionDepth(ctx).Return(1)
+		for _, r := range []struct{ actor, inbox string }{
+			{testFederatedActorIRI, testFederatedInboxIRI},
+			{testFederatedActorIRI2, testFederatedInboxIRI2},
+			{testFederatedActorIRI3, testFederatedInboxIRI3},
+		} {
+			mockDb.EXPECT().Lock(ctx, mustParse(r.actor))
+			mockDb.EXPECT().InboxForActor(ctx, mustParse(r.actor)).Return(mustParse(r.inbox), nil)
+			mockDb.EXPECT().Unlock(ctx, mustParse(r.actor))
+		}
+		resolver.EXPECT().ResolveInboxIRIs(ctx, mockTp, gomock.Any(), gomock.Any()).DoAndReturn(ResolveSharedInboxIRIs)
+		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActorIRI)).Return(
+			mustActorWithSharedInbox(testFederatedActorIRI, testFederatedInboxIRI, testSharedInboxIRI), nil)
+		mockFp.EXPECT().MaxDeliveryRecipients(ctx).Return(0)
+		mockDb.EXPECT().Lock(ctx, mustParse(testMyOutboxIRI))
+		mockDb.EXPECT().ActorForOutbox(ctx, mustParse(testMyOutboxIRI)).Return(
+			mustParse(testPersonIRI), nil)
+		mockDb.EXPECT().Unlock(ctx, mustParse(testMyOutboxIRI))
+		mockDb.EXPECT().Lock(ctx, mustParse(testPersonIRI))
+		mockDb.EXPECT().Get(ctx, mustParse(testPersonIRI)).Return(
+			testMyPerson, nil)
+		mockDb.EXPECT().Unlock(ctx, mustParse(testPersonIRI))
+		mockTp.EXPECT().BatchDeliver(ctx, mustSerializeToBytes(expectAct), expectRecip)
 		// Run
-		deliverable, err := a.PostOutbox(ctx, testMyCreate, outboxIRI, mustSerialize(testMyCreate))
-		// Verify
+		err := a.Deliver(ctx, mustParse(testMyOutboxIRI), act)
 		// Verify
 		assertEqual(t, err, nil)
-		assertEqual(t, deliverable, true)
-		assertEqual(t, pass, true)
 	})
-}
-
-// TestAddNewIDs ensures that new 'id' properties are set on an activity and all
-// of its 'object' property values if it is a Create activity.
-func TestAddNewIDs(t *testing.T) {
-	ctx := context.Background()
-	setupFn := func(ctl *gomock.Controller) (c *MockCommonBehavior, fp *MockFederatingProtocol, sp *MockSocialProtocol, db *MockDatabase, cl *MockClock, a DelegateActor) {
-		setupData()
-		c = NewMockCommonBehavior(ctl)
-		fp = NewMockFederatingProtocol(ctl)
-		sp = NewMockSocialProtocol(ctl)
-		db = NewMockDatabase(ctl)
-		cl = NewMockClock(ctl)
-		a = &sideEffectActor{
-			common: c,
-			s2s:    fp,
-			c2s:    sp,
-			db:     db,
-			clock:  cl,
-		}
-		return
-	}
-	t.Run("AddsIdToActivityWithoutId", func(t *testing.T) {
+	t.Run("ErrorsWithoutAddressing", func(t *testing.T) {
 		// Setup
 		ctl := gomock.NewController(t)
 		defer ctl.Finish()
-		_, _, _, db, _, a := setupFn(ctl)
-		db.EXPECT().NewID(ctx, testMyListenNoId).Return(mustParse(testNewActivityIRI2), nil)
+		_, _, _, _, _, a := setupFn(ctl)
+		act := baseActivityFn()
 		// Run
-		err := a.AddNewIDs(ctx, testMyListenNoId)
+		err := a.Deliver(ctx, mustParse(testMyOutboxIRI), act)
 		// Verify
-		assertEqual(t, err, nil)
-		resultId := testMyListenNoId.GetJSONLDId()
-		assertNotEqual(t, resultId, nil)
-		assertEqual(t, resultId.Get().String(), mustParse(testNewActivityIRI2).String())
+		assertNotEqual(t, err, nil)
 	})
-	t.Run("AddsIdToActivityWithId", func(t *testing.T) {
+	t.Run("DeliverToInboxSkipsResolutionAndStripsBcc", func(t *testing.T) {
 		// Setup
 		ctl := gomock.NewController(t)
 		defer ctl.Finish()
-		_, _, _, db, _, a := setupFn(ctl)
-		db.EXPECT().NewID(ctx, testMyListen).Return(mustParse(testNewActivityIRI2), nil)
+		c, _, _, _, _, a := setupFn(ctl)
+		mockTp := NewMockTransport(ctl)
+		act := baseActivityFn()
+		to := streams.NewActivityStreamsToProperty()
+		to.AppendIRI(mustParse(testFederatedActorIRI))
+		act.SetActivityStreamsTo(to)
+		bcc := streams.NewActivityStreamsBccProperty()
+		bcc.AppendIRI(mustParse(testFederatedActorIRI2))
+		act.SetActivityStreamsBcc(bcc)
+		expectAct := baseActivityFn()
+		expectAct.SetActivityStreamsTo(to)
+		// Mock
+		c.EXPECT().NewTransport(ctx, mustParse(testMyOutboxIRI), goFedUserAgent()).Return(
+			mockTp, nil)
+		mockTp.EXPECT().Deliver(ctx, mustSerializeToBytes(expectAct), mustParse(testFederatedInboxIRI3))
 		// Run
-		err := a.AddNewIDs(ctx, testMyListen)
+		err := a.(*sideEffectActor).DeliverToInbox(ctx, mustParse(testMyOutboxIRI), mustParse(testFederatedInboxIRI3), act)
 		// Verify
 		assertEqual(t, err, nil)
-		resultId := testMyListen.GetJSONLDId()
-		assertNotEqual(t, resultId, nil)
-		assertEqual(t, resultId.Get().String(), mustParse(testNewActivityIRI2).String())
+		assertEqual(t, act.GetActivityStreamsBcc().Len(), 1)
 	})
-	t.Run("AddsIdsToObjectsIfCreateActivity", func(t *testing.T) {
+	t.Run("DoesNotDeliverLocalOnly", func(t *testing.T) {
 		// Setup
 		ctl := gomock.NewController(t)
 		defer ctl.Finish()
-		_, _, _, db, _, a := setupFn(ctl)
-		db.EXPECT().NewID(ctx, testMyCreate).Return(mustParse(testNewActivityIRI2), nil)
-		db.EXPECT().NewID(ctx, testMyNote).Return(mustParse(testNewActivityIRI3), nil)
+		_, _, _, _, _, a := setupFn(ctl)
+		act := baseActivityFn()
 		// Run
-		err := a.AddNewIDs(ctx, testMyCreate)
+		err := a.Deliver(WithLocalOnly(ctx), mustParse(testMyOutboxIRI), act)
 		// Verify
 		assertEqual(t, err, nil)
-		op := testMyCreate.GetActivityStreamsObject()
-		assertNotEqual(t, op, nil)
-		assertEqual(t, op.Len(), 1)
-		n := op.At(0).GetActivityStreamsNote()
-		assertNotEqual(t, n, nil)
-		noteId := n.GetJSONLDId()
-		assertNotEqual(t, noteId, nil)
-		assertEqual(t, noteId.Get().String(), mustParse(testNewActivityIRI3).String())
 	})
-	t.Run("DoesNotAddIdsToObjectsIfNotCreateActivity", func(t *testing.T) {
+	t.Run("ErrorsWhenExceedingMaxDeliveryRecipients", func(t *testing.T) {
 		// Setup
 		ctl := gomock.NewController(t)
 		defer ctl.Finish()
-		_, _, _, db, _, a := setupFn(ctl)
-		db.EXPECT().NewID(ctx, testMyListenNoId).Return(mustParse(testNewActivityIRI2), nil)
+		c, mockFp, _, mockDb, _, a := setupFn(ctl)
+		mockTp := NewMockTransport(ctl)
+		act := baseActivityFn()
+		to := streams.NewActivityStreamsToProperty()
+		to.AppendIRI(mustParse(testFederatedActorIRI))
+		to.AppendIRI(mustParse(testFederatedActorIRI2))
+		act.SetActivityStreamsTo(to)
+		// Mock
+		c.EXPECT().NewTransport(ctx, mustParse(testMyOutboxIRI), goFedUserAgent()).Return(
+			mockTp, nil)
+		mockFp.EXPECT().MaxDeliveryRecursionDepth(ctx).Return(1)
+		mockDb.EXPECT().Lock(ctx, mustParse(testFederatedActorIRI))
+		mockDb.EXPECT().InboxForActor(ctx, mustParse(testFederatedActorIRI)).Return(nil, nil)
+		mockDb.EXPECT().Unlock(ctx, mustParse(testFederatedActorIRI))
+		mockDb.EXPECT().Lock(ctx, mustParse(testFederatedActorIRI2))
+		mockDb.EXPECT().InboxForActor(ctx, mustParse(testFederatedActorIRI2)).Return(nil, nil)
+		mockDb.EXPECT().Unlock(ctx, mustParse(testFederatedActorIRI2))
+		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActorIRI)).Return(
+			mustSerializeToBytes(testFederatedPerson1), nil)
+		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActorIRI2)).Return(
+			mustSerializeToBytes(testFederatedPerson2), nil)
+		mockFp.EXPECT().MaxDeliveryRecipients(ctx).Return(1)
+		mockDb.EXPECT().Lock(ctx, mustParse(testMyOutboxIRI))
+		mockDb.EXPECT().ActorForOutbox(ctx, mustParse(testMyOutboxIRI)).Return(
+			mustParse(testPersonIRI), nil)
+		mockDb.EXPECT().Unlock(ctx, mustParse(testMyOutboxIRI))
+		mockDb.EXPECT().Lock(ctx, mustParse(testPersonIRI))
+		mockDb.EXPECT().Get(ctx, mustParse(testPersonIRI)).Return(
+			testMyPerson, nil)
+		mockDb.EXPECT().Unlock(ctx, mustParse(testPersonIRI))
 		// Run
-		err := a.AddNewIDs(ctx, testMyListenNoId)
+		err := a.Deliver(ctx, mustParse(testMyOutboxIRI), act)
 		// Verify
-		assertEqual(t, err, nil)
-		op := testMyListenNoId.GetActivityStreamsObject()
-		assertNotEqual(t, op, nil)
-		assertEqual(t, op.Len(), 1)
-		n := op.At(0).GetActivityStreamsNote()
-		assertNotEqual(t, n, nil)
-		noteId := n.GetJSONLDId()
-		assertEqual(t, noteId, nil)
+		assertNotEqual(t, err, nil)
 	})
-}
-
-// TestDeliver ensures federated delivery of an activity happens correctly to
-// the ActivityPub specification.
-func TestDeliver(t *testing.T) {
-	baseActivityFn := func() vocab.ActivityStreamsCreate {
-		act := streams.NewActivityStreamsCreate()
-		id := streams.NewJSONLDIdProperty()
-		id.Set(mustParse(testNewActivityIRI))
-		act.SetJSONLDId(id)
-		op := streams.NewActivityStreamsObjectProperty()
-		note := streams.NewActivityStreamsNote()
-		op.AppendActivityStreamsNote(note)
-		act.SetActivityStreamsObject(op)
-		return act
-	}
-	ctx := context.Background()
-	setupFn := func(ctl *gomock.Controller) (c *MockCommonBehavior, fp *MockFederatingProtocol, sp *MockSocialProtocol, db *MockDatabase, cl *MockClock, a DelegateActor) {
-		setupData()
-		c = NewMockCommonBehavior(ctl)
-		fp = NewMockFederatingProtocol(ctl)
-		sp = NewMockSocialProtocol(ctl)
-		db = NewMockDatabase(ctl)
-		cl = NewMockClock(ctl)
-		a = &sideEffectActor{
-			common: c,
-			s2s:    fp,
-			c2s:    sp,
-			db:     db,
-			clock:  cl,
-		}
-		return
-	}
-	t.Run("SendToRecipientsInTo", func(t *testing.T) {
+	t.Run("AppliesMaxDeliveryRecipientsAfterResolvingInboxes", func(t *testing.T) {
 		// Setup
 		ctl := gomock.NewController(t)
 		defer ctl.Finish()
 		c, mockFp, _, mockDb, _, a := setupFn(ctl)
 		mockTp := NewMockTransport(ctl)
+		resolver := NewMockInboxResolver(ctl)
+		a.(*sideEffectActor).s2s = inboxResolverFederatingProtocol{mockFp, resolver}
 		act := baseActivityFn()
 		to := streams.NewActivityStreamsToProperty()
 		to.AppendIRI(mustParse(testFederatedActorIRI))
 		to.AppendIRI(mustParse(testFederatedActorIRI2))
 		act.SetActivityStreamsTo(to)
-		expectRecip := []*url.URL{
-			mustParse(testFederatedInboxIRI),
-			mustParse(testFederatedInboxIRI2),
-		}
+		sharedInbox := mustParse("https://example.com/sharedInbox")
 		// Mock
 		c.EXPECT().NewTransport(ctx, mustParse(testMyOutboxIRI), goFedUserAgent()).Return(
 			mockTp, nil)
 		mockFp.EXPECT().MaxDeliveryRecursionDepth(ctx).Return(1)
+		mockDb.EXPECT().Lock(ctx, mustParse(testFederatedActorIRI))
+		mockDb.EXPECT().InboxForActor(ctx, mustParse(testFederatedActorIRI)).Return(nil, nil)
+		mockDb.EXPECT().Unlock(ctx, mustParse(testFederatedActorIRI))
+		mockDb.EXPECT().Lock(ctx, mustParse(testFederatedActorIRI2))
+		mockDb.EXPECT().InboxForActor(ctx, mustParse(testFederatedActorIRI2)).Return(nil, nil)
+		mockDb.EXPECT().Unlock(ctx, mustParse(testFederatedActorIRI2))
 		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActorIRI)).Return(
 			mustSerializeToBytes(testFederatedPerson1), nil)
 		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActorIRI2)).Return(
 			mustSerializeToBytes(testFederatedPerson2), nil)
+		resolver.EXPECT().ResolveInboxIRIs(ctx, mockTp, gomock.Any(), gomock.Any()).Return(
+			[]*url.URL{sharedInbox, sharedInbox}, nil)
+		mockFp.EXPECT().MaxDeliveryRecipients(ctx).Return(1)
 		mockDb.EXPECT().Lock(ctx, mustParse(testMyOutboxIRI))
 		mockDb.EXPECT().ActorForOutbox(ctx, mustParse(testMyOutboxIRI)).Return(
 			mustParse(testPersonIRI), nil)
@@ -1133,7 +2558,7 @@ func TestDeliver(t *testing.T) {
 		mockDb.EXPECT().Unlock(ctx, mustParse(testPersonIRI))
 		c.EXPECT().NewTransport(ctx, mustParse(testMyOutboxIRI), goFedUserAgent()).Return(
 			mockTp, nil)
-		mockTp.EXPECT().BatchDeliver(ctx, mustSerializeToBytes(act), expectRecip)
+		mockTp.EXPECT().BatchDeliver(ctx, mustSerializeToBytes(act), []*url.URL{sharedInbox})
 		// Run & Verify
 		err := a.Deliver(ctx, mustParse(testMyOutboxIRI), act)
 		assertEqual(t, err, nil)
@@ -1158,6 +2583,13 @@ func TestDeliver(t *testing.T) {
 		c.EXPECT().NewTransport(ctx, mustParse(testMyOutboxIRI), goFedUserAgent()).Return(
 			mockTp, nil)
 		mockFp.EXPECT().MaxDeliveryRecursionDepth(ctx).Return(1)
+		mockDb.EXPECT().Lock(ctx, mustParse(testFederatedActorIRI))
+		mockDb.EXPECT().InboxForActor(ctx, mustParse(testFederatedActorIRI)).Return(nil, nil)
+		mockDb.EXPECT().Unlock(ctx, mustParse(testFederatedActorIRI))
+		mockDb.EXPECT().Lock(ctx, mustParse(testFederatedActorIRI2))
+		mockDb.EXPECT().InboxForActor(ctx, mustParse(testFederatedActorIRI2)).Return(nil, nil)
+		mockDb.EXPECT().Unlock(ctx, mustParse(testFederatedActorIRI2))
+		mockFp.EXPECT().MaxDeliveryRecipients(ctx).Return(0)
 		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActorIRI)).Return(
 			mustSerializeToBytes(testFederatedPerson1), nil)
 		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActorIRI2)).Return(
@@ -1176,6 +2608,7 @@ func TestDeliver(t *testing.T) {
 		// Run & Verify
 		err := a.Deliver(ctx, mustParse(testMyOutboxIRI), act)
 		assertEqual(t, err, nil)
+		assertNotEqual(t, act.GetActivityStreamsBto(), nil) // Retained for storage
 	})
 	t.Run("SendToRecipientsInCc", func(t *testing.T) {
 		// Setup
@@ -1196,6 +2629,13 @@ func TestDeliver(t *testing.T) {
 		c.EXPECT().NewTransport(ctx, mustParse(testMyOutboxIRI), goFedUserAgent()).Return(
 			mockTp, nil)
 		mockFp.EXPECT().MaxDeliveryRecursionDepth(ctx).Return(1)
+		mockDb.EXPECT().Lock(ctx, mustParse(testFederatedActorIRI))
+		mockDb.EXPECT().InboxForActor(ctx, mustParse(testFederatedActorIRI)).Return(nil, nil)
+		mockDb.EXPECT().Unlock(ctx, mustParse(testFederatedActorIRI))
+		mockDb.EXPECT().Lock(ctx, mustParse(testFederatedActorIRI2))
+		mockDb.EXPECT().InboxForActor(ctx, mustParse(testFederatedActorIRI2)).Return(nil, nil)
+		mockDb.EXPECT().Unlock(ctx, mustParse(testFederatedActorIRI2))
+		mockFp.EXPECT().MaxDeliveryRecipients(ctx).Return(0)
 		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActorIRI)).Return(
 			mustSerializeToBytes(testFederatedPerson1), nil)
 		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActorIRI2)).Return(
@@ -1235,6 +2675,13 @@ func TestDeliver(t *testing.T) {
 		c.EXPECT().NewTransport(ctx, mustParse(testMyOutboxIRI), goFedUserAgent()).Return(
 			mockTp, nil)
 		mockFp.EXPECT().MaxDeliveryRecursionDepth(ctx).Return(1)
+		mockDb.EXPECT().Lock(ctx, mustParse(testFederatedActorIRI))
+		mockDb.EXPECT().InboxForActor(ctx, mustParse(testFederatedActorIRI)).Return(nil, nil)
+		mockDb.EXPECT().Unlock(ctx, mustParse(testFederatedActorIRI))
+		mockDb.EXPECT().Lock(ctx, mustParse(testFederatedActorIRI2))
+		mockDb.EXPECT().InboxForActor(ctx, mustParse(testFederatedActorIRI2)).Return(nil, nil)
+		mockDb.EXPECT().Unlock(ctx, mustParse(testFederatedActorIRI2))
+		mockFp.EXPECT().MaxDeliveryRecipients(ctx).Return(0)
 		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActorIRI)).Return(
 			mustSerializeToBytes(testFederatedPerson1), nil)
 		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActorIRI2)).Return(
@@ -1253,6 +2700,7 @@ func TestDeliver(t *testing.T) {
 		// Run & Verify
 		err := a.Deliver(ctx, mustParse(testMyOutboxIRI), act)
 		assertEqual(t, err, nil)
+		assertNotEqual(t, act.GetActivityStreamsBcc(), nil) // Retained for storage
 	})
 	t.Run("SendToRecipientsInAudience", func(t *testing.T) {
 		// Setup
@@ -1273,6 +2721,13 @@ func TestDeliver(t *testing.T) {
 		c.EXPECT().NewTransport(ctx, mustParse(testMyOutboxIRI), goFedUserAgent()).Return(
 			mockTp, nil)
 		mockFp.EXPECT().MaxDeliveryRecursionDepth(ctx).Return(1)
+		mockDb.EXPECT().Lock(ctx, mustParse(testFederatedActorIRI))
+		mockDb.EXPECT().InboxForActor(ctx, mustParse(testFederatedActorIRI)).Return(nil, nil)
+		mockDb.EXPECT().Unlock(ctx, mustParse(testFederatedActorIRI))
+		mockDb.EXPECT().Lock(ctx, mustParse(testFederatedActorIRI2))
+		mockDb.EXPECT().InboxForActor(ctx, mustParse(testFederatedActorIRI2)).Return(nil, nil)
+		mockDb.EXPECT().Unlock(ctx, mustParse(testFederatedActorIRI2))
+		mockFp.EXPECT().MaxDeliveryRecipients(ctx).Return(0)
 		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActorIRI)).Return(
 			mustSerializeToBytes(testFederatedPerson1), nil)
 		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActorIRI2)).Return(
@@ -1312,6 +2767,13 @@ func TestDeliver(t *testing.T) {
 		c.EXPECT().NewTransport(ctx, mustParse(testMyOutboxIRI), goFedUserAgent()).Return(
 			mockTp, nil)
 		mockFp.EXPECT().MaxDeliveryRecursionDepth(ctx).Return(1)
+		mockDb.EXPECT().Lock(ctx, mustParse(testFederatedActorIRI))
+		mockDb.EXPECT().InboxForActor(ctx, mustParse(testFederatedActorIRI)).Return(nil, nil)
+		mockDb.EXPECT().Unlock(ctx, mustParse(testFederatedActorIRI))
+		mockDb.EXPECT().Lock(ctx, mustParse(testFederatedActorIRI2))
+		mockDb.EXPECT().InboxForActor(ctx, mustParse(testFederatedActorIRI2)).Return(nil, nil)
+		mockDb.EXPECT().Unlock(ctx, mustParse(testFederatedActorIRI2))
+		mockFp.EXPECT().MaxDeliveryRecipients(ctx).Return(0)
 		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActorIRI)).Return(
 			mustSerializeToBytes(testFederatedPerson1), nil)
 		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActorIRI2)).Return(
@@ -1349,6 +2811,10 @@ func TestDeliver(t *testing.T) {
 		c.EXPECT().NewTransport(ctx, mustParse(testMyOutboxIRI), goFedUserAgent()).Return(
 			mockTp, nil)
 		mockFp.EXPECT().MaxDeliveryRecursionDepth(ctx).Return(2)
+		mockDb.EXPECT().Lock(ctx, mustParse(testAudienceIRI))
+		mockDb.EXPECT().InboxForActor(ctx, mustParse(testAudienceIRI)).Return(nil, nil)
+		mockDb.EXPECT().Unlock(ctx, mustParse(testAudienceIRI))
+		mockFp.EXPECT().MaxDeliveryRecipients(ctx).Return(0)
 		mockTp.EXPECT().Dereference(ctx, mustParse(testAudienceIRI)).Return(
 			mustSerializeToBytes(testCollectionOfActors), nil)
 		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActorIRI)).Return(
@@ -1388,6 +2854,10 @@ func TestDeliver(t *testing.T) {
 		c.EXPECT().NewTransport(ctx, mustParse(testMyOutboxIRI), goFedUserAgent()).Return(
 			mockTp, nil)
 		mockFp.EXPECT().MaxDeliveryRecursionDepth(ctx).Return(2)
+		mockDb.EXPECT().Lock(ctx, mustParse(testAudienceIRI))
+		mockDb.EXPECT().InboxForActor(ctx, mustParse(testAudienceIRI)).Return(nil, nil)
+		mockDb.EXPECT().Unlock(ctx, mustParse(testAudienceIRI))
+		mockFp.EXPECT().MaxDeliveryRecipients(ctx).Return(0)
 		mockTp.EXPECT().Dereference(ctx, mustParse(testAudienceIRI)).Return(
 			mustSerializeToBytes(testOrderedCollectionOfActors), nil)
 		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActorIRI3)).Return(
@@ -1423,6 +2893,10 @@ func TestDeliver(t *testing.T) {
 		c.EXPECT().NewTransport(ctx, mustParse(testMyOutboxIRI), goFedUserAgent()).Return(
 			mockTp, nil)
 		mockFp.EXPECT().MaxDeliveryRecursionDepth(ctx).Return(1)
+		mockDb.EXPECT().Lock(ctx, mustParse(testAudienceIRI))
+		mockDb.EXPECT().InboxForActor(ctx, mustParse(testAudienceIRI)).Return(nil, nil)
+		mockDb.EXPECT().Unlock(ctx, mustParse(testAudienceIRI))
+		mockFp.EXPECT().MaxDeliveryRecipients(ctx).Return(0)
 		mockTp.EXPECT().Dereference(ctx, mustParse(testAudienceIRI)).Return(
 			mustSerializeToBytes(testCollectionOfActors), nil)
 		mockDb.EXPECT().Lock(ctx, mustParse(testMyOutboxIRI))
@@ -1474,10 +2948,78 @@ func TestDeliver(t *testing.T) {
 		c.EXPECT().NewTransport(ctx, mustParse(testMyOutboxIRI), goFedUserAgent()).Return(
 			mockTp, nil)
 		mockFp.EXPECT().MaxDeliveryRecursionDepth(ctx).Return(1)
+		mockDb.EXPECT().Lock(ctx, mustParse(testFederatedActorIRI)).Times(2)
+		mockDb.EXPECT().InboxForActor(ctx, mustParse(testFederatedActorIRI)).Return(nil, nil).Times(2)
+		mockDb.EXPECT().Unlock(ctx, mustParse(testFederatedActorIRI)).Times(2)
+		mockDb.EXPECT().Lock(ctx, mustParse(testFederatedActorIRI2)).Times(2)
+		mockDb.EXPECT().InboxForActor(ctx, mustParse(testFederatedActorIRI2)).Return(nil, nil).Times(2)
+		mockDb.EXPECT().Unlock(ctx, mustParse(testFederatedActorIRI2)).Times(2)
+		mockFp.EXPECT().MaxDeliveryRecipients(ctx).Return(0)
 		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActorIRI)).Return(
-			mustSerializeToBytes(testFederatedPerson1), nil).Times(4)
+			mustSerializeToBytes(testFederatedPerson1), nil).Times(2)
+		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActorIRI2)).Return(
+			mustSerializeToBytes(testFederatedPerson2), nil).Times(2)
+		mockDb.EXPECT().Lock(ctx, mustParse(testMyOutboxIRI))
+		mockDb.EXPECT().ActorForOutbox(ctx, mustParse(testMyOutboxIRI)).Return(
+			mustParse(testPersonIRI), nil)
+		mockDb.EXPECT().Unlock(ctx, mustParse(testMyOutboxIRI))
+		mockDb.EXPECT().Lock(ctx, mustParse(testPersonIRI))
+		mockDb.EXPECT().Get(ctx, mustParse(testPersonIRI)).Return(
+			testMyPerson, nil)
+		mockDb.EXPECT().Unlock(ctx, mustParse(testPersonIRI))
+		c.EXPECT().NewTransport(ctx, mustParse(testMyOutboxIRI), goFedUserAgent()).Return(
+			mockTp, nil)
+		mockTp.EXPECT().BatchDeliver(ctx, mustSerializeToBytes(expectAct), expectRecip)
+		// Run & Verify
+		err := a.Deliver(ctx, mustParse(testMyOutboxIRI), act)
+		assertEqual(t, err, nil)
+	})
+	t.Run("ResolvesInboxesOfHiddenReceiversSeparately", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		c, mockFp, _, mockDb, _, a := setupFn(ctl)
+		mockTp := NewMockTransport(ctl)
+		resolver := NewMockInboxResolver(ctl)
+		a.(*sideEffectActor).s2s = inboxResolverFederatingProtocol{mockFp, resolver}
+		act := baseActivityFn()
+		to := streams.NewActivityStreamsToProperty()
+		to.AppendIRI(mustParse(testFederatedActorIRI))
+		act.SetActivityStreamsTo(to)
+		bcc := streams.NewActivityStreamsBccProperty()
+		bcc.AppendIRI(mustParse(testFederatedActorIRI2))
+		act.SetActivityStreamsBcc(bcc)
+		expectAct := baseActivityFn() // Ensure Bcc is stripped
+		expectAct.SetActivityStreamsTo(to)
+		expectRecip := []*url.URL{
+			mustParse(testFederatedInboxIRI),
+			mustParse(testFederatedInboxIRI2),
+		}
+		// Mock
+		c.EXPECT().NewTransport(ctx, mustParse(testMyOutboxIRI), goFedUserAgent()).Return(
+			mockTp, nil)
+		mockFp.EXPECT().MaxDeliveryRecursionDepth(ctx).Return(1)
+		mockDb.EXPECT().Lock(ctx, mustParse(testFederatedActorIRI))
+		mockDb.EXPECT().InboxForActor(ctx, mustParse(testFederatedActorIRI)).Return(
+			mustParse(testFederatedInboxIRI), nil)
+		mockDb.EXPECT().Unlock(ctx, mustParse(testFederatedActorIRI))
+		mockDb.EXPECT().Lock(ctx, mustParse(testFederatedActorIRI2))
+		mockDb.EXPECT().InboxForActor(ctx, mustParse(testFederatedActorIRI2)).Return(nil, nil)
+		mockDb.EXPECT().Unlock(ctx, mustParse(testFederatedActorIRI2))
 		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActorIRI2)).Return(
-			mustSerializeToBytes(testFederatedPerson2), nil).Times(4)
+			mustSerializeToBytes(testFederatedPerson2), nil)
+		resolver.EXPECT().ResolveInboxIRIs(
+			ctx,
+			mockTp,
+			[]Recipient{{
+				ActorIRI: mustParse(testFederatedActorIRI),
+				InboxIRI: mustParse(testFederatedInboxIRI),
+			}},
+			[]Recipient{{
+				ActorIRI: mustParse(testFederatedActorIRI2),
+				InboxIRI: mustParse(testFederatedInboxIRI2),
+			}}).Return(expectRecip, nil)
+		mockFp.EXPECT().MaxDeliveryRecipients(ctx).Return(0)
 		mockDb.EXPECT().Lock(ctx, mustParse(testMyOutboxIRI))
 		mockDb.EXPECT().ActorForOutbox(ctx, mustParse(testMyOutboxIRI)).Return(
 			mustParse(testPersonIRI), nil)
@@ -1514,6 +3056,13 @@ func TestDeliver(t *testing.T) {
 		c.EXPECT().NewTransport(ctx, mustParse(testMyOutboxIRI), goFedUserAgent()).Return(
 			mockTp, nil)
 		mockFp.EXPECT().MaxDeliveryRecursionDepth(ctx).Return(1)
+		mockDb.EXPECT().Lock(ctx, mustParse(testFederatedActorIRI))
+		mockDb.EXPECT().InboxForActor(ctx, mustParse(testFederatedActorIRI)).Return(nil, nil)
+		mockDb.EXPECT().Unlock(ctx, mustParse(testFederatedActorIRI))
+		mockDb.EXPECT().Lock(ctx, mustParse(testFederatedActorIRI2))
+		mockDb.EXPECT().InboxForActor(ctx, mustParse(testFederatedActorIRI2)).Return(nil, nil)
+		mockDb.EXPECT().Unlock(ctx, mustParse(testFederatedActorIRI2))
+		mockFp.EXPECT().MaxDeliveryRecipients(ctx).Return(0)
 		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActorIRI)).Return(
 			mustSerializeToBytes(testFederatedPerson1), nil)
 		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActorIRI2)).Return(
@@ -1532,6 +3081,7 @@ func TestDeliver(t *testing.T) {
 		// Run & Verify
 		err := a.Deliver(ctx, mustParse(testMyOutboxIRI), act)
 		assertEqual(t, err, nil)
+		assertNotEqual(t, act.GetActivityStreamsBto(), nil) // Retained for storage
 	})
 	t.Run("StripsBccOnObject", func(t *testing.T) {
 		// Setup
@@ -1554,6 +3104,13 @@ func TestDeliver(t *testing.T) {
 		c.EXPECT().NewTransport(ctx, mustParse(testMyOutboxIRI), goFedUserAgent()).Return(
 			mockTp, nil)
 		mockFp.EXPECT().MaxDeliveryRecursionDepth(ctx).Return(1)
+		mockDb.EXPECT().Lock(ctx, mustParse(testFederatedActorIRI))
+		mockDb.EXPECT().InboxForActor(ctx, mustParse(testFederatedActorIRI)).Return(nil, nil)
+		mockDb.EXPECT().Unlock(ctx, mustParse(testFederatedActorIRI))
+		mockDb.EXPECT().Lock(ctx, mustParse(testFederatedActorIRI2))
+		mockDb.EXPECT().InboxForActor(ctx, mustParse(testFederatedActorIRI2)).Return(nil, nil)
+		mockDb.EXPECT().Unlock(ctx, mustParse(testFederatedActorIRI2))
+		mockFp.EXPECT().MaxDeliveryRecipients(ctx).Return(0)
 		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActorIRI)).Return(
 			mustSerializeToBytes(testFederatedPerson1), nil)
 		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActorIRI2)).Return(
@@ -1572,6 +3129,7 @@ func TestDeliver(t *testing.T) {
 		// Run & Verify
 		err := a.Deliver(ctx, mustParse(testMyOutboxIRI), act)
 		assertEqual(t, err, nil)
+		assertNotEqual(t, act.GetActivityStreamsBcc(), nil) // Retained for storage
 	})
 	t.Run("DoesNotReturnErrorIfDereferenceRecipientFails", func(t *testing.T) {
 		// Setup
@@ -1591,6 +3149,13 @@ func TestDeliver(t *testing.T) {
 		c.EXPECT().NewTransport(ctx, mustParse(testMyOutboxIRI), goFedUserAgent()).Return(
 			mockTp, nil)
 		mockFp.EXPECT().MaxDeliveryRecursionDepth(ctx).Return(1)
+		mockDb.EXPECT().Lock(ctx, mustParse(testFederatedActorIRI))
+		mockDb.EXPECT().InboxForActor(ctx, mustParse(testFederatedActorIRI)).Return(nil, nil)
+		mockDb.EXPECT().Unlock(ctx, mustParse(testFederatedActorIRI))
+		mockDb.EXPECT().Lock(ctx, mustParse(testFederatedActorIRI2))
+		mockDb.EXPECT().InboxForActor(ctx, mustParse(testFederatedActorIRI2)).Return(nil, nil)
+		mockDb.EXPECT().Unlock(ctx, mustParse(testFederatedActorIRI2))
+		mockFp.EXPECT().MaxDeliveryRecipients(ctx).Return(0)
 		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActorIRI)).Return(
 			[]byte{}, fmt.Errorf("test error"))
 		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActorIRI2)).Return(
@@ -1630,6 +3195,13 @@ func TestDeliver(t *testing.T) {
 		c.EXPECT().NewTransport(ctx, mustParse(testMyOutboxIRI), goFedUserAgent()).Return(
 			mockTp, nil)
 		mockFp.EXPECT().MaxDeliveryRecursionDepth(ctx).Return(1)
+		mockDb.EXPECT().Lock(ctx, mustParse(testFederatedActorIRI))
+		mockDb.EXPECT().InboxForActor(ctx, mustParse(testFederatedActorIRI)).Return(nil, nil)
+		mockDb.EXPECT().Unlock(ctx, mustParse(testFederatedActorIRI))
+		mockDb.EXPECT().Lock(ctx, mustParse(testFederatedActorIRI2))
+		mockDb.EXPECT().InboxForActor(ctx, mustParse(testFederatedActorIRI2)).Return(nil, nil)
+		mockDb.EXPECT().Unlock(ctx, mustParse(testFederatedActorIRI2))
+		mockFp.EXPECT().MaxDeliveryRecipients(ctx).Return(0)
 		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActorIRI)).Return(
 			mustSerializeToBytes(testFederatedPerson1), nil)
 		mockTp.EXPECT().Dereference(ctx, mustParse(testFederatedActorIRI2)).Return(
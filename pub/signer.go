@@ -0,0 +1,208 @@
+package pub
+
+import (
+	"crypto"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/go-fed/httpsig"
+)
+
+const (
+	hostHeaderName   = "host"
+	dateHeaderName   = "date"
+	digestHeaderName = "digest"
+)
+
+var (
+	// MinimalGETHeaders are the headers signed on GET requests that are
+	// accepted by common Fediverse software.
+	MinimalGETHeaders = []string{httpsig.RequestTarget, hostHeaderName, dateHeaderName}
+	// StandardPOSTHeaders are the headers signed on POST requests that are
+	// expected by common Fediverse software. The Digest of the body is
+	// computed when signing.
+	//
+	// Note that headers such as Content-Length are deliberately excluded,
+	// as they may be set or altered after signing by the HTTP client or
+	// intermediaries, which some peers reject.
+	StandardPOSTHeaders = []string{httpsig.RequestTarget, hostHeaderName, dateHeaderName, digestHeaderName}
+)
+
+// NewHttpSigSigner creates a Signer that signs the given headers, for use with
+// a HttpSigTransport. The preferred algorithms, digest algorithm, and scheme
+// are the same as for the github.com/go-fed/httpsig library's NewSigner,
+// returning the algorithm used. The preferred algorithms may also be PS256 or
+// PS512, which the httpsig library does not support.
+//
+// The headers are normalized to lowercase with duplicates removed, and an
+// error is returned if a header is empty. When signing, every header to be
+// signed must be present on the request or response, otherwise an error is
+// returned instead of a signature. The only exceptions are pseudo-headers
+// such as '(request-target)', the 'digest' header which is computed when
+// signing, and the 'host' header of a request, which is set from the request's
+// Host if missing.
+//
+// MinimalGETHeaders and StandardPOSTHeaders are suitable presets.
+func NewHttpSigSigner(prefs []httpsig.Algorithm, dAlgo httpsig.DigestAlgorithm, headers []string, scheme httpsig.SignatureScheme) (httpsig.Signer, httpsig.Algorithm, error) {
+	normalized, err := normalizeSignedHeaders(headers)
+	if err != nil {
+		return nil, "", err
+	}
+	for _, pref := range prefs {
+		if pss, ok := newPSSSigner(pref, normalized, scheme); ok {
+			return &validatingSigner{
+				Signer:  pss,
+				dAlgo:   dAlgo,
+				headers: normalized,
+			}, pref, nil
+		} else if isSupportedAlgorithm(pref) {
+			break
+		}
+	}
+	s, algo, err := httpsig.NewSigner(prefs, dAlgo, normalized, scheme)
+	if err != nil {
+		return nil, "", err
+	}
+	return &validatingSigner{
+		Signer:  s,
+		dAlgo:   dAlgo,
+		headers: normalized,
+	}, algo, nil
+}
+
+// WithoutRequestTargetQuery wraps the Signer so that the '(request-target)' it
+// signs is only the method and path of a request, without its query string.
+//
+// By default the query string is included, as specified by the HTTP Signatures
+// draft and done by most Fediverse software. Some peers verify the
+// '(request-target)' without it, so that signed requests with a query string,
+// such as a GET of a page of a collection, fail to verify for them.
+// HttpSigVerifier accepts either form by default.
+func WithoutRequestTargetQuery(s httpsig.Signer) httpsig.Signer {
+	return &noQuerySigner{Signer: s}
+}
+
+// noQuerySigner signs requests as if they had no query string.
+type noQuerySigner struct {
+	httpsig.Signer
+}
+
+// SignRequest signs a shallow copy of the request without its query string,
+// which shares the headers of the request so that they are set on it.
+func (n *noQuerySigner) SignRequest(pKey crypto.PrivateKey, pubKeyId string, r *http.Request, body []byte) error {
+	return n.Signer.SignRequest(pKey, pubKeyId, withoutQuery(r), body)
+}
+
+// withoutQuery returns a shallow copy of the request without its query
+// string, or the request itself if it has none.
+func withoutQuery(r *http.Request) *http.Request {
+	if len(r.URL.RawQuery) == 0 && !r.URL.ForceQuery {
+		return r
+	}
+	rc := r.WithContext(r.Context())
+	u := *r.URL
+	u.RawQuery = ""
+	u.ForceQuery = false
+	rc.URL = &u
+	return rc
+}
+
+// validatingSigner ensures the headers to be signed are present before
+// signing, and computes the Digest header itself.
+type validatingSigner struct {
+	httpsig.Signer
+	dAlgo   httpsig.DigestAlgorithm
+	headers []string
+}
+
+// SignRequest validates the request's headers, then signs it.
+func (v *validatingSigner) SignRequest(pKey crypto.PrivateKey, pubKeyId string, r *http.Request, body []byte) error {
+	if len(r.Header.Get(hostHeaderName)) == 0 && len(r.Host) > 0 {
+		r.Header.Set(hostHeaderName, r.Host)
+	}
+	if err := validateSignedHeaders(r.Header, v.headers); err != nil {
+		return err
+	}
+	if err := v.ensureDigest(r.Header, body); err != nil {
+		return err
+	}
+	return v.Signer.SignRequest(pKey, pubKeyId, r, nil)
+}
+
+// SignResponse validates the response's headers, then signs it.
+func (v *validatingSigner) SignResponse(pKey crypto.PrivateKey, pubKeyId string, w http.ResponseWriter, body []byte) error {
+	if err := validateSignedHeaders(w.Header(), v.headers); err != nil {
+		return err
+	}
+	if err := v.ensureDigest(w.Header(), body); err != nil {
+		return err
+	}
+	return v.Signer.SignResponse(pKey, pubKeyId, w, nil)
+}
+
+// ensureDigest sets the Digest header of the body if one is given. Otherwise,
+// if the Digest is to be signed but absent, the Digest of an empty body is
+// set, as some verifiers require it even on requests without a body.
+func (v *validatingSigner) ensureDigest(h http.Header, body []byte) error {
+	if body != nil {
+		return v.setDigest(h, body)
+	} else if _, ok := h[digestHeader]; ok || !v.signsDigest() {
+		return nil
+	}
+	return v.setDigest(h, []byte{})
+}
+
+// signsDigest determines whether the Digest header is to be signed.
+func (v *validatingSigner) signsDigest() bool {
+	for _, name := range v.headers {
+		if name == digestHeaderName {
+			return true
+		}
+	}
+	return false
+}
+
+// setDigest sets the Digest header of the body, like the httpsig library
+// does when given a body, refusing to replace an existing Digest.
+func (v *validatingSigner) setDigest(h http.Header, body []byte) error {
+	if _, ok := h[digestHeader]; ok {
+		return fmt.Errorf("cannot add Digest: Digest is already set")
+	}
+	digest, err := digestValue(v.dAlgo, body)
+	if err != nil {
+		return err
+	}
+	h.Set(digestHeader, digest)
+	return nil
+}
+
+// normalizeSignedHeaders lowercases and deduplicates the headers to be signed.
+func normalizeSignedHeaders(headers []string) ([]string, error) {
+	seen := make(map[string]bool, len(headers))
+	normalized := make([]string, 0, len(headers))
+	for _, h := range headers {
+		h = strings.ToLower(strings.TrimSpace(h))
+		if len(h) == 0 {
+			return nil, fmt.Errorf("cannot sign an empty header name")
+		} else if seen[h] {
+			continue
+		}
+		seen[h] = true
+		normalized = append(normalized, h)
+	}
+	return normalized, nil
+}
+
+// validateSignedHeaders ensures every header to be signed is present.
+func validateSignedHeaders(h http.Header, headers []string) error {
+	for _, name := range headers {
+		if strings.HasPrefix(name, "(") || name == digestHeaderName {
+			continue
+		}
+		if _, ok := h[http.CanonicalHeaderKey(name)]; !ok {
+			return fmt.Errorf("cannot sign header %q: absent from the request", name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+package pub
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"net/http"
+	"testing"
+
+	"github.com/go-fed/httpsig"
+)
+
+func TestNewHttpSigSigner(t *testing.T) {
+	k, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body := []byte(`{"type":"Note"}`)
+	getFn := func() *http.Request {
+		r, err := http.NewRequest("GET", testNoteId1, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r.Header.Set("Date", nowDateHeader())
+		return r
+	}
+	postFn := func() *http.Request {
+		r, err := http.NewRequest("POST", testFederatedInboxIRI, bytes.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		r.Header.Set("Date", nowDateHeader())
+		r.Header.Set(contentTypeHeader, contentTypeHeaderValue)
+		return r
+	}
+	tests := []struct {
+		name      string
+		headers   []string
+		req       func() *http.Request
+		body      []byte
+		expectErr bool
+	}{
+		{
+			name:    "MinimalGETOnGET",
+			headers: MinimalGETHeaders,
+			req:     getFn,
+		},
+		{
+			name:    "StandardPOSTOnPOST",
+			headers: StandardPOSTHeaders,
+			req:     postFn,
+			body:    body,
+		},
+		{
+			name:    "MinimalGETOnPOST",
+			headers: MinimalGETHeaders,
+			req:     postFn,
+			body:    body,
+		},
+		{
+			name:    "StandardPOSTWithContentType",
+			headers: append(StandardPOSTHeaders, "content-type"),
+			req:     postFn,
+			body:    body,
+		},
+		{
+			name:    "NormalizesCaseAndDuplicates",
+			headers: []string{"(Request-Target)", "Host", "host", "DATE"},
+			req:     getFn,
+		},
+		{
+			name:      "ErrorIfContentLengthAbsent",
+			headers:   append(StandardPOSTHeaders, "content-length"),
+			req:       postFn,
+			body:      body,
+			expectErr: true,
+		},
+		{
+			name:    "ErrorIfDateAbsent",
+			headers: MinimalGETHeaders,
+			req: func() *http.Request {
+				r := getFn()
+				r.Header.Del("Date")
+				return r
+			},
+			expectErr: true,
+		},
+		{
+			name:      "ErrorIfContentTypeAbsentOnGET",
+			headers:   append(MinimalGETHeaders, "content-type"),
+			req:       getFn,
+			expectErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s, _, err := NewHttpSigSigner([]httpsig.Algorithm{httpsig.RSA_SHA256}, httpsig.DigestSha256, test.headers, httpsig.Signature)
+			assertEqual(t, err, nil)
+			r := test.req()
+			err = s.SignRequest(k, testPubKeyId, r, test.body)
+			if test.expectErr {
+				assertNotEqual(t, err, nil)
+				assertEqual(t, r.Header.Get("Signature"), "")
+				return
+			}
+			assertEqual(t, err, nil)
+			v, err := httpsig.NewVerifier(r)
+			assertEqual(t, err, nil)
+			assertEqual(t, v.Verify(&k.PublicKey, httpsig.RSA_SHA256), nil)
+		})
+	}
+	t.Run("SetsDigestOfEmptyBody", func(t *testing.T) {
+		s, _, err := NewHttpSigSigner([]httpsig.Algorithm{httpsig.RSA_SHA256}, httpsig.DigestSha256, StandardPOSTHeaders, httpsig.Signature)
+		assertEqual(t, err, nil)
+		r, err := http.NewRequest("POST", testFederatedInboxIRI, nil)
+		assertEqual(t, err, nil)
+		r.Header.Set("Date", nowDateHeader())
+		err = s.SignRequest(k, testPubKeyId, r, nil)
+		assertEqual(t, err, nil)
+		assertEqual(t, r.Header.Get(digestHeader), "SHA-256=47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU=")
+		v, err := httpsig.NewVerifier(r)
+		assertEqual(t, err, nil)
+		assertEqual(t, v.Verify(&k.PublicKey, httpsig.RSA_SHA256), nil)
+	})
+	t.Run("KeepsExistingDigestWithoutBody", func(t *testing.T) {
+		s, _, err := NewHttpSigSigner([]httpsig.Algorithm{httpsig.RSA_SHA256}, httpsig.DigestSha256, StandardPOSTHeaders, httpsig.Signature)
+		assertEqual(t, err, nil)
+		r := postFn()
+		digest, err := digestValue(httpsig.DigestSha256, body)
+		assertEqual(t, err, nil)
+		r.Header.Set(digestHeader, digest)
+		err = s.SignRequest(k, testPubKeyId, r, nil)
+		assertEqual(t, err, nil)
+		assertEqual(t, r.Header.Get(digestHeader), digest)
+	})
+	t.Run("ErrorIfEmptyHeaderName", func(t *testing.T) {
+		_, _, err := NewHttpSigSigner([]httpsig.Algorithm{httpsig.RSA_SHA256}, httpsig.DigestSha256, []string{"date", " "}, httpsig.Signature)
+		assertNotEqual(t, err, nil)
+	})
+	t.Run("WithoutRequestTargetQuery", func(t *testing.T) {
+		s, _, err := NewHttpSigSigner([]httpsig.Algorithm{httpsig.RSA_SHA256}, httpsig.DigestSha256, MinimalGETHeaders, httpsig.Signature)
+		assertEqual(t, err, nil)
+		r, err := http.NewRequest("GET", testNoteId1+"?page=2", nil)
+		assertEqual(t, err, nil)
+		r.Header.Set("Date", nowDateHeader())
+		err = WithoutRequestTargetQuery(s).SignRequest(k, testPubKeyId, r, nil)
+		assertEqual(t, err, nil)
+		assertEqual(t, r.URL.RawQuery, "page=2")
+		// The signature only verifies for the path.
+		v, err := httpsig.NewVerifier(r)
+		assertEqual(t, err, nil)
+		assertNotEqual(t, v.Verify(&k.PublicKey, httpsig.RSA_SHA256), nil)
+		r.URL.RawQuery = ""
+		v, err = httpsig.NewVerifier(r)
+		assertEqual(t, err, nil)
+		assertEqual(t, v.Verify(&k.PublicKey, httpsig.RSA_SHA256), nil)
+	})
+}
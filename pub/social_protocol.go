@@ -80,3 +80,54 @@ type SocialProtocol interface {
 	// DefaultCallback.
 	DefaultCallback(c context.Context, activity Activity) error
 }
+
+// TagProcessor may optionally be implemented by a SocialProtocol or a
+// DelegateActor to process the tags of a value posted by a client to an
+// outbox.
+//
+// When not implemented, tags are left as they were posted.
+type TagProcessor interface {
+	// ProcessTags is called during a client's request to the Actor's
+	// outbox, after PostOutboxRequestBodyHook and before the value is
+	// wrapped in a Create, given new ids, or delivered.
+	//
+	// It allows the implementation to resolve the 'href' of Mention tags
+	// to canonical actor IRIs, for example from handles written by the
+	// user, and to address the mentioned actors. The object is the value
+	// as posted by the client: either an activity, or an object that is
+	// later wrapped in a Create copying its addressing. The implementation
+	// modifies it in place.
+	//
+	// Only called if the Social API is enabled.
+	//
+	// If an error is returned, it is passed back to the caller of
+	// PostOutbox. In this case, the implementation must not write a
+	// response to the ResponseWriter as is expected that the caller to
+	// PostOutbox will do so when handling the error.
+	ProcessTags(c context.Context, object vocab.Type) error
+}
+
+// OutboundDecorator may optionally be implemented by a SocialProtocol, a
+// FederatingProtocol, or a DelegateActor to set instance-wide properties on
+// outgoing activities. When both protocols implement it, only the
+// SocialProtocol is called.
+//
+// When not implemented, activities are stored and delivered as they were
+// composed.
+type OutboundDecorator interface {
+	// DecorateOutbound is called for every activity posted to an outbox,
+	// whether by a client or with Send, after AddNewIDs and before the
+	// activity is stored, serialized, or delivered.
+	//
+	// It allows the implementation to set properties uniformly in one
+	// place, such as a 'generator', a 'published' time from the Clock, or
+	// a 'context' of the conversation. The implementation modifies the
+	// activity in place, including any of its embedded objects.
+	//
+	// If an error is returned, it is passed back to the caller of
+	// PostOutbox or Send, and the activity is neither stored nor
+	// delivered. In this case, the implementation must not write a
+	// response to the ResponseWriter as is expected that the caller to
+	// PostOutbox will do so when handling the error.
+	DecorateOutbound(c context.Context, t vocab.Type) error
+}
@@ -461,7 +461,7 @@ func (w SocialWrappedCallbacks) like(c context.Context, a vocab.ActivityStreamsL
 		return err
 	}
 	// WARNING: Unlock not deferred.
-	actorIRI, err := w.db.ActorForOutbox(c, w.outboxIRI)
+	actorIRI, err := actorForOutbox(c, w.db, w.outboxIRI)
 	if err != nil {
 		w.db.Unlock(c, w.outboxIRI)
 		return err
@@ -4,12 +4,15 @@ import (
 	"bytes"
 	"context"
 	"crypto"
+	"encoding/base64"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/go-fed/httpsig"
 )
@@ -28,6 +31,27 @@ func isSuccess(code int) bool {
 		code == http.StatusAccepted
 }
 
+// StatusError is returned by a HttpSigTransport when a peer responds to a
+// request with an unsuccessful HTTP status code.
+//
+// Applications may use the StatusCode to respond appropriately, for example
+// to a 410 Gone from a deleted actor.
+type StatusError struct {
+	// Method is the method of the request, such as GET or POST.
+	Method string
+	// IRI is the IRI the request was sent to.
+	IRI *url.URL
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+	// Status is the HTTP status of the response, such as "410 Gone".
+	Status string
+}
+
+// Error returns the request and the status of its response.
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%s request to %s failed (%d): %s", e.Method, e.IRI.String(), e.StatusCode, e.Status)
+}
+
 // Transport makes ActivityStreams calls to other servers in order to send or
 // receive ActivityStreams data.
 //
@@ -55,7 +79,7 @@ var _ Transport = &HttpSigTransport{}
 // HttpSigTransport makes a dereference call using HTTP signatures to
 // authenticate the request on behalf of a particular actor.
 //
-// No rate limiting is applied.
+// No rate limiting is applied unless a RateLimiter is provided.
 //
 // Only one request is tried per call.
 type HttpSigTransport struct {
@@ -69,6 +93,174 @@ type HttpSigTransport struct {
 	postSignerMu *sync.Mutex
 	pubKeyId     string
 	privKey      crypto.PrivateKey
+	modifier     RequestModifier
+	userAgent    string
+	limiter      RateLimiter
+	getPolicy    GETSignPolicy
+	getPrefs     []httpsig.Algorithm
+	getSigners   *getSignerCache
+	chunkSize    int
+	keyProvider  KeyProvider
+	logger       Logger
+	timeout      time.Duration
+	transform    OutboundJSONTransform
+}
+
+// RequestModifier alters an outgoing request before it is signed, for example
+// to rewrite the Host when delivering through a reverse proxy or egress
+// gateway, or to add tracing headers. Returning an error aborts the request.
+//
+// It is called after the request and its headers are built, but before the
+// HTTP Signature is computed. Any modification made to the request after it
+// is signed would invalidate the signature, so this is the last opportunity
+// to alter the request.
+type RequestModifier func(r *http.Request) error
+
+// OutboundJSONTransform alters the JSON body of a POST request to the host
+// before its Digest is computed and it is signed, for example to work around
+// a peer requiring a specific '@context' order. Returning an error aborts the
+// request.
+//
+// The body is shared by the deliveries to every recipient of a BatchDeliver,
+// some of which run concurrently, so it must not be modified in place.
+// Return a new slice instead.
+type OutboundJSONTransform func(host string, body []byte) ([]byte, error)
+
+// HttpSigTransportOption configures optional behavior of a HttpSigTransport.
+type HttpSigTransportOption func(h *HttpSigTransport)
+
+// WithRequestModifier applies the RequestModifier to every request made by the
+// HttpSigTransport, before it is signed.
+func WithRequestModifier(m RequestModifier) HttpSigTransportOption {
+	return func(h *HttpSigTransport) {
+		h.modifier = m
+	}
+}
+
+// WithUserAgent sets the entire User-Agent header of every request made by the
+// HttpSigTransport, replacing the default combining the appAgent and go-fed's
+// agent. It is meant for presenting the software's name and version to peers.
+func WithUserAgent(userAgent string) HttpSigTransportOption {
+	return func(h *HttpSigTransport) {
+		h.userAgent = userAgent
+	}
+}
+
+// WithRateLimiter applies the RateLimiter to every request made by the
+// HttpSigTransport, keyed by the hostname of the request. When a host's limit
+// is reached, requests block until permitted or until their context is done,
+// and are not dropped.
+func WithRateLimiter(l RateLimiter) HttpSigTransportOption {
+	return func(h *HttpSigTransport) {
+		h.limiter = l
+	}
+}
+
+// GETSignPolicy determines how a GET request to the host is signed, for peers
+// that only accept particular signed headers or mishandle signed GET requests.
+//
+// When sign is false, the request is sent without an HTTP Signature. When
+// sign is true and headers is empty, the request is signed by the
+// HttpSigTransport's GET Signer. Otherwise, the headers are signed instead.
+type GETSignPolicy func(host string) (sign bool, headers []string)
+
+// WithGETSignPolicy applies the GETSignPolicy to every GET request made by the
+// HttpSigTransport. Signers for the headers returned by the policy are created
+// with NewHttpSigSigner using the preferred algorithms, which must be
+// compatible with the private key.
+func WithGETSignPolicy(p GETSignPolicy, prefs []httpsig.Algorithm) HttpSigTransportOption {
+	return func(h *HttpSigTransport) {
+		h.getPolicy = p
+		h.getPrefs = prefs
+	}
+}
+
+// KeyProvider returns the public key id and the private key to sign a request
+// with, for the actor sending it. It lets a single HttpSigTransport sign on
+// behalf of different actors, for example by looking up the actor placed on
+// the context by the application.
+type KeyProvider func(c context.Context) (pubKeyId string, privKey crypto.PrivateKey, err error)
+
+// WithKeyProvider signs every request made by the HttpSigTransport with the
+// key returned by the KeyProvider for the request's context, instead of the
+// key it was created with. Returning an error aborts the request.
+func WithKeyProvider(p KeyProvider) HttpSigTransportOption {
+	return func(h *HttpSigTransport) {
+		h.keyProvider = p
+	}
+}
+
+// BatchDeliverProgressFunc is called by a HttpSigTransport after each chunk of
+// a BatchDeliverWithProgress, with the number of recipients delivered to so far, the total
+// number of recipients, and the error of the last chunk, if any.
+//
+// It is called in the goroutine doing the delivery, so the next chunk is not
+// delivered until it returns. It must return quickly, for example by
+// recording the progress or handing it off to another goroutine.
+type BatchDeliverProgressFunc func(delivered, total int, lastErr error)
+
+// WithBatchDeliverChunks makes BatchDeliver deliver to the recipients in
+// chunks of the size, one chunk after another. The requests within a chunk
+// are sent concurrently. BatchDeliverWithProgress reports the progress after
+// each chunk.
+//
+// The recipients are delivered to in the order of SortRecipients, so the
+// number delivered is an offset into the sorted recipients. Applications
+// durably recording it may resume an interrupted delivery by sorting the
+// recipients and delivering to those past the offset, or use
+// ResumeBatchDeliver, which does so with an opaque token.
+func WithBatchDeliverChunks(size int) HttpSigTransportOption {
+	return func(h *HttpSigTransport) {
+		h.chunkSize = size
+	}
+}
+
+// WithPerRequestTimeout limits each HTTP request made by the HttpSigTransport
+// to the duration, so that a slow peer fails its request quickly instead of
+// holding up a delivery. The request is given a context derived from the one
+// passed to the HttpSigTransport, which continues to bound the whole
+// operation: waiting for the RateLimiter, every request of a BatchDeliver,
+// and any retries scheduled by the application.
+func WithPerRequestTimeout(d time.Duration) HttpSigTransportOption {
+	return func(h *HttpSigTransport) {
+		h.timeout = d
+	}
+}
+
+// WithOutboundJSONTransform applies the OutboundJSONTransform to the body of
+// every request delivered by the HttpSigTransport. Without it, bodies are sent
+// as they are given.
+func WithOutboundJSONTransform(t OutboundJSONTransform) HttpSigTransportOption {
+	return func(h *HttpSigTransport) {
+		h.transform = t
+	}
+}
+
+// WithTransportLogger logs the outcome of each delivery made by the
+// HttpSigTransport to the Logger.
+func WithTransportLogger(l Logger) HttpSigTransportOption {
+	return func(h *HttpSigTransport) {
+		h.logger = l
+	}
+}
+
+// SortRecipients returns a copy of the recipients sorted by IRI, in the order
+// a HttpSigTransport delivers to them in chunks.
+func SortRecipients(recipients []*url.URL) []*url.URL {
+	sorted := make([]*url.URL, len(recipients))
+	copy(sorted, recipients)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].String() < sorted[j].String()
+	})
+	return sorted
+}
+
+// getSignerCache holds the Signers created for the headers returned by a
+// GETSignPolicy. Signers are not safe for concurrent use, so they are used
+// while holding the lock.
+type getSignerCache struct {
+	mu      sync.Mutex
+	signers map[string]httpsig.Signer
 }
 
 // NewHttpSigTransport returns a new Transport.
@@ -86,14 +278,18 @@ type HttpSigTransport struct {
 // agent string will also include one for go-fed, so at minimum peer servers can
 // reach out to the go-fed library to aid in notifying implementors of malformed
 // or unsupported requests.
+//
+// Additional options, such as a RequestModifier, a User-Agent, a RateLimiter,
+// a GETSignPolicy, delivery in chunks, or a KeyProvider, may be provided.
 func NewHttpSigTransport(
 	client HttpClient,
 	appAgent string,
 	clock Clock,
 	getSigner, postSigner httpsig.Signer,
 	pubKeyId string,
-	privKey crypto.PrivateKey) *HttpSigTransport {
-	return &HttpSigTransport{
+	privKey crypto.PrivateKey,
+	opts ...HttpSigTransportOption) *HttpSigTransport {
+	h := &HttpSigTransport{
 		client:       client,
 		appAgent:     appAgent,
 		gofedAgent:   goFedUserAgent(),
@@ -104,26 +300,105 @@ func NewHttpSigTransport(
 		postSignerMu: &sync.Mutex{},
 		pubKeyId:     pubKeyId,
 		privKey:      privKey,
+		getSigners:   &getSignerCache{signers: make(map[string]httpsig.Signer)},
+	}
+	h.userAgent = fmt.Sprintf("%s %s", appAgent, h.gofedAgent)
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// wait blocks until the RateLimiter, if any, permits a request to the IRI's
+// host.
+func (h HttpSigTransport) wait(c context.Context, iri *url.URL) error {
+	if h.limiter == nil {
+		return nil
+	}
+	return h.limiter.Wait(c, iri.Hostname())
+}
+
+// requestContext derives the context of a single request from the context of
+// the operation, limited by the per-request timeout if any.
+func (h HttpSigTransport) requestContext(c context.Context) (context.Context, context.CancelFunc) {
+	if h.timeout <= 0 {
+		return c, func() {}
+	}
+	return context.WithTimeout(c, h.timeout)
+}
+
+// signingKey returns the public key id and private key to sign a request with,
+// from the KeyProvider if any.
+func (h HttpSigTransport) signingKey(c context.Context) (string, crypto.PrivateKey, error) {
+	if h.keyProvider == nil {
+		return h.pubKeyId, h.privKey, nil
+	}
+	return h.keyProvider(c)
+}
+
+// modifyRequest applies the RequestModifier, if any, to the request.
+func (h HttpSigTransport) modifyRequest(req *http.Request) error {
+	if h.modifier == nil {
+		return nil
 	}
+	return h.modifier(req)
+}
+
+// signGET signs the GET request to the host as determined by the
+// GETSignPolicy, if any.
+func (h HttpSigTransport) signGET(c context.Context, req *http.Request, host string) error {
+	sign, headers := true, []string(nil)
+	if h.getPolicy != nil {
+		sign, headers = h.getPolicy(host)
+	}
+	if !sign {
+		return nil
+	}
+	pubKeyId, privKey, err := h.signingKey(c)
+	if err != nil {
+		return err
+	}
+	if len(headers) == 0 {
+		h.getSignerMu.Lock()
+		defer h.getSignerMu.Unlock()
+		return h.getSigner.SignRequest(privKey, pubKeyId, req, nil)
+	}
+	key := strings.ToLower(strings.Join(headers, " "))
+	h.getSigners.mu.Lock()
+	defer h.getSigners.mu.Unlock()
+	s, ok := h.getSigners.signers[key]
+	if !ok {
+		s, _, err = NewHttpSigSigner(h.getPrefs, httpsig.DigestSha256, headers, httpsig.Signature)
+		if err != nil {
+			return err
+		}
+		h.getSigners.signers[key] = s
+	}
+	return s.SignRequest(privKey, pubKeyId, req, nil)
 }
 
 // Dereference sends a GET request signed with an HTTP Signature to obtain an
-// ActivityStreams value.
+// ActivityStreams value, unless the GETSignPolicy determines otherwise.
 func (h HttpSigTransport) Dereference(c context.Context, iri *url.URL) ([]byte, error) {
+	if err := h.wait(c, iri); err != nil {
+		return nil, err
+	}
 	req, err := http.NewRequest("GET", iri.String(), nil)
 	if err != nil {
 		return nil, err
 	}
-	req = req.WithContext(c)
+	rc, cancel := h.requestContext(c)
+	defer cancel()
+	req = req.WithContext(rc)
 	req.Header.Add(acceptHeader, acceptHeaderValue)
 	req.Header.Add("Accept-Charset", "utf-8")
 	req.Header.Add("Date", h.clock.Now().UTC().Format("Mon, 02 Jan 2006 15:04:05")+" GMT")
-	req.Header.Add("User-Agent", fmt.Sprintf("%s %s", h.appAgent, h.gofedAgent))
+	req.Header.Add("User-Agent", h.userAgent)
 	req.Header.Set("Host", iri.Host)
-	h.getSignerMu.Lock()
-	err = h.getSigner.SignRequest(h.privKey, h.pubKeyId, req, nil)
-	h.getSignerMu.Unlock()
-	if err != nil {
+	if err = h.modifyRequest(req); err != nil {
+		return nil, err
+	}
+	if err = h.signGET(c, req, iri.Host); err != nil {
 		return nil, err
 	}
 	resp, err := h.client.Do(req)
@@ -132,25 +407,66 @@ func (h HttpSigTransport) Dereference(c context.Context, iri *url.URL) ([]byte,
 	}
 	defer resp.Body.Close()
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("GET request to %s failed (%d): %s", iri.String(), resp.StatusCode, resp.Status)
+		return nil, &StatusError{
+			Method:     "GET",
+			IRI:        iri,
+			StatusCode: resp.StatusCode,
+			Status:     resp.Status,
+		}
 	}
 	return ioutil.ReadAll(resp.Body)
 }
 
 // Deliver sends a POST request with an HTTP Signature.
 func (h HttpSigTransport) Deliver(c context.Context, b []byte, to *url.URL) error {
+	err := h.deliver(c, b, to)
+	if h.logger != nil {
+		if err != nil {
+			h.logger.Debug("delivery failed", "inbox", to, "err", err)
+		} else {
+			h.logger.Debug("delivered", "inbox", to)
+		}
+	}
+	return err
+}
+
+// deliver sends the POST request.
+func (h HttpSigTransport) deliver(c context.Context, b []byte, to *url.URL) error {
+	if err := h.wait(c, to); err != nil {
+		return err
+	}
+	if h.transform != nil {
+		var err error
+		if b, err = h.transform(to.Host, b); err != nil {
+			return err
+		}
+	}
 	req, err := http.NewRequest("POST", to.String(), bytes.NewReader(b))
 	if err != nil {
 		return err
 	}
-	req = req.WithContext(c)
+	rc, cancel := h.requestContext(c)
+	defer cancel()
+	req = req.WithContext(rc)
 	req.Header.Add(contentTypeHeader, contentTypeHeaderValue)
 	req.Header.Add("Accept-Charset", "utf-8")
 	req.Header.Add("Date", h.clock.Now().UTC().Format("Mon, 02 Jan 2006 15:04:05")+" GMT")
-	req.Header.Add("User-Agent", fmt.Sprintf("%s %s", h.appAgent, h.gofedAgent))
+	req.Header.Add("User-Agent", h.userAgent)
 	req.Header.Set("Host", to.Host)
+	if err = h.modifyRequest(req); err != nil {
+		return err
+	}
+	digest, err := digestValue(httpsig.DigestSha256, b)
+	if err != nil {
+		return err
+	}
+	req.Header.Set(digestHeader, digest)
+	pubKeyId, privKey, err := h.signingKey(c)
+	if err != nil {
+		return err
+	}
 	h.postSignerMu.Lock()
-	err = h.postSigner.SignRequest(h.privKey, h.pubKeyId, req, b)
+	err = h.postSigner.SignRequest(privKey, pubKeyId, req, nil)
 	h.postSignerMu.Unlock()
 	if err != nil {
 		return err
@@ -161,14 +477,116 @@ func (h HttpSigTransport) Deliver(c context.Context, b []byte, to *url.URL) erro
 	}
 	defer resp.Body.Close()
 	if !isSuccess(resp.StatusCode) {
-		return fmt.Errorf("POST request to %s failed (%d): %s", to.String(), resp.StatusCode, resp.Status)
+		return &StatusError{
+			Method:     "POST",
+			IRI:        to,
+			StatusCode: resp.StatusCode,
+			Status:     resp.Status,
+		}
 	}
 	return nil
 }
 
 // BatchDeliver sends concurrent POST requests. Returns an error if any of the
 // requests had an error.
+//
+// If chunks are configured with WithBatchDeliverChunks, the recipients are
+// sorted and delivered to one chunk at a time.
 func (h HttpSigTransport) BatchDeliver(c context.Context, b []byte, recipients []*url.URL) error {
+	return h.BatchDeliverWithProgress(c, b, recipients, nil)
+}
+
+// BatchDeliverWithProgress is like BatchDeliver, but calls progress after
+// each chunk configured with WithBatchDeliverChunks, so each delivery may
+// report to its own job. Without chunks, progress is not called. It may be
+// nil.
+func (h HttpSigTransport) BatchDeliverWithProgress(c context.Context, b []byte, recipients []*url.URL, progress BatchDeliverProgressFunc) error {
+	if h.chunkSize <= 0 {
+		return batchDeliverError(h.deliverChunk(c, b, recipients))
+	}
+	sorted := SortRecipients(recipients)
+	var errs []string
+	for i := 0; i < len(sorted); i += h.chunkSize {
+		end := i + h.chunkSize
+		if end > len(sorted) {
+			end = len(sorted)
+		}
+		chunkErrs := h.deliverChunk(c, b, sorted[i:end])
+		errs = append(errs, chunkErrs...)
+		if progress != nil {
+			progress(end, len(sorted), batchDeliverError(chunkErrs))
+		}
+	}
+	return batchDeliverError(errs)
+}
+
+// ResumeBatchDeliver is like BatchDeliver, but delivers to the recipients in
+// the order of SortRecipients and stops when the context is done, returning a
+// token from which a later call resumes the delivery. It lets applications
+// durably checkpoint large deliveries, and finish them after a restart
+// without delivering to the same recipients again.
+//
+// An empty token starts from the first recipient. If the context is done, its
+// error is returned with the token resuming the delivery. Otherwise every
+// recipient was attempted, the returned token is empty, and the error
+// combines those of the requests that failed, which are not attempted again.
+//
+// The token is opaque, and records the last recipient attempted rather than
+// an offset, so recipients may be added or removed between calls. A chunk
+// interrupted by the context is attempted again in full when resumed.
+// Without WithBatchDeliverChunks, all recipients form a single chunk.
+//
+// The progress function, which may be nil, is called after each chunk as
+// with BatchDeliverWithProgress.
+func (h HttpSigTransport) ResumeBatchDeliver(c context.Context, b []byte, recipients []*url.URL, token string, progress BatchDeliverProgressFunc) (next string, err error) {
+	sorted := SortRecipients(recipients)
+	start := 0
+	if len(token) > 0 {
+		last, err := base64.RawURLEncoding.DecodeString(token)
+		if err != nil {
+			return token, fmt.Errorf("invalid batch deliver resume token: %s", err)
+		}
+		start = sort.Search(len(sorted), func(i int) bool {
+			return sorted[i].String() > string(last)
+		})
+	}
+	size := h.chunkSize
+	if size <= 0 {
+		size = len(sorted)
+	}
+	var errs []string
+	for i := start; i < len(sorted); i += size {
+		if err = c.Err(); err != nil {
+			return resumeToken(sorted, i), err
+		}
+		end := i + size
+		if end > len(sorted) {
+			end = len(sorted)
+		}
+		chunkErrs := h.deliverChunk(c, b, sorted[i:end])
+		if err = c.Err(); err != nil {
+			return resumeToken(sorted, i), err
+		}
+		errs = append(errs, chunkErrs...)
+		if progress != nil {
+			progress(end, len(sorted), batchDeliverError(chunkErrs))
+		}
+	}
+	return "", batchDeliverError(errs)
+}
+
+// resumeToken returns the token resuming a ResumeBatchDeliver from the sorted
+// recipient at the offset.
+func resumeToken(sorted []*url.URL, offset int) string {
+	if offset == 0 {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString([]byte(sorted[offset-1].String()))
+}
+
+// deliverChunk sends concurrent POST requests to the recipients, returning
+// the errors of the requests that failed.
+func (h HttpSigTransport) deliverChunk(c context.Context, b []byte, recipients []*url.URL) []string {
 	var wg sync.WaitGroup
 	errCh := make(chan error, len(recipients))
 	for _, recipient := range recipients {
@@ -191,6 +609,11 @@ outer:
 			break outer
 		}
 	}
+	return errs
+}
+
+// batchDeliverError combines the errors of a BatchDeliver, if any.
+func batchDeliverError(errs []string) error {
 	if len(errs) > 0 {
 		return fmt.Errorf("batch deliver had at least one failure: %s", strings.Join(errs, "; "))
 	}
@@ -2,12 +2,22 @@ package pub
 
 import (
 	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/go-fed/httpsig"
 	"github.com/golang/mock/gomock"
 )
 
@@ -66,6 +76,7 @@ func TestHttpSigTransportDereference(t *testing.T) {
 		expectReq.Header.Add("Accept-Charset", "utf-8")
 		expectReq.Header.Add("Date", nowDateHeader())
 		expectReq.Header.Add("User-Agent", fmt.Sprintf("%s %s", testAppAgent, goFedUserAgent()))
+		expectReq.Header.Set("Host", mustParse(testNoteId1).Host)
 		respR := httptest.NewRecorder()
 		respR.Write(testRespBody)
 		resp := respR.Result()
@@ -80,6 +91,183 @@ func TestHttpSigTransportDereference(t *testing.T) {
 	})
 }
 
+func TestHttpSigTransportRequestModifier(t *testing.T) {
+	ctx := context.Background()
+	setupFn := func(ctl *gomock.Controller, m RequestModifier) (t *HttpSigTransport, c *MockClock, hc *MockHttpClient, gs, ps *MockSigner) {
+		c = NewMockClock(ctl)
+		hc = NewMockHttpClient(ctl)
+		gs = NewMockSigner(ctl)
+		ps = NewMockSigner(ctl)
+		t = NewHttpSigTransport(
+			hc,
+			testAppAgent,
+			c,
+			gs,
+			ps,
+			testPubKeyId,
+			testPrivKey,
+			WithRequestModifier(m))
+		return
+	}
+	proxyModifier := func(r *http.Request) error {
+		r.Host = "proxy.example.com"
+		r.Header.Set("Host", r.Host)
+		r.Header.Set("X-Trace", "trace")
+		return nil
+	}
+	t.Run("ModifiesDereferenceBeforeSigning", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		tp, c, hc, gs, _ := setupFn(ctl, proxyModifier)
+		expectReq, err := http.NewRequest("GET", testNoteId1, nil)
+		assertEqual(t, err, nil)
+		expectReq = expectReq.WithContext(ctx)
+		expectReq.Header.Add(acceptHeader, acceptHeaderValue)
+		expectReq.Header.Add("Accept-Charset", "utf-8")
+		expectReq.Header.Add("Date", nowDateHeader())
+		expectReq.Header.Add("User-Agent", fmt.Sprintf("%s %s", testAppAgent, goFedUserAgent()))
+		proxyModifier(expectReq)
+		respR := httptest.NewRecorder()
+		respR.Write(testRespBody)
+		resp := respR.Result()
+		// Mock
+		c.EXPECT().Now().Return(now())
+		gs.EXPECT().SignRequest(testPrivKey, testPubKeyId, expectReq, nil)
+		hc.EXPECT().Do(expectReq).Return(resp, nil)
+		// Run & Verify
+		b, err := tp.Dereference(ctx, mustParse(testNoteId1))
+		assertByteEqual(t, b, testRespBody)
+		assertEqual(t, err, nil)
+	})
+	t.Run("ModifiesDeliverBeforeSigning", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		tp, c, hc, _, ps := setupFn(ctl, proxyModifier)
+		respR := httptest.NewRecorder()
+		respR.WriteHeader(http.StatusOK)
+		resp := respR.Result()
+		// Mock
+		c.EXPECT().Now().Return(now())
+		ps.EXPECT().SignRequest(testPrivKey, testPubKeyId, gomock.Any(), nil).Do(
+			func(pKey interface{}, pubKeyId string, r *http.Request, body []byte) {
+				assertEqual(t, r.Host, "proxy.example.com")
+				assertEqual(t, r.Header.Get("X-Trace"), "trace")
+			})
+		hc.EXPECT().Do(gomock.Any()).Return(resp, nil)
+		// Run & Verify
+		err := tp.Deliver(ctx, testRespBody, mustParse(testFederatedActorIRI))
+		assertEqual(t, err, nil)
+	})
+	t.Run("ReturnsErrorIfModifierFails", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		testErr := fmt.Errorf("test error")
+		tp, c, _, _, _ := setupFn(ctl, func(r *http.Request) error {
+			return testErr
+		})
+		// Mock
+		c.EXPECT().Now().Return(now()).Times(2)
+		// Run & Verify
+		_, err := tp.Dereference(ctx, mustParse(testNoteId1))
+		assertEqual(t, err, testErr)
+		err = tp.Deliver(ctx, testRespBody, mustParse(testFederatedActorIRI))
+		assertEqual(t, err, testErr)
+	})
+}
+
+// recordingRateLimiter records the hosts it is waited upon for.
+type recordingRateLimiter struct {
+	hosts []string
+	err   error
+}
+
+func (r *recordingRateLimiter) Wait(c context.Context, host string) error {
+	r.hosts = append(r.hosts, host)
+	return r.err
+}
+
+func TestHttpSigTransportUserAgentAndRateLimiter(t *testing.T) {
+	ctx := context.Background()
+	const userAgent = "MyServer/1.2.3 (+https://example.com)"
+	setupFn := func(ctl *gomock.Controller, l RateLimiter) (t *HttpSigTransport, c *MockClock, hc *MockHttpClient, gs, ps *MockSigner) {
+		c = NewMockClock(ctl)
+		hc = NewMockHttpClient(ctl)
+		gs = NewMockSigner(ctl)
+		ps = NewMockSigner(ctl)
+		t = NewHttpSigTransport(
+			hc,
+			testAppAgent,
+			c,
+			gs,
+			ps,
+			testPubKeyId,
+			testPrivKey,
+			WithUserAgent(userAgent),
+			WithRateLimiter(l))
+		return
+	}
+	t.Run("DereferenceUsesUserAgentAndWaits", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		l := &recordingRateLimiter{}
+		tp, c, hc, gs, _ := setupFn(ctl, l)
+		respR := httptest.NewRecorder()
+		respR.Write(testRespBody)
+		resp := respR.Result()
+		// Mock
+		c.EXPECT().Now().Return(now())
+		gs.EXPECT().SignRequest(testPrivKey, testPubKeyId, gomock.Any(), nil).Do(
+			func(pKey interface{}, pubKeyId string, r *http.Request, body []byte) {
+				assertEqual(t, r.Header.Get("User-Agent"), userAgent)
+			})
+		hc.EXPECT().Do(gomock.Any()).Return(resp, nil)
+		// Run & Verify
+		b, err := tp.Dereference(ctx, mustParse(testNoteId1))
+		assertByteEqual(t, b, testRespBody)
+		assertEqual(t, err, nil)
+		assertEqual(t, len(l.hosts), 1)
+		assertEqual(t, l.hosts[0], "example.com")
+	})
+	t.Run("DeliverUsesUserAgentAndWaits", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		l := &recordingRateLimiter{}
+		tp, c, hc, _, ps := setupFn(ctl, l)
+		respR := httptest.NewRecorder()
+		respR.WriteHeader(http.StatusOK)
+		resp := respR.Result()
+		// Mock
+		c.EXPECT().Now().Return(now())
+		ps.EXPECT().SignRequest(testPrivKey, testPubKeyId, gomock.Any(), nil).Do(
+			func(pKey interface{}, pubKeyId string, r *http.Request, body []byte) {
+				assertEqual(t, r.Header.Get("User-Agent"), userAgent)
+			})
+		hc.EXPECT().Do(gomock.Any()).Return(resp, nil)
+		// Run & Verify
+		err := tp.Deliver(ctx, testRespBody, mustParse("https://other.example.com:8443/inbox"))
+		assertEqual(t, err, nil)
+		assertEqual(t, len(l.hosts), 1)
+		assertEqual(t, l.hosts[0], "other.example.com")
+	})
+	t.Run("ReturnsErrorIfWaitFails", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		l := &recordingRateLimiter{err: context.Canceled}
+		tp, _, _, _, _ := setupFn(ctl, l)
+		// Run & Verify
+		_, err := tp.Dereference(ctx, mustParse(testNoteId1))
+		assertEqual(t, err, context.Canceled)
+		err = tp.Deliver(ctx, testRespBody, mustParse(testFederatedActorIRI))
+		assertEqual(t, err, context.Canceled)
+	})
+}
+
 func TestHttpSigTransportDeliver(t *testing.T) {
 	ctx := context.Background()
 	t.Run("ReturnsErrorWhenHTTPStatusError", func(t *testing.T) {
@@ -108,7 +296,27 @@ func TestHttpSigTransportDeliver(t *testing.T) {
 		resp := respR.Result()
 		// Mock
 		c.EXPECT().Now().Return(now())
-		ps.EXPECT().SignRequest(testPrivKey, testPubKeyId, gomock.Any(), testRespBody)
+		ps.EXPECT().SignRequest(testPrivKey, testPubKeyId, gomock.Any(), nil)
+		hc.EXPECT().Do(gomock.Any()).Return(resp, nil)
+		// Run & Verify
+		err := tp.Deliver(ctx, testRespBody, mustParse(testFederatedActorIRI))
+		assertEqual(t, err, nil)
+	})
+	t.Run("SetsDigestOfBody", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		tp, c, hc, _, ps := httpSigSetupFn(ctl)
+		respR := httptest.NewRecorder()
+		respR.WriteHeader(http.StatusOK)
+		resp := respR.Result()
+		hashed := sha256.Sum256(testRespBody)
+		// Mock
+		c.EXPECT().Now().Return(now())
+		ps.EXPECT().SignRequest(testPrivKey, testPubKeyId, gomock.Any(), nil).Do(
+			func(pKey interface{}, pubKeyId string, r *http.Request, body []byte) {
+				assertEqual(t, r.Header.Get(digestHeader), "SHA-256="+base64.StdEncoding.EncodeToString(hashed[:]))
+			})
 		hc.EXPECT().Do(gomock.Any()).Return(resp, nil)
 		// Run & Verify
 		err := tp.Deliver(ctx, testRespBody, mustParse(testFederatedActorIRI))
@@ -116,6 +324,69 @@ func TestHttpSigTransportDeliver(t *testing.T) {
 	})
 }
 
+func TestHttpSigTransportOutboundJSONTransform(t *testing.T) {
+	ctx := context.Background()
+	transformed := []byte(`{"transformed":true}`)
+	setupFn := func(ctl *gomock.Controller, tf OutboundJSONTransform) (t *HttpSigTransport, c *MockClock, hc *MockHttpClient, ps *MockSigner) {
+		c = NewMockClock(ctl)
+		hc = NewMockHttpClient(ctl)
+		ps = NewMockSigner(ctl)
+		t = NewHttpSigTransport(
+			hc,
+			testAppAgent,
+			c,
+			NewMockSigner(ctl),
+			ps,
+			testPubKeyId,
+			testPrivKey,
+			WithOutboundJSONTransform(tf))
+		return
+	}
+	t.Run("SignsDigestOfTransformedBody", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		var gotHost string
+		tp, c, hc, ps := setupFn(ctl, func(host string, body []byte) ([]byte, error) {
+			gotHost = host
+			assertByteEqual(t, body, testRespBody)
+			return transformed, nil
+		})
+		respR := httptest.NewRecorder()
+		respR.WriteHeader(http.StatusOK)
+		resp := respR.Result()
+		hashed := sha256.Sum256(transformed)
+		// Mock
+		c.EXPECT().Now().Return(now())
+		ps.EXPECT().SignRequest(testPrivKey, testPubKeyId, gomock.Any(), nil).Do(
+			func(pKey interface{}, pubKeyId string, r *http.Request, body []byte) {
+				assertEqual(t, r.Header.Get(digestHeader), "SHA-256="+base64.StdEncoding.EncodeToString(hashed[:]))
+			})
+		hc.EXPECT().Do(gomock.Any()).DoAndReturn(func(r *http.Request) (*http.Response, error) {
+			b, err := ioutil.ReadAll(r.Body)
+			assertEqual(t, err, nil)
+			assertByteEqual(t, b, transformed)
+			return resp, nil
+		})
+		// Run & Verify
+		err := tp.Deliver(ctx, testRespBody, mustParse(testFederatedActorIRI))
+		assertEqual(t, err, nil)
+		assertEqual(t, gotHost, mustParse(testFederatedActorIRI).Host)
+	})
+	t.Run("ReturnsErrorIfTransformFails", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		testErr := fmt.Errorf("test error")
+		tp, _, _, _ := setupFn(ctl, func(host string, body []byte) ([]byte, error) {
+			return nil, testErr
+		})
+		// Run & Verify
+		err := tp.Deliver(ctx, testRespBody, mustParse(testFederatedActorIRI))
+		assertEqual(t, err, testErr)
+	})
+}
+
 func TestHttpSigTransportBatchDeliver(t *testing.T) {
 	ctx := context.Background()
 	t.Run("BatchDelivers", func(t *testing.T) {
@@ -129,7 +400,7 @@ func TestHttpSigTransportBatchDeliver(t *testing.T) {
 		resp := respR.Result()
 		// Mock
 		c.EXPECT().Now().Return(now()).Times(2)
-		ps.EXPECT().SignRequest(testPrivKey, testPubKeyId, gomock.Any(), testRespBody).Times(2)
+		ps.EXPECT().SignRequest(testPrivKey, testPubKeyId, gomock.Any(), nil).Times(2)
 		hc.EXPECT().Do(gomock.Any()).Return(resp, nil).Times(2)
 		// Run & Verify
 		err := tp.BatchDeliver(ctx, testRespBody, []*url.URL{mustParse(testFederatedActorIRI), mustParse(testFederatedActorIRI2)})
@@ -148,7 +419,7 @@ func TestHttpSigTransportBatchDeliver(t *testing.T) {
 		testErr := fmt.Errorf("test error")
 		// Mock
 		c.EXPECT().Now().Return(now()).Times(2)
-		ps.EXPECT().SignRequest(testPrivKey, testPubKeyId, gomock.Any(), testRespBody).Times(2)
+		ps.EXPECT().SignRequest(testPrivKey, testPubKeyId, gomock.Any(), nil).Times(2)
 		first := hc.EXPECT().Do(gomock.Any()).Return(resp, nil)
 		hc.EXPECT().Do(gomock.Any()).Return(errResp, testErr).After(first)
 		// Run & Verify
@@ -157,3 +428,480 @@ func TestHttpSigTransportBatchDeliver(t *testing.T) {
 
 	})
 }
+
+func TestHttpSigTransportBatchDeliverChunks(t *testing.T) {
+	ctx := context.Background()
+	recipients := []*url.URL{
+		mustParse(testFederatedActorIRI3),
+		mustParse(testFederatedActorIRI),
+		mustParse(testFederatedActorIRI2),
+	}
+	type progress struct {
+		delivered, total int
+		err              error
+	}
+	setupFn := func(ctl *gomock.Controller, size int) (tp *HttpSigTransport, c *MockClock, hc *MockHttpClient, ps *MockSigner) {
+		c = NewMockClock(ctl)
+		hc = NewMockHttpClient(ctl)
+		ps = NewMockSigner(ctl)
+		tp = NewHttpSigTransport(
+			hc,
+			testAppAgent,
+			c,
+			NewMockSigner(ctl),
+			ps,
+			testPubKeyId,
+			testPrivKey,
+			WithBatchDeliverChunks(size))
+		return
+	}
+	record := func(got *[]progress) BatchDeliverProgressFunc {
+		return func(delivered, total int, lastErr error) {
+			*got = append(*got, progress{delivered, total, lastErr})
+		}
+	}
+	t.Run("DeliversSortedChunksReportingProgress", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		tp, c, hc, ps := setupFn(ctl, 2)
+		var got []progress
+		var mu sync.Mutex
+		var sent []string
+		// Mock
+		c.EXPECT().Now().Return(now()).Times(3)
+		ps.EXPECT().SignRequest(testPrivKey, testPubKeyId, gomock.Any(), nil).Times(3)
+		hc.EXPECT().Do(gomock.Any()).DoAndReturn(func(r *http.Request) (*http.Response, error) {
+			mu.Lock()
+			sent = append(sent, r.URL.String())
+			mu.Unlock()
+			respR := httptest.NewRecorder()
+			respR.WriteHeader(http.StatusOK)
+			return respR.Result(), nil
+		}).Times(3)
+		// Run
+		err := tp.BatchDeliverWithProgress(ctx, testRespBody, recipients, record(&got))
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, len(got), 2)
+		assertEqual(t, got[0], progress{2, 3, nil})
+		assertEqual(t, got[1], progress{3, 3, nil})
+		assertEqual(t, sent[2], testFederatedActorIRI3)
+	})
+	t.Run("ReportsErrorOfChunk", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		tp, c, hc, ps := setupFn(ctl, 2)
+		var got []progress
+		// Mock
+		c.EXPECT().Now().Return(now()).Times(3)
+		ps.EXPECT().SignRequest(testPrivKey, testPubKeyId, gomock.Any(), nil).Times(3)
+		hc.EXPECT().Do(gomock.Any()).DoAndReturn(func(r *http.Request) (*http.Response, error) {
+			if r.URL.String() == testFederatedActorIRI3 {
+				return &http.Response{}, fmt.Errorf("test error")
+			}
+			respR := httptest.NewRecorder()
+			respR.WriteHeader(http.StatusOK)
+			return respR.Result(), nil
+		}).Times(3)
+		// Run
+		err := tp.BatchDeliverWithProgress(ctx, testRespBody, recipients, record(&got))
+		// Verify
+		assertNotEqual(t, err, nil)
+		assertEqual(t, len(got), 2)
+		assertEqual(t, got[0].err, nil)
+		assertNotEqual(t, got[1].err, nil)
+	})
+	t.Run("ReportsToEachCallsProgress", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		tp, c, hc, ps := setupFn(ctl, 3)
+		var first, second []progress
+		// Mock
+		c.EXPECT().Now().Return(now()).Times(5)
+		ps.EXPECT().SignRequest(testPrivKey, testPubKeyId, gomock.Any(), nil).Times(5)
+		hc.EXPECT().Do(gomock.Any()).DoAndReturn(func(r *http.Request) (*http.Response, error) {
+			respR := httptest.NewRecorder()
+			respR.WriteHeader(http.StatusOK)
+			return respR.Result(), nil
+		}).Times(5)
+		// Run
+		err := tp.BatchDeliverWithProgress(ctx, testRespBody, recipients, record(&first))
+		assertEqual(t, err, nil)
+		err = tp.BatchDeliverWithProgress(ctx, testRespBody, recipients[:2], record(&second))
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, len(first), 1)
+		assertEqual(t, first[0], progress{3, 3, nil})
+		assertEqual(t, len(second), 1)
+		assertEqual(t, second[0], progress{2, 2, nil})
+	})
+}
+
+func TestHttpSigTransportResumeBatchDeliver(t *testing.T) {
+	ctx := context.Background()
+	recipients := []*url.URL{
+		mustParse(testFederatedActorIRI3),
+		mustParse(testFederatedActorIRI),
+		mustParse(testFederatedActorIRI2),
+	}
+	sorted := SortRecipients(recipients)
+	setupFn := func(ctl *gomock.Controller) (tp *HttpSigTransport, c *MockClock, hc *MockHttpClient, ps *MockSigner) {
+		c = NewMockClock(ctl)
+		hc = NewMockHttpClient(ctl)
+		ps = NewMockSigner(ctl)
+		tp = NewHttpSigTransport(
+			hc,
+			testAppAgent,
+			c,
+			NewMockSigner(ctl),
+			ps,
+			testPubKeyId,
+			testPrivKey,
+			WithBatchDeliverChunks(1))
+		return
+	}
+	t.Run("DeliversToAllRecipients", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		tp, c, hc, ps := setupFn(ctl)
+		// Mock
+		c.EXPECT().Now().Return(now()).Times(3)
+		ps.EXPECT().SignRequest(testPrivKey, testPubKeyId, gomock.Any(), nil).Times(3)
+		hc.EXPECT().Do(gomock.Any()).DoAndReturn(func(r *http.Request) (*http.Response, error) {
+			respR := httptest.NewRecorder()
+			respR.WriteHeader(http.StatusOK)
+			return respR.Result(), nil
+		}).Times(3)
+		// Run
+		next, err := tp.ResumeBatchDeliver(ctx, testRespBody, recipients, "", nil)
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, next, "")
+	})
+	t.Run("ResumesAfterInterruption", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		tp, c, hc, ps := setupFn(ctl)
+		cancelCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		var sent []string
+		// Mock
+		c.EXPECT().Now().Return(now()).Times(4)
+		ps.EXPECT().SignRequest(testPrivKey, testPubKeyId, gomock.Any(), nil).Times(4)
+		hc.EXPECT().Do(gomock.Any()).DoAndReturn(func(r *http.Request) (*http.Response, error) {
+			sent = append(sent, r.URL.String())
+			if r.URL.String() == sorted[1].String() {
+				cancel()
+			}
+			respR := httptest.NewRecorder()
+			respR.WriteHeader(http.StatusOK)
+			return respR.Result(), nil
+		}).Times(4)
+		// Run
+		next, err := tp.ResumeBatchDeliver(cancelCtx, testRespBody, recipients, "", nil)
+		assertEqual(t, err, context.Canceled)
+		assertNotEqual(t, next, "")
+		next, err = tp.ResumeBatchDeliver(ctx, testRespBody, recipients, next, nil)
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, next, "")
+		assertEqual(t, len(sent), 4)
+		assertEqual(t, sent[0], sorted[0].String())
+		assertEqual(t, sent[2], sorted[1].String())
+		assertEqual(t, sent[3], sorted[2].String())
+	})
+	t.Run("ResumesPastRemovedRecipient", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		tp, c, hc, ps := setupFn(ctl)
+		token := resumeToken(sorted, 2)
+		// Mock
+		c.EXPECT().Now().Return(now())
+		ps.EXPECT().SignRequest(testPrivKey, testPubKeyId, gomock.Any(), nil)
+		hc.EXPECT().Do(gomock.Any()).DoAndReturn(func(r *http.Request) (*http.Response, error) {
+			assertEqual(t, r.URL.String(), sorted[2].String())
+			respR := httptest.NewRecorder()
+			respR.WriteHeader(http.StatusOK)
+			return respR.Result(), nil
+		})
+		// Run
+		next, err := tp.ResumeBatchDeliver(ctx, testRespBody, []*url.URL{sorted[0], sorted[2]}, token, nil)
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, next, "")
+	})
+	t.Run("RejectsInvalidToken", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		tp, _, _, _ := setupFn(ctl)
+		// Run
+		next, err := tp.ResumeBatchDeliver(ctx, testRespBody, recipients, "!", nil)
+		// Verify
+		assertNotEqual(t, err, nil)
+		assertEqual(t, next, "!")
+	})
+}
+
+func TestHttpSigTransportKeyProvider(t *testing.T) {
+	type actorKey struct{}
+	ctx := context.WithValue(context.Background(), actorKey{}, "alice")
+	otherPrivKey := []byte("alice's private key")
+	provider := func(c context.Context) (string, crypto.PrivateKey, error) {
+		if actor, _ := c.Value(actorKey{}).(string); actor != "alice" {
+			return "", nil, fmt.Errorf("no key for %q", actor)
+		}
+		return "aliceKeyId", otherPrivKey, nil
+	}
+	setupFn := func(ctl *gomock.Controller) (t *HttpSigTransport, c *MockClock, hc *MockHttpClient, gs, ps *MockSigner) {
+		c = NewMockClock(ctl)
+		hc = NewMockHttpClient(ctl)
+		gs = NewMockSigner(ctl)
+		ps = NewMockSigner(ctl)
+		t = NewHttpSigTransport(
+			hc,
+			testAppAgent,
+			c,
+			gs,
+			ps,
+			testPubKeyId,
+			testPrivKey,
+			WithKeyProvider(provider))
+		return
+	}
+	t.Run("SignsDereferenceWithProvidedKey", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		tp, c, hc, gs, _ := setupFn(ctl)
+		respR := httptest.NewRecorder()
+		respR.Write(testRespBody)
+		resp := respR.Result()
+		// Mock
+		c.EXPECT().Now().Return(now())
+		gs.EXPECT().SignRequest(otherPrivKey, "aliceKeyId", gomock.Any(), nil)
+		hc.EXPECT().Do(gomock.Any()).Return(resp, nil)
+		// Run & Verify
+		b, err := tp.Dereference(ctx, mustParse(testNoteId1))
+		assertByteEqual(t, b, testRespBody)
+		assertEqual(t, err, nil)
+	})
+	t.Run("SignsDeliverWithProvidedKey", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		tp, c, hc, _, ps := setupFn(ctl)
+		respR := httptest.NewRecorder()
+		respR.WriteHeader(http.StatusOK)
+		resp := respR.Result()
+		// Mock
+		c.EXPECT().Now().Return(now())
+		ps.EXPECT().SignRequest(otherPrivKey, "aliceKeyId", gomock.Any(), nil)
+		hc.EXPECT().Do(gomock.Any()).Return(resp, nil)
+		// Run & Verify
+		err := tp.Deliver(ctx, testRespBody, mustParse(testFederatedActorIRI))
+		assertEqual(t, err, nil)
+	})
+	t.Run("AbortsWhenProviderErrors", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		tp, c, _, _, _ := setupFn(ctl)
+		// Mock
+		c.EXPECT().Now().Return(now())
+		// Run & Verify
+		err := tp.Deliver(context.Background(), testRespBody, mustParse(testFederatedActorIRI))
+		assertNotEqual(t, err, nil)
+	})
+}
+
+func TestSortRecipients(t *testing.T) {
+	recipients := []*url.URL{
+		mustParse(testFederatedActorIRI),
+		mustParse(testFederatedActorIRI2),
+	}
+	sorted := SortRecipients(recipients)
+	assertEqual(t, sorted[0].String(), testFederatedActorIRI2)
+	assertEqual(t, sorted[1].String(), testFederatedActorIRI)
+	assertEqual(t, recipients[0].String(), testFederatedActorIRI)
+	assertEqual(t, recipients[1].String(), testFederatedActorIRI2)
+}
+
+func TestHttpSigTransportGETSignPolicy(t *testing.T) {
+	ctx := context.Background()
+	k, err := rsa.GenerateKey(rand.Reader, 2048)
+	assertEqual(t, err, nil)
+	setupFn := func(ctl *gomock.Controller, p GETSignPolicy) (t *HttpSigTransport, c *MockClock, hc *MockHttpClient, gs *MockSigner) {
+		c = NewMockClock(ctl)
+		hc = NewMockHttpClient(ctl)
+		gs = NewMockSigner(ctl)
+		t = NewHttpSigTransport(
+			hc,
+			testAppAgent,
+			c,
+			gs,
+			NewMockSigner(ctl),
+			testPubKeyId,
+			k,
+			WithGETSignPolicy(p, []httpsig.Algorithm{httpsig.RSA_SHA256}))
+		return
+	}
+	okResp := func() *http.Response {
+		respR := httptest.NewRecorder()
+		respR.Write(testRespBody)
+		return respR.Result()
+	}
+	t.Run("DoesNotSignWhenPolicyRefuses", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		var hosts []string
+		tp, c, hc, _ := setupFn(ctl, func(host string) (bool, []string) {
+			hosts = append(hosts, host)
+			return false, nil
+		})
+		// Mock
+		c.EXPECT().Now().Return(now())
+		hc.EXPECT().Do(gomock.Any()).DoAndReturn(func(r *http.Request) (*http.Response, error) {
+			assertEqual(t, r.Header.Get("Signature"), "")
+			return okResp(), nil
+		})
+		// Run & Verify
+		b, err := tp.Dereference(ctx, mustParse(testNoteId1))
+		assertByteEqual(t, b, testRespBody)
+		assertEqual(t, err, nil)
+		assertEqual(t, len(hosts), 1)
+		assertEqual(t, hosts[0], "example.com")
+	})
+	t.Run("UsesGETSignerWithoutHeaders", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		tp, c, hc, gs := setupFn(ctl, func(host string) (bool, []string) {
+			return true, nil
+		})
+		// Mock
+		c.EXPECT().Now().Return(now())
+		gs.EXPECT().SignRequest(k, testPubKeyId, gomock.Any(), nil)
+		hc.EXPECT().Do(gomock.Any()).Return(okResp(), nil)
+		// Run & Verify
+		_, err := tp.Dereference(ctx, mustParse(testNoteId1))
+		assertEqual(t, err, nil)
+	})
+	t.Run("SignsHeadersOfPolicy", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		tp, c, hc, _ := setupFn(ctl, func(host string) (bool, []string) {
+			return true, []string{httpsig.RequestTarget, "host"}
+		})
+		// Mock
+		c.EXPECT().Now().Return(now()).Times(2)
+		hc.EXPECT().Do(gomock.Any()).DoAndReturn(func(r *http.Request) (*http.Response, error) {
+			sig := r.Header.Get("Signature")
+			assertEqual(t, strings.Contains(sig, `headers="(request-target) host"`), true)
+			return okResp(), nil
+		}).Times(2)
+		// Run & Verify
+		_, err := tp.Dereference(ctx, mustParse(testNoteId1))
+		assertEqual(t, err, nil)
+		_, err = tp.Dereference(ctx, mustParse(testNoteId2))
+		assertEqual(t, err, nil)
+	})
+}
+
+func TestHttpSigTransportPerRequestTimeout(t *testing.T) {
+	ctx := context.Background()
+	release := make(chan struct{})
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ioutil.ReadAll(r.Body)
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer slow.Close()
+	defer close(release)
+	setupFn := func(ctl *gomock.Controller) (tp *HttpSigTransport, c *MockClock, gs, ps *MockSigner) {
+		c = NewMockClock(ctl)
+		gs = NewMockSigner(ctl)
+		ps = NewMockSigner(ctl)
+		tp = NewHttpSigTransport(
+			slow.Client(),
+			testAppAgent,
+			c,
+			gs,
+			ps,
+			testPubKeyId,
+			testPrivKey,
+			WithPerRequestTimeout(50*time.Millisecond))
+		return
+	}
+	t.Run("DeliverFailsSlowRequest", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		tp, c, _, ps := setupFn(ctl)
+		// Mock
+		c.EXPECT().Now().Return(now())
+		ps.EXPECT().SignRequest(testPrivKey, testPubKeyId, gomock.Any(), nil)
+		// Run
+		start := time.Now()
+		err := tp.Deliver(ctx, testRespBody, mustParse(slow.URL+"/inbox"))
+		// Verify
+		assertNotEqual(t, err, nil)
+		if elapsed := time.Since(start); elapsed > 5*time.Second {
+			t.Fatalf("slow request was not abandoned: took %s", elapsed)
+		}
+		assertEqual(t, ctx.Err(), nil)
+	})
+	t.Run("DereferenceFailsSlowRequest", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		tp, c, gs, _ := setupFn(ctl)
+		// Mock
+		c.EXPECT().Now().Return(now())
+		gs.EXPECT().SignRequest(testPrivKey, testPubKeyId, gomock.Any(), nil)
+		// Run
+		_, err := tp.Dereference(ctx, mustParse(slow.URL+"/note"))
+		// Verify
+		assertNotEqual(t, err, nil)
+	})
+	t.Run("BatchDeliverContinuesAfterSlowRequest", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		fast := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer fast.Close()
+		tp, c, _, ps := setupFn(ctl)
+		var chunks, failed int
+		progress := func(n, total int, lastErr error) {
+			chunks++
+			if lastErr != nil {
+				failed++
+			}
+		}
+		tp.chunkSize = 1
+		// Mock
+		c.EXPECT().Now().Return(now()).Times(2)
+		ps.EXPECT().SignRequest(testPrivKey, testPubKeyId, gomock.Any(), nil).Times(2)
+		// Run
+		err := tp.BatchDeliverWithProgress(ctx, testRespBody, []*url.URL{
+			mustParse(slow.URL + "/inbox"),
+			mustParse(fast.URL + "/inbox"),
+		}, progress)
+		// Verify
+		assertNotEqual(t, err, nil)
+		assertEqual(t, chunks, 2)
+		assertEqual(t, failed, 1)
+	})
+}
@@ -0,0 +1,94 @@
+package pub
+
+import (
+	"fmt"
+
+	"github.com/go-fed/activity/streams"
+	"github.com/go-fed/activity/streams/vocab"
+)
+
+// undoableTypes are the types of activities that may be undone with NewUndo.
+var undoableTypes = map[string]bool{
+	"Accept":   true,
+	"Announce": true,
+	"Block":    true,
+	"Dislike":  true,
+	"Follow":   true,
+	"Like":     true,
+}
+
+// NewUndo builds an Undo of the original activity, such as a Like, Follow, or
+// Announce, as expected by peers in order to reverse its side effects.
+//
+// The original activity must have an 'id' and an 'actor'. It is set as the
+// 'object' of the Undo, without being copied, and its 'actor', 'to', and 'cc'
+// are copied to the Undo. When a Follow or Block has neither 'to' nor 'cc', as
+// it is addressed only through its 'object', the Undo is addressed to the ids
+// of its 'object' instead.
+//
+// An error is returned for activities that cannot be undone, such as a Create
+// which is reversed with a Delete instead.
+func NewUndo(original Activity) (vocab.ActivityStreamsUndo, error) {
+	if !undoableTypes[original.GetTypeName()] {
+		return nil, fmt.Errorf("cannot undo an activity of type %s", original.GetTypeName())
+	} else if _, err := GetId(original); err != nil {
+		return nil, err
+	}
+	actors := original.GetActivityStreamsActor()
+	if actors == nil || actors.Len() == 0 {
+		return nil, fmt.Errorf("cannot undo %s activity without an actor", original.GetTypeName())
+	}
+	undo := streams.NewActivityStreamsUndo()
+	actor := streams.NewActivityStreamsActorProperty()
+	for iter := actors.Begin(); iter != actors.End(); iter = iter.Next() {
+		id, err := ToId(iter)
+		if err != nil {
+			return nil, err
+		}
+		actor.AppendIRI(id)
+	}
+	undo.SetActivityStreamsActor(actor)
+	op := streams.NewActivityStreamsObjectProperty()
+	if err := op.AppendType(original); err != nil {
+		return nil, err
+	}
+	undo.SetActivityStreamsObject(op)
+	to := streams.NewActivityStreamsToProperty()
+	if t := original.GetActivityStreamsTo(); t != nil {
+		for iter := t.Begin(); iter != t.End(); iter = iter.Next() {
+			id, err := ToId(iter)
+			if err != nil {
+				return nil, err
+			}
+			to.AppendIRI(id)
+		}
+	}
+	cc := streams.NewActivityStreamsCcProperty()
+	if c := original.GetActivityStreamsCc(); c != nil {
+		for iter := c.Begin(); iter != c.End(); iter = iter.Next() {
+			id, err := ToId(iter)
+			if err != nil {
+				return nil, err
+			}
+			cc.AppendIRI(id)
+		}
+	}
+	if name := original.GetTypeName(); to.Len() == 0 && cc.Len() == 0 && (name == "Follow" || name == "Block") {
+		if o := original.GetActivityStreamsObject(); o != nil {
+			for iter := o.Begin(); iter != o.End(); iter = iter.Next() {
+				id, err := ToId(iter)
+				if err != nil {
+					return nil, err
+				}
+				to.AppendIRI(id)
+			}
+		}
+	}
+	if to.Len() > 0 {
+		undo.SetActivityStreamsTo(to)
+	}
+	if cc.Len() > 0 {
+		undo.SetActivityStreamsCc(cc)
+	}
+	return undo, nil
+}
@@ -0,0 +1,90 @@
+package pub
+
+import (
+	"testing"
+
+	"github.com/go-fed/activity/streams"
+)
+
+func TestNewUndo(t *testing.T) {
+	t.Run("UndoesLikeWithItsAddressing", func(t *testing.T) {
+		// Setup
+		like := streams.NewActivityStreamsLike()
+		id := streams.NewJSONLDIdProperty()
+		id.Set(mustParse(testNewActivityIRI))
+		like.SetJSONLDId(id)
+		actor := streams.NewActivityStreamsActorProperty()
+		actor.AppendIRI(mustParse(testPersonIRI))
+		like.SetActivityStreamsActor(actor)
+		op := streams.NewActivityStreamsObjectProperty()
+		op.AppendIRI(mustParse(testNoteId1))
+		like.SetActivityStreamsObject(op)
+		to := streams.NewActivityStreamsToProperty()
+		to.AppendIRI(mustParse(testFederatedActorIRI))
+		like.SetActivityStreamsTo(to)
+		cc := streams.NewActivityStreamsCcProperty()
+		cc.AppendIRI(mustParse(PublicActivityPubIRI))
+		like.SetActivityStreamsCc(cc)
+		// Run
+		undo, err := NewUndo(like)
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, undo.GetActivityStreamsActor().At(0).GetIRI().String(), testPersonIRI)
+		assertEqual(t, undo.GetActivityStreamsObject().At(0).IsActivityStreamsLike(), true)
+		objId, err := GetId(undo.GetActivityStreamsObject().At(0).GetType())
+		assertEqual(t, err, nil)
+		assertEqual(t, objId.String(), testNewActivityIRI)
+		assertEqual(t, undo.GetActivityStreamsTo().Len(), 1)
+		assertEqual(t, undo.GetActivityStreamsTo().At(0).GetIRI().String(), testFederatedActorIRI)
+		assertEqual(t, undo.GetActivityStreamsCc().Len(), 1)
+		assertEqual(t, undo.GetActivityStreamsCc().At(0).GetIRI().String(), PublicActivityPubIRI)
+	})
+	t.Run("AddressesUndoFollowToFollowedActor", func(t *testing.T) {
+		// Setup
+		follow := streams.NewActivityStreamsFollow()
+		id := streams.NewJSONLDIdProperty()
+		id.Set(mustParse(testNewActivityIRI))
+		follow.SetJSONLDId(id)
+		actor := streams.NewActivityStreamsActorProperty()
+		actor.AppendIRI(mustParse(testPersonIRI))
+		follow.SetActivityStreamsActor(actor)
+		op := streams.NewActivityStreamsObjectProperty()
+		op.AppendIRI(mustParse(testFederatedActorIRI))
+		follow.SetActivityStreamsObject(op)
+		// Run
+		undo, err := NewUndo(follow)
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, undo.GetActivityStreamsTo().Len(), 1)
+		assertEqual(t, undo.GetActivityStreamsTo().At(0).GetIRI().String(), testFederatedActorIRI)
+		assertEqual(t, undo.GetActivityStreamsCc(), nil)
+	})
+	t.Run("ErrorsForTypeThatCannotBeUndone", func(t *testing.T) {
+		// Run
+		_, err := NewUndo(streams.NewActivityStreamsCreate())
+		// Verify
+		assertNotEqual(t, err, nil)
+	})
+	t.Run("ErrorsWithoutId", func(t *testing.T) {
+		// Setup
+		like := streams.NewActivityStreamsLike()
+		actor := streams.NewActivityStreamsActorProperty()
+		actor.AppendIRI(mustParse(testPersonIRI))
+		like.SetActivityStreamsActor(actor)
+		// Run
+		_, err := NewUndo(like)
+		// Verify
+		assertNotEqual(t, err, nil)
+	})
+	t.Run("ErrorsWithoutActor", func(t *testing.T) {
+		// Setup
+		like := streams.NewActivityStreamsLike()
+		id := streams.NewJSONLDIdProperty()
+		id.Set(mustParse(testNewActivityIRI))
+		like.SetJSONLDId(id)
+		// Run
+		_, err := NewUndo(like)
+		// Verify
+		assertNotEqual(t, err, nil)
+	})
+}
@@ -0,0 +1,425 @@
+package pub
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-fed/activity/streams"
+)
+
+// URDNA2015Canonicalizer is the default LDCanonicalizer. It converts JSON-LD
+// 1.0 documents to RDF and canonicalizes them with the URDNA2015 algorithm
+// into N-Quads, as needed to verify RsaSignature2017 Linked Data Signatures.
+//
+// The remote JSON-LD contexts of documents are loaded with its
+// streams.ContextLoader, which has the ActivityStreams, Security v1, and
+// Identity v1 contexts bundled. Contexts such as Mastodon's are usually
+// embedded in the documents themselves.
+type URDNA2015Canonicalizer struct {
+	loader *streams.ContextLoader
+}
+
+var _ LDCanonicalizer = &URDNA2015Canonicalizer{}
+
+// NewURDNA2015Canonicalizer returns a URDNA2015Canonicalizer loading the
+// JSON-LD contexts with the ContextLoader. If it is nil, a ContextLoader with
+// only the bundled contexts is used.
+func NewURDNA2015Canonicalizer(l *streams.ContextLoader) *URDNA2015Canonicalizer {
+	if l == nil {
+		l = streams.NewContextLoader()
+	}
+	return &URDNA2015Canonicalizer{loader: l}
+}
+
+// Canonicalize returns the canonical N-Quads of the JSON-LD document.
+func (u *URDNA2015Canonicalizer) Canonicalize(doc map[string]interface{}) ([]byte, error) {
+	p := &jsonLDProcessor{
+		c:      context.Background(),
+		loader: u.loader,
+	}
+	quads, err := p.toRDF(copyJSONLD(doc).(map[string]interface{}))
+	if err != nil {
+		return nil, err
+	}
+	return []byte(urdna2015(quads)), nil
+}
+
+// copyJSONLD deep copies the JSON value, so that processing it does not
+// modify the caller's document.
+func copyJSONLD(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(t))
+		for k, e := range t {
+			m[k] = copyJSONLD(e)
+		}
+		return m
+	case []interface{}:
+		a := make([]interface{}, len(t))
+		for i, e := range t {
+			a[i] = copyJSONLD(e)
+		}
+		return a
+	default:
+		return v
+	}
+}
+
+// The kinds of rdfNode.
+const (
+	rdfIRI = iota + 1
+	rdfBlank
+	rdfLiteral
+)
+
+// rdfNode is an IRI, blank node, or literal of an RDF quad. The zero value is
+// the default graph.
+type rdfNode struct {
+	kind     int
+	value    string
+	datatype string
+	language string
+}
+
+// rdfQuad is a statement of an RDF dataset.
+type rdfQuad struct {
+	subject   rdfNode
+	predicate rdfNode
+	object    rdfNode
+	graph     rdfNode
+}
+
+// iriOrBnode returns the IRI or blank node of the identifier.
+func iriOrBnode(id string) rdfNode {
+	if strings.HasPrefix(id, "_:") {
+		return rdfNode{kind: rdfBlank, value: id}
+	}
+	return rdfNode{kind: rdfIRI, value: id}
+}
+
+// nquad serializes the quad in N-Quads.
+func (q rdfQuad) nquad() string {
+	var b strings.Builder
+	writeNQuadsTerm(&b, q.subject)
+	b.WriteByte(' ')
+	writeNQuadsTerm(&b, q.predicate)
+	b.WriteByte(' ')
+	writeNQuadsTerm(&b, q.object)
+	if q.graph.kind != 0 {
+		b.WriteByte(' ')
+		writeNQuadsTerm(&b, q.graph)
+	}
+	b.WriteString(" .\n")
+	return b.String()
+}
+
+// nquadsEscaper escapes the characters of literals the way canonical N-Quads
+// do.
+var nquadsEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`)
+
+// writeNQuadsTerm serializes the node in N-Quads.
+func writeNQuadsTerm(b *strings.Builder, n rdfNode) {
+	switch n.kind {
+	case rdfIRI:
+		b.WriteString("<" + n.value + ">")
+	case rdfBlank:
+		b.WriteString(n.value)
+	case rdfLiteral:
+		b.WriteString(`"` + nquadsEscaper.Replace(n.value) + `"`)
+		if n.datatype == rdfLangString {
+			b.WriteString("@" + n.language)
+		} else if n.datatype != xsdString {
+			b.WriteString("^^<" + n.datatype + ">")
+		}
+	}
+}
+
+// bnodeIssuer issues blank node identifiers with a prefix and a counter,
+// remembering the identifier issued for each existing one.
+type bnodeIssuer struct {
+	prefix  string
+	counter int
+	issued  map[string]string
+	order   []string
+}
+
+// newBnodeIssuer returns a bnodeIssuer of identifiers with the prefix.
+func newBnodeIssuer(prefix string) *bnodeIssuer {
+	return &bnodeIssuer{
+		prefix: prefix,
+		issued: make(map[string]string),
+	}
+}
+
+// issue returns the identifier issued for the existing one, issuing a new one
+// if needed. An empty existing identifier always gets a new one.
+func (i *bnodeIssuer) issue(existing string) string {
+	if id, ok := i.issued[existing]; ok && len(existing) > 0 {
+		return id
+	}
+	id := i.prefix + strconv.Itoa(i.counter)
+	i.counter++
+	if len(existing) > 0 {
+		i.issued[existing] = id
+		i.order = append(i.order, existing)
+	}
+	return id
+}
+
+// has returns whether an identifier was issued for the existing one.
+func (i *bnodeIssuer) has(existing string) bool {
+	_, ok := i.issued[existing]
+	return ok
+}
+
+// clone returns a copy of the issuer.
+func (i *bnodeIssuer) clone() *bnodeIssuer {
+	c := &bnodeIssuer{
+		prefix:  i.prefix,
+		counter: i.counter,
+		issued:  make(map[string]string, len(i.issued)),
+		order:   append([]string(nil), i.order...),
+	}
+	for k, v := range i.issued {
+		c.issued[k] = v
+	}
+	return c
+}
+
+// urdna2015State is the state of the URDNA2015 canonicalization of a dataset.
+type urdna2015State struct {
+	// quads are the quads mentioning each blank node.
+	quads map[string][]rdfQuad
+	// canonical issues the canonical blank node identifiers.
+	canonical *bnodeIssuer
+	// firstDegree caches the first degree hash of each blank node.
+	firstDegree map[string]string
+}
+
+// urdna2015 returns the canonical N-Quads of the dataset, following the
+// URDNA2015 algorithm of the RDF Dataset Normalization specification.
+func urdna2015(quads []rdfQuad) string {
+	s := &urdna2015State{
+		quads:       make(map[string][]rdfQuad),
+		canonical:   newBnodeIssuer("_:c14n"),
+		firstDegree: make(map[string]string),
+	}
+	for _, q := range quads {
+		added := make(map[string]bool, 3)
+		for _, n := range []rdfNode{q.subject, q.object, q.graph} {
+			if n.kind == rdfBlank && !added[n.value] {
+				added[n.value] = true
+				s.quads[n.value] = append(s.quads[n.value], q)
+			}
+		}
+	}
+	// Issue the canonical identifiers of the blank nodes with a unique
+	// first degree hash, repeatedly, in the order of their hashes.
+	nonNormalized := make(map[string]bool, len(s.quads))
+	for id := range s.quads {
+		nonNormalized[id] = true
+	}
+	hashToBnodes := make(map[string][]string)
+	for simple := true; simple; {
+		simple = false
+		hashToBnodes = make(map[string][]string)
+		for _, id := range sortedKeys(nonNormalized) {
+			h := s.hashFirstDegreeQuads(id)
+			hashToBnodes[h] = append(hashToBnodes[h], id)
+		}
+		for _, h := range sortedKeys(hashToBnodes) {
+			ids := hashToBnodes[h]
+			if len(ids) > 1 {
+				continue
+			}
+			s.canonical.issue(ids[0])
+			delete(nonNormalized, ids[0])
+			delete(hashToBnodes, h)
+			simple = true
+		}
+	}
+	// Issue the canonical identifiers of the remaining blank nodes, which
+	// share their first degree hashes, by hashing their paths to the other
+	// blank nodes.
+	for _, h := range sortedKeys(hashToBnodes) {
+		type pathResult struct {
+			hash   string
+			issuer *bnodeIssuer
+		}
+		var results []pathResult
+		for _, id := range hashToBnodes[h] {
+			if s.canonical.has(id) {
+				continue
+			}
+			issuer := newBnodeIssuer("_:b")
+			issuer.issue(id)
+			hash, issuer := s.hashNDegreeQuads(id, issuer)
+			results = append(results, pathResult{hash, issuer})
+		}
+		sort.SliceStable(results, func(i, j int) bool {
+			return results[i].hash < results[j].hash
+		})
+		for _, r := range results {
+			for _, existing := range r.issuer.order {
+				s.canonical.issue(existing)
+			}
+		}
+	}
+	lines := make([]string, 0, len(quads))
+	seen := make(map[string]bool, len(quads))
+	for _, q := range quads {
+		q.subject = s.relabel(q.subject)
+		q.object = s.relabel(q.object)
+		q.graph = s.relabel(q.graph)
+		line := q.nquad()
+		if !seen[line] {
+			seen[line] = true
+			lines = append(lines, line)
+		}
+	}
+	sort.Strings(lines)
+	return strings.Join(lines, "")
+}
+
+// relabel returns the node with its canonical identifier if it is a blank
+// node.
+func (s *urdna2015State) relabel(n rdfNode) rdfNode {
+	if n.kind == rdfBlank {
+		n.value = s.canonical.issue(n.value)
+	}
+	return n
+}
+
+// hashFirstDegreeQuads hashes the quads mentioning the blank node, with the
+// blank node itself named "_:a" and the others "_:z".
+func (s *urdna2015State) hashFirstDegreeQuads(id string) string {
+	if h, ok := s.firstDegree[id]; ok {
+		return h
+	}
+	replace := func(n rdfNode) rdfNode {
+		if n.kind == rdfBlank {
+			if n.value == id {
+				n.value = "_:a"
+			} else {
+				n.value = "_:z"
+			}
+		}
+		return n
+	}
+	var lines []string
+	for _, q := range s.quads[id] {
+		q.subject = replace(q.subject)
+		q.object = replace(q.object)
+		q.graph = replace(q.graph)
+		lines = append(lines, q.nquad())
+	}
+	sort.Strings(lines)
+	h := sha256Hex(strings.Join(lines, ""))
+	s.firstDegree[id] = h
+	return h
+}
+
+// hashRelatedBlankNode hashes a blank node related to another through a quad,
+// in the position "s", "o", or "g".
+func (s *urdna2015State) hashRelatedBlankNode(related string, q rdfQuad, issuer *bnodeIssuer, position string) string {
+	var id string
+	if s.canonical.has(related) {
+		id = s.canonical.issue(related)
+	} else if issuer.has(related) {
+		id = issuer.issue(related)
+	} else {
+		id = s.hashFirstDegreeQuads(related)
+	}
+	input := position
+	if position != "g" {
+		input += "<" + q.predicate.value + ">"
+	}
+	return sha256Hex(input + id)
+}
+
+// hashNDegreeQuads hashes the paths from the blank node to the blank nodes it
+// is related to, returning the hash and the issuer of the chosen path.
+func (s *urdna2015State) hashNDegreeQuads(id string, issuer *bnodeIssuer) (string, *bnodeIssuer) {
+	hashToRelated := make(map[string][]string)
+	for _, q := range s.quads[id] {
+		for _, c := range []struct {
+			n        rdfNode
+			position string
+		}{{q.subject, "s"}, {q.object, "o"}, {q.graph, "g"}} {
+			if c.n.kind != rdfBlank || c.n.value == id {
+				continue
+			}
+			h := s.hashRelatedBlankNode(c.n.value, q, issuer, c.position)
+			hashToRelated[h] = append(hashToRelated[h], c.n.value)
+		}
+	}
+	var data strings.Builder
+	for _, h := range sortedKeys(hashToRelated) {
+		data.WriteString(h)
+		var chosenPath string
+		var chosenIssuer *bnodeIssuer
+		permute(hashToRelated[h], func(perm []string) {
+			issuerCopy := issuer.clone()
+			var path string
+			var recursion []string
+			for _, related := range perm {
+				if s.canonical.has(related) {
+					path += s.canonical.issue(related)
+				} else {
+					if !issuerCopy.has(related) {
+						recursion = append(recursion, related)
+					}
+					path += issuerCopy.issue(related)
+				}
+				if len(chosenPath) > 0 && len(path) >= len(chosenPath) && path > chosenPath {
+					return
+				}
+			}
+			for _, related := range recursion {
+				hash, resultIssuer := s.hashNDegreeQuads(related, issuerCopy)
+				path += issuerCopy.issue(related)
+				path += "<" + hash + ">"
+				issuerCopy = resultIssuer
+				if len(chosenPath) > 0 && len(path) >= len(chosenPath) && path > chosenPath {
+					return
+				}
+			}
+			if len(chosenPath) == 0 || path < chosenPath {
+				chosenPath = path
+				chosenIssuer = issuerCopy
+			}
+		})
+		data.WriteString(chosenPath)
+		issuer = chosenIssuer
+	}
+	return sha256Hex(data.String()), issuer
+}
+
+// permute calls the function with every permutation of the identifiers.
+func permute(ids []string, fn func([]string)) {
+	perm := append([]string(nil), ids...)
+	sort.Strings(perm)
+	var generate func(int)
+	generate = func(k int) {
+		if k == len(perm) {
+			fn(perm)
+			return
+		}
+		for i := k; i < len(perm); i++ {
+			perm[k], perm[i] = perm[i], perm[k]
+			generate(k + 1)
+			perm[k], perm[i] = perm[i], perm[k]
+		}
+	}
+	generate(0)
+}
+
+// sha256Hex returns the hex SHA-256 hash of the string.
+func sha256Hex(s string) string {
+	h := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(h[:])
+}
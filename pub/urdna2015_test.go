@@ -0,0 +1,181 @@
+package pub
+
+import (
+	"encoding/json"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/go-fed/activity/streams"
+)
+
+func TestURDNA2015Canonicalizer(t *testing.T) {
+	canonicalize := func(t *testing.T, doc string) string {
+		var m map[string]interface{}
+		if err := unmarshalJSON([]byte(doc), &m); err != nil {
+			t.Fatal(err)
+		}
+		b, err := NewURDNA2015Canonicalizer(nil).Canonicalize(m)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		return string(b)
+	}
+	t.Run("CanonicalizesSignatureOptions", func(t *testing.T) {
+		got := canonicalize(t, `{
+			"@context": "https://w3id.org/identity/v1",
+			"creator": "https://example.com/users/alice#main-key",
+			"created": "2019-09-14T11:39:40Z"
+		}`)
+		expect := `_:c14n0 <http://purl.org/dc/terms/created> "2019-09-14T11:39:40Z"^^<http://www.w3.org/2001/XMLSchema#dateTime> .
+_:c14n0 <http://purl.org/dc/terms/creator> <https://example.com/users/alice#main-key> .
+`
+		assertEqual(t, got, expect)
+	})
+	t.Run("CanonicalizesActivityStreams", func(t *testing.T) {
+		got := canonicalize(t, `{
+			"@context": [
+				"https://www.w3.org/ns/activitystreams",
+				{"sensitive": "as:sensitive", "toot": "http://joinmastodon.org/ns#", "Emoji": "toot:Emoji"}
+			],
+			"id": "https://example.com/notes/1",
+			"type": "Note",
+			"content": "Hi \"there\"\n",
+			"contentMap": {"EN": "Hi"},
+			"published": "2019-09-14T11:39:40Z",
+			"sensitive": false,
+			"height": 5,
+			"accuracy": 94.5,
+			"to": ["https://www.w3.org/ns/activitystreams#Public"],
+			"unknownProperty": "dropped",
+			"tag": [{"type": "Emoji", "name": ":blob:"}],
+			"orderedItems": ["https://example.com/notes/2"]
+		}`)
+		expect := `<https://example.com/notes/1> <http://www.w3.org/1999/02/22-rdf-syntax-ns#type> <https://www.w3.org/ns/activitystreams#Note> .
+<https://example.com/notes/1> <https://www.w3.org/ns/activitystreams#accuracy> "9.45E1"^^<http://www.w3.org/2001/XMLSchema#float> .
+<https://example.com/notes/1> <https://www.w3.org/ns/activitystreams#content> "Hi \"there\"\n" .
+<https://example.com/notes/1> <https://www.w3.org/ns/activitystreams#content> "Hi"@en .
+<https://example.com/notes/1> <https://www.w3.org/ns/activitystreams#height> "5"^^<http://www.w3.org/2001/XMLSchema#nonNegativeInteger> .
+<https://example.com/notes/1> <https://www.w3.org/ns/activitystreams#items> _:c14n0 .
+<https://example.com/notes/1> <https://www.w3.org/ns/activitystreams#published> "2019-09-14T11:39:40Z"^^<http://www.w3.org/2001/XMLSchema#dateTime> .
+<https://example.com/notes/1> <https://www.w3.org/ns/activitystreams#sensitive> "false"^^<http://www.w3.org/2001/XMLSchema#boolean> .
+<https://example.com/notes/1> <https://www.w3.org/ns/activitystreams#tag> _:c14n1 .
+<https://example.com/notes/1> <https://www.w3.org/ns/activitystreams#to> <https://www.w3.org/ns/activitystreams#Public> .
+_:c14n0 <http://www.w3.org/1999/02/22-rdf-syntax-ns#first> <https://example.com/notes/2> .
+_:c14n0 <http://www.w3.org/1999/02/22-rdf-syntax-ns#rest> <http://www.w3.org/1999/02/22-rdf-syntax-ns#nil> .
+_:c14n1 <http://www.w3.org/1999/02/22-rdf-syntax-ns#type> <http://joinmastodon.org/ns#Emoji> .
+_:c14n1 <https://www.w3.org/ns/activitystreams#name> ":blob:" .
+`
+		assertEqual(t, got, expect)
+	})
+	t.Run("DoesNotModifyDocument", func(t *testing.T) {
+		m := map[string]interface{}{
+			"@context": streams.ActivityStreamsContextURL,
+			"type":     "Note",
+			"tag":      []interface{}{map[string]interface{}{"type": "Mention"}},
+		}
+		before, err := json.Marshal(m)
+		assertEqual(t, err, nil)
+		_, err = NewURDNA2015Canonicalizer(nil).Canonicalize(m)
+		assertEqual(t, err, nil)
+		after, err := json.Marshal(m)
+		assertEqual(t, err, nil)
+		assertEqual(t, string(after), string(before))
+	})
+	t.Run("ErrorsOnUnregisteredContext", func(t *testing.T) {
+		m := map[string]interface{}{
+			"@context": "https://example.com/context",
+			"type":     "Note",
+		}
+		_, err := NewURDNA2015Canonicalizer(nil).Canonicalize(m)
+		assertNotEqual(t, err, nil)
+	})
+	t.Run("UsesRegisteredContexts", func(t *testing.T) {
+		l := streams.NewContextLoader()
+		assertEqual(t, l.Register("https://example.com/context", []byte(`{"@context": {"name": "http://schema.org/name"}}`)), nil)
+		m := map[string]interface{}{
+			"@context": "https://example.com/context",
+			"@id":      "https://example.com/thing",
+			"name":     "thing",
+		}
+		b, err := NewURDNA2015Canonicalizer(l).Canonicalize(m)
+		assertEqual(t, err, nil)
+		assertEqual(t, string(b), "<https://example.com/thing> <http://schema.org/name> \"thing\" .\n")
+	})
+}
+
+func TestURDNA2015(t *testing.T) {
+	iri := func(s string) rdfNode { return rdfNode{kind: rdfIRI, value: s} }
+	bnode := func(s string) rdfNode { return rdfNode{kind: rdfBlank, value: "_:" + s} }
+	literal := func(s string) rdfNode { return rdfNode{kind: rdfLiteral, value: s, datatype: xsdString} }
+	p, q := iri("http://example.com/p"), iri("http://example.com/q")
+	// relabel returns the quads with the blank nodes renamed and shuffled.
+	relabel := func(quads []rdfQuad, r *rand.Rand) []rdfQuad {
+		names := make(map[string]string)
+		rename := func(n rdfNode) rdfNode {
+			if n.kind != rdfBlank {
+				return n
+			}
+			if _, ok := names[n.value]; !ok {
+				names[n.value] = "_:n" + strings.Repeat("x", r.Intn(5)) + string(rune('a'+len(names)))
+			}
+			n.value = names[n.value]
+			return n
+		}
+		out := make([]rdfQuad, len(quads))
+		for i, quad := range quads {
+			quad.subject, quad.object = rename(quad.subject), rename(quad.object)
+			out[i] = quad
+		}
+		r.Shuffle(len(out), func(i, j int) { out[i], out[j] = out[j], out[i] })
+		return out
+	}
+	tests := []struct {
+		name   string
+		quads  []rdfQuad
+		expect string
+	}{
+		{
+			name: "symmetric blank nodes",
+			quads: []rdfQuad{
+				{subject: bnode("a"), predicate: p, object: bnode("b")},
+				{subject: bnode("b"), predicate: p, object: bnode("a")},
+			},
+			expect: "_:c14n0 <http://example.com/p> _:c14n1 .\n_:c14n1 <http://example.com/p> _:c14n0 .\n",
+		},
+		{
+			name: "cycle distinguished by a literal",
+			quads: []rdfQuad{
+				{subject: bnode("a"), predicate: p, object: bnode("b")},
+				{subject: bnode("b"), predicate: p, object: bnode("c")},
+				{subject: bnode("c"), predicate: p, object: bnode("d")},
+				{subject: bnode("d"), predicate: p, object: bnode("a")},
+				{subject: bnode("a"), predicate: q, object: literal("start")},
+			},
+		},
+		{
+			name: "indistinguishable pairs",
+			quads: []rdfQuad{
+				{subject: bnode("a"), predicate: p, object: bnode("b")},
+				{subject: bnode("c"), predicate: p, object: bnode("d")},
+				{subject: bnode("b"), predicate: q, object: bnode("e")},
+				{subject: bnode("d"), predicate: q, object: bnode("e")},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			expect := urdna2015(test.quads)
+			if len(test.expect) > 0 {
+				assertEqual(t, expect, test.expect)
+			}
+			if strings.Contains(expect, "_:a") || strings.Contains(expect, "_:b ") {
+				t.Fatalf("blank nodes were not relabeled: %s", expect)
+			}
+			r := rand.New(rand.NewSource(1))
+			for i := 0; i < 20; i++ {
+				assertEqual(t, urdna2015(relabel(test.quads, r)), expect)
+			}
+		})
+	}
+}
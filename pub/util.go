@@ -4,12 +4,15 @@ import (
 	"bytes"
 	"context"
 	"crypto/sha256"
+	"crypto/sha512"
 	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/go-fed/activity/streams"
 	"github.com/go-fed/activity/streams/vocab"
+	"github.com/go-fed/httpsig"
+	"io"
 	"net/http"
 	"net/url"
 	"strings"
@@ -25,6 +28,22 @@ var (
 	// set. Can be returned by DelegateActor's PostInbox or PostOutbox so a
 	// Bad Request response is set.
 	ErrTargetRequired = errors.New("target property required on the provided activity")
+	// ErrCreateAddressingMismatch indicates a Create activity is addressed
+	// to different recipients than its object. Returned by the
+	// DelegateActor's PostOutbox when WithConsistentCreateAddressing is
+	// used, so a Bad Request response is set.
+	ErrCreateAddressingMismatch = errors.New("create activity and its object are addressed to different recipients")
+	// ErrUndeliverableAddressing indicates an activity to deliver is not
+	// addressed to any recipient it may be delivered to: it has no 'to',
+	// 'bto', 'cc', 'bcc', or 'audience', or only the Public collection,
+	// which is never delivered to. Returned before the activity is posted
+	// to the outbox, so a Bad Request response is set. An activity meant
+	// to stay on this server is marked with WithLocalOnly instead.
+	ErrUndeliverableAddressing = errors.New("activity is not addressed to any recipient it can be delivered to")
+	// ErrUnhandledActivity indicates no callback handles the activity. Can
+	// be returned by DelegateActor's PostInbox so an Unprocessable Entity
+	// response is set.
+	ErrUnhandledActivity = errors.New("activity type is not handled")
 )
 
 // activityStreamsMediaTypes contains all of the accepted ActivityStreams media
@@ -82,6 +101,55 @@ func isActivityPubGet(r *http.Request) bool {
 	return r.Method == "GET" && headerIsActivityPubMediaType(r.Header.Get(acceptHeader))
 }
 
+// collectionItems returns the members of a Collection, OrderedCollection, or
+// one of their pages, so that unordered and ordered collections are walked
+// alike. The 'orderedItems' are used when present, otherwise the 'items'.
+//
+// Members of an embedded 'first' page are included as well. The IRIs of a
+// 'first' or 'next' page that must be dereferenced to obtain more members are
+// returned as pages.
+//
+// Returns false if the value has neither an 'items' nor an 'orderedItems'
+// property.
+func collectionItems(t vocab.Type) (items []IdProperty, pages []*url.URL, ok bool) {
+	if oi, isOrdered := t.(orderedItemser); isOrdered {
+		ok = true
+		if p := oi.GetActivityStreamsOrderedItems(); p != nil {
+			for iter := p.Begin(); iter != p.End(); iter = iter.Next() {
+				items = append(items, iter)
+			}
+		}
+	}
+	if i, isUnordered := t.(itemser); isUnordered && len(items) == 0 {
+		ok = true
+		if p := i.GetActivityStreamsItems(); p != nil {
+			for iter := p.Begin(); iter != p.End(); iter = iter.Next() {
+				items = append(items, iter)
+			}
+		}
+	}
+	if !ok {
+		return
+	}
+	if f, isPaged := t.(firster); isPaged {
+		if p := f.GetActivityStreamsFirst(); p != nil {
+			if page := p.GetType(); page != nil {
+				pageItems, more, _ := collectionItems(page)
+				items = append(items, pageItems...)
+				pages = append(pages, more...)
+			} else if p.IsIRI() {
+				pages = append(pages, p.GetIRI())
+			}
+		}
+	}
+	if n, isPage := t.(nexter); isPage {
+		if p := n.GetActivityStreamsNext(); p != nil && p.IsIRI() {
+			pages = append(pages, p.GetIRI())
+		}
+	}
+	return
+}
+
 // dedupeOrderedItems deduplicates the 'orderedItems' within an ordered
 // collection type. Deduplication happens by the 'id' property.
 func dedupeOrderedItems(oc orderedItemser) error {
@@ -129,11 +197,14 @@ const (
 	digestDelimiter = "="
 	// SHA-256 string for the Digest header.
 	sha256Digest = "SHA-256"
+	// SHA-512 string for the Digest header.
+	sha512Digest = "SHA-512"
 )
 
 // addResponseHeaders sets headers needed in the HTTP response, such but not
-// limited to the Content-Type, Date, and Digest headers.
-func addResponseHeaders(h http.Header, c Clock, responseContent []byte) {
+// limited to the Content-Type, Date, and Digest headers. The Digest is of the
+// SHA-256 hash of the response content.
+func addResponseHeaders(h http.Header, c Clock, hashed []byte) {
 	h.Set(contentTypeHeader, contentTypeHeaderValue)
 	// RFC 7231 §7.1.1.2
 	h.Set(dateHeader, c.Now().UTC().Format("Mon, 02 Jan 2006 15:04:05")+" GMT")
@@ -141,11 +212,88 @@ func addResponseHeaders(h http.Header, c Clock, responseContent []byte) {
 	var b bytes.Buffer
 	b.WriteString(sha256Digest)
 	b.WriteString(digestDelimiter)
-	hashed := sha256.Sum256(responseContent)
-	b.WriteString(base64.StdEncoding.EncodeToString(hashed[:]))
+	b.WriteString(base64.StdEncoding.EncodeToString(hashed))
 	h.Set(digestHeader, b.String())
 }
 
+// writeSerializedResponse writes the value as the body of the response with
+// the status, streaming its JSON encoding with streams.SerializeTo. The value
+// is encoded twice: first to compute the Digest header, which is written
+// before the body.
+func writeSerializedResponse(w http.ResponseWriter, c Clock, status int, t vocab.Type) error {
+	hash := sha256.New()
+	if err := streams.SerializeTo(hash, t); err != nil {
+		return err
+	}
+	addResponseHeaders(w.Header(), c, hash.Sum(nil))
+	w.WriteHeader(status)
+	return streams.SerializeTo(w, t)
+}
+
+// statusRecorder is a ResponseWriter discarding the response, except for its
+// status. It is given to the delegate when an activity is not received by an
+// HTTP request, such as by ProcessInboxCollection.
+type statusRecorder struct {
+	header http.Header
+	status int
+}
+
+// Header returns the discarded headers.
+func (s *statusRecorder) Header() http.Header {
+	if s.header == nil {
+		s.header = make(http.Header)
+	}
+	return s.header
+}
+
+// Write discards the body.
+func (s *statusRecorder) Write(b []byte) (int, error) {
+	if s.status == 0 {
+		s.status = http.StatusOK
+	}
+	return len(b), nil
+}
+
+// WriteHeader records the status.
+func (s *statusRecorder) WriteHeader(status int) {
+	s.status = status
+}
+
+// unmarshalJSON decodes the JSON into the value like json.Unmarshal, except
+// that numbers are decoded as json.Number instead of float64. This keeps the
+// precision of integers beyond 2^53, such as the large ids or timestamps of
+// some peers, which are then serialized again unchanged.
+func unmarshalJSON(b []byte, v interface{}) error {
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+	if err := d.Decode(v); err != nil {
+		return err
+	}
+	if _, err := d.Token(); err != io.EOF {
+		return fmt.Errorf("invalid JSON: unexpected data after the top-level value")
+	}
+	return nil
+}
+
+// digestValue computes the value of a Digest header for the body.
+//
+// It is used instead of the github.com/go-fed/httpsig library when signing,
+// which appends the hash of nothing to the body instead of hashing it.
+func digestValue(algo httpsig.DigestAlgorithm, body []byte) (string, error) {
+	var sum []byte
+	switch strings.ToUpper(string(algo)) {
+	case sha256Digest:
+		h := sha256.Sum256(body)
+		sum = h[:]
+	case sha512Digest:
+		h := sha512.Sum512(body)
+		sum = h[:]
+	default:
+		return "", fmt.Errorf("unsupported digest algorithm: %s", algo)
+	}
+	return strings.ToUpper(string(algo)) + digestDelimiter + base64.StdEncoding.EncodeToString(sum), nil
+}
+
 // IdProperty is a property that can readily have its id obtained
 type IdProperty interface {
 	// GetIRI returns the IRI of this property. When IsIRI returns false,
@@ -186,7 +334,8 @@ func GetId(t vocab.Type) (*url.URL, error) {
 }
 
 // getInboxForwardingValues obtains the 'inReplyTo', 'object', 'target', and
-// 'tag' values on an ActivityStreams value.
+// 'tag' values on an ActivityStreams value. For a collection, its members and
+// any pages to dereference are obtained as well.
 func getInboxForwardingValues(o vocab.Type) (t []vocab.Type, iri []*url.URL) {
 	// 'inReplyTo'
 	if i, ok := o.(inReplyToer); ok {
@@ -236,6 +385,17 @@ func getInboxForwardingValues(o vocab.Type) (t []vocab.Type, iri []*url.URL) {
 			}
 		}
 	}
+	// 'items' or 'orderedItems', such as of a 'tag' or 'replies'
+	// collection.
+	items, pages, _ := collectionItems(o)
+	for _, item := range items {
+		if tv := item.GetType(); tv != nil {
+			t = append(t, tv)
+		} else if item.IsIRI() {
+			iri = append(iri, item.GetIRI())
+		}
+	}
+	iri = append(iri, pages...)
 	return
 }
 
@@ -357,17 +517,67 @@ func IsPublic(s string) bool {
 	return s == PublicActivityPubIRI || s == publicJsonLD || s == publicJsonLDAS
 }
 
-// getInboxes extracts the 'inbox' IRIs from actor types.
-func getInboxes(t []vocab.Type) (u []*url.URL, err error) {
-	for _, elem := range t {
-		var iri *url.URL
-		iri, err = getInbox(elem)
-		if err != nil {
-			return
+// addressingProperties are the properties whose values may be the Public
+// collection.
+var addressingProperties = []string{"to", "bto", "cc", "bcc", "audience"}
+
+// normalizePublicAddressing replaces the bare 'Public' and compact 'as:Public'
+// forms of the Public collection with the PublicActivityPubIRI in the
+// addressing properties of the JSON, and of any object embedded within it.
+//
+// Older implementations emit the bare form, which has no scheme and so is not
+// deserialized as an IRI, causing its addressing to be lost.
+func normalizePublicAddressing(m map[string]interface{}) {
+	for _, name := range addressingProperties {
+		switch v := m[name].(type) {
+		case string:
+			if IsPublic(v) {
+				m[name] = PublicActivityPubIRI
+			}
+		case []interface{}:
+			for i, elem := range v {
+				if s, ok := elem.(string); ok && IsPublic(s) {
+					v[i] = PublicActivityPubIRI
+				}
+			}
 		}
-		u = append(u, iri)
 	}
-	return
+	switch v := m["object"].(type) {
+	case map[string]interface{}:
+		normalizePublicAddressing(v)
+	case []interface{}:
+		for _, elem := range v {
+			if obj, ok := elem.(map[string]interface{}); ok {
+				normalizePublicAddressing(obj)
+			}
+		}
+	}
+}
+
+// IsPublicIRI determines if an IRI is the Public collection, in any of the
+// forms recognized by IsPublic.
+func IsPublicIRI(iri *url.URL) bool {
+	return iri != nil && IsPublic(iri.String())
+}
+
+// validateAddressing ensures the activity is addressed to at least one
+// recipient it may be delivered to, as otherwise delivering it silently does
+// nothing, returning ErrUndeliverableAddressing if not.
+//
+// The Public collection is never delivered to, so an activity addressed only
+// to it is rejected: it must also be addressed to the followers collection of
+// the actor, or to specific actors, to reach anyone.
+func validateAddressing(activity Activity) error {
+	iris, err := addressedIRIs(activity)
+	if err != nil {
+		return err
+	}
+	for _, iri := range iris {
+		if !IsPublicIRI(iri) {
+			return nil
+		}
+	}
+	return ErrUndeliverableAddressing
 }
 
 // getInbox extracts the 'inbox' IRI from an actor type.
@@ -409,14 +619,61 @@ func removeOne(entries []*url.URL, entry *url.URL) (out []*url.URL) {
 	return out
 }
 
-// stripHiddenRecipients removes "bto" and "bcc" from the activity.
+// hasActor returns true if the value has the IRI in its 'actor' property.
+func hasActor(t vocab.Type, actorIRI *url.URL) bool {
+	ac, ok := t.(actorer)
+	if !ok {
+		return false
+	}
+	actors := ac.GetActivityStreamsActor()
+	if actors == nil {
+		return false
+	}
+	for iter := actors.Begin(); iter != actors.End(); iter = iter.Next() {
+		if id, err := ToId(iter); err == nil && id.String() == actorIRI.String() {
+			return true
+		}
+	}
+	return false
+}
+
+// isAttributedToActor returns true if the value has the IRI in its
+// 'attributedTo' property.
+func isAttributedToActor(t vocab.Type, actorIRI *url.URL) bool {
+	at, ok := t.(attributedToer)
+	if !ok {
+		return false
+	}
+	attrTo := at.GetActivityStreamsAttributedTo()
+	if attrTo == nil {
+		return false
+	}
+	for iter := attrTo.Begin(); iter != attrTo.End(); iter = iter.Next() {
+		if id, err := ToId(iter); err == nil && id.String() == actorIRI.String() {
+			return true
+		}
+	}
+	return false
+}
+
+// stripHiddenRecipients returns a copy of the activity without its "bto" and
+// "bcc", nor those of its objects, for delivery. The activity itself is left
+// unmodified, so the copy stored locally retains its hidden recipients.
 //
 // Note that this requirement of the specification is under "Section 6: Client
 // to Server Interactions", the Social API, and not the Federative API.
-func stripHiddenRecipients(activity Activity) {
-	activity.SetActivityStreamsBto(nil)
-	activity.SetActivityStreamsBcc(nil)
-	op := activity.GetActivityStreamsObject()
+func stripHiddenRecipients(activity Activity) (Activity, error) {
+	t, err := streams.Clone(activity)
+	if err != nil {
+		return nil, err
+	}
+	stripped, ok := t.(Activity)
+	if !ok {
+		return nil, fmt.Errorf("copy of activity is not an Activity: %T", t)
+	}
+	stripped.SetActivityStreamsBto(nil)
+	stripped.SetActivityStreamsBcc(nil)
+	op := stripped.GetActivityStreamsObject()
 	if op != nil {
 		for iter := op.Begin(); iter != op.End(); iter = iter.Next() {
 			if v, ok := iter.GetType().(btoer); ok {
@@ -427,6 +684,7 @@ func stripHiddenRecipients(activity Activity) {
 			}
 		}
 	}
+	return stripped, nil
 }
 
 // mustHaveActivityOriginMatchObjects ensures that the Host in the activity id
@@ -777,7 +1035,7 @@ func mustHaveActivityActorsMatchObjectActors(c context.Context,
 			return err
 		}
 		var m map[string]interface{}
-		if err = json.Unmarshal(b, &m); err != nil {
+		if err = unmarshalJSON(b, &m); err != nil {
 			return err
 		}
 		t, err := streams.ToType(c, m)
@@ -1,7 +1,14 @@
 package pub
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
 	"testing"
+
+	"github.com/go-fed/activity/streams"
+	"github.com/go-fed/activity/streams/vocab"
 )
 
 func TestHeaderIsActivityPubMediaType(t *testing.T) {
@@ -74,3 +81,256 @@ func TestHeaderIsActivityPubMediaType(t *testing.T) {
 		})
 	}
 }
+
+func TestStripHiddenRecipients(t *testing.T) {
+	newActivity := func() Activity {
+		act := streams.NewActivityStreamsCreate()
+		id := streams.NewJSONLDIdProperty()
+		id.Set(mustParse(testNewActivityIRI))
+		act.SetJSONLDId(id)
+		to := streams.NewActivityStreamsToProperty()
+		to.AppendIRI(mustParse(testFederatedActorIRI))
+		act.SetActivityStreamsTo(to)
+		bto := streams.NewActivityStreamsBtoProperty()
+		bto.AppendIRI(mustParse(testFederatedActorIRI2))
+		act.SetActivityStreamsBto(bto)
+		bcc := streams.NewActivityStreamsBccProperty()
+		bcc.AppendIRI(mustParse(testFederatedActorIRI3))
+		act.SetActivityStreamsBcc(bcc)
+		note := streams.NewActivityStreamsNote()
+		noteBto := streams.NewActivityStreamsBtoProperty()
+		noteBto.AppendIRI(mustParse(testFederatedActorIRI2))
+		note.SetActivityStreamsBto(noteBto)
+		noteBcc := streams.NewActivityStreamsBccProperty()
+		noteBcc.AppendIRI(mustParse(testFederatedActorIRI3))
+		note.SetActivityStreamsBcc(noteBcc)
+		op := streams.NewActivityStreamsObjectProperty()
+		op.AppendActivityStreamsNote(note)
+		act.SetActivityStreamsObject(op)
+		return act
+	}
+	t.Run("StripsDeliveredCopy", func(t *testing.T) {
+		// Run
+		stripped, err := stripHiddenRecipients(newActivity())
+		// Verify
+		assertEqual(t, err, nil)
+		b := mustSerializeToBytes(stripped)
+		assertEqual(t, bytes.Contains(b, []byte(`"bto"`)), false)
+		assertEqual(t, bytes.Contains(b, []byte(`"bcc"`)), false)
+		assertEqual(t, bytes.Contains(b, []byte(testFederatedActorIRI2)), false)
+		assertEqual(t, bytes.Contains(b, []byte(testFederatedActorIRI3)), false)
+		assertEqual(t, stripped.GetActivityStreamsTo().At(0).GetIRI().String(), testFederatedActorIRI)
+	})
+	t.Run("RetainsStoredCopy", func(t *testing.T) {
+		// Setup
+		act := newActivity()
+		expected := mustSerializeToBytes(act)
+		// Run
+		_, err := stripHiddenRecipients(act)
+		// Verify
+		assertEqual(t, err, nil)
+		assertByteEqual(t, mustSerializeToBytes(act), expected)
+	})
+}
+
+func TestCollectionItems(t *testing.T) {
+	ids := func(items []IdProperty) string {
+		var s []string
+		for _, item := range items {
+			id, err := ToId(item)
+			if err != nil {
+				t.Fatal(err)
+			}
+			s = append(s, id.String())
+		}
+		return strings.Join(s, " ")
+	}
+	t.Run("ReadsItemsOfUnorderedCollection", func(t *testing.T) {
+		// Setup
+		col := streams.NewActivityStreamsCollection()
+		items := streams.NewActivityStreamsItemsProperty()
+		items.AppendIRI(mustParse(testFederatedActorIRI))
+		items.AppendIRI(mustParse(testFederatedActorIRI2))
+		col.SetActivityStreamsItems(items)
+		// Run
+		got, pages, ok := collectionItems(col)
+		// Verify
+		assertEqual(t, ok, true)
+		assertEqual(t, len(pages), 0)
+		assertEqual(t, ids(got), testFederatedActorIRI+" "+testFederatedActorIRI2)
+	})
+	t.Run("ReadsOrderedItemsOfOrderedCollection", func(t *testing.T) {
+		// Setup
+		col := streams.NewActivityStreamsOrderedCollection()
+		items := streams.NewActivityStreamsOrderedItemsProperty()
+		items.AppendIRI(mustParse(testFederatedActorIRI))
+		col.SetActivityStreamsOrderedItems(items)
+		// Run
+		got, _, ok := collectionItems(col)
+		// Verify
+		assertEqual(t, ok, true)
+		assertEqual(t, ids(got), testFederatedActorIRI)
+	})
+	t.Run("FlattensEmbeddedFirstPageAndReturnsNextPage", func(t *testing.T) {
+		// Setup
+		page := streams.NewActivityStreamsCollectionPage()
+		items := streams.NewActivityStreamsItemsProperty()
+		items.AppendIRI(mustParse(testFederatedActorIRI))
+		page.SetActivityStreamsItems(items)
+		next := streams.NewActivityStreamsNextProperty()
+		next.SetIRI(mustParse(testFederatedActorIRI4))
+		page.SetActivityStreamsNext(next)
+		col := streams.NewActivityStreamsCollection()
+		first := streams.NewActivityStreamsFirstProperty()
+		first.SetActivityStreamsCollectionPage(page)
+		col.SetActivityStreamsFirst(first)
+		// Run
+		got, pages, ok := collectionItems(col)
+		// Verify
+		assertEqual(t, ok, true)
+		assertEqual(t, ids(got), testFederatedActorIRI)
+		assertEqual(t, len(pages), 1)
+		assertEqual(t, pages[0].String(), testFederatedActorIRI4)
+	})
+	t.Run("ReturnsFirstPageIRI", func(t *testing.T) {
+		// Setup
+		col := streams.NewActivityStreamsOrderedCollection()
+		first := streams.NewActivityStreamsFirstProperty()
+		first.SetIRI(mustParse(testFederatedActorIRI3))
+		col.SetActivityStreamsFirst(first)
+		// Run
+		got, pages, ok := collectionItems(col)
+		// Verify
+		assertEqual(t, ok, true)
+		assertEqual(t, len(got), 0)
+		assertEqual(t, len(pages), 1)
+		assertEqual(t, pages[0].String(), testFederatedActorIRI3)
+	})
+	t.Run("IgnoresNonCollection", func(t *testing.T) {
+		// Run
+		got, pages, ok := collectionItems(streams.NewActivityStreamsNote())
+		// Verify
+		assertEqual(t, ok, false)
+		assertEqual(t, len(got), 0)
+		assertEqual(t, len(pages), 0)
+	})
+}
+
+func TestValidateAddressing(t *testing.T) {
+	tests := []struct {
+		name    string
+		to      []string
+		cc      []string
+		isValid bool
+	}{
+		{"NoAddressing", nil, nil, false},
+		{"OnlyPublic", []string{PublicActivityPubIRI}, nil, false},
+		{"PublicAndFollowers", []string{PublicActivityPubIRI}, []string{testPersonIRI + "/followers"}, true},
+		{"Actor", []string{testFederatedActorIRI}, nil, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			act := streams.NewActivityStreamsCreate()
+			if len(test.to) > 0 {
+				to := streams.NewActivityStreamsToProperty()
+				for _, s := range test.to {
+					to.AppendIRI(mustParse(s))
+				}
+				act.SetActivityStreamsTo(to)
+			}
+			if len(test.cc) > 0 {
+				cc := streams.NewActivityStreamsCcProperty()
+				for _, s := range test.cc {
+					cc.AppendIRI(mustParse(s))
+				}
+				act.SetActivityStreamsCc(cc)
+			}
+			err := validateAddressing(act)
+			assertEqual(t, err == nil, test.isValid)
+		})
+	}
+}
+
+func TestIsPublicIRI(t *testing.T) {
+	tests := []struct {
+		name     string
+		iri      string
+		expected bool
+	}{
+		{"Full", PublicActivityPubIRI, true},
+		{"Compact", "as:Public", true},
+		{"Bare", "Public", true},
+		{"Actor", testFederatedActorIRI, false},
+		{"OtherFragment", "https://www.w3.org/ns/activitystreams#Person", false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assertEqual(t, IsPublicIRI(mustParse(test.iri)), test.expected)
+		})
+	}
+	t.Run("Nil", func(t *testing.T) {
+		assertEqual(t, IsPublicIRI(nil), false)
+	})
+}
+
+func TestNormalizePublicAddressing(t *testing.T) {
+	// Setup
+	var m map[string]interface{}
+	err := json.Unmarshal([]byte(`{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "type": "Create",
+  "id": "https://example.com/new/1",
+  "to": ["Public", "https://other.example.com/dakota"],
+  "cc": "as:Public",
+  "object": {
+    "type": "Note",
+    "id": "https://example.com/note/1",
+    "to": "Public"
+  }
+}`), &m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Run
+	normalizePublicAddressing(m)
+	v, err := streams.ToType(context.Background(), m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Verify
+	create := v.(vocab.ActivityStreamsCreate)
+	to := create.GetActivityStreamsTo()
+	assertEqual(t, to.Len(), 2)
+	assertEqual(t, to.At(0).IsIRI(), true)
+	assertEqual(t, to.At(0).GetIRI().String(), PublicActivityPubIRI)
+	assertEqual(t, to.At(1).GetIRI().String(), testFederatedActorIRI)
+	assertEqual(t, create.GetActivityStreamsCc().At(0).GetIRI().String(), PublicActivityPubIRI)
+	note := create.GetActivityStreamsObject().At(0).GetActivityStreamsNote()
+	assertEqual(t, note.GetActivityStreamsTo().At(0).GetIRI().String(), PublicActivityPubIRI)
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	t.Run("PreservesLargeIntegers", func(t *testing.T) {
+		const js = `{"@context":"https://www.w3.org/ns/activitystreams","id":"https://example.com/note/1","type":"Note","ext:snowflake":1234567890123456789}`
+		var m map[string]interface{}
+		if err := unmarshalJSON([]byte(js), &m); err != nil {
+			t.Fatal(err)
+		}
+		v, err := streams.ToType(context.Background(), m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b := mustSerializeToBytes(v)
+		if !bytes.Contains(b, []byte(`"ext:snowflake":1234567890123456789`)) {
+			t.Fatalf("lost the precision of the 64-bit integer: %s", b)
+		}
+	})
+	t.Run("RejectsTrailingData", func(t *testing.T) {
+		var m map[string]interface{}
+		err := unmarshalJSON([]byte(`{"type":"Note"} {}`), &m)
+		if err == nil {
+			t.Fatalf("expected an error")
+		}
+		assertNotEqual(t, json.Unmarshal([]byte(`{"type":"Note"} {}`), &m), nil)
+	})
+}
@@ -0,0 +1,96 @@
+package pub
+
+import (
+	"fmt"
+	"strings"
+)
+
+// objectRequiredTypes are the types of activities that must have an 'object'.
+var objectRequiredTypes = map[string]bool{
+	"Accept":          true,
+	"Add":             true,
+	"Announce":        true,
+	"Block":           true,
+	"Create":          true,
+	"Delete":          true,
+	"Dislike":         true,
+	"Flag":            true,
+	"Follow":          true,
+	"Ignore":          true,
+	"Invite":          true,
+	"Join":            true,
+	"Leave":           true,
+	"Like":            true,
+	"Listen":          true,
+	"Move":            true,
+	"Offer":           true,
+	"Read":            true,
+	"Reject":          true,
+	"Remove":          true,
+	"TentativeAccept": true,
+	"TentativeReject": true,
+	"Undo":            true,
+	"Update":          true,
+	"View":            true,
+}
+
+// targetRequiredTypes are the types of activities that must have a 'target'.
+var targetRequiredTypes = map[string]bool{
+	"Add":    true,
+	"Remove": true,
+}
+
+// ActivityValidationError is returned by ValidateInboundActivity with every
+// structural requirement the activity violates.
+type ActivityValidationError struct {
+	// Violations describes each violated requirement.
+	Violations []string
+}
+
+// Error lists the violations.
+func (e *ActivityValidationError) Error() string {
+	return fmt.Sprintf("invalid activity: %s", strings.Join(e.Violations, "; "))
+}
+
+// ValidateInboundActivity checks the structural requirements of an activity
+// received from a peer, returning an *ActivityValidationError listing every
+// violation, or nil if there are none.
+//
+// The activity must have a 'type' and an 'id' that is an absolute IRI, as
+// received activities are not transient. It must have an 'actor'. Activities
+// whose type requires it, such as a Create or Follow, must have an 'object',
+// and an Add or Remove must also have a 'target'. Other types, such as a
+// generic Activity, require neither.
+//
+// It is meant to be called from PostInboxRequestBodyHook, so that malformed
+// activities are rejected before any side effect.
+func ValidateInboundActivity(a Activity) error {
+	var violations []string
+	name := a.GetTypeName()
+	if len(name) == 0 {
+		violations = append(violations, "'type' is missing")
+	}
+	if id := a.GetJSONLDId(); id == nil || id.Get() == nil {
+		violations = append(violations, "'id' is missing")
+	} else if iri := id.Get(); !iri.IsAbs() || len(iri.Host) == 0 {
+		violations = append(violations, fmt.Sprintf("'id' is not an absolute IRI: %q", iri.String()))
+	}
+	if actor := a.GetActivityStreamsActor(); actor == nil || actor.Len() == 0 {
+		violations = append(violations, "'actor' is missing")
+	}
+	if objectRequiredTypes[name] {
+		if op := a.GetActivityStreamsObject(); op == nil || op.Len() == 0 {
+			violations = append(violations, fmt.Sprintf("'object' is required on %s", name))
+		}
+	}
+	if targetRequiredTypes[name] {
+		t, ok := a.(targeter)
+		if !ok || t.GetActivityStreamsTarget() == nil || t.GetActivityStreamsTarget().Len() == 0 {
+			violations = append(violations, fmt.Sprintf("'target' is required on %s", name))
+		}
+	}
+	if len(violations) > 0 {
+		return &ActivityValidationError{Violations: violations}
+	}
+	return nil
+}
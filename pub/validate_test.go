@@ -0,0 +1,85 @@
+package pub
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-fed/activity/streams"
+)
+
+func TestValidateInboundActivity(t *testing.T) {
+	setId := func(a Activity, iri string) {
+		id := streams.NewJSONLDIdProperty()
+		id.Set(mustParse(iri))
+		a.SetJSONLDId(id)
+	}
+	setActor := func(a Activity) {
+		actor := streams.NewActivityStreamsActorProperty()
+		actor.AppendIRI(mustParse(testFederatedActorIRI))
+		a.SetActivityStreamsActor(actor)
+	}
+	t.Run("AcceptsValidCreate", func(t *testing.T) {
+		// Setup
+		create := streams.NewActivityStreamsCreate()
+		setId(create, testFederatedActivityIRI)
+		setActor(create)
+		op := streams.NewActivityStreamsObjectProperty()
+		op.AppendIRI(mustParse(testNoteId1))
+		create.SetActivityStreamsObject(op)
+		// Run
+		err := ValidateInboundActivity(create)
+		// Verify
+		assertEqual(t, err, nil)
+	})
+	t.Run("AcceptsGenericActivityWithoutObject", func(t *testing.T) {
+		// Setup
+		act := streams.NewActivityStreamsActivity()
+		setId(act, testFederatedActivityIRI)
+		setActor(act)
+		// Run
+		err := ValidateInboundActivity(act)
+		// Verify
+		assertEqual(t, err, nil)
+	})
+	t.Run("ListsEveryViolation", func(t *testing.T) {
+		// Setup
+		create := streams.NewActivityStreamsCreate()
+		// Run
+		err := ValidateInboundActivity(create)
+		// Verify
+		vErr, ok := err.(*ActivityValidationError)
+		assertEqual(t, ok, true)
+		assertEqual(t, strings.Join(vErr.Violations, "; "),
+			"'id' is missing; 'actor' is missing; 'object' is required on Create")
+	})
+	t.Run("RejectsRelativeId", func(t *testing.T) {
+		// Setup
+		like := streams.NewActivityStreamsLike()
+		setId(like, "/activities/1")
+		setActor(like)
+		op := streams.NewActivityStreamsObjectProperty()
+		op.AppendIRI(mustParse(testNoteId1))
+		like.SetActivityStreamsObject(op)
+		// Run
+		err := ValidateInboundActivity(like)
+		// Verify
+		vErr, ok := err.(*ActivityValidationError)
+		assertEqual(t, ok, true)
+		assertEqual(t, len(vErr.Violations), 1)
+	})
+	t.Run("RequiresTargetOnAdd", func(t *testing.T) {
+		// Setup
+		add := streams.NewActivityStreamsAdd()
+		setId(add, testFederatedActivityIRI)
+		setActor(add)
+		op := streams.NewActivityStreamsObjectProperty()
+		op.AppendIRI(mustParse(testNoteId1))
+		add.SetActivityStreamsObject(op)
+		// Run
+		err := ValidateInboundActivity(add)
+		// Verify
+		vErr, ok := err.(*ActivityValidationError)
+		assertEqual(t, ok, true)
+		assertEqual(t, strings.Join(vErr.Violations, "; "), "'target' is required on Add")
+	})
+}
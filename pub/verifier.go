@@ -0,0 +1,662 @@
+package pub
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rsa"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-fed/httpsig"
+)
+
+const (
+	// DefaultClockSkew is the clock skew tolerated by a HttpSigVerifier
+	// when none is specified.
+	DefaultClockSkew = 30 * time.Second
+	// DefaultMaxSignatureAge is the maximum age of a signature accepted by
+	// a HttpSigVerifier when none is specified.
+	DefaultMaxSignatureAge = time.Hour
+)
+
+const (
+	// The HTTP Signature parameters examined when verifying.
+	sigHeadersParam   = "headers"
+	sigCreatedParam   = "created"
+	sigExpiresParam   = "expires"
+	sigAlgorithmParam = "algorithm"
+	// The pseudo-headers for the HTTP Signature parameters.
+	sigCreatedHeader = "(created)"
+	sigExpiresHeader = "(expires)"
+	// The HTTP Signature auth-scheme used with the Authorization header.
+	sigAuthScheme = "Signature "
+	// The prefixes of the HTTP Signature algorithms by kind of key.
+	rsaPrefix    = "rsa-"
+	hmacPrefix   = "hmac-"
+	blake2Prefix = "blake2"
+)
+
+// VerificationReason is the cause of a failure to verify a HTTP Signature.
+type VerificationReason int
+
+const (
+	// ReasonBadSignature is when the signature does not match the key and
+	// the signed headers, or is malformed.
+	ReasonBadSignature VerificationReason = iota
+	// ReasonDigestMismatch is when the signed Digest header does not match
+	// the body of the request.
+	ReasonDigestMismatch
+	// ReasonExpired is when the signature was created outside of the
+	// acceptable window of time, or has expired.
+	ReasonExpired
+	// ReasonMissingHeader is when a header or signature parameter
+	// required to verify the signature is missing.
+	ReasonMissingHeader
+	// ReasonUnsupportedAlgorithm is when the signature or digest
+	// algorithm is not supported.
+	ReasonUnsupportedAlgorithm
+	// ReasonHostMismatch is when the signed Host header does not match the
+	// host of the keyId.
+	ReasonHostMismatch
+)
+
+// String returns a description of the reason.
+func (r VerificationReason) String() string {
+	switch r {
+	case ReasonBadSignature:
+		return "bad signature"
+	case ReasonDigestMismatch:
+		return "digest mismatch"
+	case ReasonExpired:
+		return "expired"
+	case ReasonMissingHeader:
+		return "missing header"
+	case ReasonUnsupportedAlgorithm:
+		return "unsupported algorithm"
+	case ReasonHostMismatch:
+		return "host mismatch"
+	default:
+		return fmt.Sprintf("unknown reason %d", int(r))
+	}
+}
+
+// VerificationError is returned by a HttpSigVerifier when a HTTP Signature
+// fails to be verified.
+//
+// Applications may use the Reason to respond appropriately, for example with
+// 400 Bad Request for a missing header or digest mismatch but 401
+// Unauthorized for a bad or expired signature.
+type VerificationError struct {
+	// Reason is why the verification failed.
+	Reason VerificationReason
+	// Header is the name of the offending header or signature parameter,
+	// if any.
+	Header string
+	// Err is the underlying error.
+	Err error
+	// Mismatch is set when the signature failed to verify and its
+	// 'algorithm' parameter differs from the algorithm it was verified
+	// with.
+	Mismatch *AlgorithmMismatch
+}
+
+// Error returns the reason, offending header, and underlying error.
+func (e *VerificationError) Error() string {
+	if len(e.Header) > 0 {
+		return fmt.Sprintf("http signature verification failed: %s (%s): %s", e.Reason, e.Header, e.Err)
+	}
+	return fmt.Sprintf("http signature verification failed: %s: %s", e.Reason, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *VerificationError) Unwrap() error {
+	return e.Err
+}
+
+// AlgorithmMismatch is a discrepancy between the algorithm declared by the
+// 'algorithm' parameter of a HTTP Signature and the algorithm derived from
+// the key that it was verified with.
+//
+// The declared algorithm is never trusted for verification, so a mismatch is
+// not a failure by itself. Peers commonly declare "hs2019", which requires the
+// algorithm to be derived from the key, or declare "rsa-sha256" regardless of
+// the hash they actually sign with.
+type AlgorithmMismatch struct {
+	// Declared is the value of the 'algorithm' parameter.
+	Declared string
+	// Derived is the algorithm the signature was verified with.
+	Derived httpsig.Algorithm
+}
+
+// newAlgorithmMismatch returns the mismatch between the declared algorithm
+// and the derived one, or nil if there is none or none was declared.
+func newAlgorithmMismatch(params map[string]string, derived httpsig.Algorithm) *AlgorithmMismatch {
+	declared, ok := params[sigAlgorithmParam]
+	if !ok || strings.EqualFold(declared, string(derived)) {
+		return nil
+	}
+	return &AlgorithmMismatch{
+		Declared: declared,
+		Derived:  derived,
+	}
+}
+
+// SignatureAlgorithmMismatch returns the mismatch between the declared and
+// derived algorithms of a signature successfully verified by a Verifier
+// returned by a HttpSigVerifier, so that problematic peers may be logged. It
+// returns nil if there was no mismatch, or if the signature has not been
+// verified.
+func SignatureAlgorithmMismatch(v httpsig.Verifier) *AlgorithmMismatch {
+	if hv, ok := v.(*httpSigVerifier); ok {
+		return hv.mismatch
+	}
+	return nil
+}
+
+// SharedSecretResolver resolves the keyId of a HTTP Signature made by a trusted
+// service, such as an internal worker posting to a local inbox, to the secret
+// it shares with this server. It returns false if the keyId is not one of a
+// shared secret.
+//
+// The keyIds of shared secrets must never collide with those of actors, such
+// as by not being IRIs.
+type SharedSecretResolver func(keyId string) (secret []byte, ok bool)
+
+// VerifySharedSecret verifies the HMAC signature of a request made by a trusted
+// service, using a Verifier returned by a HttpSigVerifier and the secret its
+// keyId resolves to. The HMAC hash is derived from the signature, starting
+// with "hmac-sha256".
+//
+// It returns false without an error if the keyId is not one of a shared
+// secret, so that the application may verify the signature with the public
+// key of an actor instead. Such a signature is never verified with a shared
+// secret, nor is a signature made with a shared secret verified with a public
+// key.
+func VerifySharedSecret(v httpsig.Verifier, secrets SharedSecretResolver) (bool, error) {
+	secret, ok := secrets(v.KeyId())
+	if !ok {
+		return false, nil
+	}
+	return true, v.Verify(secret, httpsig.HMAC_SHA256)
+}
+
+// VerifyHostConsistency checks that the HTTP Signature of the request covers
+// the Host header, and that the signed host is the host of the keyID, which
+// applications may call in AuthenticatePostInbox after verifying the
+// signature. It catches a captured signature being replayed against a
+// different host than the one it was made for.
+//
+// Hosts are compared case-insensitively, and a missing port is the default
+// port of the scheme of the keyID. A *VerificationError is returned with
+// ReasonMissingHeader if the Host header is not signed, or with
+// ReasonHostMismatch if the hosts differ.
+func VerifyHostConsistency(r *http.Request, keyID *url.URL) error {
+	params, err := getSignatureParams(r.Header)
+	if err != nil {
+		return newVerificationError(ReasonBadSignature, "", err)
+	} else if !signedHeaders(params)[hostHeaderName] {
+		return newVerificationError(ReasonMissingHeader, hostHeaderName, fmt.Errorf("http signature does not cover the host header"))
+	}
+	host := r.Host
+	if len(host) == 0 && r.URL != nil {
+		host = r.URL.Host
+	}
+	if keyID == nil || len(keyID.Host) == 0 {
+		return newVerificationError(ReasonHostMismatch, hostHeaderName, fmt.Errorf("keyId %s has no host", keyID))
+	}
+	wantName, wantPort := hostPort(keyID.Host, keyID.Scheme)
+	gotName, gotPort := hostPort(host, keyID.Scheme)
+	if !strings.EqualFold(wantName, gotName) || wantPort != gotPort {
+		return newVerificationError(ReasonHostMismatch, hostHeaderName, fmt.Errorf("signed host %q does not match the host of keyId %s", host, keyID))
+	}
+	return nil
+}
+
+// hostPort splits the host into its name and port, defaulting the port to
+// that of the scheme.
+func hostPort(host, scheme string) (name, port string) {
+	name, port, err := net.SplitHostPort(host)
+	if err != nil {
+		name, port = strings.Trim(host, "[]"), ""
+	}
+	if len(port) == 0 {
+		switch scheme {
+		case "https":
+			port = "443"
+		case "http":
+			port = "80"
+		}
+	}
+	return name, port
+}
+
+// newVerificationError returns a VerificationError for the reason and the
+// offending header, if any.
+func newVerificationError(reason VerificationReason, header string, err error) *VerificationError {
+	return &VerificationError{
+		Reason: reason,
+		Header: header,
+		Err:    err,
+	}
+}
+
+// HttpSigVerifier verifies HTTP Signatures on incoming requests, such as
+// those sent by a HttpSigTransport.
+//
+// In addition to the cryptographic verification done by the
+// github.com/go-fed/httpsig library, it ensures the signature was made
+// recently: the signed '(created)' parameter or 'Date' header must be no
+// further in the future than the clock skew, and no older than the maximum
+// signature age. A signed '(expires)' parameter must not have passed. The
+// current time is always obtained from the Clock. It also ensures every
+// signed header is present, and that a signed 'Digest' header matches the
+// body of the request.
+//
+// Peers differ on whether a signed '(request-target)' includes the query
+// string of the request. By default, a signature is verified with the query
+// string first, as specified by the HTTP Signatures draft, and then without
+// it. WithStrictRequestTarget only accepts the former.
+//
+// A signature is verified with either a public key, such as an
+// *rsa.PublicKey, or a shared secret as a []byte with an HMAC algorithm, as
+// done by VerifySharedSecret. The kind of the key must match the algorithm.
+//
+// Failures are reported as a *VerificationError.
+//
+// It is safe to use concurrently.
+type HttpSigVerifier struct {
+	clock     Clock
+	clockSkew time.Duration
+	maxAge    time.Duration
+	logger    Logger
+	// strictRequestTarget only accepts a '(request-target)' including
+	// the query string.
+	strictRequestTarget bool
+}
+
+// HttpSigVerifierOption configures optional behavior of a HttpSigVerifier.
+type HttpSigVerifierOption func(h *HttpSigVerifier)
+
+// WithVerifierLogger logs the result of each verification made by the
+// HttpSigVerifier to the Logger.
+func WithVerifierLogger(l Logger) HttpSigVerifierOption {
+	return func(h *HttpSigVerifier) {
+		h.logger = l
+	}
+}
+
+// WithStrictRequestTarget only accepts a signed '(request-target)' that
+// includes the query string of the request, rejecting signatures made without
+// it.
+func WithStrictRequestTarget() HttpSigVerifierOption {
+	return func(h *HttpSigVerifier) {
+		h.strictRequestTarget = true
+	}
+}
+
+// NewHttpSigVerifier returns a new HttpSigVerifier.
+//
+// The clockSkew is how far the clocks of peers are permitted to differ from
+// this server's clock. The maxAge is the oldest a signature may be before it
+// is rejected, preventing old signed requests from being replayed. A zero
+// value uses DefaultClockSkew and DefaultMaxSignatureAge respectively.
+//
+// Additional options, such as WithVerifierLogger or WithStrictRequestTarget,
+// may be provided.
+func NewHttpSigVerifier(clock Clock, clockSkew, maxAge time.Duration, opts ...HttpSigVerifierOption) *HttpSigVerifier {
+	if clockSkew == 0 {
+		clockSkew = DefaultClockSkew
+	}
+	if maxAge == 0 {
+		maxAge = DefaultMaxSignatureAge
+	}
+	h := &HttpSigVerifier{
+		clock:     clock,
+		clockSkew: clockSkew,
+		maxAge:    maxAge,
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// NewVerifier parses the HTTP Signature in the request, returning a Verifier
+// that is able to report the key id before verifying the signature.
+//
+// If the signature covers the 'Digest' header, the body of the request is
+// read to be compared against it and replaced so that it may be read again.
+func (h *HttpSigVerifier) NewVerifier(r *http.Request) (httpsig.Verifier, error) {
+	v, err := httpsig.NewVerifier(r)
+	if err != nil {
+		if len(r.Header.Get(string(httpsig.Signature))) == 0 && len(r.Header.Get(string(httpsig.Authorization))) == 0 {
+			return nil, newVerificationError(ReasonMissingHeader, string(httpsig.Signature), err)
+		}
+		return nil, newVerificationError(ReasonBadSignature, "", err)
+	}
+	params, err := getSignatureParams(r.Header)
+	if err != nil {
+		return nil, newVerificationError(ReasonBadSignature, "", err)
+	}
+	var body []byte
+	if signedHeaders(params)[strings.ToLower(digestHeader)] && r.Body != nil {
+		if body, err = ioutil.ReadAll(r.Body); err != nil {
+			return nil, err
+		}
+		r.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+	// Prepare to verify the request target without the query string too.
+	var noQuery httpsig.Verifier
+	if rc := withoutQuery(r); rc != r && !h.strictRequestTarget && signedHeaders(params)[httpsig.RequestTarget] {
+		if noQuery, err = httpsig.NewVerifier(rc); err != nil {
+			return nil, newVerificationError(ReasonBadSignature, "", err)
+		}
+	}
+	targets := []string{requestTarget(r)}
+	if noQuery != nil {
+		targets = append(targets, requestTarget(withoutQuery(r)))
+	}
+	return &httpSigVerifier{
+		Verifier: v,
+		noQuery:  noQuery,
+		targets:  targets,
+		h:        h,
+		header:   r.Header,
+		host:     r.Host,
+		body:     body,
+		params:   params,
+	}, nil
+}
+
+// httpSigVerifier applies the HttpSigVerifier checks before verifying the
+// signature itself.
+type httpSigVerifier struct {
+	httpsig.Verifier
+	// noQuery verifies the request target without the query string, if
+	// the request has one and it may be omitted.
+	noQuery httpsig.Verifier
+	// targets are the request targets the signature may have been made
+	// over, which are verified directly for RSASSA-PSS signatures.
+	targets  []string
+	h        *HttpSigVerifier
+	header   http.Header
+	host     string
+	body     []byte
+	params   map[string]string
+	mismatch *AlgorithmMismatch
+}
+
+// Verify ensures the signed headers are present, the signature is recent, and
+// the digest matches the body before verifying the signature with the given
+// key and algorithm.
+//
+// If the signature fails to verify with an RSA algorithm, the other supported
+// RSA hashes are tried, as peers do not always sign with the algorithm they
+// declare. RSASSA-PSS is also tried if the signature declares PS256, PS512,
+// or "hs2019". The algorithm that succeeded is compared to the declared one,
+// which is obtainable with SignatureAlgorithmMismatch.
+func (v *httpSigVerifier) Verify(pKey crypto.PublicKey, algo httpsig.Algorithm) error {
+	err := v.verify(pKey, algo)
+	if v.h.logger != nil {
+		if err != nil {
+			v.h.logger.Debug("signature verification failed", "keyId", v.KeyId(), "algorithm", algo, "err", err)
+		} else {
+			v.h.logger.Debug("signature verified", "keyId", v.KeyId(), "algorithm", algo, "algorithmMismatch", v.mismatch != nil)
+		}
+	}
+	return err
+}
+
+// verify applies the checks and verifies the signature.
+func (v *httpSigVerifier) verify(pKey crypto.PublicKey, algo httpsig.Algorithm) error {
+	if !isSupportedAlgorithm(algo) {
+		return newVerificationError(ReasonUnsupportedAlgorithm, "", fmt.Errorf("unsupported http signature algorithm: %s", algo))
+	} else if err := checkKeyAlgorithm(pKey, algo); err != nil {
+		return newVerificationError(ReasonUnsupportedAlgorithm, "", err)
+	}
+	signed := signedHeaders(v.params)
+	for name := range signed {
+		if strings.HasPrefix(name, "(") {
+			continue
+		} else if name == hostHeaderName && len(v.host) > 0 {
+			continue
+		} else if len(v.header.Get(name)) == 0 {
+			return newVerificationError(ReasonMissingHeader, name, fmt.Errorf("signed header is missing from the request"))
+		}
+	}
+	if err := v.h.verifyTime(v.header, v.params); err != nil {
+		return err
+	}
+	if signed[strings.ToLower(digestHeader)] {
+		if err := verifyDigest(v.header.Get(digestHeader), v.body); err != nil {
+			return err
+		}
+	}
+	err := v.verifyAlgorithm(pKey, algo)
+	if err == nil {
+		v.mismatch = newAlgorithmMismatch(v.params, algo)
+		return nil
+	}
+	for _, other := range keyAlgorithms(pKey, algo, v.params[sigAlgorithmParam]) {
+		if v.verifyAlgorithm(pKey, other) == nil {
+			v.mismatch = newAlgorithmMismatch(v.params, other)
+			return nil
+		}
+	}
+	vErr := newVerificationError(ReasonBadSignature, "", err)
+	vErr.Mismatch = newAlgorithmMismatch(v.params, algo)
+	return vErr
+}
+
+// verifyAlgorithm verifies the signature with the key and algorithm, with and
+// without the query string of the request target if it may be omitted.
+func (v *httpSigVerifier) verifyAlgorithm(pKey crypto.PublicKey, algo httpsig.Algorithm) error {
+	if hash, ok := pssHashes[algo]; ok {
+		return v.verifyPSS(pKey, hash)
+	}
+	err := v.Verifier.Verify(pKey, algo)
+	if err != nil && v.noQuery != nil && v.noQuery.Verify(pKey, algo) == nil {
+		return nil
+	}
+	return err
+}
+
+// rsaAlgorithms are the RSA algorithms tried when deriving the algorithm of a
+// signature from an RSA key.
+var rsaAlgorithms = []httpsig.Algorithm{
+	httpsig.RSA_SHA256,
+	httpsig.RSA_SHA512,
+	httpsig.RSA_SHA384,
+	httpsig.RSA_SHA224,
+}
+
+// hmacAlgorithms are the HMAC algorithms tried when deriving the algorithm of a
+// signature made with a shared secret.
+var hmacAlgorithms = []httpsig.Algorithm{
+	httpsig.HMAC_SHA256,
+	httpsig.HMAC_SHA512,
+	httpsig.HMAC_SHA384,
+	httpsig.HMAC_SHA224,
+}
+
+// keyAlgorithms returns the algorithms other than algo that a signature may
+// have been made with by the key, in order to derive the actual algorithm.
+//
+// RSASSA-PSS is only tried if the signature declares it, or declares
+// "hs2019", so that the padding a signature was made with is never guessed.
+func keyAlgorithms(pKey crypto.PublicKey, algo httpsig.Algorithm, declared string) []httpsig.Algorithm {
+	var candidates []httpsig.Algorithm
+	switch pKey.(type) {
+	case *rsa.PublicKey:
+		if strings.HasPrefix(string(algo), rsaPrefix) {
+			candidates = rsaAlgorithms
+			if _, ok := pssHashes[httpsig.Algorithm(declared)]; ok {
+				candidates = append([]httpsig.Algorithm{httpsig.Algorithm(declared)}, candidates...)
+			} else if declared == hs2019 {
+				candidates = append(candidates[:len(candidates):len(candidates)], PS512)
+			}
+		}
+	case []byte:
+		if strings.HasPrefix(string(algo), hmacPrefix) {
+			candidates = hmacAlgorithms
+		}
+	}
+	var algos []httpsig.Algorithm
+	for _, a := range candidates {
+		if a != algo {
+			algos = append(algos, a)
+		}
+	}
+	return algos
+}
+
+// checkKeyAlgorithm ensures the algorithm is of the same kind as the key, so
+// that a shared secret is only used with a MAC algorithm and a public key is
+// never used as one. Otherwise a peer could have a signature verified with
+// the public key of another actor used as an HMAC secret, which anyone is
+// able to forge.
+func checkKeyAlgorithm(pKey crypto.PublicKey, algo httpsig.Algorithm) error {
+	isMAC := strings.HasPrefix(string(algo), hmacPrefix) || strings.HasPrefix(string(algo), blake2Prefix)
+	switch pKey.(type) {
+	case []byte:
+		if !isMAC {
+			return fmt.Errorf("http signature algorithm %s cannot be verified with a shared secret", algo)
+		}
+	case *rsa.PublicKey:
+		if !strings.HasPrefix(string(algo), rsaPrefix) {
+			return fmt.Errorf("http signature algorithm %s cannot be verified with an RSA public key", algo)
+		}
+	default:
+		if isMAC {
+			return fmt.Errorf("http signature algorithm %s requires a shared secret, got %T", algo, pKey)
+		}
+	}
+	return nil
+}
+
+// isSupportedAlgorithm determines whether signatures with the algorithm are
+// able to be verified: an RSASSA-PSS algorithm, or one the httpsig library
+// supports, which is an RSA or HMAC algorithm using a supported hash, or a
+// BLAKE2 MAC.
+func isSupportedAlgorithm(algo httpsig.Algorithm) bool {
+	if _, ok := pssHashes[algo]; ok {
+		return true
+	}
+	a := string(algo)
+	for _, prefix := range []string{rsaPrefix, hmacPrefix} {
+		if strings.HasPrefix(a, prefix) {
+			return httpsig.IsSupportedHttpSigAlgorithm(strings.TrimPrefix(a, prefix))
+		}
+	}
+	return httpsig.IsSupportedHttpSigAlgorithm(a)
+}
+
+// verifyDigest ensures the value of a Digest header matches the body, using
+// the first digest algorithm it supports.
+func verifyDigest(digest string, body []byte) error {
+	for _, d := range strings.Split(digest, ",") {
+		kv := strings.SplitN(strings.TrimSpace(d), digestDelimiter, 2)
+		if len(kv) != 2 || !httpsig.IsSupportedDigestAlgorithm(kv[0]) {
+			continue
+		}
+		expected, err := digestValue(httpsig.DigestAlgorithm(kv[0]), body)
+		if err != nil {
+			return err
+		}
+		if expected != strings.ToUpper(kv[0])+digestDelimiter+kv[1] {
+			return newVerificationError(ReasonDigestMismatch, digestHeader, fmt.Errorf("%s digest does not match the body", kv[0]))
+		}
+		return nil
+	}
+	return newVerificationError(ReasonUnsupportedAlgorithm, digestHeader, fmt.Errorf("no supported digest algorithm in %q", digest))
+}
+
+// verifyTime ensures the signature was created within the acceptable window
+// of time and has not expired.
+func (h *HttpSigVerifier) verifyTime(header http.Header, params map[string]string) error {
+	signed := signedHeaders(params)
+	var created time.Time
+	if signed[sigCreatedHeader] {
+		t, err := parseUnixParam(params, sigCreatedParam)
+		if err != nil {
+			return err
+		}
+		created = t
+	} else if signed[strings.ToLower(dateHeader)] {
+		t, err := http.ParseTime(header.Get(dateHeader))
+		if err != nil {
+			return newVerificationError(ReasonBadSignature, dateHeader, fmt.Errorf("cannot parse signed %s header: %s", dateHeader, err))
+		}
+		created = t
+	} else {
+		return newVerificationError(ReasonMissingHeader, dateHeader, fmt.Errorf("http signature covers neither %s nor the %s header", sigCreatedHeader, dateHeader))
+	}
+	now := h.clock.Now()
+	if created.After(now.Add(h.clockSkew)) {
+		return newVerificationError(ReasonExpired, "", fmt.Errorf("http signature was created in the future: %s", created))
+	} else if now.Sub(created) > h.maxAge+h.clockSkew {
+		return newVerificationError(ReasonExpired, "", fmt.Errorf("http signature is older than %s: %s", h.maxAge, created))
+	}
+	if signed[sigExpiresHeader] {
+		expires, err := parseUnixParam(params, sigExpiresParam)
+		if err != nil {
+			return err
+		}
+		if now.After(expires.Add(h.clockSkew)) {
+			return newVerificationError(ReasonExpired, "", fmt.Errorf("http signature expired: %s", expires))
+		}
+	}
+	return nil
+}
+
+// getSignatureParams parses the parameters of the HTTP Signature in either
+// the 'Signature' or 'Authorization' header.
+func getSignatureParams(h http.Header) (map[string]string, error) {
+	s := h.Get(string(httpsig.Signature))
+	if len(s) == 0 {
+		s = strings.TrimPrefix(h.Get(string(httpsig.Authorization)), sigAuthScheme)
+	}
+	params := make(map[string]string)
+	for _, p := range strings.Split(s, ",") {
+		kv := strings.SplitN(strings.TrimSpace(p), "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("malformed http signature parameter: %q", p)
+		}
+		params[kv[0]] = strings.Trim(kv[1], "\"")
+	}
+	return params, nil
+}
+
+// signedHeaders returns the lowercased set of headers covered by the HTTP
+// Signature, defaulting to only the 'Date' header as per the specification.
+func signedHeaders(params map[string]string) map[string]bool {
+	h, ok := params[sigHeadersParam]
+	if !ok {
+		return map[string]bool{strings.ToLower(dateHeader): true}
+	}
+	signed := make(map[string]bool)
+	for _, s := range strings.Fields(h) {
+		signed[strings.ToLower(s)] = true
+	}
+	return signed
+}
+
+// parseUnixParam parses a HTTP Signature parameter containing a Unix
+// timestamp.
+func parseUnixParam(params map[string]string, name string) (time.Time, error) {
+	v, ok := params[name]
+	if !ok {
+		return time.Time{}, newVerificationError(ReasonMissingHeader, name, fmt.Errorf("http signature covers (%s) but the %q parameter is missing", name, name))
+	}
+	i, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return time.Time{}, newVerificationError(ReasonBadSignature, name, fmt.Errorf("cannot parse http signature %q parameter: %s", name, err))
+	}
+	return time.Unix(i, 0), nil
+}
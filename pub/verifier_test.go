@@ -0,0 +1,686 @@
+package pub
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-fed/httpsig"
+	"github.com/golang/mock/gomock"
+)
+
+// mustSignedRequest returns a request signed over the given headers, with the
+// Date header set to the given time.
+func mustSignedRequest(t *testing.T, k *rsa.PrivateKey, date time.Time, headers []string) *http.Request {
+	r, err := http.NewRequest("GET", testNoteId1, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.Header.Set(dateHeader, date.UTC().Format(http.TimeFormat))
+	s, _, err := httpsig.NewSigner([]httpsig.Algorithm{httpsig.RSA_SHA256}, httpsig.DigestSha256, headers, httpsig.Signature)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.SignRequest(k, testPubKeyId, r, nil); err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+// mustSignedPostRequest returns a request with the body, signed over the
+// given headers including its digest, with the Date header set to now.
+func mustSignedPostRequest(t *testing.T, k *rsa.PrivateKey, body []byte, headers []string) *http.Request {
+	r, err := http.NewRequest("POST", testMyInboxIRI, bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.Header.Set(dateHeader, now().UTC().Format(http.TimeFormat))
+	s, _, err := NewHttpSigSigner([]httpsig.Algorithm{httpsig.RSA_SHA256}, httpsig.DigestSha256, headers, httpsig.Signature)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.SignRequest(k, testPubKeyId, r, body); err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+// assertVerificationError ensures the error is a *VerificationError with the
+// reason and header.
+func assertVerificationError(t *testing.T, err error, reason VerificationReason, header string) {
+	t.Helper()
+	vErr, ok := err.(*VerificationError)
+	if !ok {
+		t.Fatalf("expected a *VerificationError, got %T: %v", err, err)
+	}
+	assertEqual(t, vErr.Reason, reason)
+	assertEqual(t, vErr.Header, header)
+}
+
+func TestHttpSigVerifier(t *testing.T) {
+	k, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signed := []string{httpsig.RequestTarget, "date"}
+	verify := func(t *testing.T, c Clock, r *http.Request) error {
+		v, err := NewHttpSigVerifier(c, 0, 0).NewVerifier(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertEqual(t, v.KeyId(), testPubKeyId)
+		return v.Verify(&k.PublicKey, httpsig.RSA_SHA256)
+	}
+	t.Run("VerifiesWithinClockSkew", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		c := NewMockClock(ctl)
+		r := mustSignedRequest(t, k, now().Add(DefaultClockSkew), signed)
+		// Mock
+		c.EXPECT().Now().Return(now())
+		// Run & Verify
+		assertEqual(t, verify(t, c, r), nil)
+	})
+	t.Run("VerifiesWithinMaxAge", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		c := NewMockClock(ctl)
+		r := mustSignedRequest(t, k, now().Add(-DefaultMaxSignatureAge), signed)
+		// Mock
+		c.EXPECT().Now().Return(now())
+		// Run & Verify
+		assertEqual(t, verify(t, c, r), nil)
+	})
+	t.Run("ReturnsErrorIfDateInFuture", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		c := NewMockClock(ctl)
+		r := mustSignedRequest(t, k, now().Add(DefaultClockSkew+time.Second), signed)
+		// Mock
+		c.EXPECT().Now().Return(now())
+		// Run & Verify
+		assertVerificationError(t, verify(t, c, r), ReasonExpired, "")
+	})
+	t.Run("ReturnsErrorIfDateTooOld", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		c := NewMockClock(ctl)
+		r := mustSignedRequest(t, k, now().Add(-DefaultMaxSignatureAge-DefaultClockSkew-time.Second), signed)
+		// Mock
+		c.EXPECT().Now().Return(now())
+		// Run & Verify
+		assertVerificationError(t, verify(t, c, r), ReasonExpired, "")
+	})
+	t.Run("ReturnsErrorIfDateNotSigned", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		c := NewMockClock(ctl)
+		r := mustSignedRequest(t, k, now(), []string{httpsig.RequestTarget})
+		// Run & Verify
+		assertVerificationError(t, verify(t, c, r), ReasonMissingHeader, dateHeader)
+	})
+	t.Run("ReturnsErrorIfSignatureExpired", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		c := NewMockClock(ctl)
+		r := mustSignedRequest(t, k, now(), signed)
+		expires := strconv.FormatInt(now().Add(-time.Minute).Unix(), 10)
+		r.Header.Set("Signature", strings.Replace(
+			r.Header.Get("Signature"),
+			`headers="(request-target) date"`,
+			`headers="(request-target) date (expires)",expires=`+expires,
+			1))
+		// Mock
+		c.EXPECT().Now().Return(now())
+		// Run & Verify
+		assertVerificationError(t, verify(t, c, r), ReasonExpired, "")
+	})
+	t.Run("UsesConfiguredClockSkew", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		c := NewMockClock(ctl)
+		r := mustSignedRequest(t, k, now().Add(2*time.Minute), signed)
+		// Mock
+		c.EXPECT().Now().Return(now())
+		// Run
+		v, err := NewHttpSigVerifier(c, 5*time.Minute, 0).NewVerifier(r)
+		assertEqual(t, err, nil)
+		// Verify
+		assertEqual(t, v.Verify(&k.PublicKey, httpsig.RSA_SHA256), nil)
+	})
+	t.Run("ReturnsErrorIfWrongKey", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		c := NewMockClock(ctl)
+		r := mustSignedRequest(t, k, now(), signed)
+		other, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Mock
+		c.EXPECT().Now().Return(now())
+		// Run
+		v, err := NewHttpSigVerifier(c, 0, 0).NewVerifier(r)
+		assertEqual(t, err, nil)
+		// Verify
+		assertVerificationError(t, v.Verify(&other.PublicKey, httpsig.RSA_SHA256), ReasonBadSignature, "")
+	})
+	t.Run("ReturnsErrorIfUnsupportedAlgorithm", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		c := NewMockClock(ctl)
+		r := mustSignedRequest(t, k, now(), signed)
+		// Run
+		v, err := NewHttpSigVerifier(c, 0, 0).NewVerifier(r)
+		assertEqual(t, err, nil)
+		// Verify
+		assertVerificationError(t, v.Verify(&k.PublicKey, httpsig.Algorithm("rsa-md5")), ReasonUnsupportedAlgorithm, "")
+	})
+	t.Run("ReturnsErrorIfSignedHeaderMissing", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		c := NewMockClock(ctl)
+		r := mustSignedRequest(t, k, now(), signed)
+		r.Header.Del(dateHeader)
+		// Run
+		v, err := NewHttpSigVerifier(c, 0, 0).NewVerifier(r)
+		assertEqual(t, err, nil)
+		// Verify
+		assertVerificationError(t, v.Verify(&k.PublicKey, httpsig.RSA_SHA256), ReasonMissingHeader, "date")
+	})
+	t.Run("ReturnsErrorIfNoSignature", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		c := NewMockClock(ctl)
+		r, err := http.NewRequest("GET", testNoteId1, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Run
+		_, err = NewHttpSigVerifier(c, 0, 0).NewVerifier(r)
+		// Verify
+		assertVerificationError(t, err, ReasonMissingHeader, "Signature")
+	})
+	t.Run("VerifiesMatchingDigest", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		c := NewMockClock(ctl)
+		body := []byte(`{"type":"Create"}`)
+		r := mustSignedPostRequest(t, k, body, []string{httpsig.RequestTarget, "date", "digest"})
+		// Mock
+		c.EXPECT().Now().Return(now())
+		// Run & Verify
+		assertEqual(t, verify(t, c, r), nil)
+		b, err := ioutil.ReadAll(r.Body)
+		assertEqual(t, err, nil)
+		assertByteEqual(t, b, body)
+	})
+	t.Run("ReturnsErrorIfDigestMismatch", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		c := NewMockClock(ctl)
+		r := mustSignedPostRequest(t, k, []byte(`{"type":"Create"}`), []string{httpsig.RequestTarget, "date", "digest"})
+		r.Body = ioutil.NopCloser(bytes.NewReader([]byte(`{"type":"Delete"}`)))
+		// Mock
+		c.EXPECT().Now().Return(now())
+		// Run & Verify
+		assertVerificationError(t, verify(t, c, r), ReasonDigestMismatch, digestHeader)
+	})
+	t.Run("VerifiesEmptyBodyDigest", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		c := NewMockClock(ctl)
+		r := mustSignedPostRequest(t, k, []byte{}, []string{httpsig.RequestTarget, "date", "digest"})
+		// Mock
+		c.EXPECT().Now().Return(now())
+		// Run & Verify
+		assertEqual(t, r.Header.Get(digestHeader), "SHA-256=47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU=")
+		assertEqual(t, verify(t, c, r), nil)
+	})
+	t.Run("VerifiesEmptyBodyDigestWithoutBody", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		c := NewMockClock(ctl)
+		r := mustSignedPostRequest(t, k, nil, []string{httpsig.RequestTarget, "date", "digest"})
+		r.Body = nil
+		// Mock
+		c.EXPECT().Now().Return(now())
+		// Run & Verify
+		assertEqual(t, verify(t, c, r), nil)
+	})
+	t.Run("ReturnsErrorIfEmptyBodyDigestMismatch", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		c := NewMockClock(ctl)
+		r := mustSignedPostRequest(t, k, []byte(`{"type":"Create"}`), []string{httpsig.RequestTarget, "date", "digest"})
+		r.Body = http.NoBody
+		// Mock
+		c.EXPECT().Now().Return(now())
+		// Run & Verify
+		assertVerificationError(t, verify(t, c, r), ReasonDigestMismatch, digestHeader)
+	})
+	t.Run("VerificationErrorDescribesReasonAndHeader", func(t *testing.T) {
+		err := &VerificationError{
+			Reason: ReasonDigestMismatch,
+			Header: digestHeader,
+			Err:    testErr,
+		}
+		assertEqual(t, err.Error(), "http signature verification failed: digest mismatch (Digest): "+testErr.Error())
+	})
+}
+
+func TestHttpSigVerifierAlgorithmMismatch(t *testing.T) {
+	k, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// signedRequest signs with the algorithm, but declares another one.
+	signedRequest := func(t *testing.T, algo httpsig.Algorithm, declared string) *http.Request {
+		r, err := http.NewRequest("GET", testNoteId1, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r.Header.Set(dateHeader, now().UTC().Format(http.TimeFormat))
+		s, _, err := httpsig.NewSigner([]httpsig.Algorithm{algo}, httpsig.DigestSha256, []string{httpsig.RequestTarget, "date"}, httpsig.Signature)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := s.SignRequest(k, testPubKeyId, r, nil); err != nil {
+			t.Fatal(err)
+		}
+		sig := r.Header.Get(string(httpsig.Signature))
+		r.Header.Set(string(httpsig.Signature), strings.Replace(sig, `algorithm="`+string(algo)+`"`, `algorithm="`+declared+`"`, 1))
+		return r
+	}
+	newVerifier := func(t *testing.T, ctl *gomock.Controller, r *http.Request) httpsig.Verifier {
+		c := NewMockClock(ctl)
+		c.EXPECT().Now().Return(now())
+		v, err := NewHttpSigVerifier(c, 0, 0).NewVerifier(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return v
+	}
+	t.Run("NoMismatchWhenDeclaredMatches", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		v := newVerifier(t, ctl, signedRequest(t, httpsig.RSA_SHA256, string(httpsig.RSA_SHA256)))
+		// Run
+		err := v.Verify(&k.PublicKey, httpsig.RSA_SHA256)
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, SignatureAlgorithmMismatch(v), (*AlgorithmMismatch)(nil))
+	})
+	t.Run("RecordsHs2019", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		v := newVerifier(t, ctl, signedRequest(t, httpsig.RSA_SHA256, "hs2019"))
+		// Run
+		err := v.Verify(&k.PublicKey, httpsig.RSA_SHA256)
+		// Verify
+		assertEqual(t, err, nil)
+		m := SignatureAlgorithmMismatch(v)
+		assertEqual(t, m.Declared, "hs2019")
+		assertEqual(t, m.Derived, httpsig.RSA_SHA256)
+	})
+	t.Run("DerivesHashFromKeyAndSignature", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		v := newVerifier(t, ctl, signedRequest(t, httpsig.RSA_SHA512, string(httpsig.RSA_SHA256)))
+		// Run
+		err := v.Verify(&k.PublicKey, httpsig.RSA_SHA256)
+		// Verify
+		assertEqual(t, err, nil)
+		m := SignatureAlgorithmMismatch(v)
+		assertEqual(t, m.Declared, string(httpsig.RSA_SHA256))
+		assertEqual(t, m.Derived, httpsig.RSA_SHA512)
+	})
+	t.Run("ReportsMismatchOnFailure", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		v := newVerifier(t, ctl, signedRequest(t, httpsig.RSA_SHA256, "hs2019"))
+		other, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Run
+		err = v.Verify(&other.PublicKey, httpsig.RSA_SHA256)
+		// Verify
+		assertVerificationError(t, err, ReasonBadSignature, "")
+		m := err.(*VerificationError).Mismatch
+		assertEqual(t, m.Declared, "hs2019")
+		assertEqual(t, m.Derived, httpsig.RSA_SHA256)
+		assertEqual(t, SignatureAlgorithmMismatch(v), (*AlgorithmMismatch)(nil))
+	})
+}
+
+func TestHttpSigVerifierRequestTargetQuery(t *testing.T) {
+	k, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const pageIRI = testNoteId1 + "?page=2"
+	// signedRequest signs a GET of the page, with or without the query
+	// string in the request target.
+	signedRequest := func(t *testing.T, includeQuery bool) *http.Request {
+		r, err := http.NewRequest("GET", pageIRI, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r.Header.Set(dateHeader, now().UTC().Format(http.TimeFormat))
+		s, _, err := NewHttpSigSigner([]httpsig.Algorithm{httpsig.RSA_SHA256}, httpsig.DigestSha256, MinimalGETHeaders, httpsig.Signature)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !includeQuery {
+			s = WithoutRequestTargetQuery(s)
+		}
+		if err := s.SignRequest(k, testPubKeyId, r, nil); err != nil {
+			t.Fatal(err)
+		}
+		assertEqual(t, r.URL.String(), pageIRI)
+		return r
+	}
+	newVerifier := func(t *testing.T, ctl *gomock.Controller, r *http.Request, strict bool) httpsig.Verifier {
+		c := NewMockClock(ctl)
+		c.EXPECT().Now().Return(now())
+		var opts []HttpSigVerifierOption
+		if strict {
+			opts = append(opts, WithStrictRequestTarget())
+		}
+		v, err := NewHttpSigVerifier(c, 0, 0, opts...).NewVerifier(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return v
+	}
+	tests := []struct {
+		name         string
+		includeQuery bool
+		strict       bool
+		expectErr    bool
+	}{
+		{
+			name:         "AcceptsWithQuery",
+			includeQuery: true,
+		},
+		{
+			name:         "AcceptsWithoutQuery",
+			includeQuery: false,
+		},
+		{
+			name:         "StrictAcceptsWithQuery",
+			includeQuery: true,
+			strict:       true,
+		},
+		{
+			name:         "StrictRejectsWithoutQuery",
+			includeQuery: false,
+			strict:       true,
+			expectErr:    true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Setup
+			ctl := gomock.NewController(t)
+			defer ctl.Finish()
+			v := newVerifier(t, ctl, signedRequest(t, test.includeQuery), test.strict)
+			// Run
+			err := v.Verify(&k.PublicKey, httpsig.RSA_SHA256)
+			// Verify
+			if test.expectErr {
+				assertVerificationError(t, err, ReasonBadSignature, "")
+			} else {
+				assertEqual(t, err, nil)
+			}
+		})
+	}
+	t.Run("RejectsOtherQuery", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		r := signedRequest(t, true)
+		r.URL.RawQuery = "page=3"
+		v := newVerifier(t, ctl, r, false)
+		// Run
+		err := v.Verify(&k.PublicKey, httpsig.RSA_SHA256)
+		// Verify
+		assertVerificationError(t, err, ReasonBadSignature, "")
+	})
+}
+
+func TestHttpSigVerifierSharedSecret(t *testing.T) {
+	k, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	secret := []byte("internal shared secret")
+	const workerKeyId = "internal-worker"
+	secrets := func(keyId string) ([]byte, bool) {
+		if keyId == workerKeyId {
+			return secret, true
+		}
+		return nil, false
+	}
+	// signedRequest signs the request with the algorithm and key.
+	signedRequest := func(t *testing.T, algo httpsig.Algorithm, key interface{}, keyId string) *http.Request {
+		r, err := http.NewRequest("POST", testMyInboxIRI, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r.Header.Set(dateHeader, now().UTC().Format(http.TimeFormat))
+		s, _, err := httpsig.NewSigner([]httpsig.Algorithm{algo}, httpsig.DigestSha256, []string{httpsig.RequestTarget, "date"}, httpsig.Signature)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := s.SignRequest(key, keyId, r, nil); err != nil {
+			t.Fatal(err)
+		}
+		return r
+	}
+	newVerifier := func(t *testing.T, ctl *gomock.Controller, r *http.Request) httpsig.Verifier {
+		c := NewMockClock(ctl)
+		c.EXPECT().Now().Return(now()).AnyTimes()
+		v, err := NewHttpSigVerifier(c, 0, 0).NewVerifier(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return v
+	}
+	t.Run("VerifiesHMACSignature", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		v := newVerifier(t, ctl, signedRequest(t, httpsig.HMAC_SHA256, secret, workerKeyId))
+		ok, err := VerifySharedSecret(v, secrets)
+		assertEqual(t, ok, true)
+		assertEqual(t, err, nil)
+	})
+	t.Run("DerivesHMACHash", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		v := newVerifier(t, ctl, signedRequest(t, httpsig.HMAC_SHA512, secret, workerKeyId))
+		ok, err := VerifySharedSecret(v, secrets)
+		assertEqual(t, ok, true)
+		assertEqual(t, err, nil)
+	})
+	t.Run("ReturnsErrorIfWrongSecret", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		v := newVerifier(t, ctl, signedRequest(t, httpsig.HMAC_SHA256, []byte("other secret"), workerKeyId))
+		ok, err := VerifySharedSecret(v, secrets)
+		assertEqual(t, ok, true)
+		assertVerificationError(t, err, ReasonBadSignature, "")
+	})
+	t.Run("IgnoresKeyIdOfActor", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		v := newVerifier(t, ctl, signedRequest(t, httpsig.RSA_SHA256, k, testPubKeyId))
+		ok, err := VerifySharedSecret(v, secrets)
+		assertEqual(t, ok, false)
+		assertEqual(t, err, nil)
+		assertEqual(t, v.Verify(&k.PublicKey, httpsig.RSA_SHA256), nil)
+	})
+	t.Run("RejectsSecretWithPublicKeyAlgorithm", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		v := newVerifier(t, ctl, signedRequest(t, httpsig.HMAC_SHA256, secret, workerKeyId))
+		assertVerificationError(t, v.Verify(secret, httpsig.RSA_SHA256), ReasonUnsupportedAlgorithm, "")
+	})
+	t.Run("RejectsPublicKeyWithHMACAlgorithm", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		v := newVerifier(t, ctl, signedRequest(t, httpsig.RSA_SHA256, k, testPubKeyId))
+		assertVerificationError(t, v.Verify(&k.PublicKey, httpsig.HMAC_SHA256), ReasonUnsupportedAlgorithm, "")
+	})
+}
+
+func TestVerifyHostConsistency(t *testing.T) {
+	k, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signedHeaders := []string{httpsig.RequestTarget, "host", "date"}
+	t.Run("AcceptsMatchingHost", func(t *testing.T) {
+		r := mustSignedPostRequest(t, k, nil, signedHeaders)
+		assertEqual(t, VerifyHostConsistency(r, mustParse("https://example.com/actor#main-key")), nil)
+	})
+	t.Run("AcceptsDefaultPortAndCase", func(t *testing.T) {
+		r := mustSignedPostRequest(t, k, nil, signedHeaders)
+		assertEqual(t, VerifyHostConsistency(r, mustParse("https://EXAMPLE.com:443/actor#main-key")), nil)
+	})
+	t.Run("RejectsMismatchedHost", func(t *testing.T) {
+		r := mustSignedPostRequest(t, k, nil, signedHeaders)
+		err := VerifyHostConsistency(r, mustParse("https://other.example.com/actor#main-key"))
+		assertVerificationError(t, err, ReasonHostMismatch, "host")
+	})
+	t.Run("RejectsMismatchedPort", func(t *testing.T) {
+		r := mustSignedPostRequest(t, k, nil, signedHeaders)
+		err := VerifyHostConsistency(r, mustParse("https://example.com:8443/actor#main-key"))
+		assertVerificationError(t, err, ReasonHostMismatch, "host")
+	})
+	t.Run("RejectsReplayedAgainstDifferentHost", func(t *testing.T) {
+		r := mustSignedPostRequest(t, k, nil, signedHeaders)
+		r.Host = "other.example.com"
+		err := VerifyHostConsistency(r, mustParse("https://example.com/actor#main-key"))
+		assertVerificationError(t, err, ReasonHostMismatch, "host")
+	})
+	t.Run("RejectsUnsignedHost", func(t *testing.T) {
+		r := mustSignedPostRequest(t, k, nil, []string{httpsig.RequestTarget, "date"})
+		err := VerifyHostConsistency(r, mustParse("https://example.com/actor#main-key"))
+		assertVerificationError(t, err, ReasonMissingHeader, "host")
+	})
+}
+
+func TestHttpSigVerifierRSAPSS(t *testing.T) {
+	k, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body := []byte(`{"type":"Note"}`)
+	// signedRequest signs with the algorithm, then declares another one if
+	// it is not empty.
+	signedRequest := func(t *testing.T, algo httpsig.Algorithm, declared string) *http.Request {
+		r, err := http.NewRequest("POST", testMyInboxIRI+"?page=1", bytes.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		r.Header.Set(dateHeader, now().UTC().Format(http.TimeFormat))
+		s, used, err := NewHttpSigSigner([]httpsig.Algorithm{algo}, httpsig.DigestSha256, StandardPOSTHeaders, httpsig.Signature)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertEqual(t, used, algo)
+		if err := s.SignRequest(k, testPubKeyId, r, body); err != nil {
+			t.Fatal(err)
+		}
+		if len(declared) > 0 {
+			sig := r.Header.Get(string(httpsig.Signature))
+			r.Header.Set(string(httpsig.Signature), strings.Replace(sig, `algorithm="`+string(algo)+`"`, `algorithm="`+declared+`"`, 1))
+		}
+		// Received requests have their Host in the request only.
+		r.Header.Del(hostHeaderName)
+		return r
+	}
+	newVerifier := func(t *testing.T, ctl *gomock.Controller, r *http.Request) httpsig.Verifier {
+		c := NewMockClock(ctl)
+		c.EXPECT().Now().Return(now())
+		v, err := NewHttpSigVerifier(c, 0, 0).NewVerifier(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return v
+	}
+	for _, algo := range []httpsig.Algorithm{PS256, PS512} {
+		t.Run("RoundTrips"+string(algo), func(t *testing.T) {
+			ctl := gomock.NewController(t)
+			defer ctl.Finish()
+			v := newVerifier(t, ctl, signedRequest(t, algo, ""))
+			assertEqual(t, v.Verify(&k.PublicKey, algo), nil)
+			assertEqual(t, SignatureAlgorithmMismatch(v), (*AlgorithmMismatch)(nil))
+		})
+	}
+	t.Run("VerifiesWithDeclaredPSS", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		v := newVerifier(t, ctl, signedRequest(t, PS256, ""))
+		assertEqual(t, v.Verify(&k.PublicKey, httpsig.RSA_SHA256), nil)
+		assertEqual(t, SignatureAlgorithmMismatch(v), (*AlgorithmMismatch)(nil))
+	})
+	t.Run("DerivesPSSFromHs2019", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		v := newVerifier(t, ctl, signedRequest(t, PS512, hs2019))
+		assertEqual(t, v.Verify(&k.PublicKey, httpsig.RSA_SHA256), nil)
+		assertEqual(t, SignatureAlgorithmMismatch(v).Derived, PS512)
+	})
+	t.Run("PSSDoesNotVerifyAsPKCS1", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		r := signedRequest(t, PS256, string(httpsig.RSA_SHA256))
+		v := newVerifier(t, ctl, r)
+		assertVerificationError(t, v.Verify(&k.PublicKey, httpsig.RSA_SHA256), ReasonBadSignature, "")
+	})
+	t.Run("PKCS1DoesNotVerifyAsPSS", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		v := newVerifier(t, ctl, signedRequest(t, httpsig.RSA_SHA256, ""))
+		assertVerificationError(t, v.Verify(&k.PublicKey, PS256), ReasonBadSignature, "")
+	})
+	t.Run("RejectsSecretWithPSS", func(t *testing.T) {
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		r := signedRequest(t, PS256, "")
+		v, err := NewHttpSigVerifier(NewMockClock(ctl), 0, 0).NewVerifier(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertVerificationError(t, v.Verify([]byte("secret"), PS256), ReasonUnsupportedAlgorithm, "")
+	})
+}
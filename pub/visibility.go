@@ -0,0 +1,293 @@
+package pub
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/go-fed/activity/streams"
+	"github.com/go-fed/activity/streams/vocab"
+)
+
+// InCollection returns true if the Collection or OrderedCollection at the
+// collection IRI contains the item.
+//
+// The Database's InCollection is preferred if it is a CollectionChecker.
+// Otherwise, the collection is obtained with Get and its items are searched,
+// which is only possible for collections owned by this server. Collections
+// owned by peers are never considered to contain the item.
+func InCollection(c context.Context, db Database, collection, item *url.URL) (bool, error) {
+	if err := db.Lock(c, collection); err != nil {
+		return false, err
+	}
+	defer db.Unlock(c, collection)
+	if cc, ok := db.(CollectionChecker); ok {
+		return cc.InCollection(c, collection, item)
+	}
+	if owns, err := db.Owns(c, collection); err != nil || !owns {
+		return false, err
+	}
+	t, err := db.Get(c, collection)
+	if err != nil {
+		return false, err
+	}
+	items, _, _ := collectionItems(t)
+	for _, i := range items {
+		if id, err := ToId(i); err == nil && id.String() == item.String() {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// IsVisibleTo determines whether the viewer may see the object, based on its
+// addressing. It is meant to be used when authorizing requests such as
+// AuthenticateGetInbox or those to GetHandlerFunc, for example to enforce
+// followers-only visibility.
+//
+// The object is visible if it is addressed to the Public collection, if the
+// viewer is one of its 'attributedTo' or 'actor', if the viewer is addressed
+// directly, or if the viewer is in one of the collections it is addressed to,
+// such as the author's followers. Collections are checked with InCollection.
+//
+// A nil viewer is anonymous, and may only see objects addressed to the
+// Public collection.
+func IsVisibleTo(c context.Context, db Database, object vocab.Type, viewer *url.URL) (bool, error) {
+	addressed, err := addressedIRIs(object)
+	if err != nil {
+		return false, err
+	}
+	for _, iri := range addressed {
+		if IsPublicIRI(iri) {
+			return true, nil
+		}
+	}
+	if viewer == nil {
+		return false, nil
+	} else if isAttributedToActor(object, viewer) || hasActor(object, viewer) {
+		return true, nil
+	}
+	for _, iri := range addressed {
+		if iri.String() == viewer.String() {
+			return true, nil
+		}
+	}
+	for _, iri := range addressed {
+		if in, err := InCollection(c, db, iri, viewer); err != nil {
+			return false, err
+		} else if in {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// addressedIRIs returns the ids in the 'to', 'bto', 'cc', 'bcc', and
+// 'audience' properties of the value.
+func addressedIRIs(t vocab.Type) (iris []*url.URL, err error) {
+	add := func(i IdProperty) error {
+		id, err := ToId(i)
+		if err != nil {
+			return err
+		}
+		iris = append(iris, id)
+		return nil
+	}
+	if v, ok := t.(toer); ok && v.GetActivityStreamsTo() != nil {
+		p := v.GetActivityStreamsTo()
+		for iter := p.Begin(); iter != p.End(); iter = iter.Next() {
+			if err = add(iter); err != nil {
+				return
+			}
+		}
+	}
+	if v, ok := t.(btoer); ok && v.GetActivityStreamsBto() != nil {
+		p := v.GetActivityStreamsBto()
+		for iter := p.Begin(); iter != p.End(); iter = iter.Next() {
+			if err = add(iter); err != nil {
+				return
+			}
+		}
+	}
+	if v, ok := t.(ccer); ok && v.GetActivityStreamsCc() != nil {
+		p := v.GetActivityStreamsCc()
+		for iter := p.Begin(); iter != p.End(); iter = iter.Next() {
+			if err = add(iter); err != nil {
+				return
+			}
+		}
+	}
+	if v, ok := t.(bccer); ok && v.GetActivityStreamsBcc() != nil {
+		p := v.GetActivityStreamsBcc()
+		for iter := p.Begin(); iter != p.End(); iter = iter.Next() {
+			if err = add(iter); err != nil {
+				return
+			}
+		}
+	}
+	if v, ok := t.(audiencer); ok && v.GetActivityStreamsAudience() != nil {
+		p := v.GetActivityStreamsAudience()
+		for iter := p.Begin(); iter != p.End(); iter = iter.Next() {
+			if err = add(iter); err != nil {
+				return
+			}
+		}
+	}
+	return
+}
+
+// addressingIds returns the ids in each of the 'to', 'bto', 'cc', 'bcc', and
+// 'audience' properties of the value, keyed by the property name. Properties
+// without ids are absent.
+func addressingIds(t vocab.Type) (ids map[string][]*url.URL, err error) {
+	ids = make(map[string][]*url.URL)
+	add := func(name string, i IdProperty) error {
+		id, err := ToId(i)
+		if err != nil {
+			return err
+		}
+		ids[name] = append(ids[name], id)
+		return nil
+	}
+	if v, ok := t.(toer); ok && v.GetActivityStreamsTo() != nil {
+		p := v.GetActivityStreamsTo()
+		for iter := p.Begin(); iter != p.End(); iter = iter.Next() {
+			if err = add("to", iter); err != nil {
+				return
+			}
+		}
+	}
+	if v, ok := t.(btoer); ok && v.GetActivityStreamsBto() != nil {
+		p := v.GetActivityStreamsBto()
+		for iter := p.Begin(); iter != p.End(); iter = iter.Next() {
+			if err = add("bto", iter); err != nil {
+				return
+			}
+		}
+	}
+	if v, ok := t.(ccer); ok && v.GetActivityStreamsCc() != nil {
+		p := v.GetActivityStreamsCc()
+		for iter := p.Begin(); iter != p.End(); iter = iter.Next() {
+			if err = add("cc", iter); err != nil {
+				return
+			}
+		}
+	}
+	if v, ok := t.(bccer); ok && v.GetActivityStreamsBcc() != nil {
+		p := v.GetActivityStreamsBcc()
+		for iter := p.Begin(); iter != p.End(); iter = iter.Next() {
+			if err = add("bcc", iter); err != nil {
+				return
+			}
+		}
+	}
+	if v, ok := t.(audiencer); ok && v.GetActivityStreamsAudience() != nil {
+		p := v.GetActivityStreamsAudience()
+		for iter := p.Begin(); iter != p.End(); iter = iter.Next() {
+			if err = add("audience", iter); err != nil {
+				return
+			}
+		}
+	}
+	return
+}
+
+// sameAddressing returns true if each addressing property has the same ids,
+// regardless of their order or duplicates.
+func sameAddressing(a, b map[string][]*url.URL) bool {
+	set := func(ids []*url.URL) map[string]bool {
+		m := make(map[string]bool, len(ids))
+		for _, id := range ids {
+			m[id.String()] = true
+		}
+		return m
+	}
+	for _, name := range []string{"to", "bto", "cc", "bcc", "audience"} {
+		sa, sb := set(a[name]), set(b[name])
+		if len(sa) != len(sb) {
+			return false
+		}
+		for id := range sa {
+			if !sb[id] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// setAddressing sets the 'to', 'bto', 'cc', 'bcc', and 'audience' properties
+// of the activity to the ids, keyed by the property name.
+func setAddressing(activity Activity, ids map[string][]*url.URL) {
+	if v, ok := activity.(toer); ok && len(ids["to"]) > 0 {
+		p := streams.NewActivityStreamsToProperty()
+		for _, id := range ids["to"] {
+			p.AppendIRI(id)
+		}
+		v.SetActivityStreamsTo(p)
+	}
+	if v, ok := activity.(btoer); ok && len(ids["bto"]) > 0 {
+		p := streams.NewActivityStreamsBtoProperty()
+		for _, id := range ids["bto"] {
+			p.AppendIRI(id)
+		}
+		v.SetActivityStreamsBto(p)
+	}
+	if v, ok := activity.(ccer); ok && len(ids["cc"]) > 0 {
+		p := streams.NewActivityStreamsCcProperty()
+		for _, id := range ids["cc"] {
+			p.AppendIRI(id)
+		}
+		v.SetActivityStreamsCc(p)
+	}
+	if v, ok := activity.(bccer); ok && len(ids["bcc"]) > 0 {
+		p := streams.NewActivityStreamsBccProperty()
+		for _, id := range ids["bcc"] {
+			p.AppendIRI(id)
+		}
+		v.SetActivityStreamsBcc(p)
+	}
+	if v, ok := activity.(audiencer); ok && len(ids["audience"]) > 0 {
+		p := streams.NewActivityStreamsAudienceProperty()
+		for _, id := range ids["audience"] {
+			p.AppendIRI(id)
+		}
+		v.SetActivityStreamsAudience(p)
+	}
+}
+
+// checkCreateAddressing ensures the Create and each of its embedded objects
+// are addressed to the same recipients in each of the 'to', 'bto', 'cc',
+// 'bcc', and 'audience' properties, returning ErrCreateAddressingMismatch
+// otherwise. Objects referenced by IRI are not checked.
+//
+// If the Create is not addressed at all, the addressing of its first embedded
+// object is copied to it first.
+func checkCreateAddressing(create Activity) error {
+	op := create.GetActivityStreamsObject()
+	if op == nil {
+		return nil
+	}
+	ids, err := addressingIds(create)
+	if err != nil {
+		return err
+	}
+	copied := len(ids) > 0
+	for iter := op.Begin(); iter != op.End(); iter = iter.Next() {
+		t := iter.GetType()
+		if t == nil {
+			continue
+		}
+		objIds, err := addressingIds(t)
+		if err != nil {
+			return err
+		}
+		if !copied {
+			setAddressing(create, objIds)
+			ids = objIds
+			copied = true
+		} else if !sameAddressing(ids, objIds) {
+			return ErrCreateAddressingMismatch
+		}
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+package pub
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-fed/activity/streams"
+	"github.com/go-fed/activity/streams/vocab"
+	"github.com/golang/mock/gomock"
+)
+
+// collectionCheckerDatabase is a Database that is also a CollectionChecker.
+type collectionCheckerDatabase struct {
+	*MockDatabase
+	*MockCollectionChecker
+}
+
+func TestIsVisibleTo(t *testing.T) {
+	ctx := context.Background()
+	const followersIRI = "https://example.com/addison/followers"
+	newNoteFn := func(to string) vocab.ActivityStreamsNote {
+		n := streams.NewActivityStreamsNote()
+		attrTo := streams.NewActivityStreamsAttributedToProperty()
+		attrTo.AppendIRI(mustParse(testPersonIRI))
+		n.SetActivityStreamsAttributedTo(attrTo)
+		toProp := streams.NewActivityStreamsToProperty()
+		toProp.AppendIRI(mustParse(to))
+		n.SetActivityStreamsTo(toProp)
+		return n
+	}
+	t.Run("PublicIsVisibleToAnonymous", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		db := NewMockDatabase(ctl)
+		// Run
+		v, err := IsVisibleTo(ctx, db, newNoteFn(PublicActivityPubIRI), nil)
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, v, true)
+	})
+	t.Run("FollowersOnlyIsNotVisibleToAnonymous", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		db := NewMockDatabase(ctl)
+		// Run
+		v, err := IsVisibleTo(ctx, db, newNoteFn(followersIRI), nil)
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, v, false)
+	})
+	t.Run("VisibleToAuthor", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		db := NewMockDatabase(ctl)
+		// Run
+		v, err := IsVisibleTo(ctx, db, newNoteFn(followersIRI), mustParse(testPersonIRI))
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, v, true)
+	})
+	t.Run("VisibleToDirectlyAddressed", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		db := NewMockDatabase(ctl)
+		// Run
+		v, err := IsVisibleTo(ctx, db, newNoteFn(testFederatedActorIRI), mustParse(testFederatedActorIRI))
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, v, true)
+	})
+	t.Run("UsesCollectionChecker", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		db := NewMockDatabase(ctl)
+		cc := NewMockCollectionChecker(ctl)
+		// Mock
+		db.EXPECT().Lock(ctx, mustParse(followersIRI))
+		cc.EXPECT().InCollection(ctx, mustParse(followersIRI), mustParse(testFederatedActorIRI)).Return(true, nil)
+		db.EXPECT().Unlock(ctx, mustParse(followersIRI))
+		// Run
+		v, err := IsVisibleTo(ctx, collectionCheckerDatabase{db, cc}, newNoteFn(followersIRI), mustParse(testFederatedActorIRI))
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, v, true)
+	})
+	t.Run("SearchesOwnedCollectionWithoutCollectionChecker", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		db := NewMockDatabase(ctl)
+		followers := streams.NewActivityStreamsOrderedCollection()
+		oi := streams.NewActivityStreamsOrderedItemsProperty()
+		oi.AppendIRI(mustParse(testFederatedActorIRI2))
+		oi.AppendIRI(mustParse(testFederatedActorIRI))
+		followers.SetActivityStreamsOrderedItems(oi)
+		// Mock
+		db.EXPECT().Lock(ctx, mustParse(followersIRI))
+		db.EXPECT().Owns(ctx, mustParse(followersIRI)).Return(true, nil)
+		db.EXPECT().Get(ctx, mustParse(followersIRI)).Return(followers, nil)
+		db.EXPECT().Unlock(ctx, mustParse(followersIRI))
+		// Run
+		v, err := IsVisibleTo(ctx, db, newNoteFn(followersIRI), mustParse(testFederatedActorIRI))
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, v, true)
+	})
+	t.Run("NotVisibleToNonFollower", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		db := NewMockDatabase(ctl)
+		followers := streams.NewActivityStreamsCollection()
+		items := streams.NewActivityStreamsItemsProperty()
+		items.AppendIRI(mustParse(testFederatedActorIRI2))
+		followers.SetActivityStreamsItems(items)
+		// Mock
+		db.EXPECT().Lock(ctx, mustParse(followersIRI))
+		db.EXPECT().Owns(ctx, mustParse(followersIRI)).Return(true, nil)
+		db.EXPECT().Get(ctx, mustParse(followersIRI)).Return(followers, nil)
+		db.EXPECT().Unlock(ctx, mustParse(followersIRI))
+		// Run
+		v, err := IsVisibleTo(ctx, db, newNoteFn(followersIRI), mustParse(testFederatedActorIRI))
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, v, false)
+	})
+	t.Run("DoesNotSearchPeerCollections", func(t *testing.T) {
+		// Setup
+		ctl := gomock.NewController(t)
+		defer ctl.Finish()
+		db := NewMockDatabase(ctl)
+		peerFollowers := mustParse("https://other.example.com/dakota/followers")
+		// Mock
+		db.EXPECT().Lock(ctx, peerFollowers)
+		db.EXPECT().Owns(ctx, peerFollowers).Return(false, nil)
+		db.EXPECT().Unlock(ctx, peerFollowers)
+		// Run
+		v, err := IsVisibleTo(ctx, db, newNoteFn(peerFollowers.String()), mustParse(testFederatedActorIRI))
+		// Verify
+		assertEqual(t, err, nil)
+		assertEqual(t, v, false)
+	})
+}
@@ -0,0 +1,17 @@
+package streams
+
+import (
+	"github.com/go-fed/activity/streams/vocab"
+)
+
+// Clone returns a deep copy of the value, which shares no mutable state with
+// it: modifying one, including the values of its unknown properties, never
+// affects the other.
+//
+// The copy is made by the value's Clone method, without serializing it.
+// Unknown properties and the '@context' the value was deserialized with are
+// kept, and natural language maps keep their type. The returned error is
+// currently always nil.
+func Clone(t vocab.Type) (vocab.Type, error) {
+	return t.Clone(), nil
+}
@@ -0,0 +1,53 @@
+package streams
+
+import (
+	"fmt"
+
+	"github.com/go-fed/activity/streams/vocab"
+)
+
+// NewFocalPoint returns a 'focalPoint' property with the x and y coordinates
+// of the focal point of an image, which are each within [-1, 1].
+func NewFocalPoint(x, y float64) (vocab.TootFocalPointProperty, error) {
+	if err := checkFocalPointCoordinate(x); err != nil {
+		return nil, err
+	} else if err := checkFocalPointCoordinate(y); err != nil {
+		return nil, err
+	}
+	p := NewTootFocalPointProperty()
+	p.AppendXMLSchemaFloat(x)
+	p.AppendXMLSchemaFloat(y)
+	return p, nil
+}
+
+// FocalPoint obtains the x and y coordinates of a 'focalPoint' property.
+//
+// The deserialized property retains any value it does not understand, so an
+// error is returned unless it is exactly two numbers, each within [-1, 1].
+func FocalPoint(p vocab.TootFocalPointProperty) (x, y float64, err error) {
+	if p == nil || p.Len() != 2 {
+		err = fmt.Errorf("focalPoint must have exactly two values")
+		return
+	}
+	var xy [2]float64
+	for i := range xy {
+		iter := p.At(i)
+		if !iter.IsXMLSchemaFloat() {
+			err = fmt.Errorf("focalPoint value at index %d is not a number", i)
+			return
+		}
+		xy[i] = iter.Get()
+		if err = checkFocalPointCoordinate(xy[i]); err != nil {
+			return
+		}
+	}
+	return xy[0], xy[1], nil
+}
+
+// checkFocalPointCoordinate ensures a focal point coordinate is within [-1, 1].
+func checkFocalPointCoordinate(v float64) error {
+	if v < -1 || v > 1 {
+		return fmt.Errorf("focalPoint coordinate %v is not within [-1, 1]", v)
+	}
+	return nil
+}
@@ -56,6 +56,9 @@ var ActivityStreamsDocumentName string = "Document"
 // TootEmojiName is the string literal of the name for the Emoji type in the Toot vocabulary.
 var TootEmojiName string = "Emoji"
 
+// ActivityStreamsEndpointsName is the string literal of the name for the Endpoints type in the ActivityStreams vocabulary.
+var ActivityStreamsEndpointsName string = "Endpoints"
+
 // ActivityStreamsEventName is the string literal of the name for the Event type in the ActivityStreams vocabulary.
 var ActivityStreamsEventName string = "Event"
 
@@ -68,6 +71,9 @@ var ActivityStreamsFollowName string = "Follow"
 // ActivityStreamsGroupName is the string literal of the name for the Group type in the ActivityStreams vocabulary.
 var ActivityStreamsGroupName string = "Group"
 
+// ActivityStreamsHashtagName is the string literal of the name for the Hashtag type in the ActivityStreams vocabulary.
+var ActivityStreamsHashtagName string = "Hashtag"
+
 // TootIdentityProofName is the string literal of the name for the IdentityProof type in the Toot vocabulary.
 var TootIdentityProofName string = "IdentityProof"
 
@@ -134,6 +140,9 @@ var ActivityStreamsPlaceName string = "Place"
 // ActivityStreamsProfileName is the string literal of the name for the Profile type in the ActivityStreams vocabulary.
 var ActivityStreamsProfileName string = "Profile"
 
+// SchemaPropertyValueName is the string literal of the name for the PropertyValue type in the Schema vocabulary.
+var SchemaPropertyValueName string = "PropertyValue"
+
 // W3IDSecurityV1PublicKeyName is the string literal of the name for the PublicKey type in the W3IDSecurityV1 vocabulary.
 var W3IDSecurityV1PublicKeyName string = "PublicKey"
 
@@ -197,6 +206,9 @@ var ActivityStreamsAccuracyPropertyName string = "accuracy"
 // ActivityStreamsActorPropertyName is the string literal of the name for the actor property in the ActivityStreams vocabulary.
 var ActivityStreamsActorPropertyName string = "actor"
 
+// ActivityStreamsAlsoKnownAsPropertyName is the string literal of the name for the alsoKnownAs property in the ActivityStreams vocabulary.
+var ActivityStreamsAlsoKnownAsPropertyName string = "alsoKnownAs"
+
 // ActivityStreamsAltitudePropertyName is the string literal of the name for the altitude property in the ActivityStreams vocabulary.
 var ActivityStreamsAltitudePropertyName string = "altitude"
 
@@ -281,9 +293,15 @@ var ForgeFedEarlyItemsPropertyName string = "earlyItems"
 // ActivityStreamsEndTimePropertyName is the string literal of the name for the endTime property in the ActivityStreams vocabulary.
 var ActivityStreamsEndTimePropertyName string = "endTime"
 
+// ActivityStreamsEndpointsPropertyName is the string literal of the name for the endpoints property in the ActivityStreams vocabulary.
+var ActivityStreamsEndpointsPropertyName string = "endpoints"
+
 // TootFeaturedPropertyName is the string literal of the name for the featured property in the Toot vocabulary.
 var TootFeaturedPropertyName string = "featured"
 
+// TootFeaturedTagsPropertyName is the string literal of the name for the featuredTags property in the Toot vocabulary.
+var TootFeaturedTagsPropertyName string = "featuredTags"
+
 // ForgeFedFilesAddedPropertyName is the string literal of the name for the filesAdded property in the ForgeFed vocabulary.
 var ForgeFedFilesAddedPropertyName string = "filesAdded"
 
@@ -296,6 +314,9 @@ var ForgeFedFilesRemovedPropertyName string = "filesRemoved"
 // ActivityStreamsFirstPropertyName is the string literal of the name for the first property in the ActivityStreams vocabulary.
 var ActivityStreamsFirstPropertyName string = "first"
 
+// TootFocalPointPropertyName is the string literal of the name for the focalPoint property in the Toot vocabulary.
+var TootFocalPointPropertyName string = "focalPoint"
+
 // ActivityStreamsFollowersPropertyName is the string literal of the name for the followers property in the ActivityStreams vocabulary.
 var ActivityStreamsFollowersPropertyName string = "followers"
 
@@ -368,6 +389,9 @@ var ActivityStreamsManuallyApprovesFollowersPropertyName string = "manuallyAppro
 // ActivityStreamsMediaTypePropertyName is the string literal of the name for the mediaType property in the ActivityStreams vocabulary.
 var ActivityStreamsMediaTypePropertyName string = "mediaType"
 
+// ActivityStreamsMovedToPropertyName is the string literal of the name for the movedTo property in the ActivityStreams vocabulary.
+var ActivityStreamsMovedToPropertyName string = "movedTo"
+
 // ActivityStreamsNamePropertyName is the string literal of the name for the name property in the ActivityStreams vocabulary.
 var ActivityStreamsNamePropertyName string = "name"
 
@@ -377,6 +401,12 @@ var ActivityStreamsNamePropertyMapName string = "nameMap"
 // ActivityStreamsNextPropertyName is the string literal of the name for the next property in the ActivityStreams vocabulary.
 var ActivityStreamsNextPropertyName string = "next"
 
+// ActivityStreamsOauthAuthorizationEndpointPropertyName is the string literal of the name for the oauthAuthorizationEndpoint property in the ActivityStreams vocabulary.
+var ActivityStreamsOauthAuthorizationEndpointPropertyName string = "oauthAuthorizationEndpoint"
+
+// ActivityStreamsOauthTokenEndpointPropertyName is the string literal of the name for the oauthTokenEndpoint property in the ActivityStreams vocabulary.
+var ActivityStreamsOauthTokenEndpointPropertyName string = "oauthTokenEndpoint"
+
 // ActivityStreamsObjectPropertyName is the string literal of the name for the object property in the ActivityStreams vocabulary.
 var ActivityStreamsObjectPropertyName string = "object"
 
@@ -410,6 +440,9 @@ var ActivityStreamsPrevPropertyName string = "prev"
 // ActivityStreamsPreviewPropertyName is the string literal of the name for the preview property in the ActivityStreams vocabulary.
 var ActivityStreamsPreviewPropertyName string = "preview"
 
+// ActivityStreamsProvideClientKeyPropertyName is the string literal of the name for the provideClientKey property in the ActivityStreams vocabulary.
+var ActivityStreamsProvideClientKeyPropertyName string = "provideClientKey"
+
 // W3IDSecurityV1PublicKeyPropertyName is the string literal of the name for the publicKey property in the W3IDSecurityV1 vocabulary.
 var W3IDSecurityV1PublicKeyPropertyName string = "publicKey"
 
@@ -440,9 +473,15 @@ var ActivityStreamsResultPropertyName string = "result"
 // ActivityStreamsSensitivePropertyName is the string literal of the name for the sensitive property in the ActivityStreams vocabulary.
 var ActivityStreamsSensitivePropertyName string = "sensitive"
 
+// ActivityStreamsSharedInboxPropertyName is the string literal of the name for the sharedInbox property in the ActivityStreams vocabulary.
+var ActivityStreamsSharedInboxPropertyName string = "sharedInbox"
+
 // ActivityStreamsSharesPropertyName is the string literal of the name for the shares property in the ActivityStreams vocabulary.
 var ActivityStreamsSharesPropertyName string = "shares"
 
+// ActivityStreamsSignClientKeyPropertyName is the string literal of the name for the signClientKey property in the ActivityStreams vocabulary.
+var ActivityStreamsSignClientKeyPropertyName string = "signClientKey"
+
 // TootSignatureAlgorithmPropertyName is the string literal of the name for the signatureAlgorithm property in the Toot vocabulary.
 var TootSignatureAlgorithmPropertyName string = "signatureAlgorithm"
 
@@ -497,9 +536,15 @@ var ActivityStreamsUnitsPropertyName string = "units"
 // ActivityStreamsUpdatedPropertyName is the string literal of the name for the updated property in the ActivityStreams vocabulary.
 var ActivityStreamsUpdatedPropertyName string = "updated"
 
+// ActivityStreamsUploadMediaPropertyName is the string literal of the name for the uploadMedia property in the ActivityStreams vocabulary.
+var ActivityStreamsUploadMediaPropertyName string = "uploadMedia"
+
 // ActivityStreamsUrlPropertyName is the string literal of the name for the url property in the ActivityStreams vocabulary.
 var ActivityStreamsUrlPropertyName string = "url"
 
+// SchemaValuePropertyName is the string literal of the name for the value property in the Schema vocabulary.
+var SchemaValuePropertyName string = "value"
+
 // TootVotersCountPropertyName is the string literal of the name for the votersCount property in the Toot vocabulary.
 var TootVotersCountPropertyName string = "votersCount"
 
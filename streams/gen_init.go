@@ -5,6 +5,7 @@ package streams
 import (
 	propertyaccuracy "github.com/go-fed/activity/streams/impl/activitystreams/property_accuracy"
 	propertyactor "github.com/go-fed/activity/streams/impl/activitystreams/property_actor"
+	propertyalsoknownas "github.com/go-fed/activity/streams/impl/activitystreams/property_alsoknownas"
 	propertyaltitude "github.com/go-fed/activity/streams/impl/activitystreams/property_altitude"
 	propertyanyof "github.com/go-fed/activity/streams/impl/activitystreams/property_anyof"
 	propertyattachment "github.com/go-fed/activity/streams/impl/activitystreams/property_attachment"
@@ -20,6 +21,7 @@ import (
 	propertydeleted "github.com/go-fed/activity/streams/impl/activitystreams/property_deleted"
 	propertydescribes "github.com/go-fed/activity/streams/impl/activitystreams/property_describes"
 	propertyduration "github.com/go-fed/activity/streams/impl/activitystreams/property_duration"
+	propertyendpoints "github.com/go-fed/activity/streams/impl/activitystreams/property_endpoints"
 	propertyendtime "github.com/go-fed/activity/streams/impl/activitystreams/property_endtime"
 	propertyfirst "github.com/go-fed/activity/streams/impl/activitystreams/property_first"
 	propertyfollowers "github.com/go-fed/activity/streams/impl/activitystreams/property_followers"
@@ -43,8 +45,11 @@ import (
 	propertylongitude "github.com/go-fed/activity/streams/impl/activitystreams/property_longitude"
 	propertymanuallyapprovesfollowers "github.com/go-fed/activity/streams/impl/activitystreams/property_manuallyapprovesfollowers"
 	propertymediatype "github.com/go-fed/activity/streams/impl/activitystreams/property_mediatype"
+	propertymovedto "github.com/go-fed/activity/streams/impl/activitystreams/property_movedto"
 	propertyname "github.com/go-fed/activity/streams/impl/activitystreams/property_name"
 	propertynext "github.com/go-fed/activity/streams/impl/activitystreams/property_next"
+	propertyoauthauthorizationendpoint "github.com/go-fed/activity/streams/impl/activitystreams/property_oauthauthorizationendpoint"
+	propertyoauthtokenendpoint "github.com/go-fed/activity/streams/impl/activitystreams/property_oauthtokenendpoint"
 	propertyobject "github.com/go-fed/activity/streams/impl/activitystreams/property_object"
 	propertyoneof "github.com/go-fed/activity/streams/impl/activitystreams/property_oneof"
 	propertyordereditems "github.com/go-fed/activity/streams/impl/activitystreams/property_ordereditems"
@@ -54,6 +59,7 @@ import (
 	propertypreferredusername "github.com/go-fed/activity/streams/impl/activitystreams/property_preferredusername"
 	propertyprev "github.com/go-fed/activity/streams/impl/activitystreams/property_prev"
 	propertypreview "github.com/go-fed/activity/streams/impl/activitystreams/property_preview"
+	propertyprovideclientkey "github.com/go-fed/activity/streams/impl/activitystreams/property_provideclientkey"
 	propertypublished "github.com/go-fed/activity/streams/impl/activitystreams/property_published"
 	propertyradius "github.com/go-fed/activity/streams/impl/activitystreams/property_radius"
 	propertyrel "github.com/go-fed/activity/streams/impl/activitystreams/property_rel"
@@ -61,7 +67,9 @@ import (
 	propertyreplies "github.com/go-fed/activity/streams/impl/activitystreams/property_replies"
 	propertyresult "github.com/go-fed/activity/streams/impl/activitystreams/property_result"
 	propertysensitive "github.com/go-fed/activity/streams/impl/activitystreams/property_sensitive"
+	propertysharedinbox "github.com/go-fed/activity/streams/impl/activitystreams/property_sharedinbox"
 	propertyshares "github.com/go-fed/activity/streams/impl/activitystreams/property_shares"
+	propertysignclientkey "github.com/go-fed/activity/streams/impl/activitystreams/property_signclientkey"
 	propertysource "github.com/go-fed/activity/streams/impl/activitystreams/property_source"
 	propertystartindex "github.com/go-fed/activity/streams/impl/activitystreams/property_startindex"
 	propertystarttime "github.com/go-fed/activity/streams/impl/activitystreams/property_starttime"
@@ -74,6 +82,7 @@ import (
 	propertytotalitems "github.com/go-fed/activity/streams/impl/activitystreams/property_totalitems"
 	propertyunits "github.com/go-fed/activity/streams/impl/activitystreams/property_units"
 	propertyupdated "github.com/go-fed/activity/streams/impl/activitystreams/property_updated"
+	propertyuploadmedia "github.com/go-fed/activity/streams/impl/activitystreams/property_uploadmedia"
 	propertyurl "github.com/go-fed/activity/streams/impl/activitystreams/property_url"
 	propertywidth "github.com/go-fed/activity/streams/impl/activitystreams/property_width"
 	typeaccept "github.com/go-fed/activity/streams/impl/activitystreams/type_accept"
@@ -91,10 +100,12 @@ import (
 	typedelete "github.com/go-fed/activity/streams/impl/activitystreams/type_delete"
 	typedislike "github.com/go-fed/activity/streams/impl/activitystreams/type_dislike"
 	typedocument "github.com/go-fed/activity/streams/impl/activitystreams/type_document"
+	typeendpoints "github.com/go-fed/activity/streams/impl/activitystreams/type_endpoints"
 	typeevent "github.com/go-fed/activity/streams/impl/activitystreams/type_event"
 	typeflag "github.com/go-fed/activity/streams/impl/activitystreams/type_flag"
 	typefollow "github.com/go-fed/activity/streams/impl/activitystreams/type_follow"
 	typegroup "github.com/go-fed/activity/streams/impl/activitystreams/type_group"
+	typehashtag "github.com/go-fed/activity/streams/impl/activitystreams/type_hashtag"
 	typeignore "github.com/go-fed/activity/streams/impl/activitystreams/type_ignore"
 	typeimage "github.com/go-fed/activity/streams/impl/activitystreams/type_image"
 	typeintransitiveactivity "github.com/go-fed/activity/streams/impl/activitystreams/type_intransitiveactivity"
@@ -155,9 +166,13 @@ import (
 	typerepository "github.com/go-fed/activity/streams/impl/forgefed/type_repository"
 	typeticket "github.com/go-fed/activity/streams/impl/forgefed/type_ticket"
 	typeticketdependency "github.com/go-fed/activity/streams/impl/forgefed/type_ticketdependency"
+	propertyvalue "github.com/go-fed/activity/streams/impl/schema/property_value"
+	typepropertyvalue "github.com/go-fed/activity/streams/impl/schema/type_propertyvalue"
 	propertyblurhash "github.com/go-fed/activity/streams/impl/toot/property_blurhash"
 	propertydiscoverable "github.com/go-fed/activity/streams/impl/toot/property_discoverable"
 	propertyfeatured "github.com/go-fed/activity/streams/impl/toot/property_featured"
+	propertyfeaturedtags "github.com/go-fed/activity/streams/impl/toot/property_featuredtags"
+	propertyfocalpoint "github.com/go-fed/activity/streams/impl/toot/property_focalpoint"
 	propertysignaturealgorithm "github.com/go-fed/activity/streams/impl/toot/property_signaturealgorithm"
 	propertysignaturevalue "github.com/go-fed/activity/streams/impl/toot/property_signaturevalue"
 	propertyvoterscount "github.com/go-fed/activity/streams/impl/toot/property_voterscount"
@@ -184,6 +199,7 @@ func init() {
 	mgr = &Manager{}
 	propertyaccuracy.SetManager(mgr)
 	propertyactor.SetManager(mgr)
+	propertyalsoknownas.SetManager(mgr)
 	propertyaltitude.SetManager(mgr)
 	propertyanyof.SetManager(mgr)
 	propertyattachment.SetManager(mgr)
@@ -199,6 +215,7 @@ func init() {
 	propertydeleted.SetManager(mgr)
 	propertydescribes.SetManager(mgr)
 	propertyduration.SetManager(mgr)
+	propertyendpoints.SetManager(mgr)
 	propertyendtime.SetManager(mgr)
 	propertyfirst.SetManager(mgr)
 	propertyfollowers.SetManager(mgr)
@@ -222,8 +239,11 @@ func init() {
 	propertylongitude.SetManager(mgr)
 	propertymanuallyapprovesfollowers.SetManager(mgr)
 	propertymediatype.SetManager(mgr)
+	propertymovedto.SetManager(mgr)
 	propertyname.SetManager(mgr)
 	propertynext.SetManager(mgr)
+	propertyoauthauthorizationendpoint.SetManager(mgr)
+	propertyoauthtokenendpoint.SetManager(mgr)
 	propertyobject.SetManager(mgr)
 	propertyoneof.SetManager(mgr)
 	propertyordereditems.SetManager(mgr)
@@ -233,6 +253,7 @@ func init() {
 	propertypreferredusername.SetManager(mgr)
 	propertyprev.SetManager(mgr)
 	propertypreview.SetManager(mgr)
+	propertyprovideclientkey.SetManager(mgr)
 	propertypublished.SetManager(mgr)
 	propertyradius.SetManager(mgr)
 	propertyrel.SetManager(mgr)
@@ -240,7 +261,9 @@ func init() {
 	propertyreplies.SetManager(mgr)
 	propertyresult.SetManager(mgr)
 	propertysensitive.SetManager(mgr)
+	propertysharedinbox.SetManager(mgr)
 	propertyshares.SetManager(mgr)
+	propertysignclientkey.SetManager(mgr)
 	propertysource.SetManager(mgr)
 	propertystartindex.SetManager(mgr)
 	propertystarttime.SetManager(mgr)
@@ -253,6 +276,7 @@ func init() {
 	propertytotalitems.SetManager(mgr)
 	propertyunits.SetManager(mgr)
 	propertyupdated.SetManager(mgr)
+	propertyuploadmedia.SetManager(mgr)
 	propertyurl.SetManager(mgr)
 	propertywidth.SetManager(mgr)
 	typeaccept.SetManager(mgr)
@@ -270,10 +294,12 @@ func init() {
 	typedelete.SetManager(mgr)
 	typedislike.SetManager(mgr)
 	typedocument.SetManager(mgr)
+	typeendpoints.SetManager(mgr)
 	typeevent.SetManager(mgr)
 	typeflag.SetManager(mgr)
 	typefollow.SetManager(mgr)
 	typegroup.SetManager(mgr)
+	typehashtag.SetManager(mgr)
 	typeignore.SetManager(mgr)
 	typeimage.SetManager(mgr)
 	typeintransitiveactivity.SetManager(mgr)
@@ -334,9 +360,13 @@ func init() {
 	typerepository.SetManager(mgr)
 	typeticket.SetManager(mgr)
 	typeticketdependency.SetManager(mgr)
+	propertyvalue.SetManager(mgr)
+	typepropertyvalue.SetManager(mgr)
 	propertyblurhash.SetManager(mgr)
 	propertydiscoverable.SetManager(mgr)
 	propertyfeatured.SetManager(mgr)
+	propertyfeaturedtags.SetManager(mgr)
+	propertyfocalpoint.SetManager(mgr)
 	propertysignaturealgorithm.SetManager(mgr)
 	propertysignaturevalue.SetManager(mgr)
 	propertyvoterscount.SetManager(mgr)
@@ -361,10 +391,12 @@ func init() {
 	typedelete.SetTypePropertyConstructor(NewJSONLDTypeProperty)
 	typedislike.SetTypePropertyConstructor(NewJSONLDTypeProperty)
 	typedocument.SetTypePropertyConstructor(NewJSONLDTypeProperty)
+	typeendpoints.SetTypePropertyConstructor(NewJSONLDTypeProperty)
 	typeevent.SetTypePropertyConstructor(NewJSONLDTypeProperty)
 	typeflag.SetTypePropertyConstructor(NewJSONLDTypeProperty)
 	typefollow.SetTypePropertyConstructor(NewJSONLDTypeProperty)
 	typegroup.SetTypePropertyConstructor(NewJSONLDTypeProperty)
+	typehashtag.SetTypePropertyConstructor(NewJSONLDTypeProperty)
 	typeignore.SetTypePropertyConstructor(NewJSONLDTypeProperty)
 	typeimage.SetTypePropertyConstructor(NewJSONLDTypeProperty)
 	typeintransitiveactivity.SetTypePropertyConstructor(NewJSONLDTypeProperty)
@@ -406,6 +438,7 @@ func init() {
 	typerepository.SetTypePropertyConstructor(NewJSONLDTypeProperty)
 	typeticket.SetTypePropertyConstructor(NewJSONLDTypeProperty)
 	typeticketdependency.SetTypePropertyConstructor(NewJSONLDTypeProperty)
+	typepropertyvalue.SetTypePropertyConstructor(NewJSONLDTypeProperty)
 	typeemoji.SetTypePropertyConstructor(NewJSONLDTypeProperty)
 	typeidentityproof.SetTypePropertyConstructor(NewJSONLDTypeProperty)
 	typepublickey.SetTypePropertyConstructor(NewJSONLDTypeProperty)
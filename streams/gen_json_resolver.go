@@ -67,6 +67,8 @@ func NewJSONResolver(callbacks ...interface{}) (*JSONResolver, error) {
 			// Do nothing, this callback has a correct signature.
 		case func(context.Context, vocab.TootEmoji) error:
 			// Do nothing, this callback has a correct signature.
+		case func(context.Context, vocab.ActivityStreamsEndpoints) error:
+			// Do nothing, this callback has a correct signature.
 		case func(context.Context, vocab.ActivityStreamsEvent) error:
 			// Do nothing, this callback has a correct signature.
 		case func(context.Context, vocab.ActivityStreamsFlag) error:
@@ -75,6 +77,8 @@ func NewJSONResolver(callbacks ...interface{}) (*JSONResolver, error) {
 			// Do nothing, this callback has a correct signature.
 		case func(context.Context, vocab.ActivityStreamsGroup) error:
 			// Do nothing, this callback has a correct signature.
+		case func(context.Context, vocab.ActivityStreamsHashtag) error:
+			// Do nothing, this callback has a correct signature.
 		case func(context.Context, vocab.TootIdentityProof) error:
 			// Do nothing, this callback has a correct signature.
 		case func(context.Context, vocab.ActivityStreamsIgnore) error:
@@ -119,6 +123,8 @@ func NewJSONResolver(callbacks ...interface{}) (*JSONResolver, error) {
 			// Do nothing, this callback has a correct signature.
 		case func(context.Context, vocab.ActivityStreamsProfile) error:
 			// Do nothing, this callback has a correct signature.
+		case func(context.Context, vocab.SchemaPropertyValue) error:
+			// Do nothing, this callback has a correct signature.
 		case func(context.Context, vocab.W3IDSecurityV1PublicKey) error:
 			// Do nothing, this callback has a correct signature.
 		case func(context.Context, vocab.ForgeFedPush) error:
@@ -179,6 +185,17 @@ func toAliasMap(i interface{}) (m map[string]string) {
 		}
 		return
 	}
+	definesTermsFn := func(c map[string]interface{}, prefix string) bool {
+		for _, val := range c {
+			if d, ok := val.(map[string]interface{}); ok {
+				val = d["@id"]
+			}
+			if s, ok := val.(string); ok && strings.HasPrefix(s, prefix+":") {
+				return true
+			}
+		}
+		return false
+	}
 	switch v := i.(type) {
 	case string:
 		// Single entry, no alias.
@@ -193,6 +210,11 @@ func toAliasMap(i interface{}) (m map[string]string) {
 		for _, elem := range v {
 			r := toAliasMap(elem)
 			for k, val := range r {
+				// An unaliased vocabulary takes precedence, as its terms
+				// may then be used without a prefix.
+				if existing, ok := m[k]; ok && len(existing) == 0 {
+					continue
+				}
 				m[k] = val
 			}
 		}
@@ -203,6 +225,21 @@ func toAliasMap(i interface{}) (m map[string]string) {
 			switch conc := val.(type) {
 			case string:
 				m[k] = conc
+				// A term defining the IRI of a vocabulary is its alias,
+				// unless other terms are defined with it as a prefix,
+				// as those are then used without a prefix.
+				alias := k
+				if k == "@vocab" {
+					alias = ""
+				} else if strings.HasPrefix(k, "@") || definesTermsFn(v, k) {
+					continue
+				}
+				if ok, http, https := toHttpHttpsFn(strings.TrimSuffix(conc, "#")); ok {
+					if existing, ok := m[http]; !ok || len(existing) > 0 {
+						m[http] = alias
+						m[https] = alias
+					}
+				}
 			}
 		}
 	}
@@ -250,6 +287,13 @@ func (this JSONResolver) Resolve(ctx context.Context, m map[string]interface{})
 		if len(TootAlias) > 0 {
 			TootAlias += ":"
 		}
+		SchemaAlias, ok := aliasMap["https://schema.org"]
+		if !ok {
+			SchemaAlias = aliasMap["http://schema.org"]
+		}
+		if len(SchemaAlias) > 0 {
+			SchemaAlias += ":"
+		}
 		W3IDSecurityV1Alias, ok := aliasMap["https://w3id.org/security/v1"]
 		if !ok {
 			W3IDSecurityV1Alias = aliasMap["http://w3id.org/security/v1"]
@@ -456,6 +500,17 @@ func (this JSONResolver) Resolve(ctx context.Context, m map[string]interface{})
 				}
 			}
 			return ErrNoCallbackMatch
+		} else if typeString == ActivityStreamsAlias+"Endpoints" {
+			v, err := mgr.DeserializeEndpointsActivityStreams()(m, aliasMap)
+			if err != nil {
+				return err
+			}
+			for _, i := range this.callbacks {
+				if fn, ok := i.(func(context.Context, vocab.ActivityStreamsEndpoints) error); ok {
+					return fn(ctx, v)
+				}
+			}
+			return ErrNoCallbackMatch
 		} else if typeString == ActivityStreamsAlias+"Event" {
 			v, err := mgr.DeserializeEventActivityStreams()(m, aliasMap)
 			if err != nil {
@@ -500,6 +555,17 @@ func (this JSONResolver) Resolve(ctx context.Context, m map[string]interface{})
 				}
 			}
 			return ErrNoCallbackMatch
+		} else if typeString == ActivityStreamsAlias+"Hashtag" {
+			v, err := mgr.DeserializeHashtagActivityStreams()(m, aliasMap)
+			if err != nil {
+				return err
+			}
+			for _, i := range this.callbacks {
+				if fn, ok := i.(func(context.Context, vocab.ActivityStreamsHashtag) error); ok {
+					return fn(ctx, v)
+				}
+			}
+			return ErrNoCallbackMatch
 		} else if typeString == TootAlias+"IdentityProof" {
 			v, err := mgr.DeserializeIdentityProofToot()(m, aliasMap)
 			if err != nil {
@@ -742,6 +808,17 @@ func (this JSONResolver) Resolve(ctx context.Context, m map[string]interface{})
 				}
 			}
 			return ErrNoCallbackMatch
+		} else if typeString == SchemaAlias+"PropertyValue" {
+			v, err := mgr.DeserializePropertyValueSchema()(m, aliasMap)
+			if err != nil {
+				return err
+			}
+			for _, i := range this.callbacks {
+				if fn, ok := i.(func(context.Context, vocab.SchemaPropertyValue) error); ok {
+					return fn(ctx, v)
+				}
+			}
+			return ErrNoCallbackMatch
 		} else if typeString == W3IDSecurityV1Alias+"PublicKey" {
 			v, err := mgr.DeserializePublicKeyW3IDSecurityV1()(m, aliasMap)
 			if err != nil {
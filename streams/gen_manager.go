@@ -5,6 +5,7 @@ package streams
 import (
 	propertyaccuracy "github.com/go-fed/activity/streams/impl/activitystreams/property_accuracy"
 	propertyactor "github.com/go-fed/activity/streams/impl/activitystreams/property_actor"
+	propertyalsoknownas "github.com/go-fed/activity/streams/impl/activitystreams/property_alsoknownas"
 	propertyaltitude "github.com/go-fed/activity/streams/impl/activitystreams/property_altitude"
 	propertyanyof "github.com/go-fed/activity/streams/impl/activitystreams/property_anyof"
 	propertyattachment "github.com/go-fed/activity/streams/impl/activitystreams/property_attachment"
@@ -20,6 +21,7 @@ import (
 	propertydeleted "github.com/go-fed/activity/streams/impl/activitystreams/property_deleted"
 	propertydescribes "github.com/go-fed/activity/streams/impl/activitystreams/property_describes"
 	propertyduration "github.com/go-fed/activity/streams/impl/activitystreams/property_duration"
+	propertyendpoints "github.com/go-fed/activity/streams/impl/activitystreams/property_endpoints"
 	propertyendtime "github.com/go-fed/activity/streams/impl/activitystreams/property_endtime"
 	propertyfirst "github.com/go-fed/activity/streams/impl/activitystreams/property_first"
 	propertyfollowers "github.com/go-fed/activity/streams/impl/activitystreams/property_followers"
@@ -43,8 +45,11 @@ import (
 	propertylongitude "github.com/go-fed/activity/streams/impl/activitystreams/property_longitude"
 	propertymanuallyapprovesfollowers "github.com/go-fed/activity/streams/impl/activitystreams/property_manuallyapprovesfollowers"
 	propertymediatype "github.com/go-fed/activity/streams/impl/activitystreams/property_mediatype"
+	propertymovedto "github.com/go-fed/activity/streams/impl/activitystreams/property_movedto"
 	propertyname "github.com/go-fed/activity/streams/impl/activitystreams/property_name"
 	propertynext "github.com/go-fed/activity/streams/impl/activitystreams/property_next"
+	propertyoauthauthorizationendpoint "github.com/go-fed/activity/streams/impl/activitystreams/property_oauthauthorizationendpoint"
+	propertyoauthtokenendpoint "github.com/go-fed/activity/streams/impl/activitystreams/property_oauthtokenendpoint"
 	propertyobject "github.com/go-fed/activity/streams/impl/activitystreams/property_object"
 	propertyoneof "github.com/go-fed/activity/streams/impl/activitystreams/property_oneof"
 	propertyordereditems "github.com/go-fed/activity/streams/impl/activitystreams/property_ordereditems"
@@ -54,6 +59,7 @@ import (
 	propertypreferredusername "github.com/go-fed/activity/streams/impl/activitystreams/property_preferredusername"
 	propertyprev "github.com/go-fed/activity/streams/impl/activitystreams/property_prev"
 	propertypreview "github.com/go-fed/activity/streams/impl/activitystreams/property_preview"
+	propertyprovideclientkey "github.com/go-fed/activity/streams/impl/activitystreams/property_provideclientkey"
 	propertypublished "github.com/go-fed/activity/streams/impl/activitystreams/property_published"
 	propertyradius "github.com/go-fed/activity/streams/impl/activitystreams/property_radius"
 	propertyrel "github.com/go-fed/activity/streams/impl/activitystreams/property_rel"
@@ -61,7 +67,9 @@ import (
 	propertyreplies "github.com/go-fed/activity/streams/impl/activitystreams/property_replies"
 	propertyresult "github.com/go-fed/activity/streams/impl/activitystreams/property_result"
 	propertysensitive "github.com/go-fed/activity/streams/impl/activitystreams/property_sensitive"
+	propertysharedinbox "github.com/go-fed/activity/streams/impl/activitystreams/property_sharedinbox"
 	propertyshares "github.com/go-fed/activity/streams/impl/activitystreams/property_shares"
+	propertysignclientkey "github.com/go-fed/activity/streams/impl/activitystreams/property_signclientkey"
 	propertysource "github.com/go-fed/activity/streams/impl/activitystreams/property_source"
 	propertystartindex "github.com/go-fed/activity/streams/impl/activitystreams/property_startindex"
 	propertystarttime "github.com/go-fed/activity/streams/impl/activitystreams/property_starttime"
@@ -74,6 +82,7 @@ import (
 	propertytotalitems "github.com/go-fed/activity/streams/impl/activitystreams/property_totalitems"
 	propertyunits "github.com/go-fed/activity/streams/impl/activitystreams/property_units"
 	propertyupdated "github.com/go-fed/activity/streams/impl/activitystreams/property_updated"
+	propertyuploadmedia "github.com/go-fed/activity/streams/impl/activitystreams/property_uploadmedia"
 	propertyurl "github.com/go-fed/activity/streams/impl/activitystreams/property_url"
 	propertywidth "github.com/go-fed/activity/streams/impl/activitystreams/property_width"
 	typeaccept "github.com/go-fed/activity/streams/impl/activitystreams/type_accept"
@@ -91,10 +100,12 @@ import (
 	typedelete "github.com/go-fed/activity/streams/impl/activitystreams/type_delete"
 	typedislike "github.com/go-fed/activity/streams/impl/activitystreams/type_dislike"
 	typedocument "github.com/go-fed/activity/streams/impl/activitystreams/type_document"
+	typeendpoints "github.com/go-fed/activity/streams/impl/activitystreams/type_endpoints"
 	typeevent "github.com/go-fed/activity/streams/impl/activitystreams/type_event"
 	typeflag "github.com/go-fed/activity/streams/impl/activitystreams/type_flag"
 	typefollow "github.com/go-fed/activity/streams/impl/activitystreams/type_follow"
 	typegroup "github.com/go-fed/activity/streams/impl/activitystreams/type_group"
+	typehashtag "github.com/go-fed/activity/streams/impl/activitystreams/type_hashtag"
 	typeignore "github.com/go-fed/activity/streams/impl/activitystreams/type_ignore"
 	typeimage "github.com/go-fed/activity/streams/impl/activitystreams/type_image"
 	typeintransitiveactivity "github.com/go-fed/activity/streams/impl/activitystreams/type_intransitiveactivity"
@@ -157,9 +168,13 @@ import (
 	typeticketdependency "github.com/go-fed/activity/streams/impl/forgefed/type_ticketdependency"
 	propertyid "github.com/go-fed/activity/streams/impl/jsonld/property_id"
 	propertytype "github.com/go-fed/activity/streams/impl/jsonld/property_type"
+	propertyvalue "github.com/go-fed/activity/streams/impl/schema/property_value"
+	typepropertyvalue "github.com/go-fed/activity/streams/impl/schema/type_propertyvalue"
 	propertyblurhash "github.com/go-fed/activity/streams/impl/toot/property_blurhash"
 	propertydiscoverable "github.com/go-fed/activity/streams/impl/toot/property_discoverable"
 	propertyfeatured "github.com/go-fed/activity/streams/impl/toot/property_featured"
+	propertyfeaturedtags "github.com/go-fed/activity/streams/impl/toot/property_featuredtags"
+	propertyfocalpoint "github.com/go-fed/activity/streams/impl/toot/property_focalpoint"
 	propertysignaturealgorithm "github.com/go-fed/activity/streams/impl/toot/property_signaturealgorithm"
 	propertysignaturevalue "github.com/go-fed/activity/streams/impl/toot/property_signaturevalue"
 	propertyvoterscount "github.com/go-fed/activity/streams/impl/toot/property_voterscount"
@@ -243,6 +258,19 @@ func (this Manager) DeserializeAddActivityStreams() func(map[string]interface{},
 	}
 }
 
+// DeserializeAlsoKnownAsPropertyActivityStreams returns the deserialization
+// method for the "ActivityStreamsAlsoKnownAsProperty" non-functional property
+// in the vocabulary "ActivityStreams"
+func (this Manager) DeserializeAlsoKnownAsPropertyActivityStreams() func(map[string]interface{}, map[string]string) (vocab.ActivityStreamsAlsoKnownAsProperty, error) {
+	return func(m map[string]interface{}, aliasMap map[string]string) (vocab.ActivityStreamsAlsoKnownAsProperty, error) {
+		i, err := propertyalsoknownas.DeserializeAlsoKnownAsProperty(m, aliasMap)
+		if i == nil {
+			return nil, err
+		}
+		return i, err
+	}
+}
+
 // DeserializeAltitudePropertyActivityStreams returns the deserialization method
 // for the "ActivityStreamsAltitudeProperty" non-functional property in the
 // vocabulary "ActivityStreams"
@@ -784,6 +812,32 @@ func (this Manager) DeserializeEndTimePropertyActivityStreams() func(map[string]
 	}
 }
 
+// DeserializeEndpointsActivityStreams returns the deserialization method for the
+// "ActivityStreamsEndpoints" non-functional property in the vocabulary
+// "ActivityStreams"
+func (this Manager) DeserializeEndpointsActivityStreams() func(map[string]interface{}, map[string]string) (vocab.ActivityStreamsEndpoints, error) {
+	return func(m map[string]interface{}, aliasMap map[string]string) (vocab.ActivityStreamsEndpoints, error) {
+		i, err := typeendpoints.DeserializeEndpoints(m, aliasMap)
+		if i == nil {
+			return nil, err
+		}
+		return i, err
+	}
+}
+
+// DeserializeEndpointsPropertyActivityStreams returns the deserialization method
+// for the "ActivityStreamsEndpointsProperty" non-functional property in the
+// vocabulary "ActivityStreams"
+func (this Manager) DeserializeEndpointsPropertyActivityStreams() func(map[string]interface{}, map[string]string) (vocab.ActivityStreamsEndpointsProperty, error) {
+	return func(m map[string]interface{}, aliasMap map[string]string) (vocab.ActivityStreamsEndpointsProperty, error) {
+		i, err := propertyendpoints.DeserializeEndpointsProperty(m, aliasMap)
+		if i == nil {
+			return nil, err
+		}
+		return i, err
+	}
+}
+
 // DeserializeEventActivityStreams returns the deserialization method for the
 // "ActivityStreamsEvent" non-functional property in the vocabulary
 // "ActivityStreams"
@@ -809,6 +863,18 @@ func (this Manager) DeserializeFeaturedPropertyToot() func(map[string]interface{
 	}
 }
 
+// DeserializeFeaturedTagsPropertyToot returns the deserialization method for the
+// "TootFeaturedTagsProperty" non-functional property in the vocabulary "Toot"
+func (this Manager) DeserializeFeaturedTagsPropertyToot() func(map[string]interface{}, map[string]string) (vocab.TootFeaturedTagsProperty, error) {
+	return func(m map[string]interface{}, aliasMap map[string]string) (vocab.TootFeaturedTagsProperty, error) {
+		i, err := propertyfeaturedtags.DeserializeFeaturedTagsProperty(m, aliasMap)
+		if i == nil {
+			return nil, err
+		}
+		return i, err
+	}
+}
+
 // DeserializeFilesAddedPropertyForgeFed returns the deserialization method for
 // the "ForgeFedFilesAddedProperty" non-functional property in the vocabulary
 // "ForgeFed"
@@ -874,6 +940,18 @@ func (this Manager) DeserializeFlagActivityStreams() func(map[string]interface{}
 	}
 }
 
+// DeserializeFocalPointPropertyToot returns the deserialization method for the
+// "TootFocalPointProperty" non-functional property in the vocabulary "Toot"
+func (this Manager) DeserializeFocalPointPropertyToot() func(map[string]interface{}, map[string]string) (vocab.TootFocalPointProperty, error) {
+	return func(m map[string]interface{}, aliasMap map[string]string) (vocab.TootFocalPointProperty, error) {
+		i, err := propertyfocalpoint.DeserializeFocalPointProperty(m, aliasMap)
+		if i == nil {
+			return nil, err
+		}
+		return i, err
+	}
+}
+
 // DeserializeFollowActivityStreams returns the deserialization method for the
 // "ActivityStreamsFollow" non-functional property in the vocabulary
 // "ActivityStreams"
@@ -976,6 +1054,19 @@ func (this Manager) DeserializeHashPropertyForgeFed() func(map[string]interface{
 	}
 }
 
+// DeserializeHashtagActivityStreams returns the deserialization method for the
+// "ActivityStreamsHashtag" non-functional property in the vocabulary
+// "ActivityStreams"
+func (this Manager) DeserializeHashtagActivityStreams() func(map[string]interface{}, map[string]string) (vocab.ActivityStreamsHashtag, error) {
+	return func(m map[string]interface{}, aliasMap map[string]string) (vocab.ActivityStreamsHashtag, error) {
+		i, err := typehashtag.DeserializeHashtag(m, aliasMap)
+		if i == nil {
+			return nil, err
+		}
+		return i, err
+	}
+}
+
 // DeserializeHeightPropertyActivityStreams returns the deserialization method for
 // the "ActivityStreamsHeightProperty" non-functional property in the
 // vocabulary "ActivityStreams"
@@ -1378,6 +1469,19 @@ func (this Manager) DeserializeMoveActivityStreams() func(map[string]interface{}
 	}
 }
 
+// DeserializeMovedToPropertyActivityStreams returns the deserialization method
+// for the "ActivityStreamsMovedToProperty" non-functional property in the
+// vocabulary "ActivityStreams"
+func (this Manager) DeserializeMovedToPropertyActivityStreams() func(map[string]interface{}, map[string]string) (vocab.ActivityStreamsMovedToProperty, error) {
+	return func(m map[string]interface{}, aliasMap map[string]string) (vocab.ActivityStreamsMovedToProperty, error) {
+		i, err := propertymovedto.DeserializeMovedToProperty(m, aliasMap)
+		if i == nil {
+			return nil, err
+		}
+		return i, err
+	}
+}
+
 // DeserializeNamePropertyActivityStreams returns the deserialization method for
 // the "ActivityStreamsNameProperty" non-functional property in the vocabulary
 // "ActivityStreams"
@@ -1417,6 +1521,33 @@ func (this Manager) DeserializeNoteActivityStreams() func(map[string]interface{}
 	}
 }
 
+// DeserializeOauthAuthorizationEndpointPropertyActivityStreams returns the
+// deserialization method for the
+// "ActivityStreamsOauthAuthorizationEndpointProperty" non-functional property
+// in the vocabulary "ActivityStreams"
+func (this Manager) DeserializeOauthAuthorizationEndpointPropertyActivityStreams() func(map[string]interface{}, map[string]string) (vocab.ActivityStreamsOauthAuthorizationEndpointProperty, error) {
+	return func(m map[string]interface{}, aliasMap map[string]string) (vocab.ActivityStreamsOauthAuthorizationEndpointProperty, error) {
+		i, err := propertyoauthauthorizationendpoint.DeserializeOauthAuthorizationEndpointProperty(m, aliasMap)
+		if i == nil {
+			return nil, err
+		}
+		return i, err
+	}
+}
+
+// DeserializeOauthTokenEndpointPropertyActivityStreams returns the
+// deserialization method for the "ActivityStreamsOauthTokenEndpointProperty"
+// non-functional property in the vocabulary "ActivityStreams"
+func (this Manager) DeserializeOauthTokenEndpointPropertyActivityStreams() func(map[string]interface{}, map[string]string) (vocab.ActivityStreamsOauthTokenEndpointProperty, error) {
+	return func(m map[string]interface{}, aliasMap map[string]string) (vocab.ActivityStreamsOauthTokenEndpointProperty, error) {
+		i, err := propertyoauthtokenendpoint.DeserializeOauthTokenEndpointProperty(m, aliasMap)
+		if i == nil {
+			return nil, err
+		}
+		return i, err
+	}
+}
+
 // DeserializeObjectActivityStreams returns the deserialization method for the
 // "ActivityStreamsObject" non-functional property in the vocabulary
 // "ActivityStreams"
@@ -1664,6 +1795,31 @@ func (this Manager) DeserializeProfileActivityStreams() func(map[string]interfac
 	}
 }
 
+// DeserializePropertyValueSchema returns the deserialization method for the
+// "SchemaPropertyValue" non-functional property in the vocabulary "Schema"
+func (this Manager) DeserializePropertyValueSchema() func(map[string]interface{}, map[string]string) (vocab.SchemaPropertyValue, error) {
+	return func(m map[string]interface{}, aliasMap map[string]string) (vocab.SchemaPropertyValue, error) {
+		i, err := typepropertyvalue.DeserializePropertyValue(m, aliasMap)
+		if i == nil {
+			return nil, err
+		}
+		return i, err
+	}
+}
+
+// DeserializeProvideClientKeyPropertyActivityStreams returns the deserialization
+// method for the "ActivityStreamsProvideClientKeyProperty" non-functional
+// property in the vocabulary "ActivityStreams"
+func (this Manager) DeserializeProvideClientKeyPropertyActivityStreams() func(map[string]interface{}, map[string]string) (vocab.ActivityStreamsProvideClientKeyProperty, error) {
+	return func(m map[string]interface{}, aliasMap map[string]string) (vocab.ActivityStreamsProvideClientKeyProperty, error) {
+		i, err := propertyprovideclientkey.DeserializeProvideClientKeyProperty(m, aliasMap)
+		if i == nil {
+			return nil, err
+		}
+		return i, err
+	}
+}
+
 // DeserializePublicKeyPemPropertyW3IDSecurityV1 returns the deserialization
 // method for the "W3IDSecurityV1PublicKeyPemProperty" non-functional property
 // in the vocabulary "W3IDSecurityV1"
@@ -1908,6 +2064,19 @@ func (this Manager) DeserializeServiceActivityStreams() func(map[string]interfac
 	}
 }
 
+// DeserializeSharedInboxPropertyActivityStreams returns the deserialization
+// method for the "ActivityStreamsSharedInboxProperty" non-functional property
+// in the vocabulary "ActivityStreams"
+func (this Manager) DeserializeSharedInboxPropertyActivityStreams() func(map[string]interface{}, map[string]string) (vocab.ActivityStreamsSharedInboxProperty, error) {
+	return func(m map[string]interface{}, aliasMap map[string]string) (vocab.ActivityStreamsSharedInboxProperty, error) {
+		i, err := propertysharedinbox.DeserializeSharedInboxProperty(m, aliasMap)
+		if i == nil {
+			return nil, err
+		}
+		return i, err
+	}
+}
+
 // DeserializeSharesPropertyActivityStreams returns the deserialization method for
 // the "ActivityStreamsSharesProperty" non-functional property in the
 // vocabulary "ActivityStreams"
@@ -1921,6 +2090,19 @@ func (this Manager) DeserializeSharesPropertyActivityStreams() func(map[string]i
 	}
 }
 
+// DeserializeSignClientKeyPropertyActivityStreams returns the deserialization
+// method for the "ActivityStreamsSignClientKeyProperty" non-functional
+// property in the vocabulary "ActivityStreams"
+func (this Manager) DeserializeSignClientKeyPropertyActivityStreams() func(map[string]interface{}, map[string]string) (vocab.ActivityStreamsSignClientKeyProperty, error) {
+	return func(m map[string]interface{}, aliasMap map[string]string) (vocab.ActivityStreamsSignClientKeyProperty, error) {
+		i, err := propertysignclientkey.DeserializeSignClientKeyProperty(m, aliasMap)
+		if i == nil {
+			return nil, err
+		}
+		return i, err
+	}
+}
+
 // DeserializeSignatureAlgorithmPropertyToot returns the deserialization method
 // for the "TootSignatureAlgorithmProperty" non-functional property in the
 // vocabulary "Toot"
@@ -2256,6 +2438,19 @@ func (this Manager) DeserializeUpdatedPropertyActivityStreams() func(map[string]
 	}
 }
 
+// DeserializeUploadMediaPropertyActivityStreams returns the deserialization
+// method for the "ActivityStreamsUploadMediaProperty" non-functional property
+// in the vocabulary "ActivityStreams"
+func (this Manager) DeserializeUploadMediaPropertyActivityStreams() func(map[string]interface{}, map[string]string) (vocab.ActivityStreamsUploadMediaProperty, error) {
+	return func(m map[string]interface{}, aliasMap map[string]string) (vocab.ActivityStreamsUploadMediaProperty, error) {
+		i, err := propertyuploadmedia.DeserializeUploadMediaProperty(m, aliasMap)
+		if i == nil {
+			return nil, err
+		}
+		return i, err
+	}
+}
+
 // DeserializeUrlPropertyActivityStreams returns the deserialization method for
 // the "ActivityStreamsUrlProperty" non-functional property in the vocabulary
 // "ActivityStreams"
@@ -2269,6 +2464,18 @@ func (this Manager) DeserializeUrlPropertyActivityStreams() func(map[string]inte
 	}
 }
 
+// DeserializeValuePropertySchema returns the deserialization method for the
+// "SchemaValueProperty" non-functional property in the vocabulary "Schema"
+func (this Manager) DeserializeValuePropertySchema() func(map[string]interface{}, map[string]string) (vocab.SchemaValueProperty, error) {
+	return func(m map[string]interface{}, aliasMap map[string]string) (vocab.SchemaValueProperty, error) {
+		i, err := propertyvalue.DeserializeValueProperty(m, aliasMap)
+		if i == nil {
+			return nil, err
+		}
+		return i, err
+	}
+}
+
 // DeserializeVideoActivityStreams returns the deserialization method for the
 // "ActivityStreamsVideo" non-functional property in the vocabulary
 // "ActivityStreams"
@@ -18,10 +18,12 @@ import (
 	typedelete "github.com/go-fed/activity/streams/impl/activitystreams/type_delete"
 	typedislike "github.com/go-fed/activity/streams/impl/activitystreams/type_dislike"
 	typedocument "github.com/go-fed/activity/streams/impl/activitystreams/type_document"
+	typeendpoints "github.com/go-fed/activity/streams/impl/activitystreams/type_endpoints"
 	typeevent "github.com/go-fed/activity/streams/impl/activitystreams/type_event"
 	typeflag "github.com/go-fed/activity/streams/impl/activitystreams/type_flag"
 	typefollow "github.com/go-fed/activity/streams/impl/activitystreams/type_follow"
 	typegroup "github.com/go-fed/activity/streams/impl/activitystreams/type_group"
+	typehashtag "github.com/go-fed/activity/streams/impl/activitystreams/type_hashtag"
 	typeignore "github.com/go-fed/activity/streams/impl/activitystreams/type_ignore"
 	typeimage "github.com/go-fed/activity/streams/impl/activitystreams/type_image"
 	typeintransitiveactivity "github.com/go-fed/activity/streams/impl/activitystreams/type_intransitiveactivity"
@@ -150,6 +152,12 @@ func ActivityStreamsDocumentIsDisjointWith(other vocab.Type) bool {
 	return typedocument.DocumentIsDisjointWith(other)
 }
 
+// ActivityStreamsEndpointsIsDisjointWith returns true if Endpoints is disjoint
+// with the other's type.
+func ActivityStreamsEndpointsIsDisjointWith(other vocab.Type) bool {
+	return typeendpoints.EndpointsIsDisjointWith(other)
+}
+
 // ActivityStreamsEventIsDisjointWith returns true if Event is disjoint with the
 // other's type.
 func ActivityStreamsEventIsDisjointWith(other vocab.Type) bool {
@@ -174,6 +182,12 @@ func ActivityStreamsGroupIsDisjointWith(other vocab.Type) bool {
 	return typegroup.GroupIsDisjointWith(other)
 }
 
+// ActivityStreamsHashtagIsDisjointWith returns true if Hashtag is disjoint with
+// the other's type.
+func ActivityStreamsHashtagIsDisjointWith(other vocab.Type) bool {
+	return typehashtag.HashtagIsDisjointWith(other)
+}
+
 // ActivityStreamsIgnoreIsDisjointWith returns true if Ignore is disjoint with the
 // other's type.
 func ActivityStreamsIgnoreIsDisjointWith(other vocab.Type) bool {
@@ -18,10 +18,12 @@ import (
 	typedelete "github.com/go-fed/activity/streams/impl/activitystreams/type_delete"
 	typedislike "github.com/go-fed/activity/streams/impl/activitystreams/type_dislike"
 	typedocument "github.com/go-fed/activity/streams/impl/activitystreams/type_document"
+	typeendpoints "github.com/go-fed/activity/streams/impl/activitystreams/type_endpoints"
 	typeevent "github.com/go-fed/activity/streams/impl/activitystreams/type_event"
 	typeflag "github.com/go-fed/activity/streams/impl/activitystreams/type_flag"
 	typefollow "github.com/go-fed/activity/streams/impl/activitystreams/type_follow"
 	typegroup "github.com/go-fed/activity/streams/impl/activitystreams/type_group"
+	typehashtag "github.com/go-fed/activity/streams/impl/activitystreams/type_hashtag"
 	typeignore "github.com/go-fed/activity/streams/impl/activitystreams/type_ignore"
 	typeimage "github.com/go-fed/activity/streams/impl/activitystreams/type_image"
 	typeintransitiveactivity "github.com/go-fed/activity/streams/impl/activitystreams/type_intransitiveactivity"
@@ -165,6 +167,13 @@ func ActivityStreamsDocumentIsExtendedBy(other vocab.Type) bool {
 	return typedocument.DocumentIsExtendedBy(other)
 }
 
+// ActivityStreamsEndpointsIsExtendedBy returns true if the other's type extends
+// from Endpoints. Note that it returns false if the types are the same; see
+// the "IsOrExtends" variant instead.
+func ActivityStreamsEndpointsIsExtendedBy(other vocab.Type) bool {
+	return typeendpoints.EndpointsIsExtendedBy(other)
+}
+
 // ActivityStreamsEventIsExtendedBy returns true if the other's type extends from
 // Event. Note that it returns false if the types are the same; see the
 // "IsOrExtends" variant instead.
@@ -193,6 +202,13 @@ func ActivityStreamsGroupIsExtendedBy(other vocab.Type) bool {
 	return typegroup.GroupIsExtendedBy(other)
 }
 
+// ActivityStreamsHashtagIsExtendedBy returns true if the other's type extends
+// from Hashtag. Note that it returns false if the types are the same; see the
+// "IsOrExtends" variant instead.
+func ActivityStreamsHashtagIsExtendedBy(other vocab.Type) bool {
+	return typehashtag.HashtagIsExtendedBy(other)
+}
+
 // ActivityStreamsIgnoreIsExtendedBy returns true if the other's type extends from
 // Ignore. Note that it returns false if the types are the same; see the
 // "IsOrExtends" variant instead.
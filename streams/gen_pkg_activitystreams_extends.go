@@ -18,10 +18,12 @@ import (
 	typedelete "github.com/go-fed/activity/streams/impl/activitystreams/type_delete"
 	typedislike "github.com/go-fed/activity/streams/impl/activitystreams/type_dislike"
 	typedocument "github.com/go-fed/activity/streams/impl/activitystreams/type_document"
+	typeendpoints "github.com/go-fed/activity/streams/impl/activitystreams/type_endpoints"
 	typeevent "github.com/go-fed/activity/streams/impl/activitystreams/type_event"
 	typeflag "github.com/go-fed/activity/streams/impl/activitystreams/type_flag"
 	typefollow "github.com/go-fed/activity/streams/impl/activitystreams/type_follow"
 	typegroup "github.com/go-fed/activity/streams/impl/activitystreams/type_group"
+	typehashtag "github.com/go-fed/activity/streams/impl/activitystreams/type_hashtag"
 	typeignore "github.com/go-fed/activity/streams/impl/activitystreams/type_ignore"
 	typeimage "github.com/go-fed/activity/streams/impl/activitystreams/type_image"
 	typeintransitiveactivity "github.com/go-fed/activity/streams/impl/activitystreams/type_intransitiveactivity"
@@ -150,6 +152,12 @@ func ActivityStreamsActivityStreamsDocumentExtends(other vocab.Type) bool {
 	return typedocument.ActivityStreamsDocumentExtends(other)
 }
 
+// ActivityStreamsActivityStreamsEndpointsExtends returns true if Endpoints
+// extends from the other's type.
+func ActivityStreamsActivityStreamsEndpointsExtends(other vocab.Type) bool {
+	return typeendpoints.ActivityStreamsEndpointsExtends(other)
+}
+
 // ActivityStreamsActivityStreamsEventExtends returns true if Event extends from
 // the other's type.
 func ActivityStreamsActivityStreamsEventExtends(other vocab.Type) bool {
@@ -174,6 +182,12 @@ func ActivityStreamsActivityStreamsGroupExtends(other vocab.Type) bool {
 	return typegroup.ActivityStreamsGroupExtends(other)
 }
 
+// ActivityStreamsActivityStreamsHashtagExtends returns true if Hashtag extends
+// from the other's type.
+func ActivityStreamsActivityStreamsHashtagExtends(other vocab.Type) bool {
+	return typehashtag.ActivityStreamsHashtagExtends(other)
+}
+
 // ActivityStreamsActivityStreamsIgnoreExtends returns true if Ignore extends from
 // the other's type.
 func ActivityStreamsActivityStreamsIgnoreExtends(other vocab.Type) bool {
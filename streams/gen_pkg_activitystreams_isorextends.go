@@ -18,10 +18,12 @@ import (
 	typedelete "github.com/go-fed/activity/streams/impl/activitystreams/type_delete"
 	typedislike "github.com/go-fed/activity/streams/impl/activitystreams/type_dislike"
 	typedocument "github.com/go-fed/activity/streams/impl/activitystreams/type_document"
+	typeendpoints "github.com/go-fed/activity/streams/impl/activitystreams/type_endpoints"
 	typeevent "github.com/go-fed/activity/streams/impl/activitystreams/type_event"
 	typeflag "github.com/go-fed/activity/streams/impl/activitystreams/type_flag"
 	typefollow "github.com/go-fed/activity/streams/impl/activitystreams/type_follow"
 	typegroup "github.com/go-fed/activity/streams/impl/activitystreams/type_group"
+	typehashtag "github.com/go-fed/activity/streams/impl/activitystreams/type_hashtag"
 	typeignore "github.com/go-fed/activity/streams/impl/activitystreams/type_ignore"
 	typeimage "github.com/go-fed/activity/streams/impl/activitystreams/type_image"
 	typeintransitiveactivity "github.com/go-fed/activity/streams/impl/activitystreams/type_intransitiveactivity"
@@ -150,6 +152,12 @@ func IsOrExtendsActivityStreamsDocument(other vocab.Type) bool {
 	return typedocument.IsOrExtendsDocument(other)
 }
 
+// IsOrExtendsActivityStreamsEndpoints returns true if the other provided type is
+// the Endpoints type or extends from the Endpoints type.
+func IsOrExtendsActivityStreamsEndpoints(other vocab.Type) bool {
+	return typeendpoints.IsOrExtendsEndpoints(other)
+}
+
 // IsOrExtendsActivityStreamsEvent returns true if the other provided type is the
 // Event type or extends from the Event type.
 func IsOrExtendsActivityStreamsEvent(other vocab.Type) bool {
@@ -174,6 +182,12 @@ func IsOrExtendsActivityStreamsGroup(other vocab.Type) bool {
 	return typegroup.IsOrExtendsGroup(other)
 }
 
+// IsOrExtendsActivityStreamsHashtag returns true if the other provided type is
+// the Hashtag type or extends from the Hashtag type.
+func IsOrExtendsActivityStreamsHashtag(other vocab.Type) bool {
+	return typehashtag.IsOrExtendsHashtag(other)
+}
+
 // IsOrExtendsActivityStreamsIgnore returns true if the other provided type is the
 // Ignore type or extends from the Ignore type.
 func IsOrExtendsActivityStreamsIgnore(other vocab.Type) bool {
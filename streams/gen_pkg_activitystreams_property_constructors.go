@@ -5,6 +5,7 @@ package streams
 import (
 	propertyaccuracy "github.com/go-fed/activity/streams/impl/activitystreams/property_accuracy"
 	propertyactor "github.com/go-fed/activity/streams/impl/activitystreams/property_actor"
+	propertyalsoknownas "github.com/go-fed/activity/streams/impl/activitystreams/property_alsoknownas"
 	propertyaltitude "github.com/go-fed/activity/streams/impl/activitystreams/property_altitude"
 	propertyanyof "github.com/go-fed/activity/streams/impl/activitystreams/property_anyof"
 	propertyattachment "github.com/go-fed/activity/streams/impl/activitystreams/property_attachment"
@@ -20,6 +21,7 @@ import (
 	propertydeleted "github.com/go-fed/activity/streams/impl/activitystreams/property_deleted"
 	propertydescribes "github.com/go-fed/activity/streams/impl/activitystreams/property_describes"
 	propertyduration "github.com/go-fed/activity/streams/impl/activitystreams/property_duration"
+	propertyendpoints "github.com/go-fed/activity/streams/impl/activitystreams/property_endpoints"
 	propertyendtime "github.com/go-fed/activity/streams/impl/activitystreams/property_endtime"
 	propertyfirst "github.com/go-fed/activity/streams/impl/activitystreams/property_first"
 	propertyfollowers "github.com/go-fed/activity/streams/impl/activitystreams/property_followers"
@@ -43,8 +45,11 @@ import (
 	propertylongitude "github.com/go-fed/activity/streams/impl/activitystreams/property_longitude"
 	propertymanuallyapprovesfollowers "github.com/go-fed/activity/streams/impl/activitystreams/property_manuallyapprovesfollowers"
 	propertymediatype "github.com/go-fed/activity/streams/impl/activitystreams/property_mediatype"
+	propertymovedto "github.com/go-fed/activity/streams/impl/activitystreams/property_movedto"
 	propertyname "github.com/go-fed/activity/streams/impl/activitystreams/property_name"
 	propertynext "github.com/go-fed/activity/streams/impl/activitystreams/property_next"
+	propertyoauthauthorizationendpoint "github.com/go-fed/activity/streams/impl/activitystreams/property_oauthauthorizationendpoint"
+	propertyoauthtokenendpoint "github.com/go-fed/activity/streams/impl/activitystreams/property_oauthtokenendpoint"
 	propertyobject "github.com/go-fed/activity/streams/impl/activitystreams/property_object"
 	propertyoneof "github.com/go-fed/activity/streams/impl/activitystreams/property_oneof"
 	propertyordereditems "github.com/go-fed/activity/streams/impl/activitystreams/property_ordereditems"
@@ -54,6 +59,7 @@ import (
 	propertypreferredusername "github.com/go-fed/activity/streams/impl/activitystreams/property_preferredusername"
 	propertyprev "github.com/go-fed/activity/streams/impl/activitystreams/property_prev"
 	propertypreview "github.com/go-fed/activity/streams/impl/activitystreams/property_preview"
+	propertyprovideclientkey "github.com/go-fed/activity/streams/impl/activitystreams/property_provideclientkey"
 	propertypublished "github.com/go-fed/activity/streams/impl/activitystreams/property_published"
 	propertyradius "github.com/go-fed/activity/streams/impl/activitystreams/property_radius"
 	propertyrel "github.com/go-fed/activity/streams/impl/activitystreams/property_rel"
@@ -61,7 +67,9 @@ import (
 	propertyreplies "github.com/go-fed/activity/streams/impl/activitystreams/property_replies"
 	propertyresult "github.com/go-fed/activity/streams/impl/activitystreams/property_result"
 	propertysensitive "github.com/go-fed/activity/streams/impl/activitystreams/property_sensitive"
+	propertysharedinbox "github.com/go-fed/activity/streams/impl/activitystreams/property_sharedinbox"
 	propertyshares "github.com/go-fed/activity/streams/impl/activitystreams/property_shares"
+	propertysignclientkey "github.com/go-fed/activity/streams/impl/activitystreams/property_signclientkey"
 	propertysource "github.com/go-fed/activity/streams/impl/activitystreams/property_source"
 	propertystartindex "github.com/go-fed/activity/streams/impl/activitystreams/property_startindex"
 	propertystarttime "github.com/go-fed/activity/streams/impl/activitystreams/property_starttime"
@@ -74,6 +82,7 @@ import (
 	propertytotalitems "github.com/go-fed/activity/streams/impl/activitystreams/property_totalitems"
 	propertyunits "github.com/go-fed/activity/streams/impl/activitystreams/property_units"
 	propertyupdated "github.com/go-fed/activity/streams/impl/activitystreams/property_updated"
+	propertyuploadmedia "github.com/go-fed/activity/streams/impl/activitystreams/property_uploadmedia"
 	propertyurl "github.com/go-fed/activity/streams/impl/activitystreams/property_url"
 	propertywidth "github.com/go-fed/activity/streams/impl/activitystreams/property_width"
 	vocab "github.com/go-fed/activity/streams/vocab"
@@ -91,6 +100,12 @@ func NewActivityStreamsActorProperty() vocab.ActivityStreamsActorProperty {
 	return propertyactor.NewActivityStreamsActorProperty()
 }
 
+// NewActivityStreamsActivityStreamsAlsoKnownAsProperty creates a new
+// ActivityStreamsAlsoKnownAsProperty
+func NewActivityStreamsAlsoKnownAsProperty() vocab.ActivityStreamsAlsoKnownAsProperty {
+	return propertyalsoknownas.NewActivityStreamsAlsoKnownAsProperty()
+}
+
 // NewActivityStreamsActivityStreamsAltitudeProperty creates a new
 // ActivityStreamsAltitudeProperty
 func NewActivityStreamsAltitudeProperty() vocab.ActivityStreamsAltitudeProperty {
@@ -187,6 +202,12 @@ func NewActivityStreamsEndTimeProperty() vocab.ActivityStreamsEndTimeProperty {
 	return propertyendtime.NewActivityStreamsEndTimeProperty()
 }
 
+// NewActivityStreamsActivityStreamsEndpointsProperty creates a new
+// ActivityStreamsEndpointsProperty
+func NewActivityStreamsEndpointsProperty() vocab.ActivityStreamsEndpointsProperty {
+	return propertyendpoints.NewActivityStreamsEndpointsProperty()
+}
+
 // NewActivityStreamsActivityStreamsFirstProperty creates a new
 // ActivityStreamsFirstProperty
 func NewActivityStreamsFirstProperty() vocab.ActivityStreamsFirstProperty {
@@ -319,6 +340,12 @@ func NewActivityStreamsMediaTypeProperty() vocab.ActivityStreamsMediaTypePropert
 	return propertymediatype.NewActivityStreamsMediaTypeProperty()
 }
 
+// NewActivityStreamsActivityStreamsMovedToProperty creates a new
+// ActivityStreamsMovedToProperty
+func NewActivityStreamsMovedToProperty() vocab.ActivityStreamsMovedToProperty {
+	return propertymovedto.NewActivityStreamsMovedToProperty()
+}
+
 // NewActivityStreamsActivityStreamsNameProperty creates a new
 // ActivityStreamsNameProperty
 func NewActivityStreamsNameProperty() vocab.ActivityStreamsNameProperty {
@@ -331,6 +358,18 @@ func NewActivityStreamsNextProperty() vocab.ActivityStreamsNextProperty {
 	return propertynext.NewActivityStreamsNextProperty()
 }
 
+// NewActivityStreamsActivityStreamsOauthAuthorizationEndpointProperty creates a
+// new ActivityStreamsOauthAuthorizationEndpointProperty
+func NewActivityStreamsOauthAuthorizationEndpointProperty() vocab.ActivityStreamsOauthAuthorizationEndpointProperty {
+	return propertyoauthauthorizationendpoint.NewActivityStreamsOauthAuthorizationEndpointProperty()
+}
+
+// NewActivityStreamsActivityStreamsOauthTokenEndpointProperty creates a new
+// ActivityStreamsOauthTokenEndpointProperty
+func NewActivityStreamsOauthTokenEndpointProperty() vocab.ActivityStreamsOauthTokenEndpointProperty {
+	return propertyoauthtokenendpoint.NewActivityStreamsOauthTokenEndpointProperty()
+}
+
 // NewActivityStreamsActivityStreamsObjectProperty creates a new
 // ActivityStreamsObjectProperty
 func NewActivityStreamsObjectProperty() vocab.ActivityStreamsObjectProperty {
@@ -385,6 +424,12 @@ func NewActivityStreamsPreviewProperty() vocab.ActivityStreamsPreviewProperty {
 	return propertypreview.NewActivityStreamsPreviewProperty()
 }
 
+// NewActivityStreamsActivityStreamsProvideClientKeyProperty creates a new
+// ActivityStreamsProvideClientKeyProperty
+func NewActivityStreamsProvideClientKeyProperty() vocab.ActivityStreamsProvideClientKeyProperty {
+	return propertyprovideclientkey.NewActivityStreamsProvideClientKeyProperty()
+}
+
 // NewActivityStreamsActivityStreamsPublishedProperty creates a new
 // ActivityStreamsPublishedProperty
 func NewActivityStreamsPublishedProperty() vocab.ActivityStreamsPublishedProperty {
@@ -427,12 +472,24 @@ func NewActivityStreamsSensitiveProperty() vocab.ActivityStreamsSensitivePropert
 	return propertysensitive.NewActivityStreamsSensitiveProperty()
 }
 
+// NewActivityStreamsActivityStreamsSharedInboxProperty creates a new
+// ActivityStreamsSharedInboxProperty
+func NewActivityStreamsSharedInboxProperty() vocab.ActivityStreamsSharedInboxProperty {
+	return propertysharedinbox.NewActivityStreamsSharedInboxProperty()
+}
+
 // NewActivityStreamsActivityStreamsSharesProperty creates a new
 // ActivityStreamsSharesProperty
 func NewActivityStreamsSharesProperty() vocab.ActivityStreamsSharesProperty {
 	return propertyshares.NewActivityStreamsSharesProperty()
 }
 
+// NewActivityStreamsActivityStreamsSignClientKeyProperty creates a new
+// ActivityStreamsSignClientKeyProperty
+func NewActivityStreamsSignClientKeyProperty() vocab.ActivityStreamsSignClientKeyProperty {
+	return propertysignclientkey.NewActivityStreamsSignClientKeyProperty()
+}
+
 // NewActivityStreamsActivityStreamsSourceProperty creates a new
 // ActivityStreamsSourceProperty
 func NewActivityStreamsSourceProperty() vocab.ActivityStreamsSourceProperty {
@@ -505,6 +562,12 @@ func NewActivityStreamsUpdatedProperty() vocab.ActivityStreamsUpdatedProperty {
 	return propertyupdated.NewActivityStreamsUpdatedProperty()
 }
 
+// NewActivityStreamsActivityStreamsUploadMediaProperty creates a new
+// ActivityStreamsUploadMediaProperty
+func NewActivityStreamsUploadMediaProperty() vocab.ActivityStreamsUploadMediaProperty {
+	return propertyuploadmedia.NewActivityStreamsUploadMediaProperty()
+}
+
 // NewActivityStreamsActivityStreamsUrlProperty creates a new
 // ActivityStreamsUrlProperty
 func NewActivityStreamsUrlProperty() vocab.ActivityStreamsUrlProperty {
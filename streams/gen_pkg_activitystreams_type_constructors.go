@@ -18,10 +18,12 @@ import (
 	typedelete "github.com/go-fed/activity/streams/impl/activitystreams/type_delete"
 	typedislike "github.com/go-fed/activity/streams/impl/activitystreams/type_dislike"
 	typedocument "github.com/go-fed/activity/streams/impl/activitystreams/type_document"
+	typeendpoints "github.com/go-fed/activity/streams/impl/activitystreams/type_endpoints"
 	typeevent "github.com/go-fed/activity/streams/impl/activitystreams/type_event"
 	typeflag "github.com/go-fed/activity/streams/impl/activitystreams/type_flag"
 	typefollow "github.com/go-fed/activity/streams/impl/activitystreams/type_follow"
 	typegroup "github.com/go-fed/activity/streams/impl/activitystreams/type_group"
+	typehashtag "github.com/go-fed/activity/streams/impl/activitystreams/type_hashtag"
 	typeignore "github.com/go-fed/activity/streams/impl/activitystreams/type_ignore"
 	typeimage "github.com/go-fed/activity/streams/impl/activitystreams/type_image"
 	typeintransitiveactivity "github.com/go-fed/activity/streams/impl/activitystreams/type_intransitiveactivity"
@@ -135,6 +137,11 @@ func NewActivityStreamsDocument() vocab.ActivityStreamsDocument {
 	return typedocument.NewActivityStreamsDocument()
 }
 
+// NewActivityStreamsEndpoints creates a new ActivityStreamsEndpoints
+func NewActivityStreamsEndpoints() vocab.ActivityStreamsEndpoints {
+	return typeendpoints.NewActivityStreamsEndpoints()
+}
+
 // NewActivityStreamsEvent creates a new ActivityStreamsEvent
 func NewActivityStreamsEvent() vocab.ActivityStreamsEvent {
 	return typeevent.NewActivityStreamsEvent()
@@ -155,6 +162,11 @@ func NewActivityStreamsGroup() vocab.ActivityStreamsGroup {
 	return typegroup.NewActivityStreamsGroup()
 }
 
+// NewActivityStreamsHashtag creates a new ActivityStreamsHashtag
+func NewActivityStreamsHashtag() vocab.ActivityStreamsHashtag {
+	return typehashtag.NewActivityStreamsHashtag()
+}
+
 // NewActivityStreamsIgnore creates a new ActivityStreamsIgnore
 func NewActivityStreamsIgnore() vocab.ActivityStreamsIgnore {
 	return typeignore.NewActivityStreamsIgnore()
@@ -0,0 +1,14 @@
+// Code generated by astool. DO NOT EDIT.
+
+package streams
+
+import (
+	typepropertyvalue "github.com/go-fed/activity/streams/impl/schema/type_propertyvalue"
+	vocab "github.com/go-fed/activity/streams/vocab"
+)
+
+// SchemaPropertyValueIsDisjointWith returns true if PropertyValue is disjoint
+// with the other's type.
+func SchemaPropertyValueIsDisjointWith(other vocab.Type) bool {
+	return typepropertyvalue.PropertyValueIsDisjointWith(other)
+}
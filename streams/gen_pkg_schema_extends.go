@@ -0,0 +1,14 @@
+// Code generated by astool. DO NOT EDIT.
+
+package streams
+
+import (
+	typepropertyvalue "github.com/go-fed/activity/streams/impl/schema/type_propertyvalue"
+	vocab "github.com/go-fed/activity/streams/vocab"
+)
+
+// SchemaSchemaPropertyValueExtends returns true if PropertyValue extends from the
+// other's type.
+func SchemaSchemaPropertyValueExtends(other vocab.Type) bool {
+	return typepropertyvalue.SchemaPropertyValueExtends(other)
+}
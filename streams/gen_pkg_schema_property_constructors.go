@@ -0,0 +1,13 @@
+// Code generated by astool. DO NOT EDIT.
+
+package streams
+
+import (
+	propertyvalue "github.com/go-fed/activity/streams/impl/schema/property_value"
+	vocab "github.com/go-fed/activity/streams/vocab"
+)
+
+// NewSchemaSchemaValueProperty creates a new SchemaValueProperty
+func NewSchemaValueProperty() vocab.SchemaValueProperty {
+	return propertyvalue.NewSchemaValueProperty()
+}
@@ -0,0 +1,13 @@
+// Code generated by astool. DO NOT EDIT.
+
+package streams
+
+import (
+	typepropertyvalue "github.com/go-fed/activity/streams/impl/schema/type_propertyvalue"
+	vocab "github.com/go-fed/activity/streams/vocab"
+)
+
+// NewSchemaPropertyValue creates a new SchemaPropertyValue
+func NewSchemaPropertyValue() vocab.SchemaPropertyValue {
+	return typepropertyvalue.NewSchemaPropertyValue()
+}
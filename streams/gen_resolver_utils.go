@@ -100,6 +100,9 @@ func ToType(c context.Context, m map[string]interface{}) (t vocab.Type, err erro
 	}, func(ctx context.Context, i vocab.TootEmoji) error {
 		t = i
 		return nil
+	}, func(ctx context.Context, i vocab.ActivityStreamsEndpoints) error {
+		t = i
+		return nil
 	}, func(ctx context.Context, i vocab.ActivityStreamsEvent) error {
 		t = i
 		return nil
@@ -112,6 +115,9 @@ func ToType(c context.Context, m map[string]interface{}) (t vocab.Type, err erro
 	}, func(ctx context.Context, i vocab.ActivityStreamsGroup) error {
 		t = i
 		return nil
+	}, func(ctx context.Context, i vocab.ActivityStreamsHashtag) error {
+		t = i
+		return nil
 	}, func(ctx context.Context, i vocab.TootIdentityProof) error {
 		t = i
 		return nil
@@ -178,6 +184,9 @@ func ToType(c context.Context, m map[string]interface{}) (t vocab.Type, err erro
 	}, func(ctx context.Context, i vocab.ActivityStreamsProfile) error {
 		t = i
 		return nil
+	}, func(ctx context.Context, i vocab.SchemaPropertyValue) error {
+		t = i
+		return nil
 	}, func(ctx context.Context, i vocab.W3IDSecurityV1PublicKey) error {
 		t = i
 		return nil
@@ -0,0 +1,36 @@
+// Code generated by astool. DO NOT EDIT.
+
+package propertyalsoknownas
+
+var mgr privateManager
+
+// privateManager abstracts the code-generated manager that provides access to
+// concrete implementations.
+type privateManager interface{}
+
+// SetManager sets the manager package-global variable. For internal use only, do
+// not use as part of Application behavior. Must be called at golang init time.
+func SetManager(m privateManager) {
+	mgr = m
+}
+
+// cloneUnknown deep copies an unknown value decoded from JSON. Maps and slices
+// are copied, and all other values are immutable and returned as-is.
+func cloneUnknown(i interface{}) interface{} {
+	switch v := i.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			m[k] = cloneUnknown(e)
+		}
+		return m
+	case []interface{}:
+		s := make([]interface{}, len(v))
+		for idx, e := range v {
+			s[idx] = cloneUnknown(e)
+		}
+		return s
+	default:
+		return i
+	}
+}
@@ -77,6 +77,20 @@ func (this *ActivityStreamsDurationProperty) Clear() {
 	this.hasDurationMember = false
 }
 
+// Clone returns a deep copy of this property. Modifying the copy does not modify
+// this property.
+func (this ActivityStreamsDurationProperty) Clone() vocab.ActivityStreamsDurationProperty {
+	c := &ActivityStreamsDurationProperty{alias: this.alias}
+	c.xmlschemaDurationMember = this.xmlschemaDurationMember
+	c.hasDurationMember = this.hasDurationMember
+	c.unknown = cloneUnknown(this.unknown)
+	if this.iri != nil {
+		u := *this.iri
+		c.iri = &u
+	}
+	return c
+}
+
 // Get returns the value of this property. When IsXMLSchemaDuration returns false,
 // Get will return any arbitrary value.
 func (this ActivityStreamsDurationProperty) Get() time.Duration {
@@ -0,0 +1,239 @@
+// Code generated by astool. DO NOT EDIT.
+
+package propertyendpoints
+
+import (
+	"fmt"
+	vocab "github.com/go-fed/activity/streams/vocab"
+	"net/url"
+)
+
+// ActivityStreamsEndpointsProperty is the functional property "endpoints". It is
+// permitted to be a single nilable value type.
+type ActivityStreamsEndpointsProperty struct {
+	activitystreamsEndpointsMember vocab.ActivityStreamsEndpoints
+	unknown                        interface{}
+	iri                            *url.URL
+	alias                          string
+}
+
+// DeserializeEndpointsProperty creates a "endpoints" property from an interface
+// representation that has been unmarshalled from a text or binary format.
+func DeserializeEndpointsProperty(m map[string]interface{}, aliasMap map[string]string) (*ActivityStreamsEndpointsProperty, error) {
+	alias := ""
+	if a, ok := aliasMap["https://www.w3.org/ns/activitystreams"]; ok {
+		alias = a
+	}
+	propName := "endpoints"
+	if len(alias) > 0 {
+		// Use alias both to find the property, and set within the property.
+		propName = fmt.Sprintf("%s:%s", alias, "endpoints")
+	}
+	i, ok := m[propName]
+
+	if ok {
+		if s, ok := i.(string); ok {
+			u, err := url.Parse(s)
+			// If error exists, don't error out -- skip this and treat as unknown string ([]byte) at worst
+			// Also, if no scheme exists, don't treat it as a URL -- net/url is greedy
+			if err == nil && len(u.Scheme) > 0 {
+				this := &ActivityStreamsEndpointsProperty{
+					alias: alias,
+					iri:   u,
+				}
+				return this, nil
+			}
+		}
+		if m, ok := i.(map[string]interface{}); ok {
+			if v, err := mgr.DeserializeEndpointsActivityStreams()(m, aliasMap); err == nil {
+				this := &ActivityStreamsEndpointsProperty{
+					activitystreamsEndpointsMember: v,
+					alias:                          alias,
+				}
+				return this, nil
+			}
+		}
+		this := &ActivityStreamsEndpointsProperty{
+			alias:   alias,
+			unknown: i,
+		}
+		return this, nil
+	}
+	return nil, nil
+}
+
+// NewActivityStreamsEndpointsProperty creates a new endpoints property.
+func NewActivityStreamsEndpointsProperty() *ActivityStreamsEndpointsProperty {
+	return &ActivityStreamsEndpointsProperty{alias: ""}
+}
+
+// Clear ensures no value of this property is set. Calling
+// IsActivityStreamsEndpoints afterwards will return false.
+func (this *ActivityStreamsEndpointsProperty) Clear() {
+	this.unknown = nil
+	this.iri = nil
+	this.activitystreamsEndpointsMember = nil
+}
+
+// Clone returns a deep copy of this property. Modifying the copy does not modify
+// this property.
+func (this ActivityStreamsEndpointsProperty) Clone() vocab.ActivityStreamsEndpointsProperty {
+	c := &ActivityStreamsEndpointsProperty{alias: this.alias}
+	if this.activitystreamsEndpointsMember != nil {
+		c.activitystreamsEndpointsMember = this.activitystreamsEndpointsMember.Clone().(vocab.ActivityStreamsEndpoints)
+	}
+	c.unknown = cloneUnknown(this.unknown)
+	if this.iri != nil {
+		u := *this.iri
+		c.iri = &u
+	}
+	return c
+}
+
+// Get returns the value of this property. When IsActivityStreamsEndpoints returns
+// false, Get will return any arbitrary value.
+func (this ActivityStreamsEndpointsProperty) Get() vocab.ActivityStreamsEndpoints {
+	return this.activitystreamsEndpointsMember
+}
+
+// GetIRI returns the IRI of this property. When IsIRI returns false, GetIRI will
+// return any arbitrary value.
+func (this ActivityStreamsEndpointsProperty) GetIRI() *url.URL {
+	return this.iri
+}
+
+// GetType returns the value in this property as a Type. Returns nil if the value
+// is not an ActivityStreams type, such as an IRI or another value.
+func (this ActivityStreamsEndpointsProperty) GetType() vocab.Type {
+	if this.IsActivityStreamsEndpoints() {
+		return this.Get()
+	}
+
+	return nil
+}
+
+// HasAny returns true if the value or IRI is set.
+func (this ActivityStreamsEndpointsProperty) HasAny() bool {
+	return this.IsActivityStreamsEndpoints() || this.iri != nil
+}
+
+// IsActivityStreamsEndpoints returns true if this property is set and not an IRI.
+func (this ActivityStreamsEndpointsProperty) IsActivityStreamsEndpoints() bool {
+	return this.activitystreamsEndpointsMember != nil
+}
+
+// IsIRI returns true if this property is an IRI.
+func (this ActivityStreamsEndpointsProperty) IsIRI() bool {
+	return this.iri != nil
+}
+
+// JSONLDContext returns the JSONLD URIs required in the context string for this
+// property and the specific values that are set. The value in the map is the
+// alias used to import the property's value or values.
+func (this ActivityStreamsEndpointsProperty) JSONLDContext() map[string]string {
+	m := map[string]string{"https://www.w3.org/ns/activitystreams": this.alias}
+	var child map[string]string
+	if this.IsActivityStreamsEndpoints() {
+		child = this.Get().JSONLDContext()
+	}
+	/*
+	   Since the literal maps in this function are determined at
+	   code-generation time, this loop should not overwrite an existing key with a
+	   new value.
+	*/
+	for k, v := range child {
+		m[k] = v
+	}
+	return m
+}
+
+// KindIndex computes an arbitrary value for indexing this kind of value. This is
+// a leaky API detail only for folks looking to replace the go-fed
+// implementation. Applications should not use this method.
+func (this ActivityStreamsEndpointsProperty) KindIndex() int {
+	if this.IsActivityStreamsEndpoints() {
+		return 0
+	}
+	if this.IsIRI() {
+		return -2
+	}
+	return -1
+}
+
+// LessThan compares two instances of this property with an arbitrary but stable
+// comparison. Applications should not use this because it is only meant to
+// help alternative implementations to go-fed to be able to normalize
+// nonfunctional properties.
+func (this ActivityStreamsEndpointsProperty) LessThan(o vocab.ActivityStreamsEndpointsProperty) bool {
+	// LessThan comparison for if either or both are IRIs.
+	if this.IsIRI() && o.IsIRI() {
+		return this.iri.String() < o.GetIRI().String()
+	} else if this.IsIRI() {
+		// IRIs are always less than other values, none, or unknowns
+		return true
+	} else if o.IsIRI() {
+		// This other, none, or unknown value is always greater than IRIs
+		return false
+	}
+	// LessThan comparison for the single value or unknown value.
+	if !this.IsActivityStreamsEndpoints() && !o.IsActivityStreamsEndpoints() {
+		// Both are unknowns.
+		return false
+	} else if this.IsActivityStreamsEndpoints() && !o.IsActivityStreamsEndpoints() {
+		// Values are always greater than unknown values.
+		return false
+	} else if !this.IsActivityStreamsEndpoints() && o.IsActivityStreamsEndpoints() {
+		// Unknowns are always less than known values.
+		return true
+	} else {
+		// Actual comparison.
+		return this.Get().LessThan(o.Get())
+	}
+}
+
+// Name returns the name of this property: "endpoints".
+func (this ActivityStreamsEndpointsProperty) Name() string {
+	if len(this.alias) > 0 {
+		return this.alias + ":" + "endpoints"
+	} else {
+		return "endpoints"
+	}
+}
+
+// Serialize converts this into an interface representation suitable for
+// marshalling into a text or binary format. Applications should not need this
+// function as most typical use cases serialize types instead of individual
+// properties. It is exposed for alternatives to go-fed implementations to use.
+func (this ActivityStreamsEndpointsProperty) Serialize() (interface{}, error) {
+	if this.IsActivityStreamsEndpoints() {
+		return this.Get().Serialize()
+	} else if this.IsIRI() {
+		return this.iri.String(), nil
+	}
+	return this.unknown, nil
+}
+
+// Set sets the value of this property. Calling IsActivityStreamsEndpoints
+// afterwards will return true.
+func (this *ActivityStreamsEndpointsProperty) Set(v vocab.ActivityStreamsEndpoints) {
+	this.Clear()
+	this.activitystreamsEndpointsMember = v
+}
+
+// SetIRI sets the value of this property. Calling IsIRI afterwards will return
+// true.
+func (this *ActivityStreamsEndpointsProperty) SetIRI(v *url.URL) {
+	this.Clear()
+	this.iri = v
+}
+
+// SetType attempts to set the property for the arbitrary type. Returns an error
+// if it is not a valid type to set on this property.
+func (this *ActivityStreamsEndpointsProperty) SetType(t vocab.Type) error {
+	if v, ok := t.(vocab.ActivityStreamsEndpoints); ok {
+		this.Set(v)
+		return nil
+	}
+
+	return fmt.Errorf("illegal type to set on endpoints property: %T", t)
+}
@@ -77,6 +77,20 @@ func (this *ActivityStreamsEndTimeProperty) Clear() {
 	this.hasDateTimeMember = false
 }
 
+// Clone returns a deep copy of this property. Modifying the copy does not modify
+// this property.
+func (this ActivityStreamsEndTimeProperty) Clone() vocab.ActivityStreamsEndTimeProperty {
+	c := &ActivityStreamsEndTimeProperty{alias: this.alias}
+	c.xmlschemaDateTimeMember = this.xmlschemaDateTimeMember
+	c.hasDateTimeMember = this.hasDateTimeMember
+	c.unknown = cloneUnknown(this.unknown)
+	if this.iri != nil {
+		u := *this.iri
+		c.iri = &u
+	}
+	return c
+}
+
 // Get returns the value of this property. When IsXMLSchemaDateTime returns false,
 // Get will return any arbitrary value.
 func (this ActivityStreamsEndTimeProperty) Get() time.Time {
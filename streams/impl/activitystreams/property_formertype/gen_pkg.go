@@ -177,6 +177,10 @@ type privateManager interface {
 	// for the "ActivityStreamsProfile" non-functional property in the
 	// vocabulary "ActivityStreams"
 	DeserializeProfileActivityStreams() func(map[string]interface{}, map[string]string) (vocab.ActivityStreamsProfile, error)
+	// DeserializePropertyValueSchema returns the deserialization method for
+	// the "SchemaPropertyValue" non-functional property in the vocabulary
+	// "Schema"
+	DeserializePropertyValueSchema() func(map[string]interface{}, map[string]string) (vocab.SchemaPropertyValue, error)
 	// DeserializePushForgeFed returns the deserialization method for the
 	// "ForgeFedPush" non-functional property in the vocabulary "ForgeFed"
 	DeserializePushForgeFed() func(map[string]interface{}, map[string]string) (vocab.ForgeFedPush, error)
@@ -255,3 +259,24 @@ type privateManager interface {
 func SetManager(m privateManager) {
 	mgr = m
 }
+
+// cloneUnknown deep copies an unknown value decoded from JSON. Maps and slices
+// are copied, and all other values are immutable and returned as-is.
+func cloneUnknown(i interface{}) interface{} {
+	switch v := i.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			m[k] = cloneUnknown(e)
+		}
+		return m
+	case []interface{}:
+		s := make([]interface{}, len(v))
+		for idx, e := range v {
+			s[idx] = cloneUnknown(e)
+		}
+		return s
+	default:
+		return i
+	}
+}
@@ -76,6 +76,20 @@ func (this *ActivityStreamsHeightProperty) Clear() {
 	this.hasNonNegativeIntegerMember = false
 }
 
+// Clone returns a deep copy of this property. Modifying the copy does not modify
+// this property.
+func (this ActivityStreamsHeightProperty) Clone() vocab.ActivityStreamsHeightProperty {
+	c := &ActivityStreamsHeightProperty{alias: this.alias}
+	c.xmlschemaNonNegativeIntegerMember = this.xmlschemaNonNegativeIntegerMember
+	c.hasNonNegativeIntegerMember = this.hasNonNegativeIntegerMember
+	c.unknown = cloneUnknown(this.unknown)
+	if this.iri != nil {
+		u := *this.iri
+		c.iri = &u
+	}
+	return c
+}
+
 // Get returns the value of this property. When IsXMLSchemaNonNegativeInteger
 // returns false, Get will return any arbitrary value.
 func (this ActivityStreamsHeightProperty) Get() int {
@@ -60,6 +60,18 @@ func (this *ActivityStreamsHrefProperty) Clear() {
 	this.xmlschemaAnyURIMember = nil
 }
 
+// Clone returns a deep copy of this property. Modifying the copy does not modify
+// this property.
+func (this ActivityStreamsHrefProperty) Clone() vocab.ActivityStreamsHrefProperty {
+	c := &ActivityStreamsHrefProperty{alias: this.alias}
+	if this.xmlschemaAnyURIMember != nil {
+		u := *this.xmlschemaAnyURIMember
+		c.xmlschemaAnyURIMember = &u
+	}
+	c.unknown = cloneUnknown(this.unknown)
+	return c
+}
+
 // Get returns the value of this property. When IsXMLSchemaAnyURI returns false,
 // Get will return any arbitrary value.
 func (this ActivityStreamsHrefProperty) Get() *url.URL {
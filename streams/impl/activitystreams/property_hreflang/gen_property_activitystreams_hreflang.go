@@ -76,6 +76,20 @@ func (this *ActivityStreamsHreflangProperty) Clear() {
 	this.hasBcp47Member = false
 }
 
+// Clone returns a deep copy of this property. Modifying the copy does not modify
+// this property.
+func (this ActivityStreamsHreflangProperty) Clone() vocab.ActivityStreamsHreflangProperty {
+	c := &ActivityStreamsHreflangProperty{alias: this.alias}
+	c.rfcBcp47Member = this.rfcBcp47Member
+	c.hasBcp47Member = this.hasBcp47Member
+	c.unknown = cloneUnknown(this.unknown)
+	if this.iri != nil {
+		u := *this.iri
+		c.iri = &u
+	}
+	return c
+}
+
 // Get returns the value of this property. When IsRFCBcp47 returns false, Get will
 // return any arbitrary value.
 func (this ActivityStreamsHreflangProperty) Get() string {
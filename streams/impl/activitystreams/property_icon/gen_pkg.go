@@ -9,6 +9,10 @@ var mgr privateManager
 // privateManager abstracts the code-generated manager that provides access to
 // concrete implementations.
 type privateManager interface {
+	// DeserializeHashtagActivityStreams returns the deserialization method
+	// for the "ActivityStreamsHashtag" non-functional property in the
+	// vocabulary "ActivityStreams"
+	DeserializeHashtagActivityStreams() func(map[string]interface{}, map[string]string) (vocab.ActivityStreamsHashtag, error)
 	// DeserializeImageActivityStreams returns the deserialization method for
 	// the "ActivityStreamsImage" non-functional property in the
 	// vocabulary "ActivityStreams"
@@ -28,3 +32,24 @@ type privateManager interface {
 func SetManager(m privateManager) {
 	mgr = m
 }
+
+// cloneUnknown deep copies an unknown value decoded from JSON. Maps and slices
+// are copied, and all other values are immutable and returned as-is.
+func cloneUnknown(i interface{}) interface{} {
+	switch v := i.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			m[k] = cloneUnknown(e)
+		}
+		return m
+	case []interface{}:
+		s := make([]interface{}, len(v))
+		for idx, e := range v {
+			s[idx] = cloneUnknown(e)
+		}
+		return s
+	default:
+		return i
+	}
+}
@@ -13,6 +13,10 @@ type privateManager interface {
 	// method for the "ActivityStreamsCollectionPage" non-functional
 	// property in the vocabulary "ActivityStreams"
 	DeserializeCollectionPageActivityStreams() func(map[string]interface{}, map[string]string) (vocab.ActivityStreamsCollectionPage, error)
+	// DeserializeHashtagActivityStreams returns the deserialization method
+	// for the "ActivityStreamsHashtag" non-functional property in the
+	// vocabulary "ActivityStreams"
+	DeserializeHashtagActivityStreams() func(map[string]interface{}, map[string]string) (vocab.ActivityStreamsHashtag, error)
 	// DeserializeLinkActivityStreams returns the deserialization method for
 	// the "ActivityStreamsLink" non-functional property in the vocabulary
 	// "ActivityStreams"
@@ -33,3 +37,24 @@ type privateManager interface {
 func SetManager(m privateManager) {
 	mgr = m
 }
+
+// cloneUnknown deep copies an unknown value decoded from JSON. Maps and slices
+// are copied, and all other values are immutable and returned as-is.
+func cloneUnknown(i interface{}) interface{} {
+	switch v := i.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			m[k] = cloneUnknown(e)
+		}
+		return m
+	case []interface{}:
+		s := make([]interface{}, len(v))
+		for idx, e := range v {
+			s[idx] = cloneUnknown(e)
+		}
+		return s
+	default:
+		return i
+	}
+}
@@ -76,6 +76,20 @@ func (this *ActivityStreamsMediaTypeProperty) Clear() {
 	this.hasRfc2045Member = false
 }
 
+// Clone returns a deep copy of this property. Modifying the copy does not modify
+// this property.
+func (this ActivityStreamsMediaTypeProperty) Clone() vocab.ActivityStreamsMediaTypeProperty {
+	c := &ActivityStreamsMediaTypeProperty{alias: this.alias}
+	c.rfcRfc2045Member = this.rfcRfc2045Member
+	c.hasRfc2045Member = this.hasRfc2045Member
+	c.unknown = cloneUnknown(this.unknown)
+	if this.iri != nil {
+		u := *this.iri
+		c.iri = &u
+	}
+	return c
+}
+
 // Get returns the value of this property. When IsRFCRfc2045 returns false, Get
 // will return any arbitrary value.
 func (this ActivityStreamsMediaTypeProperty) Get() string {
@@ -273,6 +273,25 @@ func (this *ActivityStreamsNamePropertyIterator) clear() {
 	this.rdfLangStringMember = nil
 }
 
+// clone returns a deep copy of this iterator, which has no parent property.
+func (this ActivityStreamsNamePropertyIterator) clone() *ActivityStreamsNamePropertyIterator {
+	c := &ActivityStreamsNamePropertyIterator{alias: this.alias}
+	c.xmlschemaStringMember = this.xmlschemaStringMember
+	c.hasStringMember = this.hasStringMember
+	if this.rdfLangStringMember != nil {
+		c.rdfLangStringMember = make(map[string]string, len(this.rdfLangStringMember))
+		for k, v := range this.rdfLangStringMember {
+			c.rdfLangStringMember[k] = v
+		}
+	}
+	c.unknown = cloneUnknown(this.unknown)
+	if this.iri != nil {
+		u := *this.iri
+		c.iri = &u
+	}
+	return c
+}
+
 // serialize converts this into an interface representation suitable for
 // marshalling into a text or binary format. Applications should not need this
 // function as most typical use cases serialize types instead of individual
@@ -396,6 +415,22 @@ func (this ActivityStreamsNameProperty) Begin() vocab.ActivityStreamsNamePropert
 	}
 }
 
+// Clone returns a deep copy of this property and each of its values. Modifying
+// the copy does not modify this property.
+func (this ActivityStreamsNameProperty) Clone() vocab.ActivityStreamsNameProperty {
+	c := &ActivityStreamsNameProperty{alias: this.alias}
+	if this.properties != nil {
+		c.properties = make([]*ActivityStreamsNamePropertyIterator, len(this.properties))
+	}
+	for i, it := range this.properties {
+		cit := it.clone()
+		cit.parent = c
+		cit.myIdx = i
+		c.properties[i] = cit
+	}
+	return c
+}
+
 // Empty returns returns true if there are no elements.
 func (this ActivityStreamsNameProperty) Empty() bool {
 	return this.Len() == 0
@@ -622,6 +657,21 @@ func (this ActivityStreamsNameProperty) Serialize() (interface{}, error) {
 	return s, nil
 }
 
+// SerializeEach serializes each value of this property in order, calling fn with
+// each, and stops at the first error. Unlike Serialize, it never holds the
+// serialized values all at once, so large properties can be encoded as a
+// stream.
+func (this ActivityStreamsNameProperty) SerializeEach(fn func(interface{}) error) error {
+	for _, iterator := range this.properties {
+		if b, err := iterator.serialize(); err != nil {
+			return err
+		} else if err := fn(b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // SetIRI sets an IRI value to be at the specified index for the property "name".
 // Panics if the index is out of bounds.
 func (this *ActivityStreamsNameProperty) SetIRI(idx int, v *url.URL) {
@@ -95,6 +95,26 @@ func (this *ActivityStreamsPreferredUsernameProperty) Clear() {
 	this.rdfLangStringMember = nil
 }
 
+// Clone returns a deep copy of this property. Modifying the copy does not modify
+// this property.
+func (this ActivityStreamsPreferredUsernameProperty) Clone() vocab.ActivityStreamsPreferredUsernameProperty {
+	c := &ActivityStreamsPreferredUsernameProperty{alias: this.alias}
+	c.xmlschemaStringMember = this.xmlschemaStringMember
+	c.hasStringMember = this.hasStringMember
+	if this.rdfLangStringMember != nil {
+		c.rdfLangStringMember = make(map[string]string, len(this.rdfLangStringMember))
+		for k, v := range this.rdfLangStringMember {
+			c.rdfLangStringMember[k] = v
+		}
+	}
+	c.unknown = cloneUnknown(this.unknown)
+	if this.iri != nil {
+		u := *this.iri
+		c.iri = &u
+	}
+	return c
+}
+
 // GetIRI returns the IRI of this property. When IsIRI returns false, GetIRI will
 // return an arbitrary value.
 func (this ActivityStreamsPreferredUsernameProperty) GetIRI() *url.URL {
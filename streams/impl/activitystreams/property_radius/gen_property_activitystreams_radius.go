@@ -76,6 +76,20 @@ func (this *ActivityStreamsRadiusProperty) Clear() {
 	this.hasFloatMember = false
 }
 
+// Clone returns a deep copy of this property. Modifying the copy does not modify
+// this property.
+func (this ActivityStreamsRadiusProperty) Clone() vocab.ActivityStreamsRadiusProperty {
+	c := &ActivityStreamsRadiusProperty{alias: this.alias}
+	c.xmlschemaFloatMember = this.xmlschemaFloatMember
+	c.hasFloatMember = this.hasFloatMember
+	c.unknown = cloneUnknown(this.unknown)
+	if this.iri != nil {
+		u := *this.iri
+		c.iri = &u
+	}
+	return c
+}
+
 // Get returns the value of this property. When IsXMLSchemaFloat returns false,
 // Get will return any arbitrary value.
 func (this ActivityStreamsRadiusProperty) Get() float64 {
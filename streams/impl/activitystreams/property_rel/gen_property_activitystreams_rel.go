@@ -199,6 +199,19 @@ func (this *ActivityStreamsRelPropertyIterator) clear() {
 	this.hasRfc5988Member = false
 }
 
+// clone returns a deep copy of this iterator, which has no parent property.
+func (this ActivityStreamsRelPropertyIterator) clone() *ActivityStreamsRelPropertyIterator {
+	c := &ActivityStreamsRelPropertyIterator{alias: this.alias}
+	c.rfcRfc5988Member = this.rfcRfc5988Member
+	c.hasRfc5988Member = this.hasRfc5988Member
+	c.unknown = cloneUnknown(this.unknown)
+	if this.iri != nil {
+		u := *this.iri
+		c.iri = &u
+	}
+	return c
+}
+
 // serialize converts this into an interface representation suitable for
 // marshalling into a text or binary format. Applications should not need this
 // function as most typical use cases serialize types instead of individual
@@ -306,6 +319,22 @@ func (this ActivityStreamsRelProperty) Begin() vocab.ActivityStreamsRelPropertyI
 	}
 }
 
+// Clone returns a deep copy of this property and each of its values. Modifying
+// the copy does not modify this property.
+func (this ActivityStreamsRelProperty) Clone() vocab.ActivityStreamsRelProperty {
+	c := &ActivityStreamsRelProperty{alias: this.alias}
+	if this.properties != nil {
+		c.properties = make([]*ActivityStreamsRelPropertyIterator, len(this.properties))
+	}
+	for i, it := range this.properties {
+		cit := it.clone()
+		cit.parent = c
+		cit.myIdx = i
+		c.properties[i] = cit
+	}
+	return c
+}
+
 // Empty returns returns true if there are no elements.
 func (this ActivityStreamsRelProperty) Empty() bool {
 	return this.Len() == 0
@@ -497,6 +526,21 @@ func (this ActivityStreamsRelProperty) Serialize() (interface{}, error) {
 	return s, nil
 }
 
+// SerializeEach serializes each value of this property in order, calling fn with
+// each, and stops at the first error. Unlike Serialize, it never holds the
+// serialized values all at once, so large properties can be encoded as a
+// stream.
+func (this ActivityStreamsRelProperty) SerializeEach(fn func(interface{}) error) error {
+	for _, iterator := range this.properties {
+		if b, err := iterator.serialize(); err != nil {
+			return err
+		} else if err := fn(b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Set sets a rfc5988 value to be at the specified index for the property "rel".
 // Panics if the index is out of bounds. Invalidates all iterators.
 func (this *ActivityStreamsRelProperty) Set(idx int, v string) {
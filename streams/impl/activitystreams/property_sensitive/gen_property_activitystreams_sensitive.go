@@ -200,6 +200,19 @@ func (this *ActivityStreamsSensitivePropertyIterator) clear() {
 	this.hasBooleanMember = false
 }
 
+// clone returns a deep copy of this iterator, which has no parent property.
+func (this ActivityStreamsSensitivePropertyIterator) clone() *ActivityStreamsSensitivePropertyIterator {
+	c := &ActivityStreamsSensitivePropertyIterator{alias: this.alias}
+	c.xmlschemaBooleanMember = this.xmlschemaBooleanMember
+	c.hasBooleanMember = this.hasBooleanMember
+	c.unknown = cloneUnknown(this.unknown)
+	if this.iri != nil {
+		u := *this.iri
+		c.iri = &u
+	}
+	return c
+}
+
 // serialize converts this into an interface representation suitable for
 // marshalling into a text or binary format. Applications should not need this
 // function as most typical use cases serialize types instead of individual
@@ -307,6 +320,22 @@ func (this ActivityStreamsSensitiveProperty) Begin() vocab.ActivityStreamsSensit
 	}
 }
 
+// Clone returns a deep copy of this property and each of its values. Modifying
+// the copy does not modify this property.
+func (this ActivityStreamsSensitiveProperty) Clone() vocab.ActivityStreamsSensitiveProperty {
+	c := &ActivityStreamsSensitiveProperty{alias: this.alias}
+	if this.properties != nil {
+		c.properties = make([]*ActivityStreamsSensitivePropertyIterator, len(this.properties))
+	}
+	for i, it := range this.properties {
+		cit := it.clone()
+		cit.parent = c
+		cit.myIdx = i
+		c.properties[i] = cit
+	}
+	return c
+}
+
 // Empty returns returns true if there are no elements.
 func (this ActivityStreamsSensitiveProperty) Empty() bool {
 	return this.Len() == 0
@@ -499,6 +528,21 @@ func (this ActivityStreamsSensitiveProperty) Serialize() (interface{}, error) {
 	return s, nil
 }
 
+// SerializeEach serializes each value of this property in order, calling fn with
+// each, and stops at the first error. Unlike Serialize, it never holds the
+// serialized values all at once, so large properties can be encoded as a
+// stream.
+func (this ActivityStreamsSensitiveProperty) SerializeEach(fn func(interface{}) error) error {
+	for _, iterator := range this.properties {
+		if b, err := iterator.serialize(); err != nil {
+			return err
+		} else if err := fn(b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Set sets a boolean value to be at the specified index for the property
 // "sensitive". Panics if the index is out of bounds. Invalidates all
 // iterators.
@@ -74,6 +74,20 @@ func (this *ActivityStreamsUnitsProperty) Clear() {
 	this.unknown = nil
 }
 
+// Clone returns a deep copy of this property. Modifying the copy does not modify
+// this property.
+func (this ActivityStreamsUnitsProperty) Clone() vocab.ActivityStreamsUnitsProperty {
+	c := &ActivityStreamsUnitsProperty{alias: this.alias}
+	c.xmlschemaStringMember = this.xmlschemaStringMember
+	c.hasStringMember = this.hasStringMember
+	if this.xmlschemaAnyURIMember != nil {
+		u := *this.xmlschemaAnyURIMember
+		c.xmlschemaAnyURIMember = &u
+	}
+	c.unknown = cloneUnknown(this.unknown)
+	return c
+}
+
 // GetIRI returns the IRI of this property. When IsIRI returns false, GetIRI will
 // return an arbitrary value.
 func (this ActivityStreamsUnitsProperty) GetIRI() *url.URL {
@@ -91,7 +91,7 @@ func ActivityStreamsAnnounceExtends(other vocab.Type) bool {
 // AnnounceIsDisjointWith returns true if the other provided type is disjoint with
 // the Announce type.
 func AnnounceIsDisjointWith(other vocab.Type) bool {
-	disjointWith := []string{"Link", "Mention"}
+	disjointWith := []string{"Hashtag", "Link", "Mention"}
 	for _, disjoint := range disjointWith {
 		if disjoint == other.GetTypeName() {
 			return true
@@ -358,97 +358,106 @@ func DeserializeAnnounce(m map[string]interface{}, aliasMap map[string]string) (
 	// End: Known property deserialization
 
 	// Begin: Unknown deserialization
+	// Known properties are prefixed with the alias of their vocabulary, if any.
+	activitystreamsPrefix := ""
+	if a, ok := aliasMap["https://www.w3.org/ns/activitystreams"]; ok && len(a) > 0 {
+		activitystreamsPrefix = a + ":"
+	}
+	forgefedPrefix := ""
+	if a, ok := aliasMap["https://forgefed.peers.community/ns"]; ok && len(a) > 0 {
+		forgefedPrefix = a + ":"
+	}
 	for k, v := range m {
 		// Begin: Code that ensures a property name is unknown
-		if k == "actor" {
+		if k == activitystreamsPrefix+"actor" {
 			continue
-		} else if k == "altitude" {
+		} else if k == activitystreamsPrefix+"altitude" {
 			continue
-		} else if k == "attachment" {
+		} else if k == activitystreamsPrefix+"attachment" {
 			continue
-		} else if k == "attributedTo" {
+		} else if k == activitystreamsPrefix+"attributedTo" {
 			continue
-		} else if k == "audience" {
+		} else if k == activitystreamsPrefix+"audience" {
 			continue
-		} else if k == "bcc" {
+		} else if k == activitystreamsPrefix+"bcc" {
 			continue
-		} else if k == "bto" {
+		} else if k == activitystreamsPrefix+"bto" {
 			continue
-		} else if k == "cc" {
+		} else if k == activitystreamsPrefix+"cc" {
 			continue
-		} else if k == "content" {
+		} else if k == activitystreamsPrefix+"content" {
 			continue
-		} else if k == "contentMap" {
+		} else if k == activitystreamsPrefix+"contentMap" {
 			continue
-		} else if k == "context" {
+		} else if k == activitystreamsPrefix+"context" {
 			continue
-		} else if k == "duration" {
+		} else if k == activitystreamsPrefix+"duration" {
 			continue
-		} else if k == "endTime" {
+		} else if k == activitystreamsPrefix+"endTime" {
 			continue
-		} else if k == "generator" {
+		} else if k == activitystreamsPrefix+"generator" {
 			continue
-		} else if k == "icon" {
+		} else if k == activitystreamsPrefix+"icon" {
 			continue
 		} else if k == "id" {
 			continue
-		} else if k == "image" {
+		} else if k == activitystreamsPrefix+"image" {
 			continue
-		} else if k == "inReplyTo" {
+		} else if k == activitystreamsPrefix+"inReplyTo" {
 			continue
-		} else if k == "instrument" {
+		} else if k == activitystreamsPrefix+"instrument" {
 			continue
-		} else if k == "likes" {
+		} else if k == activitystreamsPrefix+"likes" {
 			continue
-		} else if k == "location" {
+		} else if k == activitystreamsPrefix+"location" {
 			continue
-		} else if k == "mediaType" {
+		} else if k == activitystreamsPrefix+"mediaType" {
 			continue
-		} else if k == "name" {
+		} else if k == activitystreamsPrefix+"name" {
 			continue
-		} else if k == "nameMap" {
+		} else if k == activitystreamsPrefix+"nameMap" {
 			continue
-		} else if k == "object" {
+		} else if k == activitystreamsPrefix+"object" {
 			continue
-		} else if k == "origin" {
+		} else if k == activitystreamsPrefix+"origin" {
 			continue
-		} else if k == "preview" {
+		} else if k == activitystreamsPrefix+"preview" {
 			continue
-		} else if k == "published" {
+		} else if k == activitystreamsPrefix+"published" {
 			continue
-		} else if k == "replies" {
+		} else if k == activitystreamsPrefix+"replies" {
 			continue
-		} else if k == "result" {
+		} else if k == activitystreamsPrefix+"result" {
 			continue
-		} else if k == "sensitive" {
+		} else if k == activitystreamsPrefix+"sensitive" {
 			continue
-		} else if k == "shares" {
+		} else if k == activitystreamsPrefix+"shares" {
 			continue
-		} else if k == "source" {
+		} else if k == activitystreamsPrefix+"source" {
 			continue
-		} else if k == "startTime" {
+		} else if k == activitystreamsPrefix+"startTime" {
 			continue
-		} else if k == "summary" {
+		} else if k == activitystreamsPrefix+"summary" {
 			continue
-		} else if k == "summaryMap" {
+		} else if k == activitystreamsPrefix+"summaryMap" {
 			continue
-		} else if k == "tag" {
+		} else if k == activitystreamsPrefix+"tag" {
 			continue
-		} else if k == "target" {
+		} else if k == activitystreamsPrefix+"target" {
 			continue
-		} else if k == "team" {
+		} else if k == forgefedPrefix+"team" {
 			continue
-		} else if k == "ticketsTrackedBy" {
+		} else if k == forgefedPrefix+"ticketsTrackedBy" {
 			continue
-		} else if k == "to" {
+		} else if k == activitystreamsPrefix+"to" {
 			continue
-		} else if k == "tracksTicketsFor" {
+		} else if k == forgefedPrefix+"tracksTicketsFor" {
 			continue
 		} else if k == "type" {
 			continue
-		} else if k == "updated" {
+		} else if k == activitystreamsPrefix+"updated" {
 			continue
-		} else if k == "url" {
+		} else if k == activitystreamsPrefix+"url" {
 			continue
 		} // End: Code that ensures a property name is unknown
 
@@ -479,6 +488,273 @@ func NewActivityStreamsAnnounce() *ActivityStreamsAnnounce {
 	}
 }
 
+// Clone returns a deep copy of this type. Modifying the copy does not modify this
+// type.
+func (this ActivityStreamsAnnounce) Clone() vocab.Type {
+	c := &ActivityStreamsAnnounce{alias: this.alias}
+	if this.ActivityStreamsActor != nil {
+		c.ActivityStreamsActor = this.ActivityStreamsActor.Clone()
+	}
+	if this.ActivityStreamsAltitude != nil {
+		c.ActivityStreamsAltitude = this.ActivityStreamsAltitude.Clone()
+	}
+	if this.ActivityStreamsAttachment != nil {
+		c.ActivityStreamsAttachment = this.ActivityStreamsAttachment.Clone()
+	}
+	if this.ActivityStreamsAttributedTo != nil {
+		c.ActivityStreamsAttributedTo = this.ActivityStreamsAttributedTo.Clone()
+	}
+	if this.ActivityStreamsAudience != nil {
+		c.ActivityStreamsAudience = this.ActivityStreamsAudience.Clone()
+	}
+	if this.ActivityStreamsBcc != nil {
+		c.ActivityStreamsBcc = this.ActivityStreamsBcc.Clone()
+	}
+	if this.ActivityStreamsBto != nil {
+		c.ActivityStreamsBto = this.ActivityStreamsBto.Clone()
+	}
+	if this.ActivityStreamsCc != nil {
+		c.ActivityStreamsCc = this.ActivityStreamsCc.Clone()
+	}
+	if this.ActivityStreamsContent != nil {
+		c.ActivityStreamsContent = this.ActivityStreamsContent.Clone()
+	}
+	if this.ActivityStreamsContext != nil {
+		c.ActivityStreamsContext = this.ActivityStreamsContext.Clone()
+	}
+	if this.ActivityStreamsDuration != nil {
+		c.ActivityStreamsDuration = this.ActivityStreamsDuration.Clone()
+	}
+	if this.ActivityStreamsEndTime != nil {
+		c.ActivityStreamsEndTime = this.ActivityStreamsEndTime.Clone()
+	}
+	if this.ActivityStreamsGenerator != nil {
+		c.ActivityStreamsGenerator = this.ActivityStreamsGenerator.Clone()
+	}
+	if this.ActivityStreamsIcon != nil {
+		c.ActivityStreamsIcon = this.ActivityStreamsIcon.Clone()
+	}
+	if this.JSONLDId != nil {
+		c.JSONLDId = this.JSONLDId.Clone()
+	}
+	if this.ActivityStreamsImage != nil {
+		c.ActivityStreamsImage = this.ActivityStreamsImage.Clone()
+	}
+	if this.ActivityStreamsInReplyTo != nil {
+		c.ActivityStreamsInReplyTo = this.ActivityStreamsInReplyTo.Clone()
+	}
+	if this.ActivityStreamsInstrument != nil {
+		c.ActivityStreamsInstrument = this.ActivityStreamsInstrument.Clone()
+	}
+	if this.ActivityStreamsLikes != nil {
+		c.ActivityStreamsLikes = this.ActivityStreamsLikes.Clone()
+	}
+	if this.ActivityStreamsLocation != nil {
+		c.ActivityStreamsLocation = this.ActivityStreamsLocation.Clone()
+	}
+	if this.ActivityStreamsMediaType != nil {
+		c.ActivityStreamsMediaType = this.ActivityStreamsMediaType.Clone()
+	}
+	if this.ActivityStreamsName != nil {
+		c.ActivityStreamsName = this.ActivityStreamsName.Clone()
+	}
+	if this.ActivityStreamsObject != nil {
+		c.ActivityStreamsObject = this.ActivityStreamsObject.Clone()
+	}
+	if this.ActivityStreamsOrigin != nil {
+		c.ActivityStreamsOrigin = this.ActivityStreamsOrigin.Clone()
+	}
+	if this.ActivityStreamsPreview != nil {
+		c.ActivityStreamsPreview = this.ActivityStreamsPreview.Clone()
+	}
+	if this.ActivityStreamsPublished != nil {
+		c.ActivityStreamsPublished = this.ActivityStreamsPublished.Clone()
+	}
+	if this.ActivityStreamsReplies != nil {
+		c.ActivityStreamsReplies = this.ActivityStreamsReplies.Clone()
+	}
+	if this.ActivityStreamsResult != nil {
+		c.ActivityStreamsResult = this.ActivityStreamsResult.Clone()
+	}
+	if this.ActivityStreamsSensitive != nil {
+		c.ActivityStreamsSensitive = this.ActivityStreamsSensitive.Clone()
+	}
+	if this.ActivityStreamsShares != nil {
+		c.ActivityStreamsShares = this.ActivityStreamsShares.Clone()
+	}
+	if this.ActivityStreamsSource != nil {
+		c.ActivityStreamsSource = this.ActivityStreamsSource.Clone()
+	}
+	if this.ActivityStreamsStartTime != nil {
+		c.ActivityStreamsStartTime = this.ActivityStreamsStartTime.Clone()
+	}
+	if this.ActivityStreamsSummary != nil {
+		c.ActivityStreamsSummary = this.ActivityStreamsSummary.Clone()
+	}
+	if this.ActivityStreamsTag != nil {
+		c.ActivityStreamsTag = this.ActivityStreamsTag.Clone()
+	}
+	if this.ActivityStreamsTarget != nil {
+		c.ActivityStreamsTarget = this.ActivityStreamsTarget.Clone()
+	}
+	if this.ForgeFedTeam != nil {
+		c.ForgeFedTeam = this.ForgeFedTeam.Clone()
+	}
+	if this.ForgeFedTicketsTrackedBy != nil {
+		c.ForgeFedTicketsTrackedBy = this.ForgeFedTicketsTrackedBy.Clone()
+	}
+	if this.ActivityStreamsTo != nil {
+		c.ActivityStreamsTo = this.ActivityStreamsTo.Clone()
+	}
+	if this.ForgeFedTracksTicketsFor != nil {
+		c.ForgeFedTracksTicketsFor = this.ForgeFedTracksTicketsFor.Clone()
+	}
+	if this.JSONLDType != nil {
+		c.JSONLDType = this.JSONLDType.Clone()
+	}
+	if this.ActivityStreamsUpdated != nil {
+		c.ActivityStreamsUpdated = this.ActivityStreamsUpdated.Clone()
+	}
+	if this.ActivityStreamsUrl != nil {
+		c.ActivityStreamsUrl = this.ActivityStreamsUrl.Clone()
+	}
+	if this.unknown != nil {
+		c.unknown = cloneUnknown(this.unknown).(map[string]interface{})
+	}
+	return c
+}
+
+// EachProperty calls fn with the name and value of each property that is set, in
+// a stable order. Unknown properties are not included.
+func (this ActivityStreamsAnnounce) EachProperty(fn func(name string, value vocab.PropertyInterface)) {
+	if this.ActivityStreamsActor != nil {
+		fn(this.ActivityStreamsActor.Name(), this.ActivityStreamsActor)
+	}
+	if this.ActivityStreamsAltitude != nil {
+		fn(this.ActivityStreamsAltitude.Name(), this.ActivityStreamsAltitude)
+	}
+	if this.ActivityStreamsAttachment != nil {
+		fn(this.ActivityStreamsAttachment.Name(), this.ActivityStreamsAttachment)
+	}
+	if this.ActivityStreamsAttributedTo != nil {
+		fn(this.ActivityStreamsAttributedTo.Name(), this.ActivityStreamsAttributedTo)
+	}
+	if this.ActivityStreamsAudience != nil {
+		fn(this.ActivityStreamsAudience.Name(), this.ActivityStreamsAudience)
+	}
+	if this.ActivityStreamsBcc != nil {
+		fn(this.ActivityStreamsBcc.Name(), this.ActivityStreamsBcc)
+	}
+	if this.ActivityStreamsBto != nil {
+		fn(this.ActivityStreamsBto.Name(), this.ActivityStreamsBto)
+	}
+	if this.ActivityStreamsCc != nil {
+		fn(this.ActivityStreamsCc.Name(), this.ActivityStreamsCc)
+	}
+	if this.ActivityStreamsContent != nil {
+		fn(this.ActivityStreamsContent.Name(), this.ActivityStreamsContent)
+	}
+	if this.ActivityStreamsContext != nil {
+		fn(this.ActivityStreamsContext.Name(), this.ActivityStreamsContext)
+	}
+	if this.ActivityStreamsDuration != nil {
+		fn(this.ActivityStreamsDuration.Name(), this.ActivityStreamsDuration)
+	}
+	if this.ActivityStreamsEndTime != nil {
+		fn(this.ActivityStreamsEndTime.Name(), this.ActivityStreamsEndTime)
+	}
+	if this.ActivityStreamsGenerator != nil {
+		fn(this.ActivityStreamsGenerator.Name(), this.ActivityStreamsGenerator)
+	}
+	if this.ActivityStreamsIcon != nil {
+		fn(this.ActivityStreamsIcon.Name(), this.ActivityStreamsIcon)
+	}
+	if this.JSONLDId != nil {
+		fn(this.JSONLDId.Name(), this.JSONLDId)
+	}
+	if this.ActivityStreamsImage != nil {
+		fn(this.ActivityStreamsImage.Name(), this.ActivityStreamsImage)
+	}
+	if this.ActivityStreamsInReplyTo != nil {
+		fn(this.ActivityStreamsInReplyTo.Name(), this.ActivityStreamsInReplyTo)
+	}
+	if this.ActivityStreamsInstrument != nil {
+		fn(this.ActivityStreamsInstrument.Name(), this.ActivityStreamsInstrument)
+	}
+	if this.ActivityStreamsLikes != nil {
+		fn(this.ActivityStreamsLikes.Name(), this.ActivityStreamsLikes)
+	}
+	if this.ActivityStreamsLocation != nil {
+		fn(this.ActivityStreamsLocation.Name(), this.ActivityStreamsLocation)
+	}
+	if this.ActivityStreamsMediaType != nil {
+		fn(this.ActivityStreamsMediaType.Name(), this.ActivityStreamsMediaType)
+	}
+	if this.ActivityStreamsName != nil {
+		fn(this.ActivityStreamsName.Name(), this.ActivityStreamsName)
+	}
+	if this.ActivityStreamsObject != nil {
+		fn(this.ActivityStreamsObject.Name(), this.ActivityStreamsObject)
+	}
+	if this.ActivityStreamsOrigin != nil {
+		fn(this.ActivityStreamsOrigin.Name(), this.ActivityStreamsOrigin)
+	}
+	if this.ActivityStreamsPreview != nil {
+		fn(this.ActivityStreamsPreview.Name(), this.ActivityStreamsPreview)
+	}
+	if this.ActivityStreamsPublished != nil {
+		fn(this.ActivityStreamsPublished.Name(), this.ActivityStreamsPublished)
+	}
+	if this.ActivityStreamsReplies != nil {
+		fn(this.ActivityStreamsReplies.Name(), this.ActivityStreamsReplies)
+	}
+	if this.ActivityStreamsResult != nil {
+		fn(this.ActivityStreamsResult.Name(), this.ActivityStreamsResult)
+	}
+	if this.ActivityStreamsSensitive != nil {
+		fn(this.ActivityStreamsSensitive.Name(), this.ActivityStreamsSensitive)
+	}
+	if this.ActivityStreamsShares != nil {
+		fn(this.ActivityStreamsShares.Name(), this.ActivityStreamsShares)
+	}
+	if this.ActivityStreamsSource != nil {
+		fn(this.ActivityStreamsSource.Name(), this.ActivityStreamsSource)
+	}
+	if this.ActivityStreamsStartTime != nil {
+		fn(this.ActivityStreamsStartTime.Name(), this.ActivityStreamsStartTime)
+	}
+	if this.ActivityStreamsSummary != nil {
+		fn(this.ActivityStreamsSummary.Name(), this.ActivityStreamsSummary)
+	}
+	if this.ActivityStreamsTag != nil {
+		fn(this.ActivityStreamsTag.Name(), this.ActivityStreamsTag)
+	}
+	if this.ActivityStreamsTarget != nil {
+		fn(this.ActivityStreamsTarget.Name(), this.ActivityStreamsTarget)
+	}
+	if this.ForgeFedTeam != nil {
+		fn(this.ForgeFedTeam.Name(), this.ForgeFedTeam)
+	}
+	if this.ForgeFedTicketsTrackedBy != nil {
+		fn(this.ForgeFedTicketsTrackedBy.Name(), this.ForgeFedTicketsTrackedBy)
+	}
+	if this.ActivityStreamsTo != nil {
+		fn(this.ActivityStreamsTo.Name(), this.ActivityStreamsTo)
+	}
+	if this.ForgeFedTracksTicketsFor != nil {
+		fn(this.ForgeFedTracksTicketsFor.Name(), this.ForgeFedTracksTicketsFor)
+	}
+	if this.JSONLDType != nil {
+		fn(this.JSONLDType.Name(), this.JSONLDType)
+	}
+	if this.ActivityStreamsUpdated != nil {
+		fn(this.ActivityStreamsUpdated.Name(), this.ActivityStreamsUpdated)
+	}
+	if this.ActivityStreamsUrl != nil {
+		fn(this.ActivityStreamsUrl.Name(), this.ActivityStreamsUrl)
+	}
+}
+
 // GetActivityStreamsActor returns the "actor" property if it exists, and nil
 // otherwise.
 func (this ActivityStreamsAnnounce) GetActivityStreamsActor() vocab.ActivityStreamsActorProperty {
@@ -1405,6 +1681,20 @@ func (this ActivityStreamsAnnounce) LessThan(o vocab.ActivityStreamsAnnounce) bo
 // Serialize converts this into an interface representation suitable for
 // marshalling into a text or binary format.
 func (this ActivityStreamsAnnounce) Serialize() (map[string]interface{}, error) {
+	return this.SerializeWithout()
+}
+
+// SerializeWithout is like Serialize, but leaves out the known properties with
+// the names, as well as unknown properties with the same names, so that they
+// can be serialized separately.
+func (this ActivityStreamsAnnounce) SerializeWithout(names ...string) (map[string]interface{}, error) {
+	var omit map[string]bool
+	if len(names) > 0 {
+		omit = make(map[string]bool, len(names))
+		for _, name := range names {
+			omit[name] = true
+		}
+	}
 	m := make(map[string]interface{})
 	typeName := "Announce"
 	if len(this.alias) > 0 {
@@ -1413,7 +1703,7 @@ func (this ActivityStreamsAnnounce) Serialize() (map[string]interface{}, error)
 	m["type"] = typeName
 	// Begin: Serialize known properties
 	// Maybe serialize property "actor"
-	if this.ActivityStreamsActor != nil {
+	if this.ActivityStreamsActor != nil && !omit[this.ActivityStreamsActor.Name()] {
 		if i, err := this.ActivityStreamsActor.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1421,7 +1711,7 @@ func (this ActivityStreamsAnnounce) Serialize() (map[string]interface{}, error)
 		}
 	}
 	// Maybe serialize property "altitude"
-	if this.ActivityStreamsAltitude != nil {
+	if this.ActivityStreamsAltitude != nil && !omit[this.ActivityStreamsAltitude.Name()] {
 		if i, err := this.ActivityStreamsAltitude.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1429,7 +1719,7 @@ func (this ActivityStreamsAnnounce) Serialize() (map[string]interface{}, error)
 		}
 	}
 	// Maybe serialize property "attachment"
-	if this.ActivityStreamsAttachment != nil {
+	if this.ActivityStreamsAttachment != nil && !omit[this.ActivityStreamsAttachment.Name()] {
 		if i, err := this.ActivityStreamsAttachment.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1437,7 +1727,7 @@ func (this ActivityStreamsAnnounce) Serialize() (map[string]interface{}, error)
 		}
 	}
 	// Maybe serialize property "attributedTo"
-	if this.ActivityStreamsAttributedTo != nil {
+	if this.ActivityStreamsAttributedTo != nil && !omit[this.ActivityStreamsAttributedTo.Name()] {
 		if i, err := this.ActivityStreamsAttributedTo.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1445,7 +1735,7 @@ func (this ActivityStreamsAnnounce) Serialize() (map[string]interface{}, error)
 		}
 	}
 	// Maybe serialize property "audience"
-	if this.ActivityStreamsAudience != nil {
+	if this.ActivityStreamsAudience != nil && !omit[this.ActivityStreamsAudience.Name()] {
 		if i, err := this.ActivityStreamsAudience.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1453,7 +1743,7 @@ func (this ActivityStreamsAnnounce) Serialize() (map[string]interface{}, error)
 		}
 	}
 	// Maybe serialize property "bcc"
-	if this.ActivityStreamsBcc != nil {
+	if this.ActivityStreamsBcc != nil && !omit[this.ActivityStreamsBcc.Name()] {
 		if i, err := this.ActivityStreamsBcc.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1461,7 +1751,7 @@ func (this ActivityStreamsAnnounce) Serialize() (map[string]interface{}, error)
 		}
 	}
 	// Maybe serialize property "bto"
-	if this.ActivityStreamsBto != nil {
+	if this.ActivityStreamsBto != nil && !omit[this.ActivityStreamsBto.Name()] {
 		if i, err := this.ActivityStreamsBto.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1469,7 +1759,7 @@ func (this ActivityStreamsAnnounce) Serialize() (map[string]interface{}, error)
 		}
 	}
 	// Maybe serialize property "cc"
-	if this.ActivityStreamsCc != nil {
+	if this.ActivityStreamsCc != nil && !omit[this.ActivityStreamsCc.Name()] {
 		if i, err := this.ActivityStreamsCc.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1477,7 +1767,7 @@ func (this ActivityStreamsAnnounce) Serialize() (map[string]interface{}, error)
 		}
 	}
 	// Maybe serialize property "content"
-	if this.ActivityStreamsContent != nil {
+	if this.ActivityStreamsContent != nil && !omit[this.ActivityStreamsContent.Name()] {
 		if i, err := this.ActivityStreamsContent.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1485,7 +1775,7 @@ func (this ActivityStreamsAnnounce) Serialize() (map[string]interface{}, error)
 		}
 	}
 	// Maybe serialize property "context"
-	if this.ActivityStreamsContext != nil {
+	if this.ActivityStreamsContext != nil && !omit[this.ActivityStreamsContext.Name()] {
 		if i, err := this.ActivityStreamsContext.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1493,7 +1783,7 @@ func (this ActivityStreamsAnnounce) Serialize() (map[string]interface{}, error)
 		}
 	}
 	// Maybe serialize property "duration"
-	if this.ActivityStreamsDuration != nil {
+	if this.ActivityStreamsDuration != nil && !omit[this.ActivityStreamsDuration.Name()] {
 		if i, err := this.ActivityStreamsDuration.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1501,7 +1791,7 @@ func (this ActivityStreamsAnnounce) Serialize() (map[string]interface{}, error)
 		}
 	}
 	// Maybe serialize property "endTime"
-	if this.ActivityStreamsEndTime != nil {
+	if this.ActivityStreamsEndTime != nil && !omit[this.ActivityStreamsEndTime.Name()] {
 		if i, err := this.ActivityStreamsEndTime.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1509,7 +1799,7 @@ func (this ActivityStreamsAnnounce) Serialize() (map[string]interface{}, error)
 		}
 	}
 	// Maybe serialize property "generator"
-	if this.ActivityStreamsGenerator != nil {
+	if this.ActivityStreamsGenerator != nil && !omit[this.ActivityStreamsGenerator.Name()] {
 		if i, err := this.ActivityStreamsGenerator.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1517,7 +1807,7 @@ func (this ActivityStreamsAnnounce) Serialize() (map[string]interface{}, error)
 		}
 	}
 	// Maybe serialize property "icon"
-	if this.ActivityStreamsIcon != nil {
+	if this.ActivityStreamsIcon != nil && !omit[this.ActivityStreamsIcon.Name()] {
 		if i, err := this.ActivityStreamsIcon.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1525,7 +1815,7 @@ func (this ActivityStreamsAnnounce) Serialize() (map[string]interface{}, error)
 		}
 	}
 	// Maybe serialize property "id"
-	if this.JSONLDId != nil {
+	if this.JSONLDId != nil && !omit[this.JSONLDId.Name()] {
 		if i, err := this.JSONLDId.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1533,7 +1823,7 @@ func (this ActivityStreamsAnnounce) Serialize() (map[string]interface{}, error)
 		}
 	}
 	// Maybe serialize property "image"
-	if this.ActivityStreamsImage != nil {
+	if this.ActivityStreamsImage != nil && !omit[this.ActivityStreamsImage.Name()] {
 		if i, err := this.ActivityStreamsImage.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1541,7 +1831,7 @@ func (this ActivityStreamsAnnounce) Serialize() (map[string]interface{}, error)
 		}
 	}
 	// Maybe serialize property "inReplyTo"
-	if this.ActivityStreamsInReplyTo != nil {
+	if this.ActivityStreamsInReplyTo != nil && !omit[this.ActivityStreamsInReplyTo.Name()] {
 		if i, err := this.ActivityStreamsInReplyTo.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1549,7 +1839,7 @@ func (this ActivityStreamsAnnounce) Serialize() (map[string]interface{}, error)
 		}
 	}
 	// Maybe serialize property "instrument"
-	if this.ActivityStreamsInstrument != nil {
+	if this.ActivityStreamsInstrument != nil && !omit[this.ActivityStreamsInstrument.Name()] {
 		if i, err := this.ActivityStreamsInstrument.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1557,7 +1847,7 @@ func (this ActivityStreamsAnnounce) Serialize() (map[string]interface{}, error)
 		}
 	}
 	// Maybe serialize property "likes"
-	if this.ActivityStreamsLikes != nil {
+	if this.ActivityStreamsLikes != nil && !omit[this.ActivityStreamsLikes.Name()] {
 		if i, err := this.ActivityStreamsLikes.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1565,7 +1855,7 @@ func (this ActivityStreamsAnnounce) Serialize() (map[string]interface{}, error)
 		}
 	}
 	// Maybe serialize property "location"
-	if this.ActivityStreamsLocation != nil {
+	if this.ActivityStreamsLocation != nil && !omit[this.ActivityStreamsLocation.Name()] {
 		if i, err := this.ActivityStreamsLocation.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1573,7 +1863,7 @@ func (this ActivityStreamsAnnounce) Serialize() (map[string]interface{}, error)
 		}
 	}
 	// Maybe serialize property "mediaType"
-	if this.ActivityStreamsMediaType != nil {
+	if this.ActivityStreamsMediaType != nil && !omit[this.ActivityStreamsMediaType.Name()] {
 		if i, err := this.ActivityStreamsMediaType.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1581,7 +1871,7 @@ func (this ActivityStreamsAnnounce) Serialize() (map[string]interface{}, error)
 		}
 	}
 	// Maybe serialize property "name"
-	if this.ActivityStreamsName != nil {
+	if this.ActivityStreamsName != nil && !omit[this.ActivityStreamsName.Name()] {
 		if i, err := this.ActivityStreamsName.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1589,7 +1879,7 @@ func (this ActivityStreamsAnnounce) Serialize() (map[string]interface{}, error)
 		}
 	}
 	// Maybe serialize property "object"
-	if this.ActivityStreamsObject != nil {
+	if this.ActivityStreamsObject != nil && !omit[this.ActivityStreamsObject.Name()] {
 		if i, err := this.ActivityStreamsObject.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1597,7 +1887,7 @@ func (this ActivityStreamsAnnounce) Serialize() (map[string]interface{}, error)
 		}
 	}
 	// Maybe serialize property "origin"
-	if this.ActivityStreamsOrigin != nil {
+	if this.ActivityStreamsOrigin != nil && !omit[this.ActivityStreamsOrigin.Name()] {
 		if i, err := this.ActivityStreamsOrigin.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1605,7 +1895,7 @@ func (this ActivityStreamsAnnounce) Serialize() (map[string]interface{}, error)
 		}
 	}
 	// Maybe serialize property "preview"
-	if this.ActivityStreamsPreview != nil {
+	if this.ActivityStreamsPreview != nil && !omit[this.ActivityStreamsPreview.Name()] {
 		if i, err := this.ActivityStreamsPreview.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1613,7 +1903,7 @@ func (this ActivityStreamsAnnounce) Serialize() (map[string]interface{}, error)
 		}
 	}
 	// Maybe serialize property "published"
-	if this.ActivityStreamsPublished != nil {
+	if this.ActivityStreamsPublished != nil && !omit[this.ActivityStreamsPublished.Name()] {
 		if i, err := this.ActivityStreamsPublished.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1621,7 +1911,7 @@ func (this ActivityStreamsAnnounce) Serialize() (map[string]interface{}, error)
 		}
 	}
 	// Maybe serialize property "replies"
-	if this.ActivityStreamsReplies != nil {
+	if this.ActivityStreamsReplies != nil && !omit[this.ActivityStreamsReplies.Name()] {
 		if i, err := this.ActivityStreamsReplies.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1629,7 +1919,7 @@ func (this ActivityStreamsAnnounce) Serialize() (map[string]interface{}, error)
 		}
 	}
 	// Maybe serialize property "result"
-	if this.ActivityStreamsResult != nil {
+	if this.ActivityStreamsResult != nil && !omit[this.ActivityStreamsResult.Name()] {
 		if i, err := this.ActivityStreamsResult.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1637,7 +1927,7 @@ func (this ActivityStreamsAnnounce) Serialize() (map[string]interface{}, error)
 		}
 	}
 	// Maybe serialize property "sensitive"
-	if this.ActivityStreamsSensitive != nil {
+	if this.ActivityStreamsSensitive != nil && !omit[this.ActivityStreamsSensitive.Name()] {
 		if i, err := this.ActivityStreamsSensitive.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1645,7 +1935,7 @@ func (this ActivityStreamsAnnounce) Serialize() (map[string]interface{}, error)
 		}
 	}
 	// Maybe serialize property "shares"
-	if this.ActivityStreamsShares != nil {
+	if this.ActivityStreamsShares != nil && !omit[this.ActivityStreamsShares.Name()] {
 		if i, err := this.ActivityStreamsShares.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1653,7 +1943,7 @@ func (this ActivityStreamsAnnounce) Serialize() (map[string]interface{}, error)
 		}
 	}
 	// Maybe serialize property "source"
-	if this.ActivityStreamsSource != nil {
+	if this.ActivityStreamsSource != nil && !omit[this.ActivityStreamsSource.Name()] {
 		if i, err := this.ActivityStreamsSource.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1661,7 +1951,7 @@ func (this ActivityStreamsAnnounce) Serialize() (map[string]interface{}, error)
 		}
 	}
 	// Maybe serialize property "startTime"
-	if this.ActivityStreamsStartTime != nil {
+	if this.ActivityStreamsStartTime != nil && !omit[this.ActivityStreamsStartTime.Name()] {
 		if i, err := this.ActivityStreamsStartTime.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1669,7 +1959,7 @@ func (this ActivityStreamsAnnounce) Serialize() (map[string]interface{}, error)
 		}
 	}
 	// Maybe serialize property "summary"
-	if this.ActivityStreamsSummary != nil {
+	if this.ActivityStreamsSummary != nil && !omit[this.ActivityStreamsSummary.Name()] {
 		if i, err := this.ActivityStreamsSummary.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1677,7 +1967,7 @@ func (this ActivityStreamsAnnounce) Serialize() (map[string]interface{}, error)
 		}
 	}
 	// Maybe serialize property "tag"
-	if this.ActivityStreamsTag != nil {
+	if this.ActivityStreamsTag != nil && !omit[this.ActivityStreamsTag.Name()] {
 		if i, err := this.ActivityStreamsTag.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1685,7 +1975,7 @@ func (this ActivityStreamsAnnounce) Serialize() (map[string]interface{}, error)
 		}
 	}
 	// Maybe serialize property "target"
-	if this.ActivityStreamsTarget != nil {
+	if this.ActivityStreamsTarget != nil && !omit[this.ActivityStreamsTarget.Name()] {
 		if i, err := this.ActivityStreamsTarget.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1693,7 +1983,7 @@ func (this ActivityStreamsAnnounce) Serialize() (map[string]interface{}, error)
 		}
 	}
 	// Maybe serialize property "team"
-	if this.ForgeFedTeam != nil {
+	if this.ForgeFedTeam != nil && !omit[this.ForgeFedTeam.Name()] {
 		if i, err := this.ForgeFedTeam.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1701,7 +1991,7 @@ func (this ActivityStreamsAnnounce) Serialize() (map[string]interface{}, error)
 		}
 	}
 	// Maybe serialize property "ticketsTrackedBy"
-	if this.ForgeFedTicketsTrackedBy != nil {
+	if this.ForgeFedTicketsTrackedBy != nil && !omit[this.ForgeFedTicketsTrackedBy.Name()] {
 		if i, err := this.ForgeFedTicketsTrackedBy.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1709,7 +1999,7 @@ func (this ActivityStreamsAnnounce) Serialize() (map[string]interface{}, error)
 		}
 	}
 	// Maybe serialize property "to"
-	if this.ActivityStreamsTo != nil {
+	if this.ActivityStreamsTo != nil && !omit[this.ActivityStreamsTo.Name()] {
 		if i, err := this.ActivityStreamsTo.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1717,7 +2007,7 @@ func (this ActivityStreamsAnnounce) Serialize() (map[string]interface{}, error)
 		}
 	}
 	// Maybe serialize property "tracksTicketsFor"
-	if this.ForgeFedTracksTicketsFor != nil {
+	if this.ForgeFedTracksTicketsFor != nil && !omit[this.ForgeFedTracksTicketsFor.Name()] {
 		if i, err := this.ForgeFedTracksTicketsFor.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1725,7 +2015,7 @@ func (this ActivityStreamsAnnounce) Serialize() (map[string]interface{}, error)
 		}
 	}
 	// Maybe serialize property "type"
-	if this.JSONLDType != nil {
+	if this.JSONLDType != nil && !omit[this.JSONLDType.Name()] {
 		if i, err := this.JSONLDType.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1733,7 +2023,7 @@ func (this ActivityStreamsAnnounce) Serialize() (map[string]interface{}, error)
 		}
 	}
 	// Maybe serialize property "updated"
-	if this.ActivityStreamsUpdated != nil {
+	if this.ActivityStreamsUpdated != nil && !omit[this.ActivityStreamsUpdated.Name()] {
 		if i, err := this.ActivityStreamsUpdated.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1741,7 +2031,7 @@ func (this ActivityStreamsAnnounce) Serialize() (map[string]interface{}, error)
 		}
 	}
 	// Maybe serialize property "url"
-	if this.ActivityStreamsUrl != nil {
+	if this.ActivityStreamsUrl != nil && !omit[this.ActivityStreamsUrl.Name()] {
 		if i, err := this.ActivityStreamsUrl.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1753,7 +2043,7 @@ func (this ActivityStreamsAnnounce) Serialize() (map[string]interface{}, error)
 	// Begin: Serialize unknown properties
 	for k, v := range this.unknown {
 		// To be safe, ensure we aren't overwriting a known property
-		if _, has := m[k]; !has {
+		if _, has := m[k]; !has && !omit[k] {
 			m[k] = v
 		}
 	}
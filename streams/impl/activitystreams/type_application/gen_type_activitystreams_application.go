@@ -16,6 +16,7 @@ import (
 //     "type": "Application"
 //   }
 type ActivityStreamsApplication struct {
+	ActivityStreamsAlsoKnownAs               vocab.ActivityStreamsAlsoKnownAsProperty
 	ActivityStreamsAltitude                  vocab.ActivityStreamsAltitudeProperty
 	ActivityStreamsAttachment                vocab.ActivityStreamsAttachmentProperty
 	ActivityStreamsAttributedTo              vocab.ActivityStreamsAttributedToProperty
@@ -28,7 +29,9 @@ type ActivityStreamsApplication struct {
 	TootDiscoverable                         vocab.TootDiscoverableProperty
 	ActivityStreamsDuration                  vocab.ActivityStreamsDurationProperty
 	ActivityStreamsEndTime                   vocab.ActivityStreamsEndTimeProperty
+	ActivityStreamsEndpoints                 vocab.ActivityStreamsEndpointsProperty
 	TootFeatured                             vocab.TootFeaturedProperty
+	TootFeaturedTags                         vocab.TootFeaturedTagsProperty
 	ActivityStreamsFollowers                 vocab.ActivityStreamsFollowersProperty
 	ActivityStreamsFollowing                 vocab.ActivityStreamsFollowingProperty
 	ActivityStreamsGenerator                 vocab.ActivityStreamsGeneratorProperty
@@ -42,6 +45,7 @@ type ActivityStreamsApplication struct {
 	ActivityStreamsLocation                  vocab.ActivityStreamsLocationProperty
 	ActivityStreamsManuallyApprovesFollowers vocab.ActivityStreamsManuallyApprovesFollowersProperty
 	ActivityStreamsMediaType                 vocab.ActivityStreamsMediaTypeProperty
+	ActivityStreamsMovedTo                   vocab.ActivityStreamsMovedToProperty
 	ActivityStreamsName                      vocab.ActivityStreamsNameProperty
 	ActivityStreamsObject                    vocab.ActivityStreamsObjectProperty
 	ActivityStreamsOutbox                    vocab.ActivityStreamsOutboxProperty
@@ -83,7 +87,7 @@ func ActivityStreamsApplicationExtends(other vocab.Type) bool {
 // ApplicationIsDisjointWith returns true if the other provided type is disjoint
 // with the Application type.
 func ApplicationIsDisjointWith(other vocab.Type) bool {
-	disjointWith := []string{"Link", "Mention"}
+	disjointWith := []string{"Hashtag", "Link", "Mention"}
 	for _, disjoint := range disjointWith {
 		if disjoint == other.GetTypeName() {
 			return true
@@ -137,6 +141,11 @@ func DeserializeApplication(m map[string]interface{}, aliasMap map[string]string
 		return nil, fmt.Errorf("\"type\" property is unrecognized type: %T", typeValue)
 	}
 	// Begin: Known property deserialization
+	if p, err := mgr.DeserializeAlsoKnownAsPropertyActivityStreams()(m, aliasMap); err != nil {
+		return nil, err
+	} else if p != nil {
+		this.ActivityStreamsAlsoKnownAs = p
+	}
 	if p, err := mgr.DeserializeAltitudePropertyActivityStreams()(m, aliasMap); err != nil {
 		return nil, err
 	} else if p != nil {
@@ -197,11 +206,21 @@ func DeserializeApplication(m map[string]interface{}, aliasMap map[string]string
 	} else if p != nil {
 		this.ActivityStreamsEndTime = p
 	}
+	if p, err := mgr.DeserializeEndpointsPropertyActivityStreams()(m, aliasMap); err != nil {
+		return nil, err
+	} else if p != nil {
+		this.ActivityStreamsEndpoints = p
+	}
 	if p, err := mgr.DeserializeFeaturedPropertyToot()(m, aliasMap); err != nil {
 		return nil, err
 	} else if p != nil {
 		this.TootFeatured = p
 	}
+	if p, err := mgr.DeserializeFeaturedTagsPropertyToot()(m, aliasMap); err != nil {
+		return nil, err
+	} else if p != nil {
+		this.TootFeaturedTags = p
+	}
 	if p, err := mgr.DeserializeFollowersPropertyActivityStreams()(m, aliasMap); err != nil {
 		return nil, err
 	} else if p != nil {
@@ -267,6 +286,11 @@ func DeserializeApplication(m map[string]interface{}, aliasMap map[string]string
 	} else if p != nil {
 		this.ActivityStreamsMediaType = p
 	}
+	if p, err := mgr.DeserializeMovedToPropertyActivityStreams()(m, aliasMap); err != nil {
+		return nil, err
+	} else if p != nil {
+		this.ActivityStreamsMovedTo = p
+	}
 	if p, err := mgr.DeserializeNamePropertyActivityStreams()(m, aliasMap); err != nil {
 		return nil, err
 	} else if p != nil {
@@ -380,111 +404,136 @@ func DeserializeApplication(m map[string]interface{}, aliasMap map[string]string
 	// End: Known property deserialization
 
 	// Begin: Unknown deserialization
+	// Known properties are prefixed with the alias of their vocabulary, if any.
+	activitystreamsPrefix := ""
+	if a, ok := aliasMap["https://www.w3.org/ns/activitystreams"]; ok && len(a) > 0 {
+		activitystreamsPrefix = a + ":"
+	}
+	tootPrefix := ""
+	if a, ok := aliasMap["http://joinmastodon.org/ns"]; ok && len(a) > 0 {
+		tootPrefix = a + ":"
+	}
+	w3idsecurityv1Prefix := ""
+	if a, ok := aliasMap["https://w3id.org/security/v1"]; ok && len(a) > 0 {
+		w3idsecurityv1Prefix = a + ":"
+	}
+	forgefedPrefix := ""
+	if a, ok := aliasMap["https://forgefed.peers.community/ns"]; ok && len(a) > 0 {
+		forgefedPrefix = a + ":"
+	}
 	for k, v := range m {
 		// Begin: Code that ensures a property name is unknown
-		if k == "altitude" {
+		if k == activitystreamsPrefix+"alsoKnownAs" {
+			continue
+		} else if k == activitystreamsPrefix+"altitude" {
+			continue
+		} else if k == activitystreamsPrefix+"attachment" {
 			continue
-		} else if k == "attachment" {
+		} else if k == activitystreamsPrefix+"attributedTo" {
 			continue
-		} else if k == "attributedTo" {
+		} else if k == activitystreamsPrefix+"audience" {
 			continue
-		} else if k == "audience" {
+		} else if k == activitystreamsPrefix+"bcc" {
 			continue
-		} else if k == "bcc" {
+		} else if k == activitystreamsPrefix+"bto" {
 			continue
-		} else if k == "bto" {
+		} else if k == activitystreamsPrefix+"cc" {
 			continue
-		} else if k == "cc" {
+		} else if k == activitystreamsPrefix+"content" {
 			continue
-		} else if k == "content" {
+		} else if k == activitystreamsPrefix+"contentMap" {
 			continue
-		} else if k == "contentMap" {
+		} else if k == activitystreamsPrefix+"context" {
 			continue
-		} else if k == "context" {
+		} else if k == tootPrefix+"discoverable" {
 			continue
-		} else if k == "discoverable" {
+		} else if k == activitystreamsPrefix+"duration" {
 			continue
-		} else if k == "duration" {
+		} else if k == activitystreamsPrefix+"endTime" {
 			continue
-		} else if k == "endTime" {
+		} else if k == activitystreamsPrefix+"endpoints" {
 			continue
-		} else if k == "featured" {
+		} else if k == tootPrefix+"featured" {
 			continue
-		} else if k == "followers" {
+		} else if k == tootPrefix+"featuredTags" {
 			continue
-		} else if k == "following" {
+		} else if k == activitystreamsPrefix+"followers" {
 			continue
-		} else if k == "generator" {
+		} else if k == activitystreamsPrefix+"following" {
 			continue
-		} else if k == "icon" {
+		} else if k == activitystreamsPrefix+"generator" {
+			continue
+		} else if k == activitystreamsPrefix+"icon" {
 			continue
 		} else if k == "id" {
 			continue
-		} else if k == "image" {
+		} else if k == activitystreamsPrefix+"image" {
+			continue
+		} else if k == activitystreamsPrefix+"inReplyTo" {
 			continue
-		} else if k == "inReplyTo" {
+		} else if k == activitystreamsPrefix+"inbox" {
 			continue
-		} else if k == "inbox" {
+		} else if k == activitystreamsPrefix+"liked" {
 			continue
-		} else if k == "liked" {
+		} else if k == activitystreamsPrefix+"likes" {
 			continue
-		} else if k == "likes" {
+		} else if k == activitystreamsPrefix+"location" {
 			continue
-		} else if k == "location" {
+		} else if k == activitystreamsPrefix+"manuallyApprovesFollowers" {
 			continue
-		} else if k == "manuallyApprovesFollowers" {
+		} else if k == activitystreamsPrefix+"mediaType" {
 			continue
-		} else if k == "mediaType" {
+		} else if k == activitystreamsPrefix+"movedTo" {
 			continue
-		} else if k == "name" {
+		} else if k == activitystreamsPrefix+"name" {
 			continue
-		} else if k == "nameMap" {
+		} else if k == activitystreamsPrefix+"nameMap" {
 			continue
-		} else if k == "object" {
+		} else if k == activitystreamsPrefix+"object" {
 			continue
-		} else if k == "outbox" {
+		} else if k == activitystreamsPrefix+"outbox" {
 			continue
-		} else if k == "preferredUsername" {
+		} else if k == activitystreamsPrefix+"preferredUsername" {
 			continue
-		} else if k == "preferredUsernameMap" {
+		} else if k == activitystreamsPrefix+"preferredUsernameMap" {
 			continue
-		} else if k == "preview" {
+		} else if k == activitystreamsPrefix+"preview" {
 			continue
-		} else if k == "publicKey" {
+		} else if k == w3idsecurityv1Prefix+"publicKey" {
 			continue
-		} else if k == "published" {
+		} else if k == activitystreamsPrefix+"published" {
 			continue
-		} else if k == "replies" {
+		} else if k == activitystreamsPrefix+"replies" {
 			continue
-		} else if k == "sensitive" {
+		} else if k == activitystreamsPrefix+"sensitive" {
 			continue
-		} else if k == "shares" {
+		} else if k == activitystreamsPrefix+"shares" {
 			continue
-		} else if k == "source" {
+		} else if k == activitystreamsPrefix+"source" {
 			continue
-		} else if k == "startTime" {
+		} else if k == activitystreamsPrefix+"startTime" {
 			continue
-		} else if k == "streams" {
+		} else if k == activitystreamsPrefix+"streams" {
 			continue
-		} else if k == "summary" {
+		} else if k == activitystreamsPrefix+"summary" {
 			continue
-		} else if k == "summaryMap" {
+		} else if k == activitystreamsPrefix+"summaryMap" {
 			continue
-		} else if k == "tag" {
+		} else if k == activitystreamsPrefix+"tag" {
 			continue
-		} else if k == "team" {
+		} else if k == forgefedPrefix+"team" {
 			continue
-		} else if k == "ticketsTrackedBy" {
+		} else if k == forgefedPrefix+"ticketsTrackedBy" {
 			continue
-		} else if k == "to" {
+		} else if k == activitystreamsPrefix+"to" {
 			continue
-		} else if k == "tracksTicketsFor" {
+		} else if k == forgefedPrefix+"tracksTicketsFor" {
 			continue
 		} else if k == "type" {
 			continue
-		} else if k == "updated" {
+		} else if k == activitystreamsPrefix+"updated" {
 			continue
-		} else if k == "url" {
+		} else if k == activitystreamsPrefix+"url" {
 			continue
 		} // End: Code that ensures a property name is unknown
 
@@ -515,6 +564,339 @@ func NewActivityStreamsApplication() *ActivityStreamsApplication {
 	}
 }
 
+// Clone returns a deep copy of this type. Modifying the copy does not modify this
+// type.
+func (this ActivityStreamsApplication) Clone() vocab.Type {
+	c := &ActivityStreamsApplication{alias: this.alias}
+	if this.ActivityStreamsAlsoKnownAs != nil {
+		c.ActivityStreamsAlsoKnownAs = this.ActivityStreamsAlsoKnownAs.Clone()
+	}
+	if this.ActivityStreamsAltitude != nil {
+		c.ActivityStreamsAltitude = this.ActivityStreamsAltitude.Clone()
+	}
+	if this.ActivityStreamsAttachment != nil {
+		c.ActivityStreamsAttachment = this.ActivityStreamsAttachment.Clone()
+	}
+	if this.ActivityStreamsAttributedTo != nil {
+		c.ActivityStreamsAttributedTo = this.ActivityStreamsAttributedTo.Clone()
+	}
+	if this.ActivityStreamsAudience != nil {
+		c.ActivityStreamsAudience = this.ActivityStreamsAudience.Clone()
+	}
+	if this.ActivityStreamsBcc != nil {
+		c.ActivityStreamsBcc = this.ActivityStreamsBcc.Clone()
+	}
+	if this.ActivityStreamsBto != nil {
+		c.ActivityStreamsBto = this.ActivityStreamsBto.Clone()
+	}
+	if this.ActivityStreamsCc != nil {
+		c.ActivityStreamsCc = this.ActivityStreamsCc.Clone()
+	}
+	if this.ActivityStreamsContent != nil {
+		c.ActivityStreamsContent = this.ActivityStreamsContent.Clone()
+	}
+	if this.ActivityStreamsContext != nil {
+		c.ActivityStreamsContext = this.ActivityStreamsContext.Clone()
+	}
+	if this.TootDiscoverable != nil {
+		c.TootDiscoverable = this.TootDiscoverable.Clone()
+	}
+	if this.ActivityStreamsDuration != nil {
+		c.ActivityStreamsDuration = this.ActivityStreamsDuration.Clone()
+	}
+	if this.ActivityStreamsEndTime != nil {
+		c.ActivityStreamsEndTime = this.ActivityStreamsEndTime.Clone()
+	}
+	if this.ActivityStreamsEndpoints != nil {
+		c.ActivityStreamsEndpoints = this.ActivityStreamsEndpoints.Clone()
+	}
+	if this.TootFeatured != nil {
+		c.TootFeatured = this.TootFeatured.Clone()
+	}
+	if this.TootFeaturedTags != nil {
+		c.TootFeaturedTags = this.TootFeaturedTags.Clone()
+	}
+	if this.ActivityStreamsFollowers != nil {
+		c.ActivityStreamsFollowers = this.ActivityStreamsFollowers.Clone()
+	}
+	if this.ActivityStreamsFollowing != nil {
+		c.ActivityStreamsFollowing = this.ActivityStreamsFollowing.Clone()
+	}
+	if this.ActivityStreamsGenerator != nil {
+		c.ActivityStreamsGenerator = this.ActivityStreamsGenerator.Clone()
+	}
+	if this.ActivityStreamsIcon != nil {
+		c.ActivityStreamsIcon = this.ActivityStreamsIcon.Clone()
+	}
+	if this.JSONLDId != nil {
+		c.JSONLDId = this.JSONLDId.Clone()
+	}
+	if this.ActivityStreamsImage != nil {
+		c.ActivityStreamsImage = this.ActivityStreamsImage.Clone()
+	}
+	if this.ActivityStreamsInReplyTo != nil {
+		c.ActivityStreamsInReplyTo = this.ActivityStreamsInReplyTo.Clone()
+	}
+	if this.ActivityStreamsInbox != nil {
+		c.ActivityStreamsInbox = this.ActivityStreamsInbox.Clone()
+	}
+	if this.ActivityStreamsLiked != nil {
+		c.ActivityStreamsLiked = this.ActivityStreamsLiked.Clone()
+	}
+	if this.ActivityStreamsLikes != nil {
+		c.ActivityStreamsLikes = this.ActivityStreamsLikes.Clone()
+	}
+	if this.ActivityStreamsLocation != nil {
+		c.ActivityStreamsLocation = this.ActivityStreamsLocation.Clone()
+	}
+	if this.ActivityStreamsManuallyApprovesFollowers != nil {
+		c.ActivityStreamsManuallyApprovesFollowers = this.ActivityStreamsManuallyApprovesFollowers.Clone()
+	}
+	if this.ActivityStreamsMediaType != nil {
+		c.ActivityStreamsMediaType = this.ActivityStreamsMediaType.Clone()
+	}
+	if this.ActivityStreamsMovedTo != nil {
+		c.ActivityStreamsMovedTo = this.ActivityStreamsMovedTo.Clone()
+	}
+	if this.ActivityStreamsName != nil {
+		c.ActivityStreamsName = this.ActivityStreamsName.Clone()
+	}
+	if this.ActivityStreamsObject != nil {
+		c.ActivityStreamsObject = this.ActivityStreamsObject.Clone()
+	}
+	if this.ActivityStreamsOutbox != nil {
+		c.ActivityStreamsOutbox = this.ActivityStreamsOutbox.Clone()
+	}
+	if this.ActivityStreamsPreferredUsername != nil {
+		c.ActivityStreamsPreferredUsername = this.ActivityStreamsPreferredUsername.Clone()
+	}
+	if this.ActivityStreamsPreview != nil {
+		c.ActivityStreamsPreview = this.ActivityStreamsPreview.Clone()
+	}
+	if this.W3IDSecurityV1PublicKey != nil {
+		c.W3IDSecurityV1PublicKey = this.W3IDSecurityV1PublicKey.Clone()
+	}
+	if this.ActivityStreamsPublished != nil {
+		c.ActivityStreamsPublished = this.ActivityStreamsPublished.Clone()
+	}
+	if this.ActivityStreamsReplies != nil {
+		c.ActivityStreamsReplies = this.ActivityStreamsReplies.Clone()
+	}
+	if this.ActivityStreamsSensitive != nil {
+		c.ActivityStreamsSensitive = this.ActivityStreamsSensitive.Clone()
+	}
+	if this.ActivityStreamsShares != nil {
+		c.ActivityStreamsShares = this.ActivityStreamsShares.Clone()
+	}
+	if this.ActivityStreamsSource != nil {
+		c.ActivityStreamsSource = this.ActivityStreamsSource.Clone()
+	}
+	if this.ActivityStreamsStartTime != nil {
+		c.ActivityStreamsStartTime = this.ActivityStreamsStartTime.Clone()
+	}
+	if this.ActivityStreamsStreams != nil {
+		c.ActivityStreamsStreams = this.ActivityStreamsStreams.Clone()
+	}
+	if this.ActivityStreamsSummary != nil {
+		c.ActivityStreamsSummary = this.ActivityStreamsSummary.Clone()
+	}
+	if this.ActivityStreamsTag != nil {
+		c.ActivityStreamsTag = this.ActivityStreamsTag.Clone()
+	}
+	if this.ForgeFedTeam != nil {
+		c.ForgeFedTeam = this.ForgeFedTeam.Clone()
+	}
+	if this.ForgeFedTicketsTrackedBy != nil {
+		c.ForgeFedTicketsTrackedBy = this.ForgeFedTicketsTrackedBy.Clone()
+	}
+	if this.ActivityStreamsTo != nil {
+		c.ActivityStreamsTo = this.ActivityStreamsTo.Clone()
+	}
+	if this.ForgeFedTracksTicketsFor != nil {
+		c.ForgeFedTracksTicketsFor = this.ForgeFedTracksTicketsFor.Clone()
+	}
+	if this.JSONLDType != nil {
+		c.JSONLDType = this.JSONLDType.Clone()
+	}
+	if this.ActivityStreamsUpdated != nil {
+		c.ActivityStreamsUpdated = this.ActivityStreamsUpdated.Clone()
+	}
+	if this.ActivityStreamsUrl != nil {
+		c.ActivityStreamsUrl = this.ActivityStreamsUrl.Clone()
+	}
+	if this.unknown != nil {
+		c.unknown = cloneUnknown(this.unknown).(map[string]interface{})
+	}
+	return c
+}
+
+// EachProperty calls fn with the name and value of each property that is set, in
+// a stable order. Unknown properties are not included.
+func (this ActivityStreamsApplication) EachProperty(fn func(name string, value vocab.PropertyInterface)) {
+	if this.ActivityStreamsAlsoKnownAs != nil {
+		fn(this.ActivityStreamsAlsoKnownAs.Name(), this.ActivityStreamsAlsoKnownAs)
+	}
+	if this.ActivityStreamsAltitude != nil {
+		fn(this.ActivityStreamsAltitude.Name(), this.ActivityStreamsAltitude)
+	}
+	if this.ActivityStreamsAttachment != nil {
+		fn(this.ActivityStreamsAttachment.Name(), this.ActivityStreamsAttachment)
+	}
+	if this.ActivityStreamsAttributedTo != nil {
+		fn(this.ActivityStreamsAttributedTo.Name(), this.ActivityStreamsAttributedTo)
+	}
+	if this.ActivityStreamsAudience != nil {
+		fn(this.ActivityStreamsAudience.Name(), this.ActivityStreamsAudience)
+	}
+	if this.ActivityStreamsBcc != nil {
+		fn(this.ActivityStreamsBcc.Name(), this.ActivityStreamsBcc)
+	}
+	if this.ActivityStreamsBto != nil {
+		fn(this.ActivityStreamsBto.Name(), this.ActivityStreamsBto)
+	}
+	if this.ActivityStreamsCc != nil {
+		fn(this.ActivityStreamsCc.Name(), this.ActivityStreamsCc)
+	}
+	if this.ActivityStreamsContent != nil {
+		fn(this.ActivityStreamsContent.Name(), this.ActivityStreamsContent)
+	}
+	if this.ActivityStreamsContext != nil {
+		fn(this.ActivityStreamsContext.Name(), this.ActivityStreamsContext)
+	}
+	if this.TootDiscoverable != nil {
+		fn(this.TootDiscoverable.Name(), this.TootDiscoverable)
+	}
+	if this.ActivityStreamsDuration != nil {
+		fn(this.ActivityStreamsDuration.Name(), this.ActivityStreamsDuration)
+	}
+	if this.ActivityStreamsEndTime != nil {
+		fn(this.ActivityStreamsEndTime.Name(), this.ActivityStreamsEndTime)
+	}
+	if this.ActivityStreamsEndpoints != nil {
+		fn(this.ActivityStreamsEndpoints.Name(), this.ActivityStreamsEndpoints)
+	}
+	if this.TootFeatured != nil {
+		fn(this.TootFeatured.Name(), this.TootFeatured)
+	}
+	if this.TootFeaturedTags != nil {
+		fn(this.TootFeaturedTags.Name(), this.TootFeaturedTags)
+	}
+	if this.ActivityStreamsFollowers != nil {
+		fn(this.ActivityStreamsFollowers.Name(), this.ActivityStreamsFollowers)
+	}
+	if this.ActivityStreamsFollowing != nil {
+		fn(this.ActivityStreamsFollowing.Name(), this.ActivityStreamsFollowing)
+	}
+	if this.ActivityStreamsGenerator != nil {
+		fn(this.ActivityStreamsGenerator.Name(), this.ActivityStreamsGenerator)
+	}
+	if this.ActivityStreamsIcon != nil {
+		fn(this.ActivityStreamsIcon.Name(), this.ActivityStreamsIcon)
+	}
+	if this.JSONLDId != nil {
+		fn(this.JSONLDId.Name(), this.JSONLDId)
+	}
+	if this.ActivityStreamsImage != nil {
+		fn(this.ActivityStreamsImage.Name(), this.ActivityStreamsImage)
+	}
+	if this.ActivityStreamsInReplyTo != nil {
+		fn(this.ActivityStreamsInReplyTo.Name(), this.ActivityStreamsInReplyTo)
+	}
+	if this.ActivityStreamsInbox != nil {
+		fn(this.ActivityStreamsInbox.Name(), this.ActivityStreamsInbox)
+	}
+	if this.ActivityStreamsLiked != nil {
+		fn(this.ActivityStreamsLiked.Name(), this.ActivityStreamsLiked)
+	}
+	if this.ActivityStreamsLikes != nil {
+		fn(this.ActivityStreamsLikes.Name(), this.ActivityStreamsLikes)
+	}
+	if this.ActivityStreamsLocation != nil {
+		fn(this.ActivityStreamsLocation.Name(), this.ActivityStreamsLocation)
+	}
+	if this.ActivityStreamsManuallyApprovesFollowers != nil {
+		fn(this.ActivityStreamsManuallyApprovesFollowers.Name(), this.ActivityStreamsManuallyApprovesFollowers)
+	}
+	if this.ActivityStreamsMediaType != nil {
+		fn(this.ActivityStreamsMediaType.Name(), this.ActivityStreamsMediaType)
+	}
+	if this.ActivityStreamsMovedTo != nil {
+		fn(this.ActivityStreamsMovedTo.Name(), this.ActivityStreamsMovedTo)
+	}
+	if this.ActivityStreamsName != nil {
+		fn(this.ActivityStreamsName.Name(), this.ActivityStreamsName)
+	}
+	if this.ActivityStreamsObject != nil {
+		fn(this.ActivityStreamsObject.Name(), this.ActivityStreamsObject)
+	}
+	if this.ActivityStreamsOutbox != nil {
+		fn(this.ActivityStreamsOutbox.Name(), this.ActivityStreamsOutbox)
+	}
+	if this.ActivityStreamsPreferredUsername != nil {
+		fn(this.ActivityStreamsPreferredUsername.Name(), this.ActivityStreamsPreferredUsername)
+	}
+	if this.ActivityStreamsPreview != nil {
+		fn(this.ActivityStreamsPreview.Name(), this.ActivityStreamsPreview)
+	}
+	if this.W3IDSecurityV1PublicKey != nil {
+		fn(this.W3IDSecurityV1PublicKey.Name(), this.W3IDSecurityV1PublicKey)
+	}
+	if this.ActivityStreamsPublished != nil {
+		fn(this.ActivityStreamsPublished.Name(), this.ActivityStreamsPublished)
+	}
+	if this.ActivityStreamsReplies != nil {
+		fn(this.ActivityStreamsReplies.Name(), this.ActivityStreamsReplies)
+	}
+	if this.ActivityStreamsSensitive != nil {
+		fn(this.ActivityStreamsSensitive.Name(), this.ActivityStreamsSensitive)
+	}
+	if this.ActivityStreamsShares != nil {
+		fn(this.ActivityStreamsShares.Name(), this.ActivityStreamsShares)
+	}
+	if this.ActivityStreamsSource != nil {
+		fn(this.ActivityStreamsSource.Name(), this.ActivityStreamsSource)
+	}
+	if this.ActivityStreamsStartTime != nil {
+		fn(this.ActivityStreamsStartTime.Name(), this.ActivityStreamsStartTime)
+	}
+	if this.ActivityStreamsStreams != nil {
+		fn(this.ActivityStreamsStreams.Name(), this.ActivityStreamsStreams)
+	}
+	if this.ActivityStreamsSummary != nil {
+		fn(this.ActivityStreamsSummary.Name(), this.ActivityStreamsSummary)
+	}
+	if this.ActivityStreamsTag != nil {
+		fn(this.ActivityStreamsTag.Name(), this.ActivityStreamsTag)
+	}
+	if this.ForgeFedTeam != nil {
+		fn(this.ForgeFedTeam.Name(), this.ForgeFedTeam)
+	}
+	if this.ForgeFedTicketsTrackedBy != nil {
+		fn(this.ForgeFedTicketsTrackedBy.Name(), this.ForgeFedTicketsTrackedBy)
+	}
+	if this.ActivityStreamsTo != nil {
+		fn(this.ActivityStreamsTo.Name(), this.ActivityStreamsTo)
+	}
+	if this.ForgeFedTracksTicketsFor != nil {
+		fn(this.ForgeFedTracksTicketsFor.Name(), this.ForgeFedTracksTicketsFor)
+	}
+	if this.JSONLDType != nil {
+		fn(this.JSONLDType.Name(), this.JSONLDType)
+	}
+	if this.ActivityStreamsUpdated != nil {
+		fn(this.ActivityStreamsUpdated.Name(), this.ActivityStreamsUpdated)
+	}
+	if this.ActivityStreamsUrl != nil {
+		fn(this.ActivityStreamsUrl.Name(), this.ActivityStreamsUrl)
+	}
+}
+
+// GetActivityStreamsAlsoKnownAs returns the "alsoKnownAs" property if it exists,
+// and nil otherwise.
+func (this ActivityStreamsApplication) GetActivityStreamsAlsoKnownAs() vocab.ActivityStreamsAlsoKnownAsProperty {
+	return this.ActivityStreamsAlsoKnownAs
+}
+
 // GetActivityStreamsAltitude returns the "altitude" property if it exists, and
 // nil otherwise.
 func (this ActivityStreamsApplication) GetActivityStreamsAltitude() vocab.ActivityStreamsAltitudeProperty {
@@ -580,6 +962,12 @@ func (this ActivityStreamsApplication) GetActivityStreamsEndTime() vocab.Activit
 	return this.ActivityStreamsEndTime
 }
 
+// GetActivityStreamsEndpoints returns the "endpoints" property if it exists, and
+// nil otherwise.
+func (this ActivityStreamsApplication) GetActivityStreamsEndpoints() vocab.ActivityStreamsEndpointsProperty {
+	return this.ActivityStreamsEndpoints
+}
+
 // GetActivityStreamsFollowers returns the "followers" property if it exists, and
 // nil otherwise.
 func (this ActivityStreamsApplication) GetActivityStreamsFollowers() vocab.ActivityStreamsFollowersProperty {
@@ -652,6 +1040,12 @@ func (this ActivityStreamsApplication) GetActivityStreamsMediaType() vocab.Activ
 	return this.ActivityStreamsMediaType
 }
 
+// GetActivityStreamsMovedTo returns the "movedTo" property if it exists, and nil
+// otherwise.
+func (this ActivityStreamsApplication) GetActivityStreamsMovedTo() vocab.ActivityStreamsMovedToProperty {
+	return this.ActivityStreamsMovedTo
+}
+
 // GetActivityStreamsName returns the "name" property if it exists, and nil
 // otherwise.
 func (this ActivityStreamsApplication) GetActivityStreamsName() vocab.ActivityStreamsNameProperty {
@@ -791,6 +1185,12 @@ func (this ActivityStreamsApplication) GetTootFeatured() vocab.TootFeaturedPrope
 	return this.TootFeatured
 }
 
+// GetTootFeaturedTags returns the "featuredTags" property if it exists, and nil
+// otherwise.
+func (this ActivityStreamsApplication) GetTootFeaturedTags() vocab.TootFeaturedTagsProperty {
+	return this.TootFeaturedTags
+}
+
 // GetTypeName returns the name of this type.
 func (this ActivityStreamsApplication) GetTypeName() string {
 	return "Application"
@@ -822,6 +1222,7 @@ func (this ActivityStreamsApplication) IsExtending(other vocab.Type) bool {
 // alias used to import the type and its properties.
 func (this ActivityStreamsApplication) JSONLDContext() map[string]string {
 	m := map[string]string{"https://www.w3.org/ns/activitystreams": this.alias}
+	m = this.helperJSONLDContext(this.ActivityStreamsAlsoKnownAs, m)
 	m = this.helperJSONLDContext(this.ActivityStreamsAltitude, m)
 	m = this.helperJSONLDContext(this.ActivityStreamsAttachment, m)
 	m = this.helperJSONLDContext(this.ActivityStreamsAttributedTo, m)
@@ -834,7 +1235,9 @@ func (this ActivityStreamsApplication) JSONLDContext() map[string]string {
 	m = this.helperJSONLDContext(this.TootDiscoverable, m)
 	m = this.helperJSONLDContext(this.ActivityStreamsDuration, m)
 	m = this.helperJSONLDContext(this.ActivityStreamsEndTime, m)
+	m = this.helperJSONLDContext(this.ActivityStreamsEndpoints, m)
 	m = this.helperJSONLDContext(this.TootFeatured, m)
+	m = this.helperJSONLDContext(this.TootFeaturedTags, m)
 	m = this.helperJSONLDContext(this.ActivityStreamsFollowers, m)
 	m = this.helperJSONLDContext(this.ActivityStreamsFollowing, m)
 	m = this.helperJSONLDContext(this.ActivityStreamsGenerator, m)
@@ -848,6 +1251,7 @@ func (this ActivityStreamsApplication) JSONLDContext() map[string]string {
 	m = this.helperJSONLDContext(this.ActivityStreamsLocation, m)
 	m = this.helperJSONLDContext(this.ActivityStreamsManuallyApprovesFollowers, m)
 	m = this.helperJSONLDContext(this.ActivityStreamsMediaType, m)
+	m = this.helperJSONLDContext(this.ActivityStreamsMovedTo, m)
 	m = this.helperJSONLDContext(this.ActivityStreamsName, m)
 	m = this.helperJSONLDContext(this.ActivityStreamsObject, m)
 	m = this.helperJSONLDContext(this.ActivityStreamsOutbox, m)
@@ -878,6 +1282,20 @@ func (this ActivityStreamsApplication) JSONLDContext() map[string]string {
 // determination.
 func (this ActivityStreamsApplication) LessThan(o vocab.ActivityStreamsApplication) bool {
 	// Begin: Compare known properties
+	// Compare property "alsoKnownAs"
+	if lhs, rhs := this.ActivityStreamsAlsoKnownAs, o.GetActivityStreamsAlsoKnownAs(); lhs != nil && rhs != nil {
+		if lhs.LessThan(rhs) {
+			return true
+		} else if rhs.LessThan(lhs) {
+			return false
+		}
+	} else if lhs == nil && rhs != nil {
+		// Nil is less than anything else
+		return true
+	} else if rhs != nil && rhs == nil {
+		// Anything else is greater than nil
+		return false
+	} // Else: Both are nil
 	// Compare property "altitude"
 	if lhs, rhs := this.ActivityStreamsAltitude, o.GetActivityStreamsAltitude(); lhs != nil && rhs != nil {
 		if lhs.LessThan(rhs) {
@@ -1046,6 +1464,20 @@ func (this ActivityStreamsApplication) LessThan(o vocab.ActivityStreamsApplicati
 		// Anything else is greater than nil
 		return false
 	} // Else: Both are nil
+	// Compare property "endpoints"
+	if lhs, rhs := this.ActivityStreamsEndpoints, o.GetActivityStreamsEndpoints(); lhs != nil && rhs != nil {
+		if lhs.LessThan(rhs) {
+			return true
+		} else if rhs.LessThan(lhs) {
+			return false
+		}
+	} else if lhs == nil && rhs != nil {
+		// Nil is less than anything else
+		return true
+	} else if rhs != nil && rhs == nil {
+		// Anything else is greater than nil
+		return false
+	} // Else: Both are nil
 	// Compare property "featured"
 	if lhs, rhs := this.TootFeatured, o.GetTootFeatured(); lhs != nil && rhs != nil {
 		if lhs.LessThan(rhs) {
@@ -1060,6 +1492,20 @@ func (this ActivityStreamsApplication) LessThan(o vocab.ActivityStreamsApplicati
 		// Anything else is greater than nil
 		return false
 	} // Else: Both are nil
+	// Compare property "featuredTags"
+	if lhs, rhs := this.TootFeaturedTags, o.GetTootFeaturedTags(); lhs != nil && rhs != nil {
+		if lhs.LessThan(rhs) {
+			return true
+		} else if rhs.LessThan(lhs) {
+			return false
+		}
+	} else if lhs == nil && rhs != nil {
+		// Nil is less than anything else
+		return true
+	} else if rhs != nil && rhs == nil {
+		// Anything else is greater than nil
+		return false
+	} // Else: Both are nil
 	// Compare property "followers"
 	if lhs, rhs := this.ActivityStreamsFollowers, o.GetActivityStreamsFollowers(); lhs != nil && rhs != nil {
 		if lhs.LessThan(rhs) {
@@ -1242,6 +1688,20 @@ func (this ActivityStreamsApplication) LessThan(o vocab.ActivityStreamsApplicati
 		// Anything else is greater than nil
 		return false
 	} // Else: Both are nil
+	// Compare property "movedTo"
+	if lhs, rhs := this.ActivityStreamsMovedTo, o.GetActivityStreamsMovedTo(); lhs != nil && rhs != nil {
+		if lhs.LessThan(rhs) {
+			return true
+		} else if rhs.LessThan(lhs) {
+			return false
+		}
+	} else if lhs == nil && rhs != nil {
+		// Nil is less than anything else
+		return true
+	} else if rhs != nil && rhs == nil {
+		// Anything else is greater than nil
+		return false
+	} // Else: Both are nil
 	// Compare property "name"
 	if lhs, rhs := this.ActivityStreamsName, o.GetActivityStreamsName(); lhs != nil && rhs != nil {
 		if lhs.LessThan(rhs) {
@@ -1566,6 +2026,20 @@ func (this ActivityStreamsApplication) LessThan(o vocab.ActivityStreamsApplicati
 // Serialize converts this into an interface representation suitable for
 // marshalling into a text or binary format.
 func (this ActivityStreamsApplication) Serialize() (map[string]interface{}, error) {
+	return this.SerializeWithout()
+}
+
+// SerializeWithout is like Serialize, but leaves out the known properties with
+// the names, as well as unknown properties with the same names, so that they
+// can be serialized separately.
+func (this ActivityStreamsApplication) SerializeWithout(names ...string) (map[string]interface{}, error) {
+	var omit map[string]bool
+	if len(names) > 0 {
+		omit = make(map[string]bool, len(names))
+		for _, name := range names {
+			omit[name] = true
+		}
+	}
 	m := make(map[string]interface{})
 	typeName := "Application"
 	if len(this.alias) > 0 {
@@ -1573,8 +2047,16 @@ func (this ActivityStreamsApplication) Serialize() (map[string]interface{}, erro
 	}
 	m["type"] = typeName
 	// Begin: Serialize known properties
+	// Maybe serialize property "alsoKnownAs"
+	if this.ActivityStreamsAlsoKnownAs != nil && !omit[this.ActivityStreamsAlsoKnownAs.Name()] {
+		if i, err := this.ActivityStreamsAlsoKnownAs.Serialize(); err != nil {
+			return nil, err
+		} else if i != nil {
+			m[this.ActivityStreamsAlsoKnownAs.Name()] = i
+		}
+	}
 	// Maybe serialize property "altitude"
-	if this.ActivityStreamsAltitude != nil {
+	if this.ActivityStreamsAltitude != nil && !omit[this.ActivityStreamsAltitude.Name()] {
 		if i, err := this.ActivityStreamsAltitude.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1582,7 +2064,7 @@ func (this ActivityStreamsApplication) Serialize() (map[string]interface{}, erro
 		}
 	}
 	// Maybe serialize property "attachment"
-	if this.ActivityStreamsAttachment != nil {
+	if this.ActivityStreamsAttachment != nil && !omit[this.ActivityStreamsAttachment.Name()] {
 		if i, err := this.ActivityStreamsAttachment.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1590,7 +2072,7 @@ func (this ActivityStreamsApplication) Serialize() (map[string]interface{}, erro
 		}
 	}
 	// Maybe serialize property "attributedTo"
-	if this.ActivityStreamsAttributedTo != nil {
+	if this.ActivityStreamsAttributedTo != nil && !omit[this.ActivityStreamsAttributedTo.Name()] {
 		if i, err := this.ActivityStreamsAttributedTo.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1598,7 +2080,7 @@ func (this ActivityStreamsApplication) Serialize() (map[string]interface{}, erro
 		}
 	}
 	// Maybe serialize property "audience"
-	if this.ActivityStreamsAudience != nil {
+	if this.ActivityStreamsAudience != nil && !omit[this.ActivityStreamsAudience.Name()] {
 		if i, err := this.ActivityStreamsAudience.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1606,7 +2088,7 @@ func (this ActivityStreamsApplication) Serialize() (map[string]interface{}, erro
 		}
 	}
 	// Maybe serialize property "bcc"
-	if this.ActivityStreamsBcc != nil {
+	if this.ActivityStreamsBcc != nil && !omit[this.ActivityStreamsBcc.Name()] {
 		if i, err := this.ActivityStreamsBcc.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1614,7 +2096,7 @@ func (this ActivityStreamsApplication) Serialize() (map[string]interface{}, erro
 		}
 	}
 	// Maybe serialize property "bto"
-	if this.ActivityStreamsBto != nil {
+	if this.ActivityStreamsBto != nil && !omit[this.ActivityStreamsBto.Name()] {
 		if i, err := this.ActivityStreamsBto.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1622,7 +2104,7 @@ func (this ActivityStreamsApplication) Serialize() (map[string]interface{}, erro
 		}
 	}
 	// Maybe serialize property "cc"
-	if this.ActivityStreamsCc != nil {
+	if this.ActivityStreamsCc != nil && !omit[this.ActivityStreamsCc.Name()] {
 		if i, err := this.ActivityStreamsCc.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1630,7 +2112,7 @@ func (this ActivityStreamsApplication) Serialize() (map[string]interface{}, erro
 		}
 	}
 	// Maybe serialize property "content"
-	if this.ActivityStreamsContent != nil {
+	if this.ActivityStreamsContent != nil && !omit[this.ActivityStreamsContent.Name()] {
 		if i, err := this.ActivityStreamsContent.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1638,7 +2120,7 @@ func (this ActivityStreamsApplication) Serialize() (map[string]interface{}, erro
 		}
 	}
 	// Maybe serialize property "context"
-	if this.ActivityStreamsContext != nil {
+	if this.ActivityStreamsContext != nil && !omit[this.ActivityStreamsContext.Name()] {
 		if i, err := this.ActivityStreamsContext.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1646,7 +2128,7 @@ func (this ActivityStreamsApplication) Serialize() (map[string]interface{}, erro
 		}
 	}
 	// Maybe serialize property "discoverable"
-	if this.TootDiscoverable != nil {
+	if this.TootDiscoverable != nil && !omit[this.TootDiscoverable.Name()] {
 		if i, err := this.TootDiscoverable.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1654,7 +2136,7 @@ func (this ActivityStreamsApplication) Serialize() (map[string]interface{}, erro
 		}
 	}
 	// Maybe serialize property "duration"
-	if this.ActivityStreamsDuration != nil {
+	if this.ActivityStreamsDuration != nil && !omit[this.ActivityStreamsDuration.Name()] {
 		if i, err := this.ActivityStreamsDuration.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1662,23 +2144,39 @@ func (this ActivityStreamsApplication) Serialize() (map[string]interface{}, erro
 		}
 	}
 	// Maybe serialize property "endTime"
-	if this.ActivityStreamsEndTime != nil {
+	if this.ActivityStreamsEndTime != nil && !omit[this.ActivityStreamsEndTime.Name()] {
 		if i, err := this.ActivityStreamsEndTime.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
 			m[this.ActivityStreamsEndTime.Name()] = i
 		}
 	}
+	// Maybe serialize property "endpoints"
+	if this.ActivityStreamsEndpoints != nil && !omit[this.ActivityStreamsEndpoints.Name()] {
+		if i, err := this.ActivityStreamsEndpoints.Serialize(); err != nil {
+			return nil, err
+		} else if i != nil {
+			m[this.ActivityStreamsEndpoints.Name()] = i
+		}
+	}
 	// Maybe serialize property "featured"
-	if this.TootFeatured != nil {
+	if this.TootFeatured != nil && !omit[this.TootFeatured.Name()] {
 		if i, err := this.TootFeatured.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
 			m[this.TootFeatured.Name()] = i
 		}
 	}
+	// Maybe serialize property "featuredTags"
+	if this.TootFeaturedTags != nil && !omit[this.TootFeaturedTags.Name()] {
+		if i, err := this.TootFeaturedTags.Serialize(); err != nil {
+			return nil, err
+		} else if i != nil {
+			m[this.TootFeaturedTags.Name()] = i
+		}
+	}
 	// Maybe serialize property "followers"
-	if this.ActivityStreamsFollowers != nil {
+	if this.ActivityStreamsFollowers != nil && !omit[this.ActivityStreamsFollowers.Name()] {
 		if i, err := this.ActivityStreamsFollowers.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1686,7 +2184,7 @@ func (this ActivityStreamsApplication) Serialize() (map[string]interface{}, erro
 		}
 	}
 	// Maybe serialize property "following"
-	if this.ActivityStreamsFollowing != nil {
+	if this.ActivityStreamsFollowing != nil && !omit[this.ActivityStreamsFollowing.Name()] {
 		if i, err := this.ActivityStreamsFollowing.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1694,7 +2192,7 @@ func (this ActivityStreamsApplication) Serialize() (map[string]interface{}, erro
 		}
 	}
 	// Maybe serialize property "generator"
-	if this.ActivityStreamsGenerator != nil {
+	if this.ActivityStreamsGenerator != nil && !omit[this.ActivityStreamsGenerator.Name()] {
 		if i, err := this.ActivityStreamsGenerator.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1702,7 +2200,7 @@ func (this ActivityStreamsApplication) Serialize() (map[string]interface{}, erro
 		}
 	}
 	// Maybe serialize property "icon"
-	if this.ActivityStreamsIcon != nil {
+	if this.ActivityStreamsIcon != nil && !omit[this.ActivityStreamsIcon.Name()] {
 		if i, err := this.ActivityStreamsIcon.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1710,7 +2208,7 @@ func (this ActivityStreamsApplication) Serialize() (map[string]interface{}, erro
 		}
 	}
 	// Maybe serialize property "id"
-	if this.JSONLDId != nil {
+	if this.JSONLDId != nil && !omit[this.JSONLDId.Name()] {
 		if i, err := this.JSONLDId.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1718,7 +2216,7 @@ func (this ActivityStreamsApplication) Serialize() (map[string]interface{}, erro
 		}
 	}
 	// Maybe serialize property "image"
-	if this.ActivityStreamsImage != nil {
+	if this.ActivityStreamsImage != nil && !omit[this.ActivityStreamsImage.Name()] {
 		if i, err := this.ActivityStreamsImage.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1726,7 +2224,7 @@ func (this ActivityStreamsApplication) Serialize() (map[string]interface{}, erro
 		}
 	}
 	// Maybe serialize property "inReplyTo"
-	if this.ActivityStreamsInReplyTo != nil {
+	if this.ActivityStreamsInReplyTo != nil && !omit[this.ActivityStreamsInReplyTo.Name()] {
 		if i, err := this.ActivityStreamsInReplyTo.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1734,7 +2232,7 @@ func (this ActivityStreamsApplication) Serialize() (map[string]interface{}, erro
 		}
 	}
 	// Maybe serialize property "inbox"
-	if this.ActivityStreamsInbox != nil {
+	if this.ActivityStreamsInbox != nil && !omit[this.ActivityStreamsInbox.Name()] {
 		if i, err := this.ActivityStreamsInbox.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1742,7 +2240,7 @@ func (this ActivityStreamsApplication) Serialize() (map[string]interface{}, erro
 		}
 	}
 	// Maybe serialize property "liked"
-	if this.ActivityStreamsLiked != nil {
+	if this.ActivityStreamsLiked != nil && !omit[this.ActivityStreamsLiked.Name()] {
 		if i, err := this.ActivityStreamsLiked.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1750,7 +2248,7 @@ func (this ActivityStreamsApplication) Serialize() (map[string]interface{}, erro
 		}
 	}
 	// Maybe serialize property "likes"
-	if this.ActivityStreamsLikes != nil {
+	if this.ActivityStreamsLikes != nil && !omit[this.ActivityStreamsLikes.Name()] {
 		if i, err := this.ActivityStreamsLikes.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1758,7 +2256,7 @@ func (this ActivityStreamsApplication) Serialize() (map[string]interface{}, erro
 		}
 	}
 	// Maybe serialize property "location"
-	if this.ActivityStreamsLocation != nil {
+	if this.ActivityStreamsLocation != nil && !omit[this.ActivityStreamsLocation.Name()] {
 		if i, err := this.ActivityStreamsLocation.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1766,7 +2264,7 @@ func (this ActivityStreamsApplication) Serialize() (map[string]interface{}, erro
 		}
 	}
 	// Maybe serialize property "manuallyApprovesFollowers"
-	if this.ActivityStreamsManuallyApprovesFollowers != nil {
+	if this.ActivityStreamsManuallyApprovesFollowers != nil && !omit[this.ActivityStreamsManuallyApprovesFollowers.Name()] {
 		if i, err := this.ActivityStreamsManuallyApprovesFollowers.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1774,15 +2272,23 @@ func (this ActivityStreamsApplication) Serialize() (map[string]interface{}, erro
 		}
 	}
 	// Maybe serialize property "mediaType"
-	if this.ActivityStreamsMediaType != nil {
+	if this.ActivityStreamsMediaType != nil && !omit[this.ActivityStreamsMediaType.Name()] {
 		if i, err := this.ActivityStreamsMediaType.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
 			m[this.ActivityStreamsMediaType.Name()] = i
 		}
 	}
+	// Maybe serialize property "movedTo"
+	if this.ActivityStreamsMovedTo != nil && !omit[this.ActivityStreamsMovedTo.Name()] {
+		if i, err := this.ActivityStreamsMovedTo.Serialize(); err != nil {
+			return nil, err
+		} else if i != nil {
+			m[this.ActivityStreamsMovedTo.Name()] = i
+		}
+	}
 	// Maybe serialize property "name"
-	if this.ActivityStreamsName != nil {
+	if this.ActivityStreamsName != nil && !omit[this.ActivityStreamsName.Name()] {
 		if i, err := this.ActivityStreamsName.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1790,7 +2296,7 @@ func (this ActivityStreamsApplication) Serialize() (map[string]interface{}, erro
 		}
 	}
 	// Maybe serialize property "object"
-	if this.ActivityStreamsObject != nil {
+	if this.ActivityStreamsObject != nil && !omit[this.ActivityStreamsObject.Name()] {
 		if i, err := this.ActivityStreamsObject.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1798,7 +2304,7 @@ func (this ActivityStreamsApplication) Serialize() (map[string]interface{}, erro
 		}
 	}
 	// Maybe serialize property "outbox"
-	if this.ActivityStreamsOutbox != nil {
+	if this.ActivityStreamsOutbox != nil && !omit[this.ActivityStreamsOutbox.Name()] {
 		if i, err := this.ActivityStreamsOutbox.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1806,7 +2312,7 @@ func (this ActivityStreamsApplication) Serialize() (map[string]interface{}, erro
 		}
 	}
 	// Maybe serialize property "preferredUsername"
-	if this.ActivityStreamsPreferredUsername != nil {
+	if this.ActivityStreamsPreferredUsername != nil && !omit[this.ActivityStreamsPreferredUsername.Name()] {
 		if i, err := this.ActivityStreamsPreferredUsername.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1814,7 +2320,7 @@ func (this ActivityStreamsApplication) Serialize() (map[string]interface{}, erro
 		}
 	}
 	// Maybe serialize property "preview"
-	if this.ActivityStreamsPreview != nil {
+	if this.ActivityStreamsPreview != nil && !omit[this.ActivityStreamsPreview.Name()] {
 		if i, err := this.ActivityStreamsPreview.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1822,7 +2328,7 @@ func (this ActivityStreamsApplication) Serialize() (map[string]interface{}, erro
 		}
 	}
 	// Maybe serialize property "publicKey"
-	if this.W3IDSecurityV1PublicKey != nil {
+	if this.W3IDSecurityV1PublicKey != nil && !omit[this.W3IDSecurityV1PublicKey.Name()] {
 		if i, err := this.W3IDSecurityV1PublicKey.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1830,7 +2336,7 @@ func (this ActivityStreamsApplication) Serialize() (map[string]interface{}, erro
 		}
 	}
 	// Maybe serialize property "published"
-	if this.ActivityStreamsPublished != nil {
+	if this.ActivityStreamsPublished != nil && !omit[this.ActivityStreamsPublished.Name()] {
 		if i, err := this.ActivityStreamsPublished.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1838,7 +2344,7 @@ func (this ActivityStreamsApplication) Serialize() (map[string]interface{}, erro
 		}
 	}
 	// Maybe serialize property "replies"
-	if this.ActivityStreamsReplies != nil {
+	if this.ActivityStreamsReplies != nil && !omit[this.ActivityStreamsReplies.Name()] {
 		if i, err := this.ActivityStreamsReplies.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1846,7 +2352,7 @@ func (this ActivityStreamsApplication) Serialize() (map[string]interface{}, erro
 		}
 	}
 	// Maybe serialize property "sensitive"
-	if this.ActivityStreamsSensitive != nil {
+	if this.ActivityStreamsSensitive != nil && !omit[this.ActivityStreamsSensitive.Name()] {
 		if i, err := this.ActivityStreamsSensitive.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1854,7 +2360,7 @@ func (this ActivityStreamsApplication) Serialize() (map[string]interface{}, erro
 		}
 	}
 	// Maybe serialize property "shares"
-	if this.ActivityStreamsShares != nil {
+	if this.ActivityStreamsShares != nil && !omit[this.ActivityStreamsShares.Name()] {
 		if i, err := this.ActivityStreamsShares.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1862,7 +2368,7 @@ func (this ActivityStreamsApplication) Serialize() (map[string]interface{}, erro
 		}
 	}
 	// Maybe serialize property "source"
-	if this.ActivityStreamsSource != nil {
+	if this.ActivityStreamsSource != nil && !omit[this.ActivityStreamsSource.Name()] {
 		if i, err := this.ActivityStreamsSource.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1870,7 +2376,7 @@ func (this ActivityStreamsApplication) Serialize() (map[string]interface{}, erro
 		}
 	}
 	// Maybe serialize property "startTime"
-	if this.ActivityStreamsStartTime != nil {
+	if this.ActivityStreamsStartTime != nil && !omit[this.ActivityStreamsStartTime.Name()] {
 		if i, err := this.ActivityStreamsStartTime.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1878,7 +2384,7 @@ func (this ActivityStreamsApplication) Serialize() (map[string]interface{}, erro
 		}
 	}
 	// Maybe serialize property "streams"
-	if this.ActivityStreamsStreams != nil {
+	if this.ActivityStreamsStreams != nil && !omit[this.ActivityStreamsStreams.Name()] {
 		if i, err := this.ActivityStreamsStreams.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1886,7 +2392,7 @@ func (this ActivityStreamsApplication) Serialize() (map[string]interface{}, erro
 		}
 	}
 	// Maybe serialize property "summary"
-	if this.ActivityStreamsSummary != nil {
+	if this.ActivityStreamsSummary != nil && !omit[this.ActivityStreamsSummary.Name()] {
 		if i, err := this.ActivityStreamsSummary.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1894,7 +2400,7 @@ func (this ActivityStreamsApplication) Serialize() (map[string]interface{}, erro
 		}
 	}
 	// Maybe serialize property "tag"
-	if this.ActivityStreamsTag != nil {
+	if this.ActivityStreamsTag != nil && !omit[this.ActivityStreamsTag.Name()] {
 		if i, err := this.ActivityStreamsTag.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1902,7 +2408,7 @@ func (this ActivityStreamsApplication) Serialize() (map[string]interface{}, erro
 		}
 	}
 	// Maybe serialize property "team"
-	if this.ForgeFedTeam != nil {
+	if this.ForgeFedTeam != nil && !omit[this.ForgeFedTeam.Name()] {
 		if i, err := this.ForgeFedTeam.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1910,7 +2416,7 @@ func (this ActivityStreamsApplication) Serialize() (map[string]interface{}, erro
 		}
 	}
 	// Maybe serialize property "ticketsTrackedBy"
-	if this.ForgeFedTicketsTrackedBy != nil {
+	if this.ForgeFedTicketsTrackedBy != nil && !omit[this.ForgeFedTicketsTrackedBy.Name()] {
 		if i, err := this.ForgeFedTicketsTrackedBy.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1918,7 +2424,7 @@ func (this ActivityStreamsApplication) Serialize() (map[string]interface{}, erro
 		}
 	}
 	// Maybe serialize property "to"
-	if this.ActivityStreamsTo != nil {
+	if this.ActivityStreamsTo != nil && !omit[this.ActivityStreamsTo.Name()] {
 		if i, err := this.ActivityStreamsTo.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1926,7 +2432,7 @@ func (this ActivityStreamsApplication) Serialize() (map[string]interface{}, erro
 		}
 	}
 	// Maybe serialize property "tracksTicketsFor"
-	if this.ForgeFedTracksTicketsFor != nil {
+	if this.ForgeFedTracksTicketsFor != nil && !omit[this.ForgeFedTracksTicketsFor.Name()] {
 		if i, err := this.ForgeFedTracksTicketsFor.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1934,7 +2440,7 @@ func (this ActivityStreamsApplication) Serialize() (map[string]interface{}, erro
 		}
 	}
 	// Maybe serialize property "type"
-	if this.JSONLDType != nil {
+	if this.JSONLDType != nil && !omit[this.JSONLDType.Name()] {
 		if i, err := this.JSONLDType.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1942,7 +2448,7 @@ func (this ActivityStreamsApplication) Serialize() (map[string]interface{}, erro
 		}
 	}
 	// Maybe serialize property "updated"
-	if this.ActivityStreamsUpdated != nil {
+	if this.ActivityStreamsUpdated != nil && !omit[this.ActivityStreamsUpdated.Name()] {
 		if i, err := this.ActivityStreamsUpdated.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1950,7 +2456,7 @@ func (this ActivityStreamsApplication) Serialize() (map[string]interface{}, erro
 		}
 	}
 	// Maybe serialize property "url"
-	if this.ActivityStreamsUrl != nil {
+	if this.ActivityStreamsUrl != nil && !omit[this.ActivityStreamsUrl.Name()] {
 		if i, err := this.ActivityStreamsUrl.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -1962,7 +2468,7 @@ func (this ActivityStreamsApplication) Serialize() (map[string]interface{}, erro
 	// Begin: Serialize unknown properties
 	for k, v := range this.unknown {
 		// To be safe, ensure we aren't overwriting a known property
-		if _, has := m[k]; !has {
+		if _, has := m[k]; !has && !omit[k] {
 			m[k] = v
 		}
 	}
@@ -1971,6 +2477,11 @@ func (this ActivityStreamsApplication) Serialize() (map[string]interface{}, erro
 	return m, nil
 }
 
+// SetActivityStreamsAlsoKnownAs sets the "alsoKnownAs" property.
+func (this *ActivityStreamsApplication) SetActivityStreamsAlsoKnownAs(i vocab.ActivityStreamsAlsoKnownAsProperty) {
+	this.ActivityStreamsAlsoKnownAs = i
+}
+
 // SetActivityStreamsAltitude sets the "altitude" property.
 func (this *ActivityStreamsApplication) SetActivityStreamsAltitude(i vocab.ActivityStreamsAltitudeProperty) {
 	this.ActivityStreamsAltitude = i
@@ -2026,6 +2537,11 @@ func (this *ActivityStreamsApplication) SetActivityStreamsEndTime(i vocab.Activi
 	this.ActivityStreamsEndTime = i
 }
 
+// SetActivityStreamsEndpoints sets the "endpoints" property.
+func (this *ActivityStreamsApplication) SetActivityStreamsEndpoints(i vocab.ActivityStreamsEndpointsProperty) {
+	this.ActivityStreamsEndpoints = i
+}
+
 // SetActivityStreamsFollowers sets the "followers" property.
 func (this *ActivityStreamsApplication) SetActivityStreamsFollowers(i vocab.ActivityStreamsFollowersProperty) {
 	this.ActivityStreamsFollowers = i
@@ -2087,6 +2603,11 @@ func (this *ActivityStreamsApplication) SetActivityStreamsMediaType(i vocab.Acti
 	this.ActivityStreamsMediaType = i
 }
 
+// SetActivityStreamsMovedTo sets the "movedTo" property.
+func (this *ActivityStreamsApplication) SetActivityStreamsMovedTo(i vocab.ActivityStreamsMovedToProperty) {
+	this.ActivityStreamsMovedTo = i
+}
+
 // SetActivityStreamsName sets the "name" property.
 func (this *ActivityStreamsApplication) SetActivityStreamsName(i vocab.ActivityStreamsNameProperty) {
 	this.ActivityStreamsName = i
@@ -2207,6 +2728,11 @@ func (this *ActivityStreamsApplication) SetTootFeatured(i vocab.TootFeaturedProp
 	this.TootFeatured = i
 }
 
+// SetTootFeaturedTags sets the "featuredTags" property.
+func (this *ActivityStreamsApplication) SetTootFeaturedTags(i vocab.TootFeaturedTagsProperty) {
+	this.TootFeaturedTags = i
+}
+
 // SetW3IDSecurityV1PublicKey sets the "publicKey" property.
 func (this *ActivityStreamsApplication) SetW3IDSecurityV1PublicKey(i vocab.W3IDSecurityV1PublicKeyProperty) {
 	this.W3IDSecurityV1PublicKey = i
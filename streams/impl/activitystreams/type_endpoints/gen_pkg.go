@@ -0,0 +1,95 @@
+// Code generated by astool. DO NOT EDIT.
+
+package typeendpoints
+
+import vocab "github.com/go-fed/activity/streams/vocab"
+
+var mgr privateManager
+
+var typePropertyConstructor func() vocab.JSONLDTypeProperty
+
+// privateManager abstracts the code-generated manager that provides access to
+// concrete implementations.
+type privateManager interface {
+	// DeserializeIdPropertyJSONLD returns the deserialization method for the
+	// "JSONLDIdProperty" non-functional property in the vocabulary
+	// "JSONLD"
+	DeserializeIdPropertyJSONLD() func(map[string]interface{}, map[string]string) (vocab.JSONLDIdProperty, error)
+	// DeserializeOauthAuthorizationEndpointPropertyActivityStreams returns
+	// the deserialization method for the
+	// "ActivityStreamsOauthAuthorizationEndpointProperty" non-functional
+	// property in the vocabulary "ActivityStreams"
+	DeserializeOauthAuthorizationEndpointPropertyActivityStreams() func(map[string]interface{}, map[string]string) (vocab.ActivityStreamsOauthAuthorizationEndpointProperty, error)
+	// DeserializeOauthTokenEndpointPropertyActivityStreams returns the
+	// deserialization method for the
+	// "ActivityStreamsOauthTokenEndpointProperty" non-functional property
+	// in the vocabulary "ActivityStreams"
+	DeserializeOauthTokenEndpointPropertyActivityStreams() func(map[string]interface{}, map[string]string) (vocab.ActivityStreamsOauthTokenEndpointProperty, error)
+	// DeserializeProvideClientKeyPropertyActivityStreams returns the
+	// deserialization method for the
+	// "ActivityStreamsProvideClientKeyProperty" non-functional property
+	// in the vocabulary "ActivityStreams"
+	DeserializeProvideClientKeyPropertyActivityStreams() func(map[string]interface{}, map[string]string) (vocab.ActivityStreamsProvideClientKeyProperty, error)
+	// DeserializeSharedInboxPropertyActivityStreams returns the
+	// deserialization method for the "ActivityStreamsSharedInboxProperty"
+	// non-functional property in the vocabulary "ActivityStreams"
+	DeserializeSharedInboxPropertyActivityStreams() func(map[string]interface{}, map[string]string) (vocab.ActivityStreamsSharedInboxProperty, error)
+	// DeserializeSignClientKeyPropertyActivityStreams returns the
+	// deserialization method for the
+	// "ActivityStreamsSignClientKeyProperty" non-functional property in
+	// the vocabulary "ActivityStreams"
+	DeserializeSignClientKeyPropertyActivityStreams() func(map[string]interface{}, map[string]string) (vocab.ActivityStreamsSignClientKeyProperty, error)
+	// DeserializeUploadMediaPropertyActivityStreams returns the
+	// deserialization method for the "ActivityStreamsUploadMediaProperty"
+	// non-functional property in the vocabulary "ActivityStreams"
+	DeserializeUploadMediaPropertyActivityStreams() func(map[string]interface{}, map[string]string) (vocab.ActivityStreamsUploadMediaProperty, error)
+}
+
+// jsonldContexter is a private interface to determine the JSON-LD contexts and
+// aliases needed for functional and non-functional properties. It is a helper
+// interface for this implementation.
+type jsonldContexter interface {
+	// JSONLDContext returns the JSONLD URIs required in the context string
+	// for this property and the specific values that are set. The value
+	// in the map is the alias used to import the property's value or
+	// values.
+	JSONLDContext() map[string]string
+}
+
+// SetManager sets the manager package-global variable. For internal use only, do
+// not use as part of Application behavior. Must be called at golang init time.
+func SetManager(m privateManager) {
+	mgr = m
+}
+
+// SetTypePropertyConstructor sets the "type" property's constructor in the
+// package-global variable. For internal use only, do not use as part of
+// Application behavior. Must be called at golang init time. Permits
+// ActivityStreams types to correctly set their "type" property at
+// construction time, so users don't have to remember to do so each time. It
+// is dependency injected so other go-fed compatible implementations could
+// inject their own type.
+func SetTypePropertyConstructor(f func() vocab.JSONLDTypeProperty) {
+	typePropertyConstructor = f
+}
+
+// cloneUnknown deep copies an unknown value decoded from JSON. Maps and slices
+// are copied, and all other values are immutable and returned as-is.
+func cloneUnknown(i interface{}) interface{} {
+	switch v := i.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			m[k] = cloneUnknown(e)
+		}
+		return m
+	case []interface{}:
+		s := make([]interface{}, len(v))
+		for idx, e := range v {
+			s[idx] = cloneUnknown(e)
+		}
+		return s
+	default:
+		return i
+	}
+}
@@ -0,0 +1,535 @@
+// Code generated by astool. DO NOT EDIT.
+
+package typeendpoints
+
+import vocab "github.com/go-fed/activity/streams/vocab"
+
+// The endpoints of an actor, which are useful for it or for others to interact
+// with it, such as the shared inbox of its server.
+type ActivityStreamsEndpoints struct {
+	JSONLDId                                  vocab.JSONLDIdProperty
+	ActivityStreamsOauthAuthorizationEndpoint vocab.ActivityStreamsOauthAuthorizationEndpointProperty
+	ActivityStreamsOauthTokenEndpoint         vocab.ActivityStreamsOauthTokenEndpointProperty
+	ActivityStreamsProvideClientKey           vocab.ActivityStreamsProvideClientKeyProperty
+	ActivityStreamsSharedInbox                vocab.ActivityStreamsSharedInboxProperty
+	ActivityStreamsSignClientKey              vocab.ActivityStreamsSignClientKeyProperty
+	ActivityStreamsUploadMedia                vocab.ActivityStreamsUploadMediaProperty
+	alias                                     string
+	unknown                                   map[string]interface{}
+}
+
+// ActivityStreamsEndpointsExtends returns true if the Endpoints type extends from
+// the other type.
+func ActivityStreamsEndpointsExtends(other vocab.Type) bool {
+	// Shortcut implementation: this does not extend anything.
+	return false
+}
+
+// DeserializeEndpoints creates a Endpoints from a map representation that has
+// been unmarshalled from a text or binary format.
+func DeserializeEndpoints(m map[string]interface{}, aliasMap map[string]string) (*ActivityStreamsEndpoints, error) {
+	alias := ""
+	if a, ok := aliasMap["https://www.w3.org/ns/activitystreams"]; ok {
+		alias = a
+	}
+	this := &ActivityStreamsEndpoints{
+		alias:   alias,
+		unknown: make(map[string]interface{}),
+	}
+
+	// Begin: Known property deserialization
+	if p, err := mgr.DeserializeIdPropertyJSONLD()(m, aliasMap); err != nil {
+		return nil, err
+	} else if p != nil {
+		this.JSONLDId = p
+	}
+	if p, err := mgr.DeserializeOauthAuthorizationEndpointPropertyActivityStreams()(m, aliasMap); err != nil {
+		return nil, err
+	} else if p != nil {
+		this.ActivityStreamsOauthAuthorizationEndpoint = p
+	}
+	if p, err := mgr.DeserializeOauthTokenEndpointPropertyActivityStreams()(m, aliasMap); err != nil {
+		return nil, err
+	} else if p != nil {
+		this.ActivityStreamsOauthTokenEndpoint = p
+	}
+	if p, err := mgr.DeserializeProvideClientKeyPropertyActivityStreams()(m, aliasMap); err != nil {
+		return nil, err
+	} else if p != nil {
+		this.ActivityStreamsProvideClientKey = p
+	}
+	if p, err := mgr.DeserializeSharedInboxPropertyActivityStreams()(m, aliasMap); err != nil {
+		return nil, err
+	} else if p != nil {
+		this.ActivityStreamsSharedInbox = p
+	}
+	if p, err := mgr.DeserializeSignClientKeyPropertyActivityStreams()(m, aliasMap); err != nil {
+		return nil, err
+	} else if p != nil {
+		this.ActivityStreamsSignClientKey = p
+	}
+	if p, err := mgr.DeserializeUploadMediaPropertyActivityStreams()(m, aliasMap); err != nil {
+		return nil, err
+	} else if p != nil {
+		this.ActivityStreamsUploadMedia = p
+	}
+	// End: Known property deserialization
+
+	// Begin: Unknown deserialization
+	// Known properties are prefixed with the alias of their vocabulary, if any.
+	activitystreamsPrefix := ""
+	if a, ok := aliasMap["https://www.w3.org/ns/activitystreams"]; ok && len(a) > 0 {
+		activitystreamsPrefix = a + ":"
+	}
+	for k, v := range m {
+		// Begin: Code that ensures a property name is unknown
+		if k == "id" {
+			continue
+		} else if k == activitystreamsPrefix+"oauthAuthorizationEndpoint" {
+			continue
+		} else if k == activitystreamsPrefix+"oauthTokenEndpoint" {
+			continue
+		} else if k == activitystreamsPrefix+"provideClientKey" {
+			continue
+		} else if k == activitystreamsPrefix+"sharedInbox" {
+			continue
+		} else if k == activitystreamsPrefix+"signClientKey" {
+			continue
+		} else if k == activitystreamsPrefix+"uploadMedia" {
+			continue
+		} // End: Code that ensures a property name is unknown
+
+		this.unknown[k] = v
+	}
+	// End: Unknown deserialization
+
+	return this, nil
+}
+
+// EndpointsIsDisjointWith returns true if the other provided type is disjoint
+// with the Endpoints type.
+func EndpointsIsDisjointWith(other vocab.Type) bool {
+	// Shortcut implementation: is not disjoint with anything.
+	return false
+}
+
+// EndpointsIsExtendedBy returns true if the other provided type extends from the
+// Endpoints type. Note that it returns false if the types are the same; see
+// the "IsOrExtendsEndpoints" variant instead.
+func EndpointsIsExtendedBy(other vocab.Type) bool {
+	// Shortcut implementation: is not extended by anything.
+	return false
+}
+
+// IsOrExtendsEndpoints returns true if the other provided type is the Endpoints
+// type or extends from the Endpoints type.
+func IsOrExtendsEndpoints(other vocab.Type) bool {
+	if other.GetTypeName() == "Endpoints" {
+		return true
+	}
+	return EndpointsIsExtendedBy(other)
+}
+
+// NewActivityStreamsEndpoints creates a new Endpoints type
+func NewActivityStreamsEndpoints() *ActivityStreamsEndpoints {
+	return &ActivityStreamsEndpoints{
+		alias:   "",
+		unknown: make(map[string]interface{}),
+	}
+}
+
+// Clone returns a deep copy of this type. Modifying the copy does not modify this
+// type.
+func (this ActivityStreamsEndpoints) Clone() vocab.Type {
+	c := &ActivityStreamsEndpoints{alias: this.alias}
+	if this.JSONLDId != nil {
+		c.JSONLDId = this.JSONLDId.Clone()
+	}
+	if this.ActivityStreamsOauthAuthorizationEndpoint != nil {
+		c.ActivityStreamsOauthAuthorizationEndpoint = this.ActivityStreamsOauthAuthorizationEndpoint.Clone()
+	}
+	if this.ActivityStreamsOauthTokenEndpoint != nil {
+		c.ActivityStreamsOauthTokenEndpoint = this.ActivityStreamsOauthTokenEndpoint.Clone()
+	}
+	if this.ActivityStreamsProvideClientKey != nil {
+		c.ActivityStreamsProvideClientKey = this.ActivityStreamsProvideClientKey.Clone()
+	}
+	if this.ActivityStreamsSharedInbox != nil {
+		c.ActivityStreamsSharedInbox = this.ActivityStreamsSharedInbox.Clone()
+	}
+	if this.ActivityStreamsSignClientKey != nil {
+		c.ActivityStreamsSignClientKey = this.ActivityStreamsSignClientKey.Clone()
+	}
+	if this.ActivityStreamsUploadMedia != nil {
+		c.ActivityStreamsUploadMedia = this.ActivityStreamsUploadMedia.Clone()
+	}
+	if this.unknown != nil {
+		c.unknown = cloneUnknown(this.unknown).(map[string]interface{})
+	}
+	return c
+}
+
+// EachProperty calls fn with the name and value of each property that is set, in
+// a stable order. Unknown properties are not included.
+func (this ActivityStreamsEndpoints) EachProperty(fn func(name string, value vocab.PropertyInterface)) {
+	if this.JSONLDId != nil {
+		fn(this.JSONLDId.Name(), this.JSONLDId)
+	}
+	if this.ActivityStreamsOauthAuthorizationEndpoint != nil {
+		fn(this.ActivityStreamsOauthAuthorizationEndpoint.Name(), this.ActivityStreamsOauthAuthorizationEndpoint)
+	}
+	if this.ActivityStreamsOauthTokenEndpoint != nil {
+		fn(this.ActivityStreamsOauthTokenEndpoint.Name(), this.ActivityStreamsOauthTokenEndpoint)
+	}
+	if this.ActivityStreamsProvideClientKey != nil {
+		fn(this.ActivityStreamsProvideClientKey.Name(), this.ActivityStreamsProvideClientKey)
+	}
+	if this.ActivityStreamsSharedInbox != nil {
+		fn(this.ActivityStreamsSharedInbox.Name(), this.ActivityStreamsSharedInbox)
+	}
+	if this.ActivityStreamsSignClientKey != nil {
+		fn(this.ActivityStreamsSignClientKey.Name(), this.ActivityStreamsSignClientKey)
+	}
+	if this.ActivityStreamsUploadMedia != nil {
+		fn(this.ActivityStreamsUploadMedia.Name(), this.ActivityStreamsUploadMedia)
+	}
+}
+
+// GetActivityStreamsOauthAuthorizationEndpoint returns the
+// "oauthAuthorizationEndpoint" property if it exists, and nil otherwise.
+func (this ActivityStreamsEndpoints) GetActivityStreamsOauthAuthorizationEndpoint() vocab.ActivityStreamsOauthAuthorizationEndpointProperty {
+	return this.ActivityStreamsOauthAuthorizationEndpoint
+}
+
+// GetActivityStreamsOauthTokenEndpoint returns the "oauthTokenEndpoint" property
+// if it exists, and nil otherwise.
+func (this ActivityStreamsEndpoints) GetActivityStreamsOauthTokenEndpoint() vocab.ActivityStreamsOauthTokenEndpointProperty {
+	return this.ActivityStreamsOauthTokenEndpoint
+}
+
+// GetActivityStreamsProvideClientKey returns the "provideClientKey" property if
+// it exists, and nil otherwise.
+func (this ActivityStreamsEndpoints) GetActivityStreamsProvideClientKey() vocab.ActivityStreamsProvideClientKeyProperty {
+	return this.ActivityStreamsProvideClientKey
+}
+
+// GetActivityStreamsSharedInbox returns the "sharedInbox" property if it exists,
+// and nil otherwise.
+func (this ActivityStreamsEndpoints) GetActivityStreamsSharedInbox() vocab.ActivityStreamsSharedInboxProperty {
+	return this.ActivityStreamsSharedInbox
+}
+
+// GetActivityStreamsSignClientKey returns the "signClientKey" property if it
+// exists, and nil otherwise.
+func (this ActivityStreamsEndpoints) GetActivityStreamsSignClientKey() vocab.ActivityStreamsSignClientKeyProperty {
+	return this.ActivityStreamsSignClientKey
+}
+
+// GetActivityStreamsUploadMedia returns the "uploadMedia" property if it exists,
+// and nil otherwise.
+func (this ActivityStreamsEndpoints) GetActivityStreamsUploadMedia() vocab.ActivityStreamsUploadMediaProperty {
+	return this.ActivityStreamsUploadMedia
+}
+
+// GetJSONLDId returns the "id" property if it exists, and nil otherwise.
+func (this ActivityStreamsEndpoints) GetJSONLDId() vocab.JSONLDIdProperty {
+	return this.JSONLDId
+}
+
+// GetTypeName returns the name of this type.
+func (this ActivityStreamsEndpoints) GetTypeName() string {
+	return "Endpoints"
+}
+
+// GetUnknownProperties returns the unknown properties for the Endpoints type.
+// Note that this should not be used by app developers. It is only used to
+// help determine which implementation is LessThan the other. Developers who
+// are creating a different implementation of this type's interface can use
+// this method in their LessThan implementation, but routine ActivityPub
+// applications should not use this to bypass the code generation tool.
+func (this ActivityStreamsEndpoints) GetUnknownProperties() map[string]interface{} {
+	return this.unknown
+}
+
+// IsExtending returns true if the Endpoints type extends from the other type.
+func (this ActivityStreamsEndpoints) IsExtending(other vocab.Type) bool {
+	return ActivityStreamsEndpointsExtends(other)
+}
+
+// JSONLDContext returns the JSONLD URIs required in the context string for this
+// type and the specific properties that are set. The value in the map is the
+// alias used to import the type and its properties.
+func (this ActivityStreamsEndpoints) JSONLDContext() map[string]string {
+	m := map[string]string{"https://www.w3.org/ns/activitystreams": this.alias}
+	m = this.helperJSONLDContext(this.JSONLDId, m)
+	m = this.helperJSONLDContext(this.ActivityStreamsOauthAuthorizationEndpoint, m)
+	m = this.helperJSONLDContext(this.ActivityStreamsOauthTokenEndpoint, m)
+	m = this.helperJSONLDContext(this.ActivityStreamsProvideClientKey, m)
+	m = this.helperJSONLDContext(this.ActivityStreamsSharedInbox, m)
+	m = this.helperJSONLDContext(this.ActivityStreamsSignClientKey, m)
+	m = this.helperJSONLDContext(this.ActivityStreamsUploadMedia, m)
+
+	return m
+}
+
+// LessThan computes if this Endpoints is lesser, with an arbitrary but stable
+// determination.
+func (this ActivityStreamsEndpoints) LessThan(o vocab.ActivityStreamsEndpoints) bool {
+	// Begin: Compare known properties
+	// Compare property "id"
+	if lhs, rhs := this.JSONLDId, o.GetJSONLDId(); lhs != nil && rhs != nil {
+		if lhs.LessThan(rhs) {
+			return true
+		} else if rhs.LessThan(lhs) {
+			return false
+		}
+	} else if lhs == nil && rhs != nil {
+		// Nil is less than anything else
+		return true
+	} else if rhs != nil && rhs == nil {
+		// Anything else is greater than nil
+		return false
+	} // Else: Both are nil
+	// Compare property "oauthAuthorizationEndpoint"
+	if lhs, rhs := this.ActivityStreamsOauthAuthorizationEndpoint, o.GetActivityStreamsOauthAuthorizationEndpoint(); lhs != nil && rhs != nil {
+		if lhs.LessThan(rhs) {
+			return true
+		} else if rhs.LessThan(lhs) {
+			return false
+		}
+	} else if lhs == nil && rhs != nil {
+		// Nil is less than anything else
+		return true
+	} else if rhs != nil && rhs == nil {
+		// Anything else is greater than nil
+		return false
+	} // Else: Both are nil
+	// Compare property "oauthTokenEndpoint"
+	if lhs, rhs := this.ActivityStreamsOauthTokenEndpoint, o.GetActivityStreamsOauthTokenEndpoint(); lhs != nil && rhs != nil {
+		if lhs.LessThan(rhs) {
+			return true
+		} else if rhs.LessThan(lhs) {
+			return false
+		}
+	} else if lhs == nil && rhs != nil {
+		// Nil is less than anything else
+		return true
+	} else if rhs != nil && rhs == nil {
+		// Anything else is greater than nil
+		return false
+	} // Else: Both are nil
+	// Compare property "provideClientKey"
+	if lhs, rhs := this.ActivityStreamsProvideClientKey, o.GetActivityStreamsProvideClientKey(); lhs != nil && rhs != nil {
+		if lhs.LessThan(rhs) {
+			return true
+		} else if rhs.LessThan(lhs) {
+			return false
+		}
+	} else if lhs == nil && rhs != nil {
+		// Nil is less than anything else
+		return true
+	} else if rhs != nil && rhs == nil {
+		// Anything else is greater than nil
+		return false
+	} // Else: Both are nil
+	// Compare property "sharedInbox"
+	if lhs, rhs := this.ActivityStreamsSharedInbox, o.GetActivityStreamsSharedInbox(); lhs != nil && rhs != nil {
+		if lhs.LessThan(rhs) {
+			return true
+		} else if rhs.LessThan(lhs) {
+			return false
+		}
+	} else if lhs == nil && rhs != nil {
+		// Nil is less than anything else
+		return true
+	} else if rhs != nil && rhs == nil {
+		// Anything else is greater than nil
+		return false
+	} // Else: Both are nil
+	// Compare property "signClientKey"
+	if lhs, rhs := this.ActivityStreamsSignClientKey, o.GetActivityStreamsSignClientKey(); lhs != nil && rhs != nil {
+		if lhs.LessThan(rhs) {
+			return true
+		} else if rhs.LessThan(lhs) {
+			return false
+		}
+	} else if lhs == nil && rhs != nil {
+		// Nil is less than anything else
+		return true
+	} else if rhs != nil && rhs == nil {
+		// Anything else is greater than nil
+		return false
+	} // Else: Both are nil
+	// Compare property "uploadMedia"
+	if lhs, rhs := this.ActivityStreamsUploadMedia, o.GetActivityStreamsUploadMedia(); lhs != nil && rhs != nil {
+		if lhs.LessThan(rhs) {
+			return true
+		} else if rhs.LessThan(lhs) {
+			return false
+		}
+	} else if lhs == nil && rhs != nil {
+		// Nil is less than anything else
+		return true
+	} else if rhs != nil && rhs == nil {
+		// Anything else is greater than nil
+		return false
+	} // Else: Both are nil
+	// End: Compare known properties
+
+	// Begin: Compare unknown properties (only by number of them)
+	if len(this.unknown) < len(o.GetUnknownProperties()) {
+		return true
+	} else if len(o.GetUnknownProperties()) < len(this.unknown) {
+		return false
+	} // End: Compare unknown properties (only by number of them)
+
+	// All properties are the same.
+	return false
+}
+
+// Serialize converts this into an interface representation suitable for
+// marshalling into a text or binary format.
+func (this ActivityStreamsEndpoints) Serialize() (map[string]interface{}, error) {
+	return this.SerializeWithout()
+}
+
+// SerializeWithout is like Serialize, but leaves out the known properties with
+// the names, as well as unknown properties with the same names, so that they
+// can be serialized separately.
+func (this ActivityStreamsEndpoints) SerializeWithout(names ...string) (map[string]interface{}, error) {
+	var omit map[string]bool
+	if len(names) > 0 {
+		omit = make(map[string]bool, len(names))
+		for _, name := range names {
+			omit[name] = true
+		}
+	}
+	m := make(map[string]interface{})
+	// Begin: Serialize known properties
+	// Maybe serialize property "id"
+	if this.JSONLDId != nil && !omit[this.JSONLDId.Name()] {
+		if i, err := this.JSONLDId.Serialize(); err != nil {
+			return nil, err
+		} else if i != nil {
+			m[this.JSONLDId.Name()] = i
+		}
+	}
+	// Maybe serialize property "oauthAuthorizationEndpoint"
+	if this.ActivityStreamsOauthAuthorizationEndpoint != nil && !omit[this.ActivityStreamsOauthAuthorizationEndpoint.Name()] {
+		if i, err := this.ActivityStreamsOauthAuthorizationEndpoint.Serialize(); err != nil {
+			return nil, err
+		} else if i != nil {
+			m[this.ActivityStreamsOauthAuthorizationEndpoint.Name()] = i
+		}
+	}
+	// Maybe serialize property "oauthTokenEndpoint"
+	if this.ActivityStreamsOauthTokenEndpoint != nil && !omit[this.ActivityStreamsOauthTokenEndpoint.Name()] {
+		if i, err := this.ActivityStreamsOauthTokenEndpoint.Serialize(); err != nil {
+			return nil, err
+		} else if i != nil {
+			m[this.ActivityStreamsOauthTokenEndpoint.Name()] = i
+		}
+	}
+	// Maybe serialize property "provideClientKey"
+	if this.ActivityStreamsProvideClientKey != nil && !omit[this.ActivityStreamsProvideClientKey.Name()] {
+		if i, err := this.ActivityStreamsProvideClientKey.Serialize(); err != nil {
+			return nil, err
+		} else if i != nil {
+			m[this.ActivityStreamsProvideClientKey.Name()] = i
+		}
+	}
+	// Maybe serialize property "sharedInbox"
+	if this.ActivityStreamsSharedInbox != nil && !omit[this.ActivityStreamsSharedInbox.Name()] {
+		if i, err := this.ActivityStreamsSharedInbox.Serialize(); err != nil {
+			return nil, err
+		} else if i != nil {
+			m[this.ActivityStreamsSharedInbox.Name()] = i
+		}
+	}
+	// Maybe serialize property "signClientKey"
+	if this.ActivityStreamsSignClientKey != nil && !omit[this.ActivityStreamsSignClientKey.Name()] {
+		if i, err := this.ActivityStreamsSignClientKey.Serialize(); err != nil {
+			return nil, err
+		} else if i != nil {
+			m[this.ActivityStreamsSignClientKey.Name()] = i
+		}
+	}
+	// Maybe serialize property "uploadMedia"
+	if this.ActivityStreamsUploadMedia != nil && !omit[this.ActivityStreamsUploadMedia.Name()] {
+		if i, err := this.ActivityStreamsUploadMedia.Serialize(); err != nil {
+			return nil, err
+		} else if i != nil {
+			m[this.ActivityStreamsUploadMedia.Name()] = i
+		}
+	}
+	// End: Serialize known properties
+
+	// Begin: Serialize unknown properties
+	for k, v := range this.unknown {
+		// To be safe, ensure we aren't overwriting a known property
+		if _, has := m[k]; !has && !omit[k] {
+			m[k] = v
+		}
+	}
+	// End: Serialize unknown properties
+
+	return m, nil
+}
+
+// SetActivityStreamsOauthAuthorizationEndpoint sets the
+// "oauthAuthorizationEndpoint" property.
+func (this *ActivityStreamsEndpoints) SetActivityStreamsOauthAuthorizationEndpoint(i vocab.ActivityStreamsOauthAuthorizationEndpointProperty) {
+	this.ActivityStreamsOauthAuthorizationEndpoint = i
+}
+
+// SetActivityStreamsOauthTokenEndpoint sets the "oauthTokenEndpoint" property.
+func (this *ActivityStreamsEndpoints) SetActivityStreamsOauthTokenEndpoint(i vocab.ActivityStreamsOauthTokenEndpointProperty) {
+	this.ActivityStreamsOauthTokenEndpoint = i
+}
+
+// SetActivityStreamsProvideClientKey sets the "provideClientKey" property.
+func (this *ActivityStreamsEndpoints) SetActivityStreamsProvideClientKey(i vocab.ActivityStreamsProvideClientKeyProperty) {
+	this.ActivityStreamsProvideClientKey = i
+}
+
+// SetActivityStreamsSharedInbox sets the "sharedInbox" property.
+func (this *ActivityStreamsEndpoints) SetActivityStreamsSharedInbox(i vocab.ActivityStreamsSharedInboxProperty) {
+	this.ActivityStreamsSharedInbox = i
+}
+
+// SetActivityStreamsSignClientKey sets the "signClientKey" property.
+func (this *ActivityStreamsEndpoints) SetActivityStreamsSignClientKey(i vocab.ActivityStreamsSignClientKeyProperty) {
+	this.ActivityStreamsSignClientKey = i
+}
+
+// SetActivityStreamsUploadMedia sets the "uploadMedia" property.
+func (this *ActivityStreamsEndpoints) SetActivityStreamsUploadMedia(i vocab.ActivityStreamsUploadMediaProperty) {
+	this.ActivityStreamsUploadMedia = i
+}
+
+// SetJSONLDId sets the "id" property.
+func (this *ActivityStreamsEndpoints) SetJSONLDId(i vocab.JSONLDIdProperty) {
+	this.JSONLDId = i
+}
+
+// VocabularyURI returns the vocabulary's URI as a string.
+func (this ActivityStreamsEndpoints) VocabularyURI() string {
+	return "https://www.w3.org/ns/activitystreams"
+}
+
+// helperJSONLDContext obtains the context uris and their aliases from a property,
+// if it is not nil.
+func (this ActivityStreamsEndpoints) helperJSONLDContext(i jsonldContexter, toMerge map[string]string) map[string]string {
+	if i == nil {
+		return toMerge
+	}
+	for k, v := range i.JSONLDContext() {
+		/*
+		   Since the literal maps in this function are determined at
+		   code-generation time, this loop should not overwrite an existing key with a
+		   new value.
+		*/
+		toMerge[k] = v
+	}
+	return toMerge
+}
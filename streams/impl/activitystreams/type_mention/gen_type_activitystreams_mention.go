@@ -146,35 +146,40 @@ func DeserializeMention(m map[string]interface{}, aliasMap map[string]string) (*
 	// End: Known property deserialization
 
 	// Begin: Unknown deserialization
+	// Known properties are prefixed with the alias of their vocabulary, if any.
+	activitystreamsPrefix := ""
+	if a, ok := aliasMap["https://www.w3.org/ns/activitystreams"]; ok && len(a) > 0 {
+		activitystreamsPrefix = a + ":"
+	}
 	for k, v := range m {
 		// Begin: Code that ensures a property name is unknown
-		if k == "attributedTo" {
+		if k == activitystreamsPrefix+"attributedTo" {
 			continue
-		} else if k == "height" {
+		} else if k == activitystreamsPrefix+"height" {
 			continue
-		} else if k == "href" {
+		} else if k == activitystreamsPrefix+"href" {
 			continue
-		} else if k == "hreflang" {
+		} else if k == activitystreamsPrefix+"hreflang" {
 			continue
 		} else if k == "id" {
 			continue
-		} else if k == "mediaType" {
+		} else if k == activitystreamsPrefix+"mediaType" {
 			continue
-		} else if k == "name" {
+		} else if k == activitystreamsPrefix+"name" {
 			continue
-		} else if k == "nameMap" {
+		} else if k == activitystreamsPrefix+"nameMap" {
 			continue
-		} else if k == "preview" {
+		} else if k == activitystreamsPrefix+"preview" {
 			continue
-		} else if k == "rel" {
+		} else if k == activitystreamsPrefix+"rel" {
 			continue
-		} else if k == "summary" {
+		} else if k == activitystreamsPrefix+"summary" {
 			continue
-		} else if k == "summaryMap" {
+		} else if k == activitystreamsPrefix+"summaryMap" {
 			continue
 		} else if k == "type" {
 			continue
-		} else if k == "width" {
+		} else if k == activitystreamsPrefix+"width" {
 			continue
 		} // End: Code that ensures a property name is unknown
 
@@ -197,7 +202,7 @@ func IsOrExtendsMention(other vocab.Type) bool {
 // MentionIsDisjointWith returns true if the other provided type is disjoint with
 // the Mention type.
 func MentionIsDisjointWith(other vocab.Type) bool {
-	disjointWith := []string{"Accept", "Activity", "Add", "Announce", "Application", "Arrive", "Article", "Audio", "Block", "Branch", "Collection", "CollectionPage", "Commit", "Create", "Delete", "Dislike", "Document", "Emoji", "Event", "Flag", "Follow", "Group", "IdentityProof", "Ignore", "Image", "IntransitiveActivity", "Invite", "Join", "Leave", "Like", "Listen", "Move", "Note", "Object", "Offer", "OrderedCollection", "OrderedCollectionPage", "OrderedCollectionPage", "Organization", "Page", "Person", "Place", "Profile", "Push", "Question", "Read", "Reject", "Relationship", "Remove", "Repository", "Service", "TentativeAccept", "TentativeReject", "Ticket", "TicketDependency", "Tombstone", "Travel", "Undo", "Update", "Video", "View"}
+	disjointWith := []string{"Accept", "Activity", "Add", "Announce", "Application", "Arrive", "Article", "Audio", "Block", "Branch", "Collection", "CollectionPage", "Commit", "Create", "Delete", "Dislike", "Document", "Emoji", "Event", "Flag", "Follow", "Group", "IdentityProof", "Ignore", "Image", "IntransitiveActivity", "Invite", "Join", "Leave", "Like", "Listen", "Move", "Note", "Object", "Offer", "OrderedCollection", "OrderedCollectionPage", "OrderedCollectionPage", "Organization", "Page", "Person", "Place", "Profile", "PropertyValue", "Push", "Question", "Read", "Reject", "Relationship", "Remove", "Repository", "Service", "TentativeAccept", "TentativeReject", "Ticket", "TicketDependency", "Tombstone", "Travel", "Undo", "Update", "Video", "View"}
 	for _, disjoint := range disjointWith {
 		if disjoint == other.GetTypeName() {
 			return true
@@ -225,6 +230,93 @@ func NewActivityStreamsMention() *ActivityStreamsMention {
 	}
 }
 
+// Clone returns a deep copy of this type. Modifying the copy does not modify this
+// type.
+func (this ActivityStreamsMention) Clone() vocab.Type {
+	c := &ActivityStreamsMention{alias: this.alias}
+	if this.ActivityStreamsAttributedTo != nil {
+		c.ActivityStreamsAttributedTo = this.ActivityStreamsAttributedTo.Clone()
+	}
+	if this.ActivityStreamsHeight != nil {
+		c.ActivityStreamsHeight = this.ActivityStreamsHeight.Clone()
+	}
+	if this.ActivityStreamsHref != nil {
+		c.ActivityStreamsHref = this.ActivityStreamsHref.Clone()
+	}
+	if this.ActivityStreamsHreflang != nil {
+		c.ActivityStreamsHreflang = this.ActivityStreamsHreflang.Clone()
+	}
+	if this.JSONLDId != nil {
+		c.JSONLDId = this.JSONLDId.Clone()
+	}
+	if this.ActivityStreamsMediaType != nil {
+		c.ActivityStreamsMediaType = this.ActivityStreamsMediaType.Clone()
+	}
+	if this.ActivityStreamsName != nil {
+		c.ActivityStreamsName = this.ActivityStreamsName.Clone()
+	}
+	if this.ActivityStreamsPreview != nil {
+		c.ActivityStreamsPreview = this.ActivityStreamsPreview.Clone()
+	}
+	if this.ActivityStreamsRel != nil {
+		c.ActivityStreamsRel = this.ActivityStreamsRel.Clone()
+	}
+	if this.ActivityStreamsSummary != nil {
+		c.ActivityStreamsSummary = this.ActivityStreamsSummary.Clone()
+	}
+	if this.JSONLDType != nil {
+		c.JSONLDType = this.JSONLDType.Clone()
+	}
+	if this.ActivityStreamsWidth != nil {
+		c.ActivityStreamsWidth = this.ActivityStreamsWidth.Clone()
+	}
+	if this.unknown != nil {
+		c.unknown = cloneUnknown(this.unknown).(map[string]interface{})
+	}
+	return c
+}
+
+// EachProperty calls fn with the name and value of each property that is set, in
+// a stable order. Unknown properties are not included.
+func (this ActivityStreamsMention) EachProperty(fn func(name string, value vocab.PropertyInterface)) {
+	if this.ActivityStreamsAttributedTo != nil {
+		fn(this.ActivityStreamsAttributedTo.Name(), this.ActivityStreamsAttributedTo)
+	}
+	if this.ActivityStreamsHeight != nil {
+		fn(this.ActivityStreamsHeight.Name(), this.ActivityStreamsHeight)
+	}
+	if this.ActivityStreamsHref != nil {
+		fn(this.ActivityStreamsHref.Name(), this.ActivityStreamsHref)
+	}
+	if this.ActivityStreamsHreflang != nil {
+		fn(this.ActivityStreamsHreflang.Name(), this.ActivityStreamsHreflang)
+	}
+	if this.JSONLDId != nil {
+		fn(this.JSONLDId.Name(), this.JSONLDId)
+	}
+	if this.ActivityStreamsMediaType != nil {
+		fn(this.ActivityStreamsMediaType.Name(), this.ActivityStreamsMediaType)
+	}
+	if this.ActivityStreamsName != nil {
+		fn(this.ActivityStreamsName.Name(), this.ActivityStreamsName)
+	}
+	if this.ActivityStreamsPreview != nil {
+		fn(this.ActivityStreamsPreview.Name(), this.ActivityStreamsPreview)
+	}
+	if this.ActivityStreamsRel != nil {
+		fn(this.ActivityStreamsRel.Name(), this.ActivityStreamsRel)
+	}
+	if this.ActivityStreamsSummary != nil {
+		fn(this.ActivityStreamsSummary.Name(), this.ActivityStreamsSummary)
+	}
+	if this.JSONLDType != nil {
+		fn(this.JSONLDType.Name(), this.JSONLDType)
+	}
+	if this.ActivityStreamsWidth != nil {
+		fn(this.ActivityStreamsWidth.Name(), this.ActivityStreamsWidth)
+	}
+}
+
 // GetActivityStreamsAttributedTo returns the "attributedTo" property if it
 // exists, and nil otherwise.
 func (this ActivityStreamsMention) GetActivityStreamsAttributedTo() vocab.ActivityStreamsAttributedToProperty {
@@ -524,6 +616,20 @@ func (this ActivityStreamsMention) LessThan(o vocab.ActivityStreamsMention) bool
 // Serialize converts this into an interface representation suitable for
 // marshalling into a text or binary format.
 func (this ActivityStreamsMention) Serialize() (map[string]interface{}, error) {
+	return this.SerializeWithout()
+}
+
+// SerializeWithout is like Serialize, but leaves out the known properties with
+// the names, as well as unknown properties with the same names, so that they
+// can be serialized separately.
+func (this ActivityStreamsMention) SerializeWithout(names ...string) (map[string]interface{}, error) {
+	var omit map[string]bool
+	if len(names) > 0 {
+		omit = make(map[string]bool, len(names))
+		for _, name := range names {
+			omit[name] = true
+		}
+	}
 	m := make(map[string]interface{})
 	typeName := "Mention"
 	if len(this.alias) > 0 {
@@ -532,7 +638,7 @@ func (this ActivityStreamsMention) Serialize() (map[string]interface{}, error) {
 	m["type"] = typeName
 	// Begin: Serialize known properties
 	// Maybe serialize property "attributedTo"
-	if this.ActivityStreamsAttributedTo != nil {
+	if this.ActivityStreamsAttributedTo != nil && !omit[this.ActivityStreamsAttributedTo.Name()] {
 		if i, err := this.ActivityStreamsAttributedTo.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -540,7 +646,7 @@ func (this ActivityStreamsMention) Serialize() (map[string]interface{}, error) {
 		}
 	}
 	// Maybe serialize property "height"
-	if this.ActivityStreamsHeight != nil {
+	if this.ActivityStreamsHeight != nil && !omit[this.ActivityStreamsHeight.Name()] {
 		if i, err := this.ActivityStreamsHeight.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -548,7 +654,7 @@ func (this ActivityStreamsMention) Serialize() (map[string]interface{}, error) {
 		}
 	}
 	// Maybe serialize property "href"
-	if this.ActivityStreamsHref != nil {
+	if this.ActivityStreamsHref != nil && !omit[this.ActivityStreamsHref.Name()] {
 		if i, err := this.ActivityStreamsHref.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -556,7 +662,7 @@ func (this ActivityStreamsMention) Serialize() (map[string]interface{}, error) {
 		}
 	}
 	// Maybe serialize property "hreflang"
-	if this.ActivityStreamsHreflang != nil {
+	if this.ActivityStreamsHreflang != nil && !omit[this.ActivityStreamsHreflang.Name()] {
 		if i, err := this.ActivityStreamsHreflang.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -564,7 +670,7 @@ func (this ActivityStreamsMention) Serialize() (map[string]interface{}, error) {
 		}
 	}
 	// Maybe serialize property "id"
-	if this.JSONLDId != nil {
+	if this.JSONLDId != nil && !omit[this.JSONLDId.Name()] {
 		if i, err := this.JSONLDId.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -572,7 +678,7 @@ func (this ActivityStreamsMention) Serialize() (map[string]interface{}, error) {
 		}
 	}
 	// Maybe serialize property "mediaType"
-	if this.ActivityStreamsMediaType != nil {
+	if this.ActivityStreamsMediaType != nil && !omit[this.ActivityStreamsMediaType.Name()] {
 		if i, err := this.ActivityStreamsMediaType.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -580,7 +686,7 @@ func (this ActivityStreamsMention) Serialize() (map[string]interface{}, error) {
 		}
 	}
 	// Maybe serialize property "name"
-	if this.ActivityStreamsName != nil {
+	if this.ActivityStreamsName != nil && !omit[this.ActivityStreamsName.Name()] {
 		if i, err := this.ActivityStreamsName.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -588,7 +694,7 @@ func (this ActivityStreamsMention) Serialize() (map[string]interface{}, error) {
 		}
 	}
 	// Maybe serialize property "preview"
-	if this.ActivityStreamsPreview != nil {
+	if this.ActivityStreamsPreview != nil && !omit[this.ActivityStreamsPreview.Name()] {
 		if i, err := this.ActivityStreamsPreview.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -596,7 +702,7 @@ func (this ActivityStreamsMention) Serialize() (map[string]interface{}, error) {
 		}
 	}
 	// Maybe serialize property "rel"
-	if this.ActivityStreamsRel != nil {
+	if this.ActivityStreamsRel != nil && !omit[this.ActivityStreamsRel.Name()] {
 		if i, err := this.ActivityStreamsRel.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -604,7 +710,7 @@ func (this ActivityStreamsMention) Serialize() (map[string]interface{}, error) {
 		}
 	}
 	// Maybe serialize property "summary"
-	if this.ActivityStreamsSummary != nil {
+	if this.ActivityStreamsSummary != nil && !omit[this.ActivityStreamsSummary.Name()] {
 		if i, err := this.ActivityStreamsSummary.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -612,7 +718,7 @@ func (this ActivityStreamsMention) Serialize() (map[string]interface{}, error) {
 		}
 	}
 	// Maybe serialize property "type"
-	if this.JSONLDType != nil {
+	if this.JSONLDType != nil && !omit[this.JSONLDType.Name()] {
 		if i, err := this.JSONLDType.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -620,7 +726,7 @@ func (this ActivityStreamsMention) Serialize() (map[string]interface{}, error) {
 		}
 	}
 	// Maybe serialize property "width"
-	if this.ActivityStreamsWidth != nil {
+	if this.ActivityStreamsWidth != nil && !omit[this.ActivityStreamsWidth.Name()] {
 		if i, err := this.ActivityStreamsWidth.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -632,7 +738,7 @@ func (this ActivityStreamsMention) Serialize() (map[string]interface{}, error) {
 	// Begin: Serialize unknown properties
 	for k, v := range this.unknown {
 		// To be safe, ensure we aren't overwriting a known property
-		if _, has := m[k]; !has {
+		if _, has := m[k]; !has && !omit[k] {
 			m[k] = v
 		}
 	}
@@ -75,6 +75,21 @@ func (this *ForgeFedAssignedToProperty) Clear() {
 	this.activitystreamsPersonMember = nil
 }
 
+// Clone returns a deep copy of this property. Modifying the copy does not modify
+// this property.
+func (this ForgeFedAssignedToProperty) Clone() vocab.ForgeFedAssignedToProperty {
+	c := &ForgeFedAssignedToProperty{alias: this.alias}
+	if this.activitystreamsPersonMember != nil {
+		c.activitystreamsPersonMember = this.activitystreamsPersonMember.Clone().(vocab.ActivityStreamsPerson)
+	}
+	c.unknown = cloneUnknown(this.unknown)
+	if this.iri != nil {
+		u := *this.iri
+		c.iri = &u
+	}
+	return c
+}
+
 // Get returns the value of this property. When IsActivityStreamsPerson returns
 // false, Get will return any arbitrary value.
 func (this ForgeFedAssignedToProperty) Get() vocab.ActivityStreamsPerson {
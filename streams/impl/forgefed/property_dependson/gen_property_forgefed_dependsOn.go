@@ -220,6 +220,20 @@ func (this *ForgeFedDependsOnPropertyIterator) clear() {
 	this.forgefedTicketMember = nil
 }
 
+// clone returns a deep copy of this iterator, which has no parent property.
+func (this ForgeFedDependsOnPropertyIterator) clone() *ForgeFedDependsOnPropertyIterator {
+	c := &ForgeFedDependsOnPropertyIterator{alias: this.alias}
+	if this.forgefedTicketMember != nil {
+		c.forgefedTicketMember = this.forgefedTicketMember.Clone().(vocab.ForgeFedTicket)
+	}
+	c.unknown = cloneUnknown(this.unknown)
+	if this.iri != nil {
+		u := *this.iri
+		c.iri = &u
+	}
+	return c
+}
+
 // serialize converts this into an interface representation suitable for
 // marshalling into a text or binary format. Applications should not need this
 // function as most typical use cases serialize types instead of individual
@@ -342,6 +356,22 @@ func (this ForgeFedDependsOnProperty) Begin() vocab.ForgeFedDependsOnPropertyIte
 	}
 }
 
+// Clone returns a deep copy of this property and each of its values. Modifying
+// the copy does not modify this property.
+func (this ForgeFedDependsOnProperty) Clone() vocab.ForgeFedDependsOnProperty {
+	c := &ForgeFedDependsOnProperty{alias: this.alias}
+	if this.properties != nil {
+		c.properties = make([]*ForgeFedDependsOnPropertyIterator, len(this.properties))
+	}
+	for i, it := range this.properties {
+		cit := it.clone()
+		cit.parent = c
+		cit.myIdx = i
+		c.properties[i] = cit
+	}
+	return c
+}
+
 // Empty returns returns true if there are no elements.
 func (this ForgeFedDependsOnProperty) Empty() bool {
 	return this.Len() == 0
@@ -572,6 +602,21 @@ func (this ForgeFedDependsOnProperty) Serialize() (interface{}, error) {
 	return s, nil
 }
 
+// SerializeEach serializes each value of this property in order, calling fn with
+// each, and stops at the first error. Unlike Serialize, it never holds the
+// serialized values all at once, so large properties can be encoded as a
+// stream.
+func (this ForgeFedDependsOnProperty) SerializeEach(fn func(interface{}) error) error {
+	for _, iterator := range this.properties {
+		if b, err := iterator.serialize(); err != nil {
+			return err
+		} else if err := fn(b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Set sets a Ticket value to be at the specified index for the property
 // "dependsOn". Panics if the index is out of bounds. Invalidates all
 // iterators.
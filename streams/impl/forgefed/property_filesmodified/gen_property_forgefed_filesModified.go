@@ -200,6 +200,19 @@ func (this *ForgeFedFilesModifiedPropertyIterator) clear() {
 	this.hasStringMember = false
 }
 
+// clone returns a deep copy of this iterator, which has no parent property.
+func (this ForgeFedFilesModifiedPropertyIterator) clone() *ForgeFedFilesModifiedPropertyIterator {
+	c := &ForgeFedFilesModifiedPropertyIterator{alias: this.alias}
+	c.xmlschemaStringMember = this.xmlschemaStringMember
+	c.hasStringMember = this.hasStringMember
+	c.unknown = cloneUnknown(this.unknown)
+	if this.iri != nil {
+		u := *this.iri
+		c.iri = &u
+	}
+	return c
+}
+
 // serialize converts this into an interface representation suitable for
 // marshalling into a text or binary format. Applications should not need this
 // function as most typical use cases serialize types instead of individual
@@ -310,6 +323,22 @@ func (this ForgeFedFilesModifiedProperty) Begin() vocab.ForgeFedFilesModifiedPro
 	}
 }
 
+// Clone returns a deep copy of this property and each of its values. Modifying
+// the copy does not modify this property.
+func (this ForgeFedFilesModifiedProperty) Clone() vocab.ForgeFedFilesModifiedProperty {
+	c := &ForgeFedFilesModifiedProperty{alias: this.alias}
+	if this.properties != nil {
+		c.properties = make([]*ForgeFedFilesModifiedPropertyIterator, len(this.properties))
+	}
+	for i, it := range this.properties {
+		cit := it.clone()
+		cit.parent = c
+		cit.myIdx = i
+		c.properties[i] = cit
+	}
+	return c
+}
+
 // Empty returns returns true if there are no elements.
 func (this ForgeFedFilesModifiedProperty) Empty() bool {
 	return this.Len() == 0
@@ -502,6 +531,21 @@ func (this ForgeFedFilesModifiedProperty) Serialize() (interface{}, error) {
 	return s, nil
 }
 
+// SerializeEach serializes each value of this property in order, calling fn with
+// each, and stops at the first error. Unlike Serialize, it never holds the
+// serialized values all at once, so large properties can be encoded as a
+// stream.
+func (this ForgeFedFilesModifiedProperty) SerializeEach(fn func(interface{}) error) error {
+	for _, iterator := range this.properties {
+		if b, err := iterator.serialize(); err != nil {
+			return err
+		} else if err := fn(b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Set sets a string value to be at the specified index for the property
 // "filesModified". Panics if the index is out of bounds. Invalidates all
 // iterators.
@@ -209,6 +209,19 @@ func (this *JSONLDTypePropertyIterator) clear() {
 	this.unknown = nil
 }
 
+// clone returns a deep copy of this iterator, which has no parent property.
+func (this JSONLDTypePropertyIterator) clone() *JSONLDTypePropertyIterator {
+	c := &JSONLDTypePropertyIterator{alias: this.alias}
+	if this.xmlschemaAnyURIMember != nil {
+		u := *this.xmlschemaAnyURIMember
+		c.xmlschemaAnyURIMember = &u
+	}
+	c.xmlschemaStringMember = this.xmlschemaStringMember
+	c.hasStringMember = this.hasStringMember
+	c.unknown = cloneUnknown(this.unknown)
+	return c
+}
+
 // serialize converts this into an interface representation suitable for
 // marshalling into a text or binary format. Applications should not need this
 // function as most typical use cases serialize types instead of individual
@@ -325,6 +338,22 @@ func (this JSONLDTypeProperty) Begin() vocab.JSONLDTypePropertyIterator {
 	}
 }
 
+// Clone returns a deep copy of this property and each of its values. Modifying
+// the copy does not modify this property.
+func (this JSONLDTypeProperty) Clone() vocab.JSONLDTypeProperty {
+	c := &JSONLDTypeProperty{alias: this.alias}
+	if this.properties != nil {
+		c.properties = make([]*JSONLDTypePropertyIterator, len(this.properties))
+	}
+	for i, it := range this.properties {
+		cit := it.clone()
+		cit.parent = c
+		cit.myIdx = i
+		c.properties[i] = cit
+	}
+	return c
+}
+
 // Empty returns returns true if there are no elements.
 func (this JSONLDTypeProperty) Empty() bool {
 	return this.Len() == 0
@@ -551,6 +580,21 @@ func (this JSONLDTypeProperty) Serialize() (interface{}, error) {
 	return s, nil
 }
 
+// SerializeEach serializes each value of this property in order, calling fn with
+// each, and stops at the first error. Unlike Serialize, it never holds the
+// serialized values all at once, so large properties can be encoded as a
+// stream.
+func (this JSONLDTypeProperty) SerializeEach(fn func(interface{}) error) error {
+	for _, iterator := range this.properties {
+		if b, err := iterator.serialize(); err != nil {
+			return err
+		} else if err := fn(b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // SetIRI sets an IRI value to be at the specified index for the property "type".
 // Panics if the index is out of bounds.
 func (this *JSONLDTypeProperty) SetIRI(idx int, v *url.URL) {
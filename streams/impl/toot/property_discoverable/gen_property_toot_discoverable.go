@@ -77,6 +77,20 @@ func (this *TootDiscoverableProperty) Clear() {
 	this.hasBooleanMember = false
 }
 
+// Clone returns a deep copy of this property. Modifying the copy does not modify
+// this property.
+func (this TootDiscoverableProperty) Clone() vocab.TootDiscoverableProperty {
+	c := &TootDiscoverableProperty{alias: this.alias}
+	c.xmlschemaBooleanMember = this.xmlschemaBooleanMember
+	c.hasBooleanMember = this.hasBooleanMember
+	c.unknown = cloneUnknown(this.unknown)
+	if this.iri != nil {
+		u := *this.iri
+		c.iri = &u
+	}
+	return c
+}
+
 // Get returns the value of this property. When IsXMLSchemaBoolean returns false,
 // Get will return any arbitrary value.
 func (this TootDiscoverableProperty) Get() bool {
@@ -60,6 +60,18 @@ func (this *W3IDSecurityV1OwnerProperty) Clear() {
 	this.xmlschemaAnyURIMember = nil
 }
 
+// Clone returns a deep copy of this property. Modifying the copy does not modify
+// this property.
+func (this W3IDSecurityV1OwnerProperty) Clone() vocab.W3IDSecurityV1OwnerProperty {
+	c := &W3IDSecurityV1OwnerProperty{alias: this.alias}
+	if this.xmlschemaAnyURIMember != nil {
+		u := *this.xmlschemaAnyURIMember
+		c.xmlschemaAnyURIMember = &u
+	}
+	c.unknown = cloneUnknown(this.unknown)
+	return c
+}
+
 // Get returns the value of this property. When IsXMLSchemaAnyURI returns false,
 // Get will return any arbitrary value.
 func (this W3IDSecurityV1OwnerProperty) Get() *url.URL {
@@ -221,6 +221,20 @@ func (this *W3IDSecurityV1PublicKeyPropertyIterator) clear() {
 	this.w3idsecurityv1PublicKeyMember = nil
 }
 
+// clone returns a deep copy of this iterator, which has no parent property.
+func (this W3IDSecurityV1PublicKeyPropertyIterator) clone() *W3IDSecurityV1PublicKeyPropertyIterator {
+	c := &W3IDSecurityV1PublicKeyPropertyIterator{alias: this.alias}
+	if this.w3idsecurityv1PublicKeyMember != nil {
+		c.w3idsecurityv1PublicKeyMember = this.w3idsecurityv1PublicKeyMember.Clone().(vocab.W3IDSecurityV1PublicKey)
+	}
+	c.unknown = cloneUnknown(this.unknown)
+	if this.iri != nil {
+		u := *this.iri
+		c.iri = &u
+	}
+	return c
+}
+
 // serialize converts this into an interface representation suitable for
 // marshalling into a text or binary format. Applications should not need this
 // function as most typical use cases serialize types instead of individual
@@ -344,6 +358,22 @@ func (this W3IDSecurityV1PublicKeyProperty) Begin() vocab.W3IDSecurityV1PublicKe
 	}
 }
 
+// Clone returns a deep copy of this property and each of its values. Modifying
+// the copy does not modify this property.
+func (this W3IDSecurityV1PublicKeyProperty) Clone() vocab.W3IDSecurityV1PublicKeyProperty {
+	c := &W3IDSecurityV1PublicKeyProperty{alias: this.alias}
+	if this.properties != nil {
+		c.properties = make([]*W3IDSecurityV1PublicKeyPropertyIterator, len(this.properties))
+	}
+	for i, it := range this.properties {
+		cit := it.clone()
+		cit.parent = c
+		cit.myIdx = i
+		c.properties[i] = cit
+	}
+	return c
+}
+
 // Empty returns returns true if there are no elements.
 func (this W3IDSecurityV1PublicKeyProperty) Empty() bool {
 	return this.Len() == 0
@@ -574,6 +604,21 @@ func (this W3IDSecurityV1PublicKeyProperty) Serialize() (interface{}, error) {
 	return s, nil
 }
 
+// SerializeEach serializes each value of this property in order, calling fn with
+// each, and stops at the first error. Unlike Serialize, it never holds the
+// serialized values all at once, so large properties can be encoded as a
+// stream.
+func (this W3IDSecurityV1PublicKeyProperty) SerializeEach(fn func(interface{}) error) error {
+	for _, iterator := range this.properties {
+		if b, err := iterator.serialize(); err != nil {
+			return err
+		} else if err := fn(b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Set sets a PublicKey value to be at the specified index for the property
 // "publicKey". Panics if the index is out of bounds. Invalidates all
 // iterators.
@@ -77,6 +77,20 @@ func (this *W3IDSecurityV1PublicKeyPemProperty) Clear() {
 	this.hasStringMember = false
 }
 
+// Clone returns a deep copy of this property. Modifying the copy does not modify
+// this property.
+func (this W3IDSecurityV1PublicKeyPemProperty) Clone() vocab.W3IDSecurityV1PublicKeyPemProperty {
+	c := &W3IDSecurityV1PublicKeyPemProperty{alias: this.alias}
+	c.xmlschemaStringMember = this.xmlschemaStringMember
+	c.hasStringMember = this.hasStringMember
+	c.unknown = cloneUnknown(this.unknown)
+	if this.iri != nil {
+		u := *this.iri
+		c.iri = &u
+	}
+	return c
+}
+
 // Get returns the value of this property. When IsXMLSchemaString returns false,
 // Get will return any arbitrary value.
 func (this W3IDSecurityV1PublicKeyPemProperty) Get() string {
@@ -44,13 +44,18 @@ func DeserializePublicKey(m map[string]interface{}, aliasMap map[string]string)
 	// End: Known property deserialization
 
 	// Begin: Unknown deserialization
+	// Known properties are prefixed with the alias of their vocabulary, if any.
+	w3idsecurityv1Prefix := ""
+	if a, ok := aliasMap["https://w3id.org/security/v1"]; ok && len(a) > 0 {
+		w3idsecurityv1Prefix = a + ":"
+	}
 	for k, v := range m {
 		// Begin: Code that ensures a property name is unknown
 		if k == "id" {
 			continue
-		} else if k == "owner" {
+		} else if k == w3idsecurityv1Prefix+"owner" {
 			continue
-		} else if k == "publicKeyPem" {
+		} else if k == w3idsecurityv1Prefix+"publicKeyPem" {
 			continue
 		} // End: Code that ensures a property name is unknown
 
@@ -100,6 +105,39 @@ func W3IDSecurityV1PublicKeyExtends(other vocab.Type) bool {
 	return false
 }
 
+// Clone returns a deep copy of this type. Modifying the copy does not modify this
+// type.
+func (this W3IDSecurityV1PublicKey) Clone() vocab.Type {
+	c := &W3IDSecurityV1PublicKey{alias: this.alias}
+	if this.JSONLDId != nil {
+		c.JSONLDId = this.JSONLDId.Clone()
+	}
+	if this.W3IDSecurityV1Owner != nil {
+		c.W3IDSecurityV1Owner = this.W3IDSecurityV1Owner.Clone()
+	}
+	if this.W3IDSecurityV1PublicKeyPem != nil {
+		c.W3IDSecurityV1PublicKeyPem = this.W3IDSecurityV1PublicKeyPem.Clone()
+	}
+	if this.unknown != nil {
+		c.unknown = cloneUnknown(this.unknown).(map[string]interface{})
+	}
+	return c
+}
+
+// EachProperty calls fn with the name and value of each property that is set, in
+// a stable order. Unknown properties are not included.
+func (this W3IDSecurityV1PublicKey) EachProperty(fn func(name string, value vocab.PropertyInterface)) {
+	if this.JSONLDId != nil {
+		fn(this.JSONLDId.Name(), this.JSONLDId)
+	}
+	if this.W3IDSecurityV1Owner != nil {
+		fn(this.W3IDSecurityV1Owner.Name(), this.W3IDSecurityV1Owner)
+	}
+	if this.W3IDSecurityV1PublicKeyPem != nil {
+		fn(this.W3IDSecurityV1PublicKeyPem.Name(), this.W3IDSecurityV1PublicKeyPem)
+	}
+}
+
 // GetJSONLDId returns the "id" property if it exists, and nil otherwise.
 func (this W3IDSecurityV1PublicKey) GetJSONLDId() vocab.JSONLDIdProperty {
 	return this.JSONLDId
@@ -211,10 +249,24 @@ func (this W3IDSecurityV1PublicKey) LessThan(o vocab.W3IDSecurityV1PublicKey) bo
 // Serialize converts this into an interface representation suitable for
 // marshalling into a text or binary format.
 func (this W3IDSecurityV1PublicKey) Serialize() (map[string]interface{}, error) {
+	return this.SerializeWithout()
+}
+
+// SerializeWithout is like Serialize, but leaves out the known properties with
+// the names, as well as unknown properties with the same names, so that they
+// can be serialized separately.
+func (this W3IDSecurityV1PublicKey) SerializeWithout(names ...string) (map[string]interface{}, error) {
+	var omit map[string]bool
+	if len(names) > 0 {
+		omit = make(map[string]bool, len(names))
+		for _, name := range names {
+			omit[name] = true
+		}
+	}
 	m := make(map[string]interface{})
 	// Begin: Serialize known properties
 	// Maybe serialize property "id"
-	if this.JSONLDId != nil {
+	if this.JSONLDId != nil && !omit[this.JSONLDId.Name()] {
 		if i, err := this.JSONLDId.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -222,7 +274,7 @@ func (this W3IDSecurityV1PublicKey) Serialize() (map[string]interface{}, error)
 		}
 	}
 	// Maybe serialize property "owner"
-	if this.W3IDSecurityV1Owner != nil {
+	if this.W3IDSecurityV1Owner != nil && !omit[this.W3IDSecurityV1Owner.Name()] {
 		if i, err := this.W3IDSecurityV1Owner.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -230,7 +282,7 @@ func (this W3IDSecurityV1PublicKey) Serialize() (map[string]interface{}, error)
 		}
 	}
 	// Maybe serialize property "publicKeyPem"
-	if this.W3IDSecurityV1PublicKeyPem != nil {
+	if this.W3IDSecurityV1PublicKeyPem != nil && !omit[this.W3IDSecurityV1PublicKeyPem.Name()] {
 		if i, err := this.W3IDSecurityV1PublicKeyPem.Serialize(); err != nil {
 			return nil, err
 		} else if i != nil {
@@ -242,7 +294,7 @@ func (this W3IDSecurityV1PublicKey) Serialize() (map[string]interface{}, error)
 	// Begin: Serialize unknown properties
 	for k, v := range this.unknown {
 		// To be safe, ensure we aren't overwriting a known property
-		if _, has := m[k]; !has {
+		if _, has := m[k]; !has && !omit[k] {
 			m[k] = v
 		}
 	}
@@ -5971,3 +5971,51 @@ func serviceHasAttachmentWithUnknownType() vocab.ActivityStreamsService {
 	serviceType.SetActivityStreamsAttachment(attachmentProp)
 	return serviceType
 }
+
+const personHasPropertyValueAttachments = `{
+  "@context": [
+    "http://schema.org",
+    "https://www.w3.org/ns/activitystreams"
+  ],
+  "id": "https://example.com/person",
+  "type": "Person",
+  "attachment": [
+    {
+      "type": "PropertyValue",
+      "name": "Website",
+      "value": "<a href=\"https://example.com\">example.com</a>"
+    },
+    {
+      "type": "PropertyValue",
+      "name": "Pronouns",
+      "value": "they/them"
+    }
+  ]
+}`
+
+func personHasPropertyValueAttachmentsType() vocab.ActivityStreamsPerson {
+	personType := NewActivityStreamsPerson()
+	idProp := NewJSONLDIdProperty()
+	idIRI := MustParseURL("https://example.com/person")
+	idProp.Set(idIRI)
+	personType.SetJSONLDId(idProp)
+	attachmentProp := NewActivityStreamsAttachmentProperty()
+	website := NewSchemaPropertyValue()
+	websiteNameProp := NewActivityStreamsNameProperty()
+	websiteNameProp.AppendXMLSchemaString("Website")
+	website.SetActivityStreamsName(websiteNameProp)
+	websiteValueProp := NewSchemaValueProperty()
+	websiteValueProp.Set("<a href=\"https://example.com\">example.com</a>")
+	website.SetSchemaValue(websiteValueProp)
+	attachmentProp.AppendSchemaPropertyValue(website)
+	pronouns := NewSchemaPropertyValue()
+	pronounsNameProp := NewActivityStreamsNameProperty()
+	pronounsNameProp.AppendXMLSchemaString("Pronouns")
+	pronouns.SetActivityStreamsName(pronounsNameProp)
+	pronounsValueProp := NewSchemaValueProperty()
+	pronounsValueProp.Set("they/them")
+	pronouns.SetSchemaValue(pronounsValueProp)
+	attachmentProp.AppendSchemaPropertyValue(pronouns)
+	personType.SetActivityStreamsAttachment(attachmentProp)
+	return personType
+}
@@ -1,13 +1,20 @@
 package streams
 
 import (
+	"bufio"
+	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
+	"github.com/go-fed/activity/streams/values/boolean"
+	"github.com/go-fed/activity/streams/values/dateTime"
 	"github.com/go-fed/activity/streams/vocab"
 	"github.com/go-test/deep"
 	"net/url"
 	"sort"
+	"strings"
 	"testing"
+	"time"
 )
 
 // IsKnownResolverError returns true if it is known that an example from
@@ -522,3 +529,1482 @@ func GetJSONDiff(str1, str2 []byte) ([]string, error) {
 	}
 	return deep.Equal(i1, i2), nil
 }
+
+func TestSerializeForcingArrays(t *testing.T) {
+	makeIRI := func(path string) *url.URL {
+		return &url.URL{
+			Scheme: "https",
+			Host:   "example.com",
+			Path:   path,
+		}
+	}
+	note := NewActivityStreamsNote()
+	noteTo := NewActivityStreamsToProperty()
+	noteTo.AppendIRI(makeIRI("/sam"))
+	note.SetActivityStreamsTo(noteTo)
+	create := NewActivityStreamsCreate()
+	to := NewActivityStreamsToProperty()
+	to.AppendIRI(makeIRI("/sam"))
+	create.SetActivityStreamsTo(to)
+	cc := NewActivityStreamsCcProperty()
+	cc.AppendIRI(makeIRI("/sally"))
+	cc.AppendIRI(makeIRI("/jessie"))
+	create.SetActivityStreamsCc(cc)
+	actor := NewActivityStreamsActorProperty()
+	actor.AppendIRI(makeIRI("/sally"))
+	create.SetActivityStreamsActor(actor)
+	obj := NewActivityStreamsObjectProperty()
+	obj.AppendActivityStreamsNote(note)
+	create.SetActivityStreamsObject(obj)
+
+	m, err := SerializeForcingArrays(create, ArrayProperties...)
+	if err != nil {
+		t.Fatalf("Serialize: %s", err)
+	}
+	expected := map[string]interface{}{
+		"@context": "https://www.w3.org/ns/activitystreams",
+		"type":     "Create",
+		"actor":    "https://example.com/sally",
+		"to":       []interface{}{"https://example.com/sam"},
+		"cc":       []interface{}{"https://example.com/sally", "https://example.com/jessie"},
+		"object": map[string]interface{}{
+			"type": "Note",
+			"to":   []interface{}{"https://example.com/sam"},
+		},
+	}
+	if diff := deep.Equal(m, expected); diff != nil {
+		t.Fatalf("Serialize: %v", diff)
+	}
+
+	// Ensure the arrays round-trip back to the same value.
+	var actual vocab.ActivityStreamsCreate
+	r, err := NewJSONResolver(func(c context.Context, v vocab.ActivityStreamsCreate) error {
+		actual = v
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("NewJSONResolver: %s", err)
+	}
+	if err := r.Resolve(context.Background(), m); err != nil {
+		t.Fatalf("Resolve: %s", err)
+	}
+	plain, err := Serialize(actual)
+	if err != nil {
+		t.Fatalf("Serialize: %s", err)
+	}
+	expectedPlain, err := Serialize(create)
+	if err != nil {
+		t.Fatalf("Serialize: %s", err)
+	}
+	if diff := deep.Equal(plain, expectedPlain); diff != nil {
+		t.Fatalf("Round trip: %v", diff)
+	}
+}
+
+func TestSerializeTo(t *testing.T) {
+	for _, example := range GetTestTable() {
+		example := example // shadow loop variable
+		t.Run(example.name, func(t *testing.T) {
+			if skip, reason := IsKnownResolverError(example); skip {
+				t.Skipf("it is known an error will be returned because %q", reason)
+				return
+			}
+			var m map[string]interface{}
+			if err := json.Unmarshal([]byte(example.expectedJSON), &m); err != nil {
+				t.Fatalf("Cannot json.Unmarshal: %v", err)
+			}
+			a, err := ToType(context.Background(), m)
+			if err != nil {
+				t.Fatalf("Cannot ToType: %v", err)
+			}
+			assertSerializeToMatchesMarshal(t, a)
+		})
+	}
+	t.Run("large ordered collection", func(t *testing.T) {
+		oc := NewActivityStreamsOrderedCollection()
+		items := NewActivityStreamsOrderedItemsProperty()
+		for i := 0; i < 1000; i++ {
+			note := NewActivityStreamsNote()
+			content := NewActivityStreamsContentProperty()
+			content.AppendXMLSchemaString(fmt.Sprintf("<p>note %d & \"quotes\"  </p>", i))
+			note.SetActivityStreamsContent(content)
+			items.AppendActivityStreamsNote(note)
+		}
+		oc.SetActivityStreamsOrderedItems(items)
+		assertSerializeToMatchesMarshal(t, oc)
+	})
+	t.Run("empty and single valued properties", func(t *testing.T) {
+		oc := NewActivityStreamsOrderedCollection()
+		oc.SetActivityStreamsOrderedItems(NewActivityStreamsOrderedItemsProperty())
+		to := NewActivityStreamsToProperty()
+		to.AppendIRI(MustParseURL("https://example.com/users/bob"))
+		oc.SetActivityStreamsTo(to)
+		assertSerializeToMatchesMarshal(t, oc)
+	})
+	t.Run("terms of streamed values", func(t *testing.T) {
+		oc := NewActivityStreamsOrderedCollection()
+		items := NewActivityStreamsOrderedItemsProperty()
+		for i := 0; i < 2; i++ {
+			tag := NewActivityStreamsTagProperty()
+			tag.AppendActivityStreamsHashtag(NewActivityStreamsHashtag())
+			note := NewActivityStreamsNote()
+			note.SetActivityStreamsTag(tag)
+			items.AppendActivityStreamsNote(note)
+		}
+		oc.SetActivityStreamsOrderedItems(items)
+		var buf bytes.Buffer
+		if err := SerializeTo(&buf, oc); err != nil {
+			t.Fatalf("SerializeTo: %v", err)
+		} else if !strings.Contains(buf.String(), `"Hashtag":"as:Hashtag"`) {
+			t.Fatalf("expected the @context to define Hashtag: %s", buf.String())
+		}
+		assertSerializeToMatchesMarshal(t, oc)
+	})
+}
+
+// assertSerializeToMatchesMarshal ensures SerializeTo writes the same bytes as
+// marshalling the result of Serialize.
+func assertSerializeToMatchesMarshal(t *testing.T, a vocab.Type) {
+	m, err := Serialize(a)
+	if err != nil {
+		t.Fatalf("Serialize: %v", err)
+	}
+	expected, err := json.Marshal(m)
+	if err != nil {
+		t.Fatalf("json.Marshal: %v", err)
+	}
+	var buf bytes.Buffer
+	bw := bufio.NewWriter(&buf)
+	if err := encodeTo(bw, m); err != nil {
+		t.Fatalf("encodeTo: %v", err)
+	} else if err := bw.Flush(); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+	if !bytes.Equal(buf.Bytes(), expected) {
+		t.Fatalf("encodeTo output differs from json.Marshal:\n%s\n%s", buf.Bytes(), expected)
+	}
+	buf.Reset()
+	if err := SerializeTo(&buf, a); err != nil {
+		t.Fatalf("SerializeTo: %v", err)
+	}
+	if !bytes.Equal(buf.Bytes(), expected) {
+		t.Fatalf("SerializeTo output differs from Serialize:\n%s\n%s", buf.Bytes(), expected)
+	}
+}
+
+func TestValidateNoCycles(t *testing.T) {
+	tests := []struct {
+		name     string
+		json     string
+		maxNodes int
+		wantErr  bool
+	}{
+		{
+			name:    "no nested objects",
+			json:    `{"@context":"https://www.w3.org/ns/activitystreams","type":"Note","id":"https://example.com/note/1","inReplyTo":"https://example.com/note/1"}`,
+			wantErr: false,
+		},
+		{
+			name:    "object inlined in its own inReplyTo",
+			json:    `{"@context":"https://www.w3.org/ns/activitystreams","type":"Note","id":"https://example.com/note/1","inReplyTo":{"type":"Note","id":"https://example.com/note/1"}}`,
+			wantErr: true,
+		},
+		{
+			name:    "activity inlined deep within its object",
+			json:    `{"@context":"https://www.w3.org/ns/activitystreams","type":"Create","id":"https://example.com/create/1","object":{"type":"Note","id":"https://example.com/note/1","inReplyTo":[{"type":"Note","id":"https://example.com/note/2","attachment":{"type":"Create","id":"https://example.com/create/1"}}]}}`,
+			wantErr: true,
+		},
+		{
+			name:    "object inlined within an unknown property",
+			json:    `{"@context":"https://www.w3.org/ns/activitystreams","type":"Note","id":"https://example.com/note/1","quote":{"type":"Note","id":"https://example.com/note/1"}}`,
+			wantErr: true,
+		},
+		{
+			name:    "same object inlined in sibling properties",
+			json:    `{"@context":"https://www.w3.org/ns/activitystreams","type":"Create","id":"https://example.com/create/1","actor":{"type":"Person","id":"https://example.com/sally"},"object":{"type":"Note","id":"https://example.com/note/1","attributedTo":{"type":"Person","id":"https://example.com/sally"}}}`,
+			wantErr: false,
+		},
+		{
+			name:     "within the node bound",
+			json:     `{"@context":"https://www.w3.org/ns/activitystreams","type":"Create","id":"https://example.com/create/1","object":[{"type":"Note"},{"type":"Note"}]}`,
+			maxNodes: 3,
+			wantErr:  false,
+		},
+		{
+			name:     "exceeds the node bound",
+			json:     `{"@context":"https://www.w3.org/ns/activitystreams","type":"Create","id":"https://example.com/create/1","object":[{"type":"Note"},{"type":"Note"},{"type":"Note"}]}`,
+			maxNodes: 3,
+			wantErr:  true,
+		},
+	}
+	for _, test := range tests {
+		test := test // shadow loop variable
+		t.Run(test.name, func(t *testing.T) {
+			var m map[string]interface{}
+			if err := json.Unmarshal([]byte(test.json), &m); err != nil {
+				t.Fatalf("Cannot json.Unmarshal: %v", err)
+			}
+			a, err := ToType(context.Background(), m)
+			if err != nil {
+				t.Fatalf("Cannot ToType: %v", err)
+			}
+			err = ValidateNoCycles(a, test.maxNodes)
+			if test.wantErr && err == nil {
+				t.Fatalf("expected an error")
+			} else if !test.wantErr && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestContextLoader(t *testing.T) {
+	ctx := context.Background()
+	t.Run("loads bundled contexts", func(t *testing.T) {
+		l := NewContextLoader()
+		for _, u := range []string{
+			ActivityStreamsContextURL,
+			ActivityStreamsContextURL + "/",
+			ActivityStreamsContextURL + "#",
+			SecurityV1ContextURL,
+			IdentityV1ContextURL,
+		} {
+			doc, err := l.Load(ctx, u)
+			if err != nil {
+				t.Fatalf("unexpected error loading %s: %v", u, err)
+			}
+			if _, ok := doc["@context"].(map[string]interface{}); !ok {
+				t.Fatalf("%s has no @context object", u)
+			}
+		}
+	})
+	t.Run("refuses unregistered contexts by default", func(t *testing.T) {
+		l := NewContextLoader()
+		if _, err := l.Load(ctx, "https://example.com/context"); err == nil {
+			t.Fatalf("expected an error")
+		}
+	})
+	t.Run("loads registered contexts", func(t *testing.T) {
+		l := NewContextLoader()
+		if err := l.Register("https://example.com/context", []byte(`{"@context":{"foo":"https://example.com/ns#foo"}}`)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		doc, err := l.Load(ctx, "https://example.com/context")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if diff := deep.Equal(doc, map[string]interface{}{
+			"@context": map[string]interface{}{"foo": "https://example.com/ns#foo"},
+		}); diff != nil {
+			t.Fatal(diff)
+		}
+		doc["@context"] = nil
+		if doc, err = l.Load(ctx, "https://example.com/context"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		} else if doc["@context"] == nil {
+			t.Fatalf("modifying a loaded document modified the registered one")
+		}
+	})
+	t.Run("rejects registering invalid documents", func(t *testing.T) {
+		l := NewContextLoader()
+		if err := l.Register("https://example.com/context", []byte(`["not an object"]`)); err == nil {
+			t.Fatalf("expected an error")
+		}
+	})
+	t.Run("fetches remote contexts once when allowed", func(t *testing.T) {
+		l := NewContextLoader()
+		var fetched []string
+		l.AllowRemote(func(c context.Context, u *url.URL) ([]byte, error) {
+			fetched = append(fetched, u.String())
+			return []byte(`{"@context":{}}`), nil
+		})
+		for i := 0; i < 2; i++ {
+			if _, err := l.Load(ctx, "https://example.com/context"); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+		if _, err := l.Load(ctx, ActivityStreamsContextURL); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if diff := deep.Equal(fetched, []string{"https://example.com/context"}); diff != nil {
+			t.Fatal(diff)
+		}
+	})
+	t.Run("returns fetch errors", func(t *testing.T) {
+		l := NewContextLoader()
+		l.AllowRemote(func(c context.Context, u *url.URL) ([]byte, error) {
+			return nil, fmt.Errorf("fetch failed")
+		})
+		if _, err := l.Load(ctx, "https://example.com/context"); err == nil {
+			t.Fatalf("expected an error")
+		}
+	})
+}
+
+func TestPropertyValueAttachmentsFromMastodon(t *testing.T) {
+	const js = `{
+  "@context": [
+    "https://www.w3.org/ns/activitystreams",
+    {
+      "schema": "http://schema.org#",
+      "PropertyValue": "schema:PropertyValue",
+      "value": "schema:value"
+    }
+  ],
+  "id": "https://example.com/users/alice",
+  "type": "Person",
+  "attachment": [
+    {
+      "type": "PropertyValue",
+      "name": "Website",
+      "value": "<a href=\"https://example.com\">example.com</a>"
+    }
+  ]
+}`
+	var m map[string]interface{}
+	if err := json.Unmarshal([]byte(js), &m); err != nil {
+		t.Fatalf("Cannot json.Unmarshal: %v", err)
+	}
+	a, err := ToType(context.Background(), m)
+	if err != nil {
+		t.Fatalf("Cannot ToType: %v", err)
+	}
+	p, ok := a.(vocab.ActivityStreamsPerson)
+	if !ok {
+		t.Fatalf("expected a Person, got %T", a)
+	}
+	iter := p.GetActivityStreamsAttachment().Begin()
+	if iter == nil || !iter.IsSchemaPropertyValue() {
+		t.Fatalf("expected a PropertyValue attachment")
+	}
+	pv := iter.GetSchemaPropertyValue()
+	if v := pv.GetSchemaValue().Get(); v != `<a href="https://example.com">example.com</a>` {
+		t.Fatalf("unexpected value: %s", v)
+	}
+	out, err := Serialize(p)
+	if err != nil {
+		t.Fatalf("Cannot Serialize: %v", err)
+	}
+	attachment, ok := out["attachment"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected a single attachment, got %v", out["attachment"])
+	}
+	if diff := deep.Equal(attachment, map[string]interface{}{
+		"type":  "PropertyValue",
+		"name":  "Website",
+		"value": `<a href="https://example.com">example.com</a>`,
+	}); diff != nil {
+		t.Fatal(diff)
+	}
+}
+
+func TestImageFocalPointAndBlurhashFromMastodon(t *testing.T) {
+	const js = `{
+  "@context": [
+    "https://www.w3.org/ns/activitystreams",
+    {
+      "toot": "http://joinmastodon.org/ns#",
+      "blurhash": "toot:blurhash",
+      "focalPoint": {
+        "@container": "@list",
+        "@id": "toot:focalPoint"
+      }
+    }
+  ],
+  "id": "https://example.com/notes/1",
+  "type": "Note",
+  "attachment": [
+    {
+      "type": "Image",
+      "mediaType": "image/png",
+      "url": "https://example.com/media/1.png",
+      "blurhash": "UBL_:rOpGG-oBUNG,qRj2so|=eE1w^n4S5NH",
+      "focalPoint": [
+        0.5,
+        -0.25
+      ]
+    }
+  ]
+}`
+	var m map[string]interface{}
+	if err := json.Unmarshal([]byte(js), &m); err != nil {
+		t.Fatalf("Cannot json.Unmarshal: %v", err)
+	}
+	a, err := ToType(context.Background(), m)
+	if err != nil {
+		t.Fatalf("Cannot ToType: %v", err)
+	}
+	n, ok := a.(vocab.ActivityStreamsNote)
+	if !ok {
+		t.Fatalf("expected a Note, got %T", a)
+	}
+	iter := n.GetActivityStreamsAttachment().Begin()
+	if iter == nil || !iter.IsActivityStreamsImage() {
+		t.Fatalf("expected an Image attachment")
+	}
+	img := iter.GetActivityStreamsImage()
+	if b := img.GetTootBlurhash().Get(); b != "UBL_:rOpGG-oBUNG,qRj2so|=eE1w^n4S5NH" {
+		t.Fatalf("unexpected blurhash: %s", b)
+	}
+	x, y, err := FocalPoint(img.GetTootFocalPoint())
+	if err != nil {
+		t.Fatalf("Cannot FocalPoint: %v", err)
+	} else if x != 0.5 || y != -0.25 {
+		t.Fatalf("unexpected focalPoint: %v, %v", x, y)
+	}
+	out, err := Serialize(n)
+	if err != nil {
+		t.Fatalf("Cannot Serialize: %v", err)
+	}
+	attachment, ok := out["attachment"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected a single attachment, got %v", out["attachment"])
+	}
+	if diff := deep.Equal(attachment, map[string]interface{}{
+		"type":       "Image",
+		"mediaType":  "image/png",
+		"url":        "https://example.com/media/1.png",
+		"blurhash":   "UBL_:rOpGG-oBUNG,qRj2so|=eE1w^n4S5NH",
+		"focalPoint": []interface{}{0.5, -0.25},
+	}); diff != nil {
+		t.Fatal(diff)
+	}
+}
+
+func TestFocalPoint(t *testing.T) {
+	p, err := NewFocalPoint(-1, 1)
+	if err != nil {
+		t.Fatalf("Cannot NewFocalPoint: %v", err)
+	}
+	if x, y, err := FocalPoint(p); err != nil {
+		t.Fatalf("Cannot FocalPoint: %v", err)
+	} else if x != -1 || y != 1 {
+		t.Fatalf("unexpected focalPoint: %v, %v", x, y)
+	}
+	if _, err := NewFocalPoint(0, 1.5); err == nil {
+		t.Fatalf("expected an error for a coordinate out of range")
+	}
+	malformed := map[string]interface{}{
+		"none":         nil,
+		"one value":    []interface{}{0.5},
+		"three values": []interface{}{0.5, 0.5, 0.5},
+		"string":       []interface{}{0.5, "center"},
+		"out of range": []interface{}{0.5, -2.0},
+	}
+	for name, v := range malformed {
+		m := map[string]interface{}{
+			"@context": "https://www.w3.org/ns/activitystreams",
+			"type":     "Image",
+		}
+		if v != nil {
+			m["focalPoint"] = v
+		}
+		a, err := ToType(context.Background(), m)
+		if err != nil {
+			t.Fatalf("%s: Cannot ToType: %v", name, err)
+		}
+		img := a.(vocab.ActivityStreamsImage)
+		if _, _, err := FocalPoint(img.GetTootFocalPoint()); err == nil {
+			t.Fatalf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestEachProperty(t *testing.T) {
+	note := NewActivityStreamsNote()
+	id := NewJSONLDIdProperty()
+	id.Set(MustParseURL("https://example.com/notes/1"))
+	note.SetJSONLDId(id)
+	to := NewActivityStreamsToProperty()
+	to.AppendIRI(MustParseURL("https://example.com/users/alice"))
+	note.SetActivityStreamsTo(to)
+	content := NewActivityStreamsContentProperty()
+	content.AppendXMLSchemaString("hello")
+	note.SetActivityStreamsContent(content)
+	var v vocab.Type = note
+	var names []string
+	v.EachProperty(func(name string, value vocab.PropertyInterface) {
+		names = append(names, name)
+		if value.Name() != name {
+			t.Fatalf("expected the value of %s, got %s", name, value.Name())
+		}
+	})
+	if diff := deep.Equal(names, []string{"content", "id", "to", "type"}); diff != nil {
+		t.Fatal(diff)
+	}
+}
+
+func TestEventAndPlaceRoundTrip(t *testing.T) {
+	const js = `{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "https://example.com/activities/1",
+  "type": "Create",
+  "actor": "https://example.com/users/alice",
+  "object": [
+    {
+      "id": "https://example.com/events/1",
+      "type": "Event",
+      "name": "Community meetup",
+      "startTime": "2019-06-01T18:00:00Z",
+      "endTime": "2019-06-01T21:30:00Z",
+      "location": {
+        "type": "Place",
+        "name": "Town hall",
+        "latitude": 48.8566,
+        "longitude": 2.3522,
+        "altitude": 35,
+        "accuracy": 94.5,
+        "radius": 15,
+        "units": "m"
+      }
+    },
+    {
+      "id": "https://example.com/articles/1",
+      "type": "Article",
+      "name": "Meetup report",
+      "content": "<p>It went well.</p>",
+      "published": "2019-06-02T09:00:00Z",
+      "location": {
+        "type": "Place",
+        "name": "Somewhere",
+        "latitude": -33.8688,
+        "longitude": 151.2093,
+        "units": "miles"
+      }
+    },
+    {
+      "id": "https://example.com/pages/1",
+      "type": "Page",
+      "name": "About the meetups",
+      "url": "https://example.com/about"
+    }
+  ]
+}`
+	var m map[string]interface{}
+	if err := json.Unmarshal([]byte(js), &m); err != nil {
+		t.Fatalf("Cannot json.Unmarshal: %v", err)
+	}
+	a, err := ToType(context.Background(), m)
+	if err != nil {
+		t.Fatalf("Cannot ToType: %v", err)
+	}
+	c, ok := a.(vocab.ActivityStreamsCreate)
+	if !ok {
+		t.Fatalf("expected a Create, got %T", a)
+	}
+	iter := c.GetActivityStreamsObject().Begin()
+	if iter == nil || !iter.IsActivityStreamsEvent() {
+		t.Fatalf("expected an Event object")
+	}
+	loc := iter.GetActivityStreamsEvent().GetActivityStreamsLocation().Begin()
+	if loc == nil || !loc.IsActivityStreamsPlace() {
+		t.Fatalf("expected a Place location")
+	}
+	place := loc.GetActivityStreamsPlace()
+	if lat := place.GetActivityStreamsLatitude().Get(); lat != 48.8566 {
+		t.Fatalf("unexpected latitude: %v", lat)
+	} else if long := place.GetActivityStreamsLongitude().Get(); long != 2.3522 {
+		t.Fatalf("unexpected longitude: %v", long)
+	} else if units := place.GetActivityStreamsUnits().GetXMLSchemaString(); units != "m" {
+		t.Fatalf("unexpected units: %v", units)
+	}
+	out, err := Serialize(c)
+	if err != nil {
+		t.Fatalf("Cannot Serialize: %v", err)
+	}
+	// Round trip once more, as would happen when stored and served again.
+	b, err := json.Marshal(out)
+	if err != nil {
+		t.Fatalf("Cannot json.Marshal: %v", err)
+	}
+	var stored map[string]interface{}
+	if err = json.Unmarshal(b, &stored); err != nil {
+		t.Fatalf("Cannot json.Unmarshal: %v", err)
+	}
+	a, err = ToType(context.Background(), stored)
+	if err != nil {
+		t.Fatalf("Cannot ToType: %v", err)
+	}
+	out, err = Serialize(a)
+	if err != nil {
+		t.Fatalf("Cannot Serialize: %v", err)
+	}
+	b, err = json.Marshal(out)
+	if err != nil {
+		t.Fatalf("Cannot json.Marshal: %v", err)
+	}
+	var got map[string]interface{}
+	if err = json.Unmarshal(b, &got); err != nil {
+		t.Fatalf("Cannot json.Unmarshal: %v", err)
+	}
+	if diff := deep.Equal(got, m); diff != nil {
+		t.Fatal(diff)
+	}
+	// Embedded in an outbox collection.
+	oc := NewActivityStreamsOrderedCollection()
+	oi := NewActivityStreamsOrderedItemsProperty()
+	oi.AppendActivityStreamsCreate(c)
+	oc.SetActivityStreamsOrderedItems(oi)
+	out, err = Serialize(oc)
+	if err != nil {
+		t.Fatalf("Cannot Serialize: %v", err)
+	}
+	b, err = json.Marshal(out["orderedItems"])
+	if err != nil {
+		t.Fatalf("Cannot json.Marshal: %v", err)
+	}
+	var item map[string]interface{}
+	if err = json.Unmarshal(b, &item); err != nil {
+		t.Fatalf("Cannot json.Unmarshal: %v", err)
+	}
+	delete(m, "@context")
+	if diff := deep.Equal(item, m); diff != nil {
+		t.Fatal(diff)
+	}
+}
+
+func TestHashtagFromMastodon(t *testing.T) {
+	const js = `{
+  "@context": [
+    "https://www.w3.org/ns/activitystreams",
+    {
+      "Hashtag": "as:Hashtag"
+    }
+  ],
+  "id": "https://example.com/notes/1",
+  "type": "Note",
+  "content": "Learning #golang",
+  "tag": [
+    {
+      "type": "Hashtag",
+      "href": "https://example.com/tags/golang",
+      "name": "#golang"
+    },
+    {
+      "type": "Mention",
+      "href": "https://example.com/users/bob",
+      "name": "@bob"
+    }
+  ]
+}`
+	var m map[string]interface{}
+	if err := json.Unmarshal([]byte(js), &m); err != nil {
+		t.Fatalf("Cannot json.Unmarshal: %v", err)
+	}
+	a, err := ToType(context.Background(), m)
+	if err != nil {
+		t.Fatalf("Cannot ToType: %v", err)
+	}
+	n, ok := a.(vocab.ActivityStreamsNote)
+	if !ok {
+		t.Fatalf("expected a Note, got %T", a)
+	}
+	iter := n.GetActivityStreamsTag().Begin()
+	if iter == nil || !iter.IsActivityStreamsHashtag() {
+		t.Fatalf("expected a Hashtag tag")
+	}
+	h := iter.GetActivityStreamsHashtag()
+	if name := h.GetActivityStreamsName().Begin().GetXMLSchemaString(); name != "#golang" {
+		t.Fatalf("unexpected name: %s", name)
+	} else if href := h.GetActivityStreamsHref().Get().String(); href != "https://example.com/tags/golang" {
+		t.Fatalf("unexpected href: %s", href)
+	}
+	if !iter.Next().IsActivityStreamsMention() {
+		t.Fatalf("expected a Mention tag")
+	}
+	out, err := Serialize(n)
+	if err != nil {
+		t.Fatalf("Cannot Serialize: %v", err)
+	}
+	b, err := json.Marshal(out)
+	if err != nil {
+		t.Fatalf("Cannot json.Marshal: %v", err)
+	}
+	var got map[string]interface{}
+	if err = json.Unmarshal(b, &got); err != nil {
+		t.Fatalf("Cannot json.Unmarshal: %v", err)
+	}
+	if diff := deep.Equal(got, m); diff != nil {
+		t.Fatal(diff)
+	}
+}
+
+func TestSerializeWithoutHashtagHasNoTermDefinition(t *testing.T) {
+	n := NewActivityStreamsNote()
+	out, err := Serialize(n)
+	if err != nil {
+		t.Fatalf("Cannot Serialize: %v", err)
+	}
+	if c := out["@context"]; c != "https://www.w3.org/ns/activitystreams" {
+		t.Fatalf("unexpected @context: %v", c)
+	}
+}
+
+func TestAccountMigrationPropertiesRoundTrip(t *testing.T) {
+	const js = `{
+  "@context": [
+    "https://www.w3.org/ns/activitystreams",
+    {
+      "alsoKnownAs": {
+        "@id": "as:alsoKnownAs",
+        "@type": "@id"
+      },
+      "movedTo": {
+        "@id": "as:movedTo",
+        "@type": "@id"
+      }
+    }
+  ],
+  "id": "https://example.com/users/alice",
+  "type": "Person",
+  "inbox": "https://example.com/users/alice/inbox",
+  "outbox": "https://example.com/users/alice/outbox",
+  "alsoKnownAs": [
+    "https://old.example/users/alice",
+    "https://older.example/users/alice"
+  ],
+  "movedTo": "https://new.example/users/alice"
+}`
+	var m map[string]interface{}
+	if err := json.Unmarshal([]byte(js), &m); err != nil {
+		t.Fatalf("Cannot json.Unmarshal: %v", err)
+	}
+	a, err := ToType(context.Background(), m)
+	if err != nil {
+		t.Fatalf("Cannot ToType: %v", err)
+	}
+	p, ok := a.(vocab.ActivityStreamsPerson)
+	if !ok {
+		t.Fatalf("expected a Person, got %T", a)
+	}
+	if aka := p.GetActivityStreamsAlsoKnownAs(); aka == nil || aka.Len() != 2 {
+		t.Fatalf("expected two alsoKnownAs")
+	} else if u := aka.At(1).Get().String(); u != "https://older.example/users/alice" {
+		t.Fatalf("unexpected alsoKnownAs: %s", u)
+	}
+	if u := p.GetActivityStreamsMovedTo().Get().String(); u != "https://new.example/users/alice" {
+		t.Fatalf("unexpected movedTo: %s", u)
+	}
+	out, err := Serialize(p)
+	if err != nil {
+		t.Fatalf("Cannot Serialize: %v", err)
+	}
+	b, err := json.Marshal(out)
+	if err != nil {
+		t.Fatalf("Cannot json.Marshal: %v", err)
+	}
+	var got map[string]interface{}
+	if err = json.Unmarshal(b, &got); err != nil {
+		t.Fatalf("Cannot json.Unmarshal: %v", err)
+	}
+	if diff := deep.Equal(got, m); diff != nil {
+		t.Fatal(diff)
+	}
+}
+
+func TestSerializePreservingUnknown(t *testing.T) {
+	const js = `{
+  "@context": [
+    "https://www.w3.org/ns/activitystreams",
+    {
+      "ext": "https://ext.example/ns#",
+      "color": "ext:color"
+    }
+  ],
+  "id": "https://example.com/notes/1",
+  "type": "Note",
+  "content": "Hello",
+  "color": "blue",
+  "ext:rating": {
+    "stars": 4
+  }
+}`
+	var m map[string]interface{}
+	if err := json.Unmarshal([]byte(js), &m); err != nil {
+		t.Fatalf("Cannot json.Unmarshal: %v", err)
+	}
+	a, err := ToType(context.Background(), m)
+	if err != nil {
+		t.Fatalf("Cannot ToType: %v", err)
+	}
+	if diff := deep.Equal(UnknownProperties(a), map[string]interface{}{
+		"color": "blue",
+		"ext:rating": map[string]interface{}{
+			"stars": float64(4),
+		},
+	}); diff != nil {
+		t.Fatal(diff)
+	}
+	out, err := SerializePreservingUnknown(a)
+	if err != nil {
+		t.Fatalf("Cannot SerializePreservingUnknown: %v", err)
+	}
+	b, err := json.Marshal(out)
+	if err != nil {
+		t.Fatalf("Cannot json.Marshal: %v", err)
+	}
+	var got map[string]interface{}
+	if err = json.Unmarshal(b, &got); err != nil {
+		t.Fatalf("Cannot json.Unmarshal: %v", err)
+	}
+	if diff := deep.Equal(got, m); diff != nil {
+		t.Fatal(diff)
+	}
+	// Serialize keeps the properties but normalizes the context.
+	out, err = Serialize(a)
+	if err != nil {
+		t.Fatalf("Cannot Serialize: %v", err)
+	}
+	if c := out["@context"]; c != "https://www.w3.org/ns/activitystreams" {
+		t.Fatalf("unexpected @context: %v", c)
+	} else if out["color"] != "blue" {
+		t.Fatalf("unexpected color: %v", out["color"])
+	}
+}
+
+func TestUnknownPropertiesOfNewValue(t *testing.T) {
+	if u := UnknownProperties(NewActivityStreamsNote()); u != nil {
+		t.Fatalf("expected no unknown properties, got %v", u)
+	}
+}
+
+func TestFeaturedPropertiesRoundTrip(t *testing.T) {
+	const js = `{
+  "@context": [
+    "https://www.w3.org/ns/activitystreams",
+    "http://joinmastodon.org/ns",
+    {
+      "toot": "http://joinmastodon.org/ns#",
+      "featured": {
+        "@id": "toot:featured",
+        "@type": "@id"
+      },
+      "featuredTags": {
+        "@id": "toot:featuredTags",
+        "@type": "@id"
+      }
+    }
+  ],
+  "id": "https://example.com/users/alice",
+  "type": "Person",
+  "inbox": "https://example.com/users/alice/inbox",
+  "outbox": "https://example.com/users/alice/outbox",
+  "featured": "https://example.com/users/alice/collections/featured",
+  "featuredTags": "https://example.com/users/alice/collections/tags"
+}`
+	var m map[string]interface{}
+	if err := json.Unmarshal([]byte(js), &m); err != nil {
+		t.Fatalf("Cannot json.Unmarshal: %v", err)
+	}
+	a, err := ToType(context.Background(), m)
+	if err != nil {
+		t.Fatalf("Cannot ToType: %v", err)
+	}
+	p, ok := a.(vocab.ActivityStreamsPerson)
+	if !ok {
+		t.Fatalf("expected a Person, got %T", a)
+	}
+	if f := p.GetTootFeatured(); f == nil || !f.IsIRI() {
+		t.Fatalf("expected featured to be an IRI")
+	} else if u := f.GetIRI().String(); u != "https://example.com/users/alice/collections/featured" {
+		t.Fatalf("unexpected featured: %s", u)
+	}
+	if f := p.GetTootFeaturedTags(); f == nil || !f.IsIRI() {
+		t.Fatalf("expected featuredTags to be an IRI")
+	} else if u := f.GetIRI().String(); u != "https://example.com/users/alice/collections/tags" {
+		t.Fatalf("unexpected featuredTags: %s", u)
+	}
+	out, err := Serialize(p)
+	if err != nil {
+		t.Fatalf("Cannot Serialize: %v", err)
+	}
+	b, err := json.Marshal(out)
+	if err != nil {
+		t.Fatalf("Cannot json.Marshal: %v", err)
+	}
+	var got map[string]interface{}
+	if err = json.Unmarshal(b, &got); err != nil {
+		t.Fatalf("Cannot json.Unmarshal: %v", err)
+	}
+	// The order of the vocabularies in the context is not deterministic.
+	gotCtx, ok := got["@context"].([]interface{})
+	if !ok || len(gotCtx) != 3 {
+		t.Fatalf("unexpected @context: %v", got["@context"])
+	}
+	if diff := deep.Equal(gotCtx[2], m["@context"].([]interface{})[2]); diff != nil {
+		t.Fatal(diff)
+	}
+	delete(got, "@context")
+	delete(m, "@context")
+	if diff := deep.Equal(got, m); diff != nil {
+		t.Fatal(diff)
+	}
+}
+
+func TestToTypeToleratesContextShapes(t *testing.T) {
+	tests := []struct {
+		name    string
+		context string
+		typ     string
+		content string
+	}{
+		{
+			name:    "String",
+			context: `"https://www.w3.org/ns/activitystreams"`,
+			typ:     "Note",
+			content: "content",
+		},
+		{
+			name: "MastodonArrayWithTermDefinitions",
+			context: `[
+  "https://www.w3.org/ns/activitystreams",
+  {
+    "ostatus": "http://ostatus.org#",
+    "atomUri": "ostatus:atomUri",
+    "inReplyToAtomUri": "ostatus:inReplyToAtomUri",
+    "conversation": "ostatus:conversation",
+    "sensitive": "as:sensitive",
+    "toot": "http://joinmastodon.org/ns#",
+    "votersCount": "toot:votersCount",
+    "Emoji": "toot:Emoji",
+    "blurhash": "toot:blurhash",
+    "focalPoint": {"@container": "@list", "@id": "toot:focalPoint"}
+  }
+]`,
+			typ:     "Note",
+			content: "content",
+		},
+		{
+			name: "PleromaLanguageInNestedArray",
+			context: `[
+  "https://www.w3.org/ns/activitystreams",
+  "https://mastodon.example/schemas/litepub-0.1.jsonld",
+  [{"@language": "und"}]
+]`,
+			typ:     "Note",
+			content: "content",
+		},
+		{
+			name: "MisskeyDeeplyNested",
+			context: `[
+  "https://www.w3.org/ns/activitystreams",
+  ["https://w3id.org/security/v1", [{"misskey": "https://misskey-hub.net/ns#", "_misskey_content": "misskey:_misskey_content"}]],
+  null
+]`,
+			typ:     "Note",
+			content: "content",
+		},
+		{
+			name:    "PeerTubeTypedTermDefinitions",
+			context: `["https://www.w3.org/ns/activitystreams", {"pt": "https://joinpeertube.org/ns#", "sc": "http://schema.org/", "originallyPublishedAt": {"@id": "sc:datePublished", "@type": "sc:Date"}, "views": {"@type": "sc:Number", "@id": "pt:views"}}]`,
+			typ:     "Note",
+			content: "content",
+		},
+		{
+			name:    "SingleObjectWithVocab",
+			context: `{"@vocab": "https://www.w3.org/ns/activitystreams", "@language": "en"}`,
+			typ:     "Note",
+			content: "content",
+		},
+		{
+			name:    "SingleObjectWithPrefix",
+			context: `{"as": "https://www.w3.org/ns/activitystreams#"}`,
+			typ:     "as:Note",
+			content: "as:content",
+		},
+		{
+			name:    "PrefixWithStringTakesUnprefixed",
+			context: `[{"as": "https://www.w3.org/ns/activitystreams#"}, "https://www.w3.org/ns/activitystreams"]`,
+			typ:     "Note",
+			content: "content",
+		},
+		{
+			name:    "EmbeddedContextObject",
+			context: `[{"@context": "https://www.w3.org/ns/activitystreams"}, {"@protected": true}]`,
+			typ:     "Note",
+			content: "content",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			js := fmt.Sprintf(`{"@context": %s, "id": "https://example.com/note/1", "type": %q, %q: "Hello"}`, test.context, test.typ, test.content)
+			var m map[string]interface{}
+			if err := json.Unmarshal([]byte(js), &m); err != nil {
+				t.Fatalf("Cannot json.Unmarshal: %v", err)
+			}
+			a, err := ToType(context.Background(), m)
+			if err != nil {
+				t.Fatalf("Cannot ToType: %v", err)
+			}
+			n, ok := a.(vocab.ActivityStreamsNote)
+			if !ok {
+				t.Fatalf("expected a Note, got %T", a)
+			}
+			if c := n.GetActivityStreamsContent(); c == nil || c.Len() != 1 || c.At(0).GetXMLSchemaString() != "Hello" {
+				t.Fatalf("expected the content to be deserialized")
+			}
+			if u := UnknownProperties(n); len(u) != 0 {
+				t.Fatalf("expected no unknown properties, got %v", u)
+			}
+		})
+	}
+}
+
+func TestClone(t *testing.T) {
+	js := `{
+  "@context": ["https://www.w3.org/ns/activitystreams", {"ext": "https://example.com/ns#"}],
+  "id": "https://example.com/note/1",
+  "type": "Note",
+  "content": "Hello",
+  "summaryMap": {"en": "Hi", "fr": "Salut"},
+  "bcc": "https://example.com/users/bob",
+  "url": "https://example.com/note/1.html",
+  "ext:meta": {"tags": ["a", "b"]}
+}`
+	var m map[string]interface{}
+	if err := json.Unmarshal([]byte(js), &m); err != nil {
+		t.Fatalf("Cannot json.Unmarshal: %v", err)
+	}
+	a, err := ToType(context.Background(), m)
+	if err != nil {
+		t.Fatalf("Cannot ToType: %v", err)
+	}
+	before, err := SerializePreservingUnknown(a)
+	if err != nil {
+		t.Fatalf("Cannot SerializePreservingUnknown: %v", err)
+	}
+	c, err := Clone(a)
+	if err != nil {
+		t.Fatalf("Cannot Clone: %v", err)
+	}
+	cloned, err := SerializePreservingUnknown(c)
+	if err != nil {
+		t.Fatalf("Cannot SerializePreservingUnknown: %v", err)
+	}
+	if diff := deep.Equal(before, cloned); diff != nil {
+		t.Fatalf("expected the clone to equal the original: %v", diff)
+	}
+	// Mutate the clone's known and unknown properties.
+	n := c.(vocab.ActivityStreamsNote)
+	n.SetActivityStreamsBcc(nil)
+	n.GetActivityStreamsContent().At(0).SetXMLSchemaString("Goodbye")
+	summary := n.GetActivityStreamsSummary().At(0)
+	if !summary.IsRDFLangString() {
+		t.Fatalf("expected the clone to keep the natural language map")
+	}
+	summary.SetLanguage("en", "Bye")
+	n.GetActivityStreamsUrl().At(0).GetIRI().Path = "/changed"
+	meta := UnknownProperties(n)["ext:meta"].(map[string]interface{})
+	meta["tags"].([]interface{})[0] = "z"
+	after, err := SerializePreservingUnknown(a)
+	if err != nil {
+		t.Fatalf("Cannot SerializePreservingUnknown: %v", err)
+	}
+	if diff := deep.Equal(before, after); diff != nil {
+		t.Fatalf("expected the original to be unmodified: %v", diff)
+	}
+}
+
+func benchmarkCloneType(b *testing.B) vocab.Type {
+	var m map[string]interface{}
+	if err := json.Unmarshal([]byte(example15), &m); err != nil {
+		b.Fatalf("Cannot json.Unmarshal: %v", err)
+	}
+	a, err := ToType(context.Background(), m)
+	if err != nil {
+		b.Fatalf("Cannot ToType: %v", err)
+	}
+	return a
+}
+
+func BenchmarkClone(b *testing.B) {
+	a := benchmarkCloneType(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := Clone(a); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkCloneRoundTrip(b *testing.B) {
+	a := benchmarkCloneType(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m, err := SerializePreservingUnknown(a)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if _, err := ToType(context.Background(), m); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestEndpointsRoundTrip(t *testing.T) {
+	const js = `{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "https://example.com/users/alice",
+  "type": "Person",
+  "inbox": "https://example.com/users/alice/inbox",
+  "outbox": "https://example.com/users/alice/outbox",
+  "endpoints": {
+    "sharedInbox": "https://example.com/inbox",
+    "oauthAuthorizationEndpoint": "https://example.com/oauth/authorize",
+    "oauthTokenEndpoint": "https://example.com/oauth/token",
+    "provideClientKey": "https://example.com/keys/provide",
+    "signClientKey": "https://example.com/keys/sign",
+    "uploadMedia": "https://example.com/media"
+  }
+}`
+	var m map[string]interface{}
+	if err := json.Unmarshal([]byte(js), &m); err != nil {
+		t.Fatalf("Cannot json.Unmarshal: %v", err)
+	}
+	a, err := ToType(context.Background(), m)
+	if err != nil {
+		t.Fatalf("Cannot ToType: %v", err)
+	}
+	p, ok := a.(vocab.ActivityStreamsPerson)
+	if !ok {
+		t.Fatalf("expected a Person, got %T", a)
+	}
+	e := p.GetActivityStreamsEndpoints()
+	if e == nil || !e.IsActivityStreamsEndpoints() {
+		t.Fatalf("expected embedded endpoints")
+	}
+	if s := e.Get().GetActivityStreamsSharedInbox(); s == nil || s.Get().String() != "https://example.com/inbox" {
+		t.Fatalf("unexpected sharedInbox")
+	}
+	if u := e.Get().GetActivityStreamsUploadMedia(); u == nil || u.Get().String() != "https://example.com/media" {
+		t.Fatalf("unexpected uploadMedia")
+	}
+	if u := UnknownProperties(p); len(u) != 0 {
+		t.Fatalf("expected no unknown properties, got %v", u)
+	}
+	out, err := Serialize(p)
+	if err != nil {
+		t.Fatalf("Cannot Serialize: %v", err)
+	}
+	b, err := json.Marshal(out)
+	if err != nil {
+		t.Fatalf("Cannot json.Marshal: %v", err)
+	}
+	var got map[string]interface{}
+	if err = json.Unmarshal(b, &got); err != nil {
+		t.Fatalf("Cannot json.Unmarshal: %v", err)
+	}
+	if diff := deep.Equal(got, m); diff != nil {
+		t.Fatal(diff)
+	}
+}
+
+func TestContentWarningRoundTrip(t *testing.T) {
+	const js = `{
+  "@context": [
+    "https://www.w3.org/ns/activitystreams",
+    {
+      "sensitive": "as:sensitive"
+    }
+  ],
+  "id": "https://example.com/notes/1",
+  "type": "Note",
+  "summary": "Spoilers for the finale",
+  "sensitive": true,
+  "content": "The butler did it.",
+  "attachment": {
+    "type": "Image",
+    "url": "https://example.com/media/1.png",
+    "sensitive": true
+  }
+}`
+	var m map[string]interface{}
+	if err := json.Unmarshal([]byte(js), &m); err != nil {
+		t.Fatalf("Cannot json.Unmarshal: %v", err)
+	}
+	a, err := ToType(context.Background(), m)
+	if err != nil {
+		t.Fatalf("Cannot ToType: %v", err)
+	}
+	n, ok := a.(vocab.ActivityStreamsNote)
+	if !ok {
+		t.Fatalf("expected a Note, got %T", a)
+	}
+	if s := n.GetActivityStreamsSensitive(); s == nil || s.Len() != 1 || !s.At(0).Get() {
+		t.Fatalf("expected the Note to be sensitive")
+	}
+	if s := n.GetActivityStreamsSummary(); s == nil || s.Begin().GetXMLSchemaString() != "Spoilers for the finale" {
+		t.Fatalf("expected the Note to have a summary")
+	}
+	out, err := Serialize(n)
+	if err != nil {
+		t.Fatalf("Cannot Serialize: %v", err)
+	}
+	b, err := json.Marshal(out)
+	if err != nil {
+		t.Fatalf("Cannot json.Marshal: %v", err)
+	}
+	var got map[string]interface{}
+	if err = json.Unmarshal(b, &got); err != nil {
+		t.Fatalf("Cannot json.Unmarshal: %v", err)
+	}
+	if diff := deep.Equal(got, m); diff != nil {
+		t.Fatal(diff)
+	}
+}
+
+func TestSerializeNotSensitive(t *testing.T) {
+	n := NewActivityStreamsNote()
+	s := NewActivityStreamsSensitiveProperty()
+	s.AppendXMLSchemaBoolean(false)
+	n.SetActivityStreamsSensitive(s)
+	out, err := Serialize(n)
+	if err != nil {
+		t.Fatalf("Cannot Serialize: %v", err)
+	}
+	if v, ok := out["sensitive"]; !ok || v != false {
+		t.Fatalf("expected sensitive to be false, got %v", v)
+	}
+	if _, ok := out["@context"].([]interface{}); !ok {
+		t.Fatalf("expected a term definition for sensitive in @context: %v", out["@context"])
+	}
+}
+
+func TestJSONNumberRoundTrip(t *testing.T) {
+	const js = `{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "https://example.com/places/1",
+  "type": "Place",
+  "latitude": 36.75,
+  "radius": 15,
+  "ext:snowflake": 1234567890123456789
+}`
+	d := json.NewDecoder(bytes.NewReader([]byte(js)))
+	d.UseNumber()
+	var m map[string]interface{}
+	if err := d.Decode(&m); err != nil {
+		t.Fatalf("Cannot Decode: %v", err)
+	}
+	a, err := ToType(context.Background(), m)
+	if err != nil {
+		t.Fatalf("Cannot ToType: %v", err)
+	}
+	p, ok := a.(vocab.ActivityStreamsPlace)
+	if !ok {
+		t.Fatalf("expected a Place, got %T", a)
+	}
+	if lat := p.GetActivityStreamsLatitude(); lat == nil || !lat.IsXMLSchemaFloat() || lat.Get() != 36.75 {
+		t.Fatalf("expected latitude 36.75, got %v", lat)
+	}
+	if r := p.GetActivityStreamsRadius(); r == nil || !r.IsXMLSchemaFloat() || r.Get() != 15 {
+		t.Fatalf("expected radius 15, got %v", r)
+	}
+	out, err := Serialize(a)
+	if err != nil {
+		t.Fatalf("Cannot Serialize: %v", err)
+	}
+	b, err := json.Marshal(out)
+	if err != nil {
+		t.Fatalf("Cannot json.Marshal: %v", err)
+	}
+	if !bytes.Contains(b, []byte(`"ext:snowflake":1234567890123456789`)) {
+		t.Fatalf("lost the precision of the 64-bit integer: %s", b)
+	}
+}
+
+func TestDeserializeJSONNumberNonNegativeInteger(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		value   json.Number
+		want    int
+		isError bool
+	}{
+		{"Integer", "9007199254740993", 9007199254740993, false},
+		{"Negative", "-1", 0, true},
+		{"Fraction", "1.5", 0, true},
+		{"FloatForm", "1.0", 1, false},
+		{"ExponentForm", "1e3", 1000, false},
+		{"NegativeExponentForm", "-1e3", 0, true},
+		{"FractionalExponentForm", "15e-1", 0, true},
+		{"Overflow", "1e30", 0, true},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			c := NewActivityStreamsCollection()
+			m, err := c.Serialize()
+			if err != nil {
+				t.Fatalf("Cannot Serialize: %v", err)
+			}
+			m["@context"] = "https://www.w3.org/ns/activitystreams"
+			m["totalItems"] = test.value
+			a, err := ToType(context.Background(), m)
+			if err != nil {
+				t.Fatalf("Cannot ToType: %v", err)
+			}
+			ti := a.(vocab.ActivityStreamsCollection).GetActivityStreamsTotalItems()
+			if test.isError {
+				if ti != nil && ti.IsXMLSchemaNonNegativeInteger() {
+					t.Fatalf("expected %s not to be a nonNegativeInteger", test.value)
+				}
+				return
+			}
+			if ti == nil || !ti.IsXMLSchemaNonNegativeInteger() || ti.Get() != test.want {
+				t.Fatalf("expected totalItems %d, got %v", test.want, ti)
+			}
+		})
+	}
+}
+
+func TestDeserializeJSONNumberBoolean(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		value   json.Number
+		want    bool
+		isError bool
+	}{
+		{"Zero", "0", false, false},
+		{"One", "1", true, false},
+		{"FloatForm", "1.0", true, false},
+		{"ExponentForm", "0e3", false, false},
+		{"Two", "2", false, true},
+		{"Fraction", "0.5", false, true},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := boolean.DeserializeBoolean(test.value)
+			if test.isError {
+				if err == nil {
+					t.Fatalf("expected an error, got %v", got)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("Cannot DeserializeBoolean: %v", err)
+			}
+			if got != test.want {
+				t.Fatalf("expected %v, got %v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestDateTimeFormatsFromPeers(t *testing.T) {
+	pst := time.FixedZone("", -8*60*60)
+	for _, test := range []struct {
+		name       string
+		value      string
+		want       time.Time
+		serialized string
+		isError    bool
+	}{
+		{"UTC", "2020-03-04T05:06:07Z", time.Date(2020, 3, 4, 5, 6, 7, 0, time.UTC), "2020-03-04T05:06:07Z", false},
+		{"ZeroOffset", "2020-03-04T05:06:07+00:00", time.Date(2020, 3, 4, 5, 6, 7, 0, time.UTC), "2020-03-04T05:06:07Z", false},
+		{"Offset", "2020-03-04T05:06:07-08:00", time.Date(2020, 3, 4, 5, 6, 7, 0, pst), "2020-03-04T05:06:07-08:00", false},
+		{"Milliseconds", "2020-03-04T05:06:07.123Z", time.Date(2020, 3, 4, 5, 6, 7, 123000000, time.UTC), "2020-03-04T05:06:07.123Z", false},
+		{"Nanoseconds", "2020-03-04T05:06:07.123456789-08:00", time.Date(2020, 3, 4, 5, 6, 7, 123456789, pst), "2020-03-04T05:06:07.123456789-08:00", false},
+		{"WithoutSeconds", "2020-03-04T05:06Z", time.Date(2020, 3, 4, 5, 6, 0, 0, time.UTC), "2020-03-04T05:06:00Z", false},
+		{"SpaceSeparator", "2020-03-04 05:06:07Z", time.Date(2020, 3, 4, 5, 6, 7, 0, time.UTC), "2020-03-04T05:06:07Z", false},
+		{"OffsetWithoutColon", "2020-03-04T05:06:07.5-0800", time.Date(2020, 3, 4, 5, 6, 7, 500000000, pst), "2020-03-04T05:06:07.5-08:00", false},
+		{"WithoutOffset", "2020-03-04T05:06:07", time.Time{}, "", true},
+		{"DateOnly", "2020-03-04", time.Time{}, "", true},
+		{"NotADate", "yesterday", time.Time{}, "", true},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := datetime.DeserializeDateTime(test.value)
+			if test.isError {
+				if err == nil {
+					t.Fatalf("expected an error, got %v", got)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("Cannot DeserializeDateTime: %v", err)
+			}
+			if !got.Equal(test.want) {
+				t.Fatalf("expected %v, got %v", test.want, got)
+			}
+			_, gotOffset := got.Zone()
+			_, wantOffset := test.want.Zone()
+			if gotOffset != wantOffset {
+				t.Fatalf("expected offset %d, got %d", wantOffset, gotOffset)
+			}
+			s, err := datetime.SerializeDateTime(got)
+			if err != nil {
+				t.Fatalf("Cannot SerializeDateTime: %v", err)
+			}
+			if s != test.serialized {
+				t.Fatalf("expected %s, got %s", test.serialized, s)
+			}
+		})
+	}
+}
+
+func TestPublishedDateTimeRoundTrip(t *testing.T) {
+	for _, test := range []struct {
+		name       string
+		value      string
+		serialized string
+		isDateTime bool
+	}{
+		{"PreservesOffsetAndPrecision", "2020-03-04T05:06:07.123-08:00", "2020-03-04T05:06:07.123-08:00", true},
+		{"Lenient", "2020-03-04 05:06Z", "2020-03-04T05:06:00Z", true},
+		{"Invalid", "2020-03-04T05:06:07", "2020-03-04T05:06:07", false},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			m := map[string]interface{}{
+				"@context":  "https://www.w3.org/ns/activitystreams",
+				"type":      "Note",
+				"published": test.value,
+			}
+			a, err := ToType(context.Background(), m)
+			if err != nil {
+				t.Fatalf("Cannot ToType: %v", err)
+			}
+			p := a.(vocab.ActivityStreamsNote).GetActivityStreamsPublished()
+			if p.IsXMLSchemaDateTime() != test.isDateTime {
+				t.Fatalf("expected IsXMLSchemaDateTime %v", test.isDateTime)
+			}
+			if test.isDateTime && p.Get().IsZero() {
+				t.Fatalf("expected a non-zero published time")
+			}
+			out, err := a.Serialize()
+			if err != nil {
+				t.Fatalf("Cannot Serialize: %v", err)
+			}
+			if out["published"] != test.serialized {
+				t.Fatalf("expected published %s, got %v", test.serialized, out["published"])
+			}
+		})
+	}
+}
+
+func TestToTypeKeepsPropertiesOfOtherVocabulariesUnknown(t *testing.T) {
+	js := `{
+  "@context": [
+    "https://www.w3.org/ns/activitystreams",
+    {"toot": "http://joinmastodon.org/ns#", "schema": "http://schema.org#"}
+  ],
+  "id": "https://example.com/note/1",
+  "type": "Note",
+  "content": "Hello",
+  "toot:content": "Bonjour",
+  "schema:name": "Greeting"
+}`
+	var m map[string]interface{}
+	if err := json.Unmarshal([]byte(js), &m); err != nil {
+		t.Fatalf("Cannot json.Unmarshal: %v", err)
+	}
+	a, err := ToType(context.Background(), m)
+	if err != nil {
+		t.Fatalf("Cannot ToType: %v", err)
+	}
+	n, ok := a.(vocab.ActivityStreamsNote)
+	if !ok {
+		t.Fatalf("expected a Note, got %T", a)
+	}
+	if c := n.GetActivityStreamsContent(); c == nil || c.Len() != 1 || c.At(0).GetXMLSchemaString() != "Hello" {
+		t.Fatalf("expected the content to be deserialized")
+	}
+	u := UnknownProperties(n)
+	if u["toot:content"] != "Bonjour" {
+		t.Fatalf("expected toot:content to be unknown, got %v", u)
+	} else if u["schema:name"] != "Greeting" {
+		t.Fatalf("expected schema:name to be unknown, got %v", u)
+	}
+}
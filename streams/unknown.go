@@ -0,0 +1,113 @@
+package streams
+
+import (
+	"github.com/go-fed/activity/streams/vocab"
+)
+
+// unknownPropertier is implemented by the generated types, which keep the
+// properties that were not recognized when deserialized.
+type unknownPropertier interface {
+	GetUnknownProperties() map[string]interface{}
+}
+
+// UnknownProperties returns the properties of the value that were not
+// recognized when it was deserialized by ToType or a JSONResolver, such as
+// extension properties of vocabularies the library does not know. The
+// '@context' is not included. Returns nil if there are none.
+//
+// The returned map is a copy, but its values are shared with the value.
+func UnknownProperties(a vocab.Type) map[string]interface{} {
+	u, ok := a.(unknownPropertier)
+	if !ok {
+		return nil
+	}
+	var m map[string]interface{}
+	for k, v := range u.GetUnknownProperties() {
+		if k == jsonLDContext {
+			continue
+		}
+		if m == nil {
+			m = make(map[string]interface{})
+		}
+		m[k] = v
+	}
+	return m
+}
+
+// SerializePreservingUnknown is like Serialize, but also keeps the '@context'
+// the value was deserialized with, so that the unknown properties re-emitted
+// by Serialize keep their meaning. It is meant for forwarding or storing
+// values from peers using extensions the library does not know.
+//
+// Serialize always re-emits the unknown properties, but replaces the
+// '@context' with the one needed by the known properties, which does not
+// define the prefixes and terms of the unknown properties. Here the original
+// '@context' entries are kept, followed by the entries Serialize would add
+// that are not already present.
+func SerializePreservingUnknown(a vocab.Type) (m map[string]interface{}, e error) {
+	m, e = Serialize(a)
+	if e != nil {
+		return
+	}
+	u, ok := a.(unknownPropertier)
+	if !ok {
+		return
+	}
+	original, ok := u.GetUnknownProperties()[jsonLDContext]
+	if !ok {
+		return
+	}
+	m[jsonLDContext] = mergeContexts(original, m[jsonLDContext])
+	return
+}
+
+// mergeContexts combines the original '@context' with the generated one. The
+// IRIs of both are kept in order without duplicates, and their term
+// definitions are combined into a single object, keeping the original
+// definition of a term defined by both.
+func mergeContexts(original, generated interface{}) interface{} {
+	var iris []interface{}
+	seen := make(map[string]bool)
+	var terms map[string]interface{}
+	var add func(v interface{})
+	add = func(v interface{}) {
+		switch c := v.(type) {
+		case string:
+			if !seen[c] {
+				seen[c] = true
+				iris = append(iris, c)
+			}
+		case []interface{}:
+			for _, e := range c {
+				add(e)
+			}
+		case map[string]interface{}:
+			for k, d := range c {
+				if terms == nil {
+					terms = make(map[string]interface{})
+				}
+				if _, has := terms[k]; !has {
+					terms[k] = d
+				}
+			}
+		case map[string]string:
+			for k, d := range c {
+				if terms == nil {
+					terms = make(map[string]interface{})
+				}
+				if _, has := terms[k]; !has {
+					terms[k] = d
+				}
+			}
+		}
+	}
+	add(original)
+	add(generated)
+	if terms != nil {
+		iris = append(iris, terms)
+	}
+	if len(iris) == 1 {
+		return iris[0]
+	}
+	return iris
+}
@@ -1,6 +1,13 @@
 package streams
 
 import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
 	"github.com/go-fed/activity/streams/vocab"
 )
 
@@ -19,48 +26,431 @@ func Serialize(a vocab.Type) (m map[string]interface{}, e error) {
 	if e != nil {
 		return
 	}
-	v := a.JSONLDContext()
-	// Transform the map of vocabulary-to-aliases into a context payload,
-	// but do so in a way that at least keeps it readable for other humans.
-	var contextValue interface{}
+	terms := make(map[string]interface{})
+	findTerms(m, terms)
+	// TODO: Update the context instead if it already exists
+	m[jsonLDContext] = addTermDefinitions(contextValue(a.JSONLDContext()), terms)
+	cleanChildContexts(m)
+	return
+}
+
+// contextValue transforms the map of vocabulary-to-aliases into a context
+// payload, but does so in a way that at least keeps it readable for other
+// humans. Vocabularies without an alias are sorted, so that the payload is the
+// same every time.
+func contextValue(v map[string]string) interface{} {
 	if len(v) == 1 {
 		for vocab, alias := range v {
 			if len(alias) == 0 {
-				contextValue = vocab
-			} else {
-				contextValue = map[string]string{
-					alias: vocab,
-				}
+				return vocab
+			}
+			return map[string]string{
+				alias: vocab,
 			}
 		}
-	} else {
-		var arr []interface{}
-		aliases := make(map[string]string)
-		for vocab, alias := range v {
-			if len(alias) == 0 {
-				arr = append(arr, vocab)
-			} else {
-				aliases[alias] = vocab
+	}
+	var vocabs []string
+	aliases := make(map[string]string)
+	for vocab, alias := range v {
+		if len(alias) == 0 {
+			vocabs = append(vocabs, vocab)
+		} else {
+			aliases[alias] = vocab
+		}
+	}
+	sort.Strings(vocabs)
+	var arr []interface{}
+	for _, vocab := range vocabs {
+		arr = append(arr, vocab)
+	}
+	if len(aliases) > 0 {
+		arr = append(arr, aliases)
+	}
+	return arr
+}
+
+// cleanChildContexts deletes any existing `@context` in child maps.
+func cleanChildContexts(m map[string]interface{}) {
+	for k, v := range m {
+		if k == jsonLDContext {
+			continue
+		}
+		if n, ok := v.(map[string]interface{}); ok {
+			delete(n, jsonLDContext)
+			cleanChildContexts(n)
+		}
+	}
+}
+
+// termDefinitions are the context entries of the types that are in the
+// ActivityStreams namespace but not in its JSON-LD context, which peers expect
+// to be defined alongside it.
+var termDefinitions = map[string]string{
+	"Hashtag": "as:Hashtag",
+}
+
+// propertyTermDefinitions are the context entries of the properties that are
+// in the ActivityStreams namespace but not in its JSON-LD context. Their values
+// are IRIs, so they are defined as such.
+var propertyTermDefinitions = map[string]map[string]string{
+	"alsoKnownAs":  {"@id": "as:alsoKnownAs", "@type": "@id"},
+	"featured":     {"@id": "toot:featured", "@type": "@id"},
+	"featuredTags": {"@id": "toot:featuredTags", "@type": "@id"},
+	"movedTo":      {"@id": "as:movedTo", "@type": "@id"},
+}
+
+// propertyTermAliases are the context entries of the properties that are in
+// the ActivityStreams namespace but not in its JSON-LD context, and whose
+// values are literals. Without "sensitive", peers processing the JSON-LD
+// would drop the content warning flag of a post.
+var propertyTermAliases = map[string]string{
+	"sensitive": "as:sensitive",
+}
+
+// termPrefixes are the context entries of the prefixes used by the
+// propertyTermDefinitions that the ActivityStreams context does not define.
+var termPrefixes = map[string]string{
+	"toot": "http://joinmastodon.org/ns#",
+}
+
+// findTerms adds the termDefinitions of the types and the
+// propertyTermDefinitions and propertyTermAliases of the properties used in
+// the serialized value to the terms.
+func findTerms(v interface{}, terms map[string]interface{}) {
+	switch r := v.(type) {
+	case map[string]interface{}:
+		for k, child := range r {
+			if k == "type" {
+				if t, ok := child.(string); ok && len(termDefinitions[t]) > 0 {
+					terms[t] = termDefinitions[t]
+				}
+			} else if d, ok := propertyTermDefinitions[k]; ok {
+				terms[k] = d
+				prefix := strings.SplitN(d["@id"], ":", 2)[0]
+				if ns, ok := termPrefixes[prefix]; ok {
+					terms[prefix] = ns
+				}
+			} else if alias, ok := propertyTermAliases[k]; ok {
+				terms[k] = alias
 			}
+			findTerms(child, terms)
 		}
-		if len(aliases) > 0 {
-			arr = append(arr, aliases)
+	case []interface{}:
+		for _, child := range r {
+			findTerms(child, terms)
 		}
-		contextValue = arr
 	}
-	// TODO: Update the context instead if it already exists
-	m[jsonLDContext] = contextValue
-	// TODO: Sort the context based on arbitrary order.
-	// Delete any existing `@context` in child maps.
-	var cleanFnRecur func(map[string]interface{})
-	cleanFnRecur = func(r map[string]interface{}) {
-		for _, v := range r {
-			if n, ok := v.(map[string]interface{}); ok {
-				delete(n, jsonLDContext)
-				cleanFnRecur(n)
+}
+
+// addTermDefinitions adds the terms found by findTerms to the context value.
+func addTermDefinitions(contextValue interface{}, terms map[string]interface{}) interface{} {
+	if len(terms) == 0 {
+		return contextValue
+	}
+	// Combines the aliases with the terms, as a single context entry.
+	withTerms := func(aliases map[string]string) map[string]interface{} {
+		for k, v := range aliases {
+			terms[k] = v
+		}
+		return terms
+	}
+	switch c := contextValue.(type) {
+	case map[string]string:
+		return withTerms(c)
+	case []interface{}:
+		for i, e := range c {
+			if aliases, ok := e.(map[string]string); ok {
+				c[i] = withTerms(aliases)
+				return c
+			}
+		}
+		return append(c, terms)
+	default:
+		return []interface{}{c, terms}
+	}
+}
+
+// ArrayProperties are the addressing and tag properties that some peers
+// expect to always be serialized as arrays.
+var ArrayProperties = []string{"to", "bto", "cc", "bcc", "audience", "tag"}
+
+// SerializeForcingArrays is like Serialize, but always serializes the named
+// properties as arrays, even when they have only one value. This also applies
+// to the properties of embedded values. ArrayProperties are a suitable set of
+// names for delivering to peers that expect arrays.
+//
+// Deserializing is unaffected, since a single value and an array with a single
+// element are equivalent in ActivityStreams and both are accepted.
+func SerializeForcingArrays(a vocab.Type, properties ...string) (m map[string]interface{}, e error) {
+	m, e = Serialize(a)
+	if e != nil {
+		return
+	}
+	force := make(map[string]bool, len(properties))
+	for _, p := range properties {
+		force[p] = true
+	}
+	var forceFnRecur func(interface{})
+	forceFnRecur = func(v interface{}) {
+		switch r := v.(type) {
+		case map[string]interface{}:
+			for k, child := range r {
+				if k == jsonLDContext {
+					continue
+				}
+				forceFnRecur(child)
+				if _, isArr := child.([]interface{}); force[k] && !isArr {
+					r[k] = []interface{}{child}
+				}
+			}
+		case []interface{}:
+			for _, child := range r {
+				forceFnRecur(child)
 			}
 		}
 	}
-	cleanFnRecur(m)
+	forceFnRecur(m)
 	return
 }
+
+// streamedProperty is a property whose values SerializeTo serializes and
+// writes one at a time.
+type streamedProperty interface {
+	Len() int
+	SerializeEach(fn func(interface{}) error) error
+}
+
+// SerializeTo is like Serialize, but writes the JSON encoding directly to the
+// Writer instead of returning a map to be marshalled.
+//
+// The values of properties with more than one value, such as the
+// 'orderedItems' of a large OrderedCollection, are serialized and written one
+// at a time, so the serialized value is never held all at once. They are
+// serialized twice: first to find the definitions needed in the '@context',
+// which is written before them. The output is byte-identical to calling
+// json.Marshal on the map returned by Serialize.
+func SerializeTo(w io.Writer, a vocab.Type) error {
+	streamed := make(map[string]streamedProperty)
+	var names []string
+	a.EachProperty(func(name string, p vocab.PropertyInterface) {
+		// A single value is serialized without an array, so it is not
+		// streamed.
+		if s, ok := p.(streamedProperty); ok && s.Len() != 1 {
+			streamed[name] = s
+			names = append(names, name)
+		}
+	})
+	m, err := a.SerializeWithout(names...)
+	if err != nil {
+		return err
+	}
+	terms := make(map[string]interface{})
+	findTerms(m, terms)
+	for _, name := range names {
+		err := streamed[name].SerializeEach(func(v interface{}) error {
+			findTerms(v, terms)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	m[jsonLDContext] = addTermDefinitions(contextValue(a.JSONLDContext()), terms)
+	cleanChildContexts(m)
+	keys := make([]string, 0, len(m)+len(names))
+	for _, name := range names {
+		// The "type" property replaces the type name, as in Serialize.
+		delete(m, name)
+		keys = append(keys, name)
+	}
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	bw := bufio.NewWriter(w)
+	if err := bw.WriteByte('{'); err != nil {
+		return err
+	}
+	for i, k := range keys {
+		if i > 0 {
+			if err := bw.WriteByte(','); err != nil {
+				return err
+			}
+		}
+		if err := marshalTo(bw, k); err != nil {
+			return err
+		}
+		if err := bw.WriteByte(':'); err != nil {
+			return err
+		}
+		if s, ok := streamed[k]; ok {
+			err = encodeEachTo(bw, s)
+		} else {
+			err = encodeTo(bw, m[k])
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if err := bw.WriteByte('}'); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// encodeEachTo writes the values of a property as a JSON array, serializing
+// and writing them one at a time.
+func encodeEachTo(w *bufio.Writer, s streamedProperty) error {
+	if err := w.WriteByte('['); err != nil {
+		return err
+	}
+	first := true
+	err := s.SerializeEach(func(v interface{}) error {
+		if !first {
+			if err := w.WriteByte(','); err != nil {
+				return err
+			}
+		}
+		first = false
+		return encodeTo(w, v)
+	})
+	if err != nil {
+		return err
+	}
+	return w.WriteByte(']')
+}
+
+// encodeTo writes the JSON encoding of a serialized value, matching the output
+// of json.Marshal.
+func encodeTo(w *bufio.Writer, v interface{}) error {
+	switch r := v.(type) {
+	case map[string]interface{}:
+		if r == nil {
+			_, err := w.WriteString("null")
+			return err
+		}
+		keys := make([]string, 0, len(r))
+		for k := range r {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		if err := w.WriteByte('{'); err != nil {
+			return err
+		}
+		for i, k := range keys {
+			if i > 0 {
+				if err := w.WriteByte(','); err != nil {
+					return err
+				}
+			}
+			if err := marshalTo(w, k); err != nil {
+				return err
+			}
+			if err := w.WriteByte(':'); err != nil {
+				return err
+			}
+			if err := encodeTo(w, r[k]); err != nil {
+				return err
+			}
+		}
+		return w.WriteByte('}')
+	case []interface{}:
+		if r == nil {
+			_, err := w.WriteString("null")
+			return err
+		}
+		if err := w.WriteByte('['); err != nil {
+			return err
+		}
+		for i, child := range r {
+			if i > 0 {
+				if err := w.WriteByte(','); err != nil {
+					return err
+				}
+			}
+			if err := encodeTo(w, child); err != nil {
+				return err
+			}
+		}
+		return w.WriteByte(']')
+	default:
+		return marshalTo(w, v)
+	}
+}
+
+// marshalTo writes the json.Marshal encoding of a value.
+func marshalTo(w *bufio.Writer, v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(b)
+	return err
+}
+
+// ValidateNoCycles walks the objects inlined within the value, returning an
+// error if an object is inlined within itself or if there are more than
+// maxNodes objects in total. A maxNodes of zero or less applies no bound.
+//
+// Deserialized payloads cannot contain true cycles, but a peer can inline an
+// object within itself by repeating its 'id', for example in its 'inReplyTo'
+// or 'object' property. Applications recursively following such a graph by
+// 'id' would never terminate. Calling this after ToType or a JSONResolver is a
+// safe gate before such recursion. Every property is examined, so objects
+// nested in any property, including unknown ones, are found.
+//
+// Links by IRI are not followed and are never considered cycles.
+func ValidateNoCycles(a vocab.Type, maxNodes int) error {
+	m, err := a.Serialize()
+	if err != nil {
+		return err
+	}
+	nodes := 0
+	var ancestors []string
+	var walkFnRecur func(interface{}) error
+	walkFnRecur = func(v interface{}) error {
+		switch r := v.(type) {
+		case map[string]interface{}:
+			nodes++
+			if maxNodes > 0 && nodes > maxNodes {
+				return fmt.Errorf("object graph has more than %d objects", maxNodes)
+			}
+			id := objectId(r)
+			if len(id) > 0 {
+				for _, ancestor := range ancestors {
+					if ancestor == id {
+						return fmt.Errorf("object graph has a cycle: %s is inlined within itself", id)
+					}
+				}
+				ancestors = append(ancestors, id)
+				defer func() { ancestors = ancestors[:len(ancestors)-1] }()
+			}
+			for k, child := range r {
+				if k == jsonLDContext {
+					continue
+				}
+				if err := walkFnRecur(child); err != nil {
+					return err
+				}
+			}
+		case []interface{}:
+			for _, child := range r {
+				if err := walkFnRecur(child); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	return walkFnRecur(m)
+}
+
+// objectId returns the 'id' of a serialized object, or an empty string if it
+// has none.
+func objectId(m map[string]interface{}) string {
+	if id, ok := m["id"].(string); ok {
+		return id
+	}
+	id, _ := m["@id"].(string)
+	return id
+}
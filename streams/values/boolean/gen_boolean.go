@@ -2,7 +2,10 @@
 
 package boolean
 
-import "fmt"
+import (
+	"encoding/json"
+	"fmt"
+)
 
 // SerializeBoolean converts a boolean value to an interface representation
 // suitable for marshalling into a text or binary format.
@@ -23,6 +26,15 @@ func DeserializeBoolean(this interface{}) (bool, error) {
 		} else {
 			return false, fmt.Errorf("%v cannot be interpreted as a bool float64 for xsd:boolean", this)
 		}
+	} else if n, ok := this.(json.Number); ok {
+		f, err := n.Float64()
+		if err == nil && f == 0 {
+			return false, nil
+		} else if err == nil && f == 1 {
+			return true, nil
+		} else {
+			return false, fmt.Errorf("%v cannot be interpreted as a bool number for xsd:boolean", this)
+		}
 	} else {
 		return false, fmt.Errorf("%v cannot be interpreted as a bool for xsd:boolean", this)
 	}
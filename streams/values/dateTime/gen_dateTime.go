@@ -10,7 +10,7 @@ import (
 // SerializeDateTime converts a dateTime value to an interface representation
 // suitable for marshalling into a text or binary format.
 func SerializeDateTime(this time.Time) (interface{}, error) {
-	return this.Format(time.RFC3339), nil
+	return this.Format(time.RFC3339Nano), nil
 }
 
 // DeserializeDateTime creates dateTime value from an interface representation
@@ -19,13 +19,20 @@ func DeserializeDateTime(this interface{}) (time.Time, error) {
 	var tmp time.Time
 	var err error
 	if s, ok := this.(string); ok {
-		tmp, err = time.Parse(time.RFC3339, s)
-		if err != nil {
-			tmp, err = time.Parse("2006-01-02T15:04Z07:00", s)
-			if err != nil {
-				err = fmt.Errorf("%v cannot be interpreted as xsd:datetime", this)
+		// Peers may separate the date and time with a space, omit the
+		// seconds, or omit the colon in the offset.
+		if len(s) > 10 && s[10] == ' ' {
+			s = s[:10] + "T" + s[11:]
+		}
+		for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04Z07:00", "2006-01-02T15:04:05.999999999Z0700", "2006-01-02T15:04Z0700"} {
+			tmp, err = time.Parse(layout, s)
+			if err == nil {
+				break
 			}
 		}
+		if err != nil {
+			err = fmt.Errorf("%q cannot be interpreted as an RFC3339 xsd:datetime", this)
+		}
 	} else {
 		err = fmt.Errorf("%v cannot be interpreted as a string for xsd:datetime", this)
 	}
@@ -2,7 +2,10 @@
 
 package float
 
-import "fmt"
+import (
+	"encoding/json"
+	"fmt"
+)
 
 // SerializeFloat converts a float value to an interface representation suitable
 // for marshalling into a text or binary format.
@@ -15,6 +18,8 @@ func SerializeFloat(this float64) (interface{}, error) {
 func DeserializeFloat(this interface{}) (float64, error) {
 	if f, ok := this.(float64); ok {
 		return f, nil
+	} else if n, ok := this.(json.Number); ok {
+		return n.Float64()
 	} else {
 		return 0, fmt.Errorf("%v cannot be interpreted as a float64 for xsd:float", this)
 	}
@@ -2,7 +2,11 @@
 
 package nonnegativeinteger
 
-import "fmt"
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+)
 
 // SerializeNonNegativeInteger converts a nonNegativeInteger value to an interface
 // representation suitable for marshalling into a text or binary format.
@@ -21,6 +25,21 @@ func DeserializeNonNegativeInteger(this interface{}) (int, error) {
 		} else {
 			return 0, fmt.Errorf("%v is a negative integer for xsd:nonNegativeInteger", this)
 		}
+	} else if i, ok := this.(json.Number); ok {
+		n, err := i.Int64()
+		if err != nil {
+			// Integral values may still be written in float or exponent form, such as 1.0 or 1e3.
+			f, ferr := i.Float64()
+			if ferr != nil || f != math.Trunc(f) || f >= math.MaxInt64 || f < math.MinInt64 {
+				return 0, fmt.Errorf("%v cannot be interpreted as an integer for xsd:nonNegativeInteger", this)
+			}
+			n = int64(f)
+		}
+		if n >= 0 {
+			return int(n), nil
+		} else {
+			return 0, fmt.Errorf("%v is a negative integer for xsd:nonNegativeInteger", this)
+		}
 	} else {
 		return 0, fmt.Errorf("%v cannot be interpreted as a float for xsd:nonNegativeInteger", this)
 	}
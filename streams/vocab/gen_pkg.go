@@ -4,6 +4,12 @@ package vocab
 
 // Type represents an ActivityStreams type.
 type Type interface {
+	// Clone returns a deep copy of this type. Modifying the copy does not
+	// modify this type.
+	Clone() Type
+	// EachProperty calls fn with the name and value of each property that is
+	// set, in a stable order. Unknown properties are not included.
+	EachProperty(fn func(name string, value PropertyInterface))
 	// GetJSONLDId returns the "id" property if it exists, and nil otherwise.
 	GetJSONLDId() JSONLDIdProperty
 	// GetTypeName returns the ActivityStreams type name.
@@ -16,8 +22,26 @@ type Type interface {
 	// Serialize converts this into an interface representation suitable for
 	// marshalling into a text or binary format.
 	Serialize() (map[string]interface{}, error)
+	// SerializeWithout is like Serialize, but leaves out the known properties
+	// with the names, as well as unknown properties with the same names,
+	// so that they can be serialized separately.
+	SerializeWithout(names ...string) (map[string]interface{}, error)
 	// SetJSONLDId sets the "id" property.
 	SetJSONLDId(JSONLDIdProperty)
 	// VocabularyURI returns the vocabulary's URI as a string.
 	VocabularyURI() string
 }
+
+// PropertyInterface represents any ActivityStreams property.
+type PropertyInterface interface {
+	// JSONLDContext returns the JSONLD URIs required in the context string
+	// for this property and the specific values that are set. The value
+	// in the map is the alias used to import the property's value or
+	// values.
+	JSONLDContext() map[string]string
+	// Name returns the name of this property.
+	Name() string
+	// Serialize converts this into an interface representation suitable for
+	// marshalling into a text or binary format.
+	Serialize() (interface{}, error)
+}
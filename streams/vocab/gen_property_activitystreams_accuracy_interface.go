@@ -19,6 +19,9 @@ type ActivityStreamsAccuracyProperty interface {
 	// Clear ensures no value of this property is set. Calling
 	// IsXMLSchemaFloat afterwards will return false.
 	Clear()
+	// Clone returns a deep copy of this property. Modifying the copy does not
+	// modify this property.
+	Clone() ActivityStreamsAccuracyProperty
 	// Get returns the value of this property. When IsXMLSchemaFloat returns
 	// false, Get will return any arbitrary value.
 	Get() float64
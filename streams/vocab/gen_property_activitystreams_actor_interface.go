@@ -83,6 +83,10 @@ type ActivityStreamsActorPropertyIterator interface {
 	// IsActivityStreamsGroup returns false, GetActivityStreamsGroup will
 	// return an arbitrary value.
 	GetActivityStreamsGroup() ActivityStreamsGroup
+	// GetActivityStreamsHashtag returns the value of this property. When
+	// IsActivityStreamsHashtag returns false, GetActivityStreamsHashtag
+	// will return an arbitrary value.
+	GetActivityStreamsHashtag() ActivityStreamsHashtag
 	// GetActivityStreamsIgnore returns the value of this property. When
 	// IsActivityStreamsIgnore returns false, GetActivityStreamsIgnore
 	// will return an arbitrary value.
@@ -251,6 +255,10 @@ type ActivityStreamsActorPropertyIterator interface {
 	// GetIRI returns the IRI of this property. When IsIRI returns false,
 	// GetIRI will return an arbitrary value.
 	GetIRI() *url.URL
+	// GetSchemaPropertyValue returns the value of this property. When
+	// IsSchemaPropertyValue returns false, GetSchemaPropertyValue will
+	// return an arbitrary value.
+	GetSchemaPropertyValue() SchemaPropertyValue
 	// GetTootEmoji returns the value of this property. When IsTootEmoji
 	// returns false, GetTootEmoji will return an arbitrary value.
 	GetTootEmoji() TootEmoji
@@ -344,6 +352,10 @@ type ActivityStreamsActorPropertyIterator interface {
 	// "Group". When true, use the GetActivityStreamsGroup and
 	// SetActivityStreamsGroup methods to access and set this property.
 	IsActivityStreamsGroup() bool
+	// IsActivityStreamsHashtag returns true if this property has a type of
+	// "Hashtag". When true, use the GetActivityStreamsHashtag and
+	// SetActivityStreamsHashtag methods to access and set this property.
+	IsActivityStreamsHashtag() bool
 	// IsActivityStreamsIgnore returns true if this property has a type of
 	// "Ignore". When true, use the GetActivityStreamsIgnore and
 	// SetActivityStreamsIgnore methods to access and set this property.
@@ -524,6 +536,10 @@ type ActivityStreamsActorPropertyIterator interface {
 	// IsIRI returns true if this property is an IRI. When true, use GetIRI
 	// and SetIRI to access and set this property
 	IsIRI() bool
+	// IsSchemaPropertyValue returns true if this property has a type of
+	// "PropertyValue". When true, use the GetSchemaPropertyValue and
+	// SetSchemaPropertyValue methods to access and set this property.
+	IsSchemaPropertyValue() bool
 	// IsTootEmoji returns true if this property has a type of "Emoji". When
 	// true, use the GetTootEmoji and SetTootEmoji methods to access and
 	// set this property.
@@ -610,6 +626,9 @@ type ActivityStreamsActorPropertyIterator interface {
 	// SetActivityStreamsGroup sets the value of this property. Calling
 	// IsActivityStreamsGroup afterwards returns true.
 	SetActivityStreamsGroup(v ActivityStreamsGroup)
+	// SetActivityStreamsHashtag sets the value of this property. Calling
+	// IsActivityStreamsHashtag afterwards returns true.
+	SetActivityStreamsHashtag(v ActivityStreamsHashtag)
 	// SetActivityStreamsIgnore sets the value of this property. Calling
 	// IsActivityStreamsIgnore afterwards returns true.
 	SetActivityStreamsIgnore(v ActivityStreamsIgnore)
@@ -738,6 +757,9 @@ type ActivityStreamsActorPropertyIterator interface {
 	// SetIRI sets the value of this property. Calling IsIRI afterwards
 	// returns true.
 	SetIRI(v *url.URL)
+	// SetSchemaPropertyValue sets the value of this property. Calling
+	// IsSchemaPropertyValue afterwards returns true.
+	SetSchemaPropertyValue(v SchemaPropertyValue)
 	// SetTootEmoji sets the value of this property. Calling IsTootEmoji
 	// afterwards returns true.
 	SetTootEmoji(v TootEmoji)
@@ -864,6 +886,10 @@ type ActivityStreamsActorProperty interface {
 	// of the property "actor". Invalidates iterators that are traversing
 	// using Prev.
 	AppendActivityStreamsGroup(v ActivityStreamsGroup)
+	// AppendActivityStreamsHashtag appends a Hashtag value to the back of a
+	// list of the property "actor". Invalidates iterators that are
+	// traversing using Prev.
+	AppendActivityStreamsHashtag(v ActivityStreamsHashtag)
 	// AppendActivityStreamsIgnore appends a Ignore value to the back of a
 	// list of the property "actor". Invalidates iterators that are
 	// traversing using Prev.
@@ -1031,6 +1057,10 @@ type ActivityStreamsActorProperty interface {
 	// AppendIRI appends an IRI value to the back of a list of the property
 	// "actor"
 	AppendIRI(v *url.URL)
+	// AppendSchemaPropertyValue appends a PropertyValue value to the back of
+	// a list of the property "actor". Invalidates iterators that are
+	// traversing using Prev.
+	AppendSchemaPropertyValue(v SchemaPropertyValue)
 	// AppendTootEmoji appends a Emoji value to the back of a list of the
 	// property "actor". Invalidates iterators that are traversing using
 	// Prev.
@@ -1051,6 +1081,9 @@ type ActivityStreamsActorProperty interface {
 	// iterator's Next method and this property's End method to iterate
 	// from front to back through all values.
 	Begin() ActivityStreamsActorPropertyIterator
+	// Clone returns a deep copy of this property and each of its values.
+	// Modifying the copy does not modify this property.
+	Clone() ActivityStreamsActorProperty
 	// Empty returns returns true if there are no elements.
 	Empty() bool
 	// End returns beyond-the-last iterator, which is nil. Can be used with
@@ -1134,6 +1167,10 @@ type ActivityStreamsActorProperty interface {
 	// for a property "actor". Existing elements at that index and higher
 	// are shifted back once. Invalidates all iterators.
 	InsertActivityStreamsGroup(idx int, v ActivityStreamsGroup)
+	// InsertActivityStreamsHashtag inserts a Hashtag value at the specified
+	// index for a property "actor". Existing elements at that index and
+	// higher are shifted back once. Invalidates all iterators.
+	InsertActivityStreamsHashtag(idx int, v ActivityStreamsHashtag)
 	// InsertActivityStreamsIgnore inserts a Ignore value at the specified
 	// index for a property "actor". Existing elements at that index and
 	// higher are shifted back once. Invalidates all iterators.
@@ -1307,6 +1344,10 @@ type ActivityStreamsActorProperty interface {
 	// "actor". Existing elements at that index and higher are shifted
 	// back once. Invalidates all iterators.
 	InsertIRI(idx int, v *url.URL)
+	// InsertSchemaPropertyValue inserts a PropertyValue value at the
+	// specified index for a property "actor". Existing elements at that
+	// index and higher are shifted back once. Invalidates all iterators.
+	InsertSchemaPropertyValue(idx int, v SchemaPropertyValue)
 	// InsertTootEmoji inserts a Emoji value at the specified index for a
 	// property "actor". Existing elements at that index and higher are
 	// shifted back once. Invalidates all iterators.
@@ -1399,6 +1440,9 @@ type ActivityStreamsActorProperty interface {
 	// PrependActivityStreamsGroup prepends a Group value to the front of a
 	// list of the property "actor". Invalidates all iterators.
 	PrependActivityStreamsGroup(v ActivityStreamsGroup)
+	// PrependActivityStreamsHashtag prepends a Hashtag value to the front of
+	// a list of the property "actor". Invalidates all iterators.
+	PrependActivityStreamsHashtag(v ActivityStreamsHashtag)
 	// PrependActivityStreamsIgnore prepends a Ignore value to the front of a
 	// list of the property "actor". Invalidates all iterators.
 	PrependActivityStreamsIgnore(v ActivityStreamsIgnore)
@@ -1531,6 +1575,9 @@ type ActivityStreamsActorProperty interface {
 	// PrependIRI prepends an IRI value to the front of a list of the property
 	// "actor".
 	PrependIRI(v *url.URL)
+	// PrependSchemaPropertyValue prepends a PropertyValue value to the front
+	// of a list of the property "actor". Invalidates all iterators.
+	PrependSchemaPropertyValue(v SchemaPropertyValue)
 	// PrependTootEmoji prepends a Emoji value to the front of a list of the
 	// property "actor". Invalidates all iterators.
 	PrependTootEmoji(v TootEmoji)
@@ -1551,6 +1598,11 @@ type ActivityStreamsActorProperty interface {
 	// instead of individual properties. It is exposed for alternatives to
 	// go-fed implementations to use.
 	Serialize() (interface{}, error)
+	// SerializeEach serializes each value of this property in order, calling
+	// fn with each, and stops at the first error. Unlike Serialize, it
+	// never holds the serialized values all at once, so large properties
+	// can be encoded as a stream.
+	SerializeEach(fn func(interface{}) error) error
 	// SetActivityStreamsAccept sets a Accept value to be at the specified
 	// index for the property "actor". Panics if the index is out of
 	// bounds. Invalidates all iterators.
@@ -1627,6 +1679,10 @@ type ActivityStreamsActorProperty interface {
 	// for the property "actor". Panics if the index is out of bounds.
 	// Invalidates all iterators.
 	SetActivityStreamsGroup(idx int, v ActivityStreamsGroup)
+	// SetActivityStreamsHashtag sets a Hashtag value to be at the specified
+	// index for the property "actor". Panics if the index is out of
+	// bounds. Invalidates all iterators.
+	SetActivityStreamsHashtag(idx int, v ActivityStreamsHashtag)
 	// SetActivityStreamsIgnore sets a Ignore value to be at the specified
 	// index for the property "actor". Panics if the index is out of
 	// bounds. Invalidates all iterators.
@@ -1794,6 +1850,10 @@ type ActivityStreamsActorProperty interface {
 	// SetIRI sets an IRI value to be at the specified index for the property
 	// "actor". Panics if the index is out of bounds.
 	SetIRI(idx int, v *url.URL)
+	// SetSchemaPropertyValue sets a PropertyValue value to be at the
+	// specified index for the property "actor". Panics if the index is
+	// out of bounds. Invalidates all iterators.
+	SetSchemaPropertyValue(idx int, v SchemaPropertyValue)
 	// SetTootEmoji sets a Emoji value to be at the specified index for the
 	// property "actor". Panics if the index is out of bounds. Invalidates
 	// all iterators.
@@ -20,6 +20,9 @@ type ActivityStreamsDeletedProperty interface {
 	// Clear ensures no value of this property is set. Calling
 	// IsXMLSchemaDateTime afterwards will return false.
 	Clear()
+	// Clone returns a deep copy of this property. Modifying the copy does not
+	// modify this property.
+	Clone() ActivityStreamsDeletedProperty
 	// Get returns the value of this property. When IsXMLSchemaDateTime
 	// returns false, Get will return any arbitrary value.
 	Get() time.Time
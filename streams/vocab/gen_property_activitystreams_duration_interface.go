@@ -24,6 +24,9 @@ type ActivityStreamsDurationProperty interface {
 	// Clear ensures no value of this property is set. Calling
 	// IsXMLSchemaDuration afterwards will return false.
 	Clear()
+	// Clone returns a deep copy of this property. Modifying the copy does not
+	// modify this property.
+	Clone() ActivityStreamsDurationProperty
 	// Get returns the value of this property. When IsXMLSchemaDuration
 	// returns false, Get will return any arbitrary value.
 	Get() time.Duration
@@ -19,6 +19,9 @@ type ActivityStreamsHreflangProperty interface {
 	// Clear ensures no value of this property is set. Calling IsRFCBcp47
 	// afterwards will return false.
 	Clear()
+	// Clone returns a deep copy of this property. Modifying the copy does not
+	// modify this property.
+	Clone() ActivityStreamsHreflangProperty
 	// Get returns the value of this property. When IsRFCBcp47 returns false,
 	// Get will return any arbitrary value.
 	Get() string
@@ -33,6 +33,9 @@ type ActivityStreamsInboxProperty interface {
 	// Clear ensures no value of this property is set. Calling HasAny or any
 	// of the 'Is' methods afterwards will return false.
 	Clear()
+	// Clone returns a deep copy of this property. Modifying the copy does not
+	// modify this property.
+	Clone() ActivityStreamsInboxProperty
 	// GetActivityStreamsOrderedCollection returns the value of this property.
 	// When IsActivityStreamsOrderedCollection returns false,
 	// GetActivityStreamsOrderedCollection will return an arbitrary value.
@@ -21,6 +21,9 @@ type ActivityStreamsMediaTypeProperty interface {
 	// Clear ensures no value of this property is set. Calling IsRFCRfc2045
 	// afterwards will return false.
 	Clear()
+	// Clone returns a deep copy of this property. Modifying the copy does not
+	// modify this property.
+	Clone() ActivityStreamsMediaTypeProperty
 	// Get returns the value of this property. When IsRFCRfc2045 returns
 	// false, Get will return any arbitrary value.
 	Get() string
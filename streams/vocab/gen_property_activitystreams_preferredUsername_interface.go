@@ -11,6 +11,9 @@ type ActivityStreamsPreferredUsernameProperty interface {
 	// Calling HasAny or any of the 'Is' methods afterwards will return
 	// false.
 	Clear()
+	// Clone returns a deep copy of this property. Modifying the copy does not
+	// modify this property.
+	Clone() ActivityStreamsPreferredUsernameProperty
 	// GetIRI returns the IRI of this property. When IsIRI returns false,
 	// GetIRI will return an arbitrary value.
 	GetIRI() *url.URL
@@ -9,6 +9,9 @@ type ForgeFedAssignedToProperty interface {
 	// Clear ensures no value of this property is set. Calling
 	// IsActivityStreamsPerson afterwards will return false.
 	Clear()
+	// Clone returns a deep copy of this property. Modifying the copy does not
+	// modify this property.
+	Clone() ForgeFedAssignedToProperty
 	// Get returns the value of this property. When IsActivityStreamsPerson
 	// returns false, Get will return any arbitrary value.
 	Get() ActivityStreamsPerson
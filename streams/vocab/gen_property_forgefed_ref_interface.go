@@ -23,6 +23,9 @@ type ForgeFedRefProperty interface {
 	// Clear ensures no value of this property is set. Calling
 	// IsXMLSchemaString afterwards will return false.
 	Clear()
+	// Clone returns a deep copy of this property. Modifying the copy does not
+	// modify this property.
+	Clone() ForgeFedRefProperty
 	// Get returns the value of this property. When IsXMLSchemaString returns
 	// false, Get will return any arbitrary value.
 	Get() string
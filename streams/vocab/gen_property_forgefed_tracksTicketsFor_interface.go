@@ -243,6 +243,10 @@ type ForgeFedTracksTicketsForPropertyIterator interface {
 	// GetIRI returns the IRI of this property. When IsIRI returns false,
 	// GetIRI will return an arbitrary value.
 	GetIRI() *url.URL
+	// GetSchemaPropertyValue returns the value of this property. When
+	// IsSchemaPropertyValue returns false, GetSchemaPropertyValue will
+	// return an arbitrary value.
+	GetSchemaPropertyValue() SchemaPropertyValue
 	// GetTootEmoji returns the value of this property. When IsTootEmoji
 	// returns false, GetTootEmoji will return an arbitrary value.
 	GetTootEmoji() TootEmoji
@@ -508,6 +512,10 @@ type ForgeFedTracksTicketsForPropertyIterator interface {
 	// IsIRI returns true if this property is an IRI. When true, use GetIRI
 	// and SetIRI to access and set this property
 	IsIRI() bool
+	// IsSchemaPropertyValue returns true if this property has a type of
+	// "PropertyValue". When true, use the GetSchemaPropertyValue and
+	// SetSchemaPropertyValue methods to access and set this property.
+	IsSchemaPropertyValue() bool
 	// IsTootEmoji returns true if this property has a type of "Emoji". When
 	// true, use the GetTootEmoji and SetTootEmoji methods to access and
 	// set this property.
@@ -716,6 +724,9 @@ type ForgeFedTracksTicketsForPropertyIterator interface {
 	// SetIRI sets the value of this property. Calling IsIRI afterwards
 	// returns true.
 	SetIRI(v *url.URL)
+	// SetSchemaPropertyValue sets the value of this property. Calling
+	// IsSchemaPropertyValue afterwards returns true.
+	SetSchemaPropertyValue(v SchemaPropertyValue)
 	// SetTootEmoji sets the value of this property. Calling IsTootEmoji
 	// afterwards returns true.
 	SetTootEmoji(v TootEmoji)
@@ -982,6 +993,10 @@ type ForgeFedTracksTicketsForProperty interface {
 	// AppendIRI appends an IRI value to the back of a list of the property
 	// "tracksTicketsFor"
 	AppendIRI(v *url.URL)
+	// AppendSchemaPropertyValue appends a PropertyValue value to the back of
+	// a list of the property "tracksTicketsFor". Invalidates iterators
+	// that are traversing using Prev.
+	AppendSchemaPropertyValue(v SchemaPropertyValue)
 	// AppendTootEmoji appends a Emoji value to the back of a list of the
 	// property "tracksTicketsFor". Invalidates iterators that are
 	// traversing using Prev.
@@ -1002,6 +1017,9 @@ type ForgeFedTracksTicketsForProperty interface {
 	// iterator's Next method and this property's End method to iterate
 	// from front to back through all values.
 	Begin() ForgeFedTracksTicketsForPropertyIterator
+	// Clone returns a deep copy of this property and each of its values.
+	// Modifying the copy does not modify this property.
+	Clone() ForgeFedTracksTicketsForProperty
 	// Empty returns returns true if there are no elements.
 	Empty() bool
 	// End returns beyond-the-last iterator, which is nil. Can be used with
@@ -1256,6 +1274,11 @@ type ForgeFedTracksTicketsForProperty interface {
 	// "tracksTicketsFor". Existing elements at that index and higher are
 	// shifted back once. Invalidates all iterators.
 	InsertIRI(idx int, v *url.URL)
+	// InsertSchemaPropertyValue inserts a PropertyValue value at the
+	// specified index for a property "tracksTicketsFor". Existing
+	// elements at that index and higher are shifted back once.
+	// Invalidates all iterators.
+	InsertSchemaPropertyValue(idx int, v SchemaPropertyValue)
 	// InsertTootEmoji inserts a Emoji value at the specified index for a
 	// property "tracksTicketsFor". Existing elements at that index and
 	// higher are shifted back once. Invalidates all iterators.
@@ -1489,6 +1512,10 @@ type ForgeFedTracksTicketsForProperty interface {
 	// PrependIRI prepends an IRI value to the front of a list of the property
 	// "tracksTicketsFor".
 	PrependIRI(v *url.URL)
+	// PrependSchemaPropertyValue prepends a PropertyValue value to the front
+	// of a list of the property "tracksTicketsFor". Invalidates all
+	// iterators.
+	PrependSchemaPropertyValue(v SchemaPropertyValue)
 	// PrependTootEmoji prepends a Emoji value to the front of a list of the
 	// property "tracksTicketsFor". Invalidates all iterators.
 	PrependTootEmoji(v TootEmoji)
@@ -1510,6 +1537,11 @@ type ForgeFedTracksTicketsForProperty interface {
 	// instead of individual properties. It is exposed for alternatives to
 	// go-fed implementations to use.
 	Serialize() (interface{}, error)
+	// SerializeEach serializes each value of this property in order, calling
+	// fn with each, and stops at the first error. Unlike Serialize, it
+	// never holds the serialized values all at once, so large properties
+	// can be encoded as a stream.
+	SerializeEach(fn func(interface{}) error) error
 	// SetActivityStreamsAccept sets a Accept value to be at the specified
 	// index for the property "tracksTicketsFor". Panics if the index is
 	// out of bounds. Invalidates all iterators.
@@ -1747,6 +1779,10 @@ type ForgeFedTracksTicketsForProperty interface {
 	// SetIRI sets an IRI value to be at the specified index for the property
 	// "tracksTicketsFor". Panics if the index is out of bounds.
 	SetIRI(idx int, v *url.URL)
+	// SetSchemaPropertyValue sets a PropertyValue value to be at the
+	// specified index for the property "tracksTicketsFor". Panics if the
+	// index is out of bounds. Invalidates all iterators.
+	SetSchemaPropertyValue(idx int, v SchemaPropertyValue)
 	// SetTootEmoji sets a Emoji value to be at the specified index for the
 	// property "tracksTicketsFor". Panics if the index is out of bounds.
 	// Invalidates all iterators.
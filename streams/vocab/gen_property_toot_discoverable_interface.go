@@ -11,6 +11,9 @@ type TootDiscoverableProperty interface {
 	// Clear ensures no value of this property is set. Calling
 	// IsXMLSchemaBoolean afterwards will return false.
 	Clear()
+	// Clone returns a deep copy of this property. Modifying the copy does not
+	// modify this property.
+	Clone() TootDiscoverableProperty
 	// Get returns the value of this property. When IsXMLSchemaBoolean returns
 	// false, Get will return any arbitrary value.
 	Get() bool
@@ -79,6 +79,9 @@ type W3IDSecurityV1PublicKeyProperty interface {
 	// iterator's Next method and this property's End method to iterate
 	// from front to back through all values.
 	Begin() W3IDSecurityV1PublicKeyPropertyIterator
+	// Clone returns a deep copy of this property and each of its values.
+	// Modifying the copy does not modify this property.
+	Clone() W3IDSecurityV1PublicKeyProperty
 	// Empty returns returns true if there are no elements.
 	Empty() bool
 	// End returns beyond-the-last iterator, which is nil. Can be used with
@@ -141,6 +144,11 @@ type W3IDSecurityV1PublicKeyProperty interface {
 	// instead of individual properties. It is exposed for alternatives to
 	// go-fed implementations to use.
 	Serialize() (interface{}, error)
+	// SerializeEach serializes each value of this property in order, calling
+	// fn with each, and stops at the first error. Unlike Serialize, it
+	// never holds the serialized values all at once, so large properties
+	// can be encoded as a stream.
+	SerializeEach(fn func(interface{}) error) error
 	// Set sets a PublicKey value to be at the specified index for the
 	// property "publicKey". Panics if the index is out of bounds.
 	// Invalidates all iterators.
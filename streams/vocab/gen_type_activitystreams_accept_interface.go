@@ -42,6 +42,12 @@ package vocab
 //     "type": "Accept"
 //   }
 type ActivityStreamsAccept interface {
+	// Clone returns a deep copy of this type. Modifying the copy does not
+	// modify this type.
+	Clone() Type
+	// EachProperty calls fn with the name and value of each property that is
+	// set, in a stable order. Unknown properties are not included.
+	EachProperty(fn func(name string, value PropertyInterface))
 	// GetActivityStreamsActor returns the "actor" property if it exists, and
 	// nil otherwise.
 	GetActivityStreamsActor() ActivityStreamsActorProperty
@@ -189,6 +195,10 @@ type ActivityStreamsAccept interface {
 	// Serialize converts this into an interface representation suitable for
 	// marshalling into a text or binary format.
 	Serialize() (map[string]interface{}, error)
+	// SerializeWithout is like Serialize, but leaves out the known properties
+	// with the names, as well as unknown properties with the same names,
+	// so that they can be serialized separately.
+	SerializeWithout(names ...string) (map[string]interface{}, error)
 	// SetActivityStreamsActor sets the "actor" property.
 	SetActivityStreamsActor(i ActivityStreamsActorProperty)
 	// SetActivityStreamsAltitude sets the "altitude" property.
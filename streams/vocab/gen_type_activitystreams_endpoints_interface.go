@@ -0,0 +1,80 @@
+// Code generated by astool. DO NOT EDIT.
+
+package vocab
+
+// The endpoints of an actor, which are useful for it or for others to interact
+// with it, such as the shared inbox of its server.
+type ActivityStreamsEndpoints interface {
+	// Clone returns a deep copy of this type. Modifying the copy does not
+	// modify this type.
+	Clone() Type
+	// EachProperty calls fn with the name and value of each property that is
+	// set, in a stable order. Unknown properties are not included.
+	EachProperty(fn func(name string, value PropertyInterface))
+	// GetActivityStreamsOauthAuthorizationEndpoint returns the
+	// "oauthAuthorizationEndpoint" property if it exists, and nil
+	// otherwise.
+	GetActivityStreamsOauthAuthorizationEndpoint() ActivityStreamsOauthAuthorizationEndpointProperty
+	// GetActivityStreamsOauthTokenEndpoint returns the "oauthTokenEndpoint"
+	// property if it exists, and nil otherwise.
+	GetActivityStreamsOauthTokenEndpoint() ActivityStreamsOauthTokenEndpointProperty
+	// GetActivityStreamsProvideClientKey returns the "provideClientKey"
+	// property if it exists, and nil otherwise.
+	GetActivityStreamsProvideClientKey() ActivityStreamsProvideClientKeyProperty
+	// GetActivityStreamsSharedInbox returns the "sharedInbox" property if it
+	// exists, and nil otherwise.
+	GetActivityStreamsSharedInbox() ActivityStreamsSharedInboxProperty
+	// GetActivityStreamsSignClientKey returns the "signClientKey" property if
+	// it exists, and nil otherwise.
+	GetActivityStreamsSignClientKey() ActivityStreamsSignClientKeyProperty
+	// GetActivityStreamsUploadMedia returns the "uploadMedia" property if it
+	// exists, and nil otherwise.
+	GetActivityStreamsUploadMedia() ActivityStreamsUploadMediaProperty
+	// GetJSONLDId returns the "id" property if it exists, and nil otherwise.
+	GetJSONLDId() JSONLDIdProperty
+	// GetTypeName returns the name of this type.
+	GetTypeName() string
+	// GetUnknownProperties returns the unknown properties for the Endpoints
+	// type. Note that this should not be used by app developers. It is
+	// only used to help determine which implementation is LessThan the
+	// other. Developers who are creating a different implementation of
+	// this type's interface can use this method in their LessThan
+	// implementation, but routine ActivityPub applications should not use
+	// this to bypass the code generation tool.
+	GetUnknownProperties() map[string]interface{}
+	// IsExtending returns true if the Endpoints type extends from the other
+	// type.
+	IsExtending(other Type) bool
+	// JSONLDContext returns the JSONLD URIs required in the context string
+	// for this type and the specific properties that are set. The value
+	// in the map is the alias used to import the type and its properties.
+	JSONLDContext() map[string]string
+	// LessThan computes if this Endpoints is lesser, with an arbitrary but
+	// stable determination.
+	LessThan(o ActivityStreamsEndpoints) bool
+	// Serialize converts this into an interface representation suitable for
+	// marshalling into a text or binary format.
+	Serialize() (map[string]interface{}, error)
+	// SerializeWithout is like Serialize, but leaves out the known properties
+	// with the names, as well as unknown properties with the same names,
+	// so that they can be serialized separately.
+	SerializeWithout(names ...string) (map[string]interface{}, error)
+	// SetActivityStreamsOauthAuthorizationEndpoint sets the
+	// "oauthAuthorizationEndpoint" property.
+	SetActivityStreamsOauthAuthorizationEndpoint(i ActivityStreamsOauthAuthorizationEndpointProperty)
+	// SetActivityStreamsOauthTokenEndpoint sets the "oauthTokenEndpoint"
+	// property.
+	SetActivityStreamsOauthTokenEndpoint(i ActivityStreamsOauthTokenEndpointProperty)
+	// SetActivityStreamsProvideClientKey sets the "provideClientKey" property.
+	SetActivityStreamsProvideClientKey(i ActivityStreamsProvideClientKeyProperty)
+	// SetActivityStreamsSharedInbox sets the "sharedInbox" property.
+	SetActivityStreamsSharedInbox(i ActivityStreamsSharedInboxProperty)
+	// SetActivityStreamsSignClientKey sets the "signClientKey" property.
+	SetActivityStreamsSignClientKey(i ActivityStreamsSignClientKeyProperty)
+	// SetActivityStreamsUploadMedia sets the "uploadMedia" property.
+	SetActivityStreamsUploadMedia(i ActivityStreamsUploadMediaProperty)
+	// SetJSONLDId sets the "id" property.
+	SetJSONLDId(i JSONLDIdProperty)
+	// VocabularyURI returns the vocabulary's URI as a string.
+	VocabularyURI() string
+}
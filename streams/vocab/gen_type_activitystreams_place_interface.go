@@ -21,6 +21,12 @@ package vocab
 //     "units": "miles"
 //   }
 type ActivityStreamsPlace interface {
+	// Clone returns a deep copy of this type. Modifying the copy does not
+	// modify this type.
+	Clone() Type
+	// EachProperty calls fn with the name and value of each property that is
+	// set, in a stable order. Unknown properties are not included.
+	EachProperty(fn func(name string, value PropertyInterface))
 	// GetActivityStreamsAccuracy returns the "accuracy" property if it
 	// exists, and nil otherwise.
 	GetActivityStreamsAccuracy() ActivityStreamsAccuracyProperty
@@ -168,6 +174,10 @@ type ActivityStreamsPlace interface {
 	// Serialize converts this into an interface representation suitable for
 	// marshalling into a text or binary format.
 	Serialize() (map[string]interface{}, error)
+	// SerializeWithout is like Serialize, but leaves out the known properties
+	// with the names, as well as unknown properties with the same names,
+	// so that they can be serialized separately.
+	SerializeWithout(names ...string) (map[string]interface{}, error)
 	// SetActivityStreamsAccuracy sets the "accuracy" property.
 	SetActivityStreamsAccuracy(i ActivityStreamsAccuracyProperty)
 	// SetActivityStreamsAltitude sets the "altitude" property.
@@ -1315,6 +1315,14 @@ func GetTestTable() []TestTable {
 			skipDeserializationTest:       true,
 			skipDeserializationTestReason: "If go-fed gets the JSON, it won't match the form of the constructed type.",
 		},
+		{
+			name:           "Person w/ schema:PropertyValue Attachments",
+			expectedJSON:   personHasPropertyValueAttachments,
+			expectedStruct: personHasPropertyValueAttachmentsType(),
+			deserializer: func(m map[string]interface{}) (vocab.Type, error) {
+				return mgr.DeserializePersonActivityStreams()(m, map[string]string{})
+			},
+		},
 	}
 }
 